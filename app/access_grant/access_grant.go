@@ -0,0 +1,65 @@
+package access_grant
+
+import (
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/account"
+	"github.com/google/uuid"
+)
+
+// AccessGrant gives a scoped admin account access to a single resource. A scoped
+// account only sees and modifies the resources granted to it, and nothing of a
+// type it holds no grant for; an account that is not scoped keeps its role-wide
+// access and its grants have no effect.
+type AccessGrant struct {
+	ID           uuid.UUID    `json:"id" gorm:"primaryKey"`
+	AccountID    uuid.UUID    `json:"accountId" gorm:"not null;uniqueIndex:idx_access_grant_resource"`
+	ResourceType ResourceType `json:"resourceType" gorm:"type:varchar(30);not null;uniqueIndex:idx_access_grant_resource"`
+	ResourceID   uuid.UUID    `json:"resourceId" gorm:"not null;uniqueIndex:idx_access_grant_resource;index"`
+	GrantedBy    uuid.UUID    `json:"grantedBy" gorm:"not null"`
+	CreatedAt    time.Time    `json:"createdAt"`
+
+	Account account.Account `json:"account" gorm:"foreignKey:AccountID"`
+}
+
+// AccessScope marks an account as scoped. Scoping is explicit rather than inferred
+// from the grants, so revoking an account's last grant leaves it with no access
+// instead of restoring its role-wide access.
+type AccessScope struct {
+	AccountID uuid.UUID `json:"accountId" gorm:"primaryKey"`
+	ScopedBy  uuid.UUID `json:"scopedBy" gorm:"not null"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ResourceType string
+
+const (
+	ResourceSocialProgram   ResourceType = "social_program"
+	ResourceDonationProgram ResourceType = "donation_program"
+	ResourceFosterChildren  ResourceType = "foster_children"
+
+	ResourceFosterChildrenCandidate ResourceType = "foster_children_candidate"
+)
+
+func (t ResourceType) IsValid() bool {
+	switch t {
+	case ResourceSocialProgram, ResourceDonationProgram, ResourceFosterChildren, ResourceFosterChildrenCandidate:
+		return true
+	}
+	return false
+}
+
+// resourceTables maps a resource type to the table holding its rows.
+var resourceTables = map[ResourceType]string{
+	ResourceSocialProgram:           "social_programs",
+	ResourceDonationProgram:         "donation_programs",
+	ResourceFosterChildren:          "foster_childrens",
+	ResourceFosterChildrenCandidate: "foster_children_candidates",
+}
+
+// softDeletedResources are the resource types whose tables have a deleted_at column.
+var softDeletedResources = map[ResourceType]bool{
+	ResourceSocialProgram:   true,
+	ResourceDonationProgram: true,
+	ResourceFosterChildren:  true,
+}
@@ -0,0 +1,150 @@
+package access_grant
+
+import (
+	"net/http"
+
+	"github.com/Vilamuzz/yota-backend/app/middleware"
+	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/Vilamuzz/yota-backend/pkg/enum"
+	jwt_pkg "github.com/Vilamuzz/yota-backend/pkg/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	service    Service
+	middleware middleware.AppMiddleware
+}
+
+func NewHandler(r *gin.RouterGroup, s Service, m middleware.AppMiddleware) {
+	h := &handler{
+		service:    s,
+		middleware: m,
+	}
+	h.RegisterRoutes(r)
+}
+
+func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
+	admin := r.Group("/admin/access-grants")
+	admin.Use(h.middleware.RequireRoles(enum.RoleChairman, enum.RoleSuperadmin))
+	{
+		admin.GET("", h.GetAccessGrantList)
+		admin.POST("", h.CreateAccessGrants)
+		admin.DELETE("/:id", h.DeleteAccessGrant)
+	}
+
+	scopes := r.Group("/admin/access-scopes")
+	scopes.Use(h.middleware.RequireRoles(enum.RoleChairman, enum.RoleSuperadmin))
+	{
+		scopes.PUT("/:accountId", h.ScopeAccount)
+		scopes.DELETE("/:accountId", h.UnscopeAccount)
+	}
+}
+
+// GetAccessGrantList
+//
+// @Summary Get Access Grant List
+// @Description Get resource-scoped access grants, filterable by account and resource
+// @Tags Access Grant
+// @Security BearerAuth
+// @Produce json
+// @Param accountId query string false "Account ID"
+// @Param resourceType query string false "Resource type (social_program, donation_program, foster_children, foster_children_candidate)"
+// @Param resourceId query string false "Resource ID"
+// @Param page query int false "Page"
+// @Param limit query int false "Limit"
+// @Success 200 {object} pkg.Response{data=AccessGrantListResponse}
+// @Router /api/admin/access-grants [get]
+func (h *handler) GetAccessGrantList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var queryParams AccessGrantQueryParams
+	if err := c.ShouldBindQuery(&queryParams); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.GetAccessGrantList(ctx, queryParams)
+	c.JSON(res.Status, res)
+}
+
+// CreateAccessGrants
+//
+// @Summary Create Access Grants
+// @Description Assign one or more resources of a single type to an account
+// @Tags Access Grant
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body CreateAccessGrantRequest true "Access grant payload"
+// @Success 201 {object} pkg.Response{data=[]AccessGrantResponse}
+// @Router /api/admin/access-grants [post]
+func (h *handler) CreateAccessGrants(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req CreateAccessGrantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.CreateAccessGrants(ctx, claims.AccountID, req)
+	c.JSON(res.Status, res)
+}
+
+// DeleteAccessGrant
+//
+// @Summary Delete Access Grant
+// @Description Revoke a resource-scoped access grant
+// @Tags Access Grant
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Access grant ID"
+// @Success 200 {object} pkg.Response
+// @Router /api/admin/access-grants/{id} [delete]
+func (h *handler) DeleteAccessGrant(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	id := c.Param("id")
+
+	res := h.service.DeleteAccessGrant(ctx, claims.AccountID, id)
+	c.JSON(res.Status, res)
+}
+
+// ScopeAccount
+//
+// @Summary Scope Account
+// @Description Restrict an account to the resources granted to it; without grants it reaches none
+// @Tags Access Grant
+// @Security BearerAuth
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Success 200 {object} pkg.Response{data=AccessScopeResponse}
+// @Router /api/admin/access-scopes/{accountId} [put]
+func (h *handler) ScopeAccount(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	accountID := c.Param("accountId")
+
+	res := h.service.ScopeAccount(ctx, claims.AccountID, accountID)
+	c.JSON(res.Status, res)
+}
+
+// UnscopeAccount
+//
+// @Summary Unscope Account
+// @Description Give a scoped account its role-wide access back; its grants are kept
+// @Tags Access Grant
+// @Security BearerAuth
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Success 200 {object} pkg.Response
+// @Router /api/admin/access-scopes/{accountId} [delete]
+func (h *handler) UnscopeAccount(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	accountID := c.Param("accountId")
+
+	res := h.service.UnscopeAccount(ctx, claims.AccountID, accountID)
+	c.JSON(res.Status, res)
+}
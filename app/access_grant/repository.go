@@ -0,0 +1,193 @@
+package access_grant
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	FindAllAccessGrants(ctx context.Context, options map[string]interface{}) ([]AccessGrant, error)
+	CountAccessGrants(ctx context.Context, options map[string]interface{}) (int64, error)
+	FindOneAccessGrant(ctx context.Context, options map[string]interface{}) (*AccessGrant, error)
+	CreateAccessGrants(ctx context.Context, grants []AccessGrant) error
+	DeleteAccessGrant(ctx context.Context, id string) error
+	// FindGrantedResourceIDs returns the resource IDs of the given type granted to the account.
+	FindGrantedResourceIDs(ctx context.Context, accountID string, resourceType ResourceType) ([]string, error)
+	CountExistingResources(ctx context.Context, resourceType ResourceType, resourceIDs []string) (int64, error)
+	// FindAccessScope reports whether the account is scoped and, if it is, the resource IDs of
+	// the given type granted to it. A scoped account without grants gets an empty, non-nil slice.
+	FindAccessScope(ctx context.Context, accountID string, resourceType ResourceType) ([]string, bool, error)
+	// HasAccess reports whether the account may reach the resource: accounts that are not
+	// scoped reach every resource, scoped ones only those granted to them.
+	HasAccess(ctx context.Context, accountID string, resourceType ResourceType, resourceID string) (bool, error)
+	FindOneAccessScope(ctx context.Context, accountID string) (*AccessScope, error)
+	CreateAccessScope(ctx context.Context, scope *AccessScope) error
+	DeleteAccessScope(ctx context.Context, accountID string) error
+}
+
+type repository struct {
+	Conn *gorm.DB
+}
+
+func NewRepository(conn *gorm.DB) Repository {
+	return &repository{Conn: conn}
+}
+
+func applyAccessGrantFilters(query *gorm.DB, options map[string]interface{}) *gorm.DB {
+	if accountID, ok := options["account_id"]; ok && accountID != "" {
+		query = query.Where("access_grants.account_id = ?", accountID)
+	}
+	if resourceType, ok := options["resource_type"]; ok && resourceType != "" {
+		query = query.Where("access_grants.resource_type = ?", resourceType)
+	}
+	if resourceID, ok := options["resource_id"]; ok && resourceID != "" {
+		query = query.Where("access_grants.resource_id = ?", resourceID)
+	}
+	return query
+}
+
+func (r *repository) FindAllAccessGrants(ctx context.Context, options map[string]interface{}) ([]AccessGrant, error) {
+	var grants []AccessGrant
+	query := applyAccessGrantFilters(r.Conn.WithContext(ctx).Preload("Account.UserProfile"), options).
+		Order("access_grants.created_at DESC")
+
+	limit := 10
+	if l, ok := options["limit"]; ok && l.(int) > 0 {
+		limit = l.(int)
+	}
+	offset := 0
+	if page, ok := options["page"]; ok && page.(int) > 1 {
+		offset = (page.(int) - 1) * limit
+	}
+
+	if err := query.Limit(limit).Offset(offset).Find(&grants).Error; err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
+
+func (r *repository) CountAccessGrants(ctx context.Context, options map[string]interface{}) (int64, error) {
+	var total int64
+	query := applyAccessGrantFilters(r.Conn.WithContext(ctx).Model(&AccessGrant{}), options)
+	err := query.Count(&total).Error
+	return total, err
+}
+
+func (r *repository) FindOneAccessGrant(ctx context.Context, options map[string]interface{}) (*AccessGrant, error) {
+	var grant AccessGrant
+	query := r.Conn.WithContext(ctx)
+	if id, ok := options["id"]; ok && id != "" {
+		query = query.Where("access_grants.id = ?", id)
+	}
+	query = applyAccessGrantFilters(query, options)
+
+	if err := query.First(&grant).Error; err != nil {
+		return nil, err
+	}
+	return &grant, nil
+}
+
+// CreateAccessGrants also scopes the account, so granting a resource never widens the access of
+// an account that was unrestricted before.
+func (r *repository) CreateAccessGrants(ctx context.Context, grants []AccessGrant) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range grants {
+			if err := tx.Create(&grants[i]).Error; err != nil {
+				return err
+			}
+			scope := AccessScope{AccountID: grants[i].AccountID, ScopedBy: grants[i].GrantedBy, CreatedAt: grants[i].CreatedAt}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&scope).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *repository) DeleteAccessGrant(ctx context.Context, id string) error {
+	return r.Conn.WithContext(ctx).Where("id = ?", id).Delete(&AccessGrant{}).Error
+}
+
+func (r *repository) FindGrantedResourceIDs(ctx context.Context, accountID string, resourceType ResourceType) ([]string, error) {
+	var ids []string
+	err := r.Conn.WithContext(ctx).Model(&AccessGrant{}).
+		Where("account_id = ? AND resource_type = ?", accountID, resourceType).
+		Pluck("resource_id", &ids).Error
+	return ids, err
+}
+
+func (r *repository) CountExistingResources(ctx context.Context, resourceType ResourceType, resourceIDs []string) (int64, error) {
+	table, ok := resourceTables[resourceType]
+	if !ok {
+		return 0, fmt.Errorf("unknown resource type %q", resourceType)
+	}
+
+	var total int64
+	query := r.Conn.WithContext(ctx).Table(table).Where("id IN ?", resourceIDs)
+	if softDeletedResources[resourceType] {
+		query = query.Where("deleted_at IS NULL")
+	}
+	err := query.Count(&total).Error
+	return total, err
+}
+
+// isScoped treats an empty account ID, as passed for public requests, as not scoped.
+func (r *repository) isScoped(ctx context.Context, accountID string) (bool, error) {
+	if accountID == "" {
+		return false, nil
+	}
+	var total int64
+	err := r.Conn.WithContext(ctx).Model(&AccessScope{}).
+		Where("account_id = ?", accountID).
+		Count(&total).Error
+	return total > 0, err
+}
+
+func (r *repository) FindAccessScope(ctx context.Context, accountID string, resourceType ResourceType) ([]string, bool, error) {
+	scoped, err := r.isScoped(ctx, accountID)
+	if err != nil || !scoped {
+		return nil, false, err
+	}
+	ids, err := r.FindGrantedResourceIDs(ctx, accountID, resourceType)
+	if err != nil {
+		return nil, false, err
+	}
+	if ids == nil {
+		ids = []string{}
+	}
+	return ids, true, nil
+}
+
+func (r *repository) HasAccess(ctx context.Context, accountID string, resourceType ResourceType, resourceID string) (bool, error) {
+	scoped, err := r.isScoped(ctx, accountID)
+	if err != nil {
+		return false, err
+	}
+	if !scoped {
+		return true, nil
+	}
+	var total int64
+	err = r.Conn.WithContext(ctx).Model(&AccessGrant{}).
+		Where("account_id = ? AND resource_type = ? AND resource_id = ?", accountID, resourceType, resourceID).
+		Count(&total).Error
+	return total > 0, err
+}
+
+func (r *repository) FindOneAccessScope(ctx context.Context, accountID string) (*AccessScope, error) {
+	var scope AccessScope
+	if err := r.Conn.WithContext(ctx).Where("account_id = ?", accountID).First(&scope).Error; err != nil {
+		return nil, err
+	}
+	return &scope, nil
+}
+
+func (r *repository) CreateAccessScope(ctx context.Context, scope *AccessScope) error {
+	return r.Conn.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(scope).Error
+}
+
+func (r *repository) DeleteAccessScope(ctx context.Context, accountID string) error {
+	return r.Conn.WithContext(ctx).Where("account_id = ?", accountID).Delete(&AccessScope{}).Error
+}
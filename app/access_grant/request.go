@@ -0,0 +1,15 @@
+package access_grant
+
+type CreateAccessGrantRequest struct {
+	AccountID    string       `json:"accountId"`
+	ResourceType ResourceType `json:"resourceType"`
+	ResourceIDs  []string     `json:"resourceIds"`
+}
+
+type AccessGrantQueryParams struct {
+	AccountID    string `form:"accountId"`
+	ResourceType string `form:"resourceType"`
+	ResourceID   string `form:"resourceId"`
+	Page         int    `form:"page"`
+	Limit        int    `form:"limit"`
+}
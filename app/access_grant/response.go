@@ -0,0 +1,63 @@
+package access_grant
+
+import (
+	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/google/uuid"
+)
+
+type AccessGrantResponse struct {
+	ID           string       `json:"id"`
+	AccountID    string       `json:"accountId"`
+	Username     string       `json:"username"`
+	Email        string       `json:"email"`
+	ResourceType ResourceType `json:"resourceType"`
+	ResourceID   string       `json:"resourceId"`
+	GrantedBy    string       `json:"grantedBy"`
+	CreatedAt    string       `json:"createdAt"`
+}
+
+type AccessScopeResponse struct {
+	AccountID string `json:"accountId"`
+	ScopedBy  string `json:"scopedBy"`
+	CreatedAt string `json:"createdAt"`
+}
+
+type AccessGrantListResponse struct {
+	AccessGrants []AccessGrantResponse `json:"accessGrants"`
+	Pagination   pkg.OffsetPagination  `json:"pagination"`
+}
+
+func (g *AccessGrant) toAccessGrantResponse() AccessGrantResponse {
+	resp := AccessGrantResponse{
+		ID:           g.ID.String(),
+		AccountID:    g.AccountID.String(),
+		Email:        g.Account.Email,
+		ResourceType: g.ResourceType,
+		ResourceID:   g.ResourceID.String(),
+		GrantedBy:    g.GrantedBy.String(),
+		CreatedAt:    g.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if g.Account.UserProfile.ID != uuid.Nil {
+		resp.Username = g.Account.UserProfile.Username
+	}
+	return resp
+}
+
+func toAccessGrantListResponse(grants []AccessGrant, pagination pkg.OffsetPagination) AccessGrantListResponse {
+	responses := make([]AccessGrantResponse, 0, len(grants))
+	for _, grant := range grants {
+		responses = append(responses, grant.toAccessGrantResponse())
+	}
+	return AccessGrantListResponse{
+		AccessGrants: responses,
+		Pagination:   pagination,
+	}
+}
+
+func (s *AccessScope) toAccessScopeResponse() AccessScopeResponse {
+	return AccessScopeResponse{
+		AccountID: s.AccountID.String(),
+		ScopedBy:  s.ScopedBy.String(),
+		CreatedAt: s.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,324 @@
+package access_grant
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/account"
+	app_log "github.com/Vilamuzz/yota-backend/app/log"
+	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	GetAccessGrantList(ctx context.Context, params AccessGrantQueryParams) pkg.Response
+	CreateAccessGrants(ctx context.Context, actorID string, payload CreateAccessGrantRequest) pkg.Response
+	DeleteAccessGrant(ctx context.Context, actorID string, id string) pkg.Response
+	ScopeAccount(ctx context.Context, actorID string, accountID string) pkg.Response
+	UnscopeAccount(ctx context.Context, actorID string, accountID string) pkg.Response
+}
+
+type service struct {
+	repo        Repository
+	accountRepo account.Repository
+	logService  app_log.Service
+	timeout     time.Duration
+}
+
+func NewService(repo Repository, accountRepo account.Repository, logService app_log.Service, timeout time.Duration) Service {
+	return &service{
+		repo:        repo,
+		accountRepo: accountRepo,
+		logService:  logService,
+		timeout:     timeout,
+	}
+}
+
+func (s *service) GetAccessGrantList(ctx context.Context, params AccessGrantQueryParams) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+
+	options := map[string]interface{}{
+		"limit": params.Limit,
+		"page":  params.Page,
+	}
+	if params.AccountID != "" {
+		if err := uuid.Validate(params.AccountID); err != nil {
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"accountId": "Format ID akun tidak valid"}, nil)
+		}
+		options["account_id"] = params.AccountID
+	}
+	if params.ResourceType != "" {
+		if !ResourceType(params.ResourceType).IsValid() {
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"resourceType": "Tipe resource tidak valid"}, nil)
+		}
+		options["resource_type"] = params.ResourceType
+	}
+	if params.ResourceID != "" {
+		if err := uuid.Validate(params.ResourceID); err != nil {
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"resourceId": "Format ID resource tidak valid"}, nil)
+		}
+		options["resource_id"] = params.ResourceID
+	}
+
+	total, err := s.repo.CountAccessGrants(ctx, options)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "access_grant.service",
+		}).WithError(err).Error("failed to count access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data hak akses", nil, nil)
+	}
+
+	grants, err := s.repo.FindAllAccessGrants(ctx, options)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "access_grant.service",
+		}).WithError(err).Error("failed to fetch access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data hak akses", nil, nil)
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit != 0 {
+		totalPages++
+	}
+
+	pagination := pkg.OffsetPagination{
+		Page:       params.Page,
+		Limit:      params.Limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, toAccessGrantListResponse(grants, pagination))
+}
+
+func (s *service) CreateAccessGrants(ctx context.Context, actorID string, payload CreateAccessGrantRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	errValidation := make(map[string]string)
+	if payload.AccountID == "" {
+		errValidation["accountId"] = "ID akun wajib diisi"
+	} else if err := uuid.Validate(payload.AccountID); err != nil {
+		errValidation["accountId"] = "Format ID akun tidak valid"
+	}
+	if !payload.ResourceType.IsValid() {
+		errValidation["resourceType"] = "Tipe resource tidak valid"
+	}
+
+	// Deduplicate so a repeated ID in the payload does not trip the unique index.
+	seen := make(map[string]bool)
+	resourceIDs := make([]string, 0, len(payload.ResourceIDs))
+	for _, id := range payload.ResourceIDs {
+		if err := uuid.Validate(id); err != nil {
+			errValidation["resourceIds"] = "Format ID resource tidak valid"
+			break
+		}
+		if !seen[id] {
+			seen[id] = true
+			resourceIDs = append(resourceIDs, id)
+		}
+	}
+	if len(resourceIDs) == 0 && errValidation["resourceIds"] == "" {
+		errValidation["resourceIds"] = "Minimal satu resource wajib dipilih"
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	if _, err := s.accountRepo.FindOneAccount(ctx, map[string]interface{}{"id": payload.AccountID}); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Akun tidak ditemukan", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "access_grant.service",
+			"account_id": payload.AccountID,
+		}).WithError(err).Error("failed to fetch account")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+
+	existing, err := s.repo.CountExistingResources(ctx, payload.ResourceType, resourceIDs)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":     "access_grant.service",
+			"resource_type": payload.ResourceType,
+		}).WithError(err).Error("failed to verify resources")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+	if int(existing) != len(resourceIDs) {
+		return pkg.NewResponse(http.StatusNotFound, "Sebagian resource tidak ditemukan", nil, nil)
+	}
+
+	granted, err := s.repo.FindGrantedResourceIDs(ctx, payload.AccountID, payload.ResourceType)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "access_grant.service",
+			"account_id": payload.AccountID,
+		}).WithError(err).Error("failed to fetch granted resources")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+	alreadyGranted := make(map[string]bool, len(granted))
+	for _, id := range granted {
+		alreadyGranted[id] = true
+	}
+
+	accountUUID := uuid.MustParse(payload.AccountID)
+	actorUUID, _ := uuid.Parse(actorID)
+	grants := make([]AccessGrant, 0, len(resourceIDs))
+	for _, id := range resourceIDs {
+		if alreadyGranted[id] {
+			continue
+		}
+		grants = append(grants, AccessGrant{
+			ID:           uuid.New(),
+			AccountID:    accountUUID,
+			ResourceType: payload.ResourceType,
+			ResourceID:   uuid.MustParse(id),
+			GrantedBy:    actorUUID,
+			CreatedAt:    time.Now(),
+		})
+	}
+	if len(grants) == 0 {
+		return pkg.NewResponse(http.StatusConflict, "Semua resource sudah diberikan ke akun ini", nil, nil)
+	}
+
+	if err := s.repo.CreateAccessGrants(ctx, grants); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "access_grant.service",
+			"account_id": payload.AccountID,
+		}).WithError(err).Error("failed to create access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memberikan hak akses", nil, nil)
+	}
+
+	responses := make([]AccessGrantResponse, 0, len(grants))
+	for _, grant := range grants {
+		resp := grant.toAccessGrantResponse()
+		s.logService.CreateLog(ctx, &actorID, "CREATE", "access_grant", resp.ID, nil, resp)
+		responses = append(responses, resp)
+	}
+
+	return pkg.NewResponse(http.StatusCreated, "Hak akses berhasil diberikan", nil, responses)
+}
+
+func (s *service) DeleteAccessGrant(ctx context.Context, actorID string, id string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := uuid.Validate(id); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID hak akses tidak valid"}, nil)
+	}
+
+	grant, err := s.repo.FindOneAccessGrant(ctx, map[string]interface{}{"id": id})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Hak akses tidak ditemukan", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":       "access_grant.service",
+			"access_grant_id": id,
+		}).WithError(err).Error("failed to fetch access grant")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+
+	if err := s.repo.DeleteAccessGrant(ctx, id); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":       "access_grant.service",
+			"access_grant_id": id,
+		}).WithError(err).Error("failed to delete access grant")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mencabut hak akses", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &actorID, "DELETE", "access_grant", id, grant.toAccessGrantResponse(), nil)
+
+	return pkg.NewResponse(http.StatusOK, "Hak akses berhasil dicabut", nil, nil)
+}
+
+// ScopeAccount restricts the account to the resources granted to it, even before it holds any
+// grant.
+func (s *service) ScopeAccount(ctx context.Context, actorID string, accountID string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := uuid.Validate(accountID); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"accountId": "Format ID akun tidak valid"}, nil)
+	}
+
+	if _, err := s.accountRepo.FindOneAccount(ctx, map[string]interface{}{"id": accountID}); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Akun tidak ditemukan", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "access_grant.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to fetch account")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+
+	actorUUID, _ := uuid.Parse(actorID)
+	scope := &AccessScope{
+		AccountID: uuid.MustParse(accountID),
+		ScopedBy:  actorUUID,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.CreateAccessScope(ctx, scope); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "access_grant.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to scope account")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membatasi akses akun", nil, nil)
+	}
+
+	resp := scope.toAccessScopeResponse()
+	s.logService.CreateLog(ctx, &actorID, "CREATE", "access_scope", accountID, nil, resp)
+
+	return pkg.NewResponse(http.StatusOK, "Akses akun berhasil dibatasi", nil, resp)
+}
+
+// UnscopeAccount gives the account its role-wide access back. Its grants are kept so scoping it
+// again restores the same assignments.
+func (s *service) UnscopeAccount(ctx context.Context, actorID string, accountID string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := uuid.Validate(accountID); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"accountId": "Format ID akun tidak valid"}, nil)
+	}
+
+	scope, err := s.repo.FindOneAccessScope(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Akses akun tidak sedang dibatasi", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "access_grant.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to fetch access scope")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+
+	if err := s.repo.DeleteAccessScope(ctx, accountID); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "access_grant.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to unscope account")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mencabut pembatasan akses akun", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &actorID, "DELETE", "access_scope", accountID, scope.toAccessScopeResponse(), nil)
+
+	return pkg.NewResponse(http.StatusOK, "Pembatasan akses akun berhasil dicabut", nil, nil)
+}
@@ -8,13 +8,14 @@ import (
 )
 
 type Account struct {
-	ID            uuid.UUID `json:"id" gorm:"primaryKey"`
-	Email         string    `json:"email" gorm:"unique;not null"`
-	Password      string    `json:"password" gorm:"not null"`
-	IsBanned      bool      `json:"isBanned" gorm:"type:boolean;not null;default:false"`
-	EmailVerified bool      `json:"emailVerified" gorm:"default:false"`
-	CreatedAt     time.Time `json:"createdAt"`
-	UpdatedAt     time.Time `json:"updatedAt"`
+	ID            uuid.UUID  `json:"id" gorm:"primaryKey"`
+	Email         string     `json:"email" gorm:"unique;not null"`
+	Password      string     `json:"password" gorm:"not null"`
+	IsBanned      bool       `json:"isBanned" gorm:"type:boolean;not null;default:false"`
+	EmailVerified bool       `json:"emailVerified" gorm:"default:false"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+	DeletedAt     *time.Time `json:"deletedAt" gorm:"index"` // erased on request; the anonymised row keeps financial records linked
 
 	UserProfile  UserProfile   `json:"userProfile" gorm:"foreignKey:AccountID;references:ID"`
 	AccountRoles []AccountRole `json:"accountRoles" gorm:"foreignKey:AccountID;references:ID"`
@@ -25,6 +26,7 @@ type UserProfile struct {
 	AccountID      uuid.UUID `json:"accountId" gorm:"unique;not null"`
 	Username       string    `json:"username"`
 	Phone          *string   `json:"phone"`
+	PhoneVerified  bool      `json:"phoneVerified" gorm:"default:false"`
 	Address        string    `json:"address"`
 	ProfilePicture string    `json:"profilePicture"`
 	CreatedAt      time.Time `json:"createdAt"`
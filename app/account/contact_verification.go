@@ -0,0 +1,31 @@
+package account
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailChangeToken holds a pending login email change. The account email is only
+// switched once the token sent to NewEmail is redeemed.
+type EmailChangeToken struct {
+	ID        uuid.UUID `json:"id" gorm:"primaryKey"`
+	AccountID uuid.UUID `json:"accountId" gorm:"not null;index"`
+	NewEmail  string    `json:"newEmail" gorm:"not null"`
+	Token     string    `json:"token" gorm:"unique;not null"`
+	ExpiredAt time.Time `json:"expiredAt" gorm:"not null"`
+	IsUsed    bool      `json:"isUsed" gorm:"default:false"`
+	CreatedAt time.Time `json:"createdAt" gorm:"not null"`
+}
+
+// PhoneVerificationCode is a hashed OTP sent to Phone over SMS or WhatsApp.
+type PhoneVerificationCode struct {
+	ID        uuid.UUID `json:"id" gorm:"primaryKey"`
+	AccountID uuid.UUID `json:"accountId" gorm:"not null;index"`
+	Phone     string    `json:"phone" gorm:"not null"`
+	CodeHash  string    `json:"-" gorm:"not null"`
+	Attempts  int       `json:"attempts" gorm:"default:0"`
+	ExpiredAt time.Time `json:"expiredAt" gorm:"not null"`
+	IsUsed    bool      `json:"isUsed" gorm:"default:false"`
+	CreatedAt time.Time `json:"createdAt" gorm:"not null"`
+}
@@ -3,6 +3,7 @@ package account
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/Vilamuzz/yota-backend/app/middleware"
 	"github.com/Vilamuzz/yota-backend/pkg"
@@ -33,7 +34,11 @@ func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
 		me.GET("", h.GetMe)
 		me.PATCH("/profile", h.UpdateUserProfile)
 		me.PATCH("/password", h.UpdatePassword)
+		me.POST("/email-change", h.middleware.CustomRateLimitHandler(3, 1*time.Minute), h.RequestEmailChange)
+		me.POST("/phone-verification", h.middleware.CustomRateLimitHandler(3, 1*time.Minute), h.RequestPhoneVerification)
+		me.POST("/phone-verification/verify", h.middleware.AuthRateLimitHandler(), h.VerifyPhone)
 	}
+	r.POST("/email-change/confirm", h.middleware.AuthRateLimitHandler(), h.ConfirmEmailChange)
 
 	admin := r.Group("/admin/accounts")
 	admin.Use(h.middleware.RequireRoles(enum.RoleSocialManager, enum.RoleAmbulanceManager))
@@ -383,3 +388,101 @@ func (h *handler) GetRoleList(c *gin.Context) {
 	res := h.service.GetRoleList(ctx)
 	c.JSON(res.Status, res)
 }
+
+// RequestEmailChange
+//
+// @Summary Request Email Change
+// @Description Send a confirmation link to the new email address. The login email changes only after confirmation
+// @Tags Account
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body RequestEmailChangeRequest true "New email"
+// @Success 200 {object} pkg.Response
+// @Router /api/me/email-change [post]
+func (h *handler) RequestEmailChange(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req RequestEmailChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.RequestEmailChange(ctx, claims.AccountID, req)
+	c.JSON(res.Status, res)
+}
+
+// ConfirmEmailChange
+//
+// @Summary Confirm Email Change
+// @Description Confirm a pending email change with the token sent to the new address
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param request body ConfirmEmailChangeRequest true "Confirmation token"
+// @Success 200 {object} pkg.Response
+// @Router /api/email-change/confirm [post]
+func (h *handler) ConfirmEmailChange(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req ConfirmEmailChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.ConfirmEmailChange(ctx, req)
+	c.JSON(res.Status, res)
+}
+
+// RequestPhoneVerification
+//
+// @Summary Request Phone Verification
+// @Description Send a one-time code to the phone number via SMS or WhatsApp
+// @Tags Account
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body RequestPhoneVerificationRequest true "Phone number and channel"
+// @Success 200 {object} pkg.Response
+// @Router /api/me/phone-verification [post]
+func (h *handler) RequestPhoneVerification(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req RequestPhoneVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.RequestPhoneVerification(ctx, claims.AccountID, req)
+	c.JSON(res.Status, res)
+}
+
+// VerifyPhone
+//
+// @Summary Verify Phone
+// @Description Verify the phone number with the one-time code
+// @Tags Account
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body VerifyPhoneRequest true "Verification code"
+// @Success 200 {object} pkg.Response
+// @Router /api/me/phone-verification/verify [post]
+func (h *handler) VerifyPhone(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req VerifyPhoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.VerifyPhone(ctx, claims.AccountID, req)
+	c.JSON(res.Status, res)
+}
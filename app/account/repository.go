@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Vilamuzz/yota-backend/pkg"
 	"github.com/Vilamuzz/yota-backend/pkg/enum"
@@ -24,6 +25,14 @@ type Repository interface {
 	FindAllRoles(ctx context.Context) ([]Role, error)
 	FindOneRole(ctx context.Context, roleID int) (*Role, error)
 	UpdateFullProfile(ctx context.Context, accountID string, updateAccount, updateProfile map[string]interface{}, defaultRoleID int) error
+
+	CreateEmailChangeToken(ctx context.Context, token *EmailChangeToken) error
+	FetchEmailChangeToken(ctx context.Context, token string) (*EmailChangeToken, error)
+	ApplyEmailChange(ctx context.Context, tokenID, accountID, newEmail string) error
+	CreatePhoneVerificationCode(ctx context.Context, code *PhoneVerificationCode) error
+	FetchActivePhoneVerificationCode(ctx context.Context, accountID string) (*PhoneVerificationCode, error)
+	IncrementPhoneVerificationAttempts(ctx context.Context, codeID string, maxAttempts int) error
+	ApplyPhoneVerification(ctx context.Context, codeID, accountID, phone string) error
 }
 
 type repository struct {
@@ -38,7 +47,8 @@ func (r *repository) FindAllAccounts(ctx context.Context, options map[string]int
 	var accounts []Account
 	query := r.Conn.WithContext(ctx).
 		Preload("UserProfile").
-		Preload("AccountRoles.Role")
+		Preload("AccountRoles.Role").
+		Where("accounts.deleted_at IS NULL")
 
 	if excludeSuperadmin, ok := options["exclude_superadmin"]; ok && excludeSuperadmin.(bool) {
 		query = query.Where("accounts.id NOT IN (SELECT account_id FROM account_roles WHERE role_id = ?)", 8)
@@ -220,3 +230,99 @@ func (r *repository) UpdateFullProfile(ctx context.Context, accountID string, up
 		return nil
 	})
 }
+
+// CreateEmailChangeToken invalidates any pending email change for the account before
+// storing the new one, so only the latest requested address can be confirmed.
+func (r *repository) CreateEmailChangeToken(ctx context.Context, token *EmailChangeToken) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&EmailChangeToken{}).
+			Where("account_id = ? AND is_used = ?", token.AccountID, false).
+			Update("is_used", true).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(token).Error
+	})
+}
+
+func (r *repository) FetchEmailChangeToken(ctx context.Context, token string) (*EmailChangeToken, error) {
+	var changeToken EmailChangeToken
+	if err := r.Conn.WithContext(ctx).
+		Where("token = ? AND is_used = ? AND expired_at > ?", token, false, time.Now()).
+		First(&changeToken).Error; err != nil {
+		return nil, err
+	}
+
+	return &changeToken, nil
+}
+
+func (r *repository) ApplyEmailChange(ctx context.Context, tokenID, accountID, newEmail string) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&EmailChangeToken{}).
+			Where("id = ? AND is_used = ?", tokenID, false).
+			Update("is_used", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Model(&Account{}).Where("id = ?", accountID).Updates(map[string]interface{}{
+			"email":          newEmail,
+			"email_verified": true,
+		}).Error
+	})
+}
+
+func (r *repository) CreatePhoneVerificationCode(ctx context.Context, code *PhoneVerificationCode) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&PhoneVerificationCode{}).
+			Where("account_id = ? AND is_used = ?", code.AccountID, false).
+			Update("is_used", true).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(code).Error
+	})
+}
+
+func (r *repository) FetchActivePhoneVerificationCode(ctx context.Context, accountID string) (*PhoneVerificationCode, error) {
+	var code PhoneVerificationCode
+	if err := r.Conn.WithContext(ctx).
+		Where("account_id = ? AND is_used = ? AND expired_at > ?", accountID, false, time.Now()).
+		Order("created_at DESC").
+		First(&code).Error; err != nil {
+		return nil, err
+	}
+
+	return &code, nil
+}
+
+func (r *repository) IncrementPhoneVerificationAttempts(ctx context.Context, codeID string, maxAttempts int) error {
+	return r.Conn.WithContext(ctx).Model(&PhoneVerificationCode{}).
+		Where("id = ?", codeID).
+		Updates(map[string]interface{}{
+			"attempts": gorm.Expr("attempts + 1"),
+			"is_used":  gorm.Expr("attempts + 1 >= ?", maxAttempts),
+		}).Error
+}
+
+func (r *repository) ApplyPhoneVerification(ctx context.Context, codeID, accountID, phone string) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&PhoneVerificationCode{}).
+			Where("id = ? AND is_used = ?", codeID, false).
+			Update("is_used", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Model(&UserProfile{}).Where("account_id = ?", accountID).Updates(map[string]interface{}{
+			"phone":          phone,
+			"phone_verified": true,
+		}).Error
+	})
+}
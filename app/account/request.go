@@ -5,6 +5,7 @@ import (
 
 	"github.com/Vilamuzz/yota-backend/pkg"
 	"github.com/Vilamuzz/yota-backend/pkg/enum"
+	"github.com/Vilamuzz/yota-backend/pkg/sms"
 )
 
 type CreateAccountRequest struct {
@@ -42,3 +43,21 @@ type AccountQueryParam struct {
 type UpdateAccountRoleRequest struct {
 	IsActive bool `json:"isActive"`
 }
+
+type RequestEmailChangeRequest struct {
+	NewEmail        string `json:"newEmail"`
+	CurrentPassword string `json:"currentPassword"`
+}
+
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token"`
+}
+
+type RequestPhoneVerificationRequest struct {
+	Phone   string      `json:"phone"`
+	Channel sms.Channel `json:"channel" enums:"sms,whatsapp"`
+}
+
+type VerifyPhoneRequest struct {
+	Code string `json:"code"`
+}
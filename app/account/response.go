@@ -14,6 +14,8 @@ type UserProfileResponse struct {
 	Roles          []AccountRolesResponse `json:"roles"`
 	ProfilePicture string                 `json:"profilePicture"`
 	Phone          string                 `json:"phone"`
+	PhoneVerified  bool                   `json:"phoneVerified"`
+	EmailVerified  bool                   `json:"emailVerified"`
 	Address        string                 `json:"address"`
 	CreatedAt      time.Time              `json:"createdAt"`
 }
@@ -76,6 +78,8 @@ func (a *Account) toUserProfileResponse() UserProfileResponse {
 		Email:          a.Email,
 		Roles:          toAccountRolesResponse(a.AccountRoles),
 		Phone:          phone,
+		PhoneVerified:  a.UserProfile.PhoneVerified,
+		EmailVerified:  a.EmailVerified,
 		Address:        a.UserProfile.Address,
 		ProfilePicture: s3_pkg.GetCDNURL(a.UserProfile.ProfilePicture),
 		CreatedAt:      a.CreatedAt,
@@ -2,12 +2,20 @@ package account
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"math/big"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/Vilamuzz/yota-backend/pkg"
 	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
+	"github.com/Vilamuzz/yota-backend/pkg/sms"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
@@ -24,21 +32,36 @@ type Service interface {
 
 	UpdateUserProfile(ctx context.Context, accountID string, payload UpdateUserProfileRequest) pkg.Response
 	UpdatePassword(ctx context.Context, accountID string, payload UpdatePasswordRequest) pkg.Response
+	RequestEmailChange(ctx context.Context, accountID string, payload RequestEmailChangeRequest) pkg.Response
+	ConfirmEmailChange(ctx context.Context, payload ConfirmEmailChangeRequest) pkg.Response
+	RequestPhoneVerification(ctx context.Context, accountID string, payload RequestPhoneVerificationRequest) pkg.Response
+	VerifyPhone(ctx context.Context, accountID string, payload VerifyPhoneRequest) pkg.Response
 
 	GetRoleList(ctx context.Context) pkg.Response
 }
 
 type service struct {
-	repo     Repository
-	timeout  time.Duration
-	s3Client s3_pkg.Client
+	repo         Repository
+	timeout      time.Duration
+	s3Client     s3_pkg.Client
+	smsSender    sms.Sender
+	emailService *pkg.EmailService
 }
 
-func NewService(r Repository, timeout time.Duration, s3Client s3_pkg.Client) Service {
+const (
+	emailChangeTokenTTL       = 30 * time.Minute
+	phoneVerificationCodeTTL  = 5 * time.Minute
+	phoneVerificationResend   = 1 * time.Minute
+	maxPhoneVerificationTries = 5
+)
+
+func NewService(r Repository, timeout time.Duration, s3Client s3_pkg.Client, smsSender sms.Sender) Service {
 	return &service{
-		repo:     r,
-		timeout:  timeout,
-		s3Client: s3Client,
+		repo:         r,
+		timeout:      timeout,
+		s3Client:     s3Client,
+		smsSender:    smsSender,
+		emailService: pkg.NewEmailService(),
 	}
 }
 
@@ -332,18 +355,12 @@ func (s *service) UpdateUserProfile(ctx context.Context, accountID string, paylo
 		}
 	}
 	if payload.Email != "" {
-		if !pkg.IsValidEmail(payload.Email) {
-			errValidation["email"] = "Format email tidak valid"
-		} else {
-			existing, err := s.repo.FindOneAccount(ctx, map[string]interface{}{"email": payload.Email})
-			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-				return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
-			}
-			if err == nil && existing.ID.String() != accountID {
-				errValidation["email"] = "Email sudah digunakan"
-			} else {
-				updateAccountMap["email"] = payload.Email
-			}
+		existing, err := s.repo.FindOneAccount(ctx, map[string]interface{}{"id": accountID})
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+		}
+		if err == nil && !strings.EqualFold(existing.Email, payload.Email) {
+			errValidation["email"] = "Email hanya dapat diubah melalui verifikasi email baru"
 		}
 	}
 	if payload.Phone != "" {
@@ -356,8 +373,10 @@ func (s *service) UpdateUserProfile(ctx context.Context, accountID string, paylo
 			}
 			if err == nil && existing.ID.String() != accountID {
 				errValidation["phone"] = "Nomor telepon sudah digunakan"
-			} else {
+			} else if err != nil {
+				// A new number must go through OTP verification again.
 				updateProfileMap["phone"] = payload.Phone
+				updateProfileMap["phone_verified"] = false
 			}
 		}
 	}
@@ -531,3 +550,276 @@ func (s *service) GetRoleList(ctx context.Context) pkg.Response {
 
 	return pkg.NewResponse(http.StatusOK, "Daftar peran berhasil diambil", nil, toRolesResponse(roles))
 }
+
+// RequestEmailChange sends a confirmation link to the new address. The login email stays
+// unchanged until that link is opened.
+func (s *service) RequestEmailChange(ctx context.Context, accountID string, payload RequestEmailChangeRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	account, err := s.repo.FindOneAccount(ctx, map[string]interface{}{"id": accountID})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Akun tidak ditemukan", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "account.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to retrieve account for email change")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+
+	errValidation := make(map[string]string)
+	payload.NewEmail = strings.TrimSpace(payload.NewEmail)
+	if payload.NewEmail == "" {
+		errValidation["newEmail"] = "Email baru wajib diisi"
+	} else if !pkg.IsValidEmail(payload.NewEmail) {
+		errValidation["newEmail"] = "Format email tidak valid"
+	} else if strings.EqualFold(payload.NewEmail, account.Email) {
+		errValidation["newEmail"] = "Email baru sama dengan email saat ini"
+	} else {
+		_, err := s.repo.FindOneAccount(ctx, map[string]interface{}{"email": payload.NewEmail})
+		if err == nil {
+			errValidation["newEmail"] = "Email sudah digunakan"
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+		}
+	}
+	if account.Password != "" {
+		if payload.CurrentPassword == "" {
+			errValidation["currentPassword"] = "Kata sandi saat ini wajib diisi"
+		} else if err := bcrypt.CompareHashAndPassword([]byte(account.Password), []byte(payload.CurrentPassword)); err != nil {
+			errValidation["currentPassword"] = "Kata sandi saat ini salah"
+		}
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		logrus.WithError(err).Error("failed to generate email change token")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+
+	changeToken := &EmailChangeToken{
+		ID:        uuid.New(),
+		AccountID: account.ID,
+		NewEmail:  payload.NewEmail,
+		Token:     hex.EncodeToString(tokenBytes),
+		ExpiredAt: time.Now().Add(emailChangeTokenTTL),
+		IsUsed:    false,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.CreateEmailChangeToken(ctx, changeToken); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "account.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to create email change token")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membuat permintaan perubahan email", nil, nil)
+	}
+
+	go func(email, username, token string) {
+		if err := s.emailService.SendEmailChangeVerificationEmail(email, username, token, int(emailChangeTokenTTL.Minutes())); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component": "account.service",
+				"email":     email,
+			}).WithError(err).Error("failed to send email change verification")
+		}
+	}(changeToken.NewEmail, account.UserProfile.Username, changeToken.Token)
+
+	return pkg.NewResponse(http.StatusOK, "Tautan konfirmasi telah dikirim ke email baru", nil, nil)
+}
+
+func (s *service) ConfirmEmailChange(ctx context.Context, payload ConfirmEmailChangeRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if payload.Token == "" {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"token": "Token wajib diisi"}, nil)
+	}
+
+	changeToken, err := s.repo.FetchEmailChangeToken(ctx, payload.Token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"token": "Token tidak valid atau sudah kedaluwarsa"}, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component": "account.service",
+		}).WithError(err).Error("failed to fetch email change token")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+
+	account, err := s.repo.FindOneAccount(ctx, map[string]interface{}{"id": changeToken.AccountID.String()})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Akun tidak ditemukan", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "account.service",
+			"account_id": changeToken.AccountID.String(),
+		}).WithError(err).Error("failed to retrieve account for email change")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+
+	// The address may have been taken while the link was pending.
+	if _, err := s.repo.FindOneAccount(ctx, map[string]interface{}{"email": changeToken.NewEmail}); err == nil {
+		return pkg.NewResponse(http.StatusConflict, "Email sudah digunakan", nil, nil)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+
+	oldEmail := account.Email
+	if err := s.repo.ApplyEmailChange(ctx, changeToken.ID.String(), account.ID.String(), changeToken.NewEmail); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"token": "Token tidak valid atau sudah kedaluwarsa"}, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "account.service",
+			"account_id": account.ID.String(),
+		}).WithError(err).Error("failed to apply email change")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengubah email", nil, nil)
+	}
+
+	go func(oldEmail, username, newEmail string) {
+		if err := s.emailService.SendEmailChangedNotificationEmail(oldEmail, username, newEmail); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component": "account.service",
+				"email":     oldEmail,
+			}).WithError(err).Error("failed to send email changed notification")
+		}
+	}(oldEmail, account.UserProfile.Username, changeToken.NewEmail)
+
+	return pkg.NewResponse(http.StatusOK, "Email berhasil diubah", nil, nil)
+}
+
+func (s *service) RequestPhoneVerification(ctx context.Context, accountID string, payload RequestPhoneVerificationRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	errValidation := make(map[string]string)
+	payload.Phone = strings.TrimSpace(payload.Phone)
+	if payload.Phone == "" {
+		errValidation["phone"] = "Nomor telepon wajib diisi"
+	} else if !pkg.IsValidPhoneNumber(payload.Phone) {
+		errValidation["phone"] = "Format nomor telepon tidak valid"
+	} else {
+		existing, err := s.repo.FindOneAccount(ctx, map[string]interface{}{"phone": payload.Phone})
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+		}
+		if err == nil && existing.ID.String() != accountID {
+			errValidation["phone"] = "Nomor telepon sudah digunakan"
+		}
+	}
+	if payload.Channel == "" {
+		payload.Channel = sms.ChannelWhatsApp
+	}
+	if payload.Channel != sms.ChannelSMS && payload.Channel != sms.ChannelWhatsApp {
+		errValidation["channel"] = "Kanal pengiriman tidak valid"
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	active, err := s.repo.FetchActivePhoneVerificationCode(ctx, accountID)
+	if err == nil && time.Since(active.CreatedAt) < phoneVerificationResend {
+		return pkg.NewResponse(http.StatusTooManyRequests, "Tunggu sebentar sebelum meminta kode baru", nil, nil)
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		logrus.WithError(err).Error("failed to generate phone verification code")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+	code := fmt.Sprintf("%06d", n.Int64())
+
+	verification := &PhoneVerificationCode{
+		ID:        uuid.New(),
+		AccountID: uuid.MustParse(accountID),
+		Phone:     payload.Phone,
+		CodeHash:  hashVerificationCode(code),
+		ExpiredAt: time.Now().Add(phoneVerificationCodeTTL),
+		IsUsed:    false,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.CreatePhoneVerificationCode(ctx, verification); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "account.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to create phone verification code")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membuat kode verifikasi", nil, nil)
+	}
+
+	message := fmt.Sprintf("Kode verifikasi Yayasan Orang Tua Asuh Anda: %s. Berlaku %d menit. Jangan berikan kode ini kepada siapa pun.", code, int(phoneVerificationCodeTTL.Minutes()))
+	if err := s.smsSender.Send(ctx, payload.Channel, payload.Phone, message); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "account.service",
+			"account_id": accountID,
+			"channel":    payload.Channel,
+		}).WithError(err).Error("failed to send phone verification code")
+		return pkg.NewResponse(http.StatusBadGateway, "Gagal mengirim kode verifikasi", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Kode verifikasi telah dikirim", nil, nil)
+}
+
+func (s *service) VerifyPhone(ctx context.Context, accountID string, payload VerifyPhoneRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	payload.Code = strings.TrimSpace(payload.Code)
+	if len(payload.Code) != 6 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"code": "Kode harus 6 digit"}, nil)
+	}
+
+	const invalidMsg = "Kode tidak valid atau sudah kedaluwarsa"
+
+	verification, err := s.repo.FetchActivePhoneVerificationCode(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"code": invalidMsg}, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "account.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to fetch phone verification code")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(verification.CodeHash), []byte(hashVerificationCode(payload.Code))) != 1 {
+		if err := s.repo.IncrementPhoneVerificationAttempts(ctx, verification.ID.String(), maxPhoneVerificationTries); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":  "account.service",
+				"account_id": accountID,
+			}).WithError(err).Error("failed to record phone verification attempt")
+		}
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"code": invalidMsg}, nil)
+	}
+
+	// The number may have been claimed by another account since the code was sent.
+	existing, err := s.repo.FindOneAccount(ctx, map[string]interface{}{"phone": verification.Phone})
+	if err == nil && existing.ID.String() != accountID {
+		return pkg.NewResponse(http.StatusConflict, "Nomor telepon sudah digunakan", nil, nil)
+	} else if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+
+	if err := s.repo.ApplyPhoneVerification(ctx, verification.ID.String(), accountID, verification.Phone); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"code": invalidMsg}, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "account.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to apply phone verification")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memverifikasi nomor telepon", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Nomor telepon berhasil diverifikasi", nil, nil)
+}
+
+func hashVerificationCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -13,6 +13,7 @@ type Ambulance struct {
 	Image       string          `json:"image"`
 	PlateNumber string          `json:"plateNumber" gorm:"not null"`
 	Status      AmbulanceStatus `json:"status"`
+	OdometerKm  int64           `json:"odometerKm" gorm:"default:0"` // latest reading, kept in sync by the fleet module
 	CreatedAt   time.Time       `json:"createdAt" gorm:"index:idx_created,type:btree"`
 	UpdatedAt   time.Time       `json:"updatedAt"`
 	DeletedAt   *time.Time      `json:"deletedAt" gorm:"index"`
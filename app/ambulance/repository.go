@@ -18,6 +18,7 @@ type Repository interface {
 	UpdateAmbulance(ctx context.Context, id string, updateData map[string]interface{}) error
 	DeleteAmbulance(ctx context.Context, id string) error
 	VerifyDriverForAssignment(ctx context.Context, driverID string, excludeAmbulanceID string) error
+	CountOpenMaintenanceJobs(ctx context.Context, ambulanceID string) (int64, error)
 }
 
 type repository struct {
@@ -117,6 +118,14 @@ func (r *repository) DeleteAmbulance(ctx context.Context, id string) error {
 	return r.Conn.WithContext(ctx).Model(&Ambulance{}).Where("id = ?", id).Update("deleted_at", time.Now()).Error
 }
 
+func (r *repository) CountOpenMaintenanceJobs(ctx context.Context, ambulanceID string) (int64, error) {
+	var count int64
+	err := r.Conn.WithContext(ctx).Table("maintenance_jobs").
+		Where("ambulance_id = ? AND status = ?", ambulanceID, "open").
+		Count(&count).Error
+	return count, err
+}
+
 func (r *repository) VerifyDriverForAssignment(ctx context.Context, driverID string, excludeAmbulanceID string) error {
 	var count int64
 	err := r.Conn.WithContext(ctx).Table("account_roles").
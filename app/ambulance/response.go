@@ -13,6 +13,7 @@ type AmbulanceResponse struct {
 	Image       string                 `json:"image"`
 	PlateNumber string                 `json:"plateNumber"`
 	Status      AmbulanceStatus        `json:"status"`
+	OdometerKm  int64                  `json:"odometerKm"`
 }
 
 type AmbulanceListResponse struct {
@@ -43,6 +44,7 @@ func (a *Ambulance) toAmbulanceResponse() AmbulanceResponse {
 		Image:       s3_pkg.GetCDNURL(a.Image),
 		PlateNumber: a.PlateNumber,
 		Status:      a.Status,
+		OdometerKm:  a.OdometerKm,
 	}
 }
 
@@ -195,6 +195,15 @@ func (s *service) UpdateAmbulance(ctx context.Context, id string, payload Update
 		if payload.Status != AmbulanceStatusAvailable && payload.Status != AmbulanceStatusInUse && payload.Status != AmbulanceStatusMaintenance {
 			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"status": "Status tidak valid"}, nil)
 		}
+		if payload.Status != AmbulanceStatusMaintenance {
+			openJobs, err := s.repo.CountOpenMaintenanceJobs(ctx, id)
+			if err != nil {
+				return pkg.NewResponse(http.StatusInternalServerError, "Gagal memeriksa perawatan ambulans", nil, nil)
+			}
+			if openJobs > 0 {
+				return pkg.NewResponse(http.StatusConflict, "Ambulans masih memiliki perawatan yang belum selesai", nil, nil)
+			}
+		}
 		updateData["status"] = payload.Status
 	}
 	if payload.Image != nil {
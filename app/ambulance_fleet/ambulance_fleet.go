@@ -0,0 +1,156 @@
+package ambulance_fleet
+
+import (
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/ambulance"
+	"github.com/google/uuid"
+)
+
+// OdometerReading is a point-in-time odometer value. Readings for an ambulance never decrease.
+type OdometerReading struct {
+	ID          uuid.UUID `json:"id" gorm:"primaryKey"`
+	AmbulanceID uuid.UUID `json:"ambulanceId" gorm:"not null;index:idx_odometer_ambulance,priority:1"`
+	ReadingKm   int64     `json:"readingKm" gorm:"not null"`
+	Source      string    `json:"source" gorm:"not null"` // manual, fuel, maintenance
+	Note        string    `json:"note"`
+	RecordedBy  uuid.UUID `json:"recordedBy" gorm:"not null"`
+	RecordedAt  time.Time `json:"recordedAt" gorm:"not null;index:idx_odometer_ambulance,priority:2"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+const (
+	OdometerSourceManual      = "manual"
+	OdometerSourceFuel        = "fuel"
+	OdometerSourceMaintenance = "maintenance"
+)
+
+type FuelLog struct {
+	ID              uuid.UUID  `json:"id" gorm:"primaryKey"`
+	AmbulanceID     uuid.UUID  `json:"ambulanceId" gorm:"not null;index"`
+	Liters          float64    `json:"liters" gorm:"not null"`
+	Cost            float64    `json:"cost" gorm:"not null"`
+	OdometerKm      *int64     `json:"odometerKm"`
+	Station         string     `json:"station"`
+	ReceiptImage    string     `json:"receiptImage"`
+	PostedAsExpense bool       `json:"postedAsExpense" gorm:"default:false"`
+	RecordedBy      uuid.UUID  `json:"recordedBy" gorm:"not null"`
+	FilledAt        time.Time  `json:"filledAt" gorm:"not null"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	DeletedAt       *time.Time `json:"deletedAt" gorm:"index"`
+
+	Ambulance ambulance.Ambulance `json:"ambulance" gorm:"foreignKey:AmbulanceID"`
+}
+
+// MaintenanceSchedule is a recurring service item due every IntervalKm and/or IntervalDays,
+// whichever comes first.
+type MaintenanceSchedule struct {
+	ID             uuid.UUID  `json:"id" gorm:"primaryKey"`
+	AmbulanceID    uuid.UUID  `json:"ambulanceId" gorm:"not null;index"`
+	Name           string     `json:"name" gorm:"not null"`
+	IntervalKm     *int64     `json:"intervalKm"`
+	IntervalDays   *int       `json:"intervalDays"`
+	LastServiceKm  *int64     `json:"lastServiceKm"`
+	LastServiceAt  *time.Time `json:"lastServiceAt"`
+	NextDueKm      *int64     `json:"nextDueKm"`
+	NextDueAt      *time.Time `json:"nextDueAt"`
+	ReminderSentAt *time.Time `json:"reminderSentAt"` // reset whenever the schedule is serviced
+	IsActive       bool       `json:"isActive" gorm:"default:true"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+
+	Ambulance ambulance.Ambulance `json:"ambulance" gorm:"foreignKey:AmbulanceID"`
+}
+
+// advance recomputes the next due point after a service at the given odometer and time.
+func (m *MaintenanceSchedule) advance(serviceKm *int64, serviceAt time.Time) {
+	m.LastServiceAt = &serviceAt
+	m.NextDueAt = nil
+	if m.IntervalDays != nil {
+		next := serviceAt.AddDate(0, 0, *m.IntervalDays)
+		m.NextDueAt = &next
+	}
+	if serviceKm != nil {
+		m.LastServiceKm = serviceKm
+	}
+	m.NextDueKm = nil
+	if m.IntervalKm != nil && m.LastServiceKm != nil {
+		next := *m.LastServiceKm + *m.IntervalKm
+		m.NextDueKm = &next
+	}
+	m.ReminderSentAt = nil
+}
+
+// MaintenanceJob is a workshop visit. While a job is open the ambulance is kept in maintenance status.
+type MaintenanceJob struct {
+	ID              uuid.UUID            `json:"id" gorm:"primaryKey"`
+	AmbulanceID     uuid.UUID            `json:"ambulanceId" gorm:"not null;index"`
+	ScheduleID      *uuid.UUID           `json:"scheduleId" gorm:"index"`
+	Title           string               `json:"title" gorm:"not null"`
+	Description     string               `json:"description"`
+	Workshop        string               `json:"workshop"`
+	Status          MaintenanceJobStatus `json:"status" gorm:"not null;index"`
+	OdometerKm      *int64               `json:"odometerKm"`
+	Cost            float64              `json:"cost" gorm:"default:0"`
+	InvoiceImage    string               `json:"invoiceImage"`
+	PostedAsExpense bool                 `json:"postedAsExpense" gorm:"default:false"`
+	OpenedBy        uuid.UUID            `json:"openedBy" gorm:"not null"`
+	OpenedAt        time.Time            `json:"openedAt" gorm:"not null"`
+	ClosedAt        *time.Time           `json:"closedAt"`
+	CreatedAt       time.Time            `json:"createdAt"`
+	UpdatedAt       time.Time            `json:"updatedAt"`
+
+	Ambulance ambulance.Ambulance  `json:"ambulance" gorm:"foreignKey:AmbulanceID"`
+	Schedule  *MaintenanceSchedule `json:"schedule" gorm:"foreignKey:ScheduleID"`
+}
+
+type MaintenanceJobStatus string
+
+const (
+	MaintenanceJobOpen      MaintenanceJobStatus = "open"
+	MaintenanceJobCompleted MaintenanceJobStatus = "completed"
+	MaintenanceJobCancelled MaintenanceJobStatus = "cancelled"
+)
+
+// VehicleDocument is a legal document that must be renewed before it expires.
+type VehicleDocument struct {
+	ID          uuid.UUID    `json:"id" gorm:"primaryKey"`
+	AmbulanceID uuid.UUID    `json:"ambulanceId" gorm:"not null;index"`
+	Type        DocumentType `json:"type" gorm:"not null"`
+	Number      string       `json:"number"`
+	IssuedAt    *time.Time   `json:"issuedAt"`
+	ExpiresAt   time.Time    `json:"expiresAt" gorm:"not null;index"`
+	File        string       `json:"file"`
+	LastAlertAt *time.Time   `json:"lastAlertAt"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+	DeletedAt   *time.Time   `json:"deletedAt" gorm:"index"`
+
+	Ambulance ambulance.Ambulance `json:"ambulance" gorm:"foreignKey:AmbulanceID"`
+}
+
+type DocumentType string
+
+const (
+	DocumentSTNK      DocumentType = "stnk"
+	DocumentKIR       DocumentType = "kir"
+	DocumentInsurance DocumentType = "insurance"
+)
+
+func (t DocumentType) IsValid() bool {
+	switch t {
+	case DocumentSTNK, DocumentKIR, DocumentInsurance:
+		return true
+	}
+	return false
+}
+
+const (
+	// maintenanceReminderDays and maintenanceReminderKm define how early a schedule is reported as due soon.
+	maintenanceReminderDays = 7
+	maintenanceReminderKm   = 500
+	// documentAlertDays is how early an expiring document is reported.
+	documentAlertDays = 30
+	// documentAlertRepeatDays throttles repeated alert emails for the same document.
+	documentAlertRepeatDays = 7
+)
@@ -0,0 +1,458 @@
+package ambulance_fleet
+
+import (
+	"net/http"
+
+	"github.com/Vilamuzz/yota-backend/app/middleware"
+	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/Vilamuzz/yota-backend/pkg/enum"
+	jwt_pkg "github.com/Vilamuzz/yota-backend/pkg/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	service    Service
+	middleware middleware.AppMiddleware
+}
+
+func NewHandler(r *gin.RouterGroup, s Service, m middleware.AppMiddleware) {
+	h := &handler{
+		service:    s,
+		middleware: m,
+	}
+	h.RegisterRoutes(r)
+}
+
+func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
+	// Drivers log odometer and fuel for their own ambulance; managers for any.
+	crew := r.Group("/admin/ambulances")
+	crew.Use(h.middleware.RequireRoles(enum.RoleAmbulanceManager, enum.RoleAmbulanceDriver))
+	{
+		crew.GET("/:id/odometer", h.ListOdometerReadings)
+		crew.POST("/:id/odometer", h.RecordOdometer)
+		crew.GET("/:id/fuel-logs", h.ListFuelLogs)
+		crew.POST("/:id/fuel-logs", h.CreateFuelLog)
+	}
+
+	ambulanceManager := r.Group("/admin/ambulances")
+	ambulanceManager.Use(h.middleware.RequireRoles(enum.RoleAmbulanceManager))
+	{
+		ambulanceManager.GET("/fleet/alerts", h.GetFleetAlerts)
+		ambulanceManager.DELETE("/:id/fuel-logs/:fuelLogId", h.DeleteFuelLog)
+		ambulanceManager.GET("/:id/maintenance-schedules", h.ListMaintenanceSchedules)
+		ambulanceManager.POST("/:id/maintenance-schedules", h.CreateMaintenanceSchedule)
+		ambulanceManager.PUT("/:id/maintenance-schedules/:scheduleId", h.UpdateMaintenanceSchedule)
+		ambulanceManager.GET("/:id/maintenance-jobs", h.ListMaintenanceJobs)
+		ambulanceManager.POST("/:id/maintenance-jobs", h.OpenMaintenanceJob)
+		ambulanceManager.PATCH("/:id/maintenance-jobs/:jobId/complete", h.CompleteMaintenanceJob)
+		ambulanceManager.PATCH("/:id/maintenance-jobs/:jobId/cancel", h.CancelMaintenanceJob)
+		ambulanceManager.GET("/:id/documents", h.ListVehicleDocuments)
+		ambulanceManager.POST("/:id/documents", h.CreateVehicleDocument)
+		ambulanceManager.PUT("/:id/documents/:documentId", h.UpdateVehicleDocument)
+		ambulanceManager.DELETE("/:id/documents/:documentId", h.DeleteVehicleDocument)
+	}
+}
+
+// ListOdometerReadings godoc
+// @Summary List odometer readings
+// @Description Get the latest odometer readings of an ambulance. Drivers can only access their assigned ambulance
+// @Tags Ambulance Fleet
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Ambulance ID"
+// @Success 200 {object} pkg.Response{data=[]OdometerReadingResponse}
+// @Failure 403 {object} pkg.Response
+// @Failure 404 {object} pkg.Response
+// @Router /admin/ambulances/{id}/odometer [get]
+func (h *handler) ListOdometerReadings(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.ListOdometerReadings(ctx, claims.AccountID, claims.ActiveRole, c.Param("id"))
+	c.JSON(res.Status, res)
+}
+
+// RecordOdometer godoc
+// @Summary Record odometer reading
+// @Description Record the current odometer of an ambulance. Readings cannot go below the latest value
+// @Tags Ambulance Fleet
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Ambulance ID"
+// @Param request body RecordOdometerRequest true "Odometer reading"
+// @Success 201 {object} pkg.Response{data=OdometerReadingResponse}
+// @Failure 400 {object} pkg.Response
+// @Failure 403 {object} pkg.Response
+// @Router /admin/ambulances/{id}/odometer [post]
+func (h *handler) RecordOdometer(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req RecordOdometerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.RecordOdometer(ctx, claims.AccountID, claims.ActiveRole, c.Param("id"), req)
+	c.JSON(res.Status, res)
+}
+
+// ListFuelLogs godoc
+// @Summary List fuel logs
+// @Description Get fuel logs of an ambulance with total liters and cost for the filtered period
+// @Tags Ambulance Fleet
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Ambulance ID"
+// @Param startDate query string false "Start date (YYYY-MM-DD)"
+// @Param endDate query string false "End date (YYYY-MM-DD)"
+// @Param page query int false "Page number"
+// @Param limit query int false "Pagination limit"
+// @Success 200 {object} pkg.Response{data=FuelLogListResponse}
+// @Failure 400 {object} pkg.Response
+// @Router /admin/ambulances/{id}/fuel-logs [get]
+func (h *handler) ListFuelLogs(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var queryParams FuelLogQueryParams
+	if err := c.ShouldBindQuery(&queryParams); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid query parameters", nil, nil))
+		return
+	}
+
+	res := h.service.ListFuelLogs(ctx, claims.AccountID, claims.ActiveRole, c.Param("id"), queryParams)
+	c.JSON(res.Status, res)
+}
+
+// CreateFuelLog godoc
+// @Summary Create fuel log
+// @Description Record a refuel. Managers can also post the cost as an ambulance operational expense
+// @Tags Ambulance Fleet
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Ambulance ID"
+// @Param liters formData number true "Liters"
+// @Param cost formData number true "Cost"
+// @Param filledAt formData string true "Refuel date (YYYY-MM-DD)"
+// @Param odometerKm formData int false "Odometer at refuel"
+// @Param station formData string false "Fuel station"
+// @Param postAsExpense formData bool false "Post as operational expense"
+// @Param receiptImage formData file false "Receipt image"
+// @Success 201 {object} pkg.Response{data=FuelLogResponse}
+// @Failure 400 {object} pkg.Response
+// @Router /admin/ambulances/{id}/fuel-logs [post]
+func (h *handler) CreateFuelLog(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req CreateFuelLogRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.CreateFuelLog(ctx, claims.AccountID, claims.ActiveRole, c.Param("id"), req)
+	c.JSON(res.Status, res)
+}
+
+// DeleteFuelLog godoc
+// @Summary Delete fuel log
+// @Description Delete a fuel log and its operational expense record
+// @Tags Ambulance Fleet
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Ambulance ID"
+// @Param fuelLogId path string true "Fuel log ID"
+// @Success 200 {object} pkg.Response
+// @Failure 404 {object} pkg.Response
+// @Router /admin/ambulances/{id}/fuel-logs/{fuelLogId} [delete]
+func (h *handler) DeleteFuelLog(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.DeleteFuelLog(ctx, claims.AccountID, c.Param("id"), c.Param("fuelLogId"))
+	c.JSON(res.Status, res)
+}
+
+// ListMaintenanceSchedules godoc
+// @Summary List maintenance schedules
+// @Description Get recurring maintenance schedules of an ambulance with their next due date and odometer
+// @Tags Ambulance Fleet
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Ambulance ID"
+// @Success 200 {object} pkg.Response{data=[]MaintenanceScheduleResponse}
+// @Router /admin/ambulances/{id}/maintenance-schedules [get]
+func (h *handler) ListMaintenanceSchedules(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	res := h.service.ListMaintenanceSchedules(ctx, c.Param("id"))
+	c.JSON(res.Status, res)
+}
+
+// CreateMaintenanceSchedule godoc
+// @Summary Create maintenance schedule
+// @Description Create a recurring maintenance item due by kilometers, days, or whichever comes first
+// @Tags Ambulance Fleet
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Ambulance ID"
+// @Param request body MaintenanceScheduleRequest true "Schedule"
+// @Success 201 {object} pkg.Response{data=MaintenanceScheduleResponse}
+// @Failure 400 {object} pkg.Response
+// @Router /admin/ambulances/{id}/maintenance-schedules [post]
+func (h *handler) CreateMaintenanceSchedule(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req MaintenanceScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.CreateMaintenanceSchedule(ctx, c.Param("id"), req)
+	c.JSON(res.Status, res)
+}
+
+// UpdateMaintenanceSchedule godoc
+// @Summary Update maintenance schedule
+// @Description Update intervals, last service or deactivate a maintenance schedule
+// @Tags Ambulance Fleet
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Ambulance ID"
+// @Param scheduleId path string true "Schedule ID"
+// @Param request body MaintenanceScheduleRequest true "Schedule"
+// @Success 200 {object} pkg.Response{data=MaintenanceScheduleResponse}
+// @Failure 400 {object} pkg.Response
+// @Failure 404 {object} pkg.Response
+// @Router /admin/ambulances/{id}/maintenance-schedules/{scheduleId} [put]
+func (h *handler) UpdateMaintenanceSchedule(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req MaintenanceScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.UpdateMaintenanceSchedule(ctx, c.Param("id"), c.Param("scheduleId"), req)
+	c.JSON(res.Status, res)
+}
+
+// ListMaintenanceJobs godoc
+// @Summary List maintenance jobs
+// @Description Get workshop jobs of an ambulance
+// @Tags Ambulance Fleet
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Ambulance ID"
+// @Param status query string false "Filter by status (open, completed, cancelled)"
+// @Param page query int false "Page number"
+// @Param limit query int false "Pagination limit"
+// @Success 200 {object} pkg.Response{data=MaintenanceJobListResponse}
+// @Router /admin/ambulances/{id}/maintenance-jobs [get]
+func (h *handler) ListMaintenanceJobs(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var queryParams MaintenanceJobQueryParams
+	if err := c.ShouldBindQuery(&queryParams); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid query parameters", nil, nil))
+		return
+	}
+
+	res := h.service.ListMaintenanceJobs(ctx, c.Param("id"), queryParams)
+	c.JSON(res.Status, res)
+}
+
+// OpenMaintenanceJob godoc
+// @Summary Open maintenance job
+// @Description Open a workshop job. The ambulance is set to maintenance until every open job is closed
+// @Tags Ambulance Fleet
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Ambulance ID"
+// @Param request body OpenMaintenanceJobRequest true "Job"
+// @Success 201 {object} pkg.Response{data=MaintenanceJobResponse}
+// @Failure 400 {object} pkg.Response
+// @Failure 409 {object} pkg.Response
+// @Router /admin/ambulances/{id}/maintenance-jobs [post]
+func (h *handler) OpenMaintenanceJob(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req OpenMaintenanceJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.OpenMaintenanceJob(ctx, claims.AccountID, c.Param("id"), req)
+	c.JSON(res.Status, res)
+}
+
+// CompleteMaintenanceJob godoc
+// @Summary Complete maintenance job
+// @Description Close a workshop job with its cost, optionally posting it as an operational expense
+// @Tags Ambulance Fleet
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Ambulance ID"
+// @Param jobId path string true "Job ID"
+// @Param cost formData number false "Cost"
+// @Param odometerKm formData int false "Odometer at service"
+// @Param description formData string false "Work done"
+// @Param postAsExpense formData bool false "Post as operational expense"
+// @Param invoiceImage formData file false "Workshop invoice"
+// @Success 200 {object} pkg.Response{data=MaintenanceJobResponse}
+// @Failure 400 {object} pkg.Response
+// @Failure 409 {object} pkg.Response
+// @Router /admin/ambulances/{id}/maintenance-jobs/{jobId}/complete [patch]
+func (h *handler) CompleteMaintenanceJob(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req CompleteMaintenanceJobRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.CompleteMaintenanceJob(ctx, claims.AccountID, c.Param("id"), c.Param("jobId"), req)
+	c.JSON(res.Status, res)
+}
+
+// CancelMaintenanceJob godoc
+// @Summary Cancel maintenance job
+// @Description Cancel an open workshop job
+// @Tags Ambulance Fleet
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Ambulance ID"
+// @Param jobId path string true "Job ID"
+// @Success 200 {object} pkg.Response
+// @Failure 400 {object} pkg.Response
+// @Router /admin/ambulances/{id}/maintenance-jobs/{jobId}/cancel [patch]
+func (h *handler) CancelMaintenanceJob(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.CancelMaintenanceJob(ctx, claims.AccountID, c.Param("id"), c.Param("jobId"))
+	c.JSON(res.Status, res)
+}
+
+// ListVehicleDocuments godoc
+// @Summary List vehicle documents
+// @Description Get STNK, KIR and insurance documents of an ambulance
+// @Tags Ambulance Fleet
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Ambulance ID"
+// @Success 200 {object} pkg.Response{data=[]VehicleDocumentResponse}
+// @Router /admin/ambulances/{id}/documents [get]
+func (h *handler) ListVehicleDocuments(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	res := h.service.ListVehicleDocuments(ctx, c.Param("id"))
+	c.JSON(res.Status, res)
+}
+
+// CreateVehicleDocument godoc
+// @Summary Create vehicle document
+// @Description Register a vehicle document with its expiry date
+// @Tags Ambulance Fleet
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Ambulance ID"
+// @Param type formData string true "Document type (stnk, kir, insurance)"
+// @Param number formData string false "Document number"
+// @Param issuedAt formData string false "Issue date (YYYY-MM-DD)"
+// @Param expiresAt formData string true "Expiry date (YYYY-MM-DD)"
+// @Param file formData file false "Document scan"
+// @Success 201 {object} pkg.Response{data=VehicleDocumentResponse}
+// @Failure 400 {object} pkg.Response
+// @Router /admin/ambulances/{id}/documents [post]
+func (h *handler) CreateVehicleDocument(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req VehicleDocumentRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.CreateVehicleDocument(ctx, c.Param("id"), req)
+	c.JSON(res.Status, res)
+}
+
+// UpdateVehicleDocument godoc
+// @Summary Update vehicle document
+// @Description Update or renew a vehicle document
+// @Tags Ambulance Fleet
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Ambulance ID"
+// @Param documentId path string true "Document ID"
+// @Param type formData string false "Document type (stnk, kir, insurance)"
+// @Param number formData string false "Document number"
+// @Param issuedAt formData string false "Issue date (YYYY-MM-DD)"
+// @Param expiresAt formData string false "Expiry date (YYYY-MM-DD)"
+// @Param file formData file false "Document scan"
+// @Success 200 {object} pkg.Response
+// @Failure 400 {object} pkg.Response
+// @Failure 404 {object} pkg.Response
+// @Router /admin/ambulances/{id}/documents/{documentId} [put]
+func (h *handler) UpdateVehicleDocument(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req VehicleDocumentRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.UpdateVehicleDocument(ctx, c.Param("id"), c.Param("documentId"), req)
+	c.JSON(res.Status, res)
+}
+
+// DeleteVehicleDocument godoc
+// @Summary Delete vehicle document
+// @Tags Ambulance Fleet
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Ambulance ID"
+// @Param documentId path string true "Document ID"
+// @Success 200 {object} pkg.Response
+// @Failure 404 {object} pkg.Response
+// @Router /admin/ambulances/{id}/documents/{documentId} [delete]
+func (h *handler) DeleteVehicleDocument(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	res := h.service.DeleteVehicleDocument(ctx, c.Param("id"), c.Param("documentId"))
+	c.JSON(res.Status, res)
+}
+
+// GetFleetAlerts godoc
+// @Summary Get fleet alerts
+// @Description Get maintenance due soon and vehicle documents expiring soon across all ambulances
+// @Tags Ambulance Fleet
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} pkg.Response{data=FleetAlertsResponse}
+// @Router /admin/ambulances/fleet/alerts [get]
+func (h *handler) GetFleetAlerts(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	res := h.service.GetFleetAlerts(ctx)
+	c.JSON(res.Status, res)
+}
@@ -0,0 +1,468 @@
+package ambulance_fleet
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/account"
+	"github.com/Vilamuzz/yota-backend/app/ambulance"
+	"github.com/Vilamuzz/yota-backend/app/finance_record"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrOdometerDecrease is returned when a reading is lower than the latest one on record.
+	ErrOdometerDecrease = errors.New("odometer reading is lower than the current value")
+	// ErrAmbulanceInUse is returned when a maintenance job is opened while the ambulance is on a trip.
+	ErrAmbulanceInUse = errors.New("ambulance is in use")
+)
+
+type Repository interface {
+	FindAmbulance(ctx context.Context, id string) (*ambulance.Ambulance, error)
+	HasActiveTrip(ctx context.Context, driverID, ambulanceID string) (bool, error)
+	FindAmbulanceManagerEmails(ctx context.Context) ([]string, error)
+
+	CreateOdometerReading(ctx context.Context, reading *OdometerReading) error
+	FindOdometerReadings(ctx context.Context, ambulanceID string, limit int) ([]OdometerReading, error)
+
+	CreateFuelLog(ctx context.Context, fuelLog *FuelLog, reading *OdometerReading, record *finance_record.FinanceRecord) error
+	FindFuelLogs(ctx context.Context, options map[string]interface{}) ([]FuelLog, error)
+	CountFuelLogs(ctx context.Context, options map[string]interface{}) (int64, error)
+	SumFuelCost(ctx context.Context, options map[string]interface{}) (float64, float64, error)
+	FindOneFuelLog(ctx context.Context, options map[string]interface{}) (*FuelLog, error)
+	DeleteFuelLog(ctx context.Context, id string) error
+
+	CreateMaintenanceSchedule(ctx context.Context, schedule *MaintenanceSchedule) error
+	FindMaintenanceSchedules(ctx context.Context, options map[string]interface{}) ([]MaintenanceSchedule, error)
+	FindOneMaintenanceSchedule(ctx context.Context, options map[string]interface{}) (*MaintenanceSchedule, error)
+	UpdateMaintenanceSchedule(ctx context.Context, id string, updateData map[string]interface{}) error
+
+	OpenMaintenanceJob(ctx context.Context, job *MaintenanceJob) error
+	CloseMaintenanceJob(ctx context.Context, job *MaintenanceJob, updateData map[string]interface{}, schedule *MaintenanceSchedule, reading *OdometerReading, record *finance_record.FinanceRecord) error
+	FindMaintenanceJobs(ctx context.Context, options map[string]interface{}) ([]MaintenanceJob, error)
+	CountMaintenanceJobs(ctx context.Context, options map[string]interface{}) (int64, error)
+	FindOneMaintenanceJob(ctx context.Context, options map[string]interface{}) (*MaintenanceJob, error)
+
+	CreateVehicleDocument(ctx context.Context, document *VehicleDocument) error
+	FindVehicleDocuments(ctx context.Context, options map[string]interface{}) ([]VehicleDocument, error)
+	FindOneVehicleDocument(ctx context.Context, options map[string]interface{}) (*VehicleDocument, error)
+	UpdateVehicleDocument(ctx context.Context, id string, updateData map[string]interface{}) error
+	DeleteVehicleDocument(ctx context.Context, id string) error
+
+	FindDueMaintenanceSchedules(ctx context.Context, dueBefore time.Time, kmMargin int64) ([]MaintenanceSchedule, error)
+	MarkSchedulesReminded(ctx context.Context, ids []string, at time.Time) error
+	MarkDocumentsAlerted(ctx context.Context, ids []string, at time.Time) error
+}
+
+type repository struct {
+	Conn *gorm.DB
+}
+
+func NewRepository(conn *gorm.DB) Repository {
+	return &repository{Conn: conn}
+}
+
+func (r *repository) FindAmbulance(ctx context.Context, id string) (*ambulance.Ambulance, error) {
+	var amb ambulance.Ambulance
+	if err := r.Conn.WithContext(ctx).Where("id = ? AND deleted_at IS NULL", id).First(&amb).Error; err != nil {
+		return nil, err
+	}
+	return &amb, nil
+}
+
+// HasActiveTrip reports whether the driver is assigned to an accepted or in-service trip on the ambulance.
+func (r *repository) HasActiveTrip(ctx context.Context, driverID, ambulanceID string) (bool, error) {
+	var count int64
+	err := r.Conn.WithContext(ctx).Table("ambulance_service_requests").
+		Where("driver_id = ? AND ambulance_id = ?", driverID, ambulanceID).
+		Where("status IN ?", []string{"accepted", "in_service"}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repository) FindAmbulanceManagerEmails(ctx context.Context) ([]string, error) {
+	var emails []string
+	err := r.Conn.WithContext(ctx).
+		Table("accounts").
+		Joins("JOIN account_roles ON account_roles.account_id = accounts.id").
+		Where("account_roles.role_id = ? AND account_roles.is_active = ?", account.AmbulanceManagerRoleID, true).
+		Where("accounts.is_banned = ? AND accounts.deleted_at IS NULL", false).
+		Pluck("accounts.email", &emails).Error
+	return emails, err
+}
+
+// recordOdometer stores a reading and moves the ambulance odometer forward. The ambulance
+// row is locked so concurrent readings cannot go backwards.
+func recordOdometer(tx *gorm.DB, reading *OdometerReading) error {
+	var amb ambulance.Ambulance
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND deleted_at IS NULL", reading.AmbulanceID).
+		First(&amb).Error; err != nil {
+		return err
+	}
+	if reading.ReadingKm < amb.OdometerKm {
+		return ErrOdometerDecrease
+	}
+	if err := tx.Create(reading).Error; err != nil {
+		return err
+	}
+	return tx.Model(&ambulance.Ambulance{}).
+		Where("id = ?", reading.AmbulanceID).
+		Updates(map[string]interface{}{"odometer_km": reading.ReadingKm, "updated_at": time.Now()}).Error
+}
+
+func (r *repository) CreateOdometerReading(ctx context.Context, reading *OdometerReading) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return recordOdometer(tx, reading)
+	})
+}
+
+func (r *repository) FindOdometerReadings(ctx context.Context, ambulanceID string, limit int) ([]OdometerReading, error) {
+	var readings []OdometerReading
+	err := r.Conn.WithContext(ctx).
+		Where("ambulance_id = ?", ambulanceID).
+		Order("recorded_at DESC").
+		Limit(limit).
+		Find(&readings).Error
+	return readings, err
+}
+
+func (r *repository) CreateFuelLog(ctx context.Context, fuelLog *FuelLog, reading *OdometerReading, record *finance_record.FinanceRecord) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if reading != nil {
+			if err := recordOdometer(tx, reading); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(fuelLog).Error; err != nil {
+			return err
+		}
+		if record != nil {
+			if err := tx.Create(record).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func applyFuelLogFilters(query *gorm.DB, options map[string]interface{}) *gorm.DB {
+	query = query.Where("fuel_logs.deleted_at IS NULL")
+	if ambulanceID, ok := options["ambulance_id"]; ok && ambulanceID != "" {
+		query = query.Where("fuel_logs.ambulance_id = ?", ambulanceID)
+	}
+	if from, ok := options["from"]; ok {
+		query = query.Where("fuel_logs.filled_at >= ?", from)
+	}
+	if to, ok := options["to"]; ok {
+		query = query.Where("fuel_logs.filled_at < ?", to)
+	}
+	return query
+}
+
+func (r *repository) FindFuelLogs(ctx context.Context, options map[string]interface{}) ([]FuelLog, error) {
+	var fuelLogs []FuelLog
+	query := applyFuelLogFilters(r.Conn.WithContext(ctx).Model(&FuelLog{}), options).
+		Order("fuel_logs.filled_at DESC, fuel_logs.id DESC")
+
+	limit := 10
+	if l, ok := options["limit"]; ok {
+		limit = l.(int)
+	}
+	page := 1
+	if p, ok := options["page"]; ok {
+		page = p.(int)
+	}
+
+	if err := query.Offset((page - 1) * limit).Limit(limit).Find(&fuelLogs).Error; err != nil {
+		return nil, err
+	}
+	return fuelLogs, nil
+}
+
+func (r *repository) CountFuelLogs(ctx context.Context, options map[string]interface{}) (int64, error) {
+	var count int64
+	err := applyFuelLogFilters(r.Conn.WithContext(ctx).Model(&FuelLog{}), options).Count(&count).Error
+	return count, err
+}
+
+// SumFuelCost returns the total liters and cost for the filtered fuel logs.
+func (r *repository) SumFuelCost(ctx context.Context, options map[string]interface{}) (float64, float64, error) {
+	var result struct {
+		Liters float64
+		Cost   float64
+	}
+	err := applyFuelLogFilters(r.Conn.WithContext(ctx).Model(&FuelLog{}), options).
+		Select("COALESCE(SUM(fuel_logs.liters), 0) AS liters, COALESCE(SUM(fuel_logs.cost), 0) AS cost").
+		Scan(&result).Error
+	return result.Liters, result.Cost, err
+}
+
+func (r *repository) FindOneFuelLog(ctx context.Context, options map[string]interface{}) (*FuelLog, error) {
+	var fuelLog FuelLog
+	query := r.Conn.WithContext(ctx).Where("deleted_at IS NULL")
+	if id, ok := options["id"]; ok && id != "" {
+		query = query.Where("id = ?", id)
+	}
+	if ambulanceID, ok := options["ambulance_id"]; ok && ambulanceID != "" {
+		query = query.Where("ambulance_id = ?", ambulanceID)
+	}
+	if err := query.First(&fuelLog).Error; err != nil {
+		return nil, err
+	}
+	return &fuelLog, nil
+}
+
+func (r *repository) DeleteFuelLog(ctx context.Context, id string) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Model(&FuelLog{}).Where("id = ?", id).Update("deleted_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Table("finance_records").Where("source_id = ? AND source_type = ?", id, finance_record.SourceTypeExpense).Update("deleted_at", now).Error; err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *repository) CreateMaintenanceSchedule(ctx context.Context, schedule *MaintenanceSchedule) error {
+	return r.Conn.WithContext(ctx).Create(schedule).Error
+}
+
+func (r *repository) FindMaintenanceSchedules(ctx context.Context, options map[string]interface{}) ([]MaintenanceSchedule, error) {
+	var schedules []MaintenanceSchedule
+	query := r.Conn.WithContext(ctx)
+	if ambulanceID, ok := options["ambulance_id"]; ok && ambulanceID != "" {
+		query = query.Where("ambulance_id = ?", ambulanceID)
+	}
+	if isActive, ok := options["is_active"]; ok {
+		query = query.Where("is_active = ?", isActive)
+	}
+	if err := query.Order("next_due_at ASC NULLS LAST, created_at ASC").Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (r *repository) FindOneMaintenanceSchedule(ctx context.Context, options map[string]interface{}) (*MaintenanceSchedule, error) {
+	var schedule MaintenanceSchedule
+	query := r.Conn.WithContext(ctx)
+	if id, ok := options["id"]; ok && id != "" {
+		query = query.Where("id = ?", id)
+	}
+	if ambulanceID, ok := options["ambulance_id"]; ok && ambulanceID != "" {
+		query = query.Where("ambulance_id = ?", ambulanceID)
+	}
+	if err := query.First(&schedule).Error; err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (r *repository) UpdateMaintenanceSchedule(ctx context.Context, id string, updateData map[string]interface{}) error {
+	return r.Conn.WithContext(ctx).Model(&MaintenanceSchedule{}).Where("id = ?", id).Updates(updateData).Error
+}
+
+// OpenMaintenanceJob creates the job and moves the ambulance into maintenance status.
+func (r *repository) OpenMaintenanceJob(ctx context.Context, job *MaintenanceJob) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var amb ambulance.Ambulance
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NULL", job.AmbulanceID).
+			First(&amb).Error; err != nil {
+			return err
+		}
+		if amb.Status == ambulance.AmbulanceStatusInUse {
+			return ErrAmbulanceInUse
+		}
+		if err := tx.Create(job).Error; err != nil {
+			return err
+		}
+		return tx.Model(&ambulance.Ambulance{}).
+			Where("id = ?", job.AmbulanceID).
+			Updates(map[string]interface{}{"status": ambulance.AmbulanceStatusMaintenance, "updated_at": time.Now()}).Error
+	})
+}
+
+// CloseMaintenanceJob completes or cancels an open job. The ambulance returns to available
+// once no other job for it is still open.
+func (r *repository) CloseMaintenanceJob(ctx context.Context, job *MaintenanceJob, updateData map[string]interface{}, schedule *MaintenanceSchedule, reading *OdometerReading, record *finance_record.FinanceRecord) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&MaintenanceJob{}).
+			Where("id = ? AND status = ?", job.ID, MaintenanceJobOpen).
+			Updates(updateData)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if reading != nil {
+			if err := recordOdometer(tx, reading); err != nil {
+				return err
+			}
+		}
+		if schedule != nil {
+			if err := tx.Model(&MaintenanceSchedule{}).Where("id = ?", schedule.ID).Updates(map[string]interface{}{
+				"last_service_km":  schedule.LastServiceKm,
+				"last_service_at":  schedule.LastServiceAt,
+				"next_due_km":      schedule.NextDueKm,
+				"next_due_at":      schedule.NextDueAt,
+				"reminder_sent_at": nil,
+				"updated_at":       time.Now(),
+			}).Error; err != nil {
+				return err
+			}
+		}
+		if record != nil {
+			if err := tx.Create(record).Error; err != nil {
+				return err
+			}
+		}
+
+		var openJobs int64
+		if err := tx.Model(&MaintenanceJob{}).
+			Where("ambulance_id = ? AND status = ?", job.AmbulanceID, MaintenanceJobOpen).
+			Count(&openJobs).Error; err != nil {
+			return err
+		}
+		if openJobs > 0 {
+			return nil
+		}
+		return tx.Model(&ambulance.Ambulance{}).
+			Where("id = ? AND status = ?", job.AmbulanceID, ambulance.AmbulanceStatusMaintenance).
+			Updates(map[string]interface{}{"status": ambulance.AmbulanceStatusAvailable, "updated_at": time.Now()}).Error
+	})
+}
+
+func applyMaintenanceJobFilters(query *gorm.DB, options map[string]interface{}) *gorm.DB {
+	if ambulanceID, ok := options["ambulance_id"]; ok && ambulanceID != "" {
+		query = query.Where("ambulance_id = ?", ambulanceID)
+	}
+	if status, ok := options["status"]; ok && status != "" {
+		query = query.Where("status = ?", status)
+	}
+	return query
+}
+
+func (r *repository) FindMaintenanceJobs(ctx context.Context, options map[string]interface{}) ([]MaintenanceJob, error) {
+	var jobs []MaintenanceJob
+	query := applyMaintenanceJobFilters(r.Conn.WithContext(ctx).Preload("Schedule"), options).
+		Order("opened_at DESC, id DESC")
+
+	limit := 10
+	if l, ok := options["limit"]; ok {
+		limit = l.(int)
+	}
+	page := 1
+	if p, ok := options["page"]; ok {
+		page = p.(int)
+	}
+
+	if err := query.Offset((page - 1) * limit).Limit(limit).Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (r *repository) CountMaintenanceJobs(ctx context.Context, options map[string]interface{}) (int64, error) {
+	var count int64
+	err := applyMaintenanceJobFilters(r.Conn.WithContext(ctx).Model(&MaintenanceJob{}), options).Count(&count).Error
+	return count, err
+}
+
+func (r *repository) FindOneMaintenanceJob(ctx context.Context, options map[string]interface{}) (*MaintenanceJob, error) {
+	var job MaintenanceJob
+	query := r.Conn.WithContext(ctx).Preload("Schedule")
+	if id, ok := options["id"]; ok && id != "" {
+		query = query.Where("id = ?", id)
+	}
+	if ambulanceID, ok := options["ambulance_id"]; ok && ambulanceID != "" {
+		query = query.Where("ambulance_id = ?", ambulanceID)
+	}
+	if err := query.First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *repository) CreateVehicleDocument(ctx context.Context, document *VehicleDocument) error {
+	return r.Conn.WithContext(ctx).Create(document).Error
+}
+
+func (r *repository) FindVehicleDocuments(ctx context.Context, options map[string]interface{}) ([]VehicleDocument, error) {
+	var documents []VehicleDocument
+	query := r.Conn.WithContext(ctx).
+		Preload("Ambulance").
+		Where("vehicle_documents.deleted_at IS NULL")
+	if ambulanceID, ok := options["ambulance_id"]; ok && ambulanceID != "" {
+		query = query.Where("vehicle_documents.ambulance_id = ?", ambulanceID)
+	}
+	if docType, ok := options["type"]; ok && docType != "" {
+		query = query.Where("vehicle_documents.type = ?", docType)
+	}
+	if expiresBefore, ok := options["expires_before"]; ok {
+		query = query.
+			Joins("JOIN ambulances ON ambulances.id = vehicle_documents.ambulance_id AND ambulances.deleted_at IS NULL").
+			Where("vehicle_documents.expires_at < ?", expiresBefore)
+	}
+	if err := query.Order("vehicle_documents.expires_at ASC").Find(&documents).Error; err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+func (r *repository) FindOneVehicleDocument(ctx context.Context, options map[string]interface{}) (*VehicleDocument, error) {
+	var document VehicleDocument
+	query := r.Conn.WithContext(ctx).Where("deleted_at IS NULL")
+	if id, ok := options["id"]; ok && id != "" {
+		query = query.Where("id = ?", id)
+	}
+	if ambulanceID, ok := options["ambulance_id"]; ok && ambulanceID != "" {
+		query = query.Where("ambulance_id = ?", ambulanceID)
+	}
+	if err := query.First(&document).Error; err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+func (r *repository) UpdateVehicleDocument(ctx context.Context, id string, updateData map[string]interface{}) error {
+	return r.Conn.WithContext(ctx).Model(&VehicleDocument{}).Where("id = ?", id).Updates(updateData).Error
+}
+
+func (r *repository) DeleteVehicleDocument(ctx context.Context, id string) error {
+	return r.Conn.WithContext(ctx).Model(&VehicleDocument{}).Where("id = ?", id).Update("deleted_at", time.Now()).Error
+}
+
+// FindDueMaintenanceSchedules returns active schedules whose next due date is before dueBefore
+// or whose next due odometer is within kmMargin of the ambulance's current reading.
+func (r *repository) FindDueMaintenanceSchedules(ctx context.Context, dueBefore time.Time, kmMargin int64) ([]MaintenanceSchedule, error) {
+	var schedules []MaintenanceSchedule
+	err := r.Conn.WithContext(ctx).
+		Preload("Ambulance").
+		Joins("JOIN ambulances ON ambulances.id = maintenance_schedules.ambulance_id AND ambulances.deleted_at IS NULL").
+		Where("maintenance_schedules.is_active = ?", true).
+		Where("maintenance_schedules.next_due_at < ? OR maintenance_schedules.next_due_km <= ambulances.odometer_km + ?", dueBefore, kmMargin).
+		Order("maintenance_schedules.next_due_at ASC NULLS LAST").
+		Find(&schedules).Error
+	return schedules, err
+}
+
+func (r *repository) MarkSchedulesReminded(ctx context.Context, ids []string, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.Conn.WithContext(ctx).Model(&MaintenanceSchedule{}).Where("id IN ?", ids).Update("reminder_sent_at", at).Error
+}
+
+func (r *repository) MarkDocumentsAlerted(ctx context.Context, ids []string, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.Conn.WithContext(ctx).Model(&VehicleDocument{}).Where("id IN ?", ids).Update("last_alert_at", at).Error
+}
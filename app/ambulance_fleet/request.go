@@ -0,0 +1,66 @@
+package ambulance_fleet
+
+import (
+	"mime/multipart"
+	"time"
+)
+
+type RecordOdometerRequest struct {
+	ReadingKm int64  `json:"readingKm"`
+	Note      string `json:"note"`
+}
+
+type CreateFuelLogRequest struct {
+	Liters        float64               `form:"liters"`
+	Cost          float64               `form:"cost"`
+	OdometerKm    *int64                `form:"odometerKm"`
+	Station       string                `form:"station"`
+	FilledAt      time.Time             `form:"filledAt" time_format:"2006-01-02"`
+	PostAsExpense bool                  `form:"postAsExpense"`
+	ReceiptImage  *multipart.FileHeader `form:"receiptImage" swaggerignore:"true"`
+}
+
+type FuelLogQueryParams struct {
+	StartDate string `form:"startDate"` // optional, format: YYYY-MM-DD
+	EndDate   string `form:"endDate"`   // optional, format: YYYY-MM-DD
+	Page      int    `form:"page"`
+	Limit     int    `form:"limit"`
+}
+
+type MaintenanceScheduleRequest struct {
+	Name          string `json:"name"`
+	IntervalKm    *int64 `json:"intervalKm"`
+	IntervalDays  *int   `json:"intervalDays"`
+	LastServiceKm *int64 `json:"lastServiceKm"`
+	LastServiceAt string `json:"lastServiceAt"` // optional, format: YYYY-MM-DD
+	IsActive      *bool  `json:"isActive"`
+}
+
+type OpenMaintenanceJobRequest struct {
+	ScheduleID  string `json:"scheduleId"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Workshop    string `json:"workshop"`
+}
+
+type CompleteMaintenanceJobRequest struct {
+	OdometerKm    *int64                `form:"odometerKm"`
+	Cost          float64               `form:"cost"`
+	Description   string                `form:"description"`
+	PostAsExpense bool                  `form:"postAsExpense"`
+	InvoiceImage  *multipart.FileHeader `form:"invoiceImage" swaggerignore:"true"`
+}
+
+type MaintenanceJobQueryParams struct {
+	Status string `form:"status"`
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
+}
+
+type VehicleDocumentRequest struct {
+	Type      DocumentType          `form:"type"`
+	Number    string                `form:"number"`
+	IssuedAt  time.Time             `form:"issuedAt" time_format:"2006-01-02"`
+	ExpiresAt time.Time             `form:"expiresAt" time_format:"2006-01-02"`
+	File      *multipart.FileHeader `form:"file" swaggerignore:"true"`
+}
@@ -0,0 +1,240 @@
+package ambulance_fleet
+
+import (
+	"math"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/pkg"
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
+)
+
+type OdometerReadingResponse struct {
+	ID         string `json:"id"`
+	ReadingKm  int64  `json:"readingKm"`
+	Source     string `json:"source"`
+	Note       string `json:"note"`
+	RecordedAt string `json:"recordedAt"`
+}
+
+type FuelLogResponse struct {
+	ID              string  `json:"id"`
+	AmbulanceID     string  `json:"ambulanceId"`
+	Liters          float64 `json:"liters"`
+	Cost            float64 `json:"cost"`
+	OdometerKm      *int64  `json:"odometerKm"`
+	Station         string  `json:"station"`
+	ReceiptImage    string  `json:"receiptImage"`
+	PostedAsExpense bool    `json:"postedAsExpense"`
+	FilledAt        string  `json:"filledAt"`
+	CreatedAt       string  `json:"createdAt"`
+}
+
+type FuelLogListResponse struct {
+	FuelLogs    []FuelLogResponse    `json:"fuelLogs"`
+	TotalLiters float64              `json:"totalLiters"`
+	TotalCost   float64              `json:"totalCost"`
+	Pagination  pkg.OffsetPagination `json:"pagination"`
+}
+
+type MaintenanceScheduleResponse struct {
+	ID            string  `json:"id"`
+	AmbulanceID   string  `json:"ambulanceId"`
+	Name          string  `json:"name"`
+	IntervalKm    *int64  `json:"intervalKm"`
+	IntervalDays  *int    `json:"intervalDays"`
+	LastServiceKm *int64  `json:"lastServiceKm"`
+	LastServiceAt *string `json:"lastServiceAt"`
+	NextDueKm     *int64  `json:"nextDueKm"`
+	NextDueAt     *string `json:"nextDueAt"`
+	IsActive      bool    `json:"isActive"`
+	IsDue         bool    `json:"isDue"`
+}
+
+type MaintenanceJobResponse struct {
+	ID              string  `json:"id"`
+	AmbulanceID     string  `json:"ambulanceId"`
+	ScheduleID      *string `json:"scheduleId"`
+	ScheduleName    string  `json:"scheduleName"`
+	Title           string  `json:"title"`
+	Description     string  `json:"description"`
+	Workshop        string  `json:"workshop"`
+	Status          string  `json:"status"`
+	OdometerKm      *int64  `json:"odometerKm"`
+	Cost            float64 `json:"cost"`
+	InvoiceImage    string  `json:"invoiceImage"`
+	PostedAsExpense bool    `json:"postedAsExpense"`
+	OpenedAt        string  `json:"openedAt"`
+	ClosedAt        *string `json:"closedAt"`
+}
+
+type MaintenanceJobListResponse struct {
+	Jobs       []MaintenanceJobResponse `json:"jobs"`
+	Pagination pkg.OffsetPagination     `json:"pagination"`
+}
+
+type VehicleDocumentResponse struct {
+	ID              string       `json:"id"`
+	AmbulanceID     string       `json:"ambulanceId"`
+	PlateNumber     string       `json:"plateNumber"`
+	Type            DocumentType `json:"type"`
+	Number          string       `json:"number"`
+	IssuedAt        *string      `json:"issuedAt"`
+	ExpiresAt       string       `json:"expiresAt"`
+	File            string       `json:"file"`
+	DaysUntilExpiry int          `json:"daysUntilExpiry"`
+	IsExpired       bool         `json:"isExpired"`
+}
+
+type MaintenanceAlertResponse struct {
+	MaintenanceScheduleResponse
+	PlateNumber       string `json:"plateNumber"`
+	CurrentOdometerKm int64  `json:"currentOdometerKm"`
+}
+
+type FleetAlertsResponse struct {
+	DueMaintenance    []MaintenanceAlertResponse `json:"dueMaintenance"`
+	ExpiringDocuments []VehicleDocumentResponse  `json:"expiringDocuments"`
+}
+
+func formatDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format("2006-01-02")
+	return &formatted
+}
+
+func (o *OdometerReading) toOdometerReadingResponse() OdometerReadingResponse {
+	return OdometerReadingResponse{
+		ID:         o.ID.String(),
+		ReadingKm:  o.ReadingKm,
+		Source:     o.Source,
+		Note:       o.Note,
+		RecordedAt: o.RecordedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func toOdometerReadingListResponse(readings []OdometerReading) []OdometerReadingResponse {
+	responses := make([]OdometerReadingResponse, 0, len(readings))
+	for _, reading := range readings {
+		responses = append(responses, reading.toOdometerReadingResponse())
+	}
+	return responses
+}
+
+func (f *FuelLog) toFuelLogResponse() FuelLogResponse {
+	return FuelLogResponse{
+		ID:              f.ID.String(),
+		AmbulanceID:     f.AmbulanceID.String(),
+		Liters:          f.Liters,
+		Cost:            f.Cost,
+		OdometerKm:      f.OdometerKm,
+		Station:         f.Station,
+		ReceiptImage:    s3_pkg.GetCDNURL(f.ReceiptImage),
+		PostedAsExpense: f.PostedAsExpense,
+		FilledAt:        f.FilledAt.Format("2006-01-02"),
+		CreatedAt:       f.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func toFuelLogListResponse(fuelLogs []FuelLog, totalLiters, totalCost float64, pagination pkg.OffsetPagination) FuelLogListResponse {
+	responses := make([]FuelLogResponse, 0, len(fuelLogs))
+	for _, fuelLog := range fuelLogs {
+		responses = append(responses, fuelLog.toFuelLogResponse())
+	}
+	return FuelLogListResponse{
+		FuelLogs:    responses,
+		TotalLiters: totalLiters,
+		TotalCost:   totalCost,
+		Pagination:  pagination,
+	}
+}
+
+// isDue reports whether the schedule falls inside the reminder window for the given odometer.
+func (m *MaintenanceSchedule) isDue(odometerKm int64, now time.Time) bool {
+	if !m.IsActive {
+		return false
+	}
+	if m.NextDueAt != nil && m.NextDueAt.Before(now.AddDate(0, 0, maintenanceReminderDays)) {
+		return true
+	}
+	return m.NextDueKm != nil && *m.NextDueKm <= odometerKm+maintenanceReminderKm
+}
+
+func (m *MaintenanceSchedule) toMaintenanceScheduleResponse(odometerKm int64, now time.Time) MaintenanceScheduleResponse {
+	return MaintenanceScheduleResponse{
+		ID:            m.ID.String(),
+		AmbulanceID:   m.AmbulanceID.String(),
+		Name:          m.Name,
+		IntervalKm:    m.IntervalKm,
+		IntervalDays:  m.IntervalDays,
+		LastServiceKm: m.LastServiceKm,
+		LastServiceAt: formatDate(m.LastServiceAt),
+		NextDueKm:     m.NextDueKm,
+		NextDueAt:     formatDate(m.NextDueAt),
+		IsActive:      m.IsActive,
+		IsDue:         m.isDue(odometerKm, now),
+	}
+}
+
+func (j *MaintenanceJob) toMaintenanceJobResponse() MaintenanceJobResponse {
+	response := MaintenanceJobResponse{
+		ID:              j.ID.String(),
+		AmbulanceID:     j.AmbulanceID.String(),
+		Title:           j.Title,
+		Description:     j.Description,
+		Workshop:        j.Workshop,
+		Status:          string(j.Status),
+		OdometerKm:      j.OdometerKm,
+		Cost:            j.Cost,
+		InvoiceImage:    s3_pkg.GetCDNURL(j.InvoiceImage),
+		PostedAsExpense: j.PostedAsExpense,
+		OpenedAt:        j.OpenedAt.Format("2006-01-02 15:04:05"),
+	}
+	if j.ScheduleID != nil {
+		scheduleID := j.ScheduleID.String()
+		response.ScheduleID = &scheduleID
+	}
+	if j.Schedule != nil {
+		response.ScheduleName = j.Schedule.Name
+	}
+	if j.ClosedAt != nil {
+		closedAt := j.ClosedAt.Format("2006-01-02 15:04:05")
+		response.ClosedAt = &closedAt
+	}
+	return response
+}
+
+func toMaintenanceJobListResponse(jobs []MaintenanceJob, pagination pkg.OffsetPagination) MaintenanceJobListResponse {
+	responses := make([]MaintenanceJobResponse, 0, len(jobs))
+	for _, job := range jobs {
+		responses = append(responses, job.toMaintenanceJobResponse())
+	}
+	return MaintenanceJobListResponse{
+		Jobs:       responses,
+		Pagination: pagination,
+	}
+}
+
+func (d *VehicleDocument) toVehicleDocumentResponse(now time.Time) VehicleDocumentResponse {
+	return VehicleDocumentResponse{
+		ID:              d.ID.String(),
+		AmbulanceID:     d.AmbulanceID.String(),
+		PlateNumber:     d.Ambulance.PlateNumber,
+		Type:            d.Type,
+		Number:          d.Number,
+		IssuedAt:        formatDate(d.IssuedAt),
+		ExpiresAt:       d.ExpiresAt.Format("2006-01-02"),
+		File:            s3_pkg.GetCDNURL(d.File),
+		DaysUntilExpiry: int(math.Floor(d.ExpiresAt.Sub(now).Hours() / 24)),
+		IsExpired:       !d.ExpiresAt.After(now),
+	}
+}
+
+func toVehicleDocumentListResponse(documents []VehicleDocument, now time.Time) []VehicleDocumentResponse {
+	responses := make([]VehicleDocumentResponse, 0, len(documents))
+	for _, document := range documents {
+		responses = append(responses, document.toVehicleDocumentResponse(now))
+	}
+	return responses
+}
@@ -0,0 +1,1093 @@
+package ambulance_fleet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/ambulance"
+	"github.com/Vilamuzz/yota-backend/app/finance_record"
+	app_log "github.com/Vilamuzz/yota-backend/app/log"
+	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/Vilamuzz/yota-backend/pkg/enum"
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	ListOdometerReadings(ctx context.Context, accountID string, role enum.RoleName, ambulanceID string) pkg.Response
+	RecordOdometer(ctx context.Context, accountID string, role enum.RoleName, ambulanceID string, payload RecordOdometerRequest) pkg.Response
+	ListFuelLogs(ctx context.Context, accountID string, role enum.RoleName, ambulanceID string, params FuelLogQueryParams) pkg.Response
+	CreateFuelLog(ctx context.Context, accountID string, role enum.RoleName, ambulanceID string, payload CreateFuelLogRequest) pkg.Response
+	DeleteFuelLog(ctx context.Context, accountID string, ambulanceID, fuelLogID string) pkg.Response
+	ListMaintenanceSchedules(ctx context.Context, ambulanceID string) pkg.Response
+	CreateMaintenanceSchedule(ctx context.Context, ambulanceID string, payload MaintenanceScheduleRequest) pkg.Response
+	UpdateMaintenanceSchedule(ctx context.Context, ambulanceID, scheduleID string, payload MaintenanceScheduleRequest) pkg.Response
+	ListMaintenanceJobs(ctx context.Context, ambulanceID string, params MaintenanceJobQueryParams) pkg.Response
+	OpenMaintenanceJob(ctx context.Context, accountID string, ambulanceID string, payload OpenMaintenanceJobRequest) pkg.Response
+	CompleteMaintenanceJob(ctx context.Context, accountID string, ambulanceID, jobID string, payload CompleteMaintenanceJobRequest) pkg.Response
+	CancelMaintenanceJob(ctx context.Context, accountID string, ambulanceID, jobID string) pkg.Response
+	ListVehicleDocuments(ctx context.Context, ambulanceID string) pkg.Response
+	CreateVehicleDocument(ctx context.Context, ambulanceID string, payload VehicleDocumentRequest) pkg.Response
+	UpdateVehicleDocument(ctx context.Context, ambulanceID, documentID string, payload VehicleDocumentRequest) pkg.Response
+	DeleteVehicleDocument(ctx context.Context, ambulanceID, documentID string) pkg.Response
+	GetFleetAlerts(ctx context.Context) pkg.Response
+	SendFleetReminders(ctx context.Context) error
+}
+
+type service struct {
+	repo         Repository
+	logService   app_log.Service
+	s3Client     s3_pkg.Client
+	emailService *pkg.EmailService
+	timeout      time.Duration
+}
+
+func NewService(repo Repository, s3Client s3_pkg.Client, logService app_log.Service, timeout time.Duration) Service {
+	return &service{
+		repo:         repo,
+		logService:   logService,
+		s3Client:     s3Client,
+		emailService: pkg.NewEmailService(),
+		timeout:      timeout,
+	}
+}
+
+// findAmbulance loads the ambulance and, for drivers, checks that it is their default vehicle or
+// that they are driving it on a current trip.
+// A nil ambulance means res holds the error response.
+func (s *service) findAmbulance(ctx context.Context, accountID string, role enum.RoleName, ambulanceID string) (*ambulance.Ambulance, pkg.Response) {
+	if err := uuid.Validate(ambulanceID); err != nil {
+		return nil, pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID ambulans tidak valid"}, nil)
+	}
+
+	amb, err := s.repo.FindAmbulance(ctx, ambulanceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, pkg.NewResponse(http.StatusNotFound, "Ambulans tidak ditemukan", nil, nil)
+		}
+		return nil, pkg.NewResponse(http.StatusInternalServerError, "Gagal menemukan data ambulans", nil, nil)
+	}
+
+	if role == enum.RoleAmbulanceDriver && amb.DriverID.String() != accountID {
+		onTrip, err := s.repo.HasActiveTrip(ctx, accountID, ambulanceID)
+		if err != nil {
+			return nil, pkg.NewResponse(http.StatusInternalServerError, "Gagal memverifikasi penugasan supir", nil, nil)
+		}
+		if !onTrip {
+			return nil, pkg.NewResponse(http.StatusForbidden, "Ambulans ini tidak ditugaskan ke supir ini", nil, nil)
+		}
+	}
+	return amb, pkg.Response{}
+}
+
+func normalizePage(page, limit int) (int, int) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if page <= 0 {
+		page = 1
+	}
+	return page, limit
+}
+
+func offsetPagination(page, limit int, total int64) pkg.OffsetPagination {
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	return pkg.OffsetPagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
+
+func (s *service) ListOdometerReadings(ctx context.Context, accountID string, role enum.RoleName, ambulanceID string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	amb, res := s.findAmbulance(ctx, accountID, role, ambulanceID)
+	if amb == nil {
+		return res
+	}
+
+	readings, err := s.repo.FindOdometerReadings(ctx, ambulanceID, 50)
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat riwayat odometer", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, toOdometerReadingListResponse(readings))
+}
+
+func (s *service) RecordOdometer(ctx context.Context, accountID string, role enum.RoleName, ambulanceID string, payload RecordOdometerRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	amb, res := s.findAmbulance(ctx, accountID, role, ambulanceID)
+	if amb == nil {
+		return res
+	}
+	if payload.ReadingKm <= 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"readingKm": "Angka odometer harus lebih besar dari 0"}, nil)
+	}
+
+	now := time.Now()
+	reading := &OdometerReading{
+		ID:          uuid.New(),
+		AmbulanceID: amb.ID,
+		ReadingKm:   payload.ReadingKm,
+		Source:      OdometerSourceManual,
+		Note:        payload.Note,
+		RecordedBy:  uuid.MustParse(accountID),
+		RecordedAt:  now,
+		CreatedAt:   now,
+	}
+
+	if err := s.repo.CreateOdometerReading(ctx, reading); err != nil {
+		if errors.Is(err, ErrOdometerDecrease) {
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"readingKm": fmt.Sprintf("Angka odometer tidak boleh lebih kecil dari %d km", amb.OdometerKm)}, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":    "ambulance_fleet.service",
+			"ambulance_id": ambulanceID,
+		}).WithError(err).Error("failed to record odometer")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menyimpan odometer", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusCreated, "Odometer berhasil dicatat", nil, reading.toOdometerReadingResponse())
+}
+
+func (s *service) ListFuelLogs(ctx context.Context, accountID string, role enum.RoleName, ambulanceID string, params FuelLogQueryParams) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	amb, res := s.findAmbulance(ctx, accountID, role, ambulanceID)
+	if amb == nil {
+		return res
+	}
+
+	params.Page, params.Limit = normalizePage(params.Page, params.Limit)
+	options := map[string]interface{}{
+		"ambulance_id": ambulanceID,
+		"page":         params.Page,
+		"limit":        params.Limit,
+	}
+
+	errValidation := make(map[string]string)
+	if params.StartDate != "" {
+		startDate, err := time.Parse("2006-01-02", params.StartDate)
+		if err != nil {
+			errValidation["startDate"] = "Format tanggal mulai tidak valid (gunakan YYYY-MM-DD)"
+		} else {
+			options["from"] = startDate
+		}
+	}
+	if params.EndDate != "" {
+		endDate, err := time.Parse("2006-01-02", params.EndDate)
+		if err != nil {
+			errValidation["endDate"] = "Format tanggal akhir tidak valid (gunakan YYYY-MM-DD)"
+		} else {
+			options["to"] = endDate.AddDate(0, 0, 1)
+		}
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	total, err := s.repo.CountFuelLogs(ctx, options)
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menghitung catatan bahan bakar", nil, nil)
+	}
+	fuelLogs, err := s.repo.FindFuelLogs(ctx, options)
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat catatan bahan bakar", nil, nil)
+	}
+	totalLiters, totalCost, err := s.repo.SumFuelCost(ctx, options)
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menghitung total biaya bahan bakar", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, toFuelLogListResponse(fuelLogs, totalLiters, totalCost, offsetPagination(params.Page, params.Limit, total)))
+}
+
+func (s *service) CreateFuelLog(ctx context.Context, accountID string, role enum.RoleName, ambulanceID string, payload CreateFuelLogRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	amb, res := s.findAmbulance(ctx, accountID, role, ambulanceID)
+	if amb == nil {
+		return res
+	}
+
+	errValidation := make(map[string]string)
+	if payload.Liters <= 0 {
+		errValidation["liters"] = "Jumlah liter harus lebih besar dari 0"
+	}
+	if payload.Cost <= 0 {
+		errValidation["cost"] = "Biaya harus lebih besar dari 0"
+	}
+	if payload.FilledAt.IsZero() {
+		errValidation["filledAt"] = "Tanggal pengisian wajib diisi"
+	} else if payload.FilledAt.After(time.Now()) {
+		errValidation["filledAt"] = "Tanggal pengisian tidak boleh di masa depan"
+	}
+	if payload.OdometerKm != nil && *payload.OdometerKm < amb.OdometerKm {
+		errValidation["odometerKm"] = fmt.Sprintf("Angka odometer tidak boleh lebih kecil dari %d km", amb.OdometerKm)
+	}
+	// Only managers decide what is booked as an operational expense.
+	if payload.PostAsExpense && role != enum.RoleAmbulanceManager {
+		errValidation["postAsExpense"] = "Hanya manajer ambulans yang dapat mencatat sebagai pengeluaran operasional"
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	var receiptURL string
+	if payload.ReceiptImage != nil {
+		uploadedURL, err := s.s3Client.UploadFile(ctx, payload.ReceiptImage, "ambulance-fuel-receipts")
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":    "ambulance_fleet.service",
+				"ambulance_id": ambulanceID,
+			}).WithError(err).Error("failed to upload fuel receipt")
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah struk bahan bakar", nil, nil)
+		}
+		receiptURL = uploadedURL
+	}
+
+	now := time.Now()
+	recordedBy := uuid.MustParse(accountID)
+	fuelLog := &FuelLog{
+		ID:              uuid.New(),
+		AmbulanceID:     amb.ID,
+		Liters:          payload.Liters,
+		Cost:            payload.Cost,
+		OdometerKm:      payload.OdometerKm,
+		Station:         payload.Station,
+		ReceiptImage:    receiptURL,
+		PostedAsExpense: payload.PostAsExpense,
+		RecordedBy:      recordedBy,
+		FilledAt:        payload.FilledAt,
+		CreatedAt:       now,
+	}
+
+	var reading *OdometerReading
+	if payload.OdometerKm != nil {
+		reading = &OdometerReading{
+			ID:          uuid.New(),
+			AmbulanceID: amb.ID,
+			ReadingKm:   *payload.OdometerKm,
+			Source:      OdometerSourceFuel,
+			RecordedBy:  recordedBy,
+			RecordedAt:  now,
+			CreatedAt:   now,
+		}
+	}
+
+	var record *finance_record.FinanceRecord
+	if payload.PostAsExpense {
+		record = &finance_record.FinanceRecord{
+			ID:              uuid.New().String(),
+			FundType:        finance_record.FundTypeAmbulanceOperational,
+			FundID:          amb.ID.String(),
+			SourceType:      finance_record.SourceTypeExpense,
+			SourceID:        fuelLog.ID.String(),
+			Amount:          fuelLog.Cost,
+			TransactionDate: fuelLog.FilledAt,
+			CreatedAt:       now,
+		}
+	}
+
+	if err := s.repo.CreateFuelLog(ctx, fuelLog, reading, record); err != nil {
+		if receiptURL != "" {
+			_ = s.s3Client.DeleteFile(ctx, s3_pkg.ExtractObjectNameFromURL(receiptURL))
+		}
+		if errors.Is(err, ErrOdometerDecrease) {
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"odometerKm": "Angka odometer lebih kecil dari catatan terakhir"}, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":    "ambulance_fleet.service",
+			"ambulance_id": ambulanceID,
+		}).WithError(err).Error("failed to create fuel log")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menyimpan catatan bahan bakar", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "CREATE", "ambulance_fuel_log", fuelLog.ID.String(), nil, fuelLog.toFuelLogResponse())
+
+	return pkg.NewResponse(http.StatusCreated, "Catatan bahan bakar berhasil dibuat", nil, fuelLog.toFuelLogResponse())
+}
+
+func (s *service) DeleteFuelLog(ctx context.Context, accountID string, ambulanceID, fuelLogID string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := uuid.Validate(fuelLogID); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"fuelLogId": "Format ID catatan bahan bakar tidak valid"}, nil)
+	}
+
+	fuelLog, err := s.repo.FindOneFuelLog(ctx, map[string]interface{}{"id": fuelLogID, "ambulance_id": ambulanceID})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Catatan bahan bakar tidak ditemukan", nil, nil)
+		}
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat catatan bahan bakar", nil, nil)
+	}
+
+	if err := s.repo.DeleteFuelLog(ctx, fuelLogID); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":   "ambulance_fleet.service",
+			"fuel_log_id": fuelLogID,
+		}).WithError(err).Error("failed to delete fuel log")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menghapus catatan bahan bakar", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "DELETE", "ambulance_fuel_log", fuelLogID, fuelLog.toFuelLogResponse(), nil)
+
+	return pkg.NewResponse(http.StatusOK, "Catatan bahan bakar berhasil dihapus", nil, nil)
+}
+
+func (s *service) ListMaintenanceSchedules(ctx context.Context, ambulanceID string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	amb, res := s.findAmbulance(ctx, "", enum.RoleAmbulanceManager, ambulanceID)
+	if amb == nil {
+		return res
+	}
+
+	schedules, err := s.repo.FindMaintenanceSchedules(ctx, map[string]interface{}{"ambulance_id": ambulanceID})
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat jadwal perawatan", nil, nil)
+	}
+
+	now := time.Now()
+	responses := make([]MaintenanceScheduleResponse, 0, len(schedules))
+	for _, schedule := range schedules {
+		responses = append(responses, schedule.toMaintenanceScheduleResponse(amb.OdometerKm, now))
+	}
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, responses)
+}
+
+func validateMaintenanceSchedule(payload MaintenanceScheduleRequest, errValidation map[string]string) *time.Time {
+	if payload.IntervalKm != nil && *payload.IntervalKm <= 0 {
+		errValidation["intervalKm"] = "Interval kilometer harus lebih besar dari 0"
+	}
+	if payload.IntervalDays != nil && *payload.IntervalDays <= 0 {
+		errValidation["intervalDays"] = "Interval hari harus lebih besar dari 0"
+	}
+	if payload.LastServiceKm != nil && *payload.LastServiceKm < 0 {
+		errValidation["lastServiceKm"] = "Kilometer servis terakhir tidak valid"
+	}
+	if payload.LastServiceAt == "" {
+		return nil
+	}
+	lastServiceAt, err := time.Parse("2006-01-02", payload.LastServiceAt)
+	if err != nil {
+		errValidation["lastServiceAt"] = "Format tanggal tidak valid (gunakan YYYY-MM-DD)"
+		return nil
+	}
+	return &lastServiceAt
+}
+
+func (s *service) CreateMaintenanceSchedule(ctx context.Context, ambulanceID string, payload MaintenanceScheduleRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	amb, res := s.findAmbulance(ctx, "", enum.RoleAmbulanceManager, ambulanceID)
+	if amb == nil {
+		return res
+	}
+
+	errValidation := make(map[string]string)
+	if strings.TrimSpace(payload.Name) == "" {
+		errValidation["name"] = "Nama perawatan wajib diisi"
+	}
+	if payload.IntervalKm == nil && payload.IntervalDays == nil {
+		errValidation["intervalKm"] = "Interval kilometer atau interval hari wajib diisi"
+	}
+	lastServiceAt := validateMaintenanceSchedule(payload, errValidation)
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	now := time.Now()
+	schedule := &MaintenanceSchedule{
+		ID:           uuid.New(),
+		AmbulanceID:  amb.ID,
+		Name:         strings.TrimSpace(payload.Name),
+		IntervalKm:   payload.IntervalKm,
+		IntervalDays: payload.IntervalDays,
+		IsActive:     true,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	// Without a known last service the schedule starts counting from today's odometer.
+	serviceKm := payload.LastServiceKm
+	if serviceKm == nil {
+		current := amb.OdometerKm
+		serviceKm = &current
+	}
+	serviceAt := now
+	if lastServiceAt != nil {
+		serviceAt = *lastServiceAt
+	}
+	schedule.advance(serviceKm, serviceAt)
+
+	if err := s.repo.CreateMaintenanceSchedule(ctx, schedule); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":    "ambulance_fleet.service",
+			"ambulance_id": ambulanceID,
+		}).WithError(err).Error("failed to create maintenance schedule")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membuat jadwal perawatan", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusCreated, "Jadwal perawatan berhasil dibuat", nil, schedule.toMaintenanceScheduleResponse(amb.OdometerKm, now))
+}
+
+func (s *service) UpdateMaintenanceSchedule(ctx context.Context, ambulanceID, scheduleID string, payload MaintenanceScheduleRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	amb, res := s.findAmbulance(ctx, "", enum.RoleAmbulanceManager, ambulanceID)
+	if amb == nil {
+		return res
+	}
+	if err := uuid.Validate(scheduleID); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"scheduleId": "Format ID jadwal perawatan tidak valid"}, nil)
+	}
+
+	schedule, err := s.repo.FindOneMaintenanceSchedule(ctx, map[string]interface{}{"id": scheduleID, "ambulance_id": ambulanceID})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Jadwal perawatan tidak ditemukan", nil, nil)
+		}
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat jadwal perawatan", nil, nil)
+	}
+
+	errValidation := make(map[string]string)
+	lastServiceAt := validateMaintenanceSchedule(payload, errValidation)
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	if strings.TrimSpace(payload.Name) != "" {
+		schedule.Name = strings.TrimSpace(payload.Name)
+	}
+	if payload.IntervalKm != nil {
+		schedule.IntervalKm = payload.IntervalKm
+	}
+	if payload.IntervalDays != nil {
+		schedule.IntervalDays = payload.IntervalDays
+	}
+	if payload.IsActive != nil {
+		schedule.IsActive = *payload.IsActive
+	}
+	serviceAt := time.Now()
+	if schedule.LastServiceAt != nil {
+		serviceAt = *schedule.LastServiceAt
+	}
+	if lastServiceAt != nil {
+		serviceAt = *lastServiceAt
+	}
+	serviceKm := schedule.LastServiceKm
+	if payload.LastServiceKm != nil {
+		serviceKm = payload.LastServiceKm
+	}
+	reminderSentAt := schedule.ReminderSentAt
+	schedule.advance(serviceKm, serviceAt)
+	// Editing the interval alone should not re-send a reminder that was already delivered.
+	if payload.LastServiceKm == nil && lastServiceAt == nil {
+		schedule.ReminderSentAt = reminderSentAt
+	}
+
+	updateData := map[string]interface{}{
+		"name":             schedule.Name,
+		"interval_km":      schedule.IntervalKm,
+		"interval_days":    schedule.IntervalDays,
+		"last_service_km":  schedule.LastServiceKm,
+		"last_service_at":  schedule.LastServiceAt,
+		"next_due_km":      schedule.NextDueKm,
+		"next_due_at":      schedule.NextDueAt,
+		"reminder_sent_at": schedule.ReminderSentAt,
+		"is_active":        schedule.IsActive,
+		"updated_at":       time.Now(),
+	}
+	if err := s.repo.UpdateMaintenanceSchedule(ctx, scheduleID, updateData); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":   "ambulance_fleet.service",
+			"schedule_id": scheduleID,
+		}).WithError(err).Error("failed to update maintenance schedule")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memperbarui jadwal perawatan", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Jadwal perawatan berhasil diperbarui", nil, schedule.toMaintenanceScheduleResponse(amb.OdometerKm, time.Now()))
+}
+
+func (s *service) ListMaintenanceJobs(ctx context.Context, ambulanceID string, params MaintenanceJobQueryParams) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	amb, res := s.findAmbulance(ctx, "", enum.RoleAmbulanceManager, ambulanceID)
+	if amb == nil {
+		return res
+	}
+
+	params.Page, params.Limit = normalizePage(params.Page, params.Limit)
+	options := map[string]interface{}{
+		"ambulance_id": ambulanceID,
+		"page":         params.Page,
+		"limit":        params.Limit,
+	}
+	if params.Status != "" {
+		options["status"] = params.Status
+	}
+
+	total, err := s.repo.CountMaintenanceJobs(ctx, options)
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menghitung pekerjaan perawatan", nil, nil)
+	}
+	jobs, err := s.repo.FindMaintenanceJobs(ctx, options)
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat pekerjaan perawatan", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, toMaintenanceJobListResponse(jobs, offsetPagination(params.Page, params.Limit, total)))
+}
+
+func (s *service) OpenMaintenanceJob(ctx context.Context, accountID string, ambulanceID string, payload OpenMaintenanceJobRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	amb, res := s.findAmbulance(ctx, accountID, enum.RoleAmbulanceManager, ambulanceID)
+	if amb == nil {
+		return res
+	}
+
+	errValidation := make(map[string]string)
+	var scheduleID *uuid.UUID
+	if payload.ScheduleID != "" {
+		schedule, err := s.repo.FindOneMaintenanceSchedule(ctx, map[string]interface{}{"id": payload.ScheduleID, "ambulance_id": ambulanceID})
+		if err != nil {
+			errValidation["scheduleId"] = "Jadwal perawatan tidak ditemukan"
+		} else {
+			scheduleID = &schedule.ID
+			if strings.TrimSpace(payload.Title) == "" {
+				payload.Title = schedule.Name
+			}
+		}
+	}
+	if strings.TrimSpace(payload.Title) == "" {
+		errValidation["title"] = "Judul pekerjaan wajib diisi"
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	now := time.Now()
+	job := &MaintenanceJob{
+		ID:          uuid.New(),
+		AmbulanceID: amb.ID,
+		ScheduleID:  scheduleID,
+		Title:       strings.TrimSpace(payload.Title),
+		Description: payload.Description,
+		Workshop:    payload.Workshop,
+		Status:      MaintenanceJobOpen,
+		OpenedBy:    uuid.MustParse(accountID),
+		OpenedAt:    now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.repo.OpenMaintenanceJob(ctx, job); err != nil {
+		if errors.Is(err, ErrAmbulanceInUse) {
+			return pkg.NewResponse(http.StatusConflict, "Ambulans sedang digunakan, selesaikan layanan terlebih dahulu", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":    "ambulance_fleet.service",
+			"ambulance_id": ambulanceID,
+		}).WithError(err).Error("failed to open maintenance job")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membuat pekerjaan perawatan", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "CREATE", "ambulance_maintenance_job", job.ID.String(), nil, job.toMaintenanceJobResponse())
+
+	return pkg.NewResponse(http.StatusCreated, "Pekerjaan perawatan dibuka, ambulans berstatus perawatan", nil, job.toMaintenanceJobResponse())
+}
+
+func (s *service) findOpenJob(ctx context.Context, ambulanceID, jobID string) (*MaintenanceJob, pkg.Response) {
+	if err := uuid.Validate(jobID); err != nil {
+		return nil, pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"jobId": "Format ID pekerjaan perawatan tidak valid"}, nil)
+	}
+	job, err := s.repo.FindOneMaintenanceJob(ctx, map[string]interface{}{"id": jobID, "ambulance_id": ambulanceID})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, pkg.NewResponse(http.StatusNotFound, "Pekerjaan perawatan tidak ditemukan", nil, nil)
+		}
+		return nil, pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat pekerjaan perawatan", nil, nil)
+	}
+	if job.Status != MaintenanceJobOpen {
+		return nil, pkg.NewResponse(http.StatusBadRequest, "Hanya pekerjaan perawatan yang masih terbuka yang dapat diproses", nil, nil)
+	}
+	return job, pkg.Response{}
+}
+
+func (s *service) CompleteMaintenanceJob(ctx context.Context, accountID string, ambulanceID, jobID string, payload CompleteMaintenanceJobRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	amb, res := s.findAmbulance(ctx, accountID, enum.RoleAmbulanceManager, ambulanceID)
+	if amb == nil {
+		return res
+	}
+	job, res := s.findOpenJob(ctx, ambulanceID, jobID)
+	if job == nil {
+		return res
+	}
+
+	errValidation := make(map[string]string)
+	if payload.Cost < 0 {
+		errValidation["cost"] = "Biaya tidak boleh negatif"
+	}
+	if payload.PostAsExpense && payload.Cost <= 0 {
+		errValidation["cost"] = "Biaya wajib diisi untuk dicatat sebagai pengeluaran"
+	}
+	if payload.OdometerKm != nil && *payload.OdometerKm < amb.OdometerKm {
+		errValidation["odometerKm"] = fmt.Sprintf("Angka odometer tidak boleh lebih kecil dari %d km", amb.OdometerKm)
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	var invoiceURL string
+	if payload.InvoiceImage != nil {
+		uploadedURL, err := s.s3Client.UploadFile(ctx, payload.InvoiceImage, "ambulance-maintenance-invoices")
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component": "ambulance_fleet.service",
+				"job_id":    jobID,
+			}).WithError(err).Error("failed to upload maintenance invoice")
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah nota perawatan", nil, nil)
+		}
+		invoiceURL = uploadedURL
+	}
+
+	now := time.Now()
+	recordedBy := uuid.MustParse(accountID)
+	updateData := map[string]interface{}{
+		"status":            MaintenanceJobCompleted,
+		"odometer_km":       payload.OdometerKm,
+		"cost":              payload.Cost,
+		"posted_as_expense": payload.PostAsExpense,
+		"closed_at":         now,
+		"updated_at":        now,
+	}
+	if payload.Description != "" {
+		updateData["description"] = payload.Description
+	}
+	if invoiceURL != "" {
+		updateData["invoice_image"] = invoiceURL
+	}
+
+	var reading *OdometerReading
+	if payload.OdometerKm != nil {
+		reading = &OdometerReading{
+			ID:          uuid.New(),
+			AmbulanceID: amb.ID,
+			ReadingKm:   *payload.OdometerKm,
+			Source:      OdometerSourceMaintenance,
+			Note:        job.Title,
+			RecordedBy:  recordedBy,
+			RecordedAt:  now,
+			CreatedAt:   now,
+		}
+	}
+
+	// Completing a scheduled job resets that schedule from this service.
+	var schedule *MaintenanceSchedule
+	if job.Schedule != nil {
+		schedule = job.Schedule
+		serviceKm := payload.OdometerKm
+		if serviceKm == nil {
+			current := amb.OdometerKm
+			serviceKm = &current
+		}
+		schedule.advance(serviceKm, now)
+	}
+
+	var record *finance_record.FinanceRecord
+	if payload.PostAsExpense {
+		record = &finance_record.FinanceRecord{
+			ID:              uuid.New().String(),
+			FundType:        finance_record.FundTypeAmbulanceOperational,
+			FundID:          amb.ID.String(),
+			SourceType:      finance_record.SourceTypeExpense,
+			SourceID:        job.ID.String(),
+			Amount:          payload.Cost,
+			TransactionDate: now,
+			CreatedAt:       now,
+		}
+	}
+
+	if err := s.repo.CloseMaintenanceJob(ctx, job, updateData, schedule, reading, record); err != nil {
+		if invoiceURL != "" {
+			_ = s.s3Client.DeleteFile(ctx, s3_pkg.ExtractObjectNameFromURL(invoiceURL))
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusConflict, "Pekerjaan perawatan sudah diproses", nil, nil)
+		}
+		if errors.Is(err, ErrOdometerDecrease) {
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"odometerKm": "Angka odometer lebih kecil dari catatan terakhir"}, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component": "ambulance_fleet.service",
+			"job_id":    jobID,
+		}).WithError(err).Error("failed to complete maintenance job")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menyelesaikan pekerjaan perawatan", nil, nil)
+	}
+
+	oldValue := job.toMaintenanceJobResponse()
+	job.Status = MaintenanceJobCompleted
+	job.OdometerKm = payload.OdometerKm
+	job.Cost = payload.Cost
+	job.PostedAsExpense = payload.PostAsExpense
+	job.ClosedAt = &now
+	if invoiceURL != "" {
+		job.InvoiceImage = invoiceURL
+	}
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "ambulance_maintenance_job", jobID, oldValue, job.toMaintenanceJobResponse())
+
+	return pkg.NewResponse(http.StatusOK, "Pekerjaan perawatan berhasil diselesaikan", nil, job.toMaintenanceJobResponse())
+}
+
+func (s *service) CancelMaintenanceJob(ctx context.Context, accountID string, ambulanceID, jobID string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	amb, res := s.findAmbulance(ctx, accountID, enum.RoleAmbulanceManager, ambulanceID)
+	if amb == nil {
+		return res
+	}
+	job, res := s.findOpenJob(ctx, ambulanceID, jobID)
+	if job == nil {
+		return res
+	}
+
+	now := time.Now()
+	updateData := map[string]interface{}{
+		"status":     MaintenanceJobCancelled,
+		"closed_at":  now,
+		"updated_at": now,
+	}
+	if err := s.repo.CloseMaintenanceJob(ctx, job, updateData, nil, nil, nil); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusConflict, "Pekerjaan perawatan sudah diproses", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component": "ambulance_fleet.service",
+			"job_id":    jobID,
+		}).WithError(err).Error("failed to cancel maintenance job")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membatalkan pekerjaan perawatan", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "ambulance_maintenance_job", jobID, job.toMaintenanceJobResponse(), map[string]interface{}{"status": MaintenanceJobCancelled})
+
+	return pkg.NewResponse(http.StatusOK, "Pekerjaan perawatan berhasil dibatalkan", nil, nil)
+}
+
+func (s *service) ListVehicleDocuments(ctx context.Context, ambulanceID string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	amb, res := s.findAmbulance(ctx, "", enum.RoleAmbulanceManager, ambulanceID)
+	if amb == nil {
+		return res
+	}
+
+	documents, err := s.repo.FindVehicleDocuments(ctx, map[string]interface{}{"ambulance_id": ambulanceID})
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat dokumen kendaraan", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, toVehicleDocumentListResponse(documents, time.Now()))
+}
+
+func (s *service) CreateVehicleDocument(ctx context.Context, ambulanceID string, payload VehicleDocumentRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	amb, res := s.findAmbulance(ctx, "", enum.RoleAmbulanceManager, ambulanceID)
+	if amb == nil {
+		return res
+	}
+
+	errValidation := make(map[string]string)
+	if !payload.Type.IsValid() {
+		errValidation["type"] = "Jenis dokumen tidak valid (stnk, kir, insurance)"
+	}
+	if payload.ExpiresAt.IsZero() {
+		errValidation["expiresAt"] = "Tanggal kedaluwarsa wajib diisi"
+	} else if !payload.IssuedAt.IsZero() && !payload.ExpiresAt.After(payload.IssuedAt) {
+		errValidation["expiresAt"] = "Tanggal kedaluwarsa harus setelah tanggal terbit"
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	var fileURL string
+	if payload.File != nil {
+		uploadedURL, err := s.s3Client.UploadFileOriginal(ctx, payload.File, "ambulance-documents")
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":    "ambulance_fleet.service",
+				"ambulance_id": ambulanceID,
+			}).WithError(err).Error("failed to upload vehicle document")
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah dokumen kendaraan", nil, nil)
+		}
+		fileURL = uploadedURL
+	}
+
+	now := time.Now()
+	document := &VehicleDocument{
+		ID:          uuid.New(),
+		AmbulanceID: amb.ID,
+		Type:        payload.Type,
+		Number:      payload.Number,
+		ExpiresAt:   payload.ExpiresAt,
+		File:        fileURL,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Ambulance:   *amb,
+	}
+	if !payload.IssuedAt.IsZero() {
+		document.IssuedAt = &payload.IssuedAt
+	}
+
+	if err := s.repo.CreateVehicleDocument(ctx, document); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":    "ambulance_fleet.service",
+			"ambulance_id": ambulanceID,
+		}).WithError(err).Error("failed to create vehicle document")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menyimpan dokumen kendaraan", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusCreated, "Dokumen kendaraan berhasil disimpan", nil, document.toVehicleDocumentResponse(now))
+}
+
+func (s *service) UpdateVehicleDocument(ctx context.Context, ambulanceID, documentID string, payload VehicleDocumentRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	amb, res := s.findAmbulance(ctx, "", enum.RoleAmbulanceManager, ambulanceID)
+	if amb == nil {
+		return res
+	}
+	if err := uuid.Validate(documentID); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"documentId": "Format ID dokumen tidak valid"}, nil)
+	}
+
+	document, err := s.repo.FindOneVehicleDocument(ctx, map[string]interface{}{"id": documentID, "ambulance_id": ambulanceID})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Dokumen kendaraan tidak ditemukan", nil, nil)
+		}
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat dokumen kendaraan", nil, nil)
+	}
+
+	updateData := map[string]interface{}{"updated_at": time.Now()}
+	if payload.Type != "" {
+		if !payload.Type.IsValid() {
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"type": "Jenis dokumen tidak valid (stnk, kir, insurance)"}, nil)
+		}
+		updateData["type"] = payload.Type
+	}
+	if payload.Number != "" {
+		updateData["number"] = payload.Number
+	}
+	if !payload.IssuedAt.IsZero() {
+		updateData["issued_at"] = payload.IssuedAt
+	}
+	if !payload.ExpiresAt.IsZero() {
+		updateData["expires_at"] = payload.ExpiresAt
+		// A renewed document starts a fresh alert cycle.
+		if payload.ExpiresAt.After(document.ExpiresAt) {
+			updateData["last_alert_at"] = nil
+		}
+	}
+	if payload.File != nil {
+		uploadedURL, err := s.s3Client.UploadFileOriginal(ctx, payload.File, "ambulance-documents")
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":   "ambulance_fleet.service",
+				"document_id": documentID,
+			}).WithError(err).Error("failed to upload vehicle document")
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah dokumen kendaraan", nil, nil)
+		}
+		if document.File != "" {
+			_ = s.s3Client.DeleteFile(ctx, s3_pkg.ExtractObjectNameFromURL(document.File))
+		}
+		updateData["file"] = uploadedURL
+	}
+
+	if err := s.repo.UpdateVehicleDocument(ctx, documentID, updateData); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":   "ambulance_fleet.service",
+			"document_id": documentID,
+		}).WithError(err).Error("failed to update vehicle document")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memperbarui dokumen kendaraan", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Dokumen kendaraan berhasil diperbarui", nil, nil)
+}
+
+func (s *service) DeleteVehicleDocument(ctx context.Context, ambulanceID, documentID string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := uuid.Validate(documentID); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"documentId": "Format ID dokumen tidak valid"}, nil)
+	}
+	if _, err := s.repo.FindOneVehicleDocument(ctx, map[string]interface{}{"id": documentID, "ambulance_id": ambulanceID}); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Dokumen kendaraan tidak ditemukan", nil, nil)
+		}
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat dokumen kendaraan", nil, nil)
+	}
+
+	if err := s.repo.DeleteVehicleDocument(ctx, documentID); err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menghapus dokumen kendaraan", nil, nil)
+	}
+	return pkg.NewResponse(http.StatusOK, "Dokumen kendaraan berhasil dihapus", nil, nil)
+}
+
+// collectAlerts returns maintenance that is due soon and documents that expire soon across the fleet.
+func (s *service) collectAlerts(ctx context.Context, now time.Time) ([]MaintenanceSchedule, []VehicleDocument, error) {
+	schedules, err := s.repo.FindDueMaintenanceSchedules(ctx, now.AddDate(0, 0, maintenanceReminderDays), maintenanceReminderKm)
+	if err != nil {
+		return nil, nil, err
+	}
+	documents, err := s.repo.FindVehicleDocuments(ctx, map[string]interface{}{"expires_before": now.AddDate(0, 0, documentAlertDays)})
+	if err != nil {
+		return nil, nil, err
+	}
+	return schedules, documents, nil
+}
+
+func (s *service) GetFleetAlerts(ctx context.Context) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	now := time.Now()
+	schedules, documents, err := s.collectAlerts(ctx, now)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "ambulance_fleet.service",
+		}).WithError(err).Error("failed to collect fleet alerts")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat peringatan armada", nil, nil)
+	}
+
+	result := FleetAlertsResponse{
+		DueMaintenance:    make([]MaintenanceAlertResponse, 0, len(schedules)),
+		ExpiringDocuments: toVehicleDocumentListResponse(documents, now),
+	}
+	for _, schedule := range schedules {
+		result.DueMaintenance = append(result.DueMaintenance, MaintenanceAlertResponse{
+			MaintenanceScheduleResponse: schedule.toMaintenanceScheduleResponse(schedule.Ambulance.OdometerKm, now),
+			PlateNumber:                 schedule.Ambulance.PlateNumber,
+			CurrentOdometerKm:           schedule.Ambulance.OdometerKm,
+		})
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, result)
+}
+
+// SendFleetReminders emails ambulance managers about maintenance coming due and documents
+// about to expire. Each schedule is reminded once per service cycle; documents are re-alerted
+// at most every documentAlertRepeatDays until renewed.
+func (s *service) SendFleetReminders(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	now := time.Now()
+	schedules, documents, err := s.collectAlerts(ctx, now)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "ambulance_fleet.service",
+		}).WithError(err).Error("failed to collect fleet alerts")
+		return err
+	}
+
+	var maintenanceItems, documentItems, scheduleIDs, documentIDs []string
+	for _, schedule := range schedules {
+		if schedule.ReminderSentAt != nil {
+			continue
+		}
+		item := fmt.Sprintf("%s — %s", schedule.Ambulance.PlateNumber, schedule.Name)
+		if schedule.NextDueAt != nil {
+			item += ", jatuh tempo " + schedule.NextDueAt.Format("02-01-2006")
+		}
+		if schedule.NextDueKm != nil {
+			item += fmt.Sprintf(", pada %d km (saat ini %d km)", *schedule.NextDueKm, schedule.Ambulance.OdometerKm)
+		}
+		maintenanceItems = append(maintenanceItems, item)
+		scheduleIDs = append(scheduleIDs, schedule.ID.String())
+	}
+	for _, document := range documents {
+		if document.LastAlertAt != nil && document.LastAlertAt.After(now.AddDate(0, 0, -documentAlertRepeatDays)) {
+			continue
+		}
+		status := "berlaku hingga " + document.ExpiresAt.Format("02-01-2006")
+		if !document.ExpiresAt.After(now) {
+			status = "sudah kedaluwarsa sejak " + document.ExpiresAt.Format("02-01-2006")
+		}
+		documentItems = append(documentItems, fmt.Sprintf("%s — %s %s", document.Ambulance.PlateNumber, strings.ToUpper(string(document.Type)), status))
+		documentIDs = append(documentIDs, document.ID.String())
+	}
+
+	if len(maintenanceItems) == 0 && len(documentItems) == 0 {
+		return nil
+	}
+
+	recipients, err := s.repo.FindAmbulanceManagerEmails(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "ambulance_fleet.service",
+		}).WithError(err).Error("failed to load ambulance manager emails")
+		return err
+	}
+	if len(recipients) == 0 {
+		logrus.WithFields(logrus.Fields{
+			"component": "ambulance_fleet.service",
+		}).Warn("no ambulance manager to receive fleet reminders")
+		return nil
+	}
+
+	sent := false
+	for _, recipient := range recipients {
+		if err := s.emailService.SendFleetReminderEmail(recipient, maintenanceItems, documentItems); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component": "ambulance_fleet.service",
+				"recipient": recipient,
+			}).WithError(err).Error("failed to send fleet reminder email")
+			continue
+		}
+		sent = true
+	}
+	if !sent {
+		return errors.New("fleet reminder email could not be delivered")
+	}
+
+	if err := s.repo.MarkSchedulesReminded(ctx, scheduleIDs, now); err != nil {
+		return err
+	}
+	return s.repo.MarkDocumentsAlerted(ctx, documentIDs, now)
+}
@@ -11,11 +11,18 @@ type AmbulanceHistory struct {
 	ID              uuid.UUID       `json:"id" gorm:"primaryKey"`
 	AmbulanceID     uuid.UUID       `json:"ambulanceId" gorm:"not null"`
 	DriverID        uuid.UUID       `json:"driverId" gorm:"not null"`
+	RequestID       *uuid.UUID      `json:"requestId" gorm:"index"`
 	ServiceCategory ServiceCategory `json:"serviceCategory" gorm:"not null"`
 	Note            string          `json:"note"`
+	StartedAt       *time.Time      `json:"startedAt"`
+	FinishedAt      *time.Time      `json:"finishedAt"`
+	DistanceMeters  float64         `json:"distanceMeters" gorm:"default:0"`
+	DurationSeconds int64           `json:"durationSeconds" gorm:"default:0"`
+	RoutePolyline   string          `json:"routePolyline" gorm:"type:text"` // Google encoded polyline of the recorded track
 	CreatedAt       time.Time       `json:"createdAt" gorm:"not null"`
 
 	Driver account.Account `json:"driver" gorm:"foreignKey:DriverID"`
+	Costs  []TripCost      `json:"costs" gorm:"foreignKey:HistoryID"`
 }
 
 type ServiceCategory string
@@ -27,3 +34,41 @@ const (
 	EmergencyService ServiceCategory = "emergency_service"
 	OtherService     ServiceCategory = "other_service"
 )
+
+// estimatedDurations is the planning estimate of how long a trip keeps an ambulance
+// busy, including the return leg. Used to block the booking calendar.
+var estimatedDurations = map[ServiceCategory]time.Duration{
+	SocialService:    3 * time.Hour,
+	MortuaryService:  4 * time.Hour,
+	PatientService:   3 * time.Hour,
+	EmergencyService: 2 * time.Hour,
+	OtherService:     3 * time.Hour,
+}
+
+func (c ServiceCategory) EstimatedDuration() time.Duration {
+	if d, ok := estimatedDurations[c]; ok {
+		return d
+	}
+	return 3 * time.Hour
+}
+
+func (c ServiceCategory) IsValid() bool {
+	_, ok := estimatedDurations[c]
+	return ok
+}
+
+var categoryLabels = map[ServiceCategory]string{
+	SocialService:    "Layanan Sosial",
+	MortuaryService:  "Layanan Jenazah",
+	PatientService:   "Layanan Pasien",
+	EmergencyService: "Layanan Darurat",
+	OtherService:     "Layanan Lainnya",
+}
+
+// Label is the Indonesian display name used in printed documents.
+func (c ServiceCategory) Label() string {
+	if l, ok := categoryLabels[c]; ok {
+		return l
+	}
+	return string(c)
+}
@@ -0,0 +1,50 @@
+package ambulance_history
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TripCostCategory string
+
+const (
+	TripCostFuel            TripCostCategory = "fuel"
+	TripCostToll            TripCostCategory = "toll"
+	TripCostDriverAllowance TripCostCategory = "driver_allowance"
+	TripCostOther           TripCostCategory = "other"
+)
+
+func (c TripCostCategory) IsValid() bool {
+	switch c {
+	case TripCostFuel, TripCostToll, TripCostDriverAllowance, TripCostOther:
+		return true
+	}
+	return false
+}
+
+// TripCost is a running cost incurred on one trip. Costs posted as expenses are mirrored in the
+// ambulance operational fund of the finance module.
+type TripCost struct {
+	ID              uuid.UUID        `json:"id" gorm:"primaryKey"`
+	HistoryID       uuid.UUID        `json:"historyId" gorm:"not null;index"`
+	AmbulanceID     uuid.UUID        `json:"ambulanceId" gorm:"not null;index"`
+	Category        TripCostCategory `json:"category" gorm:"not null"`
+	Amount          float64          `json:"amount" gorm:"not null"`
+	Note            string           `json:"note"`
+	ReceiptImage    string           `json:"receiptImage"`
+	PostedAsExpense bool             `json:"postedAsExpense" gorm:"default:false"`
+	RecordedBy      uuid.UUID        `json:"recordedBy" gorm:"not null"`
+	IncurredAt      time.Time        `json:"incurredAt" gorm:"not null"`
+	CreatedAt       time.Time        `json:"createdAt"`
+	DeletedAt       *time.Time       `json:"deletedAt" gorm:"index"`
+}
+
+// TotalCost sums the costs loaded with the history.
+func (h *AmbulanceHistory) TotalCost() float64 {
+	var total float64
+	for _, cost := range h.Costs {
+		total += cost.Amount
+	}
+	return total
+}
@@ -0,0 +1,88 @@
+package ambulance_history
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/account"
+	"github.com/google/uuid"
+)
+
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// TripFeedback is the requester's rating of a finished trip. A request can be rated once.
+type TripFeedback struct {
+	ID            uuid.UUID  `json:"id" gorm:"primaryKey"`
+	RequestID     uuid.UUID  `json:"requestId" gorm:"not null;uniqueIndex"`
+	HistoryID     *uuid.UUID `json:"historyId" gorm:"index"`
+	AmbulanceID   uuid.UUID  `json:"ambulanceId" gorm:"not null;index"`
+	DriverID      uuid.UUID  `json:"driverId" gorm:"not null;index"`
+	AccountID     uuid.UUID  `json:"accountId" gorm:"not null"`
+	DriverRating  int        `json:"driverRating" gorm:"not null"`
+	VehicleRating int        `json:"vehicleRating" gorm:"not null"`
+	Comment       string     `json:"comment" gorm:"type:text"`
+	CreatedAt     time.Time  `json:"createdAt" gorm:"not null"`
+
+	Driver  account.Account `json:"driver" gorm:"foreignKey:DriverID"`
+	Account account.Account `json:"account" gorm:"foreignKey:AccountID"`
+}
+
+// ErrFeedbackExists is returned when a request already has feedback.
+var ErrFeedbackExists = errors.New("trip feedback already exists")
+
+type IncidentType string
+
+const (
+	IncidentVehicleDamage    IncidentType = "vehicle_damage"
+	IncidentPatientCondition IncidentType = "patient_condition"
+	IncidentDelay            IncidentType = "delay"
+	IncidentOther            IncidentType = "other"
+)
+
+func (t IncidentType) IsValid() bool {
+	switch t {
+	case IncidentVehicleDamage, IncidentPatientCondition, IncidentDelay, IncidentOther:
+		return true
+	}
+	return false
+}
+
+// IncidentReport is filed by the trip's driver during or after a trip.
+type IncidentReport struct {
+	ID          uuid.UUID       `json:"id" gorm:"primaryKey"`
+	RequestID   uuid.UUID       `json:"requestId" gorm:"not null;index"`
+	AmbulanceID uuid.UUID       `json:"ambulanceId" gorm:"not null;index"`
+	DriverID    uuid.UUID       `json:"driverId" gorm:"not null;index"`
+	Type        IncidentType    `json:"type" gorm:"not null"`
+	Description string          `json:"description" gorm:"type:text;not null"`
+	OccurredAt  time.Time       `json:"occurredAt" gorm:"not null"`
+	CreatedAt   time.Time       `json:"createdAt" gorm:"not null"`
+	Photos      []IncidentPhoto `json:"photos" gorm:"foreignKey:IncidentID"`
+
+	Driver account.Account `json:"driver" gorm:"foreignKey:DriverID"`
+}
+
+type IncidentPhoto struct {
+	ID         uuid.UUID `json:"id" gorm:"primaryKey"`
+	IncidentID uuid.UUID `json:"incidentId" gorm:"not null;index"`
+	URL        string    `json:"url" gorm:"not null"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"not null"`
+}
+
+// SatisfactionMetrics aggregates requester ratings and incident reports for a driver or an ambulance.
+type SatisfactionMetrics struct {
+	FeedbackCount        int64   `json:"feedbackCount"`
+	AverageDriverRating  float64 `json:"averageDriverRating"`
+	AverageVehicleRating float64 `json:"averageVehicleRating"`
+	IncidentCount        int64   `json:"incidentCount"`
+}
+
+// round keeps averages at two decimals for display.
+func (m *SatisfactionMetrics) round() {
+	m.AverageDriverRating = math.Round(m.AverageDriverRating*100) / 100
+	m.AverageVehicleRating = math.Round(m.AverageVehicleRating*100) / 100
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/Vilamuzz/yota-backend/pkg"
 	"github.com/Vilamuzz/yota-backend/pkg/enum"
 	jwt_pkg "github.com/Vilamuzz/yota-backend/pkg/jwt"
+	"github.com/Vilamuzz/yota-backend/pkg/report"
 	"github.com/gin-gonic/gin"
 )
 
@@ -36,6 +37,12 @@ func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
 		ambulanceManager.DELETE("/:id", h.DeleteAmbulanceHistory)
 		ambulanceManager.GET("/summary", h.AllHistorySummary)
 		ambulanceManager.GET("/monthly-trend", h.HistoryMonthlyTrend)
+		ambulanceManager.GET("/reports/monthly", h.ExportMonthlyReport)
+		ambulanceManager.GET("/feedback", h.ListTripFeedback)
+		ambulanceManager.GET("/incidents", h.ListIncidentReports)
+		ambulanceManager.GET("/:id/costs", h.ListTripCosts)
+		ambulanceManager.POST("/:id/costs", h.CreateTripCost)
+		ambulanceManager.DELETE("/:id/costs/:costId", h.DeleteTripCost)
 	}
 
 	ambulanceDriver := r.Group("/admin/ambulances/history/driver")
@@ -47,6 +54,8 @@ func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
 		ambulanceDriver.DELETE("/:id", h.DeleteAmbulanceHistory)
 		ambulanceDriver.GET("/summary", h.DriverHistorySummary)
 		ambulanceDriver.GET("/monthly-trend", h.DriverHistoryMonthlyTrend)
+		ambulanceDriver.GET("/:id/costs", h.ListTripCosts)
+		ambulanceDriver.POST("/:id/costs", h.CreateTripCost)
 	}
 }
 
@@ -192,7 +201,7 @@ func (h *handler) AllHistorySummary(c *gin.Context) {
 
 // AmbulanceHistorySummary godoc
 // @Summary Get ambulance history summary
-// @Description Returns total service counts grouped by category for an ambulance.
+// @Description Returns total service counts grouped by category for an ambulance, with requester ratings and incident counts.
 // @Description Use the `period` query param to filter by time window.
 // @Tags Ambulance History
 // @Accept json
@@ -287,7 +296,7 @@ func (h *handler) DeleteAmbulanceHistory(c *gin.Context) {
 
 // DriverHistorySummary godoc
 // @Summary Get driver's own ambulance history summary
-// @Description Returns total service counts grouped by category for the authenticated driver.
+// @Description Returns total service counts grouped by category for the authenticated driver, with requester ratings and incident counts.
 // @Tags Ambulance History
 // @Security BearerAuth
 // @Accept json
@@ -338,3 +347,162 @@ func (h *handler) DriverHistoryMonthlyTrend(c *gin.Context) {
 	res := h.service.DriverHistoryMonthlyTrend(ctx, claims.AccountID, params)
 	c.JSON(res.Status, res)
 }
+
+// ListTripFeedback godoc
+// @Summary List trip feedback
+// @Description Get requester ratings and comments for finished trips
+// @Tags Ambulance History
+// @Security BearerAuth
+// @Produce json
+// @Param requestId query string false "Filter by ambulance service request ID"
+// @Param ambulanceId query string false "Filter by ambulance ID"
+// @Param driverId query string false "Filter by driver ID"
+// @Param limit query int false "Number of items to return"
+// @Param nextCursor query string false "Cursor for next page"
+// @Param prevCursor query string false "Cursor for previous page"
+// @Success 200 {object} pkg.Response{data=FeedbackListResponse}
+// @Failure 400 {object} pkg.Response
+// @Router /admin/ambulances/history/feedback [get]
+func (h *handler) ListTripFeedback(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var params FeedbackQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid query parameters", nil, nil))
+		return
+	}
+
+	res := h.service.ListTripFeedback(ctx, params)
+	c.JSON(res.Status, res)
+}
+
+// ListIncidentReports godoc
+// @Summary List incident reports
+// @Description Get incident reports filed by drivers, with photos
+// @Tags Ambulance History
+// @Security BearerAuth
+// @Produce json
+// @Param requestId query string false "Filter by ambulance service request ID"
+// @Param ambulanceId query string false "Filter by ambulance ID"
+// @Param driverId query string false "Filter by driver ID"
+// @Param type query string false "Filter by type (vehicle_damage, patient_condition, delay, other)"
+// @Param limit query int false "Number of items to return"
+// @Param nextCursor query string false "Cursor for next page"
+// @Param prevCursor query string false "Cursor for previous page"
+// @Success 200 {object} pkg.Response{data=IncidentListResponse}
+// @Failure 400 {object} pkg.Response
+// @Router /admin/ambulances/history/incidents [get]
+func (h *handler) ListIncidentReports(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var params IncidentQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid query parameters", nil, nil))
+		return
+	}
+
+	res := h.service.ListIncidentReports(ctx, params)
+	c.JSON(res.Status, res)
+}
+
+// ListTripCosts godoc
+// @Summary List trip costs
+// @Description Get the fuel, toll and allowance costs recorded for a trip. Drivers only see trips they drove
+// @Tags Ambulance History
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Ambulance History ID"
+// @Success 200 {object} pkg.Response{data=TripCostListResponse}
+// @Failure 403 {object} pkg.Response
+// @Failure 404 {object} pkg.Response
+// @Router /admin/ambulances/history/{id}/costs [get]
+func (h *handler) ListTripCosts(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.ListTripCosts(ctx, claims.AccountID, claims.ActiveRole, c.Param("id"))
+	c.JSON(res.Status, res)
+}
+
+// CreateTripCost godoc
+// @Summary Record trip cost
+// @Description Record a cost incurred on a trip. Managers can also post it as an ambulance operational expense
+// @Tags Ambulance History
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Ambulance History ID"
+// @Param category formData string true "fuel, toll, driver_allowance or other"
+// @Param amount formData number true "Amount"
+// @Param incurredAt formData string false "Cost date (YYYY-MM-DD, default trip date)"
+// @Param note formData string false "Note"
+// @Param postAsExpense formData bool false "Post as operational expense"
+// @Param receiptImage formData file false "Receipt image"
+// @Success 201 {object} pkg.Response{data=TripCostResponse}
+// @Failure 400 {object} pkg.Response
+// @Failure 403 {object} pkg.Response
+// @Router /admin/ambulances/history/{id}/costs [post]
+func (h *handler) CreateTripCost(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req CreateTripCostRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.CreateTripCost(ctx, claims.AccountID, claims.ActiveRole, c.Param("id"), req)
+	c.JSON(res.Status, res)
+}
+
+// DeleteTripCost godoc
+// @Summary Delete trip cost
+// @Description Delete a trip cost and its operational expense record
+// @Tags Ambulance History
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Ambulance History ID"
+// @Param costId path string true "Trip Cost ID"
+// @Success 200 {object} pkg.Response
+// @Failure 404 {object} pkg.Response
+// @Router /admin/ambulances/history/{id}/costs/{costId} [delete]
+func (h *handler) DeleteTripCost(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.DeleteTripCost(ctx, claims.AccountID, c.Param("id"), c.Param("costId"))
+	c.JSON(res.Status, res)
+}
+
+// ExportMonthlyReport godoc
+// @Summary Download monthly operational report
+// @Description Download the monthly ambulance operational report with per-vehicle and per-driver trip counts, categories, distance and costs
+// @Tags Ambulance History
+// @Security BearerAuth
+// @Produce application/pdf
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param month query string false "Report month (YYYY-MM), defaults to the current month"
+// @Param format query string false "pdf or xlsx, defaults to pdf"
+// @Success 200 {file} binary "Report file"
+// @Failure 400 {object} pkg.Response
+// @Router /admin/ambulances/history/reports/monthly [get]
+func (h *handler) ExportMonthlyReport(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var params MonthlyReportQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid query parameters", nil, nil))
+		return
+	}
+
+	content, filename, err := h.service.ExportMonthlyReport(ctx, params)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, err.Error(), nil, nil))
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Header("Content-Transfer-Encoding", "binary")
+	c.Data(http.StatusOK, report.ContentType(filename), content)
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Vilamuzz/yota-backend/app/finance_record"
 	"github.com/Vilamuzz/yota-backend/pkg"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
@@ -20,6 +22,25 @@ type Repository interface {
 	GetDriverMonthlyTrend(ctx context.Context, driverID string, year int) (HistoryMonthlyTrendRecord, error)
 	Update(ctx context.Context, ambulance AmbulanceHistory) error
 	Delete(ctx context.Context, id string) error
+	FindByRequestID(ctx context.Context, requestID string) (AmbulanceHistory, error)
+
+	// Trip costs
+	CreateTripCost(ctx context.Context, cost *TripCost, record *finance_record.FinanceRecord) error
+	FindTripCosts(ctx context.Context, historyID string) ([]TripCost, error)
+	FindOneTripCost(ctx context.Context, historyID, costID string) (TripCost, error)
+	DeleteTripCost(ctx context.Context, id string) error
+
+	// Feedback & incidents
+	CreateFeedback(ctx context.Context, feedback TripFeedback) error
+	FindFeedbackByRequestID(ctx context.Context, requestID string) (TripFeedback, error)
+	FindFeedbacks(ctx context.Context, options map[string]interface{}) ([]TripFeedback, error)
+	CreateIncident(ctx context.Context, incident IncidentReport) error
+	FindIncidents(ctx context.Context, options map[string]interface{}) ([]IncidentReport, error)
+	GetSatisfactionMetrics(ctx context.Context, options map[string]interface{}, startDate, endDate *time.Time) (SatisfactionMetrics, error)
+
+	// Reports
+	GetVehicleBreakdown(ctx context.Context, startDate, endDate time.Time) ([]TripBreakdown, error)
+	GetDriverBreakdown(ctx context.Context, startDate, endDate time.Time) ([]TripBreakdown, error)
 }
 
 type repository struct {
@@ -38,6 +59,7 @@ func (r *repository) FindByID(ctx context.Context, id string) (AmbulanceHistory,
 	var ambulance AmbulanceHistory
 	if err := r.Conn.WithContext(ctx).
 		Preload("Driver.UserProfile").
+		Preload("Costs", activeTripCosts).
 		First(&ambulance, "id = ?", id).Error; err != nil {
 		return AmbulanceHistory{}, err
 	}
@@ -46,7 +68,9 @@ func (r *repository) FindByID(ctx context.Context, id string) (AmbulanceHistory,
 
 func (r *repository) FindAll(ctx context.Context, options map[string]interface{}) ([]AmbulanceHistory, error) {
 	var ambulanceHistories []AmbulanceHistory
-	query := r.Conn.WithContext(ctx).Preload("Driver.UserProfile")
+	query := r.Conn.WithContext(ctx).
+		Preload("Driver.UserProfile").
+		Preload("Costs", activeTripCosts)
 
 	if ambulanceID, ok := options["ambulance_id"]; ok {
 		query = query.Where("ambulance_id = ?", ambulanceID)
@@ -92,7 +116,7 @@ func (r *repository) FindAll(ctx context.Context, options map[string]interface{}
 }
 
 func (r *repository) Update(ctx context.Context, ambulance AmbulanceHistory) error {
-	return r.Conn.Save(&ambulance).Error
+	return r.Conn.Omit("Costs").Save(&ambulance).Error
 }
 
 func (r *repository) Delete(ctx context.Context, id string) error {
@@ -330,3 +354,242 @@ func (r *repository) GetDriverMonthlyTrend(ctx context.Context, driverID string,
 
 	return response, nil
 }
+
+func (r *repository) FindByRequestID(ctx context.Context, requestID string) (AmbulanceHistory, error) {
+	var history AmbulanceHistory
+	if err := r.Conn.WithContext(ctx).
+		Where("request_id = ?", requestID).
+		Order("created_at DESC").
+		First(&history).Error; err != nil {
+		return AmbulanceHistory{}, err
+	}
+	return history, nil
+}
+
+// CreateFeedback returns ErrFeedbackExists when the request has already been rated.
+func (r *repository) CreateFeedback(ctx context.Context, feedback TripFeedback) error {
+	result := r.Conn.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "request_id"}}, DoNothing: true}).
+		Create(&feedback)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrFeedbackExists
+	}
+	return nil
+}
+
+func (r *repository) FindFeedbackByRequestID(ctx context.Context, requestID string) (TripFeedback, error) {
+	var feedback TripFeedback
+	if err := r.Conn.WithContext(ctx).
+		Preload("Driver.UserProfile").
+		Preload("Account.UserProfile").
+		First(&feedback, "request_id = ?", requestID).Error; err != nil {
+		return TripFeedback{}, err
+	}
+	return feedback, nil
+}
+
+func (r *repository) FindFeedbacks(ctx context.Context, options map[string]interface{}) ([]TripFeedback, error) {
+	var feedbacks []TripFeedback
+	query := r.Conn.WithContext(ctx).
+		Preload("Driver.UserProfile").
+		Preload("Account.UserProfile")
+
+	query = applyTripFilters(query, options)
+	query = applyCursor(query, options)
+
+	limit := 10
+	if l, ok := options["limit"]; ok {
+		limit = l.(int)
+	}
+
+	if err := query.Limit(limit + 1).Find(&feedbacks).Error; err != nil {
+		return nil, err
+	}
+	return feedbacks, nil
+}
+
+func (r *repository) CreateIncident(ctx context.Context, incident IncidentReport) error {
+	// Photos are inserted with the report in the same transaction.
+	return r.Conn.WithContext(ctx).Create(&incident).Error
+}
+
+func (r *repository) FindIncidents(ctx context.Context, options map[string]interface{}) ([]IncidentReport, error) {
+	var incidents []IncidentReport
+	query := r.Conn.WithContext(ctx).
+		Preload("Driver.UserProfile").
+		Preload("Photos", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		})
+
+	query = applyTripFilters(query, options)
+	if incidentType, ok := options["type"]; ok && incidentType != "" {
+		query = query.Where("type = ?", incidentType)
+	}
+	query = applyCursor(query, options)
+
+	limit := 10
+	if l, ok := options["limit"]; ok {
+		limit = l.(int)
+	}
+
+	if err := query.Limit(limit + 1).Find(&incidents).Error; err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
+
+func (r *repository) GetSatisfactionMetrics(ctx context.Context, options map[string]interface{}, startDate, endDate *time.Time) (SatisfactionMetrics, error) {
+	var metrics SatisfactionMetrics
+
+	scope := func(query *gorm.DB) *gorm.DB {
+		query = applyTripFilters(query, options)
+		if startDate != nil {
+			query = query.Where("created_at >= ?", *startDate)
+		}
+		if endDate != nil {
+			query = query.Where("created_at <= ?", *endDate)
+		}
+		return query
+	}
+
+	if err := scope(r.Conn.WithContext(ctx).Model(&TripFeedback{})).
+		Select("COUNT(*) AS feedback_count, " +
+			"COALESCE(AVG(driver_rating), 0) AS average_driver_rating, " +
+			"COALESCE(AVG(vehicle_rating), 0) AS average_vehicle_rating").
+		Scan(&metrics).Error; err != nil {
+		return SatisfactionMetrics{}, err
+	}
+
+	if err := scope(r.Conn.WithContext(ctx).Model(&IncidentReport{})).
+		Count(&metrics.IncidentCount).Error; err != nil {
+		return SatisfactionMetrics{}, err
+	}
+	return metrics, nil
+}
+
+// applyTripFilters narrows feedback and incident queries to a request, ambulance or driver.
+func applyTripFilters(query *gorm.DB, options map[string]interface{}) *gorm.DB {
+	if requestID, ok := options["request_id"]; ok && requestID != "" {
+		query = query.Where("request_id = ?", requestID)
+	}
+	if ambulanceID, ok := options["ambulance_id"]; ok && ambulanceID != "" {
+		query = query.Where("ambulance_id = ?", ambulanceID)
+	}
+	if driverID, ok := options["driver_id"]; ok && driverID != "" {
+		query = query.Where("driver_id = ?", driverID)
+	}
+	return query
+}
+
+func applyCursor(query *gorm.DB, options map[string]interface{}) *gorm.DB {
+	if nextCursor, ok := options["next_cursor"]; ok && nextCursor != "" {
+		cursorData, err := pkg.DecodeCursor(nextCursor.(string))
+		if err == nil {
+			query = query.Where("created_at < ? OR (created_at = ? AND id < ?)",
+				cursorData.CreatedAt, cursorData.CreatedAt, cursorData.ID)
+		}
+	} else if prevCursor, ok := options["prev_cursor"]; ok && prevCursor != "" {
+		cursorData, err := pkg.DecodeCursor(prevCursor.(string))
+		if err == nil {
+			query = query.Where("created_at > ? OR (created_at = ? AND id > ?)",
+				cursorData.CreatedAt, cursorData.CreatedAt, cursorData.ID).
+				Order("created_at ASC, id ASC")
+		}
+	}
+
+	if _, usingPrevCursor := options["prev_cursor"]; !usingPrevCursor {
+		query = query.Order("created_at DESC, id DESC")
+	}
+	return query
+}
+
+func activeTripCosts(db *gorm.DB) *gorm.DB {
+	return db.Where("deleted_at IS NULL").Order("incurred_at ASC")
+}
+
+func (r *repository) CreateTripCost(ctx context.Context, cost *TripCost, record *finance_record.FinanceRecord) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(cost).Error; err != nil {
+			return err
+		}
+		if record != nil {
+			if err := tx.Create(record).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *repository) FindTripCosts(ctx context.Context, historyID string) ([]TripCost, error) {
+	var costs []TripCost
+	if err := activeTripCosts(r.Conn.WithContext(ctx)).
+		Where("history_id = ?", historyID).
+		Find(&costs).Error; err != nil {
+		return nil, err
+	}
+	return costs, nil
+}
+
+func (r *repository) FindOneTripCost(ctx context.Context, historyID, costID string) (TripCost, error) {
+	var cost TripCost
+	if err := r.Conn.WithContext(ctx).
+		Where("id = ? AND history_id = ? AND deleted_at IS NULL", costID, historyID).
+		First(&cost).Error; err != nil {
+		return TripCost{}, err
+	}
+	return cost, nil
+}
+
+// DeleteTripCost soft deletes the cost together with its operational expense record.
+func (r *repository) DeleteTripCost(ctx context.Context, id string) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Model(&TripCost{}).Where("id = ?", id).Update("deleted_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Table("finance_records").Where("source_id = ? AND source_type = ?", id, finance_record.SourceTypeExpense).Update("deleted_at", now).Error; err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *repository) GetVehicleBreakdown(ctx context.Context, startDate, endDate time.Time) ([]TripBreakdown, error) {
+	return r.getTripBreakdown(ctx, "h.ambulance_id", "a.plate_number", "LEFT JOIN ambulances a ON a.id = h.ambulance_id", startDate, endDate)
+}
+
+func (r *repository) GetDriverBreakdown(ctx context.Context, startDate, endDate time.Time) ([]TripBreakdown, error) {
+	return r.getTripBreakdown(ctx, "h.driver_id", "up.username", "LEFT JOIN user_profiles up ON up.account_id = h.driver_id", startDate, endDate)
+}
+
+// getTripBreakdown counts trips per category together with distance and running costs, grouped
+// by groupColumn and labelled by labelColumn from the joined table.
+func (r *repository) getTripBreakdown(ctx context.Context, groupColumn, labelColumn, labelJoin string, startDate, endDate time.Time) ([]TripBreakdown, error) {
+	var rows []TripBreakdown
+	err := r.Conn.WithContext(ctx).
+		Table("ambulance_histories h").
+		Select(groupColumn+"::text as id, "+
+			"COALESCE("+labelColumn+", '') as name, "+
+			"COUNT(*) as trips, "+
+			"SUM(CASE WHEN h.service_category = 'social_service' THEN 1 ELSE 0 END) as social_service, "+
+			"SUM(CASE WHEN h.service_category = 'mortuary_service' THEN 1 ELSE 0 END) as mortuary_service, "+
+			"SUM(CASE WHEN h.service_category = 'patient_service' THEN 1 ELSE 0 END) as patient_service, "+
+			"SUM(CASE WHEN h.service_category = 'emergency_service' THEN 1 ELSE 0 END) as emergency_service, "+
+			"SUM(CASE WHEN h.service_category = 'other_service' THEN 1 ELSE 0 END) as other_service, "+
+			"COALESCE(SUM(h.distance_meters), 0) as distance_meters, "+
+			"COALESCE(SUM(tc.total), 0) as total_cost").
+		Joins(labelJoin).
+		Joins("LEFT JOIN (SELECT history_id, SUM(amount) as total FROM trip_costs WHERE deleted_at IS NULL GROUP BY history_id) tc ON tc.history_id = h.id").
+		Where("h.created_at >= ? AND h.created_at <= ?", startDate, endDate).
+		Group(groupColumn + ", " + labelColumn).
+		Order("trips DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
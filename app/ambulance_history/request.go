@@ -1,6 +1,11 @@
 package ambulance_history
 
-import "github.com/Vilamuzz/yota-backend/pkg"
+import (
+	"mime/multipart"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/pkg"
+)
 
 type CreateAmbulanceHistoryRequest struct {
 	AmbulanceID     string          `json:"ambulanceId"`
@@ -29,3 +34,32 @@ type AmbulanceSummaryQueryParams struct {
 type MonthlyTrendQueryParams struct {
 	Year string `form:"year"`
 }
+
+type MonthlyReportQueryParams struct {
+	Month  string `form:"month"`  // YYYY-MM, defaults to the current month
+	Format string `form:"format"` // pdf or xlsx, defaults to pdf
+}
+
+type FeedbackQueryParams struct {
+	RequestID   string `form:"requestId"`
+	AmbulanceID string `form:"ambulanceId"`
+	DriverID    string `form:"driverId"`
+	pkg.PaginationParams
+}
+
+type IncidentQueryParams struct {
+	RequestID   string `form:"requestId"`
+	AmbulanceID string `form:"ambulanceId"`
+	DriverID    string `form:"driverId"`
+	Type        string `form:"type"`
+	pkg.PaginationParams
+}
+
+type CreateTripCostRequest struct {
+	Category      TripCostCategory      `form:"category"`
+	Amount        float64               `form:"amount"`
+	Note          string                `form:"note"`
+	IncurredAt    time.Time             `form:"incurredAt" time_format:"2006-01-02"`
+	PostAsExpense bool                  `form:"postAsExpense"`
+	ReceiptImage  *multipart.FileHeader `form:"receiptImage" swaggerignore:"true"`
+}
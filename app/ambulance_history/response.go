@@ -3,6 +3,7 @@ package ambulance_history
 import (
 	"github.com/Vilamuzz/yota-backend/app/account"
 	"github.com/Vilamuzz/yota-backend/pkg"
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
 	"github.com/google/uuid"
 )
 
@@ -12,10 +13,11 @@ type CategoryCount struct {
 }
 
 type SummaryResponse struct {
-	Total      int64           `json:"total"`
-	Categories []CategoryCount `json:"categories"`
-	StartDate  string          `json:"startDate"`
-	EndDate    string          `json:"endDate"`
+	Total        int64                `json:"total"`
+	Categories   []CategoryCount      `json:"categories"`
+	StartDate    string               `json:"startDate"`
+	EndDate      string               `json:"endDate"`
+	Satisfaction *SatisfactionMetrics `json:"satisfaction,omitempty"`
 }
 
 type HistoryResponse struct {
@@ -34,9 +36,20 @@ type AdminHistoryResponse struct {
 	Driver          account.DriverResponse `json:"driver"`
 	ServiceCategory ServiceCategory        `json:"serviceCategory"`
 	Note            string                 `json:"note"`
+	Route           *RouteSummaryResponse  `json:"route"`
+	TotalCost       float64                `json:"totalCost"`
 	CreatedAt       string                 `json:"createdAt"`
 }
 
+type RouteSummaryResponse struct {
+	RequestID       string  `json:"requestId"`
+	StartedAt       string  `json:"startedAt"`
+	FinishedAt      string  `json:"finishedAt"`
+	DistanceMeters  float64 `json:"distanceMeters"`
+	DurationSeconds int64   `json:"durationSeconds"`
+	RoutePolyline   string  `json:"routePolyline"`
+}
+
 type AdminHistoryListResponse struct {
 	Histories  []AdminHistoryResponse `json:"histories"`
 	Pagination pkg.CursorPagination   `json:"pagination"`
@@ -94,10 +107,32 @@ func (h *AmbulanceHistory) toAdminAmbulanceHistoryToResponse() AdminHistoryRespo
 		Driver:          driver,
 		ServiceCategory: h.ServiceCategory,
 		Note:            h.Note,
+		Route:           h.toRouteSummaryResponse(),
+		TotalCost:       h.TotalCost(),
 		CreatedAt:       h.CreatedAt.Format("2006-01-02 15:04:05"),
 	}
 }
 
+// toRouteSummaryResponse is nil for histories recorded before trip tracking existed.
+func (h *AmbulanceHistory) toRouteSummaryResponse() *RouteSummaryResponse {
+	if h.RequestID == nil {
+		return nil
+	}
+	route := &RouteSummaryResponse{
+		RequestID:       h.RequestID.String(),
+		DistanceMeters:  h.DistanceMeters,
+		DurationSeconds: h.DurationSeconds,
+		RoutePolyline:   h.RoutePolyline,
+	}
+	if h.StartedAt != nil {
+		route.StartedAt = h.StartedAt.Format("2006-01-02 15:04:05")
+	}
+	if h.FinishedAt != nil {
+		route.FinishedAt = h.FinishedAt.Format("2006-01-02 15:04:05")
+	}
+	return route
+}
+
 func toAmbulanceHistoriesToAdminListResponse(histories []AmbulanceHistory, pagination pkg.CursorPagination) AdminHistoryListResponse {
 	var responses []AdminHistoryResponse
 	for _, history := range histories {
@@ -125,3 +160,166 @@ type HistoryMonthlyTrendRecord struct {
 	Year  string                    `json:"year"`
 	Items []HistoryMonthlyTrendItem `json:"items"`
 }
+
+// TripBreakdown is one vehicle or driver row of the monthly operational report.
+type TripBreakdown struct {
+	ID               string  `json:"id"`
+	Name             string  `json:"name"`
+	Trips            int64   `json:"trips"`
+	SocialService    int     `json:"socialService"`
+	MortuaryService  int     `json:"mortuaryService"`
+	PatientService   int     `json:"patientService"`
+	EmergencyService int     `json:"emergencyService"`
+	OtherService     int     `json:"otherService"`
+	DistanceMeters   float64 `json:"distanceMeters"`
+	TotalCost        float64 `json:"totalCost"`
+}
+
+type FeedbackResponse struct {
+	ID            string                 `json:"id"`
+	RequestID     string                 `json:"requestId"`
+	AmbulanceID   string                 `json:"ambulanceId"`
+	Driver        account.DriverResponse `json:"driver"`
+	SubmittedBy   string                 `json:"submittedBy"`
+	DriverRating  int                    `json:"driverRating"`
+	VehicleRating int                    `json:"vehicleRating"`
+	Comment       string                 `json:"comment"`
+	CreatedAt     string                 `json:"createdAt"`
+}
+
+type FeedbackListResponse struct {
+	Feedbacks  []FeedbackResponse   `json:"feedbacks"`
+	Pagination pkg.CursorPagination `json:"pagination"`
+}
+
+type IncidentResponse struct {
+	ID          string                 `json:"id"`
+	RequestID   string                 `json:"requestId"`
+	AmbulanceID string                 `json:"ambulanceId"`
+	Driver      account.DriverResponse `json:"driver"`
+	Type        IncidentType           `json:"type"`
+	Description string                 `json:"description"`
+	OccurredAt  string                 `json:"occurredAt"`
+	Photos      []string               `json:"photos"`
+	CreatedAt   string                 `json:"createdAt"`
+}
+
+type IncidentListResponse struct {
+	Incidents  []IncidentResponse   `json:"incidents"`
+	Pagination pkg.CursorPagination `json:"pagination"`
+}
+
+func toDriverResponse(driverID uuid.UUID, driver account.Account) account.DriverResponse {
+	response := account.DriverResponse{
+		ID:       driverID.String(),
+		Username: "Unknown",
+		Phone:    "-",
+	}
+	if driver.UserProfile.ID != uuid.Nil {
+		response.Username = driver.UserProfile.Username
+		if driver.UserProfile.Phone != nil {
+			response.Phone = *driver.UserProfile.Phone
+		}
+	}
+	return response
+}
+
+func (f *TripFeedback) ToFeedbackResponse() FeedbackResponse {
+	return FeedbackResponse{
+		ID:            f.ID.String(),
+		RequestID:     f.RequestID.String(),
+		AmbulanceID:   f.AmbulanceID.String(),
+		Driver:        toDriverResponse(f.DriverID, f.Driver),
+		SubmittedBy:   f.Account.UserProfile.Username,
+		DriverRating:  f.DriverRating,
+		VehicleRating: f.VehicleRating,
+		Comment:       f.Comment,
+		CreatedAt:     f.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func toFeedbackListResponse(feedbacks []TripFeedback, pagination pkg.CursorPagination) FeedbackListResponse {
+	responses := make([]FeedbackResponse, 0, len(feedbacks))
+	for _, feedback := range feedbacks {
+		responses = append(responses, feedback.ToFeedbackResponse())
+	}
+	return FeedbackListResponse{
+		Feedbacks:  responses,
+		Pagination: pagination,
+	}
+}
+
+func (i *IncidentReport) ToIncidentResponse() IncidentResponse {
+	photos := make([]string, 0, len(i.Photos))
+	for _, photo := range i.Photos {
+		photos = append(photos, s3_pkg.GetCDNURL(photo.URL))
+	}
+	return IncidentResponse{
+		ID:          i.ID.String(),
+		RequestID:   i.RequestID.String(),
+		AmbulanceID: i.AmbulanceID.String(),
+		Driver:      toDriverResponse(i.DriverID, i.Driver),
+		Type:        i.Type,
+		Description: i.Description,
+		OccurredAt:  i.OccurredAt.Format("2006-01-02 15:04:05"),
+		Photos:      photos,
+		CreatedAt:   i.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func toIncidentListResponse(incidents []IncidentReport, pagination pkg.CursorPagination) IncidentListResponse {
+	responses := make([]IncidentResponse, 0, len(incidents))
+	for _, incident := range incidents {
+		responses = append(responses, incident.ToIncidentResponse())
+	}
+	return IncidentListResponse{
+		Incidents:  responses,
+		Pagination: pagination,
+	}
+}
+
+type TripCostResponse struct {
+	ID              string           `json:"id"`
+	HistoryID       string           `json:"historyId"`
+	AmbulanceID     string           `json:"ambulanceId"`
+	Category        TripCostCategory `json:"category"`
+	Amount          float64          `json:"amount"`
+	Note            string           `json:"note"`
+	ReceiptImage    string           `json:"receiptImage"`
+	PostedAsExpense bool             `json:"postedAsExpense"`
+	IncurredAt      string           `json:"incurredAt"`
+	CreatedAt       string           `json:"createdAt"`
+}
+
+type TripCostListResponse struct {
+	Costs     []TripCostResponse `json:"costs"`
+	TotalCost float64            `json:"totalCost"`
+}
+
+func (c *TripCost) toTripCostResponse() TripCostResponse {
+	return TripCostResponse{
+		ID:              c.ID.String(),
+		HistoryID:       c.HistoryID.String(),
+		AmbulanceID:     c.AmbulanceID.String(),
+		Category:        c.Category,
+		Amount:          c.Amount,
+		Note:            c.Note,
+		ReceiptImage:    s3_pkg.GetCDNURL(c.ReceiptImage),
+		PostedAsExpense: c.PostedAsExpense,
+		IncurredAt:      c.IncurredAt.Format("2006-01-02"),
+		CreatedAt:       c.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func toTripCostListResponse(costs []TripCost) TripCostListResponse {
+	responses := make([]TripCostResponse, 0, len(costs))
+	var total float64
+	for _, cost := range costs {
+		responses = append(responses, cost.toTripCostResponse())
+		total += cost.Amount
+	}
+	return TripCostListResponse{
+		Costs:     responses,
+		TotalCost: total,
+	}
+}
@@ -7,8 +7,14 @@ import (
 	"time"
 
 	"github.com/Vilamuzz/yota-backend/app/ambulance"
+	"github.com/Vilamuzz/yota-backend/app/finance_record"
+	app_log "github.com/Vilamuzz/yota-backend/app/log"
 	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/Vilamuzz/yota-backend/pkg/enum"
+	"github.com/Vilamuzz/yota-backend/pkg/report"
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
 	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
@@ -24,18 +30,28 @@ type Service interface {
 	CreateAmbulanceHistory(ctx context.Context, payload CreateAmbulanceHistoryRequest) pkg.Response
 	UpdateAmbulanceHistory(ctx context.Context, id string, payload UpdateAmbulanceHistoryRequest) pkg.Response
 	DeleteAmbulanceHistory(ctx context.Context, id string) pkg.Response
+	ListTripFeedback(ctx context.Context, params FeedbackQueryParams) pkg.Response
+	ListIncidentReports(ctx context.Context, params IncidentQueryParams) pkg.Response
+	ListTripCosts(ctx context.Context, accountID string, role enum.RoleName, historyID string) pkg.Response
+	CreateTripCost(ctx context.Context, accountID string, role enum.RoleName, historyID string, payload CreateTripCostRequest) pkg.Response
+	DeleteTripCost(ctx context.Context, accountID string, historyID, costID string) pkg.Response
+	ExportMonthlyReport(ctx context.Context, params MonthlyReportQueryParams) ([]byte, string, error)
 }
 
 type service struct {
 	repo          Repository
 	ambulanceRepo ambulance.Repository
+	s3Client      s3_pkg.Client
+	logService    app_log.Service
 	timeout       time.Duration
 }
 
-func NewService(repo Repository, ambulanceRepo ambulance.Repository, timeout time.Duration) Service {
+func NewService(repo Repository, ambulanceRepo ambulance.Repository, s3Client s3_pkg.Client, logService app_log.Service, timeout time.Duration) Service {
 	return &service{
 		repo:          repo,
 		ambulanceRepo: ambulanceRepo,
+		s3Client:      s3Client,
+		logService:    logService,
 		timeout:       timeout,
 	}
 }
@@ -183,14 +199,21 @@ func (s *service) AmbulanceHistorySummary(ctx context.Context, ambulanceID strin
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mendapatkan ringkasan riwayat ambulans", nil, nil)
 	}
 
+	satisfaction, err := s.repo.GetSatisfactionMetrics(ctx, map[string]interface{}{"ambulance_id": ambulanceID}, startDate, endDate)
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mendapatkan ringkasan kepuasan layanan ambulans", nil, nil)
+	}
+	satisfaction.round()
+
 	var total int64
 	for _, c := range counts {
 		total += c.Count
 	}
 
 	summary := SummaryResponse{
-		Total:      total,
-		Categories: counts,
+		Total:        total,
+		Categories:   counts,
+		Satisfaction: &satisfaction,
 	}
 	if startDate != nil {
 		summary.StartDate = startDate.Format("2006-01-02")
@@ -410,14 +433,21 @@ func (s *service) DriverHistorySummary(ctx context.Context, driverID string, par
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mendapatkan ringkasan riwayat ambulans supir", nil, nil)
 	}
 
+	satisfaction, err := s.repo.GetSatisfactionMetrics(ctx, map[string]interface{}{"driver_id": driverID}, startDate, endDate)
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mendapatkan ringkasan kepuasan layanan supir", nil, nil)
+	}
+	satisfaction.round()
+
 	var total int64
 	for _, c := range counts {
 		total += c.Count
 	}
 
 	summary := SummaryResponse{
-		Total:      total,
-		Categories: counts,
+		Total:        total,
+		Categories:   counts,
+		Satisfaction: &satisfaction,
 	}
 	if startDate != nil {
 		summary.StartDate = startDate.Format("2006-01-02")
@@ -507,3 +537,444 @@ func (s *service) DriverListAmbulanceHistory(ctx context.Context, queryParams Am
 		Limit:      queryParams.Limit,
 	}))
 }
+
+func (s *service) ListTripFeedback(ctx context.Context, params FeedbackQueryParams) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if params.Limit == 0 {
+		params.Limit = 10
+	}
+
+	options := map[string]interface{}{
+		"limit":        params.Limit,
+		"request_id":   params.RequestID,
+		"ambulance_id": params.AmbulanceID,
+		"driver_id":    params.DriverID,
+		"next_cursor":  params.NextCursor,
+	}
+	if params.PrevCursor != "" {
+		options["prev_cursor"] = params.PrevCursor
+	}
+
+	feedbacks, err := s.repo.FindFeedbacks(ctx, options)
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mendapatkan ulasan layanan ambulans", nil, nil)
+	}
+
+	hasNext := len(feedbacks) > params.Limit
+	if hasNext {
+		feedbacks = feedbacks[:params.Limit]
+	}
+
+	var nextCursor, prevCursor string
+	if hasNext && len(feedbacks) > 0 {
+		last := feedbacks[len(feedbacks)-1]
+		nextCursor = pkg.EncodeCursor(last.CreatedAt, last.ID.String())
+	}
+	if params.PrevCursor != "" && len(feedbacks) > 0 {
+		first := feedbacks[0]
+		prevCursor = pkg.EncodeCursor(first.CreatedAt, first.ID.String())
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Sukses", nil, toFeedbackListResponse(feedbacks, pkg.CursorPagination{
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+		Limit:      params.Limit,
+	}))
+}
+
+func (s *service) ListIncidentReports(ctx context.Context, params IncidentQueryParams) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if params.Type != "" && !IncidentType(params.Type).IsValid() {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"type": "Jenis insiden tidak valid"}, nil)
+	}
+	if params.Limit == 0 {
+		params.Limit = 10
+	}
+
+	options := map[string]interface{}{
+		"limit":        params.Limit,
+		"request_id":   params.RequestID,
+		"ambulance_id": params.AmbulanceID,
+		"driver_id":    params.DriverID,
+		"type":         params.Type,
+		"next_cursor":  params.NextCursor,
+	}
+	if params.PrevCursor != "" {
+		options["prev_cursor"] = params.PrevCursor
+	}
+
+	incidents, err := s.repo.FindIncidents(ctx, options)
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mendapatkan laporan insiden", nil, nil)
+	}
+
+	hasNext := len(incidents) > params.Limit
+	if hasNext {
+		incidents = incidents[:params.Limit]
+	}
+
+	var nextCursor, prevCursor string
+	if hasNext && len(incidents) > 0 {
+		last := incidents[len(incidents)-1]
+		nextCursor = pkg.EncodeCursor(last.CreatedAt, last.ID.String())
+	}
+	if params.PrevCursor != "" && len(incidents) > 0 {
+		first := incidents[0]
+		prevCursor = pkg.EncodeCursor(first.CreatedAt, first.ID.String())
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Sukses", nil, toIncidentListResponse(incidents, pkg.CursorPagination{
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+		Limit:      params.Limit,
+	}))
+}
+
+func (s *service) ListTripCosts(ctx context.Context, accountID string, role enum.RoleName, historyID string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	history, res := s.findTripHistory(ctx, accountID, role, historyID)
+	if history == nil {
+		return res
+	}
+
+	costs, err := s.repo.FindTripCosts(ctx, historyID)
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mendapatkan biaya perjalanan", nil, nil)
+	}
+	return pkg.NewResponse(http.StatusOK, "Sukses", nil, toTripCostListResponse(costs))
+}
+
+func (s *service) CreateTripCost(ctx context.Context, accountID string, role enum.RoleName, historyID string, payload CreateTripCostRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	history, res := s.findTripHistory(ctx, accountID, role, historyID)
+	if history == nil {
+		return res
+	}
+
+	errValidation := make(map[string]string)
+	if !payload.Category.IsValid() {
+		errValidation["category"] = "Kategori biaya tidak valid (fuel, toll, driver_allowance, other)"
+	}
+	if payload.Amount <= 0 {
+		errValidation["amount"] = "Jumlah biaya harus lebih besar dari 0"
+	}
+	if payload.IncurredAt.IsZero() {
+		payload.IncurredAt = history.CreatedAt
+	} else if payload.IncurredAt.After(time.Now()) {
+		errValidation["incurredAt"] = "Tanggal biaya tidak boleh di masa depan"
+	}
+	// Only managers decide what is booked as an operational expense.
+	if payload.PostAsExpense && role != enum.RoleAmbulanceManager {
+		errValidation["postAsExpense"] = "Hanya manajer ambulans yang dapat mencatat sebagai pengeluaran operasional"
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	var receiptURL string
+	if payload.ReceiptImage != nil {
+		uploadedURL, err := s.s3Client.UploadFile(ctx, payload.ReceiptImage, "ambulance-trip-costs")
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":  "ambulance_history.service",
+				"history_id": historyID,
+			}).WithError(err).Error("failed to upload trip cost receipt")
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah bukti biaya", nil, nil)
+		}
+		receiptURL = uploadedURL
+	}
+
+	now := time.Now()
+	cost := &TripCost{
+		ID:              uuid.New(),
+		HistoryID:       history.ID,
+		AmbulanceID:     history.AmbulanceID,
+		Category:        payload.Category,
+		Amount:          payload.Amount,
+		Note:            payload.Note,
+		ReceiptImage:    receiptURL,
+		PostedAsExpense: payload.PostAsExpense,
+		RecordedBy:      uuid.MustParse(accountID),
+		IncurredAt:      payload.IncurredAt,
+		CreatedAt:       now,
+	}
+
+	var record *finance_record.FinanceRecord
+	if payload.PostAsExpense {
+		record = &finance_record.FinanceRecord{
+			ID:              uuid.New().String(),
+			FundType:        finance_record.FundTypeAmbulanceOperational,
+			FundID:          history.AmbulanceID.String(),
+			SourceType:      finance_record.SourceTypeExpense,
+			SourceID:        cost.ID.String(),
+			Amount:          cost.Amount,
+			TransactionDate: cost.IncurredAt,
+			CreatedAt:       now,
+		}
+	}
+
+	if err := s.repo.CreateTripCost(ctx, cost, record); err != nil {
+		if receiptURL != "" {
+			_ = s.s3Client.DeleteFile(ctx, s3_pkg.ExtractObjectNameFromURL(receiptURL))
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "ambulance_history.service",
+			"history_id": historyID,
+		}).WithError(err).Error("failed to create trip cost")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menyimpan biaya perjalanan", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "CREATE", "ambulance_trip_cost", cost.ID.String(), nil, cost.toTripCostResponse())
+
+	return pkg.NewResponse(http.StatusCreated, "Biaya perjalanan berhasil dicatat", nil, cost.toTripCostResponse())
+}
+
+func (s *service) DeleteTripCost(ctx context.Context, accountID string, historyID, costID string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := uuid.Validate(costID); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"costId": "Format ID biaya tidak valid"}, nil)
+	}
+	history, res := s.findTripHistory(ctx, accountID, enum.RoleAmbulanceManager, historyID)
+	if history == nil {
+		return res
+	}
+
+	cost, err := s.repo.FindOneTripCost(ctx, historyID, costID)
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
+			return pkg.NewResponse(http.StatusNotFound, "Biaya perjalanan tidak ditemukan", nil, nil)
+		}
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mendapatkan biaya perjalanan", nil, nil)
+	}
+
+	if err := s.repo.DeleteTripCost(ctx, costID); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "ambulance_history.service",
+			"cost_id":   costID,
+		}).WithError(err).Error("failed to delete trip cost")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menghapus biaya perjalanan", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "DELETE", "ambulance_trip_cost", costID, cost.toTripCostResponse(), nil)
+
+	return pkg.NewResponse(http.StatusOK, "Biaya perjalanan berhasil dihapus", nil, nil)
+}
+
+// findTripHistory loads a history for cost tracking. Drivers may only touch trips they drove.
+func (s *service) findTripHistory(ctx context.Context, accountID string, role enum.RoleName, historyID string) (*AmbulanceHistory, pkg.Response) {
+	if err := uuid.Validate(historyID); err != nil {
+		return nil, pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID riwayat tidak valid"}, nil)
+	}
+
+	history, err := s.repo.FindByID(ctx, historyID)
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
+			return nil, pkg.NewResponse(http.StatusNotFound, "Riwayat ambulans tidak ditemukan", nil, nil)
+		}
+		return nil, pkg.NewResponse(http.StatusInternalServerError, "Gagal mendapatkan riwayat ambulans", nil, nil)
+	}
+
+	if role == enum.RoleAmbulanceDriver && history.DriverID.String() != accountID {
+		return nil, pkg.NewResponse(http.StatusForbidden, "Anda tidak mengemudikan perjalanan ini", nil, nil)
+	}
+	return &history, pkg.Response{}
+}
+
+// monthlyReportData is everything the monthly operational report prints, gathered once and
+// rendered as PDF or XLSX.
+type monthlyReportData struct {
+	month      time.Time
+	categories []CategoryCount
+	trend      HistoryMonthlyTrendRecord
+	vehicles   []TripBreakdown
+	drivers    []TripBreakdown
+}
+
+func (s *service) ExportMonthlyReport(ctx context.Context, params MonthlyReportQueryParams) ([]byte, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	now := time.Now()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	if params.Month != "" {
+		parsed, err := time.ParseInLocation("2006-01", params.Month, now.Location())
+		if err != nil {
+			return nil, "", fmt.Errorf("format month tidak valid: %s (diharapkan YYYY-MM)", params.Month)
+		}
+		month = parsed
+	}
+	if params.Format == "" {
+		params.Format = "pdf"
+	}
+	if params.Format != "pdf" && params.Format != "xlsx" {
+		return nil, "", fmt.Errorf("format laporan harus pdf atau xlsx")
+	}
+
+	startDate := month
+	endDate := month.AddDate(0, 1, 0).Add(-time.Nanosecond)
+
+	data := monthlyReportData{month: month}
+	var err error
+	if data.categories, err = s.repo.GetAllSummary(ctx, &startDate, &endDate); err != nil {
+		return nil, "", s.monthlyReportError(err, "summary")
+	}
+	if data.trend, err = s.repo.GetMonthlyTrend(ctx, month.Year()); err != nil {
+		return nil, "", s.monthlyReportError(err, "monthly trend")
+	}
+	if data.vehicles, err = s.repo.GetVehicleBreakdown(ctx, startDate, endDate); err != nil {
+		return nil, "", s.monthlyReportError(err, "vehicle breakdown")
+	}
+	if data.drivers, err = s.repo.GetDriverBreakdown(ctx, startDate, endDate); err != nil {
+		return nil, "", s.monthlyReportError(err, "driver breakdown")
+	}
+
+	filename := fmt.Sprintf("ambulance_report_%s.%s", month.Format("2006_01"), params.Format)
+	if params.Format == "xlsx" {
+		content, err := data.xlsx()
+		if err != nil {
+			return nil, "", s.monthlyReportError(err, "xlsx")
+		}
+		return content, filename, nil
+	}
+	return data.pdf(), filename, nil
+}
+
+func (s *service) monthlyReportError(err error, step string) error {
+	logrus.WithFields(logrus.Fields{
+		"component": "ambulance_history.service",
+		"step":      step,
+	}).WithError(err).Error("failed to build monthly report")
+	return fmt.Errorf("gagal membuat laporan bulanan ambulans")
+}
+
+func (d monthlyReportData) totals() (int64, float64, float64) {
+	var trips int64
+	var distance, cost float64
+	for _, v := range d.vehicles {
+		trips += v.Trips
+		distance += v.DistanceMeters
+		cost += v.TotalCost
+	}
+	return trips, distance, cost
+}
+
+func breakdownName(b TripBreakdown) string {
+	if b.Name != "" {
+		return b.Name
+	}
+	return b.ID
+}
+
+func (d monthlyReportData) pdf() []byte {
+	trips, distance, cost := d.totals()
+
+	doc := report.NewPDF()
+	doc.Title("LAPORAN OPERASIONAL AMBULANS")
+	doc.Field("Periode", d.month.Format("01-2006"))
+	doc.Field("Dicetak", time.Now().Format("02-01-2006 15:04"))
+
+	doc.Heading("Ringkasan")
+	doc.Field("Total Perjalanan", fmt.Sprintf("%d", trips))
+	doc.Field("Total Jarak", fmt.Sprintf("%.1f km", distance/1000))
+	doc.Field("Total Biaya", fmt.Sprintf("Rp %.0f", cost))
+
+	doc.Heading("Perjalanan per Kategori")
+	rows := make([][]string, 0, len(d.categories))
+	for _, c := range d.categories {
+		rows = append(rows, []string{c.Category.Label(), fmt.Sprintf("%d", c.Count)})
+	}
+	doc.Table([]string{"Kategori", "Jumlah"}, rows)
+
+	breakdownHeaders := func(first string) []string {
+		return []string{first, "Perjalanan", "Sosial", "Jenazah", "Pasien", "Darurat", "Lainnya", "Jarak (km)", "Biaya (Rp)"}
+	}
+	breakdownRows := func(items []TripBreakdown) [][]string {
+		rows := make([][]string, 0, len(items))
+		for _, b := range items {
+			rows = append(rows, []string{
+				breakdownName(b),
+				fmt.Sprintf("%d", b.Trips),
+				fmt.Sprintf("%d", b.SocialService),
+				fmt.Sprintf("%d", b.MortuaryService),
+				fmt.Sprintf("%d", b.PatientService),
+				fmt.Sprintf("%d", b.EmergencyService),
+				fmt.Sprintf("%d", b.OtherService),
+				fmt.Sprintf("%.1f", b.DistanceMeters/1000),
+				fmt.Sprintf("%.0f", b.TotalCost),
+			})
+		}
+		return rows
+	}
+
+	doc.Heading("Per Kendaraan")
+	doc.Table(breakdownHeaders("No. Polisi"), breakdownRows(d.vehicles))
+
+	doc.Heading("Per Pengemudi")
+	doc.Table(breakdownHeaders("Pengemudi"), breakdownRows(d.drivers))
+
+	doc.Heading(fmt.Sprintf("Tren Bulanan %s", d.trend.Year))
+	trendRows := make([][]string, 0, len(d.trend.Items))
+	for _, item := range d.trend.Items {
+		total := item.SocialService + item.MortuaryService + item.PatientService + item.EmergencyService + item.OtherService
+		trendRows = append(trendRows, []string{
+			item.Month,
+			fmt.Sprintf("%d", item.SocialService),
+			fmt.Sprintf("%d", item.MortuaryService),
+			fmt.Sprintf("%d", item.PatientService),
+			fmt.Sprintf("%d", item.EmergencyService),
+			fmt.Sprintf("%d", item.OtherService),
+			fmt.Sprintf("%d", total),
+		})
+	}
+	doc.Table([]string{"Bulan", "Sosial", "Jenazah", "Pasien", "Darurat", "Lainnya", "Total"}, trendRows)
+
+	return doc.Bytes()
+}
+
+func (d monthlyReportData) xlsx() ([]byte, error) {
+	trips, distance, cost := d.totals()
+
+	wb := report.NewWorkbook()
+
+	summary := wb.AddSheet("Ringkasan")
+	summary.AddRow("Keterangan", "Nilai")
+	summary.AddRow("Periode", d.month.Format("2006-01"))
+	summary.AddRow("Total Perjalanan", trips)
+	summary.AddRow("Total Jarak (km)", distance/1000)
+	summary.AddRow("Total Biaya (Rp)", cost)
+	summary.AddRow()
+	summary.AddRow("Kategori", "Jumlah")
+	for _, c := range d.categories {
+		summary.AddRow(c.Category.Label(), c.Count)
+	}
+
+	addBreakdown := func(name, first string, items []TripBreakdown) {
+		sheet := wb.AddSheet(name)
+		sheet.AddRow("ID", first, "Perjalanan", "Sosial", "Jenazah", "Pasien", "Darurat", "Lainnya", "Jarak (km)", "Biaya (Rp)")
+		for _, b := range items {
+			sheet.AddRow(b.ID, breakdownName(b), b.Trips, b.SocialService, b.MortuaryService, b.PatientService,
+				b.EmergencyService, b.OtherService, b.DistanceMeters/1000, b.TotalCost)
+		}
+	}
+	addBreakdown("Per Kendaraan", "No. Polisi", d.vehicles)
+	addBreakdown("Per Pengemudi", "Pengemudi", d.drivers)
+
+	trend := wb.AddSheet("Tren " + d.trend.Year)
+	trend.AddRow("Bulan", "Sosial", "Jenazah", "Pasien", "Darurat", "Lainnya", "Total")
+	for _, item := range d.trend.Items {
+		total := item.SocialService + item.MortuaryService + item.PatientService + item.EmergencyService + item.OtherService
+		trend.AddRow(item.Month, item.SocialService, item.MortuaryService, item.PatientService, item.EmergencyService, item.OtherService, total)
+	}
+
+	return wb.Bytes()
+}
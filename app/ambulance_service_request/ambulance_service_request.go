@@ -1,26 +1,29 @@
 package ambulance_service_request
 
 import (
+	"errors"
 	"time"
 
 	"github.com/Vilamuzz/yota-backend/app/account"
 	"github.com/Vilamuzz/yota-backend/app/ambulance"
 	"github.com/Vilamuzz/yota-backend/app/ambulance_history"
+	"github.com/Vilamuzz/yota-backend/pkg/pii"
 	"github.com/google/uuid"
 )
 
 type AmbulanceServiceRequest struct {
 	ID                uuid.UUID                         `json:"id" gorm:"primaryKey"`
 	SubmittedBy       uuid.UUID                         `json:"submittedBy" gorm:"not null"`
-	AmbulanceID       *uuid.UUID                        `json:"ambulanceId"`
+	AmbulanceID       *uuid.UUID                        `json:"ambulanceId" gorm:"index:idx_ambulance_schedule,priority:1"`
+	DriverID          *uuid.UUID                        `json:"driverId" gorm:"index"` // assigned per trip on accept
 	SubmitterName     string                            `json:"submitterName"`
-	SubmitterPhone    string                            `json:"submitterPhone"`
+	SubmitterPhone    pii.String                        `json:"submitterPhone"`
 	SubmitterIDCard   string                            `json:"submitterIdCard"`
-	PatientName       string                            `json:"patientName"`
-	PatientAddress    string                            `json:"patientAddress"`
+	PatientName       string                            `json:"patientName"` // kept in plaintext for the admin search
+	PatientAddress    pii.String                        `json:"patientAddress"`
 	PatientAge        int                               `json:"patientAge"`
 	IsInfectious      bool                              `json:"isInfectious"`
-	Disease           string                            `json:"disease"`
+	Disease           pii.String                        `json:"disease"`
 	IsAbleToSit       bool                              `json:"isAbleToSit"`
 	PickupDate        time.Time                         `json:"pickupDate"`
 	PickupTime        time.Time                         `json:"pickupTime"`
@@ -30,10 +33,17 @@ type AmbulanceServiceRequest struct {
 	ServiceCategory   ambulance_history.ServiceCategory `json:"serviceCategory"`
 	RejectionReason   string                            `json:"rejectionReason"`
 	CancelationReason string                            `json:"cancelationReason"`
+	ScheduledStart    *time.Time                        `json:"scheduledStart" gorm:"index:idx_ambulance_schedule,priority:2"`
+	ScheduledEnd      *time.Time                        `json:"scheduledEnd" gorm:"index:idx_ambulance_schedule,priority:3"`
+	StartedAt         *time.Time                        `json:"startedAt"`
+	ClaimedAt         *time.Time                        `json:"claimedAt"`                        // emergency requests claimed by a driver
+	LastNotifiedAt    *time.Time                        `json:"lastNotifiedAt"`                   // last emergency dispatch notification
+	EscalationLevel   int                               `json:"escalationLevel" gorm:"default:0"` // times an unclaimed emergency was escalated
 	CreatedAt         time.Time                         `json:"createdAt"`
 	UpdatedAt         time.Time                         `json:"updatedAt"`
 
 	Ambulance *ambulance.Ambulance `json:"ambulance" gorm:"foreignKey:AmbulanceID"`
+	Driver    *account.Account     `json:"driver" gorm:"foreignKey:DriverID"`
 	Account   account.Account      `json:"account" gorm:"foreignKey:SubmittedBy"`
 }
 
@@ -47,3 +57,41 @@ const (
 	StatusInService Status = "in_service"
 	StatusDone      Status = "done"
 )
+
+// DocumentSubmitterIDCard is the only sensitive upload of a request, served from private storage.
+const DocumentSubmitterIDCard = "submitterIdCard"
+
+// ErrScheduleConflict is returned when an ambulance is already booked for an overlapping window.
+var ErrScheduleConflict = errors.New("ambulance schedule conflict")
+
+// ErrDriverConflict is returned when the driver is already assigned to an overlapping trip.
+var ErrDriverConflict = errors.New("driver schedule conflict")
+
+// bookingStatuses are the request states that occupy an ambulance's calendar.
+var bookingStatuses = []Status{StatusAccepted, StatusInService}
+
+// PickupAt combines the separately stored pickup date and time of day.
+func (a *AmbulanceServiceRequest) PickupAt() time.Time {
+	return combineDateAndTime(a.PickupDate, a.PickupTime)
+}
+
+// ScheduleWindow is the calendar slot the request blocks once accepted.
+func (a *AmbulanceServiceRequest) ScheduleWindow() (time.Time, time.Time) {
+	start := a.PickupAt()
+	return start, start.Add(a.ServiceCategory.EstimatedDuration())
+}
+
+// IsDrivenBy reports whether the driver is assigned to the trip. Requests accepted before drivers
+// were assigned per trip fall back to the ambulance's default driver.
+func (a *AmbulanceServiceRequest) IsDrivenBy(driverID string) bool {
+	if a.DriverID != nil {
+		return a.DriverID.String() == driverID
+	}
+	return a.Ambulance != nil && a.Ambulance.DriverID.String() == driverID
+}
+
+func combineDateAndTime(date, clock time.Time) time.Time {
+	d := date.UTC()
+	t := clock.UTC()
+	return time.Date(d.Year(), d.Month(), d.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+}
@@ -0,0 +1,67 @@
+package ambulance_service_request
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/ambulance_history"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxEmergencyEscalations stops re-notifying once an emergency has been escalated this many times.
+	maxEmergencyEscalations = 3
+	// openEmergencyLimit caps the list of unclaimed emergencies shown to drivers.
+	openEmergencyLimit = 50
+)
+
+// Contact is a staff member who receives dispatch notifications.
+type Contact struct {
+	AccountID uuid.UUID
+	Email     string
+	Username  string
+	Phone     *string
+}
+
+// IsEmergency reports whether the request goes through the emergency dispatch fast lane.
+func (a *AmbulanceServiceRequest) IsEmergency() bool {
+	return a.ServiceCategory == ambulance_history.EmergencyService
+}
+
+// dispatchDetails summarises an emergency for notifications. Only what a driver needs to respond is included.
+func (a *AmbulanceServiceRequest) dispatchDetails() []string {
+	details := []string{
+		fmt.Sprintf("Pengaju: %s (%s)", a.SubmitterName, a.SubmitterPhone),
+		fmt.Sprintf("Alamat penjemputan: %s", a.PatientAddress),
+	}
+	if a.Disease != "" {
+		details = append(details, fmt.Sprintf("Keluhan: %s", a.Disease))
+	}
+	if a.IsInfectious {
+		details = append(details, "Pasien berpotensi menular")
+	}
+	if !a.IsAbleToSit {
+		details = append(details, "Pasien tidak dapat duduk")
+	}
+	if a.Note != "" {
+		details = append(details, fmt.Sprintf("Catatan: %s", a.Note))
+	}
+	details = append(details, fmt.Sprintf("Dibuat: %s", a.CreatedAt.Format("2006-01-02 15:04")))
+	return details
+}
+
+// dispatchMessage is the short text sent over WhatsApp.
+func (a *AmbulanceServiceRequest) dispatchMessage(escalationLevel int) string {
+	prefix := "[DARURAT]"
+	if escalationLevel > 0 {
+		prefix = fmt.Sprintf("[DARURAT - ESKALASI %d]", escalationLevel)
+	}
+	return fmt.Sprintf("%s Permintaan ambulans darurat belum diklaim. Alamat: %s. Kontak: %s (%s). Buka aplikasi untuk mengklaim.",
+		prefix, a.PatientAddress, a.SubmitterName, a.SubmitterPhone)
+}
+
+// emergencyWindow is the calendar slot an emergency blocks when it is claimed at the given time.
+func (a *AmbulanceServiceRequest) emergencyWindow(claimedAt time.Time) (time.Time, time.Time) {
+	start := claimedAt.UTC().Truncate(time.Minute)
+	return start, start.Add(a.ServiceCategory.EstimatedDuration())
+}
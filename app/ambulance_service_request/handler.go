@@ -1,7 +1,9 @@
 package ambulance_service_request
 
 import (
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/Vilamuzz/yota-backend/app/middleware"
 	"github.com/Vilamuzz/yota-backend/pkg"
@@ -31,15 +33,26 @@ func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
 		public.GET("", h.ListMyAmbulanceServiceRequests)
 		public.GET("/:id", h.GetMyAmbulanceServiceRequestByID)
 		public.PATCH("/:id/cancel", h.CancelAmbulanceServiceRequest)
+		public.GET("/:id/track", h.GetTripTrack)
+		public.GET("/:id/track/stream", h.StreamTripTrack)
+		public.POST("/:id/feedback", h.SubmitTripFeedback)
+		public.GET("/:id/documents/:document", h.GetRequestDocument)
 	}
 
 	ambulanceManager := r.Group("/admin/ambulances/requests")
 	ambulanceManager.Use(h.middleware.RequireRoles(enum.RoleAmbulanceManager))
 	{
 		ambulanceManager.GET("", h.ListAmbulanceServiceRequests)
+		ambulanceManager.GET("/availability", h.GetAmbulanceAvailability)
+		ambulanceManager.GET("/calendar", h.GetAmbulanceCalendar)
 		ambulanceManager.GET("/:id", h.GetAmbulanceServiceRequestByID)
+		ambulanceManager.GET("/:id/driver-suggestions", h.GetDriverSuggestions)
 		ambulanceManager.PATCH("/:id/accept", h.AcceptAmbulanceServiceRequest)
 		ambulanceManager.PATCH("/:id/reject", h.RejectAmbulanceServiceRequest)
+		ambulanceManager.GET("/:id/track", h.GetTripTrack)
+		ambulanceManager.GET("/:id/track/stream", h.StreamTripTrack)
+		ambulanceManager.GET("/:id/trip-sheet", h.ExportTripSheetPDF)
+		ambulanceManager.GET("/:id/documents/:document", h.GetRequestDocument)
 	}
 
 	ambulanceDriver := r.Group("/admin/ambulances/requests/assigned")
@@ -49,7 +62,17 @@ func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
 		ambulanceDriver.GET("/:id/detail", h.GetAssignedAmbulanceServiceRequestByID)
 		ambulanceDriver.PATCH("/:id/start", h.StartAmbulanceServiceRequest)
 		ambulanceDriver.PATCH("/:id/complete", h.CompleteAmbulanceServiceRequest)
+		ambulanceDriver.POST("/:id/locations", h.middleware.CustomRateLimitHandler(30, time.Minute), h.RecordTripLocations)
 		ambulanceDriver.PATCH("/:id/cancel", h.DriverCancelAmbulanceServiceRequest)
+		ambulanceDriver.POST("/:id/incidents", h.ReportTripIncident)
+		ambulanceDriver.GET("/:id/trip-sheet", h.ExportAssignedTripSheetPDF)
+	}
+
+	emergencyDriver := r.Group("/admin/ambulances/requests/emergency")
+	emergencyDriver.Use(h.middleware.RequireRoles(enum.RoleAmbulanceDriver))
+	{
+		emergencyDriver.GET("", h.ListOpenEmergencies)
+		emergencyDriver.PATCH("/:id/claim", h.ClaimEmergencyRequest)
 	}
 }
 
@@ -210,7 +233,7 @@ func (h *handler) GetAssignedAmbulanceServiceRequestByID(c *gin.Context) {
 // @Accept multipart/form-data
 // @Produce json
 // @Param payload formData CreateAmbulanceServiceRequest true "Ambulance service request details"
-// @Param submitterIdCard formData file true "Submitter ID Card Image"
+// @Param submitterIdCard formData file false "Submitter ID Card Image (optional for emergency_service)"
 // @Success 200 {object} pkg.Response
 // @Failure 400 {object} pkg.Response
 // @Failure 500 {object} pkg.Response
@@ -231,14 +254,15 @@ func (h *handler) CreateAmbulanceServiceRequest(c *gin.Context) {
 // AcceptAmbulanceService
 //
 // @Summary Accept Ambulance Service Request
-// @Description Accept an ambulance service request and assign it to an ambulance
+// @Description Accept an ambulance service request and assign it to an ambulance and a driver. Without driverId the on-shift driver with the fewest recent trips is assigned
 // @Tags Ambulance Service Requests
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path string true "Ambulance Request ID"
-// @Param payload body AcceptAmbulanceServiceRequestPayload true "Ambulance ID to assign"
-// @Success 200 {object} pkg.Response
+// @Param payload body AcceptAmbulanceServiceRequestPayload true "Ambulance and optional driver to assign"
+// @Success 200 {object} pkg.Response{data=AcceptedAssignmentResponse}
+// @Failure 409 {object} pkg.Response{data=DriverSuggestionListResponse}
 // @Router /admin/ambulances/requests/{id}/accept [patch]
 func (h *handler) AcceptAmbulanceServiceRequest(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -330,6 +354,112 @@ func (h *handler) DriverCancelAmbulanceServiceRequest(c *gin.Context) {
 	c.JSON(res.Status, res)
 }
 
+// SubmitTripFeedback godoc
+// @Summary Rate a finished trip
+// @Description Rate the driver and vehicle of a finished trip, once per request and within 30 days of completion
+// @Tags Ambulance Service Requests
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Ambulance Service Request ID"
+// @Param request body TripFeedbackPayload true "Ratings (1-5) and comment"
+// @Success 201 {object} pkg.Response{data=ambulance_history.FeedbackResponse}
+// @Failure 400 {object} pkg.Response
+// @Failure 403 {object} pkg.Response
+// @Failure 409 {object} pkg.Response
+// @Router /ambulances/requests/{id}/feedback [post]
+func (h *handler) SubmitTripFeedback(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	id := c.Param("id")
+
+	var payload TripFeedbackPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Payload request tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.SubmitTripFeedback(ctx, claims.AccountID, id, payload)
+	c.JSON(res.Status, res)
+}
+
+// ReportTripIncident godoc
+// @Summary Report trip incident
+// @Description File an incident (vehicle damage, patient condition change, delay) for an assigned trip, with up to 5 photos
+// @Tags Ambulance Service Requests
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Ambulance Service Request ID"
+// @Param type formData string true "vehicle_damage, patient_condition, delay or other"
+// @Param description formData string true "What happened"
+// @Param occurredAt formData string false "When it happened (RFC3339, default now)"
+// @Param photos[] formData file false "Photos"
+// @Success 201 {object} pkg.Response{data=ambulance_history.IncidentResponse}
+// @Failure 400 {object} pkg.Response
+// @Failure 403 {object} pkg.Response
+// @Router /admin/ambulances/requests/assigned/{id}/incidents [post]
+func (h *handler) ReportTripIncident(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	id := c.Param("id")
+
+	var payload TripIncidentPayload
+	if err := c.ShouldBind(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Payload request tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.ReportTripIncident(ctx, claims.AccountID, id, payload)
+	c.JSON(res.Status, res)
+}
+
+// ListOpenEmergencies godoc
+// @Summary List unclaimed emergency requests
+// @Description Get pending emergency ambulance requests that no driver has claimed yet, oldest first
+// @Tags Ambulance Service Requests
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} pkg.Response{data=[]AmbulanceServiceRequestResponse}
+// @Router /admin/ambulances/requests/emergency [get]
+func (h *handler) ListOpenEmergencies(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	res := h.service.ListOpenEmergencies(ctx)
+	c.JSON(res.Status, res)
+}
+
+// ClaimEmergencyRequest godoc
+// @Summary Claim emergency request
+// @Description Claim an unclaimed emergency request as the responding driver. The first driver to claim wins; later claims get 409. The driver's own ambulance is used when no ambulance is given
+// @Tags Ambulance Service Requests
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Ambulance Service Request ID"
+// @Param request body ClaimEmergencyPayload false "Ambulance to use"
+// @Success 200 {object} pkg.Response{data=AcceptedAssignmentResponse}
+// @Failure 400 {object} pkg.Response
+// @Failure 404 {object} pkg.Response
+// @Failure 409 {object} pkg.Response
+// @Router /admin/ambulances/requests/emergency/{id}/claim [patch]
+func (h *handler) ClaimEmergencyRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	id := c.Param("id")
+
+	var payload ClaimEmergencyPayload
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Payload request tidak valid", nil, nil))
+			return
+		}
+	}
+
+	res := h.service.ClaimEmergencyRequest(ctx, claims.AccountID, id, payload)
+	c.JSON(res.Status, res)
+}
+
 func (h *handler) StartAmbulanceServiceRequest(c *gin.Context) {
 	ctx := c.Request.Context()
 	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
@@ -347,3 +477,251 @@ func (h *handler) CompleteAmbulanceServiceRequest(c *gin.Context) {
 	res := h.service.CompleteAmbulanceServiceRequest(ctx, claims.AccountID, id)
 	c.JSON(res.Status, res)
 }
+
+// GetAmbulanceAvailability godoc
+// @Summary Get ambulance availability
+// @Description Check which ambulances are free for a time slot. When endTime is omitted the estimated duration of serviceCategory is used
+// @Tags Ambulance Service Requests
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param date query string true "Date (YYYY-MM-DD)"
+// @Param startTime query string true "Start time (HH:MM)"
+// @Param endTime query string false "End time (HH:MM)"
+// @Param serviceCategory query string false "Service category used to estimate the duration"
+// @Success 200 {object} pkg.Response{data=AvailabilityListResponse}
+// @Failure 400 {object} pkg.Response
+// @Failure 500 {object} pkg.Response
+// @Router /admin/ambulances/requests/availability [get]
+func (h *handler) GetAmbulanceAvailability(c *gin.Context) {
+	ctx := c.Request.Context()
+	var queryParams AmbulanceAvailabilityQueryParams
+	if err := c.ShouldBindQuery(&queryParams); err != nil {
+		c.JSON(400, pkg.NewResponse(400, "Invalid query parameters", nil, nil))
+		return
+	}
+	res := h.service.GetAmbulanceAvailability(ctx, queryParams)
+	c.JSON(res.Status, res)
+}
+
+// GetAmbulanceCalendar godoc
+// @Summary Get ambulance booking calendar
+// @Description Get accepted and in-service bookings within a date range, optionally for a single ambulance. Defaults to one week starting today
+// @Tags Ambulance Service Requests
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param ambulanceId query string false "Ambulance ID"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date, inclusive (YYYY-MM-DD)"
+// @Success 200 {object} pkg.Response{data=CalendarResponse}
+// @Failure 400 {object} pkg.Response
+// @Failure 500 {object} pkg.Response
+// @Router /admin/ambulances/requests/calendar [get]
+func (h *handler) GetAmbulanceCalendar(c *gin.Context) {
+	ctx := c.Request.Context()
+	var queryParams AmbulanceCalendarQueryParams
+	if err := c.ShouldBindQuery(&queryParams); err != nil {
+		c.JSON(400, pkg.NewResponse(400, "Invalid query parameters", nil, nil))
+		return
+	}
+	res := h.service.GetAmbulanceCalendar(ctx, queryParams)
+	c.JSON(res.Status, res)
+}
+
+// GetDriverSuggestions godoc
+// @Summary Suggest drivers for a request
+// @Description Get drivers who are free for the request's time window, on-shift drivers first and then by fewest trips in the last 30 days
+// @Tags Ambulance Service Requests
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Ambulance Request ID"
+// @Success 200 {object} pkg.Response{data=DriverSuggestionListResponse}
+// @Failure 400 {object} pkg.Response
+// @Failure 404 {object} pkg.Response
+// @Router /admin/ambulances/requests/{id}/driver-suggestions [get]
+func (h *handler) GetDriverSuggestions(c *gin.Context) {
+	ctx := c.Request.Context()
+	res := h.service.GetDriverSuggestions(ctx, c.Param("id"))
+	c.JSON(res.Status, res)
+}
+
+// RecordTripLocations godoc
+// @Summary Record trip locations
+// @Description Upload a batch of GPS points from the driver app while the request is in service
+// @Tags Ambulance Service Requests
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Ambulance Request ID"
+// @Param request body RecordTripLocationsPayload true "GPS points"
+// @Success 201 {object} pkg.Response
+// @Failure 400 {object} pkg.Response
+// @Failure 403 {object} pkg.Response
+// @Failure 404 {object} pkg.Response
+// @Failure 500 {object} pkg.Response
+// @Router /admin/ambulances/requests/assigned/{id}/locations [post]
+func (h *handler) RecordTripLocations(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	id := c.Param("id")
+
+	var payload RecordTripLocationsPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.RecordTripLocations(ctx, claims.AccountID, id, payload)
+	c.JSON(res.Status, res)
+}
+
+// GetTripTrack godoc
+// @Summary Get trip track
+// @Description Get the recorded GPS track and latest position of an ambulance trip. Requesters can only see their own requests
+// @Tags Ambulance Service Requests
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Ambulance Request ID"
+// @Success 200 {object} pkg.Response{data=TripTrackResponse}
+// @Failure 400 {object} pkg.Response
+// @Failure 403 {object} pkg.Response
+// @Failure 404 {object} pkg.Response
+// @Failure 500 {object} pkg.Response
+// @Router /ambulances/requests/{id}/track [get]
+// @Router /admin/ambulances/requests/{id}/track [get]
+func (h *handler) GetTripTrack(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	id := c.Param("id")
+
+	res := h.service.GetTripTrack(ctx, claims.AccountID, claims.ActiveRole, id)
+	c.JSON(res.Status, res)
+}
+
+// StreamTripTrack godoc
+// @Summary Stream live trip position
+// @Description Server-Sent Events stream of the ambulance position while the request is in service. Emits a "snapshot" event first, then "location" events, and ends with "completed" or "cancelled"
+// @Tags Ambulance Service Requests
+// @Security BearerAuth
+// @Produce text/event-stream
+// @Param id path string true "Ambulance Request ID"
+// @Success 200 {string} string "event stream"
+// @Failure 400 {object} pkg.Response
+// @Failure 403 {object} pkg.Response
+// @Failure 404 {object} pkg.Response
+// @Router /ambulances/requests/{id}/track/stream [get]
+// @Router /admin/ambulances/requests/{id}/track/stream [get]
+func (h *handler) StreamTripTrack(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	id := c.Param("id")
+
+	events, stop, res := h.service.StreamTripEvents(ctx, claims.AccountID, claims.ActiveRole, id)
+	if res.Status != http.StatusOK {
+		c.JSON(res.Status, res)
+		return
+	}
+	defer stop()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	c.SSEvent("snapshot", res.Data)
+	c.Writer.Flush()
+
+	// Periodic comments keep proxies from closing an idle connection.
+	heartbeat := time.NewTicker(25 * time.Second)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-heartbeat.C:
+			_, _ = io.WriteString(w, ": ping\n\n")
+			return true
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(string(event.Type), event)
+			return event.Type == TripEventLocation
+		}
+	})
+}
+
+// ExportTripSheetPDF godoc
+// @Summary Download trip sheet
+// @Description Download the printable PDF trip sheet of an accepted ambulance service request
+// @Tags Ambulance Service Requests
+// @Security BearerAuth
+// @Produce application/pdf
+// @Param id path string true "Ambulance Service Request ID"
+// @Success 200 {file} binary "PDF file"
+// @Failure 400 {object} pkg.Response
+// @Router /admin/ambulances/requests/{id}/trip-sheet [get]
+func (h *handler) ExportTripSheetPDF(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	pdfBytes, filename, err := h.service.ExportTripSheetPDF(ctx, id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, err.Error(), nil, nil))
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Header("Content-Transfer-Encoding", "binary")
+	c.Data(http.StatusOK, "application/pdf", pdfBytes)
+}
+
+// ExportAssignedTripSheetPDF godoc
+// @Summary Download assigned trip sheet
+// @Description Download the printable PDF trip sheet of a request assigned to the authenticated driver
+// @Tags Ambulance Service Requests
+// @Security BearerAuth
+// @Produce application/pdf
+// @Param id path string true "Ambulance Service Request ID"
+// @Success 200 {file} binary "PDF file"
+// @Failure 400 {object} pkg.Response
+// @Router /admin/ambulances/requests/assigned/{id}/trip-sheet [get]
+func (h *handler) ExportAssignedTripSheetPDF(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	id := c.Param("id")
+
+	pdfBytes, filename, err := h.service.ExportAssignedTripSheetPDF(ctx, claims.AccountID, id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, err.Error(), nil, nil))
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Header("Content-Transfer-Encoding", "binary")
+	c.Data(http.StatusOK, "application/pdf", pdfBytes)
+}
+
+// GetRequestDocument godoc
+// @Summary Get request document link
+// @Description Get a short-lived signed URL for a sensitive upload of the request (submitterIdCard). Requesters can only open their own requests
+// @Tags Ambulance Service Requests
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Ambulance Request ID"
+// @Param document path string true "Document type (submitterIdCard)"
+// @Success 200 {object} pkg.Response
+// @Failure 400 {object} pkg.Response
+// @Failure 403 {object} pkg.Response
+// @Failure 404 {object} pkg.Response
+// @Router /ambulances/requests/{id}/documents/{document} [get]
+// @Router /admin/ambulances/requests/{id}/documents/{document} [get]
+func (h *handler) GetRequestDocument(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.GetRequestDocument(ctx, claims.AccountID, claims.ActiveRole, c.Param("id"), c.Param("document"))
+	c.JSON(res.Status, res)
+}
@@ -4,9 +4,15 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/Vilamuzz/yota-backend/app/account"
+	"github.com/Vilamuzz/yota-backend/app/ambulance"
+	"github.com/Vilamuzz/yota-backend/app/ambulance_history"
 	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
@@ -15,6 +21,14 @@ type Repository interface {
 	FindAll(ctx context.Context, options map[string]interface{}) ([]AmbulanceServiceRequest, error)
 	Count(ctx context.Context, options map[string]interface{}) (int64, error)
 	Update(ctx context.Context, id string, updateData map[string]interface{}) error
+	FindBookings(ctx context.Context, options map[string]interface{}) ([]AmbulanceServiceRequest, error)
+	AcceptWithSchedule(ctx context.Context, id, ambulanceID string, driverID uuid.UUID, start, end time.Time, updateData map[string]interface{}) error
+	CreateTripLocations(ctx context.Context, locations []TripLocation) error
+	FindTripLocations(ctx context.Context, requestID string, options map[string]interface{}) ([]TripLocation, error)
+	FindLatestTripLocation(ctx context.Context, requestID string) (TripLocation, error)
+	FindContacts(ctx context.Context, options map[string]interface{}) ([]Contact, error)
+	FindUnclaimedEmergencies(ctx context.Context, notifiedBefore time.Time, maxEscalationLevel int) ([]AmbulanceServiceRequest, error)
+	MarkEscalated(ctx context.Context, id string, fromLevel int, notifiedAt time.Time) (bool, error)
 }
 
 type repository struct {
@@ -25,6 +39,10 @@ func NewRepository(conn *gorm.DB) Repository {
 	return &repository{Conn: conn}
 }
 
+// assignedDriverCondition matches requests driven by a driver. Requests accepted before drivers
+// were assigned per trip have no driver and fall back to the ambulance's default driver.
+const assignedDriverCondition = "driver_id = ? OR (driver_id IS NULL AND ambulance_id IN (SELECT id FROM ambulances WHERE driver_id = ?))"
+
 var allowedAmbulanceServiceRequestSortColumns = map[string]string{
 	"submitter_name": "submitter_name",
 	"created_at":     "created_at",
@@ -38,6 +56,7 @@ func (r *repository) FindByID(ctx context.Context, id string) (AmbulanceServiceR
 	var ambulanceServiceRequest AmbulanceServiceRequest
 	if err := r.Conn.WithContext(ctx).
 		Preload("Ambulance.Driver.UserProfile").
+		Preload("Driver.UserProfile").
 		Preload("Account").
 		Preload("Account.UserProfile").
 		First(&ambulanceServiceRequest, "id = ?", id).Error; err != nil {
@@ -58,6 +77,10 @@ func (r *repository) Count(ctx context.Context, options map[string]interface{})
 		query = query.Where("ambulance_id = ?", ambulanceID)
 	}
 
+	if driverID, ok := options["driver_id"]; ok && driverID != "" {
+		query = query.Where(assignedDriverCondition, driverID, driverID)
+	}
+
 	if status, ok := options["status"]; ok && status != "" {
 		query = query.Where("status = ?", status)
 	}
@@ -93,6 +116,10 @@ func (r *repository) FindAll(ctx context.Context, options map[string]interface{}
 		query = query.Where("ambulance_id = ?", ambulanceID)
 	}
 
+	if driverID, ok := options["driver_id"]; ok && driverID != "" {
+		query = query.Where(assignedDriverCondition, driverID, driverID)
+	}
+
 	if status, ok := options["status"]; ok && status != "" {
 		query = query.Where("status = ?", status)
 	}
@@ -168,3 +195,171 @@ func (r *repository) Update(ctx context.Context, id string, updateData map[strin
 	return r.Conn.Model(&AmbulanceServiceRequest{}).Where("id = ?", id).Updates(updateData).Error
 }
 
+
+// FindBookings returns accepted or in-service requests whose schedule overlaps [from, to).
+func (r *repository) FindBookings(ctx context.Context, options map[string]interface{}) ([]AmbulanceServiceRequest, error) {
+	var bookings []AmbulanceServiceRequest
+	query := r.Conn.WithContext(ctx).
+		Preload("Ambulance").
+		Where("status IN ?", bookingStatuses).
+		Where("scheduled_start IS NOT NULL")
+
+	if ambulanceID, ok := options["ambulance_id"]; ok && ambulanceID != "" {
+		query = query.Where("ambulance_id = ?", ambulanceID)
+	}
+
+	if driverID, ok := options["driver_id"]; ok && driverID != "" {
+		query = query.Where(assignedDriverCondition, driverID, driverID)
+	}
+	if from, ok := options["from"].(time.Time); ok {
+		query = query.Where("scheduled_end > ?", from)
+	}
+	if to, ok := options["to"].(time.Time); ok {
+		query = query.Where("scheduled_start < ?", to)
+	}
+	if excludeID, ok := options["exclude_id"]; ok && excludeID != "" {
+		query = query.Where("id <> ?", excludeID)
+	}
+
+	if err := query.Order("scheduled_start ASC").Find(&bookings).Error; err != nil {
+		return nil, err
+	}
+	return bookings, nil
+}
+
+// AcceptWithSchedule locks the ambulance row so two managers cannot book the same slot
+// concurrently, re-checks for overlaps and then applies the update.
+func (r *repository) AcceptWithSchedule(ctx context.Context, id, ambulanceID string, driverID uuid.UUID, start, end time.Time, updateData map[string]interface{}) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked ambulance.Ambulance
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NULL", ambulanceID).
+			First(&locked).Error; err != nil {
+			return err
+		}
+
+		// Lock the driver too so two ambulances cannot be booked with the same driver at once.
+		var driver account.Account
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("id = ?", driverID).
+			First(&driver).Error; err != nil {
+			return err
+		}
+
+		var driverBusy int64
+		if err := tx.Model(&AmbulanceServiceRequest{}).
+			Where(assignedDriverCondition, driverID, driverID).
+			Where("id <> ? AND status IN ?", id, bookingStatuses).
+			Where("scheduled_start < ? AND scheduled_end > ?", end, start).
+			Count(&driverBusy).Error; err != nil {
+			return err
+		}
+		if driverBusy > 0 {
+			return ErrDriverConflict
+		}
+
+		var overlapping int64
+		if err := tx.Model(&AmbulanceServiceRequest{}).
+			Where("ambulance_id = ? AND id <> ?", ambulanceID, id).
+			Where("status IN ?", bookingStatuses).
+			Where("scheduled_start < ? AND scheduled_end > ?", end, start).
+			Count(&overlapping).Error; err != nil {
+			return err
+		}
+		if overlapping > 0 {
+			return ErrScheduleConflict
+		}
+
+		result := tx.Model(&AmbulanceServiceRequest{}).
+			Where("id = ? AND status = ?", id, StatusPending).
+			Updates(updateData)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *repository) CreateTripLocations(ctx context.Context, locations []TripLocation) error {
+	return r.Conn.WithContext(ctx).CreateInBatches(&locations, 100).Error
+}
+
+func (r *repository) FindTripLocations(ctx context.Context, requestID string, options map[string]interface{}) ([]TripLocation, error) {
+	var locations []TripLocation
+	query := r.Conn.WithContext(ctx).Where("request_id = ?", requestID)
+
+	if since, ok := options["since"]; ok {
+		query = query.Where("recorded_at > ?", since)
+	}
+
+	query = query.Order("recorded_at ASC")
+	if limit, ok := options["limit"]; ok {
+		query = query.Limit(limit.(int))
+	}
+
+	if err := query.Find(&locations).Error; err != nil {
+		return nil, err
+	}
+	return locations, nil
+}
+
+func (r *repository) FindLatestTripLocation(ctx context.Context, requestID string) (TripLocation, error) {
+	var location TripLocation
+	err := r.Conn.WithContext(ctx).
+		Where("request_id = ?", requestID).
+		Order("recorded_at DESC").
+		First(&location).Error
+	return location, err
+}
+
+// FindContacts returns active, non-banned staff either by role or by account IDs.
+func (r *repository) FindContacts(ctx context.Context, options map[string]interface{}) ([]Contact, error) {
+	var contacts []Contact
+	query := r.Conn.WithContext(ctx).Table("accounts").
+		Select("accounts.id AS account_id, accounts.email, user_profiles.username, user_profiles.phone").
+		Joins("LEFT JOIN user_profiles ON user_profiles.account_id = accounts.id").
+		Where("accounts.is_banned = ? AND accounts.deleted_at IS NULL", false)
+
+	if roleID, ok := options["role_id"]; ok {
+		query = query.Where("accounts.id IN (SELECT account_id FROM account_roles WHERE role_id = ? AND is_active = ?)", roleID, true)
+	}
+	if accountIDs, ok := options["account_ids"]; ok {
+		query = query.Where("accounts.id IN ?", accountIDs)
+	}
+
+	if err := query.Scan(&contacts).Error; err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
+
+// FindUnclaimedEmergencies returns pending emergency requests last notified before the given time.
+func (r *repository) FindUnclaimedEmergencies(ctx context.Context, notifiedBefore time.Time, maxEscalationLevel int) ([]AmbulanceServiceRequest, error) {
+	var requests []AmbulanceServiceRequest
+	err := r.Conn.WithContext(ctx).
+		Where("status = ? AND service_category = ?", StatusPending, ambulance_history.EmergencyService).
+		Where("last_notified_at IS NOT NULL AND last_notified_at <= ?", notifiedBefore).
+		Where("escalation_level < ?", maxEscalationLevel).
+		Order("created_at ASC").
+		Find(&requests).Error
+	return requests, err
+}
+
+// MarkEscalated moves an emergency to the next escalation level. It reports false when another
+// worker already escalated it or it was claimed in the meantime.
+func (r *repository) MarkEscalated(ctx context.Context, id string, fromLevel int, notifiedAt time.Time) (bool, error) {
+	result := r.Conn.WithContext(ctx).Model(&AmbulanceServiceRequest{}).
+		Where("id = ? AND status = ? AND escalation_level = ?", id, StatusPending, fromLevel).
+		Updates(map[string]interface{}{
+			"escalation_level": fromLevel + 1,
+			"last_notified_at": notifiedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
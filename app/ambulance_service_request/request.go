@@ -26,6 +26,24 @@ type CreateAmbulanceServiceRequest struct {
 
 type AcceptAmbulanceServiceRequestPayload struct {
 	AmbulanceID string `json:"ambulanceId"`
+	DriverID    string `json:"driverId"` // optional; picked from the roster when empty
+}
+
+type ClaimEmergencyPayload struct {
+	AmbulanceID string `json:"ambulanceId"` // optional; defaults to the driver's own ambulance
+}
+
+type TripFeedbackPayload struct {
+	DriverRating  int    `json:"driverRating"`  // 1-5
+	VehicleRating int    `json:"vehicleRating"` // 1-5
+	Comment       string `json:"comment"`
+}
+
+type TripIncidentPayload struct {
+	Type        string                  `form:"type"`
+	Description string                  `form:"description"`
+	OccurredAt  string                  `form:"occurredAt"` // RFC3339, defaults to now
+	Photos      []*multipart.FileHeader `form:"photos[]" swaggerignore:"true"`
 }
 
 type RejectAmbulanceServiceRequest struct {
@@ -54,3 +72,29 @@ type AmbulanceServiceRequestAdminQueryParams struct {
 	Page            int    `form:"page"`
 	Limit           int    `form:"limit"`
 }
+
+type AmbulanceAvailabilityQueryParams struct {
+	Date            string `form:"date"`
+	StartTime       string `form:"startTime"`
+	EndTime         string `form:"endTime"`
+	ServiceCategory string `form:"serviceCategory"`
+}
+
+type AmbulanceCalendarQueryParams struct {
+	AmbulanceID string `form:"ambulanceId"`
+	From        string `form:"from"`
+	To          string `form:"to"`
+}
+
+type TripLocationPoint struct {
+	Latitude   float64  `json:"latitude"`
+	Longitude  float64  `json:"longitude"`
+	Accuracy   *float64 `json:"accuracy"`
+	Speed      *float64 `json:"speed"`
+	Heading    *float64 `json:"heading"`
+	RecordedAt string   `json:"recordedAt"` // RFC3339
+}
+
+type RecordTripLocationsPayload struct {
+	Points []TripLocationPoint `json:"points"`
+}
@@ -1,9 +1,14 @@
 package ambulance_service_request
 
 import (
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/account"
 	"github.com/Vilamuzz/yota-backend/app/ambulance"
 	"github.com/Vilamuzz/yota-backend/app/ambulance_history"
+	"github.com/Vilamuzz/yota-backend/app/driver_roster"
 	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/Vilamuzz/yota-backend/pkg/pii"
 	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
 )
 
@@ -28,6 +33,7 @@ type AmbulanceServiceRequestResponse struct {
 	RejectionReason   string                            `json:"rejectionReason"`
 	CancelationReason string                            `json:"cancelationReason"`
 	AssignedAmbulance *ambulance.AmbulanceResponse      `json:"assignedAmbulance"`
+	AssignedDriver    *account.DriverResponse           `json:"assignedDriver"`
 	CreatedAt         string                            `json:"createdAt"`
 }
 
@@ -41,13 +47,13 @@ func (a *AmbulanceServiceRequest) toAmbulanceServiceRequestResponse() AmbulanceS
 		ID:                a.ID.String(),
 		SubmittedBy:       a.SubmittedBy.String(),
 		SubmitterName:     a.SubmitterName,
-		SubmitterPhone:    a.SubmitterPhone,
+		SubmitterPhone:    string(a.SubmitterPhone),
 		SubmitterIDCard:   s3_pkg.GetCDNURL(a.SubmitterIDCard),
 		PatientName:       a.PatientName,
-		PatientAddress:    a.PatientAddress,
+		PatientAddress:    string(a.PatientAddress),
 		PatientAge:        a.PatientAge,
 		IsInfectious:      a.IsInfectious,
-		Disease:           a.Disease,
+		Disease:           string(a.Disease),
 		IsAbleToSit:       a.IsAbleToSit,
 		PickupDate:        a.PickupDate.Format("2006-01-02"),
 		PickupTime:        a.PickupTime.Format("15:04:05"),
@@ -65,6 +71,17 @@ func (a *AmbulanceServiceRequest) toAmbulanceServiceRequestResponse() AmbulanceS
 		resp.AssignedAmbulance = &ambulanceResp
 	}
 
+	if a.DriverID != nil {
+		driver := account.DriverResponse{ID: a.DriverID.String()}
+		if a.Driver != nil {
+			driver.Username = a.Driver.UserProfile.Username
+			if a.Driver.UserProfile.Phone != nil {
+				driver.Phone = *a.Driver.UserProfile.Phone
+			}
+		}
+		resp.AssignedDriver = &driver
+	}
+
 	return resp
 }
 
@@ -87,10 +104,15 @@ type AmbulanceServiceRequestAdminListResponse struct {
 	Pagination pkg.OffsetPagination              `json:"pagination"`
 }
 
+// toAmbulanceServiceRequestsToAdminListResponse masks the submitter phone and leaves out the
+// disease; both are shown in full on the request detail.
 func toAmbulanceServiceRequestsToAdminListResponse(requests []AmbulanceServiceRequest, pagination pkg.OffsetPagination) AmbulanceServiceRequestAdminListResponse {
 	var responses []AmbulanceServiceRequestResponse
 	for _, request := range requests {
-		responses = append(responses, request.toAmbulanceServiceRequestResponse())
+		resp := request.toAmbulanceServiceRequestResponse()
+		resp.SubmitterPhone = pii.MaskPhone(request.SubmitterPhone)
+		resp.Disease = ""
+		responses = append(responses, resp)
 	}
 	if requests == nil {
 		responses = []AmbulanceServiceRequestResponse{}
@@ -100,3 +122,120 @@ func toAmbulanceServiceRequestsToAdminListResponse(requests []AmbulanceServiceRe
 		Pagination: pagination,
 	}
 }
+
+type BookingResponse struct {
+	RequestID       string                            `json:"requestId"`
+	AmbulanceID     string                            `json:"ambulanceId"`
+	PlateNumber     string                            `json:"plateNumber"`
+	ServiceCategory ambulance_history.ServiceCategory `json:"serviceCategory"`
+	Status          Status                            `json:"status"`
+	Destination     string                            `json:"destination"`
+	Start           time.Time                         `json:"start"`
+	End             time.Time                         `json:"end"`
+}
+
+type AmbulanceAvailabilityResponse struct {
+	Ambulance   ambulance.AmbulanceResponse `json:"ambulance"`
+	IsAvailable bool                        `json:"isAvailable"`
+	Reason      string                      `json:"reason,omitempty"`
+	Bookings    []BookingResponse           `json:"bookings"`
+}
+
+type AvailabilityListResponse struct {
+	From       time.Time                       `json:"from"`
+	To         time.Time                       `json:"to"`
+	Ambulances []AmbulanceAvailabilityResponse `json:"ambulances"`
+}
+
+type SlotSuggestionResponse struct {
+	AmbulanceID string    `json:"ambulanceId"`
+	PlateNumber string    `json:"plateNumber"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+}
+
+type ScheduleConflictResponse struct {
+	RequestedStart      time.Time                     `json:"requestedStart"`
+	RequestedEnd        time.Time                     `json:"requestedEnd"`
+	Conflicts           []BookingResponse             `json:"conflicts"`
+	AvailableAmbulances []ambulance.AmbulanceResponse `json:"availableAmbulances"`
+	SuggestedSlots      []SlotSuggestionResponse      `json:"suggestedSlots"`
+}
+
+type DriverSuggestionListResponse struct {
+	RequestedStart time.Time                                `json:"requestedStart"`
+	RequestedEnd   time.Time                                `json:"requestedEnd"`
+	Drivers        []driver_roster.DriverSuggestionResponse `json:"drivers"`
+}
+
+type AcceptedAssignmentResponse struct {
+	RequestID   string                                 `json:"requestId"`
+	AmbulanceID string                                 `json:"ambulanceId"`
+	PlateNumber string                                 `json:"plateNumber"`
+	Driver      driver_roster.DriverSuggestionResponse `json:"driver"`
+}
+
+type CalendarResponse struct {
+	From     time.Time         `json:"from"`
+	To       time.Time         `json:"to"`
+	Bookings []BookingResponse `json:"bookings"`
+}
+
+func (a *AmbulanceServiceRequest) toBookingResponse() BookingResponse {
+	resp := BookingResponse{
+		RequestID:       a.ID.String(),
+		ServiceCategory: a.ServiceCategory,
+		Status:          a.Status,
+		Destination:     a.Destination,
+	}
+	if a.AmbulanceID != nil {
+		resp.AmbulanceID = a.AmbulanceID.String()
+	}
+	if a.Ambulance != nil {
+		resp.PlateNumber = a.Ambulance.PlateNumber
+	}
+	if a.ScheduledStart != nil {
+		resp.Start = *a.ScheduledStart
+	}
+	if a.ScheduledEnd != nil {
+		resp.End = *a.ScheduledEnd
+	}
+	return resp
+}
+
+func toBookingResponses(bookings []AmbulanceServiceRequest) []BookingResponse {
+	responses := make([]BookingResponse, 0, len(bookings))
+	for _, booking := range bookings {
+		responses = append(responses, booking.toBookingResponse())
+	}
+	return responses
+}
+
+type TripLocationResponse struct {
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+	Accuracy   *float64  `json:"accuracy"`
+	Speed      *float64  `json:"speed"`
+	Heading    *float64  `json:"heading"`
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+type TripTrackResponse struct {
+	RequestID      string                 `json:"requestId"`
+	Status         Status                 `json:"status"`
+	StartedAt      *time.Time             `json:"startedAt"`
+	DistanceMeters float64                `json:"distanceMeters"`
+	Latest         *TripLocationResponse  `json:"latest"`
+	Points         []TripLocationResponse `json:"points"`
+}
+
+func (l *TripLocation) toTripLocationResponse() TripLocationResponse {
+	return TripLocationResponse{
+		Latitude:   l.Latitude,
+		Longitude:  l.Longitude,
+		Accuracy:   l.Accuracy,
+		Speed:      l.Speed,
+		Heading:    l.Heading,
+		RecordedAt: l.RecordedAt,
+	}
+}
@@ -2,17 +2,25 @@ package ambulance_service_request
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"regexp"
 	"strings"
 	"time"
 
+	"github.com/Vilamuzz/yota-backend/app/account"
 	"github.com/Vilamuzz/yota-backend/app/ambulance"
 	"github.com/Vilamuzz/yota-backend/app/ambulance_history"
+	"github.com/Vilamuzz/yota-backend/app/driver_roster"
 	"github.com/Vilamuzz/yota-backend/pkg"
 	"github.com/Vilamuzz/yota-backend/pkg/enum"
+	"github.com/Vilamuzz/yota-backend/pkg/pii"
+	"github.com/Vilamuzz/yota-backend/pkg/realtime"
+	"github.com/Vilamuzz/yota-backend/pkg/report"
 	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
+	"github.com/Vilamuzz/yota-backend/pkg/sms"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -32,25 +40,72 @@ type Service interface {
 	DriverCancelAmbulanceServiceRequest(ctx context.Context, driverAccountID string, id string, payload CancelAmbulanceServiceRequestPayload) pkg.Response
 	StartAmbulanceServiceRequest(ctx context.Context, driverAccountID string, id string) pkg.Response
 	CompleteAmbulanceServiceRequest(ctx context.Context, driverAccountID string, id string) pkg.Response
+	GetAmbulanceAvailability(ctx context.Context, params AmbulanceAvailabilityQueryParams) pkg.Response
+	GetAmbulanceCalendar(ctx context.Context, params AmbulanceCalendarQueryParams) pkg.Response
+	GetDriverSuggestions(ctx context.Context, id string) pkg.Response
+	ListOpenEmergencies(ctx context.Context) pkg.Response
+	ClaimEmergencyRequest(ctx context.Context, driverAccountID string, id string, payload ClaimEmergencyPayload) pkg.Response
+	EscalateEmergencyRequests(ctx context.Context) error
+	RecordTripLocations(ctx context.Context, driverAccountID string, id string, payload RecordTripLocationsPayload) pkg.Response
+	GetTripTrack(ctx context.Context, accountID string, role enum.RoleName, id string) pkg.Response
+	StreamTripEvents(ctx context.Context, accountID string, role enum.RoleName, id string) (<-chan TripEvent, func(), pkg.Response)
+	SubmitTripFeedback(ctx context.Context, accountID string, id string, payload TripFeedbackPayload) pkg.Response
+	ReportTripIncident(ctx context.Context, driverAccountID string, id string, payload TripIncidentPayload) pkg.Response
+	ExportTripSheetPDF(ctx context.Context, id string) ([]byte, string, error)
+	ExportAssignedTripSheetPDF(ctx context.Context, driverAccountID string, id string) ([]byte, string, error)
+	GetRequestDocument(ctx context.Context, accountID string, role enum.RoleName, id string, document string) pkg.Response
 }
 
+const (
+	// fleetScheduleLimit caps how many ambulances are loaded when building availability.
+	fleetScheduleLimit = 500
+	// slotSuggestionStep is the granularity used when searching for the next free slot.
+	slotSuggestionStep = 30 * time.Minute
+	// slotSuggestionHorizon is how far ahead alternatives are searched.
+	slotSuggestionHorizon = 48 * time.Hour
+	maxSlotSuggestions    = 3
+	maxCalendarRange      = 62 * 24 * time.Hour
+
+	// maxTripLocationBatch caps the number of GPS points accepted per upload.
+	maxTripLocationBatch = 100
+	// tripLocationClockSkew tolerates small clock differences on the driver's phone.
+	tripLocationClockSkew = 2 * time.Minute
+	// maxTripTrackPoints caps how many points are returned or summarised for one trip.
+	maxTripTrackPoints = 10000
+
+	// feedbackWindow is how long after completion a requester may still rate a trip.
+	feedbackWindow = 30 * 24 * time.Hour
+	// maxIncidentPhotos caps the photos attached to one incident report.
+	maxIncidentPhotos = 5
+)
+
 type service struct {
 	repo                 Repository
 	ambulanceRepo        ambulance.Repository
 	ambulanceHistoryRepo ambulance_history.Repository
+	rosterRepo           driver_roster.Repository
 	timeout              time.Duration
 	emailService         *pkg.EmailService
 	s3Client             s3_pkg.Client
+	privateStorage       s3_pkg.PrivateClient
+	broker               realtime.Broker
+	smsSender            sms.Sender
+	emergencyEscalation  time.Duration
 }
 
-func NewService(repo Repository, ambulanceRepo ambulance.Repository, ambulanceHistoryRepo ambulance_history.Repository, timeout time.Duration, s3Client s3_pkg.Client) Service {
+func NewService(repo Repository, ambulanceRepo ambulance.Repository, ambulanceHistoryRepo ambulance_history.Repository, rosterRepo driver_roster.Repository, timeout time.Duration, s3Client s3_pkg.Client, privateStorage s3_pkg.PrivateClient, broker realtime.Broker, smsSender sms.Sender, emergencyEscalation time.Duration) Service {
 	return &service{
 		repo:                 repo,
 		ambulanceRepo:        ambulanceRepo,
 		ambulanceHistoryRepo: ambulanceHistoryRepo,
+		rosterRepo:           rosterRepo,
 		timeout:              timeout,
 		emailService:         pkg.NewEmailService(),
 		s3Client:             s3Client,
+		privateStorage:       privateStorage,
+		broker:               broker,
+		smsSender:            smsSender,
+		emergencyEscalation:  emergencyEscalation,
 	}
 }
 
@@ -174,20 +229,12 @@ func (s *service) ListAssignedAmbulanceServiceRequests(ctx context.Context, driv
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	ambulanceRecord, err := s.ambulanceRepo.FindOneAmbulance(ctx, map[string]interface{}{"driver_id": driverAccountID})
-	if err != nil {
-		if err.Error() == gorm.ErrRecordNotFound.Error() {
-			return pkg.NewResponse(http.StatusOK, "Tidak ada ambulans yang ditugaskan pada supir ini", nil, []AmbulanceServiceRequestResponse{})
-		}
-		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menemukan ambulans supir ini", nil, nil)
-	}
-
 	if queryParams.Limit == 0 {
 		queryParams.Limit = 10
 	}
 	options := map[string]interface{}{
-		"limit":        queryParams.Limit,
-		"ambulance_id": ambulanceRecord.ID.String(),
+		"limit":     queryParams.Limit,
+		"driver_id": driverAccountID,
 	}
 	if queryParams.Status != "" {
 		options["status"] = queryParams.Status
@@ -244,24 +291,9 @@ func (s *service) GetAssignedAmbulanceServiceRequestByID(ctx context.Context, dr
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID permintaan ambulans tidak valid"}, nil)
 	}
 
-	ambulanceRecord, err := s.ambulanceRepo.FindOneAmbulance(ctx, map[string]interface{}{"driver_id": driverAccountID})
-	if err != nil {
-		if err.Error() == gorm.ErrRecordNotFound.Error() {
-			return pkg.NewResponse(http.StatusNotFound, "Tidak ada ambulans yang ditugaskan ke supir ini", nil, nil)
-		}
-		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menemukan ambulans supir ini", nil, nil)
-	}
-
-	ambulanceServiceRequest, err := s.repo.FindByID(ctx, id)
-	if err != nil {
-		if err.Error() == gorm.ErrRecordNotFound.Error() {
-			return pkg.NewResponse(http.StatusNotFound, "Permintaan ambulans tidak ditemukan", nil, nil)
-		}
-		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat permintaan ambulans", nil, nil)
-	}
-
-	if ambulanceServiceRequest.AmbulanceID == nil || *ambulanceServiceRequest.AmbulanceID != ambulanceRecord.ID {
-		return pkg.NewResponse(http.StatusForbidden, "Anda tidak memiliki akses untuk melihat permintaan ini", nil, nil)
+	ambulanceServiceRequest, res := s.findAssignedRequest(ctx, driverAccountID, id)
+	if ambulanceServiceRequest == nil {
+		return res
 	}
 
 	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, ambulanceServiceRequest.toAmbulanceServiceRequestResponse())
@@ -304,7 +336,8 @@ func (s *service) CreateAmbulanceServiceRequest(ctx context.Context, payload Cre
 		}
 	}
 
-	if payload.SubmitterIDCard == nil {
+	// Emergencies skip the KTP upload so help is not delayed; the driver verifies on site.
+	if payload.SubmitterIDCard == nil && !isEmergency {
 		errValidation["submitterIdCard"] = "Unggah foto KTP pengaju wajib diisi"
 	}
 
@@ -393,22 +426,25 @@ func (s *service) CreateAmbulanceServiceRequest(ctx context.Context, payload Cre
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"pickupTime": "Format jam tidak valid, diharapkan HH:MM"}, nil)
 	}
 
-	submitterIDCardURL, err := s.s3Client.UploadFile(ctx, payload.SubmitterIDCard, "ambulance-service-requests")
-	if err != nil {
-		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah KTP pengirim", nil, nil)
+	var submitterIDCardURL string
+	if payload.SubmitterIDCard != nil {
+		submitterIDCardURL, err = s.privateStorage.UploadFile(ctx, payload.SubmitterIDCard, "ambulance-service-requests")
+		if err != nil {
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah KTP pengirim", nil, nil)
+		}
 	}
 
 	request := AmbulanceServiceRequest{
 		ID:              uuid.New(),
 		SubmittedBy:     uuid.MustParse(payload.AccountID),
 		SubmitterName:   payload.SubmitterName,
-		SubmitterPhone:  payload.SubmitterPhone,
+		SubmitterPhone:  pii.String(payload.SubmitterPhone),
 		SubmitterIDCard: submitterIDCardURL,
 		PatientName:     payload.PatientName,
-		PatientAddress:  payload.PatientAddress,
+		PatientAddress:  pii.String(payload.PatientAddress),
 		PatientAge:      patientAge,
 		IsInfectious:    payload.IsInfectious,
-		Disease:         payload.Disease,
+		Disease:         pii.String(payload.Disease),
 		IsAbleToSit:     payload.IsAbleToSit,
 		PickupDate:      pickupDate,
 		PickupTime:      pickupTime,
@@ -420,9 +456,19 @@ func (s *service) CreateAmbulanceServiceRequest(ctx context.Context, payload Cre
 		UpdatedAt:       time.Now(),
 	}
 
+	if isEmergency {
+		now := time.Now()
+		request.LastNotifiedAt = &now
+	}
+
 	if err := s.repo.Create(ctx, request); err != nil {
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membuat permintaan ambulans", nil, nil)
 	}
+
+	if isEmergency {
+		go s.dispatchEmergency(request, 0)
+		return pkg.NewResponse(http.StatusOK, "Permintaan ambulans darurat berhasil dibuat, supir yang bertugas sedang dihubungi", nil, nil)
+	}
 	return pkg.NewResponse(http.StatusOK, "Permintaan ambulans berhasil dibuat", nil, nil)
 }
 
@@ -485,14 +531,53 @@ func (s *service) AcceptAmbulanceServiceRequest(ctx context.Context, id string,
 		return pkg.NewResponse(http.StatusForbidden, "Anda tidak memiliki akses untuk melakukan tindakan ini", nil, nil)
 	}
 
+	start, end := existing.ScheduleWindow()
+
+	if ambulanceRecord.Status == ambulance.AmbulanceStatusMaintenance {
+		return pkg.NewResponse(http.StatusConflict, "Ambulans sedang dalam perawatan", nil, s.buildScheduleAlternatives(ctx, start, end, ambulanceRecord.ID, existing.ID.String(), nil))
+	}
+
+	driver, res := s.resolveTripDriver(ctx, id, ambulanceRecord, payload.DriverID, start, end)
+	if driver == nil {
+		return res
+	}
+
 	updateData := map[string]interface{}{
 		"status":           StatusAccepted,
 		"ambulance_id":     ambulanceRecord.ID,
+		"driver_id":        driver.DriverID,
 		"rejection_reason": "",
+		"scheduled_start":  start,
+		"scheduled_end":    end,
 		"updated_at":       time.Now(),
 	}
 
-	if err := s.repo.Update(ctx, id, updateData); err != nil {
+	if err := s.repo.AcceptWithSchedule(ctx, id, ambulanceRecord.ID.String(), driver.DriverID, start, end, updateData); err != nil {
+		if errors.Is(err, ErrScheduleConflict) {
+			conflicts, findErr := s.repo.FindBookings(ctx, map[string]interface{}{
+				"ambulance_id": ambulanceRecord.ID.String(),
+				"from":         start,
+				"to":           end,
+				"exclude_id":   id,
+			})
+			if findErr != nil {
+				logrus.WithFields(logrus.Fields{
+					"component":    "ambulance_service_request.service",
+					"ambulance_id": ambulanceRecord.ID.String(),
+				}).WithError(findErr).Error("failed to load conflicting bookings")
+			}
+			return pkg.NewResponse(http.StatusConflict, "Ambulans sudah dijadwalkan pada waktu tersebut", nil, s.buildScheduleAlternatives(ctx, start, end, ambulanceRecord.ID, id, conflicts))
+		}
+		if errors.Is(err, ErrDriverConflict) {
+			return pkg.NewResponse(http.StatusConflict, "Supir sudah dijadwalkan pada waktu tersebut", nil, s.buildDriverSuggestions(ctx, id, start, end))
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusConflict, "Permintaan ambulans sudah diproses", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "ambulance_service_request.service",
+			"request_id": id,
+		}).WithError(err).Error("failed to accept ambulance service request")
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memperbarui status permintaan ambulans", nil, nil)
 	}
 
@@ -508,7 +593,12 @@ func (s *service) AcceptAmbulanceServiceRequest(ctx context.Context, id string,
 		}(existing.Account.Email, existing.Account.UserProfile.Username, existing.SubmitterName)
 	}
 
-	return pkg.NewResponse(http.StatusOK, "Permintaan ambulans berhasil disetujui", nil, nil)
+	return pkg.NewResponse(http.StatusOK, "Permintaan ambulans berhasil disetujui", nil, AcceptedAssignmentResponse{
+		RequestID:   id,
+		AmbulanceID: ambulanceRecord.ID.String(),
+		PlateNumber: ambulanceRecord.PlateNumber,
+		Driver:      driver.ToDriverSuggestionResponse(),
+	})
 }
 
 func (s *service) RejectAmbulanceServiceRequest(ctx context.Context, id string, req RejectAmbulanceServiceRequest) pkg.Response {
@@ -608,24 +698,9 @@ func (s *service) DriverCancelAmbulanceServiceRequest(ctx context.Context, drive
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"cancelationReason": "Alasan pembatalan wajib diisi"}, nil)
 	}
 
-	ambulanceRecord, err := s.ambulanceRepo.FindOneAmbulance(ctx, map[string]interface{}{"driver_id": driverAccountID})
-	if err != nil {
-		if err.Error() == gorm.ErrRecordNotFound.Error() {
-			return pkg.NewResponse(http.StatusNotFound, "Tidak ada ambulans yang ditugaskan ke supir ini", nil, nil)
-		}
-		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menemukan ambulans supir ini", nil, nil)
-	}
-
-	existing, err := s.repo.FindByID(ctx, id)
-	if err != nil {
-		if err.Error() == gorm.ErrRecordNotFound.Error() {
-			return pkg.NewResponse(http.StatusNotFound, "Permintaan ambulans tidak ditemukan", nil, nil)
-		}
-		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat permintaan ambulans", nil, nil)
-	}
-
-	if existing.AmbulanceID == nil || existing.AmbulanceID.String() != ambulanceRecord.ID.String() {
-		return pkg.NewResponse(http.StatusForbidden, "Ambulans ini tidak ditugaskan untuk permintaan ini", nil, nil)
+	existing, res := s.findAssignedRequest(ctx, driverAccountID, id)
+	if existing == nil {
+		return res
 	}
 
 	if existing.Status != StatusAccepted && existing.Status != StatusInService {
@@ -642,11 +717,15 @@ func (s *service) DriverCancelAmbulanceServiceRequest(ctx context.Context, drive
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membatalkan permintaan ambulans", nil, nil)
 	}
 
-	_ = s.ambulanceRepo.UpdateAmbulance(ctx, ambulanceRecord.ID.String(), map[string]interface{}{
+	_ = s.ambulanceRepo.UpdateAmbulance(ctx, existing.AmbulanceID.String(), map[string]interface{}{
 		"status":     ambulance.AmbulanceStatusAvailable,
 		"updated_at": time.Now(),
 	})
 
+	if existing.Status == StatusInService {
+		s.publishTripEvent(id, TripEvent{Type: TripEventCancelled})
+	}
+
 	return pkg.NewResponse(http.StatusOK, "Permintaan ambulans berhasil dibatalkan oleh supir", nil, nil)
 }
 
@@ -658,24 +737,9 @@ func (s *service) StartAmbulanceServiceRequest(ctx context.Context, driverAccoun
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID permintaan ambulans tidak valid"}, nil)
 	}
 
-	ambulanceRecord, err := s.ambulanceRepo.FindOneAmbulance(ctx, map[string]interface{}{"driver_id": driverAccountID})
-	if err != nil {
-		if err.Error() == gorm.ErrRecordNotFound.Error() {
-			return pkg.NewResponse(http.StatusNotFound, "Tidak ada ambulans yang ditugaskan ke supir ini", nil, nil)
-		}
-		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menemukan ambulans supir ini", nil, nil)
-	}
-
-	existing, err := s.repo.FindByID(ctx, id)
-	if err != nil {
-		if err.Error() == gorm.ErrRecordNotFound.Error() {
-			return pkg.NewResponse(http.StatusNotFound, "Permintaan ambulans tidak ditemukan", nil, nil)
-		}
-		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat permintaan ambulans", nil, nil)
-	}
-
-	if existing.AmbulanceID == nil || existing.AmbulanceID.String() != ambulanceRecord.ID.String() {
-		return pkg.NewResponse(http.StatusForbidden, "Ambulans ini tidak ditugaskan untuk permintaan ini", nil, nil)
+	existing, res := s.findAssignedRequest(ctx, driverAccountID, id)
+	if existing == nil {
+		return res
 	}
 
 	if existing.Status != StatusAccepted {
@@ -683,8 +747,8 @@ func (s *service) StartAmbulanceServiceRequest(ctx context.Context, driverAccoun
 	}
 
 	activeRequests, err := s.repo.FindAll(ctx, map[string]interface{}{
-		"ambulance_id": ambulanceRecord.ID.String(),
-		"status":       string(StatusInService),
+		"driver_id": driverAccountID,
+		"status":    string(StatusInService),
 	})
 	if err != nil {
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memverifikasi status permintaan aktif", nil, nil)
@@ -693,8 +757,20 @@ func (s *service) StartAmbulanceServiceRequest(ctx context.Context, driverAccoun
 		return pkg.NewResponse(http.StatusBadRequest, "Supir masih memiliki permintaan aktif yang belum diselesaikan", nil, nil)
 	}
 
+	activeRequests, err = s.repo.FindAll(ctx, map[string]interface{}{
+		"ambulance_id": existing.AmbulanceID.String(),
+		"status":       string(StatusInService),
+	})
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memverifikasi status permintaan aktif", nil, nil)
+	}
+	if len(activeRequests) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Ambulans masih melayani permintaan lain yang belum diselesaikan", nil, nil)
+	}
+
 	updateData := map[string]interface{}{
 		"status":     StatusInService,
+		"started_at": time.Now(),
 		"updated_at": time.Now(),
 	}
 
@@ -702,7 +778,7 @@ func (s *service) StartAmbulanceServiceRequest(ctx context.Context, driverAccoun
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memperbarui status permintaan ambulans", nil, nil)
 	}
 
-	_ = s.ambulanceRepo.UpdateAmbulance(ctx, ambulanceRecord.ID.String(), map[string]interface{}{
+	_ = s.ambulanceRepo.UpdateAmbulance(ctx, existing.AmbulanceID.String(), map[string]interface{}{
 		"status":     ambulance.AmbulanceStatusInUse,
 		"updated_at": time.Now(),
 	})
@@ -718,24 +794,9 @@ func (s *service) CompleteAmbulanceServiceRequest(ctx context.Context, driverAcc
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID permintaan ambulans tidak valid"}, nil)
 	}
 
-	ambulanceRecord, err := s.ambulanceRepo.FindOneAmbulance(ctx, map[string]interface{}{"driver_id": driverAccountID})
-	if err != nil {
-		if err.Error() == gorm.ErrRecordNotFound.Error() {
-			return pkg.NewResponse(http.StatusNotFound, "Tidak ada ambulans yang ditugaskan ke supir ini", nil, nil)
-		}
-		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengecek data ambulans supir ini", nil, nil)
-	}
-
-	existing, err := s.repo.FindByID(ctx, id)
-	if err != nil {
-		if err.Error() == gorm.ErrRecordNotFound.Error() {
-			return pkg.NewResponse(http.StatusNotFound, "Permintaan ambulans tidak ditemukan", nil, nil)
-		}
-		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat permintaan ambulans", nil, nil)
-	}
-
-	if existing.AmbulanceID == nil || existing.AmbulanceID.String() != ambulanceRecord.ID.String() {
-		return pkg.NewResponse(http.StatusForbidden, "Ambulans ini tidak ditugaskan untuk permintaan ini", nil, nil)
+	existing, res := s.findAssignedRequest(ctx, driverAccountID, id)
+	if existing == nil {
+		return res
 	}
 
 	if existing.Status != StatusInService {
@@ -751,25 +812,1215 @@ func (s *service) CompleteAmbulanceServiceRequest(ctx context.Context, driverAcc
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memperbarui status permintaan ambulans", nil, nil)
 	}
 
-	_ = s.ambulanceRepo.UpdateAmbulance(ctx, ambulanceRecord.ID.String(), map[string]interface{}{
+	_ = s.ambulanceRepo.UpdateAmbulance(ctx, existing.AmbulanceID.String(), map[string]interface{}{
 		"status":     ambulance.AmbulanceStatusAvailable,
 		"updated_at": time.Now(),
 	})
 
+	s.publishTripEvent(id, TripEvent{Type: TripEventCompleted})
+
 	now := time.Now()
 	note := fmt.Sprintf("Layanan ambulans selesai untuk permintaan dari %s. Pasien: %s", existing.SubmitterName, existing.PatientName)
+	driverID, err := uuid.Parse(driverAccountID)
+	if err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"accountId": "Format ID akun tidak valid"}, nil)
+	}
+
 	history := ambulance_history.AmbulanceHistory{
 		ID:              uuid.New(),
-		AmbulanceID:     ambulanceRecord.ID,
-		DriverID:        ambulanceRecord.DriverID,
+		AmbulanceID:     *existing.AmbulanceID,
+		DriverID:        driverID,
+		RequestID:       &existing.ID,
 		ServiceCategory: existing.ServiceCategory,
 		Note:            note,
+		StartedAt:       existing.StartedAt,
+		FinishedAt:      &now,
 		CreatedAt:       now,
 	}
+	if existing.StartedAt != nil {
+		history.DurationSeconds = int64(now.Sub(*existing.StartedAt).Seconds())
+	}
+
+	points, err := s.repo.FindTripLocations(ctx, id, map[string]interface{}{"limit": maxTripTrackPoints})
+	if err != nil {
+		// The trip itself is finished; a missing route summary should not block it.
+		logrus.WithFields(logrus.Fields{
+			"component":  "ambulance_service_request.service",
+			"request_id": id,
+		}).WithError(err).Error("failed to load trip locations for route summary")
+	} else {
+		summary := summarizeTrack(points)
+		history.DistanceMeters = summary.DistanceMeters
+		history.RoutePolyline = summary.Polyline
+	}
 
 	if err := s.ambulanceHistoryRepo.Create(ctx, history); err != nil {
 		return pkg.NewResponse(http.StatusInternalServerError, "Layanan ambulans diselesaikan tetapi gagal membuat riwayat ambulans", nil, nil)
 	}
 
+	if existing.Account.Email != "" {
+		go func(email, username, submitterName, requestID string) {
+			if err := s.emailService.SendAmbulanceTripCompletedEmail(email, username, submitterName, requestID); err != nil {
+				logrus.WithFields(logrus.Fields{
+					"component":  "ambulance_service_request.service",
+					"email":      email,
+					"request_id": requestID,
+				}).WithError(err).Error("failed to send ambulance trip completed email asynchronously")
+			}
+		}(existing.Account.Email, existing.Account.UserProfile.Username, existing.SubmitterName, id)
+	}
+
 	return pkg.NewResponse(http.StatusOK, "Layanan ambulans berhasil diselesaikan dan riwayat berhasil dibuat", nil, nil)
 }
+
+func (s *service) GetAmbulanceAvailability(ctx context.Context, params AmbulanceAvailabilityQueryParams) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	errValidation := make(map[string]string)
+	date, err := time.Parse("2006-01-02", params.Date)
+	if err != nil {
+		errValidation["date"] = "Format tanggal tidak valid (gunakan YYYY-MM-DD)"
+	}
+	startClock, err := time.Parse("15:04", params.StartTime)
+	if err != nil {
+		errValidation["startTime"] = "Format jam mulai tidak valid (gunakan HH:MM)"
+	}
+	var endClock time.Time
+	if params.EndTime != "" {
+		endClock, err = time.Parse("15:04", params.EndTime)
+		if err != nil {
+			errValidation["endTime"] = "Format jam selesai tidak valid (gunakan HH:MM)"
+		}
+	} else if params.ServiceCategory != "" && !ambulance_history.ServiceCategory(params.ServiceCategory).IsValid() {
+		errValidation["serviceCategory"] = "Kategori layanan tidak valid"
+	} else if params.ServiceCategory == "" {
+		errValidation["endTime"] = "Jam selesai atau kategori layanan wajib diisi"
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	from := combineDateAndTime(date, startClock)
+	to := from.Add(ambulance_history.ServiceCategory(params.ServiceCategory).EstimatedDuration())
+	if params.EndTime != "" {
+		to = combineDateAndTime(date, endClock)
+		if !to.After(from) {
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"endTime": "Jam selesai harus setelah jam mulai"}, nil)
+		}
+	}
+
+	ambulances, bookingsByAmbulance, err := s.loadFleetSchedule(ctx, from, to)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "ambulance_service_request.service",
+		}).WithError(err).Error("failed to load fleet schedule")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat jadwal ambulans", nil, nil)
+	}
+
+	result := AvailabilityListResponse{From: from, To: to, Ambulances: make([]AmbulanceAvailabilityResponse, 0, len(ambulances))}
+	for _, amb := range ambulances {
+		bookings := bookingsByAmbulance[amb.ID]
+		item := AmbulanceAvailabilityResponse{
+			Ambulance:   amb.ToAmbulanceResponse(),
+			IsAvailable: true,
+			Bookings:    toBookingResponses(bookings),
+		}
+		if amb.Status == ambulance.AmbulanceStatusMaintenance {
+			item.IsAvailable = false
+			item.Reason = "Dalam perawatan"
+		} else if len(bookings) > 0 {
+			item.IsAvailable = false
+			item.Reason = "Sudah dijadwalkan"
+		}
+		result.Ambulances = append(result.Ambulances, item)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Ketersediaan ambulans berhasil diambil", nil, result)
+}
+
+func (s *service) GetAmbulanceCalendar(ctx context.Context, params AmbulanceCalendarQueryParams) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	errValidation := make(map[string]string)
+	if params.AmbulanceID != "" {
+		if err := uuid.Validate(params.AmbulanceID); err != nil {
+			errValidation["ambulanceId"] = "Format ID ambulans tidak valid"
+		}
+	}
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if params.From != "" {
+		parsed, err := time.Parse("2006-01-02", params.From)
+		if err != nil {
+			errValidation["from"] = "Format tanggal tidak valid (gunakan YYYY-MM-DD)"
+		}
+		from = parsed
+	}
+	// Defaults to a one-week window starting from the first day.
+	to := from.AddDate(0, 0, 6)
+	if params.To != "" {
+		parsed, err := time.Parse("2006-01-02", params.To)
+		if err != nil {
+			errValidation["to"] = "Format tanggal tidak valid (gunakan YYYY-MM-DD)"
+		}
+		to = parsed
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	// The range is inclusive of the end date.
+	to = to.AddDate(0, 0, 1)
+	if !to.After(from) {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"to": "Tanggal akhir harus setelah tanggal awal"}, nil)
+	}
+	if to.Sub(from) > maxCalendarRange {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"to": "Rentang kalender maksimal 62 hari"}, nil)
+	}
+
+	bookings, err := s.repo.FindBookings(ctx, map[string]interface{}{
+		"ambulance_id": params.AmbulanceID,
+		"from":         from,
+		"to":           to,
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":    "ambulance_service_request.service",
+			"ambulance_id": params.AmbulanceID,
+		}).WithError(err).Error("failed to load ambulance calendar")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat kalender ambulans", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Kalender ambulans berhasil diambil", nil, CalendarResponse{
+		From:     from,
+		To:       to,
+		Bookings: toBookingResponses(bookings),
+	})
+}
+
+// loadFleetSchedule returns all active ambulances and their bookings overlapping [from, to).
+func (s *service) loadFleetSchedule(ctx context.Context, from, to time.Time) ([]ambulance.Ambulance, map[uuid.UUID][]AmbulanceServiceRequest, error) {
+	ambulances, err := s.ambulanceRepo.FindAllAmbulances(ctx, map[string]interface{}{"limit": fleetScheduleLimit})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bookings, err := s.repo.FindBookings(ctx, map[string]interface{}{"from": from, "to": to})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bookingsByAmbulance := make(map[uuid.UUID][]AmbulanceServiceRequest)
+	for _, booking := range bookings {
+		if booking.AmbulanceID != nil {
+			bookingsByAmbulance[*booking.AmbulanceID] = append(bookingsByAmbulance[*booking.AmbulanceID], booking)
+		}
+	}
+	return ambulances, bookingsByAmbulance, nil
+}
+
+// buildScheduleAlternatives lists other ambulances free for the same window and the next
+// free slots of the requested ambulance, so the manager can resolve a conflict quickly.
+func (s *service) buildScheduleAlternatives(ctx context.Context, start, end time.Time, requestedAmbulanceID uuid.UUID, requestID string, conflicts []AmbulanceServiceRequest) ScheduleConflictResponse {
+	result := ScheduleConflictResponse{
+		RequestedStart:      start,
+		RequestedEnd:        end,
+		Conflicts:           toBookingResponses(conflicts),
+		AvailableAmbulances: []ambulance.AmbulanceResponse{},
+		SuggestedSlots:      []SlotSuggestionResponse{},
+	}
+	duration := end.Sub(start)
+
+	ambulances, bookingsByAmbulance, err := s.loadFleetSchedule(ctx, start, end.Add(slotSuggestionHorizon))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "ambulance_service_request.service",
+			"request_id": requestID,
+		}).WithError(err).Error("failed to load fleet schedule for alternatives")
+		return result
+	}
+
+	var requested *ambulance.Ambulance
+	for i := range ambulances {
+		amb := ambulances[i]
+		if amb.ID == requestedAmbulanceID {
+			requested = &ambulances[i]
+			continue
+		}
+		if amb.Status == ambulance.AmbulanceStatusMaintenance {
+			continue
+		}
+		if isSlotFree(bookingsByAmbulance[amb.ID], start, end, requestID) {
+			result.AvailableAmbulances = append(result.AvailableAmbulances, amb.ToAmbulanceResponse())
+		}
+	}
+
+	if requested == nil || requested.Status == ambulance.AmbulanceStatusMaintenance {
+		return result
+	}
+	for candidate := start.Add(slotSuggestionStep); candidate.Before(start.Add(slotSuggestionHorizon)) && len(result.SuggestedSlots) < maxSlotSuggestions; candidate = candidate.Add(slotSuggestionStep) {
+		candidateEnd := candidate.Add(duration)
+		if isSlotFree(bookingsByAmbulance[requested.ID], candidate, candidateEnd, requestID) {
+			result.SuggestedSlots = append(result.SuggestedSlots, SlotSuggestionResponse{
+				AmbulanceID: requested.ID.String(),
+				PlateNumber: requested.PlateNumber,
+				Start:       candidate,
+				End:         candidateEnd,
+			})
+			// Skip past this slot so suggestions are spread out rather than 30 minutes apart.
+			candidate = candidateEnd.Add(-slotSuggestionStep)
+		}
+	}
+
+	return result
+}
+
+func isSlotFree(bookings []AmbulanceServiceRequest, start, end time.Time, excludeRequestID string) bool {
+	for _, booking := range bookings {
+		if booking.ID.String() == excludeRequestID || booking.ScheduledStart == nil || booking.ScheduledEnd == nil {
+			continue
+		}
+		if booking.ScheduledStart.Before(end) && booking.ScheduledEnd.After(start) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *service) GetDriverSuggestions(ctx context.Context, id string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := uuid.Validate(id); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID permintaan ambulans tidak valid"}, nil)
+	}
+
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
+			return pkg.NewResponse(http.StatusNotFound, "Permintaan ambulans tidak ditemukan", nil, nil)
+		}
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat permintaan ambulans", nil, nil)
+	}
+	if existing.Status != StatusPending && existing.Status != StatusAccepted {
+		return pkg.NewResponse(http.StatusBadRequest, "Saran supir hanya tersedia untuk permintaan pending atau disetujui", nil, nil)
+	}
+
+	start, end := existing.ScheduleWindow()
+	if existing.ScheduledStart != nil && existing.ScheduledEnd != nil {
+		start, end = *existing.ScheduledStart, *existing.ScheduledEnd
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, s.buildDriverSuggestions(ctx, id, start, end))
+}
+
+func (s *service) ListOpenEmergencies(ctx context.Context) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	requests, err := s.repo.FindAll(ctx, map[string]interface{}{
+		"status":           string(StatusPending),
+		"service_category": string(ambulance_history.EmergencyService),
+		"sort_by":          "created_at asc",
+		"limit":            openEmergencyLimit,
+	})
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat permintaan darurat", nil, nil)
+	}
+	if len(requests) > openEmergencyLimit {
+		requests = requests[:openEmergencyLimit]
+	}
+
+	responses := make([]AmbulanceServiceRequestResponse, 0, len(requests))
+	for _, request := range requests {
+		responses = append(responses, request.toAmbulanceServiceRequestResponse())
+	}
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, responses)
+}
+
+func (s *service) ClaimEmergencyRequest(ctx context.Context, driverAccountID string, id string, payload ClaimEmergencyPayload) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := uuid.Validate(id); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID permintaan ambulans tidak valid"}, nil)
+	}
+
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
+			return pkg.NewResponse(http.StatusNotFound, "Permintaan ambulans tidak ditemukan", nil, nil)
+		}
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat permintaan ambulans", nil, nil)
+	}
+	if !existing.IsEmergency() {
+		return pkg.NewResponse(http.StatusBadRequest, "Hanya permintaan darurat yang dapat diklaim langsung oleh supir", nil, nil)
+	}
+	if existing.Status != StatusPending {
+		return pkg.NewResponse(http.StatusConflict, "Permintaan darurat sudah ditangani", nil, nil)
+	}
+
+	var ambulanceRecord *ambulance.Ambulance
+	if payload.AmbulanceID != "" {
+		if err := uuid.Validate(payload.AmbulanceID); err != nil {
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"ambulanceId": "Format ID ambulans tidak valid"}, nil)
+		}
+		ambulanceRecord, err = s.ambulanceRepo.FindOneAmbulance(ctx, map[string]interface{}{"id": payload.AmbulanceID})
+	} else {
+		ambulanceRecord, err = s.ambulanceRepo.FindOneAmbulance(ctx, map[string]interface{}{"driver_id": driverAccountID})
+	}
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"ambulanceId": "Pilih ambulans yang akan digunakan"}, nil)
+		}
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengecek data ambulans", nil, nil)
+	}
+	if ambulanceRecord.Status == ambulance.AmbulanceStatusMaintenance {
+		return pkg.NewResponse(http.StatusConflict, "Ambulans sedang dalam perawatan", nil, nil)
+	}
+
+	now := time.Now()
+	start, end := existing.emergencyWindow(now)
+
+	candidates, err := s.rosterRepo.FindAvailableDrivers(ctx, start, end, map[string]interface{}{
+		"driver_id":          driverAccountID,
+		"exclude_request_id": id,
+	})
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memeriksa ketersediaan supir", nil, nil)
+	}
+	if len(candidates) == 0 {
+		return pkg.NewResponse(http.StatusConflict, "Anda sedang cuti atau memiliki perjalanan lain pada waktu tersebut", nil, nil)
+	}
+	driver := candidates[0]
+
+	updateData := map[string]interface{}{
+		"status":          StatusAccepted,
+		"ambulance_id":    ambulanceRecord.ID,
+		"driver_id":       driver.DriverID,
+		"claimed_at":      now,
+		"scheduled_start": start,
+		"scheduled_end":   end,
+		"updated_at":      now,
+	}
+
+	// The conditional update on the pending status lets exactly one driver win the claim.
+	if err := s.repo.AcceptWithSchedule(ctx, id, ambulanceRecord.ID.String(), driver.DriverID, start, end, updateData); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return pkg.NewResponse(http.StatusConflict, "Permintaan darurat sudah diklaim oleh supir lain", nil, nil)
+		case errors.Is(err, ErrScheduleConflict):
+			return pkg.NewResponse(http.StatusConflict, "Ambulans sudah dijadwalkan pada waktu tersebut, pilih ambulans lain", nil, nil)
+		case errors.Is(err, ErrDriverConflict):
+			return pkg.NewResponse(http.StatusConflict, "Anda sudah memiliki perjalanan lain pada waktu tersebut", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "ambulance_service_request.service",
+			"request_id": id,
+			"driver_id":  driverAccountID,
+		}).WithError(err).Error("failed to claim emergency request")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengklaim permintaan darurat", nil, nil)
+	}
+
+	if existing.Account.Email != "" {
+		go func(email, username, submitterName string) {
+			if err := s.emailService.SendAmbulanceServiceRequestAcceptedEmail(email, username, submitterName); err != nil {
+				logrus.WithFields(logrus.Fields{
+					"component":      "ambulance_service_request.service",
+					"email":          email,
+					"submitter_name": submitterName,
+				}).WithError(err).Error("failed to send ambulance request accepted email asynchronously")
+			}
+		}(existing.Account.Email, existing.Account.UserProfile.Username, existing.SubmitterName)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Permintaan darurat berhasil diklaim", nil, AcceptedAssignmentResponse{
+		RequestID:   id,
+		AmbulanceID: ambulanceRecord.ID.String(),
+		PlateNumber: ambulanceRecord.PlateNumber,
+		Driver:      driver.ToDriverSuggestionResponse(),
+	})
+}
+
+// EscalateEmergencyRequests re-notifies emergencies nobody has claimed within the escalation
+// window, widening the audience to every free driver and the foundation chairman.
+func (s *service) EscalateEmergencyRequests(ctx context.Context) error {
+	if s.emergencyEscalation <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	now := time.Now()
+	requests, err := s.repo.FindUnclaimedEmergencies(ctx, now.Add(-s.emergencyEscalation), maxEmergencyEscalations)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "ambulance_service_request.service",
+		}).WithError(err).Error("failed to load unclaimed emergency requests")
+		return err
+	}
+
+	for _, request := range requests {
+		escalated, err := s.repo.MarkEscalated(ctx, request.ID.String(), request.EscalationLevel, now)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":  "ambulance_service_request.service",
+				"request_id": request.ID.String(),
+			}).WithError(err).Error("failed to escalate emergency request")
+			continue
+		}
+		if !escalated {
+			continue
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":        "ambulance_service_request.service",
+			"request_id":       request.ID.String(),
+			"escalation_level": request.EscalationLevel + 1,
+		}).Warn("emergency ambulance request unclaimed, escalating")
+		go s.dispatchEmergency(request, request.EscalationLevel+1)
+	}
+	return nil
+}
+
+// dispatchEmergency notifies staff about an unclaimed emergency. The first round goes to on-shift
+// drivers and ambulance managers; escalations add every free driver and the foundation chairman.
+// It runs in the background, so it uses its own context.
+func (s *service) dispatchEmergency(request AmbulanceServiceRequest, escalationLevel int) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*s.timeout)
+	defer cancel()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"component":        "ambulance_service_request.service",
+		"request_id":       request.ID.String(),
+		"escalation_level": escalationLevel,
+	})
+
+	start, end := request.emergencyWindow(time.Now())
+	driverOptions := map[string]interface{}{"on_shift_only": escalationLevel == 0}
+	drivers, err := s.rosterRepo.FindAvailableDrivers(ctx, start, end, driverOptions)
+	if err != nil {
+		logger.WithError(err).Error("failed to load drivers for emergency dispatch")
+	}
+
+	var contacts []Contact
+	if len(drivers) > 0 {
+		driverIDs := make([]uuid.UUID, 0, len(drivers))
+		for _, driver := range drivers {
+			driverIDs = append(driverIDs, driver.DriverID)
+		}
+		driverContacts, err := s.repo.FindContacts(ctx, map[string]interface{}{"account_ids": driverIDs})
+		if err != nil {
+			logger.WithError(err).Error("failed to load driver contacts for emergency dispatch")
+		}
+		contacts = append(contacts, driverContacts...)
+	}
+
+	roleIDs := []int{account.AmbulanceManagerRoleID}
+	if escalationLevel > 0 {
+		roleIDs = append(roleIDs, account.ChairmanRoleID)
+	}
+	for _, roleID := range roleIDs {
+		staff, err := s.repo.FindContacts(ctx, map[string]interface{}{"role_id": roleID})
+		if err != nil {
+			logger.WithError(err).Error("failed to load staff contacts for emergency dispatch")
+			continue
+		}
+		contacts = append(contacts, staff...)
+	}
+
+	details := request.dispatchDetails()
+	message := request.dispatchMessage(escalationLevel)
+	notified := make(map[uuid.UUID]bool, len(contacts))
+	for _, contact := range contacts {
+		if notified[contact.AccountID] {
+			continue
+		}
+		notified[contact.AccountID] = true
+
+		if contact.Email != "" {
+			if err := s.emailService.SendEmergencyDispatchEmail(contact.Email, contact.Username, details, escalationLevel); err != nil {
+				logger.WithField("account_id", contact.AccountID.String()).WithError(err).Error("failed to send emergency dispatch email")
+			}
+		}
+		if contact.Phone != nil && *contact.Phone != "" {
+			if err := s.smsSender.Send(ctx, sms.ChannelWhatsApp, *contact.Phone, message); err != nil {
+				logger.WithField("account_id", contact.AccountID.String()).WithError(err).Error("failed to send emergency dispatch message")
+			}
+		}
+	}
+
+	if len(notified) == 0 {
+		logger.Warn("no staff available to notify about emergency request")
+	}
+}
+
+func (s *service) SubmitTripFeedback(ctx context.Context, accountID string, id string, payload TripFeedbackPayload) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := uuid.Validate(id); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID permintaan ambulans tidak valid"}, nil)
+	}
+
+	errValidation := make(map[string]string)
+	if payload.DriverRating < ambulance_history.MinRating || payload.DriverRating > ambulance_history.MaxRating {
+		errValidation["driverRating"] = fmt.Sprintf("Penilaian supir harus antara %d dan %d", ambulance_history.MinRating, ambulance_history.MaxRating)
+	}
+	if payload.VehicleRating < ambulance_history.MinRating || payload.VehicleRating > ambulance_history.MaxRating {
+		errValidation["vehicleRating"] = fmt.Sprintf("Penilaian kendaraan harus antara %d dan %d", ambulance_history.MinRating, ambulance_history.MaxRating)
+	}
+	if len(payload.Comment) > 1000 {
+		errValidation["comment"] = "Komentar maksimal 1000 karakter"
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
+			return pkg.NewResponse(http.StatusNotFound, "Permintaan ambulans tidak ditemukan", nil, nil)
+		}
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat permintaan ambulans", nil, nil)
+	}
+	if existing.SubmittedBy.String() != accountID {
+		return pkg.NewResponse(http.StatusForbidden, "Anda tidak memiliki akses untuk melakukan tindakan ini", nil, nil)
+	}
+	if existing.Status != StatusDone {
+		return pkg.NewResponse(http.StatusBadRequest, "Ulasan hanya dapat diberikan untuk layanan yang sudah selesai", nil, nil)
+	}
+
+	if _, err := s.ambulanceHistoryRepo.FindFeedbackByRequestID(ctx, id); err == nil {
+		return pkg.NewResponse(http.StatusConflict, "Anda sudah memberikan ulasan untuk layanan ini", nil, nil)
+	} else if err.Error() != gorm.ErrRecordNotFound.Error() {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memeriksa ulasan layanan", nil, nil)
+	}
+
+	// The history row records who actually drove and when the trip finished.
+	history, err := s.ambulanceHistoryRepo.FindByRequestID(ctx, id)
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
+			return pkg.NewResponse(http.StatusConflict, "Riwayat layanan belum tersedia untuk permintaan ini", nil, nil)
+		}
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat riwayat layanan", nil, nil)
+	}
+
+	now := time.Now()
+	finishedAt := history.CreatedAt
+	if history.FinishedAt != nil {
+		finishedAt = *history.FinishedAt
+	}
+	if now.Sub(finishedAt) > feedbackWindow {
+		return pkg.NewResponse(http.StatusBadRequest, "Batas waktu pemberian ulasan sudah berakhir", nil, nil)
+	}
+
+	feedback := ambulance_history.TripFeedback{
+		ID:            uuid.New(),
+		RequestID:     existing.ID,
+		HistoryID:     &history.ID,
+		AmbulanceID:   history.AmbulanceID,
+		DriverID:      history.DriverID,
+		AccountID:     existing.SubmittedBy,
+		DriverRating:  payload.DriverRating,
+		VehicleRating: payload.VehicleRating,
+		Comment:       strings.TrimSpace(payload.Comment),
+		CreatedAt:     now,
+	}
+	if err := s.ambulanceHistoryRepo.CreateFeedback(ctx, feedback); err != nil {
+		// Guards against a concurrent duplicate submission slipping past the check above.
+		if errors.Is(err, ambulance_history.ErrFeedbackExists) {
+			return pkg.NewResponse(http.StatusConflict, "Anda sudah memberikan ulasan untuk layanan ini", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "ambulance_service_request.service",
+			"request_id": id,
+		}).WithError(err).Error("failed to create trip feedback")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menyimpan ulasan layanan", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusCreated, "Terima kasih, ulasan Anda berhasil dikirim", nil, feedback.ToFeedbackResponse())
+}
+
+func (s *service) ReportTripIncident(ctx context.Context, driverAccountID string, id string, payload TripIncidentPayload) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	existing, res := s.findAssignedRequest(ctx, driverAccountID, id)
+	if existing == nil {
+		return res
+	}
+
+	if existing.Status != StatusAccepted && existing.Status != StatusInService && existing.Status != StatusDone {
+		return pkg.NewResponse(http.StatusBadRequest, "Laporan insiden hanya dapat dibuat untuk perjalanan yang diterima, berjalan, atau selesai", nil, nil)
+	}
+
+	now := time.Now()
+	errValidation := make(map[string]string)
+	incidentType := ambulance_history.IncidentType(payload.Type)
+	if !incidentType.IsValid() {
+		errValidation["type"] = "Jenis insiden tidak valid (vehicle_damage, patient_condition, delay, other)"
+	}
+	if strings.TrimSpace(payload.Description) == "" {
+		errValidation["description"] = "Deskripsi insiden wajib diisi"
+	}
+	occurredAt := now
+	if payload.OccurredAt != "" {
+		parsed, err := time.Parse(time.RFC3339, payload.OccurredAt)
+		if err != nil {
+			errValidation["occurredAt"] = "Format waktu tidak valid, diharapkan RFC3339"
+		} else if parsed.After(now.Add(tripLocationClockSkew)) {
+			errValidation["occurredAt"] = "Waktu insiden tidak boleh di masa depan"
+		} else {
+			occurredAt = parsed
+		}
+	}
+	if len(payload.Photos) > maxIncidentPhotos {
+		errValidation["photos"] = fmt.Sprintf("Maksimal %d foto per laporan", maxIncidentPhotos)
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	driverID, err := uuid.Parse(driverAccountID)
+	if err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"accountId": "Format ID akun tidak valid"}, nil)
+	}
+
+	incident := ambulance_history.IncidentReport{
+		ID:          uuid.New(),
+		RequestID:   existing.ID,
+		AmbulanceID: *existing.AmbulanceID,
+		DriverID:    driverID,
+		Type:        incidentType,
+		Description: strings.TrimSpace(payload.Description),
+		OccurredAt:  occurredAt,
+		CreatedAt:   now,
+	}
+
+	uploaded := make([]string, 0, len(payload.Photos))
+	for _, file := range payload.Photos {
+		photoURL, err := s.s3Client.UploadFile(ctx, file, "ambulance-incidents")
+		if err != nil {
+			s.deleteUploadedFiles(uploaded)
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah foto insiden", nil, nil)
+		}
+		uploaded = append(uploaded, photoURL)
+		incident.Photos = append(incident.Photos, ambulance_history.IncidentPhoto{
+			ID:         uuid.New(),
+			IncidentID: incident.ID,
+			URL:        photoURL,
+			CreatedAt:  now,
+		})
+	}
+
+	if err := s.ambulanceHistoryRepo.CreateIncident(ctx, incident); err != nil {
+		s.deleteUploadedFiles(uploaded)
+		logrus.WithFields(logrus.Fields{
+			"component":  "ambulance_service_request.service",
+			"request_id": id,
+			"driver_id":  driverAccountID,
+		}).WithError(err).Error("failed to create incident report")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menyimpan laporan insiden", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusCreated, "Laporan insiden berhasil dikirim", nil, incident.ToIncidentResponse())
+}
+
+// deleteUploadedFiles removes objects uploaded for a write that did not go through.
+func (s *service) deleteUploadedFiles(urls []string) {
+	for _, fileURL := range urls {
+		if err := s.s3Client.DeleteFile(context.Background(), s3_pkg.ExtractObjectNameFromURL(fileURL)); err != nil {
+			logrus.WithField("component", "ambulance_service_request.service").WithError(err).Warn("failed to delete orphaned upload")
+		}
+	}
+}
+
+// resolveTripDriver picks the driver for a trip. A driver chosen by the manager only has to be free;
+// otherwise the on-shift driver with the fewest recent trips is taken, falling back to the
+// ambulance's default driver when nobody is rostered. A nil driver means res holds the error response.
+func (s *service) resolveTripDriver(ctx context.Context, requestID string, ambulanceRecord *ambulance.Ambulance, chosenDriverID string, start, end time.Time) (*driver_roster.DriverCandidate, pkg.Response) {
+	options := map[string]interface{}{"exclude_request_id": requestID}
+	if chosenDriverID != "" {
+		if err := uuid.Validate(chosenDriverID); err != nil {
+			return nil, pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"driverId": "Format ID supir tidak valid"}, nil)
+		}
+		options["driver_id"] = chosenDriverID
+	}
+
+	candidates, err := s.rosterRepo.FindAvailableDrivers(ctx, start, end, options)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "ambulance_service_request.service",
+			"request_id": requestID,
+		}).WithError(err).Error("failed to load available drivers")
+		return nil, pkg.NewResponse(http.StatusInternalServerError, "Gagal memeriksa ketersediaan supir", nil, nil)
+	}
+
+	if chosenDriverID != "" {
+		if len(candidates) == 0 {
+			return nil, pkg.NewResponse(http.StatusConflict, "Supir sedang cuti atau sudah dijadwalkan pada waktu tersebut", nil, s.buildDriverSuggestions(ctx, requestID, start, end))
+		}
+		return &candidates[0], pkg.Response{}
+	}
+
+	// Candidates are ordered on-shift first, then by fewest trips.
+	if len(candidates) > 0 && candidates[0].OnShift {
+		return &candidates[0], pkg.Response{}
+	}
+	for i := range candidates {
+		if candidates[i].DriverID == ambulanceRecord.DriverID {
+			return &candidates[i], pkg.Response{}
+		}
+	}
+
+	return nil, pkg.NewResponse(http.StatusConflict, "Tidak ada supir yang bertugas pada waktu tersebut, pilih supir secara manual", nil, DriverSuggestionListResponse{
+		RequestedStart: start,
+		RequestedEnd:   end,
+		Drivers:        driver_roster.ToDriverSuggestionListResponse(candidates),
+	})
+}
+
+func (s *service) buildDriverSuggestions(ctx context.Context, requestID string, start, end time.Time) DriverSuggestionListResponse {
+	suggestions := DriverSuggestionListResponse{
+		RequestedStart: start,
+		RequestedEnd:   end,
+		Drivers:        []driver_roster.DriverSuggestionResponse{},
+	}
+
+	candidates, err := s.rosterRepo.FindAvailableDrivers(ctx, start, end, map[string]interface{}{"exclude_request_id": requestID})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "ambulance_service_request.service",
+			"request_id": requestID,
+		}).WithError(err).Error("failed to load driver suggestions")
+		return suggestions
+	}
+	suggestions.Drivers = driver_roster.ToDriverSuggestionListResponse(candidates)
+	return suggestions
+}
+
+// findAssignedRequest loads a request and checks that it is assigned to the driver.
+// A nil request means res holds the error response.
+func (s *service) findAssignedRequest(ctx context.Context, driverAccountID string, id string) (*AmbulanceServiceRequest, pkg.Response) {
+	if err := uuid.Validate(id); err != nil {
+		return nil, pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID permintaan ambulans tidak valid"}, nil)
+	}
+
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
+			return nil, pkg.NewResponse(http.StatusNotFound, "Permintaan ambulans tidak ditemukan", nil, nil)
+		}
+		return nil, pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat permintaan ambulans", nil, nil)
+	}
+
+	if existing.AmbulanceID == nil || !existing.IsDrivenBy(driverAccountID) {
+		return nil, pkg.NewResponse(http.StatusForbidden, "Anda tidak ditugaskan untuk permintaan ini", nil, nil)
+	}
+	return &existing, pkg.Response{}
+}
+
+func (s *service) RecordTripLocations(ctx context.Context, driverAccountID string, id string, payload RecordTripLocationsPayload) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := uuid.Validate(id); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID permintaan ambulans tidak valid"}, nil)
+	}
+	if len(payload.Points) == 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"points": "Titik lokasi wajib diisi"}, nil)
+	}
+	if len(payload.Points) > maxTripLocationBatch {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"points": fmt.Sprintf("Maksimal %d titik lokasi per pengiriman", maxTripLocationBatch)}, nil)
+	}
+
+	existing, res := s.findAssignedRequest(ctx, driverAccountID, id)
+	if existing == nil {
+		return res
+	}
+	if existing.Status != StatusInService {
+		return pkg.NewResponse(http.StatusBadRequest, "Lokasi hanya dapat dikirim saat permintaan dalam pelayanan", nil, nil)
+	}
+
+	driverID, err := uuid.Parse(driverAccountID)
+	if err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"accountId": "Format ID akun tidak valid"}, nil)
+	}
+
+	now := time.Now()
+	// Requests started before tracking existed have no start time, so only the upper bound applies.
+	var earliest time.Time
+	if existing.StartedAt != nil {
+		earliest = existing.StartedAt.Add(-tripLocationClockSkew)
+	}
+
+	errValidation := make(map[string]string)
+	locations := make([]TripLocation, 0, len(payload.Points))
+	for i, point := range payload.Points {
+		field := fmt.Sprintf("points[%d]", i)
+		if point.Latitude < -90 || point.Latitude > 90 || point.Longitude < -180 || point.Longitude > 180 {
+			errValidation[field] = "Koordinat tidak valid"
+			continue
+		}
+		recordedAt, err := time.Parse(time.RFC3339, point.RecordedAt)
+		if err != nil {
+			errValidation[field] = "Format waktu tidak valid (gunakan RFC3339)"
+			continue
+		}
+		if recordedAt.Before(earliest) || recordedAt.After(now.Add(tripLocationClockSkew)) {
+			errValidation[field] = "Waktu lokasi di luar rentang perjalanan"
+			continue
+		}
+		locations = append(locations, TripLocation{
+			ID:          uuid.New(),
+			RequestID:   existing.ID,
+			AmbulanceID: *existing.AmbulanceID,
+			DriverID:    driverID,
+			Latitude:    point.Latitude,
+			Longitude:   point.Longitude,
+			Accuracy:    point.Accuracy,
+			Speed:       point.Speed,
+			Heading:     point.Heading,
+			RecordedAt:  recordedAt.UTC(),
+			CreatedAt:   now,
+		})
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	if err := s.repo.CreateTripLocations(ctx, locations); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "ambulance_service_request.service",
+			"request_id": id,
+		}).WithError(err).Error("failed to store trip locations")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menyimpan lokasi perjalanan", nil, nil)
+	}
+
+	// Only the newest point matters to live viewers; the full track is fetched separately.
+	latest := locations[0]
+	for _, location := range locations[1:] {
+		if location.RecordedAt.After(latest.RecordedAt) {
+			latest = location
+		}
+	}
+	latestResponse := latest.toTripLocationResponse()
+	s.publishTripEvent(id, TripEvent{Type: TripEventLocation, Location: &latestResponse})
+
+	return pkg.NewResponse(http.StatusCreated, "Lokasi perjalanan berhasil disimpan", nil, map[string]int{"accepted": len(locations)})
+}
+
+func (s *service) GetTripTrack(ctx context.Context, accountID string, role enum.RoleName, id string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	existing, res := s.findTrackableRequest(ctx, accountID, role, id)
+	if existing == nil {
+		return res
+	}
+
+	points, err := s.repo.FindTripLocations(ctx, id, map[string]interface{}{"limit": maxTripTrackPoints})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "ambulance_service_request.service",
+			"request_id": id,
+		}).WithError(err).Error("failed to load trip locations")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat lokasi perjalanan", nil, nil)
+	}
+
+	track := TripTrackResponse{
+		RequestID:      existing.ID.String(),
+		Status:         existing.Status,
+		StartedAt:      existing.StartedAt,
+		DistanceMeters: summarizeTrack(points).DistanceMeters,
+		Points:         make([]TripLocationResponse, 0, len(points)),
+	}
+	for _, point := range points {
+		track.Points = append(track.Points, point.toTripLocationResponse())
+	}
+	if len(track.Points) > 0 {
+		track.Latest = &track.Points[len(track.Points)-1]
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Lokasi perjalanan berhasil diambil", nil, track)
+}
+
+func (s *service) StreamTripEvents(ctx context.Context, accountID string, role enum.RoleName, id string) (<-chan TripEvent, func(), pkg.Response) {
+	lookupCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	existing, res := s.findTrackableRequest(lookupCtx, accountID, role, id)
+	if existing == nil {
+		return nil, nil, res
+	}
+	if existing.Status != StatusInService {
+		return nil, nil, pkg.NewResponse(http.StatusBadRequest, "Pelacakan langsung hanya tersedia saat permintaan dalam pelayanan", nil, nil)
+	}
+
+	messages, release, err := s.broker.Subscribe(lookupCtx, tripTopic(id))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "ambulance_service_request.service",
+			"request_id": id,
+		}).WithError(err).Error("failed to subscribe to trip events")
+		return nil, nil, pkg.NewResponse(http.StatusInternalServerError, "Gagal memulai pelacakan langsung", nil, nil)
+	}
+
+	events := make(chan TripEvent, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(events)
+		for {
+			select {
+			case <-done:
+				return
+			case raw, ok := <-messages:
+				if !ok {
+					return
+				}
+				var event TripEvent
+				if err := json.Unmarshal(raw, &event); err != nil {
+					continue
+				}
+				select {
+				case events <- event:
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+
+	var latest *TripLocationResponse
+	if location, err := s.repo.FindLatestTripLocation(lookupCtx, id); err == nil {
+		response := location.toTripLocationResponse()
+		latest = &response
+	}
+
+	stop := func() {
+		close(done)
+		release()
+	}
+	return events, stop, pkg.NewResponse(http.StatusOK, "Berhasil", nil, latest)
+}
+
+// findTrackableRequest loads a request and checks that the caller may follow its trip:
+// managers see every request, requesters only their own. A nil request means res holds the error.
+func (s *service) findTrackableRequest(ctx context.Context, accountID string, role enum.RoleName, id string) (*AmbulanceServiceRequest, pkg.Response) {
+	if err := uuid.Validate(id); err != nil {
+		return nil, pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID permintaan ambulans tidak valid"}, nil)
+	}
+
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
+			return nil, pkg.NewResponse(http.StatusNotFound, "Permintaan ambulans tidak ditemukan", nil, nil)
+		}
+		return nil, pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat permintaan ambulans", nil, nil)
+	}
+
+	switch role {
+	case enum.RoleAmbulanceManager:
+	case enum.RoleOrangTuaAsuh:
+		if existing.SubmittedBy.String() != accountID {
+			return nil, pkg.NewResponse(http.StatusForbidden, "Anda tidak memiliki akses untuk melihat permintaan ini", nil, nil)
+		}
+	default:
+		return nil, pkg.NewResponse(http.StatusForbidden, "Anda tidak memiliki akses untuk melakukan tindakan ini", nil, nil)
+	}
+
+	if existing.Status != StatusInService && existing.Status != StatusDone && existing.Status != StatusCancelled {
+		return nil, pkg.NewResponse(http.StatusBadRequest, "Perjalanan ambulans belum dimulai", nil, nil)
+	}
+
+	return &existing, pkg.Response{}
+}
+
+// publishTripEvent is fire-and-forget: live viewers are a convenience and must never
+// fail the driver's request.
+func (s *service) publishTripEvent(requestID string, event TripEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	if err := s.broker.Publish(ctx, tripTopic(requestID), payload); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "ambulance_service_request.service",
+			"request_id": requestID,
+		}).WithError(err).Warn("failed to publish trip event")
+	}
+}
+
+// tripSheetStatuses are the states in which a request has an ambulance and driver to print.
+var tripSheetStatuses = []Status{StatusAccepted, StatusInService, StatusDone}
+
+func (s *service) ExportTripSheetPDF(ctx context.Context, id string) ([]byte, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := uuid.Validate(id); err != nil {
+		return nil, "", fmt.Errorf("format ID permintaan ambulans tidak valid")
+	}
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
+			return nil, "", fmt.Errorf("permintaan ambulans tidak ditemukan")
+		}
+		return nil, "", fmt.Errorf("gagal memuat permintaan ambulans")
+	}
+	return s.renderTripSheet(ctx, &existing)
+}
+
+func (s *service) ExportAssignedTripSheetPDF(ctx context.Context, driverAccountID string, id string) ([]byte, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	existing, res := s.findAssignedRequest(ctx, driverAccountID, id)
+	if existing == nil {
+		return nil, "", errors.New(res.Message)
+	}
+	return s.renderTripSheet(ctx, existing)
+}
+
+// renderTripSheet prints the paper trip log managers used to fill in by hand. Actual trip
+// figures are filled from the recorded history once the trip is done; otherwise blanks are left
+// for the driver.
+func (s *service) renderTripSheet(ctx context.Context, request *AmbulanceServiceRequest) ([]byte, string, error) {
+	accepted := false
+	for _, status := range tripSheetStatuses {
+		if request.Status == status {
+			accepted = true
+			break
+		}
+	}
+	if !accepted || request.Ambulance == nil {
+		return nil, "", fmt.Errorf("lembar perjalanan hanya tersedia untuk permintaan yang sudah diterima")
+	}
+
+	const blank = "..............................."
+	yesNo := func(v bool) string {
+		if v {
+			return "Ya"
+		}
+		return "Tidak"
+	}
+
+	driverName, driverPhone := "-", "-"
+	driver := request.Driver
+	if driver == nil {
+		driver = &request.Ambulance.Driver
+	}
+	if driver.UserProfile.Username != "" {
+		driverName = driver.UserProfile.Username
+	}
+	if driver.UserProfile.Phone != nil {
+		driverPhone = *driver.UserProfile.Phone
+	}
+
+	doc := report.NewPDF()
+	doc.Title("LEMBAR PERJALANAN AMBULANS")
+	doc.Field("No. Permintaan", request.ID.String())
+	doc.Field("Kategori Layanan", request.ServiceCategory.Label())
+	doc.Field("Dicetak", time.Now().Format("02-01-2006 15:04"))
+
+	doc.Heading("Pemohon")
+	doc.Field("Nama", request.SubmitterName)
+	doc.Field("No. Telepon", string(request.SubmitterPhone))
+
+	doc.Heading("Pasien")
+	doc.Field("Nama", request.PatientName)
+	if request.PatientAge > 0 {
+		doc.Field("Usia", fmt.Sprintf("%d tahun", request.PatientAge))
+	} else {
+		doc.Field("Usia", "-")
+	}
+	doc.Field("Penyakit", string(request.Disease))
+	doc.Field("Penyakit Menular", yesNo(request.IsInfectious))
+	doc.Field("Dapat Duduk", yesNo(request.IsAbleToSit))
+
+	doc.Heading("Perjalanan")
+	doc.Field("Jadwal Penjemputan", request.PickupAt().Format("02-01-2006 15:04"))
+	doc.Field("Alamat Penjemputan", string(request.PatientAddress))
+	doc.Field("Tujuan", request.Destination)
+	doc.Field("Catatan", request.Note)
+
+	doc.Heading("Kendaraan & Pengemudi")
+	doc.Field("No. Polisi", request.Ambulance.PlateNumber)
+	doc.Field("Pengemudi", driverName)
+	doc.Field("No. Telepon Pengemudi", driverPhone)
+
+	doc.Heading("Catatan Perjalanan")
+	startedAt, finishedAt, distance := blank, blank, blank
+	if request.StartedAt != nil {
+		startedAt = request.StartedAt.Format("02-01-2006 15:04")
+	}
+	if history, err := s.ambulanceHistoryRepo.FindByRequestID(ctx, request.ID.String()); err == nil {
+		if history.FinishedAt != nil {
+			finishedAt = history.FinishedAt.Format("02-01-2006 15:04")
+		}
+		if history.DistanceMeters > 0 {
+			distance = fmt.Sprintf("%.1f km", history.DistanceMeters/1000)
+		}
+	}
+	doc.Field("Berangkat", startedAt)
+	doc.Field("Selesai", finishedAt)
+	doc.Field("Jarak Tempuh", distance)
+	doc.Field("Km Awal / Km Akhir", blank+" / "+blank)
+
+	doc.Spacer(20)
+	doc.Signatures("Pemohon", "Pengemudi", "Manajer Ambulans")
+
+	filename := fmt.Sprintf("trip_sheet_%s.pdf", request.ID.String())
+	return doc.Bytes(), filename, nil
+}
+
+// GetRequestDocument hands out a short-lived link to a sensitive upload. Managers can open any
+// request's documents; requesters only their own.
+func (s *service) GetRequestDocument(ctx context.Context, accountID string, role enum.RoleName, id string, document string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := uuid.Validate(id); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID permintaan ambulans tidak valid"}, nil)
+	}
+	if document != DocumentSubmitterIDCard {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"document": "Jenis dokumen tidak valid"}, nil)
+	}
+
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
+			return pkg.NewResponse(http.StatusNotFound, "Permintaan ambulans tidak ditemukan", nil, nil)
+		}
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat permintaan ambulans", nil, nil)
+	}
+
+	switch role {
+	case enum.RoleAmbulanceManager:
+	case enum.RoleOrangTuaAsuh:
+		if existing.SubmittedBy.String() != accountID {
+			return pkg.NewResponse(http.StatusForbidden, "Anda tidak memiliki akses untuk melihat dokumen ini", nil, nil)
+		}
+	default:
+		return pkg.NewResponse(http.StatusForbidden, "Anda tidak memiliki akses untuk melakukan tindakan ini", nil, nil)
+	}
+
+	if existing.SubmitterIDCard == "" {
+		return pkg.NewResponse(http.StatusNotFound, "Dokumen tidak ditemukan", nil, nil)
+	}
+
+	link, err := s3_pkg.SignDocument(ctx, s.privateStorage, existing.SubmitterIDCard)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "ambulance_service_request.service",
+			"request_id": id,
+		}).WithError(err).Error("failed to sign document url")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membuat tautan dokumen", nil, nil)
+	}
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, link)
+}
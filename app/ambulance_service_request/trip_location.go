@@ -0,0 +1,119 @@
+package ambulance_service_request
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TripLocation is a single GPS point reported by the driver app while a request is in service.
+type TripLocation struct {
+	ID          uuid.UUID `json:"id" gorm:"primaryKey"`
+	RequestID   uuid.UUID `json:"requestId" gorm:"not null;index:idx_trip_location_request,priority:1"`
+	AmbulanceID uuid.UUID `json:"ambulanceId" gorm:"not null"`
+	DriverID    uuid.UUID `json:"driverId" gorm:"not null"`
+	Latitude    float64   `json:"latitude" gorm:"not null"`
+	Longitude   float64   `json:"longitude" gorm:"not null"`
+	Accuracy    *float64  `json:"accuracy"`
+	Speed       *float64  `json:"speed"`
+	Heading     *float64  `json:"heading"`
+	RecordedAt  time.Time `json:"recordedAt" gorm:"not null;index:idx_trip_location_request,priority:2"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+const (
+	// maxTrackAccuracyMeters drops fixes too imprecise to count towards the trip distance.
+	maxTrackAccuracyMeters = 100
+	// maxTrackSpeedMps rejects segments faster than any ambulance can travel (~200 km/h),
+	// which are GPS jumps rather than real movement.
+	maxTrackSpeedMps  = 55
+	earthRadiusMeters = 6371000
+)
+
+// TripEventType is the kind of message pushed to live trip subscribers.
+type TripEventType string
+
+const (
+	TripEventLocation  TripEventType = "location"
+	TripEventCompleted TripEventType = "completed"
+	TripEventCancelled TripEventType = "cancelled"
+)
+
+// TripEvent is published on the trip topic whenever the tracked request changes.
+type TripEvent struct {
+	Type     TripEventType         `json:"type"`
+	Location *TripLocationResponse `json:"location,omitempty"`
+}
+
+func tripTopic(requestID string) string {
+	return "ambulance_trip:" + requestID
+}
+
+// trackSummary is the route information stored on the AmbulanceHistory of a finished trip.
+type trackSummary struct {
+	DistanceMeters float64
+	Polyline       string
+}
+
+// summarizeTrack computes the travelled distance and an encoded polyline from points
+// ordered by RecordedAt. Inaccurate fixes and impossible jumps are skipped.
+func summarizeTrack(points []TripLocation) trackSummary {
+	var summary trackSummary
+	var kept []TripLocation
+	for _, p := range points {
+		if p.Accuracy != nil && *p.Accuracy > maxTrackAccuracyMeters {
+			continue
+		}
+		if len(kept) > 0 {
+			prev := kept[len(kept)-1]
+			distance := haversineMeters(prev.Latitude, prev.Longitude, p.Latitude, p.Longitude)
+			elapsed := p.RecordedAt.Sub(prev.RecordedAt).Seconds()
+			if elapsed > 0 && distance/elapsed > maxTrackSpeedMps {
+				continue
+			}
+			summary.DistanceMeters += distance
+		}
+		kept = append(kept, p)
+	}
+	summary.DistanceMeters = math.Round(summary.DistanceMeters)
+	summary.Polyline = encodePolyline(kept)
+	return summary
+}
+
+func haversineMeters(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMeters * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// encodePolyline implements the Google encoded polyline algorithm (precision 5),
+// which map SDKs on the frontend can decode directly.
+func encodePolyline(points []TripLocation) string {
+	var b strings.Builder
+	var prevLat, prevLon int64
+	for _, p := range points {
+		lat := int64(math.Round(p.Latitude * 1e5))
+		lon := int64(math.Round(p.Longitude * 1e5))
+		encodePolylineValue(&b, lat-prevLat)
+		encodePolylineValue(&b, lon-prevLon)
+		prevLat, prevLon = lat, lon
+	}
+	return b.String()
+}
+
+func encodePolylineValue(b *strings.Builder, v int64) {
+	v <<= 1
+	if v < 0 {
+		v = ^v
+	}
+	for v >= 0x20 {
+		b.WriteByte(byte((0x20 | (v & 0x1f)) + 63))
+		v >>= 5
+	}
+	b.WriteByte(byte(v + 63))
+}
@@ -0,0 +1,38 @@
+package ambulance_transaction
+
+import (
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/account"
+	"github.com/Vilamuzz/yota-backend/app/ambulance"
+	"github.com/google/uuid"
+)
+
+// AmbulanceTransaction is a donation to the ambulance service, usually made by a family after a
+// finished trip. Settled donations are income of the ambulance operational fund.
+type AmbulanceTransaction struct {
+	ID                uuid.UUID  `json:"id" gorm:"primaryKey"`
+	AmbulanceID       uuid.UUID  `json:"ambulanceId" gorm:"not null;index"`
+	RequestID         *uuid.UUID `json:"requestId" gorm:"index"` // the trip being thanked for, if any
+	OrderID           string     `json:"orderId" gorm:"uniqueIndex"`
+	AccountID         *uuid.UUID `json:"accountId"` // can be anonymous
+	DonorName         string     `json:"donorName"`
+	DonorEmail        string     `json:"donorEmail"`
+	IsOnline          bool       `json:"isOnline"`
+	GrossAmount       float64    `json:"grossAmount"`
+	FraudStatus       string     `json:"fraudStatus"`
+	TransactionStatus string     `json:"transactionStatus"`
+	Provider          string     `json:"provider"` // midtrans
+	TransactionID     string     `json:"transactionId"`
+	SnapToken         string     `json:"snapToken"`
+	SnapRedirectURL   string     `json:"snapRedirectUrl"`
+	PaidAt            *time.Time `json:"paidAt"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	UpdatedAt         time.Time  `json:"updatedAt"`
+
+	Account   *account.Account     `gorm:"foreignKey:AccountID;references:ID"`
+	Ambulance *ambulance.Ambulance `json:"-" gorm:"foreignKey:AmbulanceID;references:ID"`
+}
+
+// OrderPrefix routes Midtrans notifications for ambulance donations.
+const OrderPrefix = "AMB-"
@@ -0,0 +1,138 @@
+package ambulance_transaction
+
+import (
+	"net/http"
+
+	"github.com/Vilamuzz/yota-backend/app/middleware"
+	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/Vilamuzz/yota-backend/pkg/enum"
+	jwt_pkg "github.com/Vilamuzz/yota-backend/pkg/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	service    Service
+	middleware middleware.AppMiddleware
+}
+
+func NewHandler(r *gin.RouterGroup, s Service, m middleware.AppMiddleware) {
+	h := &handler{
+		service:    s,
+		middleware: m,
+	}
+	h.RegisterRoutes(r)
+}
+
+func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
+	r.POST("/ambulances/requests/:id/donations", h.middleware.AuthOptional(), h.CreateTripDonation)
+
+	admin := r.Group("/admin/ambulances/donations")
+	admin.Use(h.middleware.RequireRoles(enum.RoleFinance, enum.RoleAmbulanceManager))
+	{
+		admin.GET("", h.GetAmbulanceTransactionList)
+		admin.GET("/:id", h.GetAmbulanceTransactionByID)
+		admin.POST("", h.CreateOfflineAmbulanceTransaction)
+	}
+}
+
+// GetAmbulanceTransactionList
+//
+// @Summary List Ambulance Donations
+// @Description Retrieve a paginated list of ambulance service donations (finance and ambulance manager only)
+// @Tags Ambulance Donations
+// @Security BearerAuth
+// @Produce json
+// @Param ambulance_id query string false "Filter by ambulance ID"
+// @Param status query string false "Filter by payment status"
+// @Param limit query int false "Items per page"
+// @Param next_cursor query string false "Cursor for next page"
+// @Param prev_cursor query string false "Cursor for previous page"
+// @Success 200 {object} pkg.Response{data=AmbulanceTransactionListResponse}
+// @Router /api/admin/ambulances/donations [get]
+func (h *handler) GetAmbulanceTransactionList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var params AmbulanceTransactionQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid query parameters", nil, nil))
+		return
+	}
+
+	res := h.service.GetAmbulanceTransactionList(ctx, params)
+	c.JSON(res.Status, res)
+}
+
+// GetAmbulanceTransactionByID
+//
+// @Summary Get Ambulance Donation by ID
+// @Description Retrieve a specific ambulance service donation (finance and ambulance manager only)
+// @Tags Ambulance Donations
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Transaction ID"
+// @Success 200 {object} pkg.Response{data=AmbulanceTransactionResponse}
+// @Router /api/admin/ambulances/donations/{id} [get]
+func (h *handler) GetAmbulanceTransactionByID(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	res := h.service.GetAmbulanceTransactionByID(ctx, id)
+	c.JSON(res.Status, res)
+}
+
+// CreateOfflineAmbulanceTransaction
+//
+// @Summary Create Offline Ambulance Donation
+// @Description Record a cash donation for an ambulance without initiating a Midtrans payment
+// @Tags Ambulance Donations
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body CreateOfflineAmbulanceTransactionRequest true "Offline donation request"
+// @Success 201 {object} pkg.Response{data=AmbulanceTransactionResponse}
+// @Router /api/admin/ambulances/donations [post]
+func (h *handler) CreateOfflineAmbulanceTransaction(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req CreateOfflineAmbulanceTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid request body", nil, nil))
+		return
+	}
+
+	res := h.service.CreateOfflineAmbulanceTransaction(ctx, claims.AccountID, req)
+	c.JSON(res.Status, res)
+}
+
+// CreateTripDonation
+//
+// @Summary Donate After Ambulance Trip
+// @Description Initiate a Midtrans Snap payment for a donation to the ambulance that served a finished request
+// @Tags Ambulance Donations
+// @Accept json
+// @Produce json
+// @Param id path string true "Ambulance Service Request ID"
+// @Param body body CreateAmbulanceTransactionRequest true "Donation request"
+// @Success 201 {object} pkg.Response{data=AmbulanceTransactionResponse}
+// @Router /api/ambulances/requests/{id}/donations [post]
+func (h *handler) CreateTripDonation(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.Param("id")
+	accountID := ""
+	if userData, exists := c.Get("user_data"); exists {
+		if claims, ok := userData.(jwt_pkg.UserJWTClaims); ok {
+			accountID = claims.AccountID
+		}
+	}
+
+	var req CreateAmbulanceTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid request body", nil, nil))
+		return
+	}
+
+	res := h.service.CreateTripDonation(ctx, accountID, requestID, req)
+	c.JSON(res.Status, res)
+}
@@ -0,0 +1,88 @@
+package ambulance_transaction
+
+import (
+	"context"
+
+	"github.com/Vilamuzz/yota-backend/pkg"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	FindAllAmbulanceTransactions(ctx context.Context, options map[string]interface{}) ([]AmbulanceTransaction, error)
+	FindOneAmbulanceTransaction(ctx context.Context, options map[string]interface{}) (*AmbulanceTransaction, error)
+	CreateAmbulanceTransaction(ctx context.Context, tx *AmbulanceTransaction) error
+	UpdateAmbulanceTransaction(ctx context.Context, orderID string, updates map[string]interface{}) error
+}
+
+type repository struct {
+	Conn *gorm.DB
+}
+
+func NewRepository(conn *gorm.DB) Repository {
+	return &repository{Conn: conn}
+}
+
+func (r *repository) FindAllAmbulanceTransactions(ctx context.Context, options map[string]interface{}) ([]AmbulanceTransaction, error) {
+	var transactions []AmbulanceTransaction
+	query := r.Conn.WithContext(ctx).Preload("Ambulance")
+
+	if status, ok := options["status"]; ok && status.(string) != "" {
+		query = query.Where("transaction_status = ?", status.(string))
+	}
+	if ambulanceID, ok := options["ambulance_id"]; ok && ambulanceID.(string) != "" {
+		query = query.Where("ambulance_id = ?", ambulanceID.(string))
+	}
+
+	if nextCursor, ok := options["next_cursor"]; ok && nextCursor.(string) != "" {
+		cursorData, err := pkg.DecodeCursor(nextCursor.(string))
+		if err == nil {
+			query = query.Where("(created_at, id) < (?, ?)", cursorData.CreatedAt, cursorData.ID)
+		}
+	} else if prevCursor, ok := options["prev_cursor"]; ok && prevCursor.(string) != "" {
+		cursorData, err := pkg.DecodeCursor(prevCursor.(string))
+		if err == nil {
+			query = query.Where("(created_at, id) > (?, ?)", cursorData.CreatedAt, cursorData.ID)
+		}
+	}
+
+	if _, usingPrevCursor := options["prev_cursor"]; !usingPrevCursor {
+		query = query.Order("created_at DESC, id DESC")
+	} else {
+		query = query.Order("created_at ASC, id ASC")
+	}
+
+	limit := 10
+	if l, ok := options["limit"]; ok {
+		limit = l.(int)
+	}
+
+	query = query.Limit(limit + 1)
+	if err := query.Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+func (r *repository) FindOneAmbulanceTransaction(ctx context.Context, options map[string]interface{}) (*AmbulanceTransaction, error) {
+	var tx AmbulanceTransaction
+	query := r.Conn.WithContext(ctx).Preload("Ambulance")
+	if id, ok := options["id"]; ok && id.(string) != "" {
+		err := query.Where("id = ?", id.(string)).First(&tx).Error
+		return &tx, err
+	}
+	if orderID, ok := options["order_id"]; ok && orderID.(string) != "" {
+		err := query.Where("order_id = ?", orderID.(string)).First(&tx).Error
+		return &tx, err
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *repository) CreateAmbulanceTransaction(ctx context.Context, tx *AmbulanceTransaction) error {
+	return r.Conn.WithContext(ctx).Create(tx).Error
+}
+
+func (r *repository) UpdateAmbulanceTransaction(ctx context.Context, orderID string, updates map[string]interface{}) error {
+	return r.Conn.WithContext(ctx).Model(&AmbulanceTransaction{}).
+		Where("order_id = ?", orderID).
+		Updates(updates).Error
+}
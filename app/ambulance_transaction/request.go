@@ -0,0 +1,23 @@
+package ambulance_transaction
+
+import "github.com/Vilamuzz/yota-backend/pkg"
+
+type CreateAmbulanceTransactionRequest struct {
+	DonorName   string  `json:"donorName"`
+	DonorEmail  string  `json:"donorEmail"`
+	GrossAmount float64 `json:"grossAmount"`
+}
+
+type CreateOfflineAmbulanceTransactionRequest struct {
+	AmbulanceID string  `json:"ambulanceId"`
+	RequestID   string  `json:"requestId"` // optional
+	DonorName   string  `json:"donorName"`
+	DonorEmail  string  `json:"donorEmail"`
+	GrossAmount float64 `json:"grossAmount"`
+}
+
+type AmbulanceTransactionQueryParams struct {
+	AmbulanceID string `form:"ambulanceId"`
+	Status      string `form:"status"`
+	pkg.PaginationParams
+}
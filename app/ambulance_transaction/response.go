@@ -0,0 +1,66 @@
+package ambulance_transaction
+
+import (
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/pkg"
+)
+
+type AmbulanceTransactionResponse struct {
+	ID                string     `json:"id"`
+	AmbulanceID       string     `json:"ambulanceId"`
+	PlateNumber       string     `json:"plateNumber"`
+	RequestID         string     `json:"requestId"`
+	OrderID           string     `json:"orderId"`
+	DonorName         string     `json:"donorName"`
+	DonorEmail        string     `json:"donorEmail"`
+	IsOnline          bool       `json:"isOnline"`
+	GrossAmount       float64    `json:"grossAmount"`
+	TransactionStatus string     `json:"transactionStatus"`
+	TransactionID     string     `json:"transactionId"`
+	SnapToken         string     `json:"snapToken"`
+	SnapRedirectURL   string     `json:"snapRedirectUrl"`
+	PaidAt            *time.Time `json:"paidAt"`
+	CreatedAt         time.Time  `json:"createdAt"`
+}
+
+type AmbulanceTransactionListResponse struct {
+	Transactions []AmbulanceTransactionResponse `json:"transactions"`
+	Pagination   pkg.CursorPagination           `json:"pagination"`
+}
+
+func (tx *AmbulanceTransaction) toAmbulanceTransactionResponse() AmbulanceTransactionResponse {
+	response := AmbulanceTransactionResponse{
+		ID:                tx.ID.String(),
+		AmbulanceID:       tx.AmbulanceID.String(),
+		OrderID:           tx.OrderID,
+		DonorName:         tx.DonorName,
+		DonorEmail:        tx.DonorEmail,
+		IsOnline:          tx.IsOnline,
+		GrossAmount:       tx.GrossAmount,
+		TransactionStatus: tx.TransactionStatus,
+		TransactionID:     tx.TransactionID,
+		SnapToken:         tx.SnapToken,
+		SnapRedirectURL:   tx.SnapRedirectURL,
+		PaidAt:            tx.PaidAt,
+		CreatedAt:         tx.CreatedAt,
+	}
+	if tx.Ambulance != nil {
+		response.PlateNumber = tx.Ambulance.PlateNumber
+	}
+	if tx.RequestID != nil {
+		response.RequestID = tx.RequestID.String()
+	}
+	return response
+}
+
+func toAmbulanceTransactionListResponse(transactions []AmbulanceTransaction, pagination pkg.CursorPagination) AmbulanceTransactionListResponse {
+	responses := make([]AmbulanceTransactionResponse, 0, len(transactions))
+	for _, t := range transactions {
+		responses = append(responses, t.toAmbulanceTransactionResponse())
+	}
+	return AmbulanceTransactionListResponse{
+		Transactions: responses,
+		Pagination:   pagination,
+	}
+}
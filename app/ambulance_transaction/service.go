@@ -0,0 +1,412 @@
+package ambulance_transaction
+
+import (
+	"context"
+	"crypto/sha512"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/account"
+	"github.com/Vilamuzz/yota-backend/app/ambulance"
+	"github.com/Vilamuzz/yota-backend/app/ambulance_service_request"
+	"github.com/Vilamuzz/yota-backend/app/finance_record"
+	app_log "github.com/Vilamuzz/yota-backend/app/log"
+	"github.com/Vilamuzz/yota-backend/pkg"
+	payment_pkg "github.com/Vilamuzz/yota-backend/pkg/payment"
+	"github.com/google/uuid"
+	"github.com/midtrans/midtrans-go"
+	"github.com/midtrans/midtrans-go/snap"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	GetAmbulanceTransactionList(ctx context.Context, params AmbulanceTransactionQueryParams) pkg.Response
+	GetAmbulanceTransactionByID(ctx context.Context, id string) pkg.Response
+	CreateOfflineAmbulanceTransaction(ctx context.Context, accountID string, payload CreateOfflineAmbulanceTransactionRequest) pkg.Response
+	CreateTripDonation(ctx context.Context, accountID, requestID string, payload CreateAmbulanceTransactionRequest) pkg.Response
+	HandleNotification(ctx context.Context, payload payment_pkg.MidtransNotificationRequest) pkg.Response
+}
+
+type service struct {
+	repo           Repository
+	accountRepo    account.Repository
+	ambulanceRepo  ambulance.Repository
+	requestRepo    ambulance_service_request.Repository
+	financeRepo    finance_record.Repository
+	midtransClient payment_pkg.Client
+	logService     app_log.Service
+	timeout        time.Duration
+}
+
+func NewService(repo Repository, accountRepo account.Repository, ambulanceRepo ambulance.Repository, requestRepo ambulance_service_request.Repository, financeRepo finance_record.Repository, midtransClient payment_pkg.Client, logService app_log.Service, timeout time.Duration) Service {
+	return &service{
+		repo:           repo,
+		accountRepo:    accountRepo,
+		ambulanceRepo:  ambulanceRepo,
+		requestRepo:    requestRepo,
+		financeRepo:    financeRepo,
+		midtransClient: midtransClient,
+		logService:     logService,
+		timeout:        timeout,
+	}
+}
+
+func (s *service) GetAmbulanceTransactionList(ctx context.Context, params AmbulanceTransactionQueryParams) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	usingPrevCursor := params.PrevCursor != ""
+
+	options := map[string]interface{}{
+		"limit":        params.Limit,
+		"status":       params.Status,
+		"ambulance_id": params.AmbulanceID,
+		"next_cursor":  params.NextCursor,
+	}
+	if usingPrevCursor {
+		options["prev_cursor"] = params.PrevCursor
+	}
+
+	transactions, err := s.repo.FindAllAmbulanceTransactions(ctx, options)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "ambulance_transaction.service",
+		}).WithError(err).Error("failed to fetch transactions")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data transaksi", nil, nil)
+	}
+
+	hasMore := len(transactions) > params.Limit
+	if hasMore {
+		transactions = transactions[:params.Limit]
+	}
+
+	if usingPrevCursor {
+		for i, j := 0, len(transactions)-1; i < j; i, j = i+1, j-1 {
+			transactions[i], transactions[j] = transactions[j], transactions[i]
+		}
+	}
+
+	hasNext := (!usingPrevCursor && hasMore) || (usingPrevCursor && params.NextCursor == "")
+	hasPrev := (usingPrevCursor && hasMore) || (!usingPrevCursor && params.NextCursor != "")
+
+	var nextCursor, prevCursor string
+	if len(transactions) > 0 {
+		first := transactions[0]
+		last := transactions[len(transactions)-1]
+		if hasNext {
+			nextCursor = pkg.EncodeCursor(last.CreatedAt, last.ID.String())
+		}
+		if hasPrev {
+			prevCursor = pkg.EncodeCursor(first.CreatedAt, first.ID.String())
+		}
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Sukses", nil, toAmbulanceTransactionListResponse(transactions, pkg.CursorPagination{
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+		Limit:      params.Limit,
+	}))
+}
+
+func (s *service) GetAmbulanceTransactionByID(ctx context.Context, id string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := uuid.Validate(id); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID transaksi tidak valid"}, nil)
+	}
+
+	transaction, err := s.repo.FindOneAmbulanceTransaction(ctx, map[string]interface{}{"id": id})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return pkg.NewResponse(http.StatusNotFound, "Transaksi tidak ditemukan", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":      "ambulance_transaction.service",
+			"transaction_id": id,
+		}).WithError(err).Error("failed to fetch transaction")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data transaksi", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Sukses", nil, transaction.toAmbulanceTransactionResponse())
+}
+
+// CreateOfflineAmbulanceTransaction records a cash donation, e.g. one handed to the driver after a trip.
+func (s *service) CreateOfflineAmbulanceTransaction(ctx context.Context, accountID string, payload CreateOfflineAmbulanceTransactionRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	errValidation := make(map[string]string)
+
+	var amb *ambulance.Ambulance
+	if payload.AmbulanceID == "" {
+		errValidation["ambulanceId"] = "ID Ambulans wajib diisi"
+	} else if err := uuid.Validate(payload.AmbulanceID); err != nil {
+		errValidation["ambulanceId"] = "Format ID ambulans tidak valid"
+	} else {
+		found, err := s.ambulanceRepo.FindOneAmbulance(ctx, map[string]interface{}{"id": payload.AmbulanceID})
+		if err != nil {
+			errValidation["ambulanceId"] = "Ambulans tidak ditemukan"
+		} else {
+			amb = found
+		}
+	}
+
+	var requestID *uuid.UUID
+	if payload.RequestID != "" {
+		if err := uuid.Validate(payload.RequestID); err != nil {
+			errValidation["requestId"] = "Format ID permintaan ambulans tidak valid"
+		} else if request, err := s.requestRepo.FindByID(ctx, payload.RequestID); err != nil {
+			errValidation["requestId"] = "Permintaan ambulans tidak ditemukan"
+		} else {
+			requestID = &request.ID
+		}
+	}
+
+	if payload.GrossAmount <= 0 {
+		errValidation["grossAmount"] = "Jumlah kotor harus lebih besar dari 0"
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	donorName, donorEmail := donorDetails(payload.DonorName, payload.DonorEmail)
+	now := time.Now()
+
+	transaction := &AmbulanceTransaction{
+		ID:                uuid.New(),
+		AmbulanceID:       amb.ID,
+		RequestID:         requestID,
+		OrderID:           fmt.Sprintf("OFF-%s", uuid.New().String()),
+		DonorName:         donorName,
+		DonorEmail:        donorEmail,
+		IsOnline:          false,
+		GrossAmount:       payload.GrossAmount,
+		FraudStatus:       "accept",
+		TransactionStatus: "settlement",
+		Provider:          "offline",
+		PaidAt:            &now,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := s.repo.CreateAmbulanceTransaction(ctx, transaction); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":    "ambulance_transaction.service",
+			"ambulance_id": payload.AmbulanceID,
+		}).WithError(err).Error("failed to save offline transaction")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menyimpan transaksi offline", nil, nil)
+	}
+
+	s.recordIncome(ctx, transaction, now)
+
+	transaction.Ambulance = amb
+	s.logService.CreateLog(ctx, &accountID, "CREATE", "ambulance_transaction", transaction.ID.String(), nil, transaction.toAmbulanceTransactionResponse())
+
+	return pkg.NewResponse(http.StatusCreated, "Transaksi offline berhasil dibuat", nil, transaction.toAmbulanceTransactionResponse())
+}
+
+// CreateTripDonation starts a Midtrans payment for a donation after a finished trip. The donation
+// goes to the operational fund of the ambulance that served the trip.
+func (s *service) CreateTripDonation(ctx context.Context, accountID, requestID string, payload CreateAmbulanceTransactionRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := uuid.Validate(requestID); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID permintaan ambulans tidak valid"}, nil)
+	}
+
+	request, err := s.requestRepo.FindByID(ctx, requestID)
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
+			return pkg.NewResponse(http.StatusNotFound, "Permintaan ambulans tidak ditemukan", nil, nil)
+		}
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat permintaan ambulans", nil, nil)
+	}
+	if request.Status != ambulance_service_request.StatusDone || request.AmbulanceID == nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Donasi hanya dapat diberikan untuk layanan yang sudah selesai", nil, nil)
+	}
+
+	errValidation := make(map[string]string)
+	if accountID != "" {
+		if _, err := s.accountRepo.FindOneAccount(ctx, map[string]interface{}{"id": accountID}); err != nil {
+			errValidation["accountId"] = "Akun tidak ditemukan"
+		}
+	}
+	if payload.GrossAmount <= 0 {
+		errValidation["grossAmount"] = "Jumlah kotor harus lebih besar dari 0"
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	// Default to the requester's contact when the donor leaves it empty.
+	if strings.TrimSpace(payload.DonorName) == "" {
+		payload.DonorName = request.SubmitterName
+	}
+	donorName, donorEmail := donorDetails(payload.DonorName, payload.DonorEmail)
+
+	orderID := fmt.Sprintf("%s%s", OrderPrefix, uuid.New().String())
+	grossAmountInt := int64(payload.GrossAmount)
+
+	snapReq := &snap.Request{
+		TransactionDetails: midtrans.TransactionDetails{
+			OrderID:  orderID,
+			GrossAmt: grossAmountInt,
+		},
+		CustomerDetail: &midtrans.CustomerDetails{
+			FName: donorName,
+			Email: donorEmail,
+		},
+		Items: &[]midtrans.ItemDetails{
+			{
+				ID:    request.AmbulanceID.String(),
+				Price: grossAmountInt,
+				Qty:   1,
+				Name:  "Donasi Layanan Ambulans",
+			},
+		},
+	}
+
+	snapResp, err := s.midtransClient.CreateSnapTransaction(snapReq)
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membuat transaksi Midtrans: "+err.Error(), nil, nil)
+	}
+
+	var accountIDPtr *uuid.UUID
+	if accountID != "" {
+		id := uuid.MustParse(accountID)
+		accountIDPtr = &id
+	}
+
+	now := time.Now()
+	transaction := &AmbulanceTransaction{
+		ID:                uuid.New(),
+		AmbulanceID:       *request.AmbulanceID,
+		RequestID:         &request.ID,
+		AccountID:         accountIDPtr,
+		OrderID:           orderID,
+		DonorName:         donorName,
+		DonorEmail:        donorEmail,
+		IsOnline:          true,
+		GrossAmount:       payload.GrossAmount,
+		FraudStatus:       "accept",
+		TransactionStatus: "pending",
+		Provider:          "midtrans",
+		SnapToken:         snapResp.Token,
+		SnapRedirectURL:   snapResp.RedirectURL,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	if err := s.repo.CreateAmbulanceTransaction(ctx, transaction); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "ambulance_transaction.service",
+			"request_id": requestID,
+			"order_id":   orderID,
+		}).WithError(err).Error("failed to save online transaction")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menyimpan transaksi", nil, nil)
+	}
+
+	transaction.Ambulance = request.Ambulance
+	return pkg.NewResponse(http.StatusCreated, "Transaksi berhasil dibuat", nil, transaction.toAmbulanceTransactionResponse())
+}
+
+func (s *service) HandleNotification(ctx context.Context, payload payment_pkg.MidtransNotificationRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	// Verify signature key: SHA512(order_id + status_code + gross_amount + server_key)
+	raw := payload.OrderID + payload.StatusCode + payload.GrossAmount + s.midtransClient.GetServerKey()
+	hash := sha512.Sum512([]byte(raw))
+	expectedSig := fmt.Sprintf("%x", hash)
+	if expectedSig != payload.SignatureKey {
+		return pkg.NewResponse(http.StatusUnauthorized, "Tanda tangan tidak valid", nil, nil)
+	}
+
+	transaction, err := s.repo.FindOneAmbulanceTransaction(ctx, map[string]interface{}{"order_id": payload.OrderID})
+	if err != nil {
+		return pkg.NewResponse(http.StatusNotFound, "Transaksi tidak ditemukan", nil, nil)
+	}
+
+	if payload.TransactionStatus == transaction.TransactionStatus {
+		return pkg.NewResponse(http.StatusOK, "Tidak ada perubahan status", nil, nil)
+	}
+
+	updates := map[string]interface{}{
+		"transaction_status": payload.TransactionStatus,
+		"fraud_status":       payload.FraudStatus,
+		"updated_at":         time.Now(),
+	}
+	if payload.TransactionID != "" {
+		updates["transaction_id"] = payload.TransactionID
+	}
+	isSettled := payload.TransactionStatus == "settlement" ||
+		(payload.TransactionStatus == "capture" && payload.FraudStatus != "challenge")
+	if isSettled {
+		updates["paid_at"] = time.Now()
+	}
+
+	if err := s.repo.UpdateAmbulanceTransaction(ctx, payload.OrderID, updates); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":      "ambulance_transaction.service",
+			"transaction_id": transaction.ID,
+			"order_id":       payload.OrderID,
+		}).WithError(err).Error("failed to update transaction")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memperbarui transaksi", nil, nil)
+	}
+
+	if isSettled {
+		s.recordIncome(ctx, transaction, time.Now())
+		logrus.WithFields(logrus.Fields{
+			"component":      "ambulance_transaction.service",
+			"transaction_id": transaction.ID,
+			"order_id":       payload.OrderID,
+			"ambulance_id":   transaction.AmbulanceID,
+			"amount":         transaction.GrossAmount,
+		}).Info("transaction settled")
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Notifikasi berhasil diproses", nil, nil)
+}
+
+// recordIncome books a settled donation into the ambulance operational fund.
+func (s *service) recordIncome(ctx context.Context, transaction *AmbulanceTransaction, paidAt time.Time) {
+	if err := s.financeRepo.Create(ctx, &finance_record.FinanceRecord{
+		ID:              uuid.New().String(),
+		FundType:        finance_record.FundTypeAmbulanceOperational,
+		FundID:          transaction.AmbulanceID.String(),
+		SourceType:      finance_record.SourceTypeTransaction,
+		SourceID:        transaction.ID.String(),
+		Amount:          transaction.GrossAmount,
+		TransactionDate: paidAt,
+		CreatedAt:       paidAt,
+	}); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":      "ambulance_transaction.service",
+			"transaction_id": transaction.ID,
+			"order_id":       transaction.OrderID,
+		}).WithError(err).Warn("failed to create finance record for ambulance donation")
+	}
+}
+
+func donorDetails(name, email string) (string, string) {
+	donorName := "anonymous"
+	if name != "" {
+		donorName = name
+	}
+	donorEmail := "anonymous@example.com"
+	if email != "" {
+		donorEmail = email
+	}
+	return donorName, donorEmail
+}
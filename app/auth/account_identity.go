@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountIdentity links an external OAuth identity (provider + subject) to an account.
+// The subject is the provider's stable user ID, so a later email change on the
+// provider side still resolves to the same account.
+type AccountIdentity struct {
+	ID        uuid.UUID `json:"id" gorm:"primaryKey"`
+	AccountID uuid.UUID `json:"accountId" gorm:"not null;index"`
+	Provider  string    `json:"provider" gorm:"type:varchar(30);not null;uniqueIndex:idx_account_identity_subject"`
+	Subject   string    `json:"subject" gorm:"not null;uniqueIndex:idx_account_identity_subject"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// IdentityLinkToken carries a signed-in user's intent to link a provider through the
+// browser OAuth redirect, where the bearer token is not available.
+type IdentityLinkToken struct {
+	ID        uuid.UUID `json:"id" gorm:"primaryKey"`
+	AccountID uuid.UUID `json:"accountId" gorm:"not null;index"`
+	Provider  string    `json:"provider" gorm:"type:varchar(30);not null"`
+	Token     string    `json:"token" gorm:"unique;not null"`
+	ExpiredAt time.Time `json:"expiredAt" gorm:"not null"`
+	IsUsed    bool      `json:"isUsed" gorm:"default:false"`
+	CreatedAt time.Time `json:"createdAt" gorm:"not null"`
+}
@@ -3,7 +3,9 @@ package auth
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/Vilamuzz/yota-backend/app/account"
@@ -44,6 +46,21 @@ func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
 	api.GET("/oauth/:provider", h.middleware.CustomRateLimitHandler(10, 1*time.Minute), h.OAuthLogin)
 	api.GET("/oauth/:provider/callback", h.OAuthCallback)
 	api.POST("/switch-role", h.middleware.AuthRequired(), h.SwitchRole)
+
+	passwordless := api.Group("/passwordless")
+	{
+		passwordless.POST("/request", h.middleware.CustomRateLimitHandler(3, 1*time.Minute), h.RequestPasswordlessLogin)
+		passwordless.POST("/verify-link", authRateLimit, h.VerifyMagicLink)
+		passwordless.POST("/verify-otp", authRateLimit, h.VerifyLoginOTP)
+	}
+
+	identities := api.Group("/identities")
+	identities.Use(h.middleware.AuthRequired())
+	{
+		identities.GET("", h.GetIdentityList)
+		identities.POST("/link/:provider", authRateLimit, h.CreateIdentityLink)
+		identities.DELETE("/:identityId", h.UnlinkIdentity)
+	}
 }
 
 // Register
@@ -226,6 +243,16 @@ func (h *handler) OAuthCallback(c *gin.Context) {
 		return
 	}
 
+	if state := c.Query("state"); strings.HasPrefix(state, IdentityLinkStatePrefix) {
+		res := h.service.LinkOAuthIdentity(ctx, state, provider, gothUser)
+		redirectURL := fmt.Sprintf("%s/profile/identities?provider=%s&linked=%t", os.Getenv("FE_URL"), provider, res.Status == http.StatusOK)
+		if res.Status != http.StatusOK {
+			redirectURL = fmt.Sprintf("%s&error=%s", redirectURL, url.QueryEscape(res.Message))
+		}
+		c.Redirect(http.StatusTemporaryRedirect, redirectURL)
+		return
+	}
+
 	res := h.service.OAuthLogin(ctx, provider, gothUser)
 
 	if res.Status == http.StatusOK {
@@ -277,3 +304,125 @@ func (h *handler) SwitchRole(c *gin.Context) {
 	res := h.service.SwitchRole(ctx, claims, req)
 	c.JSON(res.Status, res)
 }
+
+// RequestPasswordlessLogin
+//
+// @Summary Request passwordless login
+// @Description Send a single-use magic link and 6-digit code to the email. Creates an account without a password if the email is not registered.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body PasswordlessLoginRequest true "Passwordless login request"
+// @Success 200 {object} pkg.Response
+// @Router /api/auth/passwordless/request [post]
+func (h *handler) RequestPasswordlessLogin(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req PasswordlessLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid request", nil, nil))
+		return
+	}
+
+	res := h.service.RequestPasswordlessLogin(ctx, req)
+	c.JSON(res.Status, res)
+}
+
+// VerifyMagicLink
+//
+// @Summary Verify magic link
+// @Description Exchange a magic link token for a JWT
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body VerifyMagicLinkRequest true "Magic link token"
+// @Success 200 {object} pkg.Response{data=AuthResponse}
+// @Router /api/auth/passwordless/verify-link [post]
+func (h *handler) VerifyMagicLink(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req VerifyMagicLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid request", nil, nil))
+		return
+	}
+
+	res := h.service.VerifyMagicLink(ctx, req)
+	c.JSON(res.Status, res)
+}
+
+// VerifyLoginOTP
+//
+// @Summary Verify login code
+// @Description Exchange the emailed 6-digit code for a JWT
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body VerifyLoginOTPRequest true "Email and code"
+// @Success 200 {object} pkg.Response{data=AuthResponse}
+// @Router /api/auth/passwordless/verify-otp [post]
+func (h *handler) VerifyLoginOTP(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req VerifyLoginOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid request", nil, nil))
+		return
+	}
+
+	res := h.service.VerifyLoginOTP(ctx, req)
+	c.JSON(res.Status, res)
+}
+
+// GetIdentityList
+//
+// @Summary Get Linked Identities
+// @Description Get OAuth identities linked to the current account
+// @Tags Auth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} pkg.Response{data=AccountIdentityListResponse}
+// @Router /api/auth/identities [get]
+func (h *handler) GetIdentityList(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.GetIdentityList(ctx, claims.AccountID)
+	c.JSON(res.Status, res)
+}
+
+// CreateIdentityLink
+//
+// @Summary Start Identity Link
+// @Description Create a short-lived OAuth URL that links the provider to the current account
+// @Tags Auth
+// @Security BearerAuth
+// @Produce json
+// @Param provider path string true "OAuth Provider"
+// @Success 200 {object} pkg.Response{data=IdentityLinkResponse}
+// @Router /api/auth/identities/link/{provider} [post]
+func (h *handler) CreateIdentityLink(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.CreateIdentityLink(ctx, claims.AccountID, c.Param("provider"))
+	c.JSON(res.Status, res)
+}
+
+// UnlinkIdentity
+//
+// @Summary Unlink Identity
+// @Description Remove a linked OAuth identity from the current account
+// @Tags Auth
+// @Security BearerAuth
+// @Produce json
+// @Param identityId path string true "Identity ID"
+// @Success 200 {object} pkg.Response
+// @Router /api/auth/identities/{identityId} [delete]
+func (h *handler) UnlinkIdentity(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.UnlinkIdentity(ctx, claims.AccountID, c.Param("identityId"))
+	c.JSON(res.Status, res)
+}
@@ -0,0 +1,20 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoginToken backs passwordless sign-in. A single request issues both a magic link
+// token and a 6-digit code; redeeming either one consumes the whole token.
+type LoginToken struct {
+	ID        uuid.UUID `json:"id" gorm:"primaryKey"`
+	AccountID uuid.UUID `json:"accountId" gorm:"not null;index"`
+	Token     string    `json:"token" gorm:"unique;not null"`
+	CodeHash  string    `json:"-" gorm:"not null"`
+	Attempts  int       `json:"attempts" gorm:"default:0"`
+	ExpiredAt time.Time `json:"expiredAt" gorm:"not null"`
+	IsUsed    bool      `json:"isUsed" gorm:"default:false"`
+	CreatedAt time.Time `json:"createdAt" gorm:"not null"`
+}
@@ -15,6 +15,21 @@ type Repository interface {
 	FetchEmailVerificationToken(ctx context.Context, token string) (*EmailVerificationToken, error)
 	UpdateEmailVerificationToken(ctx context.Context, token *EmailVerificationToken) error
 	ResetPassword(ctx context.Context, accountID, resetTokenID, newPasswordHash string) error
+	CreateLoginToken(ctx context.Context, token *LoginToken) error
+	FetchLoginToken(ctx context.Context, token string) (*LoginToken, error)
+	FetchActiveLoginTokenByAccount(ctx context.Context, accountID string) (*LoginToken, error)
+	IncrementLoginTokenAttempts(ctx context.Context, tokenID string, maxAttempts int) error
+	UseLoginToken(ctx context.Context, tokenID, accountID string) error
+	FindAllAccountIdentities(ctx context.Context, accountID string) ([]AccountIdentity, error)
+	FindOneAccountIdentity(ctx context.Context, options map[string]interface{}) (*AccountIdentity, error)
+	CountAccountIdentities(ctx context.Context, accountID string) (int64, error)
+	CreateAccountIdentity(ctx context.Context, identity *AccountIdentity) error
+	CreateAccountWithIdentity(ctx context.Context, newAccount *account.Account, identity *AccountIdentity) error
+	UpdateAccountIdentity(ctx context.Context, identityID string, updateData map[string]interface{}) error
+	DeleteAccountIdentity(ctx context.Context, identityID, accountID string) error
+	CreateIdentityLinkToken(ctx context.Context, token *IdentityLinkToken) error
+	FetchIdentityLinkToken(ctx context.Context, token string) (*IdentityLinkToken, error)
+	LinkAccountIdentity(ctx context.Context, linkTokenID string, identity *AccountIdentity) error
 }
 
 type repository struct {
@@ -69,3 +84,169 @@ func (r *repository) ResetPassword(ctx context.Context, accountID, resetTokenID,
 		return nil
 	})
 }
+
+// CreateLoginToken stores a new passwordless token and invalidates any earlier unused ones,
+// so only the most recently emailed link or code can be redeemed.
+func (r *repository) CreateLoginToken(ctx context.Context, token *LoginToken) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&LoginToken{}).
+			Where("account_id = ? AND is_used = ?", token.AccountID, false).
+			Update("is_used", true).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(token).Error
+	})
+}
+
+func (r *repository) FetchLoginToken(ctx context.Context, token string) (*LoginToken, error) {
+	var loginToken LoginToken
+	if err := r.Conn.WithContext(ctx).Where("token = ? AND is_used = ? AND expired_at > ?", token, false, time.Now()).First(&loginToken).Error; err != nil {
+		return nil, err
+	}
+	return &loginToken, nil
+}
+
+func (r *repository) FetchActiveLoginTokenByAccount(ctx context.Context, accountID string) (*LoginToken, error) {
+	var loginToken LoginToken
+	if err := r.Conn.WithContext(ctx).
+		Where("account_id = ? AND is_used = ? AND expired_at > ?", accountID, false, time.Now()).
+		Order("created_at DESC").
+		First(&loginToken).Error; err != nil {
+		return nil, err
+	}
+	return &loginToken, nil
+}
+
+// IncrementLoginTokenAttempts records a failed code attempt and burns the token once maxAttempts is reached.
+func (r *repository) IncrementLoginTokenAttempts(ctx context.Context, tokenID string, maxAttempts int) error {
+	return r.Conn.WithContext(ctx).Model(&LoginToken{}).
+		Where("id = ?", tokenID).
+		Updates(map[string]interface{}{
+			"attempts": gorm.Expr("attempts + 1"),
+			"is_used":  gorm.Expr("attempts + 1 >= ?", maxAttempts),
+		}).Error
+}
+
+// UseLoginToken consumes the token and marks the account email as verified. It returns
+// gorm.ErrRecordNotFound when the token was already consumed by a concurrent request.
+func (r *repository) UseLoginToken(ctx context.Context, tokenID, accountID string) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&LoginToken{}).
+			Where("id = ? AND is_used = ?", tokenID, false).
+			Update("is_used", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Model(&account.Account{}).Where("id = ?", accountID).Update("email_verified", true).Error
+	})
+}
+
+func (r *repository) FindAllAccountIdentities(ctx context.Context, accountID string) ([]AccountIdentity, error) {
+	var identities []AccountIdentity
+	if err := r.Conn.WithContext(ctx).
+		Where("account_id = ?", accountID).
+		Order("created_at ASC").
+		Find(&identities).Error; err != nil {
+		return nil, err
+	}
+
+	return identities, nil
+}
+
+func (r *repository) FindOneAccountIdentity(ctx context.Context, options map[string]interface{}) (*AccountIdentity, error) {
+	var identity AccountIdentity
+
+	query := r.Conn.WithContext(ctx).Model(&AccountIdentity{})
+	if id, ok := options["id"]; ok {
+		query = query.Where("id = ?", id)
+	}
+	if accountID, ok := options["account_id"]; ok {
+		query = query.Where("account_id = ?", accountID)
+	}
+	if provider, ok := options["provider"]; ok {
+		query = query.Where("provider = ?", provider)
+	}
+	if subject, ok := options["subject"]; ok {
+		query = query.Where("subject = ?", subject)
+	}
+	if err := query.First(&identity).Error; err != nil {
+		return nil, err
+	}
+
+	return &identity, nil
+}
+
+func (r *repository) CountAccountIdentities(ctx context.Context, accountID string) (int64, error) {
+	var count int64
+	if err := r.Conn.WithContext(ctx).Model(&AccountIdentity{}).Where("account_id = ?", accountID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *repository) CreateAccountIdentity(ctx context.Context, identity *AccountIdentity) error {
+	return r.Conn.WithContext(ctx).Create(identity).Error
+}
+
+func (r *repository) CreateAccountWithIdentity(ctx context.Context, newAccount *account.Account, identity *AccountIdentity) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(newAccount).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(identity).Error
+	})
+}
+
+func (r *repository) UpdateAccountIdentity(ctx context.Context, identityID string, updateData map[string]interface{}) error {
+	return r.Conn.WithContext(ctx).Model(&AccountIdentity{}).Where("id = ?", identityID).Updates(updateData).Error
+}
+
+func (r *repository) DeleteAccountIdentity(ctx context.Context, identityID, accountID string) error {
+	result := r.Conn.WithContext(ctx).Where("id = ? AND account_id = ?", identityID, accountID).Delete(&AccountIdentity{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) CreateIdentityLinkToken(ctx context.Context, token *IdentityLinkToken) error {
+	return r.Conn.WithContext(ctx).Create(token).Error
+}
+
+func (r *repository) FetchIdentityLinkToken(ctx context.Context, token string) (*IdentityLinkToken, error) {
+	var linkToken IdentityLinkToken
+	if err := r.Conn.WithContext(ctx).
+		Where("token = ? AND is_used = ? AND expired_at > ?", token, false, time.Now()).
+		First(&linkToken).Error; err != nil {
+		return nil, err
+	}
+
+	return &linkToken, nil
+}
+
+func (r *repository) LinkAccountIdentity(ctx context.Context, linkTokenID string, identity *AccountIdentity) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&IdentityLinkToken{}).
+			Where("id = ? AND is_used = ?", linkTokenID, false).
+			Update("is_used", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Create(identity).Error
+	})
+}
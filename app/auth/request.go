@@ -37,3 +37,16 @@ type ResendVerificationRequest struct {
 type SwitchRoleRequest struct {
 	Role string `json:"role"`
 }
+
+type PasswordlessLoginRequest struct {
+	Email string `json:"email"`
+}
+
+type VerifyMagicLinkRequest struct {
+	Token string `json:"token"`
+}
+
+type VerifyLoginOTPRequest struct {
+	Email string `json:"email"`
+	Code  string `json:"code"`
+}
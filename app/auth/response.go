@@ -1,6 +1,41 @@
 package auth
 
+import "time"
+
 type AuthResponse struct {
 	Token                 string `json:"token"`
 	RequiresPasswordSetup bool   `json:"requiresPasswordSetup"`
 }
+
+type AccountIdentityResponse struct {
+	ID        string    `json:"id"`
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type AccountIdentityListResponse struct {
+	Identities  []AccountIdentityResponse `json:"identities"`
+	HasPassword bool                      `json:"hasPassword"`
+}
+
+type IdentityLinkResponse struct {
+	AuthURL   string    `json:"authUrl"`
+	ExpiredAt time.Time `json:"expiredAt"`
+}
+
+func toAccountIdentityListResponse(identities []AccountIdentity, hasPassword bool) AccountIdentityListResponse {
+	res := AccountIdentityListResponse{
+		Identities:  make([]AccountIdentityResponse, 0, len(identities)),
+		HasPassword: hasPassword,
+	}
+	for _, identity := range identities {
+		res.Identities = append(res.Identities, AccountIdentityResponse{
+			ID:        identity.ID.String(),
+			Provider:  identity.Provider,
+			Email:     identity.Email,
+			CreatedAt: identity.CreatedAt,
+		})
+	}
+	return res
+}
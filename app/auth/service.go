@@ -3,9 +3,12 @@ package auth
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 	"strings"
 	"time"
@@ -32,8 +35,25 @@ type Service interface {
 	VerifyEmail(ctx context.Context, token string) pkg.Response
 	ResendVerificationEmail(ctx context.Context, email string) pkg.Response
 	SwitchRole(ctx context.Context, claims jwt_pkg.UserJWTClaims, payload SwitchRoleRequest) pkg.Response
+	RequestPasswordlessLogin(ctx context.Context, payload PasswordlessLoginRequest) pkg.Response
+	VerifyMagicLink(ctx context.Context, payload VerifyMagicLinkRequest) pkg.Response
+	VerifyLoginOTP(ctx context.Context, payload VerifyLoginOTPRequest) pkg.Response
+	GetIdentityList(ctx context.Context, accountID string) pkg.Response
+	CreateIdentityLink(ctx context.Context, accountID, provider string) pkg.Response
+	LinkOAuthIdentity(ctx context.Context, state, provider string, gothUser goth.User) pkg.Response
+	UnlinkIdentity(ctx context.Context, accountID, identityID string) pkg.Response
 }
 
+const (
+	passwordlessTokenTTL      = 10 * time.Minute
+	passwordlessResendWait    = 1 * time.Minute
+	passwordlessMaxOTPAttempt = 5
+	identityLinkTokenTTL      = 10 * time.Minute
+)
+
+// IdentityLinkStatePrefix marks an OAuth state value as a link request from a signed-in user.
+const IdentityLinkStatePrefix = "link_"
+
 type service struct {
 	accountRepo    account.Repository
 	authRepo       Repository
@@ -478,119 +498,144 @@ func (s *service) ResetPassword(ctx context.Context, payload ResetPasswordReques
 	return pkg.NewResponse(http.StatusOK, "Password reset successfully", nil, nil)
 }
 
+// OAuthLogin resolves the provider identity first and only falls back to the email
+// address when the identity is unknown. An existing account is linked automatically
+// only if its email has been verified; otherwise the owner must sign in and link the
+// provider from their profile, so a pre-registered unverified account cannot capture it.
 func (s *service) OAuthLogin(ctx context.Context, provider string, gothUser goth.User) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
 	defer cancel()
 
-	existingUser, err := s.accountRepo.FindOneAccount(ctx, map[string]interface{}{"email": gothUser.Email})
-	var currentAccount *account.Account
-
-	if err != nil {
-		if !errors.Is(err, gorm.ErrRecordNotFound) {
+	identity, err := s.authRepo.FindOneAccountIdentity(ctx, map[string]interface{}{"provider": provider, "subject": gothUser.UserID})
+	if err == nil {
+		currentAccount, err := s.accountRepo.FindOneAccount(ctx, map[string]interface{}{"id": identity.AccountID.String()})
+		if err != nil {
 			logrus.WithFields(logrus.Fields{
-				"component": "auth.service",
-				"email":     gothUser.Email,
-				"provider":  provider,
-			}).WithError(err).Error("failed to find account during oauth login")
+				"component":   "auth.service",
+				"identity_id": identity.ID.String(),
+				"provider":    provider,
+			}).WithError(err).Error("failed to find account for oauth identity")
 			return pkg.NewResponse(http.StatusInternalServerError, "Internal server error", nil, nil)
 		}
 
-		username := gothUser.NickName
-		if username == "" {
-			parts := strings.Split(gothUser.Email, "@")
-			username = parts[0]
-		}
-		existingAccount, err := s.accountRepo.FindOneAccount(ctx, map[string]interface{}{"user_profile.username": username})
-		if err == nil && existingAccount != nil {
-			username = fmt.Sprintf("%s_%s", username, strings.Replace(uuid.New().String(), "-", "", -1)[:6])
-		}
-
-		accountID := uuid.New()
-		now := time.Now()
-		newAccount := &account.Account{
-			ID:            accountID,
-			Email:         gothUser.Email,
-			Password:      "",
-			IsBanned:      false,
-			EmailVerified: true,
-			CreatedAt:     now,
-			UpdatedAt:     now,
-			UserProfile: account.UserProfile{
-				ID:        uuid.New(),
-				Username:  username,
-				CreatedAt: now,
-				UpdatedAt: now,
-			},
-			AccountRoles: []account.AccountRole{
-				{
-					RoleID:    account.OrangTuaAsuhRoleID,
-					IsDefault: true,
-					IsActive:  true,
-				},
-			},
+		if gothUser.Email != "" && gothUser.Email != identity.Email {
+			if err := s.authRepo.UpdateAccountIdentity(ctx, identity.ID.String(), map[string]interface{}{"email": gothUser.Email}); err != nil {
+				logrus.WithFields(logrus.Fields{
+					"component":   "auth.service",
+					"identity_id": identity.ID.String(),
+				}).WithError(err).Error("failed to update oauth identity email")
+			}
 		}
 
-		if err := s.accountRepo.CreateAccount(ctx, newAccount); err != nil {
-			logrus.WithFields(logrus.Fields{
-				"component": "auth.service",
-				"email":     gothUser.Email,
-				"provider":  provider,
-			}).WithError(err).Error("failed to create oauth user")
-			return pkg.NewResponse(http.StatusInternalServerError, "Failed to create user", nil, nil)
-		}
+		return s.issueAuthResponse(currentAccount, "OAuth login successful")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"component": "auth.service",
+			"provider":  provider,
+		}).WithError(err).Error("failed to find oauth identity")
+		return pkg.NewResponse(http.StatusInternalServerError, "Internal server error", nil, nil)
+	}
 
-		currentAccount = newAccount
-	} else {
+	if gothUser.Email == "" {
+		return pkg.NewResponse(http.StatusBadRequest, "The provider did not share an email address", nil, nil)
+	}
+
+	now := time.Now()
+	newIdentity := &AccountIdentity{
+		ID:        uuid.New(),
+		Provider:  provider,
+		Subject:   gothUser.UserID,
+		Email:     gothUser.Email,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	existingUser, err := s.accountRepo.FindOneAccount(ctx, map[string]interface{}{"email": gothUser.Email})
+	if err == nil {
 		if existingUser.IsBanned {
 			return pkg.NewResponse(http.StatusForbidden, "Your account has been banned", nil, nil)
 		}
-		currentAccount = existingUser
-	}
-
-	ttl := config.GetJWTTTL()
-	var userRoles []enum.RoleName
-	var activeRole enum.RoleName
+		if !existingUser.EmailVerified {
+			return pkg.NewResponse(http.StatusConflict, "An account with this email already exists. Sign in and link this provider from your profile", nil, nil)
+		}
 
-	if len(currentAccount.AccountRoles) > 0 {
-		for _, role := range currentAccount.AccountRoles {
-			if role.IsActive {
-				userRoles = append(userRoles, role.Role.Name)
-				if role.IsDefault {
-					activeRole = role.Role.Name
-				}
-			}
+		newIdentity.AccountID = existingUser.ID
+		if err := s.authRepo.CreateAccountIdentity(ctx, newIdentity); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":  "auth.service",
+				"account_id": existingUser.ID.String(),
+				"provider":   provider,
+			}).WithError(err).Error("failed to link oauth identity")
+			return pkg.NewResponse(http.StatusInternalServerError, "Internal server error", nil, nil)
 		}
+
+		return s.issueAuthResponse(existingUser, "OAuth login successful")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"component": "auth.service",
+			"email":     gothUser.Email,
+			"provider":  provider,
+		}).WithError(err).Error("failed to find account during oauth login")
+		return pkg.NewResponse(http.StatusInternalServerError, "Internal server error", nil, nil)
 	}
 
-	if len(userRoles) == 0 {
-		return pkg.NewResponse(http.StatusForbidden, "Your account has no active roles", nil, nil)
+	username := gothUser.NickName
+	if username == "" {
+		parts := strings.Split(gothUser.Email, "@")
+		username = parts[0]
+	}
+	existingAccount, err := s.accountRepo.FindOneAccount(ctx, map[string]interface{}{"username": username})
+	if err == nil && existingAccount != nil {
+		username = fmt.Sprintf("%s_%s", username, strings.Replace(uuid.New().String(), "-", "", -1)[:6])
 	}
 
-	claims := &jwt_pkg.UserJWTClaims{
-		AccountID:  currentAccount.ID.String(),
-		Roles:      userRoles,
-		ActiveRole: activeRole,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(ttl) * time.Minute)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+	accountID := uuid.New()
+	newAccount := &account.Account{
+		ID:            accountID,
+		Email:         gothUser.Email,
+		Password:      "",
+		IsBanned:      false,
+		EmailVerified: true,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		UserProfile: account.UserProfile{
+			ID:        uuid.New(),
+			Username:  username,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		AccountRoles: []account.AccountRole{
+			{
+				RoleID:    account.OrangTuaAsuhRoleID,
+				IsDefault: true,
+				IsActive:  true,
+			},
 		},
 	}
+	newIdentity.AccountID = accountID
 
-	token, err := jwt_pkg.GenerateJWTToken(claims)
+	if err := s.authRepo.CreateAccountWithIdentity(ctx, newAccount, newIdentity); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "auth.service",
+			"email":     gothUser.Email,
+			"provider":  provider,
+		}).WithError(err).Error("failed to create oauth user")
+		return pkg.NewResponse(http.StatusInternalServerError, "Failed to create user", nil, nil)
+	}
+
+	// Reload so AccountRoles carries the Role names needed for the JWT claims.
+	currentAccount, err := s.accountRepo.FindOneAccount(ctx, map[string]interface{}{"id": accountID.String()})
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"component":  "auth.service",
-			"account_id": currentAccount.ID.String(),
-		}).WithError(err).Error("failed to generate oauth jwt token")
-		return pkg.NewResponse(http.StatusInternalServerError, "Failed to generate token", nil, nil)
+			"account_id": accountID.String(),
+		}).WithError(err).Error("failed to reload oauth user")
+		return pkg.NewResponse(http.StatusInternalServerError, "Internal server error", nil, nil)
 	}
 
-	requiresPasswordSetup := currentAccount.Password == ""
-
-	return pkg.NewResponse(http.StatusOK, "OAuth login successful", nil, AuthResponse{
-		Token:                 token,
-		RequiresPasswordSetup: requiresPasswordSetup,
-	})
+	return s.issueAuthResponse(currentAccount, "OAuth login successful")
 }
 
 func (s *service) SwitchRole(ctx context.Context, claims jwt_pkg.UserJWTClaims, payload SwitchRoleRequest) pkg.Response {
@@ -647,3 +692,502 @@ func (s *service) SwitchRole(ctx context.Context, claims jwt_pkg.UserJWTClaims,
 		Token: newToken,
 	})
 }
+
+// RequestPasswordlessLogin emails a magic link and a 6-digit code. Unknown emails get a new
+// password-less Orang Tua Asuh account so first-time donors can sign in the same way.
+func (s *service) RequestPasswordlessLogin(ctx context.Context, payload PasswordlessLoginRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	payload.Email = strings.TrimSpace(payload.Email)
+	if payload.Email == "" || !pkg.IsValidEmail(payload.Email) {
+		return pkg.NewResponse(http.StatusBadRequest, "Validation error", map[string]string{"email": "Invalid email format"}, nil)
+	}
+	const successMsg = "A sign-in link and code have been sent to your email."
+
+	existingUser, err := s.accountRepo.FindOneAccount(ctx, map[string]interface{}{"email": payload.Email})
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logrus.WithFields(logrus.Fields{
+				"component": "auth.service",
+			}).WithError(err).Error("failed to find account by email during passwordless login")
+			return pkg.NewResponse(http.StatusInternalServerError, "Internal server error", nil, nil)
+		}
+
+		existingUser, err = s.createPasswordlessAccount(ctx, payload.Email)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component": "auth.service",
+				"email":     payload.Email,
+			}).WithError(err).Error("failed to create passwordless account")
+			return pkg.NewResponse(http.StatusInternalServerError, "Failed to create user", nil, nil)
+		}
+	}
+
+	if existingUser.IsBanned {
+		return pkg.NewResponse(http.StatusForbidden, "Your account has been banned", nil, nil)
+	}
+
+	// Silently skip re-sending while the previous email is still fresh to avoid inbox flooding.
+	activeToken, err := s.authRepo.FetchActiveLoginTokenByAccount(ctx, existingUser.ID.String())
+	if err == nil && time.Since(activeToken.CreatedAt) < passwordlessResendWait {
+		return pkg.NewResponse(http.StatusOK, successMsg, nil, nil)
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		logrus.WithError(err).Error("failed to generate login token")
+		return pkg.NewResponse(http.StatusInternalServerError, "Failed to generate login token", nil, nil)
+	}
+	loginToken := hex.EncodeToString(tokenBytes)
+
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		logrus.WithError(err).Error("failed to generate login code")
+		return pkg.NewResponse(http.StatusInternalServerError, "Failed to generate login token", nil, nil)
+	}
+	code := fmt.Sprintf("%06d", n.Int64())
+
+	token := &LoginToken{
+		ID:        uuid.New(),
+		AccountID: existingUser.ID,
+		Token:     loginToken,
+		CodeHash:  hashLoginCode(code),
+		ExpiredAt: time.Now().Add(passwordlessTokenTTL),
+		IsUsed:    false,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.authRepo.CreateLoginToken(ctx, token); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "auth.service",
+			"account_id": existingUser.ID.String(),
+		}).WithError(err).Error("failed to create login token")
+		return pkg.NewResponse(http.StatusInternalServerError, "Failed to create login token", nil, nil)
+	}
+
+	go func(email, username, token, code string) {
+		if err := s.emailService.SendPasswordlessLoginEmail(email, username, token, code, int(passwordlessTokenTTL.Minutes())); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component": "auth.service",
+				"email":     email,
+			}).WithError(err).Error("failed to send passwordless login email")
+		}
+	}(existingUser.Email, existingUser.UserProfile.Username, loginToken, code)
+
+	return pkg.NewResponse(http.StatusOK, successMsg, nil, nil)
+}
+
+func (s *service) VerifyMagicLink(ctx context.Context, payload VerifyMagicLinkRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if payload.Token == "" {
+		return pkg.NewResponse(http.StatusBadRequest, "Validation error", map[string]string{"token": "Token is required"}, nil)
+	}
+
+	loginToken, err := s.authRepo.FetchLoginToken(ctx, payload.Token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusBadRequest, "Validation error", map[string]string{"token": "Invalid or expired login link"}, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component": "auth.service",
+		}).WithError(err).Error("failed to fetch login token")
+		return pkg.NewResponse(http.StatusInternalServerError, "Internal server error", nil, nil)
+	}
+
+	return s.completePasswordlessLogin(ctx, loginToken)
+}
+
+func (s *service) VerifyLoginOTP(ctx context.Context, payload VerifyLoginOTPRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	errValidation := make(map[string]string)
+	payload.Email = strings.TrimSpace(payload.Email)
+	payload.Code = strings.TrimSpace(payload.Code)
+	if payload.Email == "" || !pkg.IsValidEmail(payload.Email) {
+		errValidation["email"] = "Invalid email format"
+	}
+	if len(payload.Code) != 6 {
+		errValidation["code"] = "Code must be 6 digits"
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Validation error", errValidation, nil)
+	}
+
+	const invalidMsg = "Invalid or expired code"
+
+	existingUser, err := s.accountRepo.FindOneAccount(ctx, map[string]interface{}{"email": payload.Email})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusBadRequest, "Validation error", map[string]string{"code": invalidMsg}, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component": "auth.service",
+		}).WithError(err).Error("failed to find account by email during otp login")
+		return pkg.NewResponse(http.StatusInternalServerError, "Internal server error", nil, nil)
+	}
+
+	loginToken, err := s.authRepo.FetchActiveLoginTokenByAccount(ctx, existingUser.ID.String())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusBadRequest, "Validation error", map[string]string{"code": invalidMsg}, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "auth.service",
+			"account_id": existingUser.ID.String(),
+		}).WithError(err).Error("failed to fetch login token")
+		return pkg.NewResponse(http.StatusInternalServerError, "Internal server error", nil, nil)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(loginToken.CodeHash), []byte(hashLoginCode(payload.Code))) != 1 {
+		if err := s.authRepo.IncrementLoginTokenAttempts(ctx, loginToken.ID.String(), passwordlessMaxOTPAttempt); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":  "auth.service",
+				"account_id": existingUser.ID.String(),
+			}).WithError(err).Error("failed to record login code attempt")
+		}
+		return pkg.NewResponse(http.StatusBadRequest, "Validation error", map[string]string{"code": invalidMsg}, nil)
+	}
+
+	return s.completePasswordlessLogin(ctx, loginToken)
+}
+
+// completePasswordlessLogin consumes the token and issues a JWT for its account.
+func (s *service) completePasswordlessLogin(ctx context.Context, loginToken *LoginToken) pkg.Response {
+	existingUser, err := s.accountRepo.FindOneAccount(ctx, map[string]interface{}{"id": loginToken.AccountID.String()})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusUnauthorized, "Account no longer exists", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "auth.service",
+			"account_id": loginToken.AccountID.String(),
+		}).WithError(err).Error("failed to retrieve account during passwordless login")
+		return pkg.NewResponse(http.StatusInternalServerError, "Internal server error", nil, nil)
+	}
+
+	if existingUser.IsBanned {
+		return pkg.NewResponse(http.StatusForbidden, "Your account has been banned", nil, nil)
+	}
+
+	if err := s.authRepo.UseLoginToken(ctx, loginToken.ID.String(), existingUser.ID.String()); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusBadRequest, "Validation error", map[string]string{"token": "Login token has already been used"}, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "auth.service",
+			"account_id": existingUser.ID.String(),
+		}).WithError(err).Error("failed to consume login token")
+		return pkg.NewResponse(http.StatusInternalServerError, "Internal server error", nil, nil)
+	}
+
+	return s.issueAuthResponse(existingUser, "Login successful")
+}
+
+// issueAuthResponse signs a JWT for the account's active roles.
+func (s *service) issueAuthResponse(existingUser *account.Account, message string) pkg.Response {
+	if existingUser.IsBanned {
+		return pkg.NewResponse(http.StatusForbidden, "Your account has been banned", nil, nil)
+	}
+
+	ttl := config.GetJWTTTL()
+	var userRoles []enum.RoleName
+	var activeRole enum.RoleName
+
+	for _, role := range existingUser.AccountRoles {
+		if role.IsActive {
+			userRoles = append(userRoles, role.Role.Name)
+			if role.IsDefault {
+				activeRole = role.Role.Name
+			}
+		}
+	}
+	if activeRole == "" && len(userRoles) > 0 {
+		activeRole = userRoles[0]
+	}
+
+	if len(userRoles) == 0 {
+		return pkg.NewResponse(http.StatusForbidden, "Your account has no active roles", nil, nil)
+	}
+
+	claims := &jwt_pkg.UserJWTClaims{
+		AccountID:  existingUser.ID.String(),
+		Roles:      userRoles,
+		ActiveRole: activeRole,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(ttl) * time.Minute)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token, err := jwt_pkg.GenerateJWTToken(claims)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "auth.service",
+			"account_id": existingUser.ID.String(),
+		}).WithError(err).Error("failed to generate jwt token")
+		return pkg.NewResponse(http.StatusInternalServerError, "Failed to generate token", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusOK, message, nil, AuthResponse{
+		Token:                 token,
+		RequiresPasswordSetup: existingUser.Password == "",
+	})
+}
+
+func (s *service) createPasswordlessAccount(ctx context.Context, email string) (*account.Account, error) {
+	username := strings.Split(email, "@")[0]
+	if len(username) > 13 {
+		username = username[:13]
+	}
+	if _, err := s.accountRepo.FindOneAccount(ctx, map[string]interface{}{"username": username}); err == nil || len(username) < 3 {
+		username = fmt.Sprintf("%s_%s", username, strings.Replace(uuid.New().String(), "-", "", -1)[:6])
+	}
+
+	now := time.Now()
+	newAccount := &account.Account{
+		ID:            uuid.New(),
+		Email:         email,
+		Password:      "",
+		IsBanned:      false,
+		EmailVerified: false,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		UserProfile: account.UserProfile{
+			ID:        uuid.New(),
+			Username:  username,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		AccountRoles: []account.AccountRole{
+			{
+				RoleID:    account.OrangTuaAsuhRoleID,
+				IsDefault: true,
+				IsActive:  true,
+			},
+		},
+	}
+
+	if err := s.accountRepo.CreateAccount(ctx, newAccount); err != nil {
+		return nil, err
+	}
+
+	// Reload so AccountRoles carries the Role names needed for the JWT claims.
+	return s.accountRepo.FindOneAccount(ctx, map[string]interface{}{"id": newAccount.ID.String()})
+}
+
+func hashLoginCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *service) GetIdentityList(ctx context.Context, accountID string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	currentAccount, err := s.accountRepo.FindOneAccount(ctx, map[string]interface{}{"id": accountID})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Account not found", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "auth.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to find account")
+		return pkg.NewResponse(http.StatusInternalServerError, "Internal server error", nil, nil)
+	}
+
+	identities, err := s.authRepo.FindAllAccountIdentities(ctx, accountID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "auth.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to fetch account identities")
+		return pkg.NewResponse(http.StatusInternalServerError, "Internal server error", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Identities retrieved successfully", nil, toAccountIdentityListResponse(identities, currentAccount.Password != ""))
+}
+
+// CreateIdentityLink issues a short-lived state token; the browser then starts the
+// regular OAuth flow with it so the callback knows which account to link to.
+func (s *service) CreateIdentityLink(ctx context.Context, accountID, provider string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if _, err := goth.GetProvider(provider); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Validation error", map[string]string{"provider": "Unsupported provider"}, nil)
+	}
+
+	if _, err := s.authRepo.FindOneAccountIdentity(ctx, map[string]interface{}{"account_id": accountID, "provider": provider}); err == nil {
+		return pkg.NewResponse(http.StatusConflict, "This provider is already linked to your account", nil, nil)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"component":  "auth.service",
+			"account_id": accountID,
+			"provider":   provider,
+		}).WithError(err).Error("failed to check existing identity")
+		return pkg.NewResponse(http.StatusInternalServerError, "Internal server error", nil, nil)
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		logrus.WithError(err).Error("failed to generate identity link token")
+		return pkg.NewResponse(http.StatusInternalServerError, "Failed to generate link token", nil, nil)
+	}
+
+	linkToken := &IdentityLinkToken{
+		ID:        uuid.New(),
+		AccountID: uuid.MustParse(accountID),
+		Provider:  provider,
+		Token:     hex.EncodeToString(tokenBytes),
+		ExpiredAt: time.Now().Add(identityLinkTokenTTL),
+		IsUsed:    false,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.authRepo.CreateIdentityLinkToken(ctx, linkToken); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "auth.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to create identity link token")
+		return pkg.NewResponse(http.StatusInternalServerError, "Failed to create link token", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Identity link created successfully", nil, IdentityLinkResponse{
+		AuthURL:   fmt.Sprintf("/api/auth/oauth/%s?state=%s%s", provider, IdentityLinkStatePrefix, linkToken.Token),
+		ExpiredAt: linkToken.ExpiredAt,
+	})
+}
+
+func (s *service) LinkOAuthIdentity(ctx context.Context, state, provider string, gothUser goth.User) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	linkToken, err := s.authRepo.FetchIdentityLinkToken(ctx, strings.TrimPrefix(state, IdentityLinkStatePrefix))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusBadRequest, "Invalid or expired link request", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component": "auth.service",
+		}).WithError(err).Error("failed to fetch identity link token")
+		return pkg.NewResponse(http.StatusInternalServerError, "Internal server error", nil, nil)
+	}
+	if linkToken.Provider != provider {
+		return pkg.NewResponse(http.StatusBadRequest, "Invalid or expired link request", nil, nil)
+	}
+
+	existing, err := s.authRepo.FindOneAccountIdentity(ctx, map[string]interface{}{"provider": provider, "subject": gothUser.UserID})
+	if err == nil {
+		if existing.AccountID != linkToken.AccountID {
+			return pkg.NewResponse(http.StatusConflict, "This provider account is already linked to another account", nil, nil)
+		}
+		return pkg.NewResponse(http.StatusOK, "Identity linked successfully", nil, nil)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"component":  "auth.service",
+			"account_id": linkToken.AccountID.String(),
+			"provider":   provider,
+		}).WithError(err).Error("failed to find oauth identity")
+		return pkg.NewResponse(http.StatusInternalServerError, "Internal server error", nil, nil)
+	}
+
+	// Linking never merges two existing accounts: a provider email that already belongs
+	// to a different account is rejected instead of silently moving data between them.
+	if gothUser.Email != "" {
+		owner, err := s.accountRepo.FindOneAccount(ctx, map[string]interface{}{"email": gothUser.Email})
+		if err == nil && owner.ID != linkToken.AccountID {
+			return pkg.NewResponse(http.StatusConflict, "The provider email belongs to another account", nil, nil)
+		}
+	}
+
+	now := time.Now()
+	identity := &AccountIdentity{
+		ID:        uuid.New(),
+		AccountID: linkToken.AccountID,
+		Provider:  provider,
+		Subject:   gothUser.UserID,
+		Email:     gothUser.Email,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.authRepo.LinkAccountIdentity(ctx, linkToken.ID.String(), identity); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusBadRequest, "Invalid or expired link request", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "auth.service",
+			"account_id": linkToken.AccountID.String(),
+			"provider":   provider,
+		}).WithError(err).Error("failed to link oauth identity")
+		return pkg.NewResponse(http.StatusInternalServerError, "Failed to link identity", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Identity linked successfully", nil, nil)
+}
+
+func (s *service) UnlinkIdentity(ctx context.Context, accountID, identityID string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if err := uuid.Validate(identityID); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Invalid identity ID", nil, nil)
+	}
+
+	currentAccount, err := s.accountRepo.FindOneAccount(ctx, map[string]interface{}{"id": accountID})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Account not found", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "auth.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to find account")
+		return pkg.NewResponse(http.StatusInternalServerError, "Internal server error", nil, nil)
+	}
+
+	if _, err := s.authRepo.FindOneAccountIdentity(ctx, map[string]interface{}{"id": identityID, "account_id": accountID}); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Identity not found", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":   "auth.service",
+			"identity_id": identityID,
+		}).WithError(err).Error("failed to find identity")
+		return pkg.NewResponse(http.StatusInternalServerError, "Internal server error", nil, nil)
+	}
+
+	// Keep at least one sign-in method that does not depend on the inbox alone.
+	if currentAccount.Password == "" {
+		count, err := s.authRepo.CountAccountIdentities(ctx, accountID)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":  "auth.service",
+				"account_id": accountID,
+			}).WithError(err).Error("failed to count identities")
+			return pkg.NewResponse(http.StatusInternalServerError, "Internal server error", nil, nil)
+		}
+		if count <= 1 {
+			return pkg.NewResponse(http.StatusBadRequest, "Set a password before unlinking your last sign-in provider", nil, nil)
+		}
+	}
+
+	if err := s.authRepo.DeleteAccountIdentity(ctx, identityID, accountID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Identity not found", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":   "auth.service",
+			"identity_id": identityID,
+		}).WithError(err).Error("failed to unlink identity")
+		return pkg.NewResponse(http.StatusInternalServerError, "Failed to unlink identity", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Identity unlinked successfully", nil, nil)
+}
@@ -3,6 +3,7 @@ package donation_program
 import (
 	"time"
 
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
 	"github.com/google/uuid"
 )
 
@@ -21,6 +22,8 @@ type DonationProgram struct {
 	UpdatedAt   time.Time  `json:"updatedAt"`
 	DeletedAt   *time.Time `json:"deletedAt" gorm:"index"`
 
+	CoverImageVariants s3_pkg.ImageVariants `json:"coverImageVariants" gorm:"type:jsonb"`
+
 	CollectedFund float64 `json:"collectedFund" gorm:"->"`
 	TotalExpense  float64 `json:"totalExpense" gorm:"->"`
 }
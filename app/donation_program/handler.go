@@ -6,6 +6,7 @@ import (
 	"github.com/Vilamuzz/yota-backend/app/middleware"
 	"github.com/Vilamuzz/yota-backend/pkg"
 	"github.com/Vilamuzz/yota-backend/pkg/enum"
+	jwt_pkg "github.com/Vilamuzz/yota-backend/pkg/jwt"
 	"github.com/gin-gonic/gin"
 )
 
@@ -66,7 +67,7 @@ func (h *handler) GetDonationProgramList(c *gin.Context) {
 		return
 	}
 
-	res := h.service.GetDonationProgramList(ctx, queryParams, false)
+	res := h.service.GetDonationProgramList(ctx, queryParams, false, "")
 	c.JSON(res.Status, res)
 }
 
@@ -113,7 +114,8 @@ func (h *handler) GetAdminDonationProgramList(c *gin.Context) {
 		return
 	}
 
-	res := h.service.GetDonationProgramList(ctx, queryParams, true)
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.GetDonationProgramList(ctx, queryParams, true, claims.AccountID)
 	c.JSON(res.Status, res)
 }
 
@@ -132,7 +134,8 @@ func (h *handler) GetDonationProgramByID(c *gin.Context) {
 	ctx := c.Request.Context()
 	donationID := c.Param("id")
 
-	res := h.service.GetDonationProgramByID(ctx, donationID)
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.GetDonationProgramByID(ctx, donationID, claims.AccountID)
 	c.JSON(res.Status, res)
 }
 
@@ -196,7 +199,8 @@ func (h *handler) UpdateDonationProgram(c *gin.Context) {
 		return
 	}
 
-	res := h.service.UpdateDonationProgram(ctx, donationID, req)
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.UpdateDonationProgram(ctx, donationID, claims.AccountID, req)
 	c.JSON(res.Status, res)
 }
 
@@ -214,7 +218,8 @@ func (h *handler) DeleteDonationProgram(c *gin.Context) {
 	ctx := c.Request.Context()
 	donationID := c.Param("id")
 
-	res := h.service.DeleteDonationProgram(ctx, donationID)
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.DeleteDonationProgram(ctx, donationID, claims.AccountID)
 	c.JSON(res.Status, res)
 }
 
@@ -233,7 +238,8 @@ func (h *handler) UpdateActiveDonationProgram(c *gin.Context) {
 	ctx := c.Request.Context()
 	donationID := c.Param("id")
 
-	res := h.service.UpdateActiveDonationProgram(ctx, donationID)
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.UpdateActiveDonationProgram(ctx, donationID, claims.AccountID)
 	c.JSON(res.Status, res)
 }
 
@@ -252,6 +258,7 @@ func (h *handler) UpdateArchiveDonationProgram(c *gin.Context) {
 	ctx := c.Request.Context()
 	donationID := c.Param("id")
 
-	res := h.service.UpdateArchivedDonationProgram(ctx, donationID)
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.UpdateArchivedDonationProgram(ctx, donationID, claims.AccountID)
 	c.JSON(res.Status, res)
 }
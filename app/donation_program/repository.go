@@ -65,6 +65,10 @@ func buildDonationProgramBaseQuery(conn *gorm.DB, ctx context.Context, options m
 	if category, ok := options["category"]; ok && category != "" {
 		query = query.Where("dp.category = ?", category)
 	}
+	// An empty ids list comes from a scoped account without grants and matches nothing.
+	if ids, ok := options["ids"].([]string); ok {
+		query = query.Where("dp.id IN ?", ids)
+	}
 	if status, ok := options["status"]; ok {
 		switch v := status.(type) {
 		case Status:
@@ -124,6 +128,9 @@ func (r *repository) CountDonationPrograms(ctx context.Context, options map[stri
 	if category, ok := options["category"]; ok && category != "" {
 		query = query.Where("category = ?", category)
 	}
+	if ids, ok := options["ids"].([]string); ok {
+		query = query.Where("id IN ?", ids)
+	}
 	if status, ok := options["status"]; ok {
 		switch v := status.(type) {
 		case Status:
@@ -171,6 +178,10 @@ func (r *repository) FindOneDonationProgram(ctx context.Context, options map[str
 		query = query.Where("dp.slug = ?", slug)
 	}
 
+	if ids, ok := options["ids"].([]string); ok {
+		query = query.Where("dp.id IN ?", ids)
+	}
+
 	if err := query.First(&donationProgram).Error; err != nil {
 		return nil, err
 	}
@@ -20,6 +20,8 @@ type DonationProgramResponse struct {
 	Status        Status    `json:"status"`
 	StartDate     time.Time `json:"startDate"`
 	EndDate       time.Time `json:"endDate"`
+
+	CoverImageSet *s3_pkg.ImageSet `json:"coverImageSet,omitempty"`
 }
 
 type AdminDonationProgramResponse struct {
@@ -35,6 +37,8 @@ type AdminDonationProgramResponse struct {
 	StartDate     time.Time `json:"startDate"`
 	EndDate       time.Time `json:"endDate"`
 	CreatedAt     time.Time `json:"createdAt"`
+
+	CoverImageSet *s3_pkg.ImageSet `json:"coverImageSet,omitempty"`
 }
 
 type AdminDonationProgramListResponse struct {
@@ -61,6 +65,8 @@ func (d *DonationProgram) toAdminDonationProgramResponse() AdminDonationProgramR
 		StartDate:     d.StartDate,
 		EndDate:       d.EndDate,
 		CreatedAt:     d.CreatedAt,
+
+		CoverImageSet: s3_pkg.NewImageSet(d.CoverImageVariants),
 	}
 }
 
@@ -78,6 +84,8 @@ func (d *DonationProgram) toDonationProgramResponse() DonationProgramResponse {
 		Status:        d.Status,
 		StartDate:     d.StartDate,
 		EndDate:       d.EndDate,
+
+		CoverImageSet: s3_pkg.NewImageSet(d.CoverImageVariants),
 	}
 }
 
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/Vilamuzz/yota-backend/app/access_grant"
 	app_log "github.com/Vilamuzz/yota-backend/app/log"
 	"github.com/Vilamuzz/yota-backend/pkg"
 	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
@@ -14,34 +15,49 @@ import (
 )
 
 type Service interface {
-	GetDonationProgramList(ctx context.Context, params DonationProgramQueryParams, isAdmin bool) pkg.Response
+	GetDonationProgramList(ctx context.Context, params DonationProgramQueryParams, isAdmin bool, accountID string) pkg.Response
 	GetDonationProgramBySlug(ctx context.Context, slug string) pkg.Response
-	GetDonationProgramByID(ctx context.Context, donationProgramID string) pkg.Response
+	GetDonationProgramByID(ctx context.Context, donationProgramID string, accountID string) pkg.Response
 	CreateDonationProgram(ctx context.Context, donation DonationProgramRequest) pkg.Response
-	UpdateDonationProgram(ctx context.Context, donationProgramID string, payload DonationProgramRequest) pkg.Response
-	DeleteDonationProgram(ctx context.Context, donationProgramID string) pkg.Response
-	UpdateActiveDonationProgram(ctx context.Context, donationProgramID string) pkg.Response
-	UpdateArchivedDonationProgram(ctx context.Context, donationProgramID string) pkg.Response
+	UpdateDonationProgram(ctx context.Context, donationProgramID string, accountID string, payload DonationProgramRequest) pkg.Response
+	DeleteDonationProgram(ctx context.Context, donationProgramID string, accountID string) pkg.Response
+	UpdateActiveDonationProgram(ctx context.Context, donationProgramID string, accountID string) pkg.Response
+	UpdateArchivedDonationProgram(ctx context.Context, donationProgramID string, accountID string) pkg.Response
 	UpdateExpiredDonationProgram(ctx context.Context) error
 }
 
 type service struct {
-	repo       Repository
-	logService app_log.Service
-	s3Client   s3_pkg.Client
-	timeout    time.Duration
+	repo            Repository
+	accessGrantRepo access_grant.Repository
+	logService      app_log.Service
+	s3Client        s3_pkg.Client
+	timeout         time.Duration
 }
 
-func NewService(repo Repository, logService app_log.Service, s3Client s3_pkg.Client, timeout time.Duration) Service {
+func NewService(repo Repository, accessGrantRepo access_grant.Repository, logService app_log.Service, s3Client s3_pkg.Client, timeout time.Duration) Service {
 	return &service{
-		repo:       repo,
-		logService: logService,
-		s3Client:   s3Client,
-		timeout:    timeout,
+		repo:            repo,
+		accessGrantRepo: accessGrantRepo,
+		logService:      logService,
+		s3Client:        s3Client,
+		timeout:         timeout,
 	}
 }
 
-func (s *service) GetDonationProgramList(ctx context.Context, params DonationProgramQueryParams, isAdmin bool) pkg.Response {
+// applyAccessScope narrows options to the donation programs granted to the account when it is
+// scoped. A scoped account without grants gets an empty list and sees nothing.
+func (s *service) applyAccessScope(ctx context.Context, accountID string, options map[string]interface{}) error {
+	ids, scoped, err := s.accessGrantRepo.FindAccessScope(ctx, accountID, access_grant.ResourceDonationProgram)
+	if err != nil {
+		return err
+	}
+	if scoped {
+		options["ids"] = ids
+	}
+	return nil
+}
+
+func (s *service) GetDonationProgramList(ctx context.Context, params DonationProgramQueryParams, isAdmin bool, accountID string) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
@@ -87,6 +103,16 @@ func (s *service) GetDonationProgramList(ctx context.Context, params DonationPro
 		}
 	}
 
+	if isAdmin {
+		if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":  "donation.service",
+				"account_id": accountID,
+			}).WithError(err).Error("failed to resolve access grants")
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data donasi", nil, nil)
+		}
+	}
+
 	total, err := s.repo.CountDonationPrograms(ctx, options)
 	if err != nil {
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data donasi", nil, nil)
@@ -127,7 +153,7 @@ func (s *service) GetDonationProgramBySlug(ctx context.Context, slug string) pkg
 	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, donation.toDonationProgramResponse())
 }
 
-func (s *service) GetDonationProgramByID(ctx context.Context, id string) pkg.Response {
+func (s *service) GetDonationProgramByID(ctx context.Context, id string, accountID string) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
@@ -135,7 +161,16 @@ func (s *service) GetDonationProgramByID(ctx context.Context, id string) pkg.Res
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID donasi tidak valid"}, nil)
 	}
 
-	donation, err := s.repo.FindOneDonationProgram(ctx, map[string]interface{}{"id": id})
+	options := map[string]interface{}{"id": id}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "donation.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+
+	donation, err := s.repo.FindOneDonationProgram(ctx, options)
 	if err != nil {
 		return pkg.NewResponse(http.StatusNotFound, "Donasi tidak ditemukan", nil, nil)
 	}
@@ -243,8 +278,9 @@ func (s *service) CreateDonationProgram(ctx context.Context, payload DonationPro
 	}
 
 	var coverImageURL string
+	var coverImageVariants s3_pkg.ImageVariants
 	if payload.CoverImage != nil {
-		uploadedURL, err := s.s3Client.UploadFile(ctx, payload.CoverImage, "donation-programs")
+		uploadedURL, variants, err := s.s3Client.UploadImage(ctx, payload.CoverImage, "donation-programs")
 		if err != nil {
 			logrus.WithFields(logrus.Fields{
 				"component": "donation.service",
@@ -253,6 +289,7 @@ func (s *service) CreateDonationProgram(ctx context.Context, payload DonationPro
 			return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah gambar cover", nil, nil)
 		}
 		coverImageURL = uploadedURL
+		coverImageVariants = variants
 	}
 
 	donationProgram := &DonationProgram{
@@ -268,6 +305,8 @@ func (s *service) CreateDonationProgram(ctx context.Context, payload DonationPro
 		EndDate:     endDate,
 		CreatedAt:   now,
 		UpdatedAt:   now,
+
+		CoverImageVariants: coverImageVariants,
 	}
 
 	if err := s.repo.CreateDonationProgram(ctx, donationProgram); err != nil {
@@ -282,7 +321,7 @@ func (s *service) CreateDonationProgram(ctx context.Context, payload DonationPro
 	return pkg.NewResponse(http.StatusCreated, "Donasi program berhasil dibuat", nil, donationProgram.toAdminDonationProgramResponse())
 }
 
-func (s *service) UpdateDonationProgram(ctx context.Context, id string, payload DonationProgramRequest) pkg.Response {
+func (s *service) UpdateDonationProgram(ctx context.Context, id string, accountID string, payload DonationProgramRequest) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
@@ -290,7 +329,16 @@ func (s *service) UpdateDonationProgram(ctx context.Context, id string, payload
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID donasi tidak valid"}, nil)
 	}
 
-	donationProgram, err := s.repo.FindOneDonationProgram(ctx, map[string]interface{}{"id": id})
+	options := map[string]interface{}{"id": id}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "donation.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+
+	donationProgram, err := s.repo.FindOneDonationProgram(ctx, options)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return pkg.NewResponse(http.StatusNotFound, "Donasi tidak ditemukan", nil, nil)
@@ -447,7 +495,7 @@ func (s *service) UpdateDonationProgram(ctx context.Context, id string, payload
 	}
 
 	if payload.CoverImage != nil {
-		uploadedURL, err := s.s3Client.UploadFile(ctx, payload.CoverImage, "donation-programs")
+		uploadedURL, variants, err := s.s3Client.UploadImage(ctx, payload.CoverImage, "donation-programs")
 		if err != nil {
 			logrus.WithFields(logrus.Fields{
 				"component":   "donation.service",
@@ -458,10 +506,11 @@ func (s *service) UpdateDonationProgram(ctx context.Context, id string, payload
 
 		if donationProgram.CoverImage != "" {
 			existingDonationImage := s3_pkg.ExtractObjectNameFromURL(donationProgram.CoverImage)
-			_ = s.s3Client.DeleteFile(ctx, existingDonationImage)
+			_ = s.s3Client.DeleteImage(ctx, existingDonationImage, donationProgram.CoverImageVariants)
 		}
 
 		updateData["cover_image"] = uploadedURL
+		updateData["cover_image_variants"] = variants
 	}
 
 	if len(updateData) == 0 {
@@ -478,11 +527,11 @@ func (s *service) UpdateDonationProgram(ctx context.Context, id string, payload
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memperbarui donasi", nil, nil)
 	}
 
-	s.logService.CreateLog(ctx, nil, "UPDATE", "donation", id, donationProgram.toAdminDonationProgramResponse(), updateData)
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "donation", id, donationProgram.toAdminDonationProgramResponse(), updateData)
 	return pkg.NewResponse(http.StatusOK, "Donasi berhasil diperbarui", nil, nil)
 }
 
-func (s *service) DeleteDonationProgram(ctx context.Context, id string) pkg.Response {
+func (s *service) DeleteDonationProgram(ctx context.Context, id string, accountID string) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
@@ -490,7 +539,16 @@ func (s *service) DeleteDonationProgram(ctx context.Context, id string) pkg.Resp
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID donasi tidak valid"}, nil)
 	}
 
-	donation, err := s.repo.FindOneDonationProgram(ctx, map[string]interface{}{"id": id})
+	options := map[string]interface{}{"id": id}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "donation.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+
+	donation, err := s.repo.FindOneDonationProgram(ctx, options)
 	if err != nil {
 		return pkg.NewResponse(http.StatusNotFound, "Donasi tidak ditemukan", nil, nil)
 	}
@@ -509,7 +567,7 @@ func (s *service) DeleteDonationProgram(ctx context.Context, id string) pkg.Resp
 
 	if donation.CoverImage != "" {
 		imageObjectName := s3_pkg.ExtractObjectNameFromURL(donation.CoverImage)
-		if err := s.s3Client.DeleteFile(ctx, imageObjectName); err != nil {
+		if err := s.s3Client.DeleteImage(ctx, imageObjectName, donation.CoverImageVariants); err != nil {
 			logrus.WithFields(logrus.Fields{
 				"component":   "donation.service",
 				"donation_id": id,
@@ -517,11 +575,11 @@ func (s *service) DeleteDonationProgram(ctx context.Context, id string) pkg.Resp
 		}
 	}
 
-	s.logService.CreateLog(ctx, nil, "DELETE", "donation", id, donation.toDonationProgramResponse(), nil)
+	s.logService.CreateLog(ctx, &accountID, "DELETE", "donation", id, donation.toDonationProgramResponse(), nil)
 	return pkg.NewResponse(http.StatusOK, "Donasi berhasil dihapus", nil, nil)
 }
 
-func (s *service) UpdateActiveDonationProgram(ctx context.Context, id string) pkg.Response {
+func (s *service) UpdateActiveDonationProgram(ctx context.Context, id string, accountID string) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
@@ -529,7 +587,16 @@ func (s *service) UpdateActiveDonationProgram(ctx context.Context, id string) pk
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID donasi tidak valid"}, nil)
 	}
 
-	donation, err := s.repo.FindOneDonationProgram(ctx, map[string]interface{}{"id": id})
+	options := map[string]interface{}{"id": id}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "donation.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+
+	donation, err := s.repo.FindOneDonationProgram(ctx, options)
 	if err != nil {
 		return pkg.NewResponse(http.StatusNotFound, "Donasi tidak ditemukan", nil, nil)
 	}
@@ -581,11 +648,11 @@ func (s *service) UpdateActiveDonationProgram(ctx context.Context, id string) pk
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengaktifkan donasi", nil, nil)
 	}
 
-	s.logService.CreateLog(ctx, nil, "UPDATE", "donation", id, donation.toAdminDonationProgramResponse(), updateData)
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "donation", id, donation.toAdminDonationProgramResponse(), updateData)
 	return pkg.NewResponse(http.StatusOK, "Donasi berhasil diaktifkan", nil, nil)
 }
 
-func (s *service) UpdateArchivedDonationProgram(ctx context.Context, id string) pkg.Response {
+func (s *service) UpdateArchivedDonationProgram(ctx context.Context, id string, accountID string) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
@@ -593,7 +660,16 @@ func (s *service) UpdateArchivedDonationProgram(ctx context.Context, id string)
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID donasi tidak valid"}, nil)
 	}
 
-	donation, err := s.repo.FindOneDonationProgram(ctx, map[string]interface{}{"id": id})
+	options := map[string]interface{}{"id": id}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "donation.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+
+	donation, err := s.repo.FindOneDonationProgram(ctx, options)
 	if err != nil {
 		return pkg.NewResponse(http.StatusNotFound, "Donasi tidak ditemukan", nil, nil)
 	}
@@ -615,7 +691,7 @@ func (s *service) UpdateArchivedDonationProgram(ctx context.Context, id string)
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengarsipkan donasi", nil, nil)
 	}
 
-	s.logService.CreateLog(ctx, nil, "UPDATE", "donation", id, donation.toAdminDonationProgramResponse(), updateData)
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "donation", id, donation.toAdminDonationProgramResponse(), updateData)
 	return pkg.NewResponse(http.StatusOK, "Donasi berhasil diarsipkan", nil, nil)
 }
 
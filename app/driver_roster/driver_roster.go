@@ -0,0 +1,72 @@
+package driver_roster
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/account"
+	"github.com/google/uuid"
+)
+
+// DriverShift is a block of time a driver is on duty and can be assigned trips.
+type DriverShift struct {
+	ID        uuid.UUID  `json:"id" gorm:"primaryKey"`
+	DriverID  uuid.UUID  `json:"driverId" gorm:"not null;index:idx_driver_shift,priority:1"`
+	StartsAt  time.Time  `json:"startsAt" gorm:"not null;index:idx_driver_shift,priority:2"`
+	EndsAt    time.Time  `json:"endsAt" gorm:"not null;index:idx_driver_shift,priority:3"`
+	Note      string     `json:"note"`
+	CreatedBy uuid.UUID  `json:"createdBy" gorm:"not null"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	DeletedAt *time.Time `json:"deletedAt" gorm:"index"`
+
+	Driver account.Account `json:"driver" gorm:"foreignKey:DriverID"`
+}
+
+// DriverLeave blocks a driver for whole days, both ends inclusive.
+type DriverLeave struct {
+	ID        uuid.UUID  `json:"id" gorm:"primaryKey"`
+	DriverID  uuid.UUID  `json:"driverId" gorm:"not null;index"`
+	StartDate time.Time  `json:"startDate" gorm:"type:date;not null"`
+	EndDate   time.Time  `json:"endDate" gorm:"type:date;not null"`
+	Reason    string     `json:"reason"`
+	CreatedBy uuid.UUID  `json:"createdBy" gorm:"not null"`
+	CreatedAt time.Time  `json:"createdAt"`
+	DeletedAt *time.Time `json:"deletedAt" gorm:"index"`
+
+	Driver account.Account `json:"driver" gorm:"foreignKey:DriverID"`
+}
+
+// DriverCandidate is a driver who is free for a trip window, ranked by recent workload.
+type DriverCandidate struct {
+	DriverID  uuid.UUID
+	Username  string
+	Phone     *string
+	OnShift   bool
+	TripCount int64
+}
+
+var (
+	// ErrShiftOverlap is returned when a driver already has a shift in the requested window.
+	ErrShiftOverlap = errors.New("driver shift overlap")
+	// ErrDriverOnLeave is returned when a shift falls on a driver's leave day.
+	ErrDriverOnLeave = errors.New("driver on leave")
+	// ErrDriverHasTrips is returned when leave is requested over already accepted trips.
+	ErrDriverHasTrips = errors.New("driver has scheduled trips")
+)
+
+const (
+	maxShiftDuration = 24 * time.Hour
+	maxRosterRange   = 62 * 24 * time.Hour
+	// defaultUpcomingDays is how far ahead a driver's own roster is shown by default.
+	defaultUpcomingDays = 14
+	// TripCountWindow is the look-back period used to balance trips between drivers.
+	TripCountWindow = 30 * 24 * time.Hour
+)
+
+// activeTripStatuses mirror the ambulance request states that occupy a driver. They are kept
+// as plain strings because the request module depends on this one.
+var activeTripStatuses = []string{"accepted", "in_service"}
+
+// countedTripStatuses are the request states that count towards a driver's workload.
+var countedTripStatuses = []string{"accepted", "in_service", "done"}
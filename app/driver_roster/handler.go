@@ -0,0 +1,204 @@
+package driver_roster
+
+import (
+	"net/http"
+
+	"github.com/Vilamuzz/yota-backend/app/middleware"
+	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/Vilamuzz/yota-backend/pkg/enum"
+	jwt_pkg "github.com/Vilamuzz/yota-backend/pkg/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	service    Service
+	middleware middleware.AppMiddleware
+}
+
+func NewHandler(r *gin.RouterGroup, s Service, m middleware.AppMiddleware) {
+	h := &handler{
+		service:    s,
+		middleware: m,
+	}
+	h.RegisterRoutes(r)
+}
+
+func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
+	ambulanceManager := r.Group("/admin/ambulances/roster")
+	ambulanceManager.Use(h.middleware.RequireRoles(enum.RoleAmbulanceManager))
+	{
+		ambulanceManager.GET("", h.ListRoster)
+		ambulanceManager.POST("/shifts", h.CreateShift)
+		ambulanceManager.PUT("/shifts/:id", h.UpdateShift)
+		ambulanceManager.DELETE("/shifts/:id", h.DeleteShift)
+		ambulanceManager.POST("/leaves", h.CreateLeave)
+		ambulanceManager.DELETE("/leaves/:id", h.DeleteLeave)
+	}
+
+	ambulanceDriver := r.Group("/admin/ambulances/roster/me")
+	ambulanceDriver.Use(h.middleware.RequireRoles(enum.RoleAmbulanceDriver))
+	{
+		ambulanceDriver.GET("", h.GetMyRoster)
+	}
+}
+
+// ListRoster godoc
+// @Summary List driver roster
+// @Description Get driver shifts and leave days for a date range (default: the next 7 days)
+// @Tags Driver Roster
+// @Security BearerAuth
+// @Produce json
+// @Param driverId query string false "Filter by driver"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date, inclusive (YYYY-MM-DD)"
+// @Success 200 {object} pkg.Response{data=RosterResponse}
+// @Failure 400 {object} pkg.Response
+// @Router /admin/ambulances/roster [get]
+func (h *handler) ListRoster(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var queryParams RosterQueryParams
+	if err := c.ShouldBindQuery(&queryParams); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid query parameters", nil, nil))
+		return
+	}
+
+	res := h.service.ListRoster(ctx, queryParams)
+	c.JSON(res.Status, res)
+}
+
+// GetMyRoster godoc
+// @Summary Get my upcoming shifts
+// @Description Get the logged in driver's upcoming shifts and leave days
+// @Tags Driver Roster
+// @Security BearerAuth
+// @Produce json
+// @Param days query int false "Days ahead (default 14, max 62)"
+// @Success 200 {object} pkg.Response{data=RosterResponse}
+// @Router /admin/ambulances/roster/me [get]
+func (h *handler) GetMyRoster(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var queryParams MyRosterQueryParams
+	if err := c.ShouldBindQuery(&queryParams); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid query parameters", nil, nil))
+		return
+	}
+
+	res := h.service.GetMyRoster(ctx, claims.AccountID, queryParams)
+	c.JSON(res.Status, res)
+}
+
+// CreateShift godoc
+// @Summary Create driver shift
+// @Description Put a driver on duty for a time window of at most 24 hours
+// @Tags Driver Roster
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body ShiftRequest true "Shift"
+// @Success 201 {object} pkg.Response{data=ShiftResponse}
+// @Failure 400 {object} pkg.Response
+// @Failure 409 {object} pkg.Response
+// @Router /admin/ambulances/roster/shifts [post]
+func (h *handler) CreateShift(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req ShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.CreateShift(ctx, claims.AccountID, req)
+	c.JSON(res.Status, res)
+}
+
+// UpdateShift godoc
+// @Summary Update driver shift
+// @Tags Driver Roster
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Shift ID"
+// @Param request body ShiftRequest true "Shift"
+// @Success 200 {object} pkg.Response{data=ShiftResponse}
+// @Failure 400 {object} pkg.Response
+// @Failure 404 {object} pkg.Response
+// @Failure 409 {object} pkg.Response
+// @Router /admin/ambulances/roster/shifts/{id} [put]
+func (h *handler) UpdateShift(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req ShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.UpdateShift(ctx, claims.AccountID, c.Param("id"), req)
+	c.JSON(res.Status, res)
+}
+
+// DeleteShift godoc
+// @Summary Delete driver shift
+// @Tags Driver Roster
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Shift ID"
+// @Success 200 {object} pkg.Response
+// @Failure 404 {object} pkg.Response
+// @Router /admin/ambulances/roster/shifts/{id} [delete]
+func (h *handler) DeleteShift(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.DeleteShift(ctx, claims.AccountID, c.Param("id"))
+	c.JSON(res.Status, res)
+}
+
+// CreateLeave godoc
+// @Summary Create driver leave
+// @Description Mark a driver unavailable for whole days. Rejected when the driver already has accepted trips in the period
+// @Tags Driver Roster
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body LeaveRequest true "Leave"
+// @Success 201 {object} pkg.Response{data=LeaveResponse}
+// @Failure 400 {object} pkg.Response
+// @Failure 409 {object} pkg.Response
+// @Router /admin/ambulances/roster/leaves [post]
+func (h *handler) CreateLeave(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req LeaveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.CreateLeave(ctx, claims.AccountID, req)
+	c.JSON(res.Status, res)
+}
+
+// DeleteLeave godoc
+// @Summary Delete driver leave
+// @Tags Driver Roster
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Leave ID"
+// @Success 200 {object} pkg.Response
+// @Failure 404 {object} pkg.Response
+// @Router /admin/ambulances/roster/leaves/{id} [delete]
+func (h *handler) DeleteLeave(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.DeleteLeave(ctx, claims.AccountID, c.Param("id"))
+	c.JSON(res.Status, res)
+}
@@ -0,0 +1,289 @@
+package driver_roster
+
+import (
+	"context"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/account"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	IsActiveDriver(ctx context.Context, driverID string) (bool, error)
+
+	CreateShift(ctx context.Context, shift *DriverShift) error
+	UpdateShift(ctx context.Context, shift *DriverShift) error
+	DeleteShift(ctx context.Context, id string) error
+	FindShifts(ctx context.Context, options map[string]interface{}) ([]DriverShift, error)
+	FindOneShift(ctx context.Context, options map[string]interface{}) (*DriverShift, error)
+
+	CreateLeave(ctx context.Context, leave *DriverLeave) error
+	DeleteLeave(ctx context.Context, id string) error
+	FindLeaves(ctx context.Context, options map[string]interface{}) ([]DriverLeave, error)
+	FindOneLeave(ctx context.Context, options map[string]interface{}) (*DriverLeave, error)
+
+	FindAvailableDrivers(ctx context.Context, start, end time.Time, options map[string]interface{}) ([]DriverCandidate, error)
+}
+
+type repository struct {
+	Conn *gorm.DB
+}
+
+func NewRepository(conn *gorm.DB) Repository {
+	return &repository{
+		Conn: conn,
+	}
+}
+
+// tripDriverMatch matches requests driven by ar.account_id. Requests accepted before the
+// roster existed have no driver and fall back to the ambulance's default driver.
+const tripDriverMatch = "(q.driver_id = ar.account_id OR (q.driver_id IS NULL AND q.ambulance_id IN (SELECT id FROM ambulances WHERE driver_id = ar.account_id)))"
+
+func (r *repository) IsActiveDriver(ctx context.Context, driverID string) (bool, error) {
+	var count int64
+	err := r.Conn.WithContext(ctx).Table("account_roles").
+		Joins("JOIN accounts ON accounts.id = account_roles.account_id").
+		Where("account_roles.account_id = ? AND account_roles.role_id = ? AND account_roles.is_active = ?", driverID, account.AmbulanceDriverRoleID, true).
+		Where("accounts.is_banned = ? AND accounts.deleted_at IS NULL", false).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// lockDriver serialises roster and assignment changes for one driver.
+func lockDriver(tx *gorm.DB, driverID uuid.UUID) error {
+	var driver account.Account
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", driverID).
+		First(&driver).Error
+}
+
+// checkShift rejects shifts that overlap another shift of the same driver or fall on leave.
+func checkShift(tx *gorm.DB, shift *DriverShift) error {
+	var overlapping int64
+	if err := tx.Model(&DriverShift{}).
+		Where("driver_id = ? AND id <> ? AND deleted_at IS NULL", shift.DriverID, shift.ID).
+		Where("starts_at < ? AND ends_at > ?", shift.EndsAt, shift.StartsAt).
+		Count(&overlapping).Error; err != nil {
+		return err
+	}
+	if overlapping > 0 {
+		return ErrShiftOverlap
+	}
+
+	var onLeave int64
+	if err := tx.Model(&DriverLeave{}).
+		Where("driver_id = ? AND deleted_at IS NULL", shift.DriverID).
+		Where("start_date <= ? AND end_date >= ?", shift.EndsAt, truncateDay(shift.StartsAt)).
+		Count(&onLeave).Error; err != nil {
+		return err
+	}
+	if onLeave > 0 {
+		return ErrDriverOnLeave
+	}
+	return nil
+}
+
+func (r *repository) CreateShift(ctx context.Context, shift *DriverShift) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockDriver(tx, shift.DriverID); err != nil {
+			return err
+		}
+		if err := checkShift(tx, shift); err != nil {
+			return err
+		}
+		return tx.Create(shift).Error
+	})
+}
+
+func (r *repository) UpdateShift(ctx context.Context, shift *DriverShift) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockDriver(tx, shift.DriverID); err != nil {
+			return err
+		}
+		if err := checkShift(tx, shift); err != nil {
+			return err
+		}
+		result := tx.Model(&DriverShift{}).
+			Where("id = ? AND deleted_at IS NULL", shift.ID).
+			Updates(map[string]interface{}{
+				"starts_at":  shift.StartsAt,
+				"ends_at":    shift.EndsAt,
+				"note":       shift.Note,
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *repository) DeleteShift(ctx context.Context, id string) error {
+	result := r.Conn.WithContext(ctx).Model(&DriverShift{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Update("deleted_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) FindShifts(ctx context.Context, options map[string]interface{}) ([]DriverShift, error) {
+	var shifts []DriverShift
+	query := r.Conn.WithContext(ctx).
+		Preload("Driver.UserProfile").
+		Where("deleted_at IS NULL")
+
+	if driverID, ok := options["driver_id"]; ok && driverID != "" {
+		query = query.Where("driver_id = ?", driverID)
+	}
+	if from, ok := options["from"]; ok {
+		query = query.Where("ends_at > ?", from)
+	}
+	if to, ok := options["to"]; ok {
+		query = query.Where("starts_at < ?", to)
+	}
+
+	if err := query.Order("starts_at ASC").Find(&shifts).Error; err != nil {
+		return nil, err
+	}
+	return shifts, nil
+}
+
+func (r *repository) FindOneShift(ctx context.Context, options map[string]interface{}) (*DriverShift, error) {
+	var shift DriverShift
+	query := r.Conn.WithContext(ctx).
+		Preload("Driver.UserProfile").
+		Where("deleted_at IS NULL")
+
+	if id, ok := options["id"]; ok && id != "" {
+		query = query.Where("id = ?", id)
+	}
+
+	if err := query.First(&shift).Error; err != nil {
+		return nil, err
+	}
+	return &shift, nil
+}
+
+func (r *repository) CreateLeave(ctx context.Context, leave *DriverLeave) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockDriver(tx, leave.DriverID); err != nil {
+			return err
+		}
+
+		var scheduled int64
+		if err := tx.Table("ambulance_service_requests AS q").
+			Where("q.driver_id = ? OR (q.driver_id IS NULL AND q.ambulance_id IN (SELECT id FROM ambulances WHERE driver_id = ?))", leave.DriverID, leave.DriverID).
+			Where("q.status IN ?", activeTripStatuses).
+			Where("q.scheduled_start < ? AND q.scheduled_end > ?", leave.EndDate.AddDate(0, 0, 1), leave.StartDate).
+			Count(&scheduled).Error; err != nil {
+			return err
+		}
+		if scheduled > 0 {
+			return ErrDriverHasTrips
+		}
+
+		return tx.Create(leave).Error
+	})
+}
+
+func (r *repository) DeleteLeave(ctx context.Context, id string) error {
+	result := r.Conn.WithContext(ctx).Model(&DriverLeave{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Update("deleted_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) FindLeaves(ctx context.Context, options map[string]interface{}) ([]DriverLeave, error) {
+	var leaves []DriverLeave
+	query := r.Conn.WithContext(ctx).
+		Preload("Driver.UserProfile").
+		Where("deleted_at IS NULL")
+
+	if driverID, ok := options["driver_id"]; ok && driverID != "" {
+		query = query.Where("driver_id = ?", driverID)
+	}
+	if from, ok := options["from"]; ok {
+		query = query.Where("end_date >= ?", from)
+	}
+	if to, ok := options["to"]; ok {
+		query = query.Where("start_date <= ?", to)
+	}
+
+	if err := query.Order("start_date ASC").Find(&leaves).Error; err != nil {
+		return nil, err
+	}
+	return leaves, nil
+}
+
+func (r *repository) FindOneLeave(ctx context.Context, options map[string]interface{}) (*DriverLeave, error) {
+	var leave DriverLeave
+	query := r.Conn.WithContext(ctx).
+		Preload("Driver.UserProfile").
+		Where("deleted_at IS NULL")
+
+	if id, ok := options["id"]; ok && id != "" {
+		query = query.Where("id = ?", id)
+	}
+
+	if err := query.First(&leave).Error; err != nil {
+		return nil, err
+	}
+	return &leave, nil
+}
+
+// FindAvailableDrivers returns active drivers who are not on leave and have no overlapping trip
+// in [start, end), on-shift drivers first and then by fewest trips in TripCountWindow.
+func (r *repository) FindAvailableDrivers(ctx context.Context, start, end time.Time, options map[string]interface{}) ([]DriverCandidate, error) {
+	var candidates []DriverCandidate
+
+	excludeRequestID := uuid.Nil.String()
+	if id, ok := options["exclude_request_id"]; ok && id != "" {
+		excludeRequestID = id.(string)
+	}
+
+	onShift := "EXISTS (SELECT 1 FROM driver_shifts s WHERE s.driver_id = ar.account_id AND s.deleted_at IS NULL AND s.starts_at <= ? AND s.ends_at >= ?)"
+	query := r.Conn.WithContext(ctx).Table("account_roles AS ar").
+		Select("ar.account_id AS driver_id, up.username, up.phone, "+onShift+" AS on_shift, "+
+			"(SELECT COUNT(*) FROM ambulance_service_requests q WHERE "+tripDriverMatch+" AND q.status IN ? AND q.scheduled_start >= ? AND q.scheduled_start < ?) AS trip_count",
+			start, end, countedTripStatuses, start.Add(-TripCountWindow), start).
+		Joins("JOIN accounts a ON a.id = ar.account_id AND a.is_banned = ? AND a.deleted_at IS NULL", false).
+		Joins("LEFT JOIN user_profiles up ON up.account_id = ar.account_id").
+		Where("ar.role_id = ? AND ar.is_active = ?", account.AmbulanceDriverRoleID, true).
+		Where("NOT EXISTS (SELECT 1 FROM driver_leaves l WHERE l.driver_id = ar.account_id AND l.deleted_at IS NULL AND l.start_date <= ? AND l.end_date >= ?)", end, truncateDay(start)).
+		Where("NOT EXISTS (SELECT 1 FROM ambulance_service_requests q WHERE "+tripDriverMatch+" AND q.id <> ? AND q.status IN ? AND q.scheduled_start < ? AND q.scheduled_end > ?)",
+			excludeRequestID, activeTripStatuses, end, start)
+
+	if driverID, ok := options["driver_id"]; ok && driverID != "" {
+		query = query.Where("ar.account_id = ?", driverID)
+	}
+	if onShiftOnly, ok := options["on_shift_only"]; ok && onShiftOnly.(bool) {
+		query = query.Where(onShift, start, end)
+	}
+
+	if err := query.Order("on_shift DESC, trip_count ASC, up.username ASC").Scan(&candidates).Error; err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+func truncateDay(t time.Time) time.Time {
+	u := t.UTC()
+	return time.Date(u.Year(), u.Month(), u.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,25 @@
+package driver_roster
+
+type ShiftRequest struct {
+	DriverID string `json:"driverId"`
+	StartsAt string `json:"startsAt"` // RFC3339
+	EndsAt   string `json:"endsAt"`   // RFC3339
+	Note     string `json:"note"`
+}
+
+type LeaveRequest struct {
+	DriverID  string `json:"driverId"`
+	StartDate string `json:"startDate"` // format: YYYY-MM-DD
+	EndDate   string `json:"endDate"`   // format: YYYY-MM-DD, inclusive
+	Reason    string `json:"reason"`
+}
+
+type RosterQueryParams struct {
+	DriverID string `form:"driverId"`
+	From     string `form:"from"` // optional, format: YYYY-MM-DD
+	To       string `form:"to"`   // optional, format: YYYY-MM-DD, inclusive
+}
+
+type MyRosterQueryParams struct {
+	Days int `form:"days"`
+}
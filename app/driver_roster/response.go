@@ -0,0 +1,105 @@
+package driver_roster
+
+import (
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/account"
+)
+
+type ShiftResponse struct {
+	ID       string                 `json:"id"`
+	Driver   account.DriverResponse `json:"driver"`
+	StartsAt time.Time              `json:"startsAt"`
+	EndsAt   time.Time              `json:"endsAt"`
+	Note     string                 `json:"note"`
+}
+
+type LeaveResponse struct {
+	ID        string                 `json:"id"`
+	Driver    account.DriverResponse `json:"driver"`
+	StartDate string                 `json:"startDate"`
+	EndDate   string                 `json:"endDate"`
+	Reason    string                 `json:"reason"`
+}
+
+type RosterResponse struct {
+	From   time.Time       `json:"from"`
+	To     time.Time       `json:"to"`
+	Shifts []ShiftResponse `json:"shifts"`
+	Leaves []LeaveResponse `json:"leaves"`
+}
+
+// DriverSuggestionResponse is a driver who can take a trip, in suggested order.
+type DriverSuggestionResponse struct {
+	Driver    account.DriverResponse `json:"driver"`
+	OnShift   bool                   `json:"onShift"`
+	TripCount int64                  `json:"tripCount"`
+}
+
+func toDriverResponse(driverID string, a account.Account) account.DriverResponse {
+	driver := account.DriverResponse{ID: driverID}
+	driver.Username = a.UserProfile.Username
+	if a.UserProfile.Phone != nil {
+		driver.Phone = *a.UserProfile.Phone
+	}
+	return driver
+}
+
+func (s *DriverShift) toShiftResponse() ShiftResponse {
+	return ShiftResponse{
+		ID:       s.ID.String(),
+		Driver:   toDriverResponse(s.DriverID.String(), s.Driver),
+		StartsAt: s.StartsAt,
+		EndsAt:   s.EndsAt,
+		Note:     s.Note,
+	}
+}
+
+func toShiftListResponse(shifts []DriverShift) []ShiftResponse {
+	responses := make([]ShiftResponse, 0, len(shifts))
+	for _, shift := range shifts {
+		responses = append(responses, shift.toShiftResponse())
+	}
+	return responses
+}
+
+func (l *DriverLeave) toLeaveResponse() LeaveResponse {
+	return LeaveResponse{
+		ID:        l.ID.String(),
+		Driver:    toDriverResponse(l.DriverID.String(), l.Driver),
+		StartDate: l.StartDate.Format("2006-01-02"),
+		EndDate:   l.EndDate.Format("2006-01-02"),
+		Reason:    l.Reason,
+	}
+}
+
+func toLeaveListResponse(leaves []DriverLeave) []LeaveResponse {
+	responses := make([]LeaveResponse, 0, len(leaves))
+	for _, leave := range leaves {
+		responses = append(responses, leave.toLeaveResponse())
+	}
+	return responses
+}
+
+func (c *DriverCandidate) ToDriverSuggestionResponse() DriverSuggestionResponse {
+	driver := account.DriverResponse{
+		ID:       c.DriverID.String(),
+		Username: c.Username,
+	}
+	if c.Phone != nil {
+		driver.Phone = *c.Phone
+	}
+	return DriverSuggestionResponse{
+		Driver:    driver,
+		OnShift:   c.OnShift,
+		TripCount: c.TripCount,
+	}
+}
+
+func ToDriverSuggestionListResponse(candidates []DriverCandidate) []DriverSuggestionResponse {
+	responses := make([]DriverSuggestionResponse, 0, len(candidates))
+	for _, candidate := range candidates {
+		responses = append(responses, candidate.ToDriverSuggestionResponse())
+	}
+	return responses
+}
@@ -0,0 +1,386 @@
+package driver_roster
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	app_log "github.com/Vilamuzz/yota-backend/app/log"
+	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	ListRoster(ctx context.Context, params RosterQueryParams) pkg.Response
+	GetMyRoster(ctx context.Context, driverID string, params MyRosterQueryParams) pkg.Response
+	CreateShift(ctx context.Context, accountID string, payload ShiftRequest) pkg.Response
+	UpdateShift(ctx context.Context, accountID string, id string, payload ShiftRequest) pkg.Response
+	DeleteShift(ctx context.Context, accountID string, id string) pkg.Response
+	CreateLeave(ctx context.Context, accountID string, payload LeaveRequest) pkg.Response
+	DeleteLeave(ctx context.Context, accountID string, id string) pkg.Response
+}
+
+type service struct {
+	repo       Repository
+	logService app_log.Service
+	timeout    time.Duration
+}
+
+func NewService(repo Repository, logService app_log.Service, timeout time.Duration) Service {
+	return &service{
+		repo:       repo,
+		logService: logService,
+		timeout:    timeout,
+	}
+}
+
+func (s *service) ListRoster(ctx context.Context, params RosterQueryParams) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	errValidation := make(map[string]string)
+	if params.DriverID != "" {
+		if err := uuid.Validate(params.DriverID); err != nil {
+			errValidation["driverId"] = "Format ID supir tidak valid"
+		}
+	}
+
+	today := truncateDay(time.Now())
+	from, to := today, today.AddDate(0, 0, 6)
+	if params.From != "" {
+		parsed, err := time.Parse("2006-01-02", params.From)
+		if err != nil {
+			errValidation["from"] = "Format tanggal harus YYYY-MM-DD"
+		} else {
+			from = parsed
+			to = parsed.AddDate(0, 0, 6)
+		}
+	}
+	if params.To != "" {
+		parsed, err := time.Parse("2006-01-02", params.To)
+		if err != nil {
+			errValidation["to"] = "Format tanggal harus YYYY-MM-DD"
+		} else {
+			to = parsed
+		}
+	}
+	if len(errValidation) == 0 {
+		if to.Before(from) {
+			errValidation["to"] = "Tanggal akhir tidak boleh sebelum tanggal awal"
+		} else if to.Sub(from) > maxRosterRange {
+			errValidation["to"] = "Rentang roster maksimal 62 hari"
+		}
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	roster, err := s.loadRoster(ctx, params.DriverID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat roster supir", nil, nil)
+	}
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, roster)
+}
+
+func (s *service) GetMyRoster(ctx context.Context, driverID string, params MyRosterQueryParams) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	days := params.Days
+	if days <= 0 {
+		days = defaultUpcomingDays
+	}
+	if time.Duration(days)*24*time.Hour > maxRosterRange {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"days": "Maksimal 62 hari"}, nil)
+	}
+
+	from := time.Now()
+	roster, err := s.loadRoster(ctx, driverID, from, truncateDay(from).AddDate(0, 0, days+1))
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat jadwal tugas", nil, nil)
+	}
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, roster)
+}
+
+// loadRoster returns the shifts and leave days that touch [from, to).
+func (s *service) loadRoster(ctx context.Context, driverID string, from, to time.Time) (RosterResponse, error) {
+	shifts, err := s.repo.FindShifts(ctx, map[string]interface{}{
+		"driver_id": driverID,
+		"from":      from,
+		"to":        to,
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "driver_roster.service",
+			"driver_id": driverID,
+		}).WithError(err).Error("failed to load driver shifts")
+		return RosterResponse{}, err
+	}
+
+	leaves, err := s.repo.FindLeaves(ctx, map[string]interface{}{
+		"driver_id": driverID,
+		"from":      truncateDay(from),
+		"to":        to,
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "driver_roster.service",
+			"driver_id": driverID,
+		}).WithError(err).Error("failed to load driver leaves")
+		return RosterResponse{}, err
+	}
+
+	return RosterResponse{
+		From:   from,
+		To:     to,
+		Shifts: toShiftListResponse(shifts),
+		Leaves: toLeaveListResponse(leaves),
+	}, nil
+}
+
+func (s *service) CreateShift(ctx context.Context, accountID string, payload ShiftRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	startsAt, endsAt, errValidation := validateShift(payload)
+	if payload.DriverID == "" {
+		errValidation["driverId"] = "Supir wajib diisi"
+	} else if err := uuid.Validate(payload.DriverID); err != nil {
+		errValidation["driverId"] = "Format ID supir tidak valid"
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	if res, ok := s.checkDriver(ctx, payload.DriverID); !ok {
+		return res
+	}
+
+	creatorID, _ := uuid.Parse(accountID)
+	shift := DriverShift{
+		ID:        uuid.New(),
+		DriverID:  uuid.MustParse(payload.DriverID),
+		StartsAt:  startsAt,
+		EndsAt:    endsAt,
+		Note:      strings.TrimSpace(payload.Note),
+		CreatedBy: creatorID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := s.repo.CreateShift(ctx, &shift); err != nil {
+		return s.shiftErrorResponse(err, "Gagal membuat jadwal tugas")
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "CREATE", "driver_shift", shift.ID.String(), nil, shift.toShiftResponse())
+
+	return pkg.NewResponse(http.StatusCreated, "Jadwal tugas berhasil dibuat", nil, shift.toShiftResponse())
+}
+
+func (s *service) UpdateShift(ctx context.Context, accountID string, id string, payload ShiftRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := uuid.Validate(id); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID jadwal tidak valid"}, nil)
+	}
+
+	shift, err := s.repo.FindOneShift(ctx, map[string]interface{}{"id": id})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Jadwal tugas tidak ditemukan", nil, nil)
+		}
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat jadwal tugas", nil, nil)
+	}
+
+	// The driver of a shift is fixed; reassigning means deleting and creating a new shift.
+	if payload.DriverID != "" && payload.DriverID != shift.DriverID.String() {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"driverId": "Supir pada jadwal tidak dapat diubah"}, nil)
+	}
+
+	startsAt, endsAt, errValidation := validateShift(payload)
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	oldValue := shift.toShiftResponse()
+	shift.StartsAt = startsAt
+	shift.EndsAt = endsAt
+	shift.Note = strings.TrimSpace(payload.Note)
+	if err := s.repo.UpdateShift(ctx, shift); err != nil {
+		return s.shiftErrorResponse(err, "Gagal memperbarui jadwal tugas")
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "driver_shift", id, oldValue, shift.toShiftResponse())
+
+	return pkg.NewResponse(http.StatusOK, "Jadwal tugas berhasil diperbarui", nil, shift.toShiftResponse())
+}
+
+func (s *service) DeleteShift(ctx context.Context, accountID string, id string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := uuid.Validate(id); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID jadwal tidak valid"}, nil)
+	}
+
+	shift, err := s.repo.FindOneShift(ctx, map[string]interface{}{"id": id})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Jadwal tugas tidak ditemukan", nil, nil)
+		}
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat jadwal tugas", nil, nil)
+	}
+
+	if err := s.repo.DeleteShift(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Jadwal tugas tidak ditemukan", nil, nil)
+		}
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menghapus jadwal tugas", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "DELETE", "driver_shift", id, shift.toShiftResponse(), nil)
+
+	return pkg.NewResponse(http.StatusOK, "Jadwal tugas berhasil dihapus", nil, nil)
+}
+
+func (s *service) CreateLeave(ctx context.Context, accountID string, payload LeaveRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	errValidation := make(map[string]string)
+	if payload.DriverID == "" {
+		errValidation["driverId"] = "Supir wajib diisi"
+	} else if err := uuid.Validate(payload.DriverID); err != nil {
+		errValidation["driverId"] = "Format ID supir tidak valid"
+	}
+	startDate, err := time.Parse("2006-01-02", payload.StartDate)
+	if err != nil {
+		errValidation["startDate"] = "Format tanggal harus YYYY-MM-DD"
+	}
+	endDate, err := time.Parse("2006-01-02", payload.EndDate)
+	if err != nil {
+		errValidation["endDate"] = "Format tanggal harus YYYY-MM-DD"
+	}
+	if _, ok := errValidation["startDate"]; !ok {
+		if _, ok := errValidation["endDate"]; !ok {
+			if endDate.Before(startDate) {
+				errValidation["endDate"] = "Tanggal akhir tidak boleh sebelum tanggal awal"
+			} else if endDate.Sub(startDate) > maxRosterRange {
+				errValidation["endDate"] = "Cuti maksimal 62 hari"
+			}
+		}
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	if res, ok := s.checkDriver(ctx, payload.DriverID); !ok {
+		return res
+	}
+
+	creatorID, _ := uuid.Parse(accountID)
+	leave := DriverLeave{
+		ID:        uuid.New(),
+		DriverID:  uuid.MustParse(payload.DriverID),
+		StartDate: startDate,
+		EndDate:   endDate,
+		Reason:    strings.TrimSpace(payload.Reason),
+		CreatedBy: creatorID,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.CreateLeave(ctx, &leave); err != nil {
+		if errors.Is(err, ErrDriverHasTrips) {
+			return pkg.NewResponse(http.StatusConflict, "Supir memiliki perjalanan terjadwal pada periode cuti, alihkan perjalanan terlebih dahulu", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component": "driver_roster.service",
+			"driver_id": payload.DriverID,
+		}).WithError(err).Error("failed to create driver leave")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membuat data cuti", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "CREATE", "driver_leave", leave.ID.String(), nil, leave.toLeaveResponse())
+
+	return pkg.NewResponse(http.StatusCreated, "Cuti supir berhasil dicatat", nil, leave.toLeaveResponse())
+}
+
+func (s *service) DeleteLeave(ctx context.Context, accountID string, id string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := uuid.Validate(id); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID cuti tidak valid"}, nil)
+	}
+
+	leave, err := s.repo.FindOneLeave(ctx, map[string]interface{}{"id": id})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Data cuti tidak ditemukan", nil, nil)
+		}
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memuat data cuti", nil, nil)
+	}
+
+	if err := s.repo.DeleteLeave(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Data cuti tidak ditemukan", nil, nil)
+		}
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menghapus data cuti", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "DELETE", "driver_leave", id, leave.toLeaveResponse(), nil)
+
+	return pkg.NewResponse(http.StatusOK, "Data cuti berhasil dihapus", nil, nil)
+}
+
+func validateShift(payload ShiftRequest) (time.Time, time.Time, map[string]string) {
+	errValidation := make(map[string]string)
+
+	startsAt, err := time.Parse(time.RFC3339, payload.StartsAt)
+	if err != nil {
+		errValidation["startsAt"] = "Format waktu mulai harus RFC3339"
+	}
+	endsAt, err := time.Parse(time.RFC3339, payload.EndsAt)
+	if err != nil {
+		errValidation["endsAt"] = "Format waktu selesai harus RFC3339"
+	}
+	if len(errValidation) == 0 {
+		if !endsAt.After(startsAt) {
+			errValidation["endsAt"] = "Waktu selesai harus setelah waktu mulai"
+		} else if endsAt.Sub(startsAt) > maxShiftDuration {
+			errValidation["endsAt"] = "Durasi jadwal tugas maksimal 24 jam"
+		}
+	}
+	return startsAt.UTC(), endsAt.UTC(), errValidation
+}
+
+// checkDriver ensures the account is an active ambulance driver. ok is false when res holds
+// the error response.
+func (s *service) checkDriver(ctx context.Context, driverID string) (pkg.Response, bool) {
+	isDriver, err := s.repo.IsActiveDriver(ctx, driverID)
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memverifikasi akun supir", nil, nil), false
+	}
+	if !isDriver {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"driverId": "Akun bukan supir ambulans aktif"}, nil), false
+	}
+	return pkg.Response{}, true
+}
+
+func (s *service) shiftErrorResponse(err error, message string) pkg.Response {
+	switch {
+	case errors.Is(err, ErrShiftOverlap):
+		return pkg.NewResponse(http.StatusConflict, "Supir sudah memiliki jadwal tugas pada waktu tersebut", nil, nil)
+	case errors.Is(err, ErrDriverOnLeave):
+		return pkg.NewResponse(http.StatusConflict, "Supir sedang cuti pada waktu tersebut", nil, nil)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return pkg.NewResponse(http.StatusNotFound, "Jadwal tugas tidak ditemukan", nil, nil)
+	}
+	logrus.WithFields(logrus.Fields{
+		"component": "driver_roster.service",
+	}).WithError(err).Error("failed to save driver shift")
+	return pkg.NewResponse(http.StatusInternalServerError, message, nil, nil)
+}
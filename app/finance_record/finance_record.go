@@ -7,6 +7,8 @@ const (
 	FundTypeDonation       = "donation_program"
 	FundTypeFosterChildren = "foster_children"
 	FundTypeSocialProgram  = "social_program"
+	// FundTypeAmbulanceOperational holds fleet running costs; FundID is the ambulance ID.
+	FundTypeAmbulanceOperational = "ambulance_operational"
 )
 
 // SourceType identifies what triggered the record
@@ -26,6 +26,7 @@ func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
 	r.GET("/finance-records/summary", h.SummaryFinanceRecord)
 	r.GET("/admin/finance-records/summary", h.middleware.RequireRoles(enum.RoleFinance, enum.RoleSocialManager), h.AdminSummaryFinanceRecord)
 	r.GET("/admin/finance-records/monthly-trend", h.middleware.RequireRoles(enum.RoleFinance, enum.RoleSocialManager), h.MonthlyTrend)
+	r.GET("/admin/finance-records/ambulance-operational", h.middleware.RequireRoles(enum.RoleFinance, enum.RoleAmbulanceManager), h.AmbulanceOperationalFund)
 }
 
 func (h *handler) SummaryFinanceRecord(c *gin.Context) {
@@ -50,3 +51,25 @@ func (h *handler) MonthlyTrend(c *gin.Context) {
 	res := h.service.GetMonthlyTrend(ctx, params)
 	c.JSON(res.Status, res)
 }
+
+// AmbulanceOperationalFund godoc
+// @Summary Ambulance operational fund
+// @Description Income (ambulance donations) and expenses (fuel, maintenance, trip costs) of the ambulance operational fund, per ambulance
+// @Tags Finance Records
+// @Security BearerAuth
+// @Produce json
+// @Param startDate query string false "Start date (YYYY-MM-DD)"
+// @Param endDate query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} pkg.Response{data=AmbulanceOperationalFundResponse}
+// @Failure 400 {object} pkg.Response
+// @Router /admin/finance-records/ambulance-operational [get]
+func (h *handler) AmbulanceOperationalFund(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params AmbulanceFundQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", nil, nil))
+		return
+	}
+	res := h.service.GetAmbulanceOperationalFund(ctx, params)
+	c.JSON(res.Status, res)
+}
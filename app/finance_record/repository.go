@@ -18,6 +18,7 @@ type Repository interface {
 	FindAll(ctx context.Context, options map[string]interface{}) ([]FinanceRecord, error)
 	Summary(ctx context.Context, isAdmin bool) (FinanceRecordSummary, error)
 	MonthlyTrend(ctx context.Context, params MonthlyTrendQueryParams) (FinanceMonthlyTrendResponse, error)
+	AmbulanceOperationalFund(ctx context.Context, startDate, endDate *time.Time) (AmbulanceOperationalFundResponse, error)
 }
 
 type repo struct {
@@ -118,6 +119,8 @@ func (r *repo) Summary(ctx context.Context, isAdmin bool) (FinanceRecordSummary,
 				summary.TotalSocialProgramExpense = res.Total
 			case FundTypeFosterChildren:
 				summary.TotalFosterChildrenExpense = res.Total
+			case FundTypeAmbulanceOperational:
+				summary.TotalAmbulanceOperationalExpense = res.Total
 			}
 		} else if isAdmin && res.SourceType == SourceTypeTransaction {
 			switch res.FundType {
@@ -127,6 +130,8 @@ func (r *repo) Summary(ctx context.Context, isAdmin bool) (FinanceRecordSummary,
 				summary.TotalSocialProgramIncome = res.Total
 			case FundTypeFosterChildren:
 				summary.TotalFosterChildrenIncome = res.Total
+			case FundTypeAmbulanceOperational:
+				summary.TotalAmbulanceOperationalIncome = res.Total
 			}
 		}
 	}
@@ -196,3 +201,52 @@ func (r *repo) MonthlyTrend(ctx context.Context, params MonthlyTrendQueryParams)
 
 	return response, nil
 }
+
+// AmbulanceOperationalFund breaks the ambulance operational fund down per ambulance.
+// Ambulance donations are income and fleet/trip costs are expenses, both keyed by ambulance ID.
+func (r *repo) AmbulanceOperationalFund(ctx context.Context, startDate, endDate *time.Time) (AmbulanceOperationalFundResponse, error) {
+	var response AmbulanceOperationalFundResponse
+
+	var rows []struct {
+		FundID      string
+		PlateNumber string
+		Income      float64
+		Expense     float64
+	}
+
+	query := r.Conn.WithContext(ctx).
+		Table("finance_records AS fr").
+		Select("fr.fund_id, COALESCE(a.plate_number, '') AS plate_number, "+
+			"SUM(CASE WHEN fr.source_type = ? THEN fr.amount ELSE 0 END) AS income, "+
+			"SUM(CASE WHEN fr.source_type = ? THEN fr.amount ELSE 0 END) AS expense",
+			SourceTypeTransaction, SourceTypeExpense).
+		Joins("LEFT JOIN ambulances a ON a.id::text = fr.fund_id").
+		Where("fr.deleted_at IS NULL").
+		Where("fr.fund_type = ?", FundTypeAmbulanceOperational)
+
+	if startDate != nil {
+		query = query.Where("fr.transaction_date >= ?", *startDate)
+	}
+	if endDate != nil {
+		query = query.Where("fr.transaction_date <= ?", *endDate)
+	}
+
+	if err := query.Group("fr.fund_id, a.plate_number").Order("a.plate_number ASC").Scan(&rows).Error; err != nil {
+		return response, err
+	}
+
+	response.Ambulances = make([]AmbulanceFundItem, 0, len(rows))
+	for _, row := range rows {
+		response.TotalIncome += row.Income
+		response.TotalExpense += row.Expense
+		response.Ambulances = append(response.Ambulances, AmbulanceFundItem{
+			AmbulanceID: row.FundID,
+			PlateNumber: row.PlateNumber,
+			Income:      row.Income,
+			Expense:     row.Expense,
+			Balance:     row.Income - row.Expense,
+		})
+	}
+	response.Balance = response.TotalIncome - response.TotalExpense
+	return response, nil
+}
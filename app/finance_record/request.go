@@ -12,3 +12,8 @@ type MonthlyTrendQueryParams struct {
 	Module string `form:"module"`
 	Year   int    `form:"year"`
 }
+
+type AmbulanceFundQueryParams struct {
+	StartDate string `form:"startDate"` // optional, format: YYYY-MM-DD
+	EndDate   string `form:"endDate"`   // optional, format: YYYY-MM-DD
+}
@@ -1,15 +1,17 @@
 package finance_record
 
 type FinanceRecordSummary struct {
-	TotalDonationProgram        int     `json:"totalDonationProgram"`
-	TotalSocialProgram          int     `json:"totalSocialProgram"`
-	TotalFosterChildren         int     `json:"totalFosterChildren"`
-	TotalDonationProgramExpense float64 `json:"totalDonationProgramExpense"`
-	TotalSocialProgramExpense   float64 `json:"totalSocialProgramExpense"`
-	TotalFosterChildrenExpense  float64 `json:"totalFosterChildrenExpense"`
-	TotalDonationProgramIncome  float64 `json:"totalDonationProgramIncome"`
-	TotalSocialProgramIncome    float64 `json:"totalSocialProgramIncome"`
-	TotalFosterChildrenIncome   float64 `json:"totalFosterChildrenIncome"`
+	TotalDonationProgram             int     `json:"totalDonationProgram"`
+	TotalSocialProgram               int     `json:"totalSocialProgram"`
+	TotalFosterChildren              int     `json:"totalFosterChildren"`
+	TotalDonationProgramExpense      float64 `json:"totalDonationProgramExpense"`
+	TotalSocialProgramExpense        float64 `json:"totalSocialProgramExpense"`
+	TotalFosterChildrenExpense       float64 `json:"totalFosterChildrenExpense"`
+	TotalDonationProgramIncome       float64 `json:"totalDonationProgramIncome"`
+	TotalSocialProgramIncome         float64 `json:"totalSocialProgramIncome"`
+	TotalFosterChildrenIncome        float64 `json:"totalFosterChildrenIncome"`
+	TotalAmbulanceOperationalExpense float64 `json:"totalAmbulanceOperationalExpense"`
+	TotalAmbulanceOperationalIncome  float64 `json:"totalAmbulanceOperationalIncome"`
 }
 
 type FinanceMonthlyTrendItem struct {
@@ -22,3 +24,20 @@ type FinanceMonthlyTrendResponse struct {
 	Module string                    `json:"module"`
 	Items  []FinanceMonthlyTrendItem `json:"items"`
 }
+
+type AmbulanceFundItem struct {
+	AmbulanceID string  `json:"ambulanceId"`
+	PlateNumber string  `json:"plateNumber"`
+	Income      float64 `json:"income"`
+	Expense     float64 `json:"expense"`
+	Balance     float64 `json:"balance"`
+}
+
+type AmbulanceOperationalFundResponse struct {
+	StartDate    string              `json:"startDate"`
+	EndDate      string              `json:"endDate"`
+	TotalIncome  float64             `json:"totalIncome"`
+	TotalExpense float64             `json:"totalExpense"`
+	Balance      float64             `json:"balance"`
+	Ambulances   []AmbulanceFundItem `json:"ambulances"`
+}
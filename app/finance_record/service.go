@@ -2,6 +2,7 @@ package finance_record
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -13,6 +14,7 @@ type Service interface {
 	CreateRecord(ctx context.Context, record *FinanceRecord) error
 	GetSummary(ctx context.Context, isAdmin bool) pkg.Response
 	GetMonthlyTrend(ctx context.Context, params MonthlyTrendQueryParams) pkg.Response
+	GetAmbulanceOperationalFund(ctx context.Context, params AmbulanceFundQueryParams) pkg.Response
 }
 
 type service struct {
@@ -62,4 +64,44 @@ func (s *service) GetMonthlyTrend(ctx context.Context, params MonthlyTrendQueryP
 	}
 
 	return pkg.NewResponse(http.StatusOK, "Berhasil mengambil data tren bulanan", nil, trend)
-}
\ No newline at end of file
+}
+
+func (s *service) GetAmbulanceOperationalFund(ctx context.Context, params AmbulanceFundQueryParams) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var startDate, endDate *time.Time
+	if params.StartDate != "" {
+		parsedStart, err := time.ParseInLocation("2006-01-02", params.StartDate, time.Local)
+		if err != nil {
+			return pkg.NewResponse(http.StatusBadRequest,
+				fmt.Sprintf("format startDate tidak valid: %s (diharapkan YYYY-MM-DD)", params.StartDate), nil, nil)
+		}
+		startDate = &parsedStart
+	}
+	if params.EndDate != "" {
+		parsedEnd, err := time.ParseInLocation("2006-01-02", params.EndDate, time.Local)
+		if err != nil {
+			return pkg.NewResponse(http.StatusBadRequest,
+				fmt.Sprintf("format endDate tidak valid: %s (diharapkan YYYY-MM-DD)", params.EndDate), nil, nil)
+		}
+		parsedEnd = parsedEnd.Add(24*time.Hour - time.Nanosecond) // inclusive end
+		endDate = &parsedEnd
+	}
+	if startDate != nil && endDate != nil && startDate.After(*endDate) {
+		return pkg.NewResponse(http.StatusBadRequest, "startDate harus sebelum endDate", nil, nil)
+	}
+
+	fund, err := s.repo.AmbulanceOperationalFund(ctx, startDate, endDate)
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data dana operasional ambulans", nil, err.Error())
+	}
+	if startDate != nil {
+		fund.StartDate = startDate.Format("2006-01-02")
+	}
+	if endDate != nil {
+		fund.EndDate = endDate.Format("2006-01-02")
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Berhasil mengambil data dana operasional ambulans", nil, fund)
+}
@@ -0,0 +1,104 @@
+package foster_children
+
+import (
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+
+	"github.com/Vilamuzz/yota-backend/config"
+)
+
+const achievementFolder = "foster-children/achievements"
+
+type AchievementCategory string
+
+const (
+	AchievementAcademic  AchievementCategory = "akademik"
+	AchievementSports    AchievementCategory = "olahraga"
+	AchievementArts      AchievementCategory = "seni"
+	AchievementReligious AchievementCategory = "keagamaan"
+	AchievementOther     AchievementCategory = "lainnya"
+)
+
+func (c AchievementCategory) IsValid() bool {
+	switch c {
+	case AchievementAcademic, AchievementSports, AchievementArts, AchievementReligious, AchievementOther:
+		return true
+	}
+	return false
+}
+
+// AchievementLevel is the scope of the competition or award, from school to international.
+type AchievementLevel string
+
+const (
+	LevelSchool        AchievementLevel = "sekolah"
+	LevelDistrict      AchievementLevel = "kecamatan"
+	LevelRegency       AchievementLevel = "kabupaten"
+	LevelProvince      AchievementLevel = "provinsi"
+	LevelNational      AchievementLevel = "nasional"
+	LevelInternational AchievementLevel = "internasional"
+)
+
+func (l AchievementLevel) IsValid() bool {
+	switch l {
+	case LevelSchool, LevelDistrict, LevelRegency, LevelProvince, LevelNational, LevelInternational:
+		return true
+	}
+	return false
+}
+
+// AchievementVisibility decides whether an achievement appears on the child's public page.
+// Achievements uploaded before the flag existed were shown publicly and stay public.
+type AchievementVisibility string
+
+const (
+	VisibilityPublic  AchievementVisibility = "public"
+	VisibilityPrivate AchievementVisibility = "private"
+)
+
+func visibilityOf(isPublic bool) AchievementVisibility {
+	if isPublic {
+		return VisibilityPublic
+	}
+	return VisibilityPrivate
+}
+
+type CertificateFileType string
+
+const (
+	CertificateImage CertificateFileType = "image"
+	CertificatePDF   CertificateFileType = "pdf"
+)
+
+var certificateImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+// certificateFileType accepts certificate scans as JPEG, PNG, WebP or PDF within the upload size
+// limit, and returns the validation message otherwise.
+func certificateFileType(file *multipart.FileHeader) (CertificateFileType, string) {
+	if maxSize := config.GetMaxFileUploadSize(); file.Size > maxSize {
+		return "", "Ukuran sertifikat melebihi batas unggahan"
+	}
+	contentType := file.Header.Get("Content-Type")
+	if contentType == "application/pdf" || strings.EqualFold(filepath.Ext(file.Filename), ".pdf") {
+		return CertificatePDF, ""
+	}
+	if certificateImageTypes[contentType] {
+		return CertificateImage, ""
+	}
+	return "", "Sertifikat harus berupa gambar JPG, PNG, WEBP atau PDF"
+}
+
+func publicAchievements(achievements []Achivement) []Achivement {
+	public := make([]Achivement, 0, len(achievements))
+	for _, a := range achievements {
+		if a.Visibility != VisibilityPrivate {
+			public = append(public, a)
+		}
+	}
+	return public
+}
@@ -3,6 +3,7 @@ package foster_children
 import (
 	"time"
 
+	"github.com/Vilamuzz/yota-backend/pkg/pii"
 	"github.com/google/uuid"
 )
 
@@ -10,10 +11,11 @@ type FosterChildren struct {
 	ID             uuid.UUID  `json:"id" gorm:"primaryKey"`
 	Slug           string     `json:"slug" gorm:"not null"`
 	Name           string     `json:"name" gorm:"not null"`
-	Nik            string     `json:"nik"`
+	Nik            pii.String `json:"nik"`
+	NikIndex       string     `json:"-" gorm:"index"` // blind index of Nik for exact-match lookups
 	ProfilePicture string     `json:"profilePicture" gorm:"not null"`
 	Gender         Gender     `json:"gender" gorm:"not null"`
-	IsGraduated    bool       `json:"isGraduated" gorm:"not null"`
+	IsGraduated    bool       `json:"isGraduated" gorm:"not null"` // mirrors Status == graduated for existing filters
 	Category       Category   `json:"category"`
 	BirthDate      time.Time  `json:"birthDate"`
 	BirthPlace     string     `json:"birthPlace"`
@@ -26,11 +28,28 @@ type FosterChildren struct {
 	UpdatedAt      time.Time  `json:"updatedAt"`
 	DeletedAt      *time.Time `json:"deletedAt" gorm:"index"`
 
+	Status              LifecycleStatus `json:"status" gorm:"type:varchar(20);not null;default:'enrolled';index"`
+	StatusReason        string          `json:"statusReason"`
+	StatusEffectiveDate *time.Time      `json:"statusEffectiveDate" gorm:"type:date"`
+
+	// FamilyCardNumber is the number on the kartu keluarga; siblings share it.
+	FamilyCardNumber      pii.String `json:"familyCardNumber"`
+	FamilyCardNumberIndex string     `json:"-" gorm:"index"` // blind index of FamilyCardNumber
+
+	// CandidateID links the child to the accepted candidate it was created from.
+	CandidateID *uuid.UUID `json:"candidateId" gorm:"uniqueIndex"`
+
 	Achivements   []Achivement `json:"achivements" gorm:"foreignKey:FosterChildrenID"`
 	CollectedFund float64      `json:"collectedFund" gorm:"->"`
 	TotalExpense  float64      `json:"totalExpense" gorm:"->"`
 }
 
+// Sensitive uploads kept in private storage, addressed by their JSON field names.
+const (
+	DocumentFamilyCard = "familyCard"
+	DocumentSKTM       = "sktm"
+)
+
 type Gender string
 
 const (
@@ -45,6 +64,14 @@ type Achivement struct {
 	Note             string    `json:"note"`
 	CreatedAt        time.Time `json:"createdAt" gorm:"not null"`
 	UpdatedAt        time.Time `json:"updatedAt" gorm:"not null"`
+
+	Title      string                `json:"title"`
+	Category   AchievementCategory   `json:"category" gorm:"type:varchar(20)"`
+	Level      AchievementLevel      `json:"level" gorm:"type:varchar(20)"`
+	AchievedAt *time.Time            `json:"achievedAt" gorm:"type:date"`
+	Visibility AchievementVisibility `json:"visibility" gorm:"type:varchar(10);not null;default:'public'"`
+	Position   int                   `json:"position" gorm:"not null;default:0"`
+	FileType   CertificateFileType   `json:"fileType" gorm:"type:varchar(10);not null;default:'image'"`
 }
 
 type Category string
@@ -6,6 +6,7 @@ import (
 	"github.com/Vilamuzz/yota-backend/app/middleware"
 	"github.com/Vilamuzz/yota-backend/pkg"
 	"github.com/Vilamuzz/yota-backend/pkg/enum"
+	jwt_pkg "github.com/Vilamuzz/yota-backend/pkg/jwt"
 	"github.com/gin-gonic/gin"
 )
 
@@ -28,12 +29,15 @@ func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
 	public := r.Group("/foster-children")
 	public.GET("", h.GetFosterChildrenList)
 	public.GET("/:slug", h.GetFosterChildrenBySlug)
+	public.GET("/:slug/achievements", h.GetPublicAchievementList)
 
 	adminFosterChildren := r.Group("/admin/foster-children")
 	adminFosterChildren.Use(h.middleware.RequireRoles(enum.RoleSocialManager, enum.RoleFinance))
 	{
 		adminFosterChildren.GET("", h.GetAdminFosterChildrenList)
 		adminFosterChildren.GET("/:id", h.GetAdminFosterChildrenByID)
+		adminFosterChildren.GET("/:id/timeline", h.GetFosterChildrenTimeline)
+		adminFosterChildren.GET("/:id/achievements", h.GetAchievementList)
 	}
 
 	socialManagerOnly := r.Group("/admin/foster-children")
@@ -42,6 +46,13 @@ func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
 		socialManagerOnly.POST("", h.CreateFosterChildren)
 		socialManagerOnly.PUT("/:id", h.UpdateFosterChildren)
 		socialManagerOnly.DELETE("/:id", h.DeleteFosterChildren)
+		socialManagerOnly.GET("/:id/documents/:document", h.GetFosterChildrenDocument)
+		socialManagerOnly.PATCH("/:id/status", h.ChangeFosterChildrenStatus)
+		socialManagerOnly.POST("/:id/promotions", h.PromoteFosterChildren)
+		socialManagerOnly.POST("/:id/achievements", h.CreateAchievement)
+		socialManagerOnly.PUT("/:id/achievements/order", h.ReorderAchievements)
+		socialManagerOnly.PUT("/:id/achievements/:achievementId", h.UpdateAchievement)
+		socialManagerOnly.DELETE("/:id/achievements/:achievementId", h.DeleteAchievement)
 	}
 
 }
@@ -72,7 +83,7 @@ func (h *handler) GetFosterChildrenList(c *gin.Context) {
 		return
 	}
 
-	res := h.service.GetFosterChildrenList(ctx, queryParams, false)
+	res := h.service.GetFosterChildrenList(ctx, queryParams, false, "")
 	c.JSON(res.Status, res)
 }
 
@@ -85,7 +96,8 @@ func (h *handler) GetAdminFosterChildrenList(c *gin.Context) {
 		return
 	}
 
-	res := h.service.GetFosterChildrenList(ctx, queryParams, true)
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.GetFosterChildrenList(ctx, queryParams, true, claims.AccountID)
 	c.JSON(res.Status, res)
 }
 
@@ -111,7 +123,8 @@ func (h *handler) GetAdminFosterChildrenByID(c *gin.Context) {
 	ctx := c.Request.Context()
 	id := c.Param("id")
 
-	res := h.service.GetFosterChildrenByID(ctx, id)
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.GetFosterChildrenByID(ctx, id, claims.AccountID)
 	c.JSON(res.Status, res)
 }
 
@@ -156,7 +169,6 @@ func (h *handler) CreateFosterChildren(c *gin.Context) {
 // @Param profile_picture formData file false "Profile Picture"
 // @Param family_card formData file false "Family Card"
 // @Param sktm formData file false "SKTM"
-// @Param achievements formData file false "Achievements"
 // @Success 200 {object} pkg.Response
 // @Router /api/admin/foster-children/{id} [put]
 func (h *handler) UpdateFosterChildren(c *gin.Context) {
@@ -169,7 +181,8 @@ func (h *handler) UpdateFosterChildren(c *gin.Context) {
 		return
 	}
 
-	res := h.service.UpdateFosterChildren(ctx, id, req)
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.UpdateFosterChildren(ctx, id, claims.AccountID, req)
 	c.JSON(res.Status, res)
 }
 
@@ -188,7 +201,251 @@ func (h *handler) DeleteFosterChildren(c *gin.Context) {
 	ctx := c.Request.Context()
 	id := c.Param("id")
 
-	res := h.service.DeleteFosterChildren(ctx, id)
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.DeleteFosterChildren(ctx, id, claims.AccountID)
+	c.JSON(res.Status, res)
+}
+
+// GetFosterChildrenDocument
+//
+// @Summary Get Foster Children Document
+// @Description Get a short-lived signed URL for the family card or SKTM of a foster child (social manager only)
+// @Tags Foster Children
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Foster Children ID"
+// @Param document path string true "Document type (familyCard, sktm)"
+// @Success 200 {object} pkg.Response
+// @Router /api/admin/foster-children/{id}/documents/{document} [get]
+func (h *handler) GetFosterChildrenDocument(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.GetFosterChildrenDocument(ctx, c.Param("id"), claims.AccountID, c.Param("document"))
+	c.JSON(res.Status, res)
+}
+
+// ChangeFosterChildrenStatus
+//
+// @Summary Change Foster Children Status
+// @Description Graduate, transfer, exit or re-enroll a foster child. Leaving closes the child's sponsorships, public page and donations (social manager only)
+// @Tags Foster Children
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Foster Children ID"
+// @Param payload body ChangeStatusRequest true "Status Change"
+// @Success 200 {object} pkg.Response{data=LifecycleEventResponse}
+// @Router /api/admin/foster-children/{id}/status [patch]
+func (h *handler) ChangeFosterChildrenStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req ChangeStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Body request tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.ChangeFosterChildrenStatus(ctx, c.Param("id"), claims.AccountID, req)
+	c.JSON(res.Status, res)
+}
+
+// PromoteFosterChildren
+//
+// @Summary Promote Foster Children
+// @Description Record a move to another education level or school (social manager only)
+// @Tags Foster Children
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Foster Children ID"
+// @Param payload body PromoteEducationRequest true "Promotion"
+// @Success 200 {object} pkg.Response{data=LifecycleEventResponse}
+// @Router /api/admin/foster-children/{id}/promotions [post]
+func (h *handler) PromoteFosterChildren(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req PromoteEducationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Body request tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.PromoteFosterChildren(ctx, c.Param("id"), claims.AccountID, req)
+	c.JSON(res.Status, res)
+}
+
+// GetFosterChildrenTimeline
+//
+// @Summary Get Foster Children Timeline
+// @Description Retrieve every status transition and education change of a foster child, oldest first
+// @Tags Foster Children
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Foster Children ID"
+// @Success 200 {object} pkg.Response{data=TimelineResponse}
+// @Router /api/admin/foster-children/{id}/timeline [get]
+func (h *handler) GetFosterChildrenTimeline(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.GetFosterChildrenTimeline(ctx, c.Param("id"), claims.AccountID)
+	c.JSON(res.Status, res)
+}
+
+// GetPublicAchievementList
+//
+// @Summary List Foster Children Achievements
+// @Description Retrieve the public achievements of a foster child for the slug page, in display order
+// @Tags Foster Children
+// @Produce json
+// @Param slug path string true "Foster Children Slug"
+// @Param category query string false "Filter by category (akademik, olahraga, seni, keagamaan, lainnya)"
+// @Param level query string false "Filter by level (sekolah, kecamatan, kabupaten, provinsi, nasional, internasional)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 10, max: 100)"
+// @Success 200 {object} pkg.Response{data=AchievementListResponse}
+// @Router /api/foster-children/{slug}/achievements [get]
+func (h *handler) GetPublicAchievementList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var params AchievementQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Parameter query tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.GetPublicAchievementList(ctx, c.Param("slug"), params)
+	c.JSON(res.Status, res)
+}
+
+// GetAchievementList
+//
+// @Summary List Foster Children Achievements (Admin)
+// @Description Retrieve every achievement of a foster child, private ones included. Private certificates are linked through short-lived URLs
+// @Tags Foster Children
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Foster Children ID"
+// @Param category query string false "Filter by category"
+// @Param level query string false "Filter by level"
+// @Param visibility query string false "Filter by visibility (public, private)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 10, max: 100)"
+// @Success 200 {object} pkg.Response{data=AchievementListResponse}
+// @Router /api/admin/foster-children/{id}/achievements [get]
+func (h *handler) GetAchievementList(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var params AchievementQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Parameter query tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.GetAchievementList(ctx, c.Param("id"), claims.AccountID, params)
+	c.JSON(res.Status, res)
+}
+
+// CreateAchievement
+//
+// @Summary Create Foster Children Achievement
+// @Description Add an achievement with a certificate image or PDF (social manager only)
+// @Tags Foster Children
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Foster Children ID"
+// @Param payload formData CreateAchievementRequest true "Achievement Data"
+// @Param certificate formData file true "Certificate (JPG, PNG, WEBP or PDF)"
+// @Success 201 {object} pkg.Response{data=AchievementResponse}
+// @Router /api/admin/foster-children/{id}/achievements [post]
+func (h *handler) CreateAchievement(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req CreateAchievementRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Body request tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.CreateAchievement(ctx, c.Param("id"), claims.AccountID, req)
 	c.JSON(res.Status, res)
 }
 
+// UpdateAchievement
+//
+// @Summary Update Foster Children Achievement
+// @Description Edit an achievement; a new certificate replaces the old file (social manager only)
+// @Tags Foster Children
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Foster Children ID"
+// @Param achievementId path string true "Achievement ID"
+// @Param payload formData UpdateAchievementRequest true "Achievement Data"
+// @Param certificate formData file false "Certificate (JPG, PNG, WEBP or PDF)"
+// @Success 200 {object} pkg.Response{data=AchievementResponse}
+// @Router /api/admin/foster-children/{id}/achievements/{achievementId} [put]
+func (h *handler) UpdateAchievement(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req UpdateAchievementRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Body request tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.UpdateAchievement(ctx, c.Param("id"), c.Param("achievementId"), claims.AccountID, req)
+	c.JSON(res.Status, res)
+}
+
+// DeleteAchievement
+//
+// @Summary Delete Foster Children Achievement
+// @Description Delete an achievement and its certificate (social manager only)
+// @Tags Foster Children
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Foster Children ID"
+// @Param achievementId path string true "Achievement ID"
+// @Success 200 {object} pkg.Response
+// @Router /api/admin/foster-children/{id}/achievements/{achievementId} [delete]
+func (h *handler) DeleteAchievement(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.DeleteAchievement(ctx, c.Param("id"), c.Param("achievementId"), claims.AccountID)
+	c.JSON(res.Status, res)
+}
+
+// ReorderAchievements
+//
+// @Summary Reorder Foster Children Achievements
+// @Description Set the display order by listing every achievement ID of the child (social manager only)
+// @Tags Foster Children
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Foster Children ID"
+// @Param payload body ReorderAchievementsRequest true "Achievement IDs in display order"
+// @Success 200 {object} pkg.Response
+// @Router /api/admin/foster-children/{id}/achievements/order [put]
+func (h *handler) ReorderAchievements(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req ReorderAchievementsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Body request tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.ReorderAchievements(ctx, c.Param("id"), claims.AccountID, req)
+	c.JSON(res.Status, res)
+}
@@ -0,0 +1,83 @@
+package foster_children
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LifecycleStatus is where a child stands in the foundation's programme. Only enrolled children
+// take new sponsors and donations.
+type LifecycleStatus string
+
+const (
+	StatusEnrolled    LifecycleStatus = "enrolled"
+	StatusGraduated   LifecycleStatus = "graduated"
+	StatusTransferred LifecycleStatus = "transferred" // moved to another institution or family
+	StatusExited      LifecycleStatus = "exited"      // left the programme for any other reason
+)
+
+func (s LifecycleStatus) IsValid() bool {
+	switch s {
+	case StatusEnrolled, StatusGraduated, StatusTransferred, StatusExited:
+		return true
+	}
+	return false
+}
+
+// lifecycleTransitions lists the allowed moves. A child who left can be re-enrolled, but a
+// leaver is never moved straight into another leaving status.
+var lifecycleTransitions = map[LifecycleStatus][]LifecycleStatus{
+	StatusEnrolled:    {StatusGraduated, StatusTransferred, StatusExited},
+	StatusGraduated:   {StatusEnrolled},
+	StatusTransferred: {StatusEnrolled},
+	StatusExited:      {StatusEnrolled},
+}
+
+func (s LifecycleStatus) CanTransitionTo(next LifecycleStatus) bool {
+	for _, allowed := range lifecycleTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// publicStatuses are shown on the public listing and slug page. Graduates stay visible as
+// alumni; transferred and exited children are taken offline.
+var publicStatuses = []LifecycleStatus{StatusEnrolled, StatusGraduated}
+
+type LifecycleEventType string
+
+const (
+	EventStatusChange    LifecycleEventType = "status_change"
+	EventEducationChange LifecycleEventType = "education_change"
+)
+
+// LifecycleEvent is one entry of a child's timeline: a status transition or a change of
+// education level or school.
+type LifecycleEvent struct {
+	ID                 uuid.UUID          `json:"id" gorm:"primaryKey"`
+	FosterChildrenID   uuid.UUID          `json:"fosterChildrenId" gorm:"not null;index"`
+	Type               LifecycleEventType `json:"type" gorm:"type:varchar(30);not null"`
+	FromStatus         LifecycleStatus    `json:"fromStatus" gorm:"type:varchar(20)"`
+	ToStatus           LifecycleStatus    `json:"toStatus" gorm:"type:varchar(20)"`
+	FromEducationLevel int                `json:"fromEducationLevel"`
+	ToEducationLevel   int                `json:"toEducationLevel"`
+	FromSchoolName     string             `json:"fromSchoolName"`
+	ToSchoolName       string             `json:"toSchoolName"`
+	Reason             string             `json:"reason" gorm:"type:text"`
+	Destination        string             `json:"destination"` // receiving institution or family on transfer
+	EffectiveDate      time.Time          `json:"effectiveDate" gorm:"type:date;not null"`
+	ClosedSponsorships int                `json:"closedSponsorships"`
+	CreatedBy          *uuid.UUID         `json:"createdBy"`
+	CreatedAt          time.Time          `json:"createdAt"`
+}
+
+var ErrStatusChanged = errors.New("foster children status changed concurrently")
+
+// IsEnrolled reports whether the child still takes new sponsors and donations.
+func (f *FosterChildren) IsEnrolled() bool {
+	return f.Status == StatusEnrolled
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/Vilamuzz/yota-backend/pkg/pii"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -21,8 +22,16 @@ type Repository interface {
 	DeleteAchievementsByFosterChildrenID(ctx context.Context, fosterChildrenID string) error
 	DeleteAchievementByID(ctx context.Context, id string) error
 	UpdateAchievement(ctx context.Context, id string, updateData map[string]interface{}) error
-	CreateAchievements(ctx context.Context, achievements []Achivement) error
-	CreateFosterChildrenFromCandidate(ctx context.Context, candidateID, name, nik, profilePicture, familyCard, sktm, birthPlace, schoolName, address string, gender string, category string, educationLevel int, birthDate time.Time) error
+	FindAllAchievements(ctx context.Context, options map[string]interface{}) ([]Achivement, error)
+	CountAchievements(ctx context.Context, options map[string]interface{}) (int64, error)
+	FindOneAchievement(ctx context.Context, options map[string]interface{}) (*Achivement, error)
+	CreateAchievement(ctx context.Context, achievement *Achivement) error
+	ReorderAchievements(ctx context.Context, fosterChildrenID string, ids []string) error
+	CreateFosterChildrenFromCandidate(ctx context.Context, tx *gorm.DB, fosterChildrenID, candidateID, name, nik, familyCardNumber, profilePicture, familyCard, sktm, birthPlace, schoolName, address string, gender string, category string, educationLevel int, birthDate time.Time) error
+	TransitionStatus(ctx context.Context, fosterChildrenID string, from LifecycleStatus, updateData map[string]interface{}, event *LifecycleEvent, closeSupport bool) error
+	UpdateFosterChildrenWithEvent(ctx context.Context, fosterChildrenID string, updateData map[string]interface{}, event *LifecycleEvent) error
+	CreateLifecycleEvent(ctx context.Context, event *LifecycleEvent) error
+	FindLifecycleEvents(ctx context.Context, fosterChildrenID string) ([]LifecycleEvent, error)
 }
 
 type repository struct {
@@ -70,6 +79,13 @@ func (r *repository) FindAllFosterChildren(ctx context.Context, options map[stri
 	if isGraduated, ok := options["is_graduated"]; ok {
 		query = query.Where("is_graduated = ?", isGraduated)
 	}
+	// An empty ids list comes from a scoped account without grants and matches nothing.
+	if ids, ok := options["ids"].([]string); ok {
+		query = query.Where("foster_childrens.id IN ?", ids)
+	}
+	if statuses, ok := options["statuses"].([]LifecycleStatus); ok && len(statuses) > 0 {
+		query = query.Where("foster_childrens.status IN ?", statuses)
+	}
 
 	// Build ORDER BY from "sort_by" option, e.g. "name asc" or "education_level desc".
 	orderClause := "created_at DESC"
@@ -118,6 +134,12 @@ func (r *repository) CountFosterChildren(ctx context.Context, options map[string
 	if isGraduated, ok := options["is_graduated"]; ok {
 		query = query.Where("is_graduated = ?", isGraduated)
 	}
+	if ids, ok := options["ids"].([]string); ok {
+		query = query.Where("foster_childrens.id IN ?", ids)
+	}
+	if statuses, ok := options["statuses"].([]LifecycleStatus); ok && len(statuses) > 0 {
+		query = query.Where("foster_childrens.status IN ?", statuses)
+	}
 	err := query.Count(&total).Error
 	return total, err
 }
@@ -135,12 +157,20 @@ func (r *repository) FindOneFosterChildren(ctx context.Context, options map[stri
 
 	query := r.Conn.WithContext(ctx).
 		Select("foster_childrens.*, (?) as collected_fund, (?) as total_expense", collectedFundSubquery, totalExpenseSubquery).
-		Preload("Achivements")
+		Preload("Achivements", func(db *gorm.DB) *gorm.DB { return db.Order(achievementOrder) })
 
 	if id, ok := options["id"]; ok && id != "" {
 		query = query.Where("id = ?", id)
 	} else if slug, ok := options["slug"]; ok && slug != "" {
 		query = query.Where("slug = ?", slug)
+	} else if nikIndex, ok := options["nik_index"]; ok && nikIndex != "" {
+		query = query.Where("nik_index = ?", nikIndex)
+	}
+	if ids, ok := options["ids"].([]string); ok {
+		query = query.Where("foster_childrens.id IN ?", ids)
+	}
+	if statuses, ok := options["statuses"].([]LifecycleStatus); ok && len(statuses) > 0 {
+		query = query.Where("foster_childrens.status IN ?", statuses)
 	}
 
 	if err := query.First(&fosterChildren).Error; err != nil {
@@ -173,20 +203,118 @@ func (r *repository) UpdateAchievement(ctx context.Context, id string, updateDat
 	return r.Conn.WithContext(ctx).Model(&Achivement{}).Where("id = ?", id).Updates(updateData).Error
 }
 
-func (r *repository) CreateAchievements(ctx context.Context, achievements []Achivement) error {
-	return r.Conn.WithContext(ctx).Create(&achievements).Error
+// achievementOrder is the display order: the coordinator's manual position first, then the
+// newest achievements.
+const achievementOrder = "position ASC, achieved_at DESC NULLS LAST, created_at DESC"
+
+func (r *repository) applyAchievementFilters(query *gorm.DB, options map[string]interface{}) *gorm.DB {
+	if fosterChildrenID, ok := options["foster_children_id"]; ok && fosterChildrenID.(string) != "" {
+		query = query.Where("achivements.foster_children_id = ?", fosterChildrenID.(string))
+	}
+	if slug, ok := options["foster_children_slug"]; ok && slug.(string) != "" {
+		query = query.Joins("JOIN foster_childrens ON foster_childrens.id = achivements.foster_children_id").
+			Where("foster_childrens.slug = ? AND foster_childrens.deleted_at IS NULL", slug.(string))
+	}
+	if category, ok := options["category"]; ok && category.(string) != "" {
+		query = query.Where("achivements.category = ?", category.(string))
+	}
+	if level, ok := options["level"]; ok && level.(string) != "" {
+		query = query.Where("achivements.level = ?", level.(string))
+	}
+	if visibility, ok := options["visibility"]; ok && visibility.(string) != "" {
+		query = query.Where("achivements.visibility = ?", visibility.(string))
+	}
+	return query
+}
+
+func (r *repository) FindAllAchievements(ctx context.Context, options map[string]interface{}) ([]Achivement, error) {
+	var achievements []Achivement
+	query := r.applyAchievementFilters(r.Conn.WithContext(ctx).Select("achivements.*"), options)
+
+	limit := 10
+	if l, ok := options["limit"]; ok && l.(int) > 0 {
+		limit = l.(int)
+	}
+	offset := 0
+	if page, ok := options["page"]; ok && page.(int) > 1 {
+		offset = (page.(int) - 1) * limit
+	}
+
+	if err := query.Order("achivements.position ASC, achivements.achieved_at DESC NULLS LAST, achivements.created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&achievements).Error; err != nil {
+		return nil, err
+	}
+	return achievements, nil
+}
+
+func (r *repository) CountAchievements(ctx context.Context, options map[string]interface{}) (int64, error) {
+	var total int64
+	query := r.applyAchievementFilters(r.Conn.WithContext(ctx).Model(&Achivement{}), options)
+	if err := query.Count(&total).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *repository) FindOneAchievement(ctx context.Context, options map[string]interface{}) (*Achivement, error) {
+	var achievement Achivement
+	query := r.Conn.WithContext(ctx)
+	if id, ok := options["id"]; ok && id.(string) != "" {
+		query = query.Where("achivements.id = ?", id.(string))
+	}
+	query = r.applyAchievementFilters(query, options)
+	if err := query.First(&achievement).Error; err != nil {
+		return nil, err
+	}
+	return &achievement, nil
+}
+
+// CreateAchievement appends the achievement after the child's existing ones.
+func (r *repository) CreateAchievement(ctx context.Context, achievement *Achivement) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var next int
+		if err := tx.Model(&Achivement{}).
+			Where("foster_children_id = ?", achievement.FosterChildrenID).
+			Select("COALESCE(MAX(position), -1) + 1").
+			Scan(&next).Error; err != nil {
+			return err
+		}
+		achievement.Position = next
+		return tx.Create(achievement).Error
+	})
+}
+
+// ReorderAchievements stores the index of each ID as its position.
+func (r *repository) ReorderAchievements(ctx context.Context, fosterChildrenID string, ids []string) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			if err := tx.Model(&Achivement{}).
+				Where("id = ? AND foster_children_id = ?", id, fosterChildrenID).
+				Updates(map[string]interface{}{"position": i, "updated_at": time.Now()}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-func (r *repository) CreateFosterChildrenFromCandidate(ctx context.Context, candidateID, name, nik, profilePicture, familyCard, sktm, birthPlace, schoolName, address string, gender string, category string, educationLevel int, birthDate time.Time) error {
+// CreateFosterChildrenFromCandidate creates the child for an accepted candidate inside the
+// caller's transaction, so the child only exists if the acceptance commits.
+func (r *repository) CreateFosterChildrenFromCandidate(ctx context.Context, tx *gorm.DB, fosterChildrenID, candidateID, name, nik, familyCardNumber, profilePicture, familyCard, sktm, birthPlace, schoolName, address string, gender string, category string, educationLevel int, birthDate time.Time) error {
 	now := time.Now()
+	id := uuid.MustParse(fosterChildrenID)
+	candidate := uuid.MustParse(candidateID)
 	fc := &FosterChildren{
-		ID:             uuid.New(),
-		Slug:           fmt.Sprintf("%s-%s", pkg.Slugify(name), uuid.New().String()[:5]),
+		ID:             id,
+		Slug:           fmt.Sprintf("%s-%s", pkg.Slugify(name), fosterChildrenID[:5]),
 		Name:           name,
-		Nik:            nik,
+		Nik:            pii.String(nik),
+		NikIndex:       pii.BlindIndex(nik),
 		ProfilePicture: profilePicture,
 		Gender:         Gender(gender),
 		IsGraduated:    false,
+		Status:         StatusEnrolled,
 		Category:       Category(category),
 		BirthDate:      birthDate,
 		BirthPlace:     birthPlace,
@@ -197,6 +325,106 @@ func (r *repository) CreateFosterChildrenFromCandidate(ctx context.Context, cand
 		SKTM:           sktm,
 		CreatedAt:      now,
 		UpdatedAt:      now,
+
+		FamilyCardNumber:      pii.String(familyCardNumber),
+		FamilyCardNumberIndex: pii.BlindIndex(familyCardNumber),
+		CandidateID:           &candidate,
 	}
-	return r.Conn.WithContext(ctx).Create(fc).Error
+
+	db := tx.WithContext(ctx)
+	if err := db.Create(fc).Error; err != nil {
+		return err
+	}
+	return db.Create(&LifecycleEvent{
+		ID:               uuid.New(),
+		FosterChildrenID: fc.ID,
+		Type:             EventStatusChange,
+		ToStatus:         StatusEnrolled,
+		ToEducationLevel: educationLevel,
+		ToSchoolName:     schoolName,
+		Reason:           "Diterima dari calon anak asuh",
+		EffectiveDate:    now,
+	}).Error
+}
+
+// TransitionStatus moves the child from one lifecycle status to the next and records the event.
+// The update only applies while the child is still in the from status, so two coordinators
+// cannot apply conflicting transitions. With closeSupport, the child's open sponsorships are
+// closed in the same transaction: active ones end on the effective date and pending requests
+// are cancelled, as are the child's open wishlist needs.
+func (r *repository) TransitionStatus(ctx context.Context, fosterChildrenID string, from LifecycleStatus, updateData map[string]interface{}, event *LifecycleEvent, closeSupport bool) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&FosterChildren{}).
+			Where("id = ? AND status = ?", fosterChildrenID, from).
+			Updates(updateData)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrStatusChanged
+		}
+
+		if closeSupport {
+			// Status values of foster_children_sponsorship, which imports this package.
+			ended := tx.Table("foster_children_sponsorships").
+				Where("foster_children_id = ? AND status = ?", fosterChildrenID, "active").
+				Updates(map[string]interface{}{
+					"status":     "ended",
+					"end_reason": event.Reason,
+					"end_date":   event.EffectiveDate,
+					"updated_at": time.Now(),
+				})
+			if ended.Error != nil {
+				return ended.Error
+			}
+			cancelled := tx.Table("foster_children_sponsorships").
+				Where("foster_children_id = ? AND status = ?", fosterChildrenID, "pending").
+				Updates(map[string]interface{}{
+					"status":     "cancelled",
+					"end_reason": event.Reason,
+					"updated_at": time.Now(),
+				})
+			if cancelled.Error != nil {
+				return cancelled.Error
+			}
+			event.ClosedSponsorships = int(ended.RowsAffected + cancelled.RowsAffected)
+
+			// Open wishlist items of foster_children_need, which imports this package, no longer
+			// accept donations once the child leaves.
+			if err := tx.Table("foster_children_needs").
+				Where("foster_children_id = ? AND status = ?", fosterChildrenID, "open").
+				Updates(map[string]interface{}{
+					"status":        "cancelled",
+					"cancel_reason": event.Reason,
+					"updated_at":    time.Now(),
+				}).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(event).Error
+	})
+}
+
+func (r *repository) UpdateFosterChildrenWithEvent(ctx context.Context, fosterChildrenID string, updateData map[string]interface{}, event *LifecycleEvent) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&FosterChildren{}).Where("id = ?", fosterChildrenID).Updates(updateData).Error; err != nil {
+			return err
+		}
+		return tx.Create(event).Error
+	})
+}
+
+func (r *repository) CreateLifecycleEvent(ctx context.Context, event *LifecycleEvent) error {
+	return r.Conn.WithContext(ctx).Create(event).Error
+}
+
+// FindLifecycleEvents returns the child's timeline, oldest first.
+func (r *repository) FindLifecycleEvents(ctx context.Context, fosterChildrenID string) ([]LifecycleEvent, error) {
+	var events []LifecycleEvent
+	err := r.Conn.WithContext(ctx).
+		Where("foster_children_id = ?", fosterChildrenID).
+		Order("effective_date ASC, created_at ASC").
+		Find(&events).Error
+	return events, err
 }
@@ -20,34 +20,88 @@ type CreateFosterChildrenRequest struct {
 	SKTM            *multipart.FileHeader   `form:"sktm" swaggerignore:"true"`
 	Achievements    []*multipart.FileHeader `form:"achievements[]" swaggerignore:"true"`
 	AchivementNotes []string                `form:"achivementNotes[]"`
+
+	FamilyCardNumber string `form:"familyCardNumber"`
 }
 
+// UpdateFosterChildrenRequest edits the profile only; achievements have their own endpoints.
 type UpdateFosterChildrenRequest struct {
-	Name                  string                  `form:"name"`
-	Nik                   string                  `form:"nik"`
-	Gender                Gender                  `form:"gender"`
-	IsGraduated           *bool                   `form:"isGraduated"`
-	Category              Category                `form:"category"`
-	BirthDate             string                  `form:"birthDate"`
-	BirthPlace            string                  `form:"birthPlace"`
-	SchoolName            string                  `form:"schoolName"`
-	EducationLevel        int                     `form:"educationLevel"`
-	Address               string                  `form:"address"`
-	ProfilePicture        *multipart.FileHeader   `form:"profilePicture" swaggerignore:"true"`
-	FamilyCard            *multipart.FileHeader   `form:"familyCard" swaggerignore:"true"`
-	SKTM                  *multipart.FileHeader   `form:"sktm" swaggerignore:"true"`
-	Achievements          []*multipart.FileHeader `form:"achievements[]" swaggerignore:"true"`
-	AchivementNotes       []string                `form:"achivementNotes[]"`
-	AchivementIDs         []string                `form:"achivementIDs[]"`
-	UpdateAchivementNotes []string                `form:"updateAchivementNotes[]"`
+	Name             string                `form:"name"`
+	Nik              string                `form:"nik"`
+	FamilyCardNumber string                `form:"familyCardNumber"`
+	Gender           Gender                `form:"gender"`
+	IsGraduated      *bool                 `form:"isGraduated"`
+	Category         Category              `form:"category"`
+	BirthDate        string                `form:"birthDate"`
+	BirthPlace       string                `form:"birthPlace"`
+	SchoolName       string                `form:"schoolName"`
+	EducationLevel   int                   `form:"educationLevel"`
+	Address          string                `form:"address"`
+	ProfilePicture   *multipart.FileHeader `form:"profilePicture" swaggerignore:"true"`
+	FamilyCard       *multipart.FileHeader `form:"familyCard" swaggerignore:"true"`
+	SKTM             *multipart.FileHeader `form:"sktm" swaggerignore:"true"`
 }
 
 type FosterChildrenQueryParams struct {
-	Search      string   `form:"search"`
-	Category    Category `form:"category"`
-	Gender      Gender   `form:"gender"`
-	IsGraduated *bool    `form:"isGraduated"`
-	SortBy      string   `form:"sortBy"` // e.g. "name asc", "education_level desc", "created_at desc"
-	Page        int      `form:"page"`
-	Limit       int      `form:"limit"`
+	Search      string          `form:"search"`
+	Category    Category        `form:"category"`
+	Gender      Gender          `form:"gender"`
+	IsGraduated *bool           `form:"isGraduated"`
+	Status      LifecycleStatus `form:"status"` // admin only
+	SortBy      string          `form:"sortBy"` // e.g. "name asc", "education_level desc", "created_at desc"
+	Page        int             `form:"page"`
+	Limit       int             `form:"limit"`
+}
+
+// ChangeStatusRequest moves a child to another lifecycle status. Reason is required when the
+// child leaves by transfer or exit, and Destination names the receiving party on transfer.
+type ChangeStatusRequest struct {
+	Status        LifecycleStatus `json:"status"`
+	Reason        string          `json:"reason"`
+	Destination   string          `json:"destination"`
+	EffectiveDate string          `json:"effectiveDate"` // YYYY-MM-DD, defaults to today
+}
+
+type PromoteEducationRequest struct {
+	EducationLevel int    `json:"educationLevel"`
+	SchoolName     string `json:"schoolName"` // defaults to the current school
+	EffectiveDate  string `json:"effectiveDate"`
+	Note           string `json:"note"`
+}
+
+// CreateAchievementRequest adds one achievement. The certificate is an image or a PDF and the
+// achievement is public unless isPublic is false.
+type CreateAchievementRequest struct {
+	Title       string                `form:"title"`
+	Category    AchievementCategory   `form:"category"`
+	Level       AchievementLevel      `form:"level"`
+	AchievedAt  string                `form:"achievedAt"` // YYYY-MM-DD
+	Note        string                `form:"note"`
+	IsPublic    *bool                 `form:"isPublic"`
+	Certificate *multipart.FileHeader `form:"certificate" swaggerignore:"true"`
+}
+
+// UpdateAchievementRequest only changes the fields that are sent; a new certificate replaces
+// the old file.
+type UpdateAchievementRequest struct {
+	Title       string                `form:"title"`
+	Category    AchievementCategory   `form:"category"`
+	Level       AchievementLevel      `form:"level"`
+	AchievedAt  string                `form:"achievedAt"`
+	Note        *string               `form:"note"`
+	IsPublic    *bool                 `form:"isPublic"`
+	Certificate *multipart.FileHeader `form:"certificate" swaggerignore:"true"`
+}
+
+// ReorderAchievementsRequest lists every achievement ID of the child in the new display order.
+type ReorderAchievementsRequest struct {
+	IDs []string `json:"ids"`
+}
+
+type AchievementQueryParams struct {
+	Category   AchievementCategory   `form:"category"`
+	Level      AchievementLevel      `form:"level"`
+	Visibility AchievementVisibility `form:"visibility"` // admin only
+	Page       int                   `form:"page"`
+	Limit      int                   `form:"limit"`
 }
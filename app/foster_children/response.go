@@ -1,15 +1,88 @@
 package foster_children
 
 import (
+	"context"
 	"time"
 
 	"github.com/Vilamuzz/yota-backend/pkg"
 	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
+	"github.com/sirupsen/logrus"
 )
 
 type AchievementResponse struct {
-	ID  string `json:"id"`
-	URL string `json:"url"`
+	ID         string              `json:"id"`
+	Title      string              `json:"title"`
+	Category   AchievementCategory `json:"category"`
+	Level      AchievementLevel    `json:"level"`
+	AchievedAt string              `json:"achievedAt"`
+	Note       string              `json:"note"`
+	URL        string              `json:"url"`
+	FileType   CertificateFileType `json:"fileType"`
+	IsPublic   bool                `json:"isPublic"`
+	Position   int                 `json:"position"`
+
+	ExpiresAt string `json:"expiresAt,omitempty"`
+}
+
+type AchievementListResponse struct {
+	Achievements []AchievementResponse `json:"achievements"`
+	Pagination   pkg.OffsetPagination  `json:"pagination"`
+}
+
+// toAchievementResponse never links a private certificate; admin responses sign it through
+// toSignedAchievementResponse.
+func (a *Achivement) toAchievementResponse() AchievementResponse {
+	url := s3_pkg.GetCDNURL(a.URL)
+	if s3_pkg.IsPrivateRef(a.URL) {
+		url = ""
+	}
+	return AchievementResponse{
+		ID:         a.ID.String(),
+		Title:      a.Title,
+		Category:   a.Category,
+		Level:      a.Level,
+		AchievedAt: formatDate(a.AchievedAt),
+		Note:       a.Note,
+		URL:        url,
+		FileType:   a.FileType,
+		IsPublic:   a.Visibility != VisibilityPrivate,
+		Position:   a.Position,
+	}
+}
+
+// toSignedAchievementResponse links a private certificate through a presigned URL. A certificate
+// that cannot be signed is returned without a link rather than failing the response.
+func (a *Achivement) toSignedAchievementResponse(ctx context.Context, storage s3_pkg.PrivateClient) AchievementResponse {
+	resp := a.toAchievementResponse()
+	if !s3_pkg.IsPrivateRef(a.URL) {
+		return resp
+	}
+	link, err := s3_pkg.SignDocument(ctx, storage, a.URL)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":      "foster_children.response",
+			"achievement_id": a.ID.String(),
+		}).WithError(err).Error("failed to sign achievement certificate url")
+		return resp
+	}
+	resp.URL = link.URL
+	resp.ExpiresAt = link.ExpiresAt
+	return resp
+}
+
+func toSignedAchievementResponses(ctx context.Context, storage s3_pkg.PrivateClient, achievements []Achivement) []AchievementResponse {
+	responses := make([]AchievementResponse, 0, len(achievements))
+	for _, a := range achievements {
+		responses = append(responses, a.toSignedAchievementResponse(ctx, storage))
+	}
+	return responses
+}
+
+func toAchievementListResponse(ctx context.Context, storage s3_pkg.PrivateClient, achievements []Achivement, pagination pkg.OffsetPagination) AchievementListResponse {
+	return AchievementListResponse{
+		Achievements: toSignedAchievementResponses(ctx, storage, achievements),
+		Pagination:   pagination,
+	}
 }
 
 type FosterChildrenDetailResponse struct {
@@ -20,6 +93,7 @@ type FosterChildrenDetailResponse struct {
 	ProfilePicture string                `json:"profilePicture"`
 	Gender         Gender                `json:"gender"`
 	IsGraduated    bool                  `json:"isGraduated"`
+	Status         LifecycleStatus       `json:"status"`
 	Category       Category              `json:"category"`
 	BirthDate      string                `json:"birthDate"`
 	BirthPlace     string                `json:"birthPlace"`
@@ -32,15 +106,16 @@ type FosterChildrenDetailResponse struct {
 }
 
 type FosterChildrenListItemResponse struct {
-	ID             string   `json:"id"`
-	Slug           string   `json:"slug"`
-	Name           string   `json:"name"`
-	ProfilePicture string   `json:"profilePicture"`
-	BirthDate      string   `json:"birthDate"`
-	Gender         Gender   `json:"gender"`
-	IsGraduated    bool     `json:"isGraduated"`
-	Category       Category `json:"category"`
-	TotalExpense   float64  `json:"totalExpense"`
+	ID             string          `json:"id"`
+	Slug           string          `json:"slug"`
+	Name           string          `json:"name"`
+	ProfilePicture string          `json:"profilePicture"`
+	BirthDate      string          `json:"birthDate"`
+	Gender         Gender          `json:"gender"`
+	IsGraduated    bool            `json:"isGraduated"`
+	Status         LifecycleStatus `json:"status"`
+	Category       Category        `json:"category"`
+	TotalExpense   float64         `json:"totalExpense"`
 }
 
 type FosterChildrenListResponse struct {
@@ -51,10 +126,7 @@ type FosterChildrenListResponse struct {
 func (f *FosterChildren) ToFosterChildrenDetailResponse() FosterChildrenDetailResponse {
 	var achievements []AchievementResponse
 	for _, a := range f.Achivements {
-		achievements = append(achievements, AchievementResponse{
-			ID:  a.ID.String(),
-			URL: s3_pkg.GetCDNURL(a.URL),
-		})
+		achievements = append(achievements, a.toAchievementResponse())
 	}
 	if achievements == nil {
 		achievements = []AchievementResponse{}
@@ -67,6 +139,7 @@ func (f *FosterChildren) ToFosterChildrenDetailResponse() FosterChildrenDetailRe
 		ProfilePicture: s3_pkg.GetCDNURL(f.ProfilePicture),
 		Gender:         f.Gender,
 		IsGraduated:    f.IsGraduated,
+		Status:         f.Status,
 		Category:       f.Category,
 		BirthDate:      f.BirthDate.Format("2006-01-02"),
 		BirthPlace:     f.BirthPlace,
@@ -88,6 +161,7 @@ func (f *FosterChildren) ToFosterChildrenListItemResponse() FosterChildrenListIt
 		BirthDate:      f.BirthDate.Format("2006-01-02"),
 		Gender:         f.Gender,
 		IsGraduated:    f.IsGraduated,
+		Status:         f.Status,
 		Category:       f.Category,
 		TotalExpense:   f.TotalExpense,
 	}
@@ -109,21 +183,26 @@ func ToFosterChildrenListResponse(fosterChildren []FosterChildren, pagination pk
 
 type AdminFosterChildrenDetailResponse struct {
 	FosterChildrenDetailResponse
-	FamilyCard    string  `json:"familyCard"`
-	SKTM          string  `json:"sktm"`
-	CollectedFund float64 `json:"collectedFund"`
+	FamilyCard          string  `json:"familyCard"`
+	FamilyCardNumber    string  `json:"familyCardNumber"`
+	SKTM                string  `json:"sktm"`
+	CollectedFund       float64 `json:"collectedFund"`
+	StatusReason        string  `json:"statusReason"`
+	StatusEffectiveDate string  `json:"statusEffectiveDate"`
+	CandidateID         *string `json:"candidateId"`
 }
 
 type AdminFosterChildrenListItemResponse struct {
-	ID             string    `json:"id"`
-	Name           string    `json:"name"`
-	ProfilePicture string    `json:"profilePicture"`
-	Gender         Gender    `json:"gender"`
-	IsGraduated    bool      `json:"isGraduated"`
-	Category       Category  `json:"category"`
-	CollectedFund  float64   `json:"collectedFund"`
-	TotalExpense   float64   `json:"totalExpense"`
-	CreatedAt      time.Time `json:"createdAt"`
+	ID             string          `json:"id"`
+	Name           string          `json:"name"`
+	ProfilePicture string          `json:"profilePicture"`
+	Gender         Gender          `json:"gender"`
+	IsGraduated    bool            `json:"isGraduated"`
+	Status         LifecycleStatus `json:"status"`
+	Category       Category        `json:"category"`
+	CollectedFund  float64         `json:"collectedFund"`
+	TotalExpense   float64         `json:"totalExpense"`
+	CreatedAt      time.Time       `json:"createdAt"`
 }
 
 type AdminFosterChildrenListResponse struct {
@@ -131,12 +210,25 @@ type AdminFosterChildrenListResponse struct {
 	Pagination          pkg.OffsetPagination                  `json:"pagination"`
 }
 
+// ToAdminFosterChildrenDetailResponse is the only response carrying the full NIK; the public
+// detail leaves it empty.
 func (a *FosterChildren) ToAdminFosterChildrenDetailResponse() AdminFosterChildrenDetailResponse {
+	detail := a.ToFosterChildrenDetailResponse()
+	detail.Nik = string(a.Nik)
+	var candidateID *string
+	if a.CandidateID != nil {
+		id := a.CandidateID.String()
+		candidateID = &id
+	}
 	return AdminFosterChildrenDetailResponse{
-		FosterChildrenDetailResponse: a.ToFosterChildrenDetailResponse(),
+		FosterChildrenDetailResponse: detail,
 		FamilyCard:                   s3_pkg.GetCDNURL(a.FamilyCard),
+		FamilyCardNumber:             string(a.FamilyCardNumber),
 		SKTM:                         s3_pkg.GetCDNURL(a.SKTM),
 		CollectedFund:                a.CollectedFund,
+		StatusReason:                 a.StatusReason,
+		StatusEffectiveDate:          formatDate(a.StatusEffectiveDate),
+		CandidateID:                  candidateID,
 	}
 }
 
@@ -147,6 +239,7 @@ func (a *FosterChildren) ToAdminFosterChildrenListItemResponse() AdminFosterChil
 		ProfilePicture: s3_pkg.GetCDNURL(a.ProfilePicture),
 		Gender:         a.Gender,
 		IsGraduated:    a.IsGraduated,
+		Status:         a.Status,
 		Category:       a.Category,
 		CollectedFund:  a.CollectedFund,
 		TotalExpense:   a.TotalExpense,
@@ -167,3 +260,73 @@ func ToAdminFosterChildrenListResponse(fosterChildren []FosterChildren, paginati
 		Pagination:          pagination,
 	}
 }
+
+type LifecycleEventResponse struct {
+	ID                 string             `json:"id"`
+	Type               LifecycleEventType `json:"type"`
+	FromStatus         LifecycleStatus    `json:"fromStatus"`
+	ToStatus           LifecycleStatus    `json:"toStatus"`
+	FromEducationLevel int                `json:"fromEducationLevel"`
+	ToEducationLevel   int                `json:"toEducationLevel"`
+	FromSchoolName     string             `json:"fromSchoolName"`
+	ToSchoolName       string             `json:"toSchoolName"`
+	Reason             string             `json:"reason"`
+	Destination        string             `json:"destination"`
+	EffectiveDate      string             `json:"effectiveDate"`
+	ClosedSponsorships int                `json:"closedSponsorships"`
+	CreatedBy          string             `json:"createdBy"`
+	CreatedAt          time.Time          `json:"createdAt"`
+}
+
+type TimelineResponse struct {
+	FosterChildrenID string                   `json:"fosterChildrenId"`
+	Name             string                   `json:"name"`
+	Status           LifecycleStatus          `json:"status"`
+	EducationLevel   int                      `json:"educationLevel"`
+	SchoolName       string                   `json:"schoolName"`
+	Events           []LifecycleEventResponse `json:"events"`
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func (e *LifecycleEvent) toLifecycleEventResponse() LifecycleEventResponse {
+	resp := LifecycleEventResponse{
+		ID:                 e.ID.String(),
+		Type:               e.Type,
+		FromStatus:         e.FromStatus,
+		ToStatus:           e.ToStatus,
+		FromEducationLevel: e.FromEducationLevel,
+		ToEducationLevel:   e.ToEducationLevel,
+		FromSchoolName:     e.FromSchoolName,
+		ToSchoolName:       e.ToSchoolName,
+		Reason:             e.Reason,
+		Destination:        e.Destination,
+		EffectiveDate:      e.EffectiveDate.Format("2006-01-02"),
+		ClosedSponsorships: e.ClosedSponsorships,
+		CreatedAt:          e.CreatedAt,
+	}
+	if e.CreatedBy != nil {
+		resp.CreatedBy = e.CreatedBy.String()
+	}
+	return resp
+}
+
+func (f *FosterChildren) toTimelineResponse(events []LifecycleEvent) TimelineResponse {
+	responses := make([]LifecycleEventResponse, 0, len(events))
+	for _, e := range events {
+		responses = append(responses, e.toLifecycleEventResponse())
+	}
+	return TimelineResponse{
+		FosterChildrenID: f.ID.String(),
+		Name:             f.Name,
+		Status:           f.Status,
+		EducationLevel:   f.EducationLevel,
+		SchoolName:       f.SchoolName,
+		Events:           responses,
+	}
+}
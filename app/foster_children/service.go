@@ -2,43 +2,76 @@ package foster_children
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"mime/multipart"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/Vilamuzz/yota-backend/app/access_grant"
 	app_log "github.com/Vilamuzz/yota-backend/app/log"
 	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/Vilamuzz/yota-backend/pkg/pii"
 	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 type Service interface {
-	GetFosterChildrenList(ctx context.Context, params FosterChildrenQueryParams, isAdmin bool) pkg.Response
-	GetFosterChildrenByID(ctx context.Context, id string) pkg.Response
+	GetFosterChildrenList(ctx context.Context, params FosterChildrenQueryParams, isAdmin bool, accountID string) pkg.Response
+	GetFosterChildrenByID(ctx context.Context, id string, accountID string) pkg.Response
 	GetFosterChildrenBySlug(ctx context.Context, slug string) pkg.Response
 	CreateFosterChildren(ctx context.Context, req CreateFosterChildrenRequest) pkg.Response
-	UpdateFosterChildren(ctx context.Context, id string, req UpdateFosterChildrenRequest) pkg.Response
-	DeleteFosterChildren(ctx context.Context, id string) pkg.Response
+	UpdateFosterChildren(ctx context.Context, id string, accountID string, req UpdateFosterChildrenRequest) pkg.Response
+	DeleteFosterChildren(ctx context.Context, id string, accountID string) pkg.Response
+	GetFosterChildrenDocument(ctx context.Context, id string, accountID string, document string) pkg.Response
+	ChangeFosterChildrenStatus(ctx context.Context, id string, accountID string, req ChangeStatusRequest) pkg.Response
+	PromoteFosterChildren(ctx context.Context, id string, accountID string, req PromoteEducationRequest) pkg.Response
+	GetFosterChildrenTimeline(ctx context.Context, id string, accountID string) pkg.Response
+	GetPublicAchievementList(ctx context.Context, slug string, params AchievementQueryParams) pkg.Response
+	GetAchievementList(ctx context.Context, id string, accountID string, params AchievementQueryParams) pkg.Response
+	CreateAchievement(ctx context.Context, id string, accountID string, req CreateAchievementRequest) pkg.Response
+	UpdateAchievement(ctx context.Context, id string, achievementID string, accountID string, req UpdateAchievementRequest) pkg.Response
+	DeleteAchievement(ctx context.Context, id string, achievementID string, accountID string) pkg.Response
+	ReorderAchievements(ctx context.Context, id string, accountID string, req ReorderAchievementsRequest) pkg.Response
 }
 
 type service struct {
-	repo       Repository
-	logService app_log.Service
-	s3Client   s3_pkg.Client
-	timeout    time.Duration
+	repo            Repository
+	accessGrantRepo access_grant.Repository
+	logService      app_log.Service
+	s3Client        s3_pkg.Client
+	privateStorage  s3_pkg.PrivateClient
+	timeout         time.Duration
 }
 
-func NewService(repo Repository, logService app_log.Service, s3Client s3_pkg.Client, timeout time.Duration) Service {
+func NewService(repo Repository, accessGrantRepo access_grant.Repository, logService app_log.Service, s3Client s3_pkg.Client, privateStorage s3_pkg.PrivateClient, timeout time.Duration) Service {
 	return &service{
-		repo:       repo,
-		logService: logService,
-		s3Client:   s3Client,
-		timeout:    timeout,
+		repo:            repo,
+		accessGrantRepo: accessGrantRepo,
+		logService:      logService,
+		s3Client:        s3Client,
+		privateStorage:  privateStorage,
+		timeout:         timeout,
 	}
 }
 
-func (s *service) GetFosterChildrenList(ctx context.Context, params FosterChildrenQueryParams, isAdmin bool) pkg.Response {
+// applyAccessScope narrows options to the foster children granted to the account when it is
+// scoped. A scoped account without grants gets an empty list and sees nothing.
+func (s *service) applyAccessScope(ctx context.Context, accountID string, options map[string]interface{}) error {
+	ids, scoped, err := s.accessGrantRepo.FindAccessScope(ctx, accountID, access_grant.ResourceFosterChildren)
+	if err != nil {
+		return err
+	}
+	if scoped {
+		options["ids"] = ids
+	}
+	return nil
+}
+
+func (s *service) GetFosterChildrenList(ctx context.Context, params FosterChildrenQueryParams, isAdmin bool, accountID string) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
@@ -58,6 +91,21 @@ func (s *service) GetFosterChildrenList(ctx context.Context, params FosterChildr
 	}
 	if isAdmin {
 		options["is_admin"] = true
+		if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":  "foster_children.service",
+				"account_id": accountID,
+			}).WithError(err).Error("failed to resolve access grants")
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data anak asuh", nil, nil)
+		}
+		if params.Status != "" {
+			if !params.Status.IsValid() {
+				return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"status": "Status tidak valid"}, nil)
+			}
+			options["statuses"] = []LifecycleStatus{params.Status}
+		}
+	} else {
+		options["statuses"] = publicStatuses
 	}
 	if params.Category != "" {
 		options["category"] = params.Category
@@ -103,7 +151,7 @@ func (s *service) GetFosterChildrenList(ctx context.Context, params FosterChildr
 	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, ToFosterChildrenListResponse(fosterChildren, pagination))
 }
 
-func (s *service) GetFosterChildrenByID(ctx context.Context, id string) pkg.Response {
+func (s *service) GetFosterChildrenByID(ctx context.Context, id string, accountID string) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
@@ -111,23 +159,35 @@ func (s *service) GetFosterChildrenByID(ctx context.Context, id string) pkg.Resp
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID anak asuh tidak valid"}, nil)
 	}
 
-	fosterChildren, err := s.repo.FindOneFosterChildren(ctx, map[string]interface{}{"id": id})
+	options := map[string]interface{}{"id": id}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "foster_children.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data anak asuh", nil, nil)
+	}
+
+	fosterChildren, err := s.repo.FindOneFosterChildren(ctx, options)
 	if err != nil {
 		return pkg.NewResponse(http.StatusNotFound, "Anak asuh tidak ditemukan", nil, nil)
 	}
 
-	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, fosterChildren.ToAdminFosterChildrenDetailResponse())
+	detail := fosterChildren.ToAdminFosterChildrenDetailResponse()
+	detail.Achievements = toSignedAchievementResponses(ctx, s.privateStorage, fosterChildren.Achivements)
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, detail)
 }
 
 func (s *service) GetFosterChildrenBySlug(ctx context.Context, slug string) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	fosterChildren, err := s.repo.FindOneFosterChildren(ctx, map[string]interface{}{"slug": slug})
+	fosterChildren, err := s.repo.FindOneFosterChildren(ctx, map[string]interface{}{"slug": slug, "statuses": publicStatuses})
 	if err != nil {
 		return pkg.NewResponse(http.StatusNotFound, "Anak asuh tidak ditemukan", nil, nil)
 	}
 
+	fosterChildren.Achivements = publicAchievements(fosterChildren.Achivements)
 	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, fosterChildren.ToFosterChildrenDetailResponse())
 }
 
@@ -176,6 +236,21 @@ func (s *service) CreateFosterChildren(ctx context.Context, req CreateFosterChil
 	if req.SKTM == nil {
 		errValidation["sktm"] = "SKTM wajib diisi"
 	}
+	if req.Nik != "" {
+		registered, err := s.isNikRegistered(ctx, req.Nik, "")
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component": "foster_children.service",
+			}).WithError(err).Error("failed to check nik")
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal memeriksa NIK", nil, nil)
+		}
+		if registered {
+			errValidation["nik"] = "NIK sudah terdaftar"
+		}
+	}
+	if req.FamilyCardNumber != "" && !pkg.IsValidFamilyCardNumber(req.FamilyCardNumber) {
+		errValidation["familyCardNumber"] = "Nomor kartu keluarga harus 16 digit angka"
+	}
 
 	if len(errValidation) > 0 {
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
@@ -193,26 +268,31 @@ func (s *service) CreateFosterChildren(ctx context.Context, req CreateFosterChil
 	}
 
 	// Upload kartu keluarga
-	familyCardURL, err := s.s3Client.UploadFile(ctx, req.FamilyCard, "foster-children")
+	familyCardURL, err := s.privateStorage.UploadFile(ctx, req.FamilyCard, "foster-children")
 	if err != nil {
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah kartu keluarga", nil, nil)
 	}
 
 	// Upload SKTM
-	sktmURL, err := s.s3Client.UploadFile(ctx, req.SKTM, "foster-children")
+	sktmURL, err := s.privateStorage.UploadFile(ctx, req.SKTM, "foster-children")
 	if err != nil {
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah SKTM", nil, nil)
 	}
 
 	now := time.Now()
 	fosterChildrenID := uuid.New()
+	status := StatusEnrolled
+	if req.IsGraduated {
+		status = StatusGraduated
+	}
 	slug := fmt.Sprintf("%s-%s", pkg.Slugify(req.Name), fosterChildrenID.String()[:5])
 
 	fosterChildren := &FosterChildren{
 		ID:             fosterChildrenID,
 		Slug:           slug,
 		Name:           req.Name,
-		Nik:            req.Nik,
+		Nik:            pii.String(req.Nik),
+		NikIndex:       pii.BlindIndex(req.Nik),
 		ProfilePicture: profilePictureURL,
 		Gender:         req.Gender,
 		IsGraduated:    req.IsGraduated,
@@ -226,13 +306,19 @@ func (s *service) CreateFosterChildren(ctx context.Context, req CreateFosterChil
 		SKTM:           sktmURL,
 		CreatedAt:      now,
 		UpdatedAt:      now,
+
+		Status:              status,
+		StatusEffectiveDate: &now,
+
+		FamilyCardNumber:      pii.String(req.FamilyCardNumber),
+		FamilyCardNumberIndex: pii.BlindIndex(req.FamilyCardNumber),
 	}
 
 	// Upload piagam prestasi jika ada
 	if len(req.Achievements) > 0 {
 		var achievements []Achivement
 		for i, file := range req.Achievements {
-			achievementURL, err := s.s3Client.UploadFile(ctx, file, "foster-children/achievements")
+			achievementURL, err := s.s3Client.UploadFile(ctx, file, achievementFolder)
 			if err != nil {
 				logrus.WithError(err).Error("failed to upload achievement")
 				continue
@@ -250,6 +336,11 @@ func (s *service) CreateFosterChildren(ctx context.Context, req CreateFosterChil
 				Note:             note,
 				CreatedAt:        now,
 				UpdatedAt:        now,
+
+				Title:      note,
+				Visibility: VisibilityPublic,
+				Position:   len(achievements),
+				FileType:   CertificateImage,
 			})
 		}
 		fosterChildren.Achivements = achievements
@@ -263,11 +354,27 @@ func (s *service) CreateFosterChildren(ctx context.Context, req CreateFosterChil
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membuat data anak asuh", nil, nil)
 	}
 
+	// The first timeline entry; the child exists either way, so a failure is only logged.
+	if err := s.repo.CreateLifecycleEvent(ctx, &LifecycleEvent{
+		ID:               uuid.New(),
+		FosterChildrenID: fosterChildrenID,
+		Type:             EventStatusChange,
+		ToStatus:         status,
+		ToEducationLevel: req.EducationLevel,
+		ToSchoolName:     req.SchoolName,
+		EffectiveDate:    now,
+	}); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":          "foster_children.service",
+			"foster_children_id": fosterChildrenID.String(),
+		}).WithError(err).Error("failed to record initial lifecycle event")
+	}
+
 	s.logService.CreateLog(ctx, nil, "CREATE", "foster_children", fosterChildren.ID.String(), nil, fosterChildren.ToAdminFosterChildrenDetailResponse())
 	return pkg.NewResponse(http.StatusCreated, "Anak asuh berhasil dibuat", nil, nil)
 }
 
-func (s *service) UpdateFosterChildren(ctx context.Context, id string, req UpdateFosterChildrenRequest) pkg.Response {
+func (s *service) UpdateFosterChildren(ctx context.Context, id string, accountID string, req UpdateFosterChildrenRequest) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
@@ -275,7 +382,16 @@ func (s *service) UpdateFosterChildren(ctx context.Context, id string, req Updat
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID anak asuh tidak valid"}, nil)
 	}
 
-	existing, err := s.repo.FindOneFosterChildren(ctx, map[string]interface{}{"id": id})
+	options := map[string]interface{}{"id": id}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "foster_children.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data anak asuh", nil, nil)
+	}
+
+	existing, err := s.repo.FindOneFosterChildren(ctx, options)
 	if err != nil {
 		return pkg.NewResponse(http.StatusNotFound, "Anak asuh tidak ditemukan", nil, nil)
 	}
@@ -287,7 +403,26 @@ func (s *service) UpdateFosterChildren(ctx context.Context, id string, req Updat
 		updateData["name"] = req.Name
 	}
 	if req.Nik != "" {
-		updateData["nik"] = req.Nik
+		registered, err := s.isNikRegistered(ctx, req.Nik, existing.ID.String())
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":          "foster_children.service",
+				"foster_children_id": existing.ID,
+			}).WithError(err).Error("failed to check nik")
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal memeriksa NIK", nil, nil)
+		}
+		if registered {
+			errValidation["nik"] = "NIK sudah terdaftar"
+		}
+		updateData["nik"] = pii.String(req.Nik)
+		updateData["nik_index"] = pii.BlindIndex(req.Nik)
+	}
+	if req.FamilyCardNumber != "" {
+		if !pkg.IsValidFamilyCardNumber(req.FamilyCardNumber) {
+			errValidation["familyCardNumber"] = "Nomor kartu keluarga harus 16 digit angka"
+		}
+		updateData["family_card_number"] = pii.String(req.FamilyCardNumber)
+		updateData["family_card_number_index"] = pii.BlindIndex(req.FamilyCardNumber)
 	}
 	if req.Gender != "" {
 		if req.Gender != Male && req.Gender != Female {
@@ -297,7 +432,7 @@ func (s *service) UpdateFosterChildren(ctx context.Context, id string, req Updat
 		}
 	}
 	if req.IsGraduated != nil {
-		updateData["is_graduated"] = *req.IsGraduated
+		errValidation["isGraduated"] = "Status kelulusan diubah melalui perubahan status anak asuh"
 	}
 	if req.Category != "" {
 		if req.Category != CategoryFatherless && req.Category != CategoryMotherless && req.Category != CategoryOrphan {
@@ -350,11 +485,10 @@ func (s *service) UpdateFosterChildren(ctx context.Context, id string, req Updat
 
 	// Upload kartu keluarga
 	if req.FamilyCard != nil {
-		existingImage := s3_pkg.ExtractObjectNameFromURL(existing.FamilyCard)
-		if err := s.s3Client.DeleteFile(ctx, existingImage); err != nil {
+		if err := s.privateStorage.DeleteFile(ctx, existing.FamilyCard); err != nil {
 			logrus.WithError(err).Warn("failed to delete existing family card from S3")
 		}
-		familyCardURL, err := s.s3Client.UploadFile(ctx, req.FamilyCard, "foster-children")
+		familyCardURL, err := s.privateStorage.UploadFile(ctx, req.FamilyCard, "foster-children")
 		if err != nil {
 			return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah kartu keluarga", nil, nil)
 		}
@@ -363,102 +497,30 @@ func (s *service) UpdateFosterChildren(ctx context.Context, id string, req Updat
 
 	// Upload SKTM
 	if req.SKTM != nil {
-		existingImage := s3_pkg.ExtractObjectNameFromURL(existing.SKTM)
-		if err := s.s3Client.DeleteFile(ctx, existingImage); err != nil {
+		if err := s.privateStorage.DeleteFile(ctx, existing.SKTM); err != nil {
 			logrus.WithError(err).Warn("failed to delete existing SKTM from S3")
 		}
-		sktmURL, err := s.s3Client.UploadFile(ctx, req.SKTM, "foster-children")
+		sktmURL, err := s.privateStorage.UploadFile(ctx, req.SKTM, "foster-children")
 		if err != nil {
 			return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah SKTM", nil, nil)
 		}
 		updateData["sktm"] = sktmURL
 	}
 
-	// Handle achievements replacement
-	if len(req.UpdateAchivementNotes) > 0 && len(req.UpdateAchivementNotes) != len(req.AchivementIDs) {
-		errValidation["updateAchivementNotes"] = "Jumlah catatan prestasi yang diperbarui harus sama dengan jumlah ID prestasi"
-	}
-
-	if len(errValidation) > 0 {
-		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
-	}
-
-	// 1. Identify and Delete Missing Achievements
-	payloadAchivementIDMap := make(map[string]bool)
-	for _, aid := range req.AchivementIDs {
-		payloadAchivementIDMap[aid] = true
-	}
-
-	for _, ach := range existing.Achivements {
-		if !payloadAchivementIDMap[ach.ID.String()] {
-			objectName := s3_pkg.ExtractObjectNameFromURL(ach.URL)
-			if err := s.s3Client.DeleteFile(ctx, objectName); err != nil {
-				logrus.WithError(err).Warn("failed to delete existing achievement from S3")
-			}
-
-			if err := s.repo.DeleteAchievementByID(ctx, ach.ID.String()); err != nil {
-				logrus.WithError(err).Error("failed to delete existing achievement from DB")
-			}
-		}
-	}
-
-	// 2. Update Existing Achievements Metadata
-	for i, aid := range req.AchivementIDs {
-		updateAchivementData := make(map[string]interface{})
-		if i < len(req.UpdateAchivementNotes) {
-			updateAchivementData["note"] = req.UpdateAchivementNotes[i]
-		}
-
-		if len(updateAchivementData) > 0 {
-			if err := s.repo.UpdateAchievement(ctx, aid, updateAchivementData); err != nil {
-				logrus.WithFields(logrus.Fields{
-					"component":     "foster_children.service",
-					"achivement_id": aid,
-				}).WithError(err).Warn("failed to update achievement metadata")
-			}
-		}
-	}
-
-	// 3. Add New Achievements
-	if len(req.Achievements) > 0 {
-		var achievements []Achivement
-		now := time.Now()
-		for i, file := range req.Achievements {
-			achievementURL, err := s.s3Client.UploadFile(ctx, file, "foster-children/achievements")
-			if err != nil {
-				logrus.WithError(err).Error("failed to upload achievement")
-				continue
-			}
-
-			note := ""
-			if i < len(req.AchivementNotes) {
-				note = req.AchivementNotes[i]
-			}
-
-			achievements = append(achievements, Achivement{
-				ID:               uuid.New(),
-				FosterChildrenID: existing.ID,
-				URL:              achievementURL,
-				Note:             note,
-				CreatedAt:        now,
-				UpdatedAt:        now,
-			})
-		}
-		if len(achievements) > 0 {
-			if err := s.repo.CreateAchievements(ctx, achievements); err != nil {
-				logrus.WithError(err).Error("failed to create achievements")
-			}
-		}
-	}
-
-	if len(updateData) == 0 && len(req.Achievements) == 0 && len(req.AchivementIDs) == 0 && len(existing.Achivements) == 0 {
+	if len(updateData) == 0 {
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"updateData": "Tidak ada data untuk diperbarui"}, nil)
 	}
 
 	if len(updateData) > 0 {
 		updateData["updated_at"] = time.Now()
 
-		if err := s.repo.UpdateFosterChildren(ctx, id, updateData); err != nil {
+		// Corrections of school or level made here still show up on the timeline.
+		if event := educationChangeEvent(existing, updateData, accountID, time.Now(), ""); event != nil {
+			err = s.repo.UpdateFosterChildrenWithEvent(ctx, id, updateData, event)
+		} else {
+			err = s.repo.UpdateFosterChildren(ctx, id, updateData)
+		}
+		if err != nil {
 			logrus.WithFields(logrus.Fields{
 				"component":          "foster_children.service",
 				"foster_children_id": id,
@@ -467,11 +529,11 @@ func (s *service) UpdateFosterChildren(ctx context.Context, id string, req Updat
 		}
 	}
 
-	s.logService.CreateLog(ctx, nil, "UPDATE", "foster_children", id, existing.ToAdminFosterChildrenDetailResponse(), updateData)
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "foster_children", id, existing.ToAdminFosterChildrenDetailResponse(), updateData)
 	return pkg.NewResponse(http.StatusOK, "Anak asuh berhasil diperbarui", nil, nil)
 }
 
-func (s *service) DeleteFosterChildren(ctx context.Context, id string) pkg.Response {
+func (s *service) DeleteFosterChildren(ctx context.Context, id string, accountID string) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
@@ -479,17 +541,23 @@ func (s *service) DeleteFosterChildren(ctx context.Context, id string) pkg.Respo
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID anak asuh tidak valid"}, nil)
 	}
 
-	fosterChildren, err := s.repo.FindOneFosterChildren(ctx, map[string]interface{}{"id": id})
+	options := map[string]interface{}{"id": id}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "foster_children.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data anak asuh", nil, nil)
+	}
+
+	fosterChildren, err := s.repo.FindOneFosterChildren(ctx, options)
 	if err != nil {
 		return pkg.NewResponse(http.StatusNotFound, "Anak asuh tidak ditemukan", nil, nil)
 	}
 
 	// Delete achievements from S3
-	for _, ach := range fosterChildren.Achivements {
-		objectName := s3_pkg.ExtractObjectNameFromURL(ach.URL)
-		if err := s.s3Client.DeleteFile(ctx, objectName); err != nil {
-			logrus.WithError(err).Warn("failed to delete achievement from S3")
-		}
+	for i := range fosterChildren.Achivements {
+		s.deleteCertificate(ctx, &fosterChildren.Achivements[i])
 	}
 
 	// Delete achievements from DB
@@ -505,14 +573,12 @@ func (s *service) DeleteFosterChildren(ctx context.Context, id string) pkg.Respo
 		}
 	}
 	if fosterChildren.FamilyCard != "" {
-		objectName := s3_pkg.ExtractObjectNameFromURL(fosterChildren.FamilyCard)
-		if err := s.s3Client.DeleteFile(ctx, objectName); err != nil {
+		if err := s.privateStorage.DeleteFile(ctx, fosterChildren.FamilyCard); err != nil {
 			logrus.WithError(err).Warn("failed to delete family card from S3")
 		}
 	}
 	if fosterChildren.SKTM != "" {
-		objectName := s3_pkg.ExtractObjectNameFromURL(fosterChildren.SKTM)
-		if err := s.s3Client.DeleteFile(ctx, objectName); err != nil {
+		if err := s.privateStorage.DeleteFile(ctx, fosterChildren.SKTM); err != nil {
 			logrus.WithError(err).Warn("failed to delete SKTM from S3")
 		}
 	}
@@ -525,6 +591,699 @@ func (s *service) DeleteFosterChildren(ctx context.Context, id string) pkg.Respo
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menghapus data anak asuh", nil, nil)
 	}
 
-	s.logService.CreateLog(ctx, nil, "DELETE", "foster_children", id, fosterChildren.ToAdminFosterChildrenDetailResponse(), nil)
+	s.logService.CreateLog(ctx, &accountID, "DELETE", "foster_children", id, fosterChildren.ToAdminFosterChildrenDetailResponse(), nil)
 	return pkg.NewResponse(http.StatusOK, "Anak asuh berhasil dihapus", nil, nil)
 }
+
+// GetFosterChildrenDocument hands out a short-lived link to the family card or SKTM. Access
+// grants narrow which children a manager may open, as for the detail endpoint.
+func (s *service) GetFosterChildrenDocument(ctx context.Context, id string, accountID string, document string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := uuid.Validate(id); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID anak asuh tidak valid"}, nil)
+	}
+
+	options := map[string]interface{}{"id": id}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "foster_children.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data anak asuh", nil, nil)
+	}
+
+	fosterChildren, err := s.repo.FindOneFosterChildren(ctx, options)
+	if err != nil {
+		return pkg.NewResponse(http.StatusNotFound, "Anak asuh tidak ditemukan", nil, nil)
+	}
+
+	var value string
+	switch document {
+	case DocumentFamilyCard:
+		value = fosterChildren.FamilyCard
+	case DocumentSKTM:
+		value = fosterChildren.SKTM
+	default:
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"document": "Jenis dokumen tidak valid"}, nil)
+	}
+	if value == "" {
+		return pkg.NewResponse(http.StatusNotFound, "Dokumen tidak ditemukan", nil, nil)
+	}
+
+	link, err := s3_pkg.SignDocument(ctx, s.privateStorage, value)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":          "foster_children.service",
+			"foster_children_id": id,
+		}).WithError(err).Error("failed to sign document url")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membuat tautan dokumen", nil, nil)
+	}
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, link)
+}
+
+// isNikRegistered looks the NIK up through its blind index, so the encrypted column never has to
+// be decrypted. excludeID skips the foster child being updated.
+func (s *service) isNikRegistered(ctx context.Context, nik, excludeID string) (bool, error) {
+	existing, err := s.repo.FindOneFosterChildren(ctx, map[string]interface{}{"nik_index": pii.BlindIndex(nik)})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return existing.ID.String() != excludeID, nil
+}
+
+// educationChangeEvent returns a timeline event when updateData changes the school or education
+// level, nil otherwise.
+func educationChangeEvent(existing *FosterChildren, updateData map[string]interface{}, accountID string, effectiveDate time.Time, note string) *LifecycleEvent {
+	level, levelChanged := updateData["education_level"].(int)
+	school, schoolChanged := updateData["school_name"].(string)
+	levelChanged = levelChanged && level != existing.EducationLevel
+	schoolChanged = schoolChanged && school != existing.SchoolName
+	if !levelChanged && !schoolChanged {
+		return nil
+	}
+	if !levelChanged {
+		level = existing.EducationLevel
+	}
+	if !schoolChanged {
+		school = existing.SchoolName
+	}
+
+	event := &LifecycleEvent{
+		ID:                 uuid.New(),
+		FosterChildrenID:   existing.ID,
+		Type:               EventEducationChange,
+		FromEducationLevel: existing.EducationLevel,
+		ToEducationLevel:   level,
+		FromSchoolName:     existing.SchoolName,
+		ToSchoolName:       school,
+		Reason:             note,
+		EffectiveDate:      effectiveDate,
+	}
+	if accountID != "" {
+		createdBy := uuid.MustParse(accountID)
+		event.CreatedBy = &createdBy
+	}
+	return event
+}
+
+// parseEffectiveDate defaults to today and rejects future dates; transitions are recorded once
+// they have happened.
+func parseEffectiveDate(value string) (time.Time, string) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if value == "" {
+		return today, ""
+	}
+	date, err := time.ParseInLocation("2006-01-02", value, now.Location())
+	if err != nil {
+		return time.Time{}, "Format tanggal tidak valid, diharapkan YYYY-MM-DD"
+	}
+	if date.After(today) {
+		return time.Time{}, "Tanggal berlaku tidak boleh di masa depan"
+	}
+	return date, ""
+}
+
+func (s *service) findScopedFosterChildren(ctx context.Context, id string, accountID string) (*FosterChildren, *pkg.Response) {
+	if err := uuid.Validate(id); err != nil {
+		res := pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID anak asuh tidak valid"}, nil)
+		return nil, &res
+	}
+
+	options := map[string]interface{}{"id": id}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "foster_children.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		res := pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data anak asuh", nil, nil)
+		return nil, &res
+	}
+
+	fosterChildren, err := s.repo.FindOneFosterChildren(ctx, options)
+	if err != nil {
+		res := pkg.NewResponse(http.StatusNotFound, "Anak asuh tidak ditemukan", nil, nil)
+		return nil, &res
+	}
+	return fosterChildren, nil
+}
+
+// ChangeFosterChildrenStatus applies a lifecycle transition. Leaving the programme closes the
+// child's sponsorships and, through the status, the public page and donations.
+func (s *service) ChangeFosterChildrenStatus(ctx context.Context, id string, accountID string, req ChangeStatusRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	existing, res := s.findScopedFosterChildren(ctx, id, accountID)
+	if res != nil {
+		return *res
+	}
+
+	errValidation := make(map[string]string)
+	req.Reason = strings.TrimSpace(req.Reason)
+	req.Destination = strings.TrimSpace(req.Destination)
+	if req.Status == "" {
+		errValidation["status"] = "Status wajib diisi"
+	} else if !req.Status.IsValid() {
+		errValidation["status"] = "Status tidak valid"
+	} else if !existing.Status.CanTransitionTo(req.Status) {
+		errValidation["status"] = fmt.Sprintf("Status tidak dapat diubah dari %s ke %s", existing.Status, req.Status)
+	}
+	if (req.Status == StatusTransferred || req.Status == StatusExited) && req.Reason == "" {
+		errValidation["reason"] = "Alasan wajib diisi"
+	}
+	if req.Status == StatusTransferred && req.Destination == "" {
+		errValidation["destination"] = "Tujuan pindah wajib diisi"
+	}
+	effectiveDate, msg := parseEffectiveDate(req.EffectiveDate)
+	if msg != "" {
+		errValidation["effectiveDate"] = msg
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	createdBy := uuid.MustParse(accountID)
+	event := &LifecycleEvent{
+		ID:                 uuid.New(),
+		FosterChildrenID:   existing.ID,
+		Type:               EventStatusChange,
+		FromStatus:         existing.Status,
+		ToStatus:           req.Status,
+		FromEducationLevel: existing.EducationLevel,
+		ToEducationLevel:   existing.EducationLevel,
+		FromSchoolName:     existing.SchoolName,
+		ToSchoolName:       existing.SchoolName,
+		Reason:             req.Reason,
+		Destination:        req.Destination,
+		EffectiveDate:      effectiveDate,
+		CreatedBy:          &createdBy,
+	}
+	updateData := map[string]interface{}{
+		"status":                req.Status,
+		"status_reason":         req.Reason,
+		"status_effective_date": effectiveDate,
+		"is_graduated":          req.Status == StatusGraduated,
+		"updated_at":            time.Now(),
+	}
+
+	if err := s.repo.TransitionStatus(ctx, id, existing.Status, updateData, event, req.Status != StatusEnrolled); err != nil {
+		if errors.Is(err, ErrStatusChanged) {
+			return pkg.NewResponse(http.StatusConflict, "Status anak asuh telah diubah, muat ulang data", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":          "foster_children.service",
+			"foster_children_id": id,
+		}).WithError(err).Error("failed to change foster children status")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengubah status anak asuh", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "foster_children", id, map[string]interface{}{"status": existing.Status}, updateData)
+	return pkg.NewResponse(http.StatusOK, "Status anak asuh berhasil diubah", nil, event.toLifecycleEventResponse())
+}
+
+// PromoteFosterChildren records a move to another education level or school, e.g. at the start
+// of a school year.
+func (s *service) PromoteFosterChildren(ctx context.Context, id string, accountID string, req PromoteEducationRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	existing, res := s.findScopedFosterChildren(ctx, id, accountID)
+	if res != nil {
+		return *res
+	}
+	if !existing.IsEnrolled() {
+		return pkg.NewResponse(http.StatusBadRequest, "Hanya anak asuh yang masih aktif yang dapat naik jenjang", nil, nil)
+	}
+
+	errValidation := make(map[string]string)
+	if req.EducationLevel < 1 || req.EducationLevel > 12 {
+		errValidation["educationLevel"] = "Tingkat pendidikan tidak valid (maksimal kelas 12)"
+	}
+	effectiveDate, msg := parseEffectiveDate(req.EffectiveDate)
+	if msg != "" {
+		errValidation["effectiveDate"] = msg
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	updateData := map[string]interface{}{"education_level": req.EducationLevel}
+	if school := strings.TrimSpace(req.SchoolName); school != "" {
+		updateData["school_name"] = school
+	}
+	event := educationChangeEvent(existing, updateData, accountID, effectiveDate, strings.TrimSpace(req.Note))
+	if event == nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"educationLevel": "Jenjang dan sekolah sama dengan data saat ini"}, nil)
+	}
+	updateData["updated_at"] = time.Now()
+
+	if err := s.repo.UpdateFosterChildrenWithEvent(ctx, id, updateData, event); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":          "foster_children.service",
+			"foster_children_id": id,
+		}).WithError(err).Error("failed to promote foster children")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memperbarui jenjang pendidikan", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "foster_children", id, map[string]interface{}{"education_level": existing.EducationLevel, "school_name": existing.SchoolName}, updateData)
+	return pkg.NewResponse(http.StatusOK, "Jenjang pendidikan berhasil diperbarui", nil, event.toLifecycleEventResponse())
+}
+
+func (s *service) GetFosterChildrenTimeline(ctx context.Context, id string, accountID string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	existing, res := s.findScopedFosterChildren(ctx, id, accountID)
+	if res != nil {
+		return *res
+	}
+
+	events, err := s.repo.FindLifecycleEvents(ctx, id)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":          "foster_children.service",
+			"foster_children_id": id,
+		}).WithError(err).Error("failed to fetch lifecycle events")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil riwayat anak asuh", nil, nil)
+	}
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, existing.toTimelineResponse(events))
+}
+
+func (s *service) listAchievements(ctx context.Context, options map[string]interface{}, params AchievementQueryParams) pkg.Response {
+	if params.Category != "" && !params.Category.IsValid() {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"category": "Kategori prestasi tidak valid"}, nil)
+	}
+	if params.Level != "" && !params.Level.IsValid() {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"level": "Tingkat prestasi tidak valid"}, nil)
+	}
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+
+	options["limit"] = params.Limit
+	options["page"] = params.Page
+	if params.Category != "" {
+		options["category"] = string(params.Category)
+	}
+	if params.Level != "" {
+		options["level"] = string(params.Level)
+	}
+
+	total, err := s.repo.CountAchievements(ctx, options)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children.service",
+			"options":   options,
+		}).WithError(err).Error("failed to count achievements")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data prestasi", nil, nil)
+	}
+
+	achievements, err := s.repo.FindAllAchievements(ctx, options)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children.service",
+			"options":   options,
+		}).WithError(err).Error("failed to fetch achievements")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data prestasi", nil, nil)
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit != 0 {
+		totalPages++
+	}
+
+	pagination := pkg.OffsetPagination{
+		Page:       params.Page,
+		Limit:      params.Limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, toAchievementListResponse(ctx, s.privateStorage, achievements, pagination))
+}
+
+// GetPublicAchievementList is the achievements feed of the slug page. Only public achievements
+// of children shown publicly are listed.
+func (s *service) GetPublicAchievementList(ctx context.Context, slug string, params AchievementQueryParams) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if _, err := s.repo.FindOneFosterChildren(ctx, map[string]interface{}{"slug": slug, "statuses": publicStatuses}); err != nil {
+		return pkg.NewResponse(http.StatusNotFound, "Anak asuh tidak ditemukan", nil, nil)
+	}
+
+	options := map[string]interface{}{
+		"foster_children_slug": slug,
+		"visibility":           string(VisibilityPublic),
+	}
+	return s.listAchievements(ctx, options, params)
+}
+
+func (s *service) GetAchievementList(ctx context.Context, id string, accountID string, params AchievementQueryParams) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if params.Visibility != "" && params.Visibility != VisibilityPublic && params.Visibility != VisibilityPrivate {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"visibility": "Visibilitas harus public atau private"}, nil)
+	}
+	if _, res := s.findScopedFosterChildren(ctx, id, accountID); res != nil {
+		return *res
+	}
+
+	options := map[string]interface{}{"foster_children_id": id}
+	if params.Visibility != "" {
+		options["visibility"] = string(params.Visibility)
+	}
+	return s.listAchievements(ctx, options, params)
+}
+
+// findScopedAchievement loads an achievement of a child the account may manage.
+func (s *service) findScopedAchievement(ctx context.Context, id string, achievementID string, accountID string) (*Achivement, *pkg.Response) {
+	if _, res := s.findScopedFosterChildren(ctx, id, accountID); res != nil {
+		return nil, res
+	}
+	if err := uuid.Validate(achievementID); err != nil {
+		res := pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"achievementId": "Format ID prestasi tidak valid"}, nil)
+		return nil, &res
+	}
+	achievement, err := s.repo.FindOneAchievement(ctx, map[string]interface{}{"id": achievementID, "foster_children_id": id})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := pkg.NewResponse(http.StatusNotFound, "Prestasi tidak ditemukan", nil, nil)
+			return nil, &res
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":      "foster_children.service",
+			"achievement_id": achievementID,
+		}).WithError(err).Error("failed to fetch achievement")
+		res := pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data prestasi", nil, nil)
+		return nil, &res
+	}
+	return achievement, nil
+}
+
+// uploadCertificate stores a public certificate with the rest of the slug page: images are
+// compressed, PDFs are kept as they are. Private certificates go to the private bucket unmodified.
+func (s *service) uploadCertificate(ctx context.Context, file *multipart.FileHeader, fileType CertificateFileType, visibility AchievementVisibility) (string, error) {
+	if visibility == VisibilityPrivate {
+		return s.privateStorage.UploadFile(ctx, file, achievementFolder)
+	}
+	if fileType == CertificatePDF {
+		return s.s3Client.UploadFileOriginal(ctx, file, achievementFolder)
+	}
+	return s.s3Client.UploadFile(ctx, file, achievementFolder)
+}
+
+func (s *service) deleteCertificate(ctx context.Context, achievement *Achivement) {
+	if achievement.URL == "" {
+		return
+	}
+	var err error
+	if s3_pkg.IsPrivateRef(achievement.URL) {
+		err = s.privateStorage.DeleteFile(ctx, achievement.URL)
+	} else {
+		err = s.s3Client.DeleteFile(ctx, s3_pkg.ExtractObjectNameFromURL(achievement.URL))
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":      "foster_children.service",
+			"achievement_id": achievement.ID.String(),
+		}).WithError(err).Warn("failed to delete achievement certificate from S3")
+	}
+}
+
+// moveCertificate moves a stored certificate into the private or the public bucket and returns its
+// new value. A certificate already in place is returned unchanged.
+func (s *service) moveCertificate(ctx context.Context, url string, private bool) (string, error) {
+	if private {
+		return s.privateStorage.MigrateFromPublic(ctx, url)
+	}
+	return s.privateStorage.MoveToPublic(ctx, url)
+}
+
+func (s *service) CreateAchievement(ctx context.Context, id string, accountID string, req CreateAchievementRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	existing, res := s.findScopedFosterChildren(ctx, id, accountID)
+	if res != nil {
+		return *res
+	}
+
+	errValidation := make(map[string]string)
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" {
+		errValidation["title"] = "Judul prestasi wajib diisi"
+	}
+	if !req.Category.IsValid() {
+		errValidation["category"] = "Kategori prestasi tidak valid"
+	}
+	if !req.Level.IsValid() {
+		errValidation["level"] = "Tingkat prestasi tidak valid"
+	}
+	achievedAt, err := time.Parse("2006-01-02", req.AchievedAt)
+	if err != nil {
+		errValidation["achievedAt"] = "Format tanggal tidak valid, diharapkan YYYY-MM-DD"
+	} else if achievedAt.After(time.Now()) {
+		errValidation["achievedAt"] = "Tanggal prestasi tidak boleh di masa depan"
+	}
+	var fileType CertificateFileType
+	if req.Certificate == nil {
+		errValidation["certificate"] = "Sertifikat wajib diunggah"
+	} else if t, msg := certificateFileType(req.Certificate); msg != "" {
+		errValidation["certificate"] = msg
+	} else {
+		fileType = t
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	visibility := visibilityOf(req.IsPublic == nil || *req.IsPublic)
+	certificateURL, err := s.uploadCertificate(ctx, req.Certificate, fileType, visibility)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":          "foster_children.service",
+			"foster_children_id": id,
+		}).WithError(err).Error("failed to upload achievement certificate")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah sertifikat", nil, nil)
+	}
+
+	now := time.Now()
+	achievement := &Achivement{
+		ID:               uuid.New(),
+		FosterChildrenID: existing.ID,
+		URL:              certificateURL,
+		Note:             strings.TrimSpace(req.Note),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+
+		Title:      req.Title,
+		Category:   req.Category,
+		Level:      req.Level,
+		AchievedAt: &achievedAt,
+		Visibility: visibility,
+		FileType:   fileType,
+	}
+	if err := s.repo.CreateAchievement(ctx, achievement); err != nil {
+		s.deleteCertificate(ctx, achievement)
+		logrus.WithFields(logrus.Fields{
+			"component":          "foster_children.service",
+			"foster_children_id": id,
+		}).WithError(err).Error("failed to create achievement")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menyimpan prestasi", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "CREATE", "foster_children_achievement", achievement.ID.String(), nil, achievement.toAchievementResponse())
+	return pkg.NewResponse(http.StatusCreated, "Prestasi berhasil ditambahkan", nil, achievement.toSignedAchievementResponse(ctx, s.privateStorage))
+}
+
+func (s *service) UpdateAchievement(ctx context.Context, id string, achievementID string, accountID string, req UpdateAchievementRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	achievement, res := s.findScopedAchievement(ctx, id, achievementID, accountID)
+	if res != nil {
+		return *res
+	}
+
+	errValidation := make(map[string]string)
+	updateData := make(map[string]interface{})
+	if title := strings.TrimSpace(req.Title); title != "" {
+		updateData["title"] = title
+	}
+	if req.Category != "" {
+		if !req.Category.IsValid() {
+			errValidation["category"] = "Kategori prestasi tidak valid"
+		}
+		updateData["category"] = req.Category
+	}
+	if req.Level != "" {
+		if !req.Level.IsValid() {
+			errValidation["level"] = "Tingkat prestasi tidak valid"
+		}
+		updateData["level"] = req.Level
+	}
+	if req.AchievedAt != "" {
+		achievedAt, err := time.Parse("2006-01-02", req.AchievedAt)
+		if err != nil {
+			errValidation["achievedAt"] = "Format tanggal tidak valid, diharapkan YYYY-MM-DD"
+		} else if achievedAt.After(time.Now()) {
+			errValidation["achievedAt"] = "Tanggal prestasi tidak boleh di masa depan"
+		}
+		updateData["achieved_at"] = achievedAt
+	}
+	if req.Note != nil {
+		updateData["note"] = strings.TrimSpace(*req.Note)
+	}
+	visibility := achievement.Visibility
+	if req.IsPublic != nil {
+		visibility = visibilityOf(*req.IsPublic)
+		updateData["visibility"] = visibility
+	}
+	var fileType CertificateFileType
+	if req.Certificate != nil {
+		t, msg := certificateFileType(req.Certificate)
+		if msg != "" {
+			errValidation["certificate"] = msg
+		}
+		fileType = t
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+	if len(updateData) == 0 && req.Certificate == nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"updateData": "Tidak ada data untuk diperbarui"}, nil)
+	}
+
+	// The certificate follows the visibility: a new one is uploaded into the matching bucket and
+	// an existing one is moved when it sits in the other bucket.
+	wasPrivate := s3_pkg.IsPrivateRef(achievement.URL)
+	moved := false
+	if req.Certificate != nil {
+		certificateURL, err := s.uploadCertificate(ctx, req.Certificate, fileType, visibility)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":      "foster_children.service",
+				"achievement_id": achievementID,
+			}).WithError(err).Error("failed to upload achievement certificate")
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah sertifikat", nil, nil)
+		}
+		updateData["url"] = certificateURL
+		updateData["file_type"] = fileType
+	} else if (visibility == VisibilityPrivate) != wasPrivate {
+		certificateURL, err := s.moveCertificate(ctx, achievement.URL, visibility == VisibilityPrivate)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":      "foster_children.service",
+				"achievement_id": achievementID,
+			}).WithError(err).Error("failed to move achievement certificate")
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal memindahkan sertifikat", nil, nil)
+		}
+		updateData["url"] = certificateURL
+		moved = true
+	}
+	updateData["updated_at"] = time.Now()
+
+	if err := s.repo.UpdateAchievement(ctx, achievementID, updateData); err != nil {
+		if url, ok := updateData["url"].(string); ok {
+			if moved {
+				if _, err := s.moveCertificate(ctx, url, wasPrivate); err != nil {
+					logrus.WithFields(logrus.Fields{
+						"component":      "foster_children.service",
+						"achievement_id": achievementID,
+					}).WithError(err).Error("failed to move achievement certificate back")
+				}
+			} else {
+				s.deleteCertificate(ctx, &Achivement{ID: achievement.ID, URL: url})
+			}
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":      "foster_children.service",
+			"achievement_id": achievementID,
+		}).WithError(err).Error("failed to update achievement")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memperbarui prestasi", nil, nil)
+	}
+	if req.Certificate != nil {
+		s.deleteCertificate(ctx, achievement)
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "foster_children_achievement", achievementID, achievement.toAchievementResponse(), updateData)
+
+	updated, res := s.findScopedAchievement(ctx, id, achievementID, accountID)
+	if res != nil {
+		return *res
+	}
+	return pkg.NewResponse(http.StatusOK, "Prestasi berhasil diperbarui", nil, updated.toSignedAchievementResponse(ctx, s.privateStorage))
+}
+
+func (s *service) DeleteAchievement(ctx context.Context, id string, achievementID string, accountID string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	achievement, res := s.findScopedAchievement(ctx, id, achievementID, accountID)
+	if res != nil {
+		return *res
+	}
+
+	if err := s.repo.DeleteAchievementByID(ctx, achievementID); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":      "foster_children.service",
+			"achievement_id": achievementID,
+		}).WithError(err).Error("failed to delete achievement")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menghapus prestasi", nil, nil)
+	}
+	s.deleteCertificate(ctx, achievement)
+
+	s.logService.CreateLog(ctx, &accountID, "DELETE", "foster_children_achievement", achievementID, achievement.toAchievementResponse(), nil)
+	return pkg.NewResponse(http.StatusOK, "Prestasi berhasil dihapus", nil, nil)
+}
+
+// ReorderAchievements sets the display order. The list must hold every achievement of the child
+// exactly once so no position is left stale.
+func (s *service) ReorderAchievements(ctx context.Context, id string, accountID string, req ReorderAchievementsRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	existing, res := s.findScopedFosterChildren(ctx, id, accountID)
+	if res != nil {
+		return *res
+	}
+
+	current := make(map[string]bool, len(existing.Achivements))
+	for _, a := range existing.Achivements {
+		current[a.ID.String()] = true
+	}
+	seen := make(map[string]bool, len(req.IDs))
+	for _, achievementID := range req.IDs {
+		if !current[achievementID] || seen[achievementID] {
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"ids": "Daftar prestasi tidak sesuai dengan prestasi anak asuh"}, nil)
+		}
+		seen[achievementID] = true
+	}
+	if len(seen) != len(current) {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"ids": "Semua prestasi anak asuh harus disertakan"}, nil)
+	}
+
+	if err := s.repo.ReorderAchievements(ctx, id, req.IDs); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":          "foster_children.service",
+			"foster_children_id": id,
+		}).WithError(err).Error("failed to reorder achievements")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengurutkan prestasi", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "foster_children", id, nil, map[string]interface{}{"achievement_order": req.IDs})
+	return pkg.NewResponse(http.StatusOK, "Urutan prestasi berhasil diperbarui", nil, nil)
+}
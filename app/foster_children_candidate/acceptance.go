@@ -0,0 +1,87 @@
+package foster_children_candidate
+
+import (
+	"context"
+
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// fosterChildrenFolder is where the foster children service keeps its uploads. The child gets
+// its own copies of the candidate's files, so neither record depends on the other's objects.
+const fosterChildrenFolder = "foster-children"
+
+// FosterChildrenDocuments are the copies of a candidate's files made for its foster child.
+type FosterChildrenDocuments struct {
+	ProfilePicture string
+	FamilyCard     string
+	SKTM           string
+}
+
+// CopyDocumentsForFosterChildren copies the candidate's profile picture, family card and SKTM
+// to the foster children folder. On failure the copies made so far are removed again.
+func CopyDocumentsForFosterChildren(ctx context.Context, s3Client s3_pkg.Client, privateStorage s3_pkg.PrivateClient, c *FosterChildrenCandidate) (FosterChildrenDocuments, error) {
+	var docs FosterChildrenDocuments
+	var err error
+	if docs.ProfilePicture, err = s3Client.CopyFile(ctx, c.ProfilePicture, fosterChildrenFolder); err != nil {
+		return FosterChildrenDocuments{}, err
+	}
+	if docs.FamilyCard, err = privateStorage.CopyFile(ctx, c.FamilyCard, fosterChildrenFolder); err != nil {
+		docs.Delete(ctx, s3Client, privateStorage)
+		return FosterChildrenDocuments{}, err
+	}
+	if docs.SKTM, err = privateStorage.CopyFile(ctx, c.SKTM, fosterChildrenFolder); err != nil {
+		docs.Delete(ctx, s3Client, privateStorage)
+		return FosterChildrenDocuments{}, err
+	}
+	return docs, nil
+}
+
+// Delete removes the copies, used when the acceptance they were made for is rolled back.
+func (d FosterChildrenDocuments) Delete(ctx context.Context, s3Client s3_pkg.Client, privateStorage s3_pkg.PrivateClient) {
+	var errs []error
+	if d.ProfilePicture != "" {
+		errs = append(errs, s3Client.DeleteFile(ctx, d.ProfilePicture))
+	}
+	for _, ref := range []string{d.FamilyCard, d.SKTM} {
+		if ref != "" {
+			errs = append(errs, privateStorage.DeleteFile(ctx, ref))
+		}
+	}
+	for _, err := range errs {
+		if err != nil {
+			logrus.WithField("component", "foster_children_candidate.acceptance").WithError(err).Error("failed to delete copied candidate document")
+		}
+	}
+}
+
+// CreateFosterChildrenInTx creates the foster child for the candidate and links the two inside
+// tx. The caller copies the documents beforehand and deletes them if tx rolls back.
+func CreateFosterChildrenInTx(ctx context.Context, tx *gorm.DB, creator FosterChildrenCreator, c *FosterChildrenCandidate, fosterChildrenID uuid.UUID, docs FosterChildrenDocuments) error {
+	if err := creator.CreateFosterChildrenFromCandidate(
+		ctx,
+		tx,
+		fosterChildrenID.String(),
+		c.ID.String(),
+		c.Name,
+		string(c.Nik),
+		string(c.FamilyCardNumber),
+		docs.ProfilePicture,
+		docs.FamilyCard,
+		docs.SKTM,
+		c.BirthPlace,
+		c.SchoolName,
+		c.Address,
+		string(c.Gender),
+		string(c.Category),
+		c.EducationLevel,
+		c.BirthDate,
+	); err != nil {
+		return err
+	}
+	return tx.WithContext(ctx).Model(&FosterChildrenCandidate{}).
+		Where("id = ?", c.ID).
+		Update("foster_children_id", fosterChildrenID).Error
+}
@@ -4,32 +4,41 @@ import (
 	"time"
 
 	"github.com/Vilamuzz/yota-backend/app/account"
+	"github.com/Vilamuzz/yota-backend/pkg/pii"
 	"github.com/google/uuid"
 )
 
 type FosterChildrenCandidate struct {
-	ID               uuid.UUID `json:"id" gorm:"primaryKey"`
-	Name             string    `json:"name" gorm:"not null"`
-	Nik              string    `json:"nik"`
-	ProfilePicture   string    `json:"profilePicture" gorm:"not null"`
-	Gender           Gender    `json:"gender" gorm:"not null"`
-	Category         Category  `json:"category"`
-	BirthDate        time.Time `json:"birthDate"`
-	BirthPlace       string    `json:"birthPlace"`
-	SchoolName       string    `json:"schoolName"`
-	EducationLevel   int       `json:"educationLevel"`
-	Address          string    `json:"address"`
-	FamilyCard       string    `json:"familyCard" gorm:"not null"`
-	SKTM             string    `json:"sktm" gorm:"not null"`
-	SubmitterName    string    `json:"submitterName"`
-	SubmitterPhone   string    `json:"submitterPhone"`
-	SubmitterAddress string    `json:"submitterAddress"`
-	SubmitterIDCard  string    `json:"submitterIdCard"`
-	SubmittedBy      uuid.UUID `json:"submittedBy" gorm:"not null"`
-	Status           Status    `json:"status"`
-	RejectionReason  string    `json:"rejectionReason"`
-	CreatedAt        time.Time `json:"createdAt"`
-	UpdatedAt        time.Time `json:"updatedAt"`
+	ID               uuid.UUID  `json:"id" gorm:"primaryKey"`
+	Name             string     `json:"name" gorm:"not null"`
+	Nik              pii.String `json:"nik"`
+	NikIndex         string     `json:"-" gorm:"index"` // blind index of Nik for exact-match lookups
+	ProfilePicture   string     `json:"profilePicture" gorm:"not null"`
+	Gender           Gender     `json:"gender" gorm:"not null"`
+	Category         Category   `json:"category"`
+	BirthDate        time.Time  `json:"birthDate"`
+	BirthPlace       string     `json:"birthPlace"`
+	SchoolName       string     `json:"schoolName"`
+	EducationLevel   int        `json:"educationLevel"`
+	Address          string     `json:"address"`
+	FamilyCard       string     `json:"familyCard" gorm:"not null"`
+	SKTM             string     `json:"sktm" gorm:"not null"`
+	SubmitterName    string     `json:"submitterName"`
+	SubmitterPhone   pii.String `json:"submitterPhone"`
+	SubmitterAddress string     `json:"submitterAddress"`
+	SubmitterIDCard  string     `json:"submitterIdCard"`
+	SubmittedBy      uuid.UUID  `json:"submittedBy" gorm:"not null"`
+	Status           Status     `json:"status"`
+	RejectionReason  string     `json:"rejectionReason"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
+
+	// FamilyCardNumber is the number on the kartu keluarga; siblings share it.
+	FamilyCardNumber      pii.String `json:"familyCardNumber"`
+	FamilyCardNumberIndex string     `json:"-" gorm:"index"` // blind index of FamilyCardNumber
+
+	// FosterChildrenID is the foster child created when the candidate was accepted.
+	FosterChildrenID *uuid.UUID `json:"fosterChildrenId" gorm:"index"`
 
 	Account account.Account `gorm:"foreignKey:SubmittedBy;references:ID"`
 }
@@ -53,8 +62,38 @@ type Status string
 
 const (
 	StatusPending               Status = "pending"
-	StatusSocialManagerAccepted Status = "social_manager_accepted"
+	StatusSocialManagerAccepted Status = "social_manager_accepted" // legacy two-step approval
+	StatusInReview              Status = "in_review"               // passed at least one review stage
 	StatusAccepted              Status = "accepted"
 	StatusRejected              Status = "rejected"
 	StatusCancelled             Status = "cancelled"
 )
+
+// IsUnderReview reports whether the candidate has not been decided yet.
+func (c *FosterChildrenCandidate) IsUnderReview() bool {
+	switch c.Status {
+	case StatusPending, StatusSocialManagerAccepted, StatusInReview:
+		return true
+	}
+	return false
+}
+
+// Sensitive uploads kept in private storage, addressed by their JSON field names.
+const (
+	DocumentFamilyCard      = "familyCard"
+	DocumentSKTM            = "sktm"
+	DocumentSubmitterIDCard = "submitterIdCard"
+)
+
+// Document returns the stored value of a sensitive upload and whether the name is known.
+func (c *FosterChildrenCandidate) Document(name string) (string, bool) {
+	switch name {
+	case DocumentFamilyCard:
+		return c.FamilyCard, true
+	case DocumentSKTM:
+		return c.SKTM, true
+	case DocumentSubmitterIDCard:
+		return c.SubmitterIDCard, true
+	}
+	return "", false
+}
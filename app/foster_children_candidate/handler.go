@@ -31,6 +31,8 @@ func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
 		user.GET("", h.GetMyFosterChildrenCandidateList)
 		user.GET("/:id", h.GetMyFosterChildrenCandidateByID)
 		user.DELETE("/:id", h.CancelFosterChildrenCandidate)
+		user.GET("/:id/documents/:document", h.GetCandidateDocument)
+		user.GET("/:id/review", h.GetMyCandidateReviewSummary)
 	}
 
 	admin := r.Group("/admin/foster-children/candidates")
@@ -40,6 +42,16 @@ func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
 		admin.GET("/:id", h.GetFosterChildrenCandidateByID)
 		admin.PATCH("/:id/accept", h.AcceptFosterChildrenCandidate)
 		admin.PATCH("/:id/reject", h.RejectFosterChildrenCandidate)
+		admin.GET("/:id/documents/:document", h.GetCandidateDocument)
+
+		admin.GET("/pipeline", h.GetReviewPipeline)
+		admin.PUT("/pipeline", h.middleware.RequireRoles(enum.RoleChairman), h.UpdateReviewPipeline)
+		admin.GET("/:id/reviews", h.GetCandidateReviewTrail)
+		admin.PATCH("/:id/reviews/:reviewId/assign", h.AssignReview)
+		admin.PATCH("/:id/reviews/:reviewId/checklist", h.UpdateReviewChecklist)
+		admin.POST("/:id/reviews/:reviewId/photos", h.AddReviewPhotos)
+		admin.POST("/:id/reviews/:reviewId/votes", h.CastReviewVote)
+		admin.PATCH("/:id/reviews/:reviewId/complete", h.CompleteReview)
 	}
 }
 
@@ -164,32 +176,34 @@ func (h *handler) GetFosterChildrenCandidateList(c *gin.Context) {
 		return
 	}
 
-	res := h.service.GetFosterChildrenCandidateList(ctx, queryParams)
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.GetFosterChildrenCandidateList(ctx, queryParams, claims.AccountID)
 	c.JSON(res.Status, res)
 }
 
 // GetFosterChildrenCandidateByID
 //
 // @Summary Get Foster Children Candidate By ID
-// @Description Get a specific foster children candidate
+// @Description Get a specific foster children candidate. While the candidate is under review the response includes screening warnings: possible duplicates by NIK, family card number or name and birth date, and eligibility rules by category and education level
 // @Tags Foster Children Candidates
 // @Security BearerAuth
 // @Produce json
 // @Param id path string true "Candidate ID"
-// @Success 200 {object} pkg.Response
+// @Success 200 {object} pkg.Response{data=FosterChildrenCandidateDetailResponse}
 // @Router /api/admin/foster-children/candidates/{id} [get]
 func (h *handler) GetFosterChildrenCandidateByID(c *gin.Context) {
 	ctx := c.Request.Context()
 	id := c.Param("id")
 
-	res := h.service.GetFosterChildrenCandidateByID(ctx, id)
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.GetFosterChildrenCandidateByID(ctx, id, claims.AccountID)
 	c.JSON(res.Status, res)
 }
 
 // AcceptFosterChildrenCandidate
 //
 // @Summary Accept Foster Children Candidate
-// @Description Accept a foster children candidate. This is a two-step process: first by Social Manager (Koordinator Sosial) and then by Chairman (Ketua Yayasan).
+// @Description Pass the candidate's current review stage. Passing the last stage of the review pipeline accepts the candidate. Only the stage's reviewer role or its assignee may do this
 // @Tags Foster Children Candidates
 // @Security BearerAuth
 // @Produce json
@@ -198,28 +212,17 @@ func (h *handler) GetFosterChildrenCandidateByID(c *gin.Context) {
 // @Router /api/admin/foster-children/candidates/{id}/accept [patch]
 func (h *handler) AcceptFosterChildrenCandidate(c *gin.Context) {
 	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
 	id := c.Param("id")
 
-	userData, exists := c.Get("user_data")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, pkg.NewResponse(http.StatusUnauthorized, "Tidak terautorisasi", nil, nil))
-		return
-	}
-
-	claims, ok := userData.(jwt_pkg.UserJWTClaims)
-	if !ok {
-		c.JSON(http.StatusUnauthorized, pkg.NewResponse(http.StatusUnauthorized, "Gagal memproses data user", nil, nil))
-		return
-	}
-
-	res := h.service.AcceptFosterChildrenCandidate(ctx, id, claims.ActiveRole)
+	res := h.service.AcceptFosterChildrenCandidate(ctx, claims.AccountID, id, claims.ActiveRole)
 	c.JSON(res.Status, res)
 }
 
 // RejectFosterChildrenCandidate
 //
 // @Summary Reject Foster Children Candidate
-// @Description Reject a pending foster children candidate with a reason
+// @Description Fail the candidate's current review stage with a reason, which rejects the candidate
 // @Tags Foster Children Candidates
 // @Security BearerAuth
 // @Accept json
@@ -230,6 +233,7 @@ func (h *handler) AcceptFosterChildrenCandidate(c *gin.Context) {
 // @Router /api/admin/foster-children/candidates/{id}/reject [patch]
 func (h *handler) RejectFosterChildrenCandidate(c *gin.Context) {
 	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
 	id := c.Param("id")
 
 	var req RejectFosterChildrenCandidateRequest
@@ -238,6 +242,239 @@ func (h *handler) RejectFosterChildrenCandidate(c *gin.Context) {
 		return
 	}
 
-	res := h.service.RejectFosterChildrenCandidate(ctx, id, req)
+	res := h.service.RejectFosterChildrenCandidate(ctx, claims.AccountID, id, claims.ActiveRole, req)
+	c.JSON(res.Status, res)
+}
+
+// GetCandidateDocument
+//
+// @Summary Get Foster Children Candidate Document
+// @Description Get a short-lived signed URL for a sensitive upload of the candidate (familyCard, sktm, submitterIdCard). Submitters can only open their own candidates
+// @Tags Foster Children Candidates
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Candidate ID"
+// @Param document path string true "Document type"
+// @Success 200 {object} pkg.Response
+// @Router /api/foster-children/candidates/{id}/documents/{document} [get]
+// @Router /api/admin/foster-children/candidates/{id}/documents/{document} [get]
+func (h *handler) GetCandidateDocument(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.GetCandidateDocument(ctx, claims.AccountID, claims.ActiveRole, c.Param("id"), c.Param("document"))
+	c.JSON(res.Status, res)
+}
+
+// GetMyCandidateReviewSummary
+//
+// @Summary Get My Candidate Review Progress
+// @Description Get the review stages of a candidate submitted by the user with their status and dates
+// @Tags Foster Children Candidates
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Candidate ID"
+// @Success 200 {object} pkg.Response{data=CandidateReviewSummaryResponse}
+// @Router /api/foster-children/candidates/{id}/review [get]
+func (h *handler) GetMyCandidateReviewSummary(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.GetMyCandidateReviewSummary(ctx, claims.AccountID, c.Param("id"))
+	c.JSON(res.Status, res)
+}
+
+// GetReviewPipeline
+//
+// @Summary Get Candidate Review Pipeline
+// @Description Get the configured review stages for new candidates
+// @Tags Foster Children Candidates
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} pkg.Response{data=[]ReviewStageResponse}
+// @Router /api/admin/foster-children/candidates/pipeline [get]
+func (h *handler) GetReviewPipeline(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	res := h.service.GetReviewPipeline(ctx)
+	c.JSON(res.Status, res)
+}
+
+// UpdateReviewPipeline
+//
+// @Summary Update Candidate Review Pipeline
+// @Description Replace the review stages (Ketua Yayasan only). Candidates already under review keep their stages
+// @Tags Foster Children Candidates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body UpdateReviewPipelineRequest true "Pipeline"
+// @Success 200 {object} pkg.Response{data=[]ReviewStageResponse}
+// @Router /api/admin/foster-children/candidates/pipeline [put]
+func (h *handler) UpdateReviewPipeline(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req UpdateReviewPipelineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Payload request tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.UpdateReviewPipeline(ctx, claims.AccountID, req)
+	c.JSON(res.Status, res)
+}
+
+// GetCandidateReviewTrail
+//
+// @Summary Get Candidate Review Trail
+// @Description Get every review stage of the candidate with checklist, photos, votes, assignee and due date, plus the trail of review actions
+// @Tags Foster Children Candidates
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Candidate ID"
+// @Success 200 {object} pkg.Response{data=CandidateReviewTrailResponse}
+// @Router /api/admin/foster-children/candidates/{id}/reviews [get]
+func (h *handler) GetCandidateReviewTrail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.GetCandidateReviewTrail(ctx, c.Param("id"), claims.AccountID)
+	c.JSON(res.Status, res)
+}
+
+// AssignReview
+//
+// @Summary Assign Candidate Review Stage
+// @Description Set the reviewer and optionally the due date of a stage that has not finished
+// @Tags Foster Children Candidates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Candidate ID"
+// @Param reviewId path string true "Review stage ID"
+// @Param body body AssignReviewRequest true "Assignment"
+// @Success 200 {object} pkg.Response
+// @Router /api/admin/foster-children/candidates/{id}/reviews/{reviewId}/assign [patch]
+func (h *handler) AssignReview(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req AssignReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Payload request tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.AssignReview(ctx, claims.AccountID, claims.ActiveRole, c.Param("id"), c.Param("reviewId"), req)
+	c.JSON(res.Status, res)
+}
+
+// UpdateReviewChecklist
+//
+// @Summary Update Candidate Review Checklist
+// @Description Check or uncheck checklist items of the running stage
+// @Tags Foster Children Candidates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Candidate ID"
+// @Param reviewId path string true "Review stage ID"
+// @Param body body UpdateReviewChecklistRequest true "Checklist items"
+// @Success 200 {object} pkg.Response
+// @Router /api/admin/foster-children/candidates/{id}/reviews/{reviewId}/checklist [patch]
+func (h *handler) UpdateReviewChecklist(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req UpdateReviewChecklistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Payload request tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.UpdateReviewChecklist(ctx, claims.AccountID, claims.ActiveRole, c.Param("id"), c.Param("reviewId"), req)
+	c.JSON(res.Status, res)
+}
+
+// AddReviewPhotos
+//
+// @Summary Add Candidate Survey Photos
+// @Description Upload photos of the field survey to the running survey stage
+// @Tags Foster Children Candidates
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Candidate ID"
+// @Param reviewId path string true "Review stage ID"
+// @Param photos[] formData file true "Photos"
+// @Param photoCaptions[] formData []string false "Captions, in the order of the photos"
+// @Success 201 {object} pkg.Response
+// @Router /api/admin/foster-children/candidates/{id}/reviews/{reviewId}/photos [post]
+func (h *handler) AddReviewPhotos(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req AddReviewPhotosRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Payload request tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.AddReviewPhotos(ctx, claims.AccountID, claims.ActiveRole, c.Param("id"), c.Param("reviewId"), req)
+	c.JSON(res.Status, res)
+}
+
+// CastReviewVote
+//
+// @Summary Vote On Candidate
+// @Description Cast or change a vote on the running committee vote stage
+// @Tags Foster Children Candidates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Candidate ID"
+// @Param reviewId path string true "Review stage ID"
+// @Param body body CastReviewVoteRequest true "Vote"
+// @Success 200 {object} pkg.Response
+// @Router /api/admin/foster-children/candidates/{id}/reviews/{reviewId}/votes [post]
+func (h *handler) CastReviewVote(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req CastReviewVoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Payload request tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.CastReviewVote(ctx, claims.AccountID, claims.ActiveRole, c.Param("id"), c.Param("reviewId"), req)
+	c.JSON(res.Status, res)
+}
+
+// CompleteReview
+//
+// @Summary Complete Candidate Review Stage
+// @Description Pass or fail the running stage. Passing requires all required checklist items and, for vote stages, enough approvals; failing requires notes and rejects the candidate
+// @Tags Foster Children Candidates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Candidate ID"
+// @Param reviewId path string true "Review stage ID"
+// @Param body body CompleteReviewRequest true "Outcome"
+// @Success 200 {object} pkg.Response
+// @Router /api/admin/foster-children/candidates/{id}/reviews/{reviewId}/complete [patch]
+func (h *handler) CompleteReview(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req CompleteReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Payload request tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.CompleteReview(ctx, claims.AccountID, claims.ActiveRole, c.Param("id"), c.Param("reviewId"), req)
 	c.JSON(res.Status, res)
 }
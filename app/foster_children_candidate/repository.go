@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/Vilamuzz/yota-backend/pkg/enum"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
@@ -15,6 +19,21 @@ type Repository interface {
 	FindOneFosterChildrenCandidate(ctx context.Context, options map[string]interface{}) (*FosterChildrenCandidate, error)
 	CreateFosterChildrenCandidate(ctx context.Context, candidate *FosterChildrenCandidate) error
 	UpdateFosterChildrenCandidate(ctx context.Context, id string, updateData map[string]interface{}) error
+
+	FindReviewStages(ctx context.Context) ([]ReviewStage, error)
+	ReplaceReviewStages(ctx context.Context, stages []ReviewStage) error
+	FindCandidateReviews(ctx context.Context, candidateID string) ([]CandidateReview, error)
+	StartCandidateReviews(ctx context.Context, candidateID string, reviews []CandidateReview, candidateData map[string]interface{}, events []CandidateReviewEvent) error
+	UpdateCandidateReview(ctx context.Context, id string, updateData map[string]interface{}, event CandidateReviewEvent) error
+	UpdateReviewChecklist(ctx context.Context, reviewID string, checked map[uuid.UUID]bool, accountID uuid.UUID, event CandidateReviewEvent) error
+	CreateReviewPhotos(ctx context.Context, photos []CandidateReviewPhoto, event CandidateReviewEvent) error
+	UpsertReviewVote(ctx context.Context, vote CandidateReviewVote, event CandidateReviewEvent) error
+	CompleteReview(ctx context.Context, completion ReviewCompletion) error
+	CloseCandidateReviews(ctx context.Context, candidateID string, event CandidateReviewEvent) error
+	FindReviewEvents(ctx context.Context, candidateID string) ([]CandidateReviewEvent, error)
+	AccountHasAnyRole(ctx context.Context, accountID string, roles []enum.RoleName) (bool, error)
+
+	FindDuplicates(ctx context.Context, q DuplicateQuery) ([]DuplicateRecord, error)
 }
 
 type repository struct {
@@ -40,6 +59,10 @@ func (r *repository) CountFosterChildrenCandidates(ctx context.Context, options
 	if accountID, ok := options["account_id"]; ok && accountID != "" {
 		query = query.Where("submitted_by = ?", accountID)
 	}
+	// An empty ids list comes from a scoped account without grants and matches nothing.
+	if ids, ok := options["ids"].([]string); ok {
+		query = query.Where("foster_children_candidates.id IN ?", ids)
+	}
 	if category, ok := options["category"]; ok && category != "" {
 		query = query.Where("category = ?", category)
 	}
@@ -71,6 +94,9 @@ func (r *repository) FindAllFosterChildrenCandidates(ctx context.Context, option
 	if accountID, ok := options["account_id"]; ok && accountID != "" {
 		query = query.Where("submitted_by = ?", accountID)
 	}
+	if ids, ok := options["ids"].([]string); ok {
+		query = query.Where("foster_children_candidates.id IN ?", ids)
+	}
 	if category, ok := options["category"]; ok && category != "" {
 		query = query.Where("category = ?", category)
 	}
@@ -149,6 +175,9 @@ func (r *repository) FindOneFosterChildrenCandidate(ctx context.Context, options
 	if id, ok := options["id"]; ok && id != "" {
 		query = query.Where("id = ?", id)
 	}
+	if ids, ok := options["ids"].([]string); ok {
+		query = query.Where("foster_children_candidates.id IN ?", ids)
+	}
 
 	if err := query.First(&candidate).Error; err != nil {
 		return nil, err
@@ -165,3 +194,245 @@ func (r *repository) UpdateFosterChildrenCandidate(ctx context.Context, id strin
 		Where("id = ?", id).
 		Updates(updateData).Error
 }
+
+func (r *repository) FindReviewStages(ctx context.Context) ([]ReviewStage, error) {
+	var stages []ReviewStage
+	err := r.Conn.WithContext(ctx).
+		Preload("ChecklistItems", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Order("position ASC").
+		Find(&stages).Error
+	return stages, err
+}
+
+// ReplaceReviewStages swaps the whole pipeline. Running reviews keep their own copy of the stages.
+func (r *repository) ReplaceReviewStages(ctx context.Context, stages []ReviewStage) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&ReviewStageChecklistItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("1 = 1").Delete(&ReviewStage{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&stages).Error
+	})
+}
+
+func (r *repository) FindCandidateReviews(ctx context.Context, candidateID string) ([]CandidateReview, error) {
+	var reviews []CandidateReview
+	err := r.Conn.WithContext(ctx).
+		Preload("Checklist", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Preload("Photos", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("Votes", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("Votes.Account.UserProfile").
+		Preload("Assignee.UserProfile").
+		Where("candidate_id = ?", candidateID).
+		Order("position ASC").
+		Find(&reviews).Error
+	return reviews, err
+}
+
+// StartCandidateReviews creates the candidate's stages together with their checklists.
+func (r *repository) StartCandidateReviews(ctx context.Context, candidateID string, reviews []CandidateReview, candidateData map[string]interface{}, events []CandidateReviewEvent) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&reviews).Error; err != nil {
+			return err
+		}
+		if len(candidateData) > 0 {
+			if err := tx.Model(&FosterChildrenCandidate{}).Where("id = ?", candidateID).Updates(candidateData).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(&events).Error
+	})
+}
+
+func (r *repository) UpdateCandidateReview(ctx context.Context, id string, updateData map[string]interface{}, event CandidateReviewEvent) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&CandidateReview{}).Where("id = ?", id).Updates(updateData).Error; err != nil {
+			return err
+		}
+		return tx.Create(&event).Error
+	})
+}
+
+func (r *repository) UpdateReviewChecklist(ctx context.Context, reviewID string, checked map[uuid.UUID]bool, accountID uuid.UUID, event CandidateReviewEvent) error {
+	now := time.Now()
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for itemID, isChecked := range checked {
+			updateData := map[string]interface{}{
+				"checked":    isChecked,
+				"checked_by": nil,
+				"checked_at": nil,
+			}
+			if isChecked {
+				updateData["checked_by"] = accountID
+				updateData["checked_at"] = now
+			}
+			if err := tx.Model(&CandidateReviewChecklistItem{}).
+				Where("id = ? AND review_id = ?", itemID, reviewID).
+				Updates(updateData).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(&event).Error
+	})
+}
+
+func (r *repository) CreateReviewPhotos(ctx context.Context, photos []CandidateReviewPhoto, event CandidateReviewEvent) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&photos).Error; err != nil {
+			return err
+		}
+		return tx.Create(&event).Error
+	})
+}
+
+// UpsertReviewVote records the account's vote, replacing the one cast earlier on the same stage.
+func (r *repository) UpsertReviewVote(ctx context.Context, vote CandidateReviewVote, event CandidateReviewEvent) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "review_id"}, {Name: "account_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"decision", "note", "updated_at"}),
+		}).Create(&vote).Error; err != nil {
+			return err
+		}
+		return tx.Create(&event).Error
+	})
+}
+
+// CompleteReview decides a stage. It returns ErrReviewNotInProgress when another reviewer
+// decided the stage first.
+func (r *repository) CompleteReview(ctx context.Context, completion ReviewCompletion) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&CandidateReview{}).
+			Where("id = ? AND status = ?", completion.ReviewID, ReviewInProgress).
+			Updates(completion.ReviewData)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrReviewNotInProgress
+		}
+
+		if completion.NextReviewID != nil {
+			if err := tx.Model(&CandidateReview{}).
+				Where("id = ? AND status = ?", *completion.NextReviewID, ReviewWaiting).
+				Updates(completion.NextData).Error; err != nil {
+				return err
+			}
+		}
+		if completion.CloseRemaining {
+			if err := tx.Model(&CandidateReview{}).
+				Where("candidate_id = ? AND status IN ?", completion.CandidateID, []ReviewStatus{ReviewWaiting, ReviewInProgress}).
+				Updates(map[string]interface{}{"status": ReviewClosed, "updated_at": time.Now()}).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&FosterChildrenCandidate{}).
+			Where("id = ?", completion.CandidateID).
+			Updates(completion.CandidateData).Error; err != nil {
+			return err
+		}
+		if completion.Accept != nil {
+			if err := completion.Accept(tx); err != nil {
+				return err
+			}
+		}
+		return tx.Create(&completion.Events).Error
+	})
+}
+
+// CloseCandidateReviews stops the stages that have not been decided yet.
+func (r *repository) CloseCandidateReviews(ctx context.Context, candidateID string, event CandidateReviewEvent) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&CandidateReview{}).
+			Where("candidate_id = ? AND status IN ?", candidateID, []ReviewStatus{ReviewWaiting, ReviewInProgress}).
+			Updates(map[string]interface{}{"status": ReviewClosed, "updated_at": time.Now()})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Create(&event).Error
+	})
+}
+
+func (r *repository) FindReviewEvents(ctx context.Context, candidateID string) ([]CandidateReviewEvent, error) {
+	var events []CandidateReviewEvent
+	err := r.Conn.WithContext(ctx).
+		Preload("Actor.UserProfile").
+		Where("candidate_id = ?", candidateID).
+		Order("created_at ASC").
+		Find(&events).Error
+	return events, err
+}
+
+func (r *repository) AccountHasAnyRole(ctx context.Context, accountID string, roles []enum.RoleName) (bool, error) {
+	var count int64
+	err := r.Conn.WithContext(ctx).Table("account_roles").
+		Joins("JOIN roles ON roles.id = account_roles.role_id").
+		Joins("JOIN accounts ON accounts.id = account_roles.account_id").
+		Where("account_roles.account_id = ? AND account_roles.is_active = ? AND roles.name IN ?", accountID, true, roles).
+		Where("accounts.is_banned = ? AND accounts.deleted_at IS NULL", false).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// duplicateLimit caps the matches returned per source; a handful is enough for a reviewer.
+const duplicateLimit = 20
+
+// FindDuplicates returns foster children and open candidates sharing the NIK, the family card
+// number or the normalised name and birth date of the query. Rejected and cancelled candidates
+// are left out.
+func (r *repository) FindDuplicates(ctx context.Context, q DuplicateQuery) ([]DuplicateRecord, error) {
+	var conditions []string
+	var args []interface{}
+	if q.NikIndex != "" {
+		conditions = append(conditions, "nik_index = ?")
+		args = append(args, q.NikIndex)
+	}
+	if q.FamilyCardNumberIndex != "" {
+		conditions = append(conditions, "family_card_number_index = ?")
+		args = append(args, q.FamilyCardNumberIndex)
+	}
+	if q.NameKey != "" {
+		conditions = append(conditions, "("+NormalizedNameSQL+" = ? AND (birth_date AT TIME ZONE 'UTC')::date = ?)")
+		args = append(args, q.NameKey, q.BirthDate.UTC().Format("2006-01-02"))
+	}
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+	match := "(" + strings.Join(conditions, " OR ") + ")"
+
+	var children []DuplicateRecord
+	if err := r.Conn.WithContext(ctx).Table("foster_childrens").
+		Select("id, name, slug, status, birth_date, nik_index, family_card_number_index, created_at").
+		Where(match, args...).
+		Where("deleted_at IS NULL").
+		Order("created_at DESC").
+		Limit(duplicateLimit).
+		Scan(&children).Error; err != nil {
+		return nil, err
+	}
+
+	var candidates []DuplicateRecord
+	query := r.Conn.WithContext(ctx).Model(&FosterChildrenCandidate{}).
+		Select("id, name, status, birth_date, nik_index, family_card_number_index, created_at").
+		Where(match, args...).
+		Where("status NOT IN ?", []Status{StatusRejected, StatusCancelled})
+	if q.ExcludeCandidateID != nil {
+		query = query.Where("id <> ?", *q.ExcludeCandidateID)
+	}
+	if err := query.Order("created_at DESC").Limit(duplicateLimit).Scan(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	records := make([]DuplicateRecord, 0, len(children)+len(candidates))
+	for _, c := range children {
+		c.Source = SourceFosterChildren
+		records = append(records, c)
+	}
+	for _, c := range candidates {
+		c.Source = SourceCandidate
+		records = append(records, c)
+	}
+	return records, nil
+}
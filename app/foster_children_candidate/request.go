@@ -4,11 +4,13 @@ import (
 	"mime/multipart"
 
 	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/Vilamuzz/yota-backend/pkg/enum"
 )
 
 type CreateFosterChildrenCandidateRequest struct {
 	Name             string                `form:"name"`
 	Nik              string                `form:"nik"`
+	FamilyCardNumber string                `form:"familyCardNumber"`
 	Gender           Gender                `form:"gender"`
 	Category         Category              `form:"category"`
 	BirthDate        string                `form:"birthDate"`
@@ -47,3 +49,57 @@ type FosterChildrenCandidateAdminQueryParams struct {
 	Page      int      `form:"page"`
 	Limit     int      `form:"limit"`
 }
+
+type ReviewStageChecklistItemRequest struct {
+	Label    string `json:"label"`
+	Required bool   `json:"required"`
+}
+
+type ReviewStageRequest struct {
+	Key           string                            `json:"key"`
+	Name          string                            `json:"name"`
+	Description   string                            `json:"description"`
+	Type          StageType                         `json:"type"`
+	ApproverRole  enum.RoleName                     `json:"approverRole"`
+	DueDays       int                               `json:"dueDays"`
+	RequiredVotes int                               `json:"requiredVotes"`
+	Checklist     []ReviewStageChecklistItemRequest `json:"checklist"`
+}
+
+// UpdateReviewPipelineRequest replaces the whole pipeline; stages run in the order given.
+type UpdateReviewPipelineRequest struct {
+	Stages []ReviewStageRequest `json:"stages"`
+}
+
+// AssignReviewRequest sets the reviewer of a stage. DueDate (YYYY-MM-DD) overrides the deadline
+// derived from the stage's due days.
+type AssignReviewRequest struct {
+	AssigneeID string `json:"assigneeId"`
+	DueDate    string `json:"dueDate"`
+}
+
+type ReviewChecklistItemUpdate struct {
+	ID      string `json:"id"`
+	Checked bool   `json:"checked"`
+}
+
+type UpdateReviewChecklistRequest struct {
+	Items []ReviewChecklistItemUpdate `json:"items"`
+}
+
+type AddReviewPhotosRequest struct {
+	Photos        []*multipart.FileHeader `form:"photos[]" swaggerignore:"true"`
+	PhotoCaptions []string                `form:"photoCaptions[]"`
+}
+
+type CastReviewVoteRequest struct {
+	Decision VoteDecision `json:"decision"`
+	Note     string       `json:"note"`
+}
+
+// CompleteReviewRequest decides a stage. Notes are required when the stage fails and become the
+// candidate's rejection reason.
+type CompleteReviewRequest struct {
+	Outcome ReviewStatus `json:"outcome"`
+	Notes   string       `json:"notes"`
+}
@@ -1,16 +1,23 @@
 package foster_children_candidate
 
 import (
+	"context"
 	"time"
 
+	"github.com/Vilamuzz/yota-backend/app/account"
 	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/Vilamuzz/yota-backend/pkg/enum"
+	"github.com/Vilamuzz/yota-backend/pkg/pii"
 	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 )
 
 type FosterChildrenCandidateResponse struct {
 	ID               string    `json:"id"`
 	Name             string    `json:"name"`
 	Nik              string    `json:"nik"`
+	FamilyCardNumber string    `json:"familyCardNumber"`
 	ProfilePicture   string    `json:"profilePicture"`
 	Gender           string    `json:"gender"`
 	Category         string    `json:"category"`
@@ -31,6 +38,7 @@ type FosterChildrenCandidateResponse struct {
 	CreatedAt        time.Time `json:"createdAt"`
 	UpdatedAt        time.Time `json:"updatedAt"`
 	AccountUsername  string    `json:"accountUsername,omitempty"`
+	FosterChildrenID *string   `json:"fosterChildrenId"`
 }
 
 type FosterChildrenCandidateListResponse struct {
@@ -52,6 +60,8 @@ func (c *FosterChildrenCandidate) ToFosterChildrenCandidateResponse() FosterChil
 	return FosterChildrenCandidateResponse{
 		ID:               c.ID.String(),
 		Name:             c.Name,
+		Nik:              string(c.Nik),
+		FamilyCardNumber: string(c.FamilyCardNumber),
 		ProfilePicture:   s3_pkg.GetCDNURL(c.ProfilePicture),
 		Gender:           string(c.Gender),
 		Category:         string(c.Category),
@@ -63,7 +73,7 @@ func (c *FosterChildrenCandidate) ToFosterChildrenCandidateResponse() FosterChil
 		FamilyCard:       s3_pkg.GetCDNURL(c.FamilyCard),
 		SKTM:             s3_pkg.GetCDNURL(c.SKTM),
 		SubmitterName:    c.SubmitterName,
-		SubmitterPhone:   c.SubmitterPhone,
+		SubmitterPhone:   string(c.SubmitterPhone),
 		SubmitterAddress: c.SubmitterAddress,
 		SubmitterIDCard:  s3_pkg.GetCDNURL(c.SubmitterIDCard),
 		SubmittedBy:      c.SubmittedBy.String(),
@@ -72,13 +82,24 @@ func (c *FosterChildrenCandidate) ToFosterChildrenCandidateResponse() FosterChil
 		CreatedAt:        c.CreatedAt,
 		UpdatedAt:        c.UpdatedAt,
 		AccountUsername:  accountUsername,
+		FosterChildrenID: uuidString(c.FosterChildrenID),
 	}
 }
 
+// toFosterChildrenCandidateListItem masks the NIK, family card number and submitter phone; list
+// views never need them in full.
+func (c *FosterChildrenCandidate) toFosterChildrenCandidateListItem() FosterChildrenCandidateResponse {
+	resp := c.ToFosterChildrenCandidateResponse()
+	resp.Nik = pii.MaskNIK(c.Nik)
+	resp.FamilyCardNumber = pii.MaskNIK(c.FamilyCardNumber)
+	resp.SubmitterPhone = pii.MaskPhone(c.SubmitterPhone)
+	return resp
+}
+
 func ToFosterChildrenCandidateListResponse(candidates []FosterChildrenCandidate, pagination pkg.CursorPagination) FosterChildrenCandidateListResponse {
 	var responses []FosterChildrenCandidateResponse
 	for _, c := range candidates {
-		responses = append(responses, c.ToFosterChildrenCandidateResponse())
+		responses = append(responses, c.toFosterChildrenCandidateListItem())
 	}
 	if responses == nil {
 		responses = []FosterChildrenCandidateResponse{}
@@ -92,7 +113,7 @@ func ToFosterChildrenCandidateListResponse(candidates []FosterChildrenCandidate,
 func ToFosterChildrenCandidateAdminListResponse(candidates []FosterChildrenCandidate, pagination pkg.OffsetPagination) FosterChildrenCandidateAdminListResponse {
 	var responses []FosterChildrenCandidateResponse
 	for _, c := range candidates {
-		responses = append(responses, c.ToFosterChildrenCandidateResponse())
+		responses = append(responses, c.toFosterChildrenCandidateListItem())
 	}
 	if responses == nil {
 		responses = []FosterChildrenCandidateResponse{}
@@ -102,3 +123,313 @@ func ToFosterChildrenCandidateAdminListResponse(candidates []FosterChildrenCandi
 		Pagination:               pagination,
 	}
 }
+
+type ReviewStageChecklistItemResponse struct {
+	ID       string `json:"id"`
+	Label    string `json:"label"`
+	Required bool   `json:"required"`
+}
+
+type ReviewStageResponse struct {
+	ID            string                             `json:"id"`
+	Key           string                             `json:"key"`
+	Name          string                             `json:"name"`
+	Description   string                             `json:"description"`
+	Type          StageType                          `json:"type"`
+	Position      int                                `json:"position"`
+	ApproverRole  enum.RoleName                      `json:"approverRole"`
+	DueDays       int                                `json:"dueDays"`
+	RequiredVotes int                                `json:"requiredVotes"`
+	Checklist     []ReviewStageChecklistItemResponse `json:"checklist"`
+}
+
+type ReviewerResponse struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+type ReviewChecklistItemResponse struct {
+	ID        string     `json:"id"`
+	Label     string     `json:"label"`
+	Required  bool       `json:"required"`
+	Checked   bool       `json:"checked"`
+	CheckedBy *string    `json:"checkedBy"`
+	CheckedAt *time.Time `json:"checkedAt"`
+}
+
+type ReviewPhotoResponse struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	ExpiresAt string    `json:"expiresAt,omitempty"`
+	Caption   string    `json:"caption"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ReviewVoteResponse struct {
+	Voter     ReviewerResponse `json:"voter"`
+	Decision  VoteDecision     `json:"decision"`
+	Note      string           `json:"note"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+}
+
+type CandidateReviewResponse struct {
+	ID            string                        `json:"id"`
+	StageKey      string                        `json:"stageKey"`
+	StageName     string                        `json:"stageName"`
+	StageType     StageType                     `json:"stageType"`
+	ApproverRole  enum.RoleName                 `json:"approverRole"`
+	Position      int                           `json:"position"`
+	Status        ReviewStatus                  `json:"status"`
+	Assignee      *ReviewerResponse             `json:"assignee"`
+	DueDate       *time.Time                    `json:"dueDate"`
+	IsOverdue     bool                          `json:"isOverdue"`
+	StartedAt     *time.Time                    `json:"startedAt"`
+	CompletedAt   *time.Time                    `json:"completedAt"`
+	CompletedBy   *string                       `json:"completedBy"`
+	Notes         string                        `json:"notes"`
+	Checklist     []ReviewChecklistItemResponse `json:"checklist"`
+	Photos        []ReviewPhotoResponse         `json:"photos"`
+	Votes         []ReviewVoteResponse          `json:"votes"`
+	RequiredVotes int                           `json:"requiredVotes"`
+	Approvals     int                           `json:"approvals"`
+	Rejections    int                           `json:"rejections"`
+}
+
+type ReviewEventResponse struct {
+	ID        string            `json:"id"`
+	ReviewID  *string           `json:"reviewId"`
+	Action    ReviewAction      `json:"action"`
+	Actor     *ReviewerResponse `json:"actor"`
+	Note      string            `json:"note"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+// CandidateReviewTrailResponse is the full review of a candidate as seen by admins.
+type CandidateReviewTrailResponse struct {
+	CandidateID string                    `json:"candidateId"`
+	Status      Status                    `json:"status"`
+	Stages      []CandidateReviewResponse `json:"stages"`
+	Events      []ReviewEventResponse     `json:"events"`
+}
+
+type ReviewSummaryStageResponse struct {
+	Name        string       `json:"name"`
+	Status      ReviewStatus `json:"status"`
+	StartedAt   *time.Time   `json:"startedAt"`
+	CompletedAt *time.Time   `json:"completedAt"`
+}
+
+// CandidateReviewSummaryResponse is what the submitter sees of the review: the stages and where
+// the candidate stands, without reviewer notes, photos or votes.
+type CandidateReviewSummaryResponse struct {
+	CandidateID     string                       `json:"candidateId"`
+	Status          Status                       `json:"status"`
+	CurrentStage    string                       `json:"currentStage"`
+	RejectionReason string                       `json:"rejectionReason"`
+	Stages          []ReviewSummaryStageResponse `json:"stages"`
+}
+
+func toReviewStageResponses(stages []ReviewStage) []ReviewStageResponse {
+	responses := make([]ReviewStageResponse, 0, len(stages))
+	for _, st := range stages {
+		checklist := make([]ReviewStageChecklistItemResponse, 0, len(st.ChecklistItems))
+		for _, item := range st.ChecklistItems {
+			checklist = append(checklist, ReviewStageChecklistItemResponse{
+				ID:       item.ID.String(),
+				Label:    item.Label,
+				Required: item.Required,
+			})
+		}
+		responses = append(responses, ReviewStageResponse{
+			ID:            st.ID.String(),
+			Key:           st.Key,
+			Name:          st.Name,
+			Description:   st.Description,
+			Type:          st.Type,
+			Position:      st.Position,
+			ApproverRole:  st.ApproverRole,
+			DueDays:       st.DueDays,
+			RequiredVotes: st.RequiredVotes,
+			Checklist:     checklist,
+		})
+	}
+	return responses
+}
+
+func toReviewerResponse(a *account.Account) *ReviewerResponse {
+	if a == nil || a.ID == uuid.Nil {
+		return nil
+	}
+	return &ReviewerResponse{
+		ID:       a.ID.String(),
+		Username: a.UserProfile.Username,
+	}
+}
+
+func uuidString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func (r *CandidateReview) toCandidateReviewResponse(ctx context.Context, storage s3_pkg.PrivateClient, now time.Time) CandidateReviewResponse {
+	checklist := make([]ReviewChecklistItemResponse, 0, len(r.Checklist))
+	for _, item := range r.Checklist {
+		checklist = append(checklist, ReviewChecklistItemResponse{
+			ID:        item.ID.String(),
+			Label:     item.Label,
+			Required:  item.Required,
+			Checked:   item.Checked,
+			CheckedBy: uuidString(item.CheckedBy),
+			CheckedAt: item.CheckedAt,
+		})
+	}
+
+	photos := make([]ReviewPhotoResponse, 0, len(r.Photos))
+	for _, p := range r.Photos {
+		link, err := s3_pkg.SignDocument(ctx, storage, p.URL)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component": "foster_children_candidate.response",
+				"photo_id":  p.ID.String(),
+			}).WithError(err).Error("failed to sign review photo url")
+			continue
+		}
+		photos = append(photos, ReviewPhotoResponse{
+			ID:        p.ID.String(),
+			URL:       link.URL,
+			ExpiresAt: link.ExpiresAt,
+			Caption:   p.Caption,
+			CreatedAt: p.CreatedAt,
+		})
+	}
+
+	votes := make([]ReviewVoteResponse, 0, len(r.Votes))
+	for _, v := range r.Votes {
+		voter := ReviewerResponse{ID: v.AccountID.String()}
+		if v.Account != nil {
+			voter.Username = v.Account.UserProfile.Username
+		}
+		votes = append(votes, ReviewVoteResponse{
+			Voter:     voter,
+			Decision:  v.Decision,
+			Note:      v.Note,
+			UpdatedAt: v.UpdatedAt,
+		})
+	}
+	approvals, rejections := r.VoteTally()
+
+	return CandidateReviewResponse{
+		ID:            r.ID.String(),
+		StageKey:      r.StageKey,
+		StageName:     r.StageName,
+		StageType:     r.StageType,
+		ApproverRole:  r.ApproverRole,
+		Position:      r.Position,
+		Status:        r.Status,
+		Assignee:      toReviewerResponse(r.Assignee),
+		DueDate:       r.DueDate,
+		IsOverdue:     r.IsOverdue(now),
+		StartedAt:     r.StartedAt,
+		CompletedAt:   r.CompletedAt,
+		CompletedBy:   uuidString(r.CompletedBy),
+		Notes:         r.Notes,
+		Checklist:     checklist,
+		Photos:        photos,
+		Votes:         votes,
+		RequiredVotes: r.RequiredVotes,
+		Approvals:     approvals,
+		Rejections:    rejections,
+	}
+}
+
+func toCandidateReviewTrailResponse(ctx context.Context, storage s3_pkg.PrivateClient, candidate *FosterChildrenCandidate, reviews []CandidateReview, events []CandidateReviewEvent) CandidateReviewTrailResponse {
+	now := time.Now()
+	stages := make([]CandidateReviewResponse, 0, len(reviews))
+	for i := range reviews {
+		stages = append(stages, reviews[i].toCandidateReviewResponse(ctx, storage, now))
+	}
+
+	trail := make([]ReviewEventResponse, 0, len(events))
+	for _, e := range events {
+		trail = append(trail, ReviewEventResponse{
+			ID:        e.ID.String(),
+			ReviewID:  uuidString(e.ReviewID),
+			Action:    e.Action,
+			Actor:     toReviewerResponse(e.Actor),
+			Note:      e.Note,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+
+	return CandidateReviewTrailResponse{
+		CandidateID: candidate.ID.String(),
+		Status:      candidate.Status,
+		Stages:      stages,
+		Events:      trail,
+	}
+}
+
+func toCandidateReviewSummaryResponse(candidate *FosterChildrenCandidate, reviews []CandidateReview) CandidateReviewSummaryResponse {
+	resp := CandidateReviewSummaryResponse{
+		CandidateID:     candidate.ID.String(),
+		Status:          candidate.Status,
+		RejectionReason: candidate.RejectionReason,
+		Stages:          make([]ReviewSummaryStageResponse, 0, len(reviews)),
+	}
+	for _, r := range reviews {
+		if r.Status == ReviewInProgress {
+			resp.CurrentStage = r.StageName
+		}
+		resp.Stages = append(resp.Stages, ReviewSummaryStageResponse{
+			Name:        r.StageName,
+			Status:      r.Status,
+			StartedAt:   r.StartedAt,
+			CompletedAt: r.CompletedAt,
+		})
+	}
+	return resp
+}
+
+type DuplicateMatchResponse struct {
+	Source    DuplicateSource  `json:"source"`
+	ID        string           `json:"id"`
+	Name      string           `json:"name"`
+	Slug      string           `json:"slug,omitempty"`
+	Status    string           `json:"status"`
+	BirthDate string           `json:"birthDate"`
+	MatchedOn []DuplicateField `json:"matchedOn"`
+}
+
+type ScreeningWarningResponse struct {
+	Code     WarningCode `json:"code"`
+	Message  string      `json:"message"`
+	Blocking bool        `json:"blocking"` // acceptance is refused while this holds
+}
+
+// CandidateScreeningResponse lists what reviewers should check before accepting a candidate.
+type CandidateScreeningResponse struct {
+	Warnings   []ScreeningWarningResponse `json:"warnings"`
+	Duplicates []DuplicateMatchResponse   `json:"duplicates"`
+}
+
+// FosterChildrenCandidateDetailResponse is the admin view of a candidate. Screening is only
+// filled while the candidate is still under review.
+type FosterChildrenCandidateDetailResponse struct {
+	FosterChildrenCandidateResponse
+	Screening *CandidateScreeningResponse `json:"screening"`
+}
+
+func (d *DuplicateRecord) toDuplicateMatchResponse(fields []DuplicateField) DuplicateMatchResponse {
+	return DuplicateMatchResponse{
+		Source:    d.Source,
+		ID:        d.ID.String(),
+		Name:      d.Name,
+		Slug:      d.Slug,
+		Status:    d.Status,
+		BirthDate: d.BirthDate.UTC().Format("2006-01-02"),
+		MatchedOn: fields,
+	}
+}
@@ -0,0 +1,329 @@
+package foster_children_candidate
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/account"
+	"github.com/Vilamuzz/yota-backend/pkg/enum"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StageType string
+
+const (
+	StageSurvey        StageType = "survey"         // field visit with notes and photos
+	StageDocumentCheck StageType = "document_check" // verification of the uploaded documents
+	StageVote          StageType = "vote"           // committee vote, passes on RequiredVotes approvals
+	StageApproval      StageType = "approval"       // sign-off by a single role
+)
+
+func (t StageType) IsValid() bool {
+	switch t {
+	case StageSurvey, StageDocumentCheck, StageVote, StageApproval:
+		return true
+	}
+	return false
+}
+
+// ReviewStage configures one step of the candidate review pipeline. Stages run in Position order.
+// Candidates copy the stages when their review starts, so changing the pipeline only affects
+// candidates submitted afterwards.
+type ReviewStage struct {
+	ID            uuid.UUID     `json:"id" gorm:"primaryKey"`
+	Key           string        `json:"key" gorm:"not null;unique"`
+	Name          string        `json:"name" gorm:"not null"`
+	Description   string        `json:"description"`
+	Type          StageType     `json:"type" gorm:"type:varchar(20);not null"`
+	Position      int           `json:"position" gorm:"not null"`
+	ApproverRole  enum.RoleName `json:"approverRole" gorm:"type:varchar(30);not null"`
+	DueDays       int           `json:"dueDays" gorm:"default:0"` // zero means no due date
+	RequiredVotes int           `json:"requiredVotes" gorm:"default:0"`
+	CreatedAt     time.Time     `json:"createdAt"`
+	UpdatedAt     time.Time     `json:"updatedAt"`
+
+	ChecklistItems []ReviewStageChecklistItem `json:"checklistItems" gorm:"foreignKey:StageID"`
+}
+
+type ReviewStageChecklistItem struct {
+	ID        uuid.UUID `json:"id" gorm:"primaryKey"`
+	StageID   uuid.UUID `json:"stageId" gorm:"not null;index"`
+	Label     string    `json:"label" gorm:"not null"`
+	Required  bool      `json:"required" gorm:"default:false"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// defaultReviewStages is the pipeline used until an admin configures one.
+var defaultReviewStages = []ReviewStage{
+	{
+		Key:          "field_survey",
+		Name:         "Survei Lapangan",
+		Description:  "Kunjungan ke tempat tinggal calon untuk memastikan kondisi keluarga",
+		Type:         StageSurvey,
+		ApproverRole: enum.RoleSocialManager,
+		DueDays:      7,
+		ChecklistItems: []ReviewStageChecklistItem{
+			{Label: "Kunjungan ke tempat tinggal calon", Required: true},
+			{Label: "Wawancara dengan wali atau keluarga", Required: true},
+			{Label: "Foto kondisi tempat tinggal", Required: false},
+		},
+	},
+	{
+		Key:          "document_verification",
+		Name:         "Verifikasi Dokumen",
+		Description:  "Pemeriksaan kartu keluarga, SKTM dan KTP pengaju",
+		Type:         StageDocumentCheck,
+		ApproverRole: enum.RoleSocialManager,
+		DueDays:      3,
+		ChecklistItems: []ReviewStageChecklistItem{
+			{Label: "Kartu keluarga sesuai dengan data calon", Required: true},
+			{Label: "SKTM masih berlaku", Required: true},
+			{Label: "KTP pengaju sesuai", Required: true},
+		},
+	},
+	{
+		Key:           "committee_vote",
+		Name:          "Rapat Komite",
+		Description:   "Pemungutan suara pengurus yayasan",
+		Type:          StageVote,
+		ApproverRole:  enum.RoleSocialManager,
+		DueDays:       7,
+		RequiredVotes: 2,
+	},
+	{
+		Key:          "final_approval",
+		Name:         "Persetujuan Akhir",
+		Description:  "Persetujuan Ketua Yayasan",
+		Type:         StageApproval,
+		ApproverRole: enum.RoleChairman,
+		DueDays:      3,
+	},
+}
+
+// reviewerRoles may vote and be assigned to stages.
+var reviewerRoles = []enum.RoleName{enum.RoleSocialManager, enum.RoleChairman}
+
+type ReviewStatus string
+
+const (
+	ReviewWaiting    ReviewStatus = "waiting"
+	ReviewInProgress ReviewStatus = "in_progress"
+	ReviewPassed     ReviewStatus = "passed"
+	ReviewFailed     ReviewStatus = "failed"
+	ReviewClosed     ReviewStatus = "closed" // not reached because the candidate was decided or cancelled
+)
+
+// CandidateReview is one stage of a candidate's review, with the stage settings copied from the
+// pipeline at the time the review started.
+type CandidateReview struct {
+	ID            uuid.UUID     `json:"id" gorm:"primaryKey"`
+	CandidateID   uuid.UUID     `json:"candidateId" gorm:"not null;uniqueIndex:idx_candidate_review_position,priority:1"`
+	StageKey      string        `json:"stageKey" gorm:"not null"`
+	StageName     string        `json:"stageName" gorm:"not null"`
+	StageType     StageType     `json:"stageType" gorm:"type:varchar(20);not null"`
+	ApproverRole  enum.RoleName `json:"approverRole" gorm:"type:varchar(30);not null"`
+	Position      int           `json:"position" gorm:"not null;uniqueIndex:idx_candidate_review_position,priority:2"`
+	RequiredVotes int           `json:"requiredVotes"`
+	DueDays       int           `json:"dueDays"`
+	Status        ReviewStatus  `json:"status" gorm:"type:varchar(20);not null"`
+	AssigneeID    *uuid.UUID    `json:"assigneeId" gorm:"index"`
+	DueDate       *time.Time    `json:"dueDate"`
+	StartedAt     *time.Time    `json:"startedAt"`
+	CompletedAt   *time.Time    `json:"completedAt"`
+	CompletedBy   *uuid.UUID    `json:"completedBy"`
+	Notes         string        `json:"notes" gorm:"type:text"`
+	CreatedAt     time.Time     `json:"createdAt"`
+	UpdatedAt     time.Time     `json:"updatedAt"`
+
+	Checklist []CandidateReviewChecklistItem `json:"checklist" gorm:"foreignKey:ReviewID"`
+	Photos    []CandidateReviewPhoto         `json:"photos" gorm:"foreignKey:ReviewID"`
+	Votes     []CandidateReviewVote          `json:"votes" gorm:"foreignKey:ReviewID"`
+	Assignee  *account.Account               `json:"-" gorm:"foreignKey:AssigneeID;references:ID"`
+}
+
+type CandidateReviewChecklistItem struct {
+	ID        uuid.UUID  `json:"id" gorm:"primaryKey"`
+	ReviewID  uuid.UUID  `json:"reviewId" gorm:"not null;index"`
+	Label     string     `json:"label" gorm:"not null"`
+	Required  bool       `json:"required"`
+	Position  int        `json:"position"`
+	Checked   bool       `json:"checked" gorm:"default:false"`
+	CheckedBy *uuid.UUID `json:"checkedBy"`
+	CheckedAt *time.Time `json:"checkedAt"`
+}
+
+// CandidateReviewPhoto is kept in private storage like the candidate's documents.
+type CandidateReviewPhoto struct {
+	ID         uuid.UUID `json:"id" gorm:"primaryKey"`
+	ReviewID   uuid.UUID `json:"reviewId" gorm:"not null;index"`
+	URL        string    `json:"url" gorm:"not null"`
+	Caption    string    `json:"caption"`
+	UploadedBy uuid.UUID `json:"uploadedBy" gorm:"not null"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type VoteDecision string
+
+const (
+	VoteApprove VoteDecision = "approve"
+	VoteReject  VoteDecision = "reject"
+)
+
+// CandidateReviewVote is one committee member's vote; voting again replaces the earlier vote.
+type CandidateReviewVote struct {
+	ID        uuid.UUID    `json:"id" gorm:"primaryKey"`
+	ReviewID  uuid.UUID    `json:"reviewId" gorm:"not null;uniqueIndex:idx_candidate_review_vote,priority:1"`
+	AccountID uuid.UUID    `json:"accountId" gorm:"not null;uniqueIndex:idx_candidate_review_vote,priority:2"`
+	Decision  VoteDecision `json:"decision" gorm:"type:varchar(10);not null"`
+	Note      string       `json:"note"`
+	CreatedAt time.Time    `json:"createdAt"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+
+	Account *account.Account `json:"-" gorm:"foreignKey:AccountID;references:ID"`
+}
+
+type ReviewAction string
+
+const (
+	ActionReviewStarted    ReviewAction = "review_started"
+	ActionStageStarted     ReviewAction = "stage_started"
+	ActionAssigned         ReviewAction = "assigned"
+	ActionChecklistUpdated ReviewAction = "checklist_updated"
+	ActionPhotosAdded      ReviewAction = "photos_added"
+	ActionVoteCast         ReviewAction = "vote_cast"
+	ActionStagePassed      ReviewAction = "stage_passed"
+	ActionStageFailed      ReviewAction = "stage_failed"
+	ActionReviewClosed     ReviewAction = "review_closed"
+)
+
+// CandidateReviewEvent is one entry of the review trail shown to admins.
+type CandidateReviewEvent struct {
+	ID          uuid.UUID    `json:"id" gorm:"primaryKey"`
+	CandidateID uuid.UUID    `json:"candidateId" gorm:"not null;index"`
+	ReviewID    *uuid.UUID   `json:"reviewId"`
+	Action      ReviewAction `json:"action" gorm:"type:varchar(30);not null"`
+	ActorID     *uuid.UUID   `json:"actorId"`
+	Note        string       `json:"note" gorm:"type:text"`
+	CreatedAt   time.Time    `json:"createdAt"`
+
+	Actor *account.Account `json:"-" gorm:"foreignKey:ActorID;references:ID"`
+}
+
+var ErrReviewNotInProgress = errors.New("review stage is not in progress")
+
+// IsOverdue reports whether a running stage has passed its due date.
+func (r *CandidateReview) IsOverdue(now time.Time) bool {
+	return r.Status == ReviewInProgress && r.DueDate != nil && now.After(*r.DueDate)
+}
+
+// CanAct reports whether the account may work on the stage: holders of the stage's approver
+// role and the assigned reviewer.
+func (r *CandidateReview) CanAct(accountID string, role enum.RoleName) bool {
+	if role == r.ApproverRole {
+		return true
+	}
+	return r.AssigneeID != nil && r.AssigneeID.String() == accountID
+}
+
+// VoteTally counts the approve and reject votes.
+func (r *CandidateReview) VoteTally() (approvals, rejections int) {
+	for _, v := range r.Votes {
+		if v.Decision == VoteApprove {
+			approvals++
+		} else {
+			rejections++
+		}
+	}
+	return approvals, rejections
+}
+
+// dueDateFrom returns the due date for a stage started at start, nil without a deadline.
+func dueDateFrom(start time.Time, dueDays int) *time.Time {
+	if dueDays <= 0 {
+		return nil
+	}
+	due := start.AddDate(0, 0, dueDays)
+	return &due
+}
+
+// ReviewCompletion is everything that changes when a stage is decided: the stage itself, the
+// stage that starts next, the candidate and the trail.
+type ReviewCompletion struct {
+	ReviewID       uuid.UUID
+	ReviewData     map[string]interface{}
+	NextReviewID   *uuid.UUID
+	NextData       map[string]interface{}
+	CloseRemaining bool
+	CandidateID    uuid.UUID
+	CandidateData  map[string]interface{}
+	Events         []CandidateReviewEvent
+	// Accept runs in the same transaction when the last stage passes; an error rolls the
+	// decision back.
+	Accept func(tx *gorm.DB) error
+}
+
+func isReviewerRole(role enum.RoleName) bool {
+	for _, r := range reviewerRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func newReviewEvent(candidateID uuid.UUID, reviewID *uuid.UUID, action ReviewAction, actorID *uuid.UUID, note string) CandidateReviewEvent {
+	return CandidateReviewEvent{
+		ID:          uuid.New(),
+		CandidateID: candidateID,
+		ReviewID:    reviewID,
+		Action:      action,
+		ActorID:     actorID,
+		Note:        note,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// currentReview returns the stage being worked on, nil once the review has ended.
+func currentReview(reviews []CandidateReview) *CandidateReview {
+	for i := range reviews {
+		if reviews[i].Status == ReviewInProgress {
+			return &reviews[i]
+		}
+	}
+	return nil
+}
+
+// nextReview returns the stage that follows review, nil when review is the last one.
+func nextReview(reviews []CandidateReview, review *CandidateReview) *CandidateReview {
+	for i := range reviews {
+		if reviews[i].Position > review.Position && reviews[i].Status == ReviewWaiting {
+			return &reviews[i]
+		}
+	}
+	return nil
+}
+
+// buildReviewStages gives the stages and their checklist items IDs and positions in the order given.
+func buildReviewStages(templates []ReviewStage) []ReviewStage {
+	now := time.Now()
+	stages := make([]ReviewStage, 0, len(templates))
+	for i, t := range templates {
+		stage := t
+		stage.ID = uuid.New()
+		stage.Position = i + 1
+		stage.CreatedAt = now
+		stage.UpdatedAt = now
+		stage.ChecklistItems = make([]ReviewStageChecklistItem, 0, len(t.ChecklistItems))
+		for j, item := range t.ChecklistItems {
+			item.ID = uuid.New()
+			item.StageID = stage.ID
+			item.Position = j + 1
+			item.CreatedAt = now
+			stage.ChecklistItems = append(stage.ChecklistItems, item)
+		}
+		stages = append(stages, stage)
+	}
+	return stages
+}
@@ -0,0 +1,182 @@
+package foster_children_candidate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EligibilityRule is the age range, in full years at submission, the foundation accepts for a
+// category.
+type EligibilityRule struct {
+	MinAge int
+	MaxAge int
+}
+
+// eligibilityRules follow the foundation's intake policy. Children who lost both parents are
+// taken in younger and supported until they finish school.
+var eligibilityRules = map[Category]EligibilityRule{
+	CategoryFatherless: {MinAge: 6, MaxAge: 18},
+	CategoryMotherless: {MinAge: 6, MaxAge: 18},
+	CategoryOrphan:     {MinAge: 5, MaxAge: 21},
+}
+
+// A child in kelas N is expected to be between N+5 and N+8 years old: most start kelas 1 at six
+// or seven and some repeat a year.
+const (
+	gradeMinAgeOffset = 5
+	gradeMaxAgeOffset = 8
+)
+
+func (c Category) IsValid() bool {
+	_, ok := eligibilityRules[c]
+	return ok
+}
+
+// ageOn returns the age in full years on the given day.
+func ageOn(birthDate, on time.Time) int {
+	age := on.Year() - birthDate.Year()
+	if on.Month() < birthDate.Month() || (on.Month() == birthDate.Month() && on.Day() < birthDate.Day()) {
+		age--
+	}
+	return age
+}
+
+// checkAge returns why a child of this category and birth date is not eligible, or "".
+func checkAge(category Category, birthDate, on time.Time) string {
+	rule, ok := eligibilityRules[category]
+	if !ok {
+		return ""
+	}
+	age := ageOn(birthDate, on)
+	if age < rule.MinAge || age > rule.MaxAge {
+		return fmt.Sprintf("Usia calon kategori %s harus %d sampai %d tahun, usia saat ini %d tahun", category, rule.MinAge, rule.MaxAge, age)
+	}
+	return ""
+}
+
+// checkEducationLevel returns a note when the grade does not fit the age, or "".
+func checkEducationLevel(educationLevel int, birthDate, on time.Time) string {
+	age := ageOn(birthDate, on)
+	if age < educationLevel+gradeMinAgeOffset || age > educationLevel+gradeMaxAgeOffset {
+		return fmt.Sprintf("Usia %d tahun tidak lazim untuk kelas %d", age, educationLevel)
+	}
+	return ""
+}
+
+// NormalizeName reduces a name to lowercase letters and digits so spacing, punctuation and
+// capitalisation do not hide a duplicate. It must stay in line with NormalizedNameSQL.
+func NormalizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return -1
+	}, strings.ToLower(name))
+}
+
+const NormalizedNameSQL = "regexp_replace(lower(name), '[^a-z0-9]', '', 'g')"
+
+type DuplicateSource string
+
+const (
+	SourceCandidate      DuplicateSource = "candidate"
+	SourceFosterChildren DuplicateSource = "foster_children"
+)
+
+type DuplicateField string
+
+const (
+	MatchNik              DuplicateField = "nik"
+	MatchFamilyCardNumber DuplicateField = "familyCardNumber"
+	MatchNameBirthDate    DuplicateField = "nameBirthDate"
+)
+
+// DuplicateRecord is a candidate or foster child sharing an identifier with the one screened.
+type DuplicateRecord struct {
+	Source                DuplicateSource
+	ID                    uuid.UUID
+	Name                  string
+	Slug                  string
+	Status                string
+	BirthDate             time.Time
+	NikIndex              string
+	FamilyCardNumberIndex string
+	CreatedAt             time.Time
+}
+
+// DuplicateQuery holds what a record is matched on. Empty values are not matched.
+type DuplicateQuery struct {
+	ExcludeCandidateID    *uuid.UUID
+	NikIndex              string
+	FamilyCardNumberIndex string
+	NameKey               string
+	BirthDate             time.Time
+}
+
+// MatchedFields lists which identifiers of the query the record shares.
+func (d *DuplicateRecord) MatchedFields(q DuplicateQuery) []DuplicateField {
+	var fields []DuplicateField
+	if q.NikIndex != "" && d.NikIndex == q.NikIndex {
+		fields = append(fields, MatchNik)
+	}
+	if q.FamilyCardNumberIndex != "" && d.FamilyCardNumberIndex == q.FamilyCardNumberIndex {
+		fields = append(fields, MatchFamilyCardNumber)
+	}
+	if q.NameKey != "" && NormalizeName(d.Name) == q.NameKey && d.BirthDate.UTC().Format("2006-01-02") == q.BirthDate.UTC().Format("2006-01-02") {
+		fields = append(fields, MatchNameBirthDate)
+	}
+	return fields
+}
+
+type WarningCode string
+
+const (
+	WarningDuplicateNik        WarningCode = "duplicate_nik"
+	WarningDuplicateFamilyCard WarningCode = "duplicate_family_card"
+	WarningDuplicateIdentity   WarningCode = "duplicate_name_birth_date"
+	WarningAgeNotEligible      WarningCode = "age_not_eligible"
+	WarningEducationMismatch   WarningCode = "education_level_mismatch"
+	WarningCategoryInvalid     WarningCode = "category_invalid"
+)
+
+func candidateDuplicateQuery(c *FosterChildrenCandidate) DuplicateQuery {
+	return DuplicateQuery{
+		ExcludeCandidateID:    &c.ID,
+		NikIndex:              c.NikIndex,
+		FamilyCardNumberIndex: c.FamilyCardNumberIndex,
+		NameKey:               NormalizeName(c.Name),
+		BirthDate:             c.BirthDate,
+	}
+}
+
+func (d *DuplicateRecord) label() string {
+	if d.Source == SourceFosterChildren {
+		return fmt.Sprintf("anak asuh %s", d.Name)
+	}
+	return fmt.Sprintf("calon %s (status %s)", d.Name, d.Status)
+}
+
+// duplicateWarning explains one shared identifier. Only a NIK already registered as a foster
+// child blocks acceptance; the other matches are for the reviewer to judge.
+func duplicateWarning(d *DuplicateRecord, field DuplicateField) ScreeningWarningResponse {
+	switch field {
+	case MatchNik:
+		return ScreeningWarningResponse{
+			Code:     WarningDuplicateNik,
+			Message:  "NIK sama dengan " + d.label(),
+			Blocking: d.Source == SourceFosterChildren,
+		}
+	case MatchFamilyCardNumber:
+		return ScreeningWarningResponse{
+			Code:    WarningDuplicateFamilyCard,
+			Message: "Nomor kartu keluarga sama dengan " + d.label() + ", periksa apakah saudara kandung",
+		}
+	}
+	return ScreeningWarningResponse{
+		Code:    WarningDuplicateIdentity,
+		Message: "Nama dan tanggal lahir sama dengan " + d.label(),
+	}
+}
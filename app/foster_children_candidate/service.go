@@ -2,55 +2,90 @@ package foster_children_candidate
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/Vilamuzz/yota-backend/app/access_grant"
 	app_log "github.com/Vilamuzz/yota-backend/app/log"
 	"github.com/Vilamuzz/yota-backend/pkg"
 	"github.com/Vilamuzz/yota-backend/pkg/enum"
+	"github.com/Vilamuzz/yota-backend/pkg/pii"
 	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 // FosterChildrenCreator is a minimal interface to create a foster children record
-// after a candidate is fully accepted, avoiding a circular import.
+// after a candidate is fully accepted, avoiding a circular import. The record is created in
+// the transaction that accepts the candidate.
 type FosterChildrenCreator interface {
-	CreateFosterChildrenFromCandidate(ctx context.Context, candidateID, name, nik, profilePicture, familyCard, sktm, birthPlace, schoolName, address string, gender string, category string, educationLevel int, birthDate time.Time) error
+	CreateFosterChildrenFromCandidate(ctx context.Context, tx *gorm.DB, fosterChildrenID, candidateID, name, nik, familyCardNumber, profilePicture, familyCard, sktm, birthPlace, schoolName, address string, gender string, category string, educationLevel int, birthDate time.Time) error
 }
 
 type Service interface {
-	GetFosterChildrenCandidateList(ctx context.Context, params FosterChildrenCandidateAdminQueryParams) pkg.Response
+	GetFosterChildrenCandidateList(ctx context.Context, params FosterChildrenCandidateAdminQueryParams, accountID string) pkg.Response
 	GetMyFosterChildrenCandidateList(ctx context.Context, params FosterChildrenCandidateQueryParams) pkg.Response
-	GetFosterChildrenCandidateByID(ctx context.Context, id string) pkg.Response
+	GetFosterChildrenCandidateByID(ctx context.Context, id string, accountID string) pkg.Response
 	GetMyFosterChildrenCandidateByID(ctx context.Context, accountID string, id string) pkg.Response
 	CreateFosterChildrenCandidate(ctx context.Context, accountID string, req CreateFosterChildrenCandidateRequest) pkg.Response
-	AcceptFosterChildrenCandidate(ctx context.Context, id string, role enum.RoleName) pkg.Response
-	RejectFosterChildrenCandidate(ctx context.Context, id string, req RejectFosterChildrenCandidateRequest) pkg.Response
+	AcceptFosterChildrenCandidate(ctx context.Context, accountID string, id string, role enum.RoleName) pkg.Response
+	RejectFosterChildrenCandidate(ctx context.Context, accountID string, id string, role enum.RoleName, req RejectFosterChildrenCandidateRequest) pkg.Response
 	CancelFosterChildrenCandidate(ctx context.Context, accountID string, id string) pkg.Response
+	GetCandidateDocument(ctx context.Context, accountID string, role enum.RoleName, id string, document string) pkg.Response
+
+	GetReviewPipeline(ctx context.Context) pkg.Response
+	UpdateReviewPipeline(ctx context.Context, accountID string, req UpdateReviewPipelineRequest) pkg.Response
+	GetCandidateReviewTrail(ctx context.Context, id string, accountID string) pkg.Response
+	GetMyCandidateReviewSummary(ctx context.Context, accountID string, id string) pkg.Response
+	AssignReview(ctx context.Context, accountID string, role enum.RoleName, id string, reviewID string, req AssignReviewRequest) pkg.Response
+	UpdateReviewChecklist(ctx context.Context, accountID string, role enum.RoleName, id string, reviewID string, req UpdateReviewChecklistRequest) pkg.Response
+	AddReviewPhotos(ctx context.Context, accountID string, role enum.RoleName, id string, reviewID string, req AddReviewPhotosRequest) pkg.Response
+	CastReviewVote(ctx context.Context, accountID string, role enum.RoleName, id string, reviewID string, req CastReviewVoteRequest) pkg.Response
+	CompleteReview(ctx context.Context, accountID string, role enum.RoleName, id string, reviewID string, req CompleteReviewRequest) pkg.Response
 }
 
 type service struct {
 	repo               Repository
 	fosterChildrenRepo FosterChildrenCreator
+	accessGrantRepo    access_grant.Repository
 	logService         app_log.Service
 	s3Client           s3_pkg.Client
+	privateStorage     s3_pkg.PrivateClient
 	timeout            time.Duration
 	emailService       *pkg.EmailService
 }
 
-func NewService(repo Repository, fosterChildrenRepo FosterChildrenCreator, logService app_log.Service, s3Client s3_pkg.Client, timeout time.Duration) Service {
+func NewService(repo Repository, fosterChildrenRepo FosterChildrenCreator, accessGrantRepo access_grant.Repository, logService app_log.Service, s3Client s3_pkg.Client, privateStorage s3_pkg.PrivateClient, timeout time.Duration) Service {
 	return &service{
 		repo:               repo,
 		fosterChildrenRepo: fosterChildrenRepo,
+		accessGrantRepo:    accessGrantRepo,
 		logService:         logService,
 		s3Client:           s3Client,
+		privateStorage:     privateStorage,
 		timeout:            timeout,
 		emailService:       pkg.NewEmailService(),
 	}
 }
 
-func (s *service) GetFosterChildrenCandidateList(ctx context.Context, params FosterChildrenCandidateAdminQueryParams) pkg.Response {
+// applyAccessScope narrows options to the candidates granted to the account when it is scoped.
+// A scoped account without grants gets an empty list and sees nothing.
+func (s *service) applyAccessScope(ctx context.Context, accountID string, options map[string]interface{}) error {
+	ids, scoped, err := s.accessGrantRepo.FindAccessScope(ctx, accountID, access_grant.ResourceFosterChildrenCandidate)
+	if err != nil {
+		return err
+	}
+	if scoped {
+		options["ids"] = ids
+	}
+	return nil
+}
+
+func (s *service) GetFosterChildrenCandidateList(ctx context.Context, params FosterChildrenCandidateAdminQueryParams, accountID string) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
@@ -86,6 +121,13 @@ func (s *service) GetFosterChildrenCandidateList(ctx context.Context, params Fos
 	if params.Search != "" {
 		options["search"] = params.Search
 	}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "foster_children_candidate.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data calon anak asuh", nil, nil)
+	}
 
 	total, err := s.repo.CountFosterChildrenCandidates(ctx, options)
 	if err != nil {
@@ -176,22 +218,29 @@ func (s *service) GetMyFosterChildrenCandidateList(ctx context.Context, params F
 	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, ToFosterChildrenCandidateListResponse(candidates, pagination))
 }
 
-func (s *service) GetFosterChildrenCandidateByID(ctx context.Context, id string) pkg.Response {
+func (s *service) GetFosterChildrenCandidateByID(ctx context.Context, id string, accountID string) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	if err := uuid.Validate(id); err != nil {
-		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format tidak valid"}, nil)
+	candidate, res := s.findCandidate(ctx, id, accountID)
+	if res != nil {
+		return *res
 	}
 
-	candidate, err := s.repo.FindOneFosterChildrenCandidate(ctx, map[string]interface{}{
-		"id": id,
-	})
-	if err != nil {
-		return pkg.NewResponse(http.StatusNotFound, "Calon tidak ditemukan", nil, nil)
+	detail := FosterChildrenCandidateDetailResponse{FosterChildrenCandidateResponse: candidate.ToFosterChildrenCandidateResponse()}
+	if candidate.IsUnderReview() {
+		screening, err := s.screenCandidate(ctx, candidate)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":    "foster_children_candidate.service",
+				"candidate_id": id,
+			}).WithError(err).Error("failed to screen candidate")
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal memeriksa data calon", nil, nil)
+		}
+		detail.Screening = screening
 	}
 
-	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, candidate.ToFosterChildrenCandidateResponse())
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, detail)
 }
 
 func (s *service) GetMyFosterChildrenCandidateByID(ctx context.Context, accountID string, id string) pkg.Response {
@@ -232,6 +281,13 @@ func (s *service) CreateFosterChildrenCandidate(ctx context.Context, accountID s
 	}
 	if req.Category == "" {
 		errValidation["category"] = "Kategori wajib diisi"
+	} else if !req.Category.IsValid() {
+		errValidation["category"] = "Kategori tidak valid"
+	}
+	if req.FamilyCardNumber == "" {
+		errValidation["familyCardNumber"] = "Nomor kartu keluarga wajib diisi"
+	} else if !pkg.IsValidFamilyCardNumber(req.FamilyCardNumber) {
+		errValidation["familyCardNumber"] = "Nomor kartu keluarga harus 16 digit angka"
 	}
 	if req.BirthDate == "" {
 		errValidation["birthDate"] = "Tanggal lahir wajib diisi"
@@ -278,23 +334,41 @@ func (s *service) CreateFosterChildrenCandidate(ctx context.Context, accountID s
 	if err != nil {
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"birthDate": "Format tanggal tidak valid, diharapkan YYYY-MM-DD"}, nil)
 	}
+	if msg := checkAge(req.Category, birthDate, time.Now()); msg != "" {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"birthDate": msg}, nil)
+	}
+
+	// The same child may not be submitted twice; other possible duplicates are left to the reviewers.
+	duplicates, err := s.repo.FindDuplicates(ctx, DuplicateQuery{NikIndex: pii.BlindIndex(req.Nik)})
+	if err != nil {
+		logrus.WithError(err).Error("failed to check duplicate candidate")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memeriksa data calon", nil, nil)
+	}
+	if len(duplicates) > 0 {
+		// foster children are listed first, so a registered child takes precedence
+		msg := "Anak dengan NIK ini sudah diajukan dan sedang diproses"
+		if duplicates[0].Source == SourceFosterChildren {
+			msg = "Anak dengan NIK ini sudah terdaftar sebagai anak asuh"
+		}
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"nik": msg}, nil)
+	}
 
 	profilePictureURL, err := s.s3Client.UploadFile(ctx, req.ProfilePicture, "foster-children-candidates")
 	if err != nil {
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah foto profil", nil, nil)
 	}
 
-	familyCardURL, err := s.s3Client.UploadFile(ctx, req.FamilyCard, "foster-children-candidates")
+	familyCardURL, err := s.privateStorage.UploadFile(ctx, req.FamilyCard, "foster-children-candidates")
 	if err != nil {
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah kartu keluarga", nil, nil)
 	}
 
-	sktmURL, err := s.s3Client.UploadFile(ctx, req.SKTM, "foster-children-candidates")
+	sktmURL, err := s.privateStorage.UploadFile(ctx, req.SKTM, "foster-children-candidates")
 	if err != nil {
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah SKTM", nil, nil)
 	}
 
-	submitterIDCardURL, err := s.s3Client.UploadFile(ctx, req.SubmitterIDCard, "foster-children-candidates")
+	submitterIDCardURL, err := s.privateStorage.UploadFile(ctx, req.SubmitterIDCard, "foster-children-candidates")
 	if err != nil {
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah KTP", nil, nil)
 	}
@@ -303,7 +377,8 @@ func (s *service) CreateFosterChildrenCandidate(ctx context.Context, accountID s
 	candidate := &FosterChildrenCandidate{
 		ID:               uuid.New(),
 		Name:             req.Name,
-		Nik:              req.Nik,
+		Nik:              pii.String(req.Nik),
+		NikIndex:         pii.BlindIndex(req.Nik),
 		ProfilePicture:   profilePictureURL,
 		Gender:           req.Gender,
 		Category:         req.Category,
@@ -315,13 +390,16 @@ func (s *service) CreateFosterChildrenCandidate(ctx context.Context, accountID s
 		FamilyCard:       familyCardURL,
 		SKTM:             sktmURL,
 		SubmitterName:    req.SubmitterName,
-		SubmitterPhone:   req.SubmitterPhone,
+		SubmitterPhone:   pii.String(req.SubmitterPhone),
 		SubmitterAddress: req.SubmitterAddress,
 		SubmitterIDCard:  submitterIDCardURL,
 		SubmittedBy:      uuid.MustParse(accountID),
 		Status:           StatusPending,
 		CreatedAt:        now,
 		UpdatedAt:        now,
+
+		FamilyCardNumber:      pii.String(req.FamilyCardNumber),
+		FamilyCardNumberIndex: pii.BlindIndex(req.FamilyCardNumber),
 	}
 
 	if err := s.repo.CreateFosterChildrenCandidate(ctx, candidate); err != nil {
@@ -329,11 +407,67 @@ func (s *service) CreateFosterChildrenCandidate(ctx context.Context, accountID s
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membuat calon anak asuh", nil, nil)
 	}
 
+	// A missing review is created again the first time the candidate is opened.
+	if _, err := s.ensureReviews(ctx, candidate); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":    "foster_children_candidate.service",
+			"candidate_id": candidate.ID.String(),
+		}).WithError(err).Error("failed to start candidate review")
+	}
+
 	s.logService.CreateLog(ctx, &accountID, "CREATE", "foster_children_candidate", candidate.ID.String(), nil, candidate.ToFosterChildrenCandidateResponse())
 	return pkg.NewResponse(http.StatusCreated, "Calon anak asuh berhasil dibuat", nil, candidate.ToFosterChildrenCandidateResponse())
 }
 
-func (s *service) AcceptFosterChildrenCandidate(ctx context.Context, id string, role enum.RoleName) pkg.Response {
+// AcceptFosterChildrenCandidate passes the candidate's current review stage. Passing the last
+// stage accepts the candidate.
+func (s *service) AcceptFosterChildrenCandidate(ctx context.Context, accountID string, id string, role enum.RoleName) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	candidate, res := s.findCandidate(ctx, id, accountID)
+	if res != nil {
+		return *res
+	}
+	reviews, res := s.candidateReviews(ctx, candidate)
+	if res != nil {
+		return *res
+	}
+
+	current := currentReview(reviews)
+	if current == nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Calon tidak sedang dalam proses peninjauan", nil, nil)
+	}
+	return s.decideReview(ctx, accountID, role, candidate, reviews, current, ReviewPassed, "")
+}
+
+// RejectFosterChildrenCandidate fails the candidate's current review stage, which rejects the
+// candidate.
+func (s *service) RejectFosterChildrenCandidate(ctx context.Context, accountID string, id string, role enum.RoleName, req RejectFosterChildrenCandidateRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if strings.TrimSpace(req.RejectionReason) == "" {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"rejectionReason": "Alasan penolakan wajib diisi"}, nil)
+	}
+
+	candidate, res := s.findCandidate(ctx, id, accountID)
+	if res != nil {
+		return *res
+	}
+	reviews, res := s.candidateReviews(ctx, candidate)
+	if res != nil {
+		return *res
+	}
+
+	current := currentReview(reviews)
+	if current == nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Calon tidak sedang dalam proses peninjauan", nil, nil)
+	}
+	return s.decideReview(ctx, accountID, role, candidate, reviews, current, ReviewFailed, req.RejectionReason)
+}
+
+func (s *service) CancelFosterChildrenCandidate(ctx context.Context, accountID string, id string) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
@@ -344,150 +478,836 @@ func (s *service) AcceptFosterChildrenCandidate(ctx context.Context, id string,
 		return pkg.NewResponse(http.StatusNotFound, "Calon tidak ditemukan", nil, nil)
 	}
 
-	var nextStatus Status
-	var message string
+	if existing.SubmittedBy.String() != accountID {
+		return pkg.NewResponse(http.StatusForbidden, "Anda tidak memiliki akses untuk membatalkan calon ini", nil, nil)
+	}
 
-	switch role {
-	case enum.RoleSocialManager:
-		if existing.Status != StatusPending {
-			return pkg.NewResponse(http.StatusBadRequest, "Hanya calon dengan status pending yang dapat disetujui oleh Koordinator Sosial", nil, nil)
-		}
-		nextStatus = StatusSocialManagerAccepted
-		message = "Calon berhasil disetujui oleh Koordinator Sosial"
-	case enum.RoleChairman:
-		if existing.Status != StatusSocialManagerAccepted {
-			return pkg.NewResponse(http.StatusBadRequest, "Hanya calon yang telah disetujui oleh Koordinator Sosial yang dapat disetujui oleh Ketua Yayasan", nil, nil)
-		}
-		nextStatus = StatusAccepted
-		message = "Calon berhasil disetujui oleh Ketua Yayasan"
-	default:
-		return pkg.NewResponse(http.StatusForbidden, "Anda tidak memiliki akses untuk melakukan tindakan ini", nil, nil)
+	if existing.Status != StatusPending {
+		return pkg.NewResponse(http.StatusBadRequest, "Hanya calon dengan status pending yang dapat dibatalkan", nil, nil)
 	}
 
 	updateData := map[string]interface{}{
-		"status":           nextStatus,
-		"rejection_reason": "",
-		"updated_at":       time.Now(),
+		"status":     StatusCancelled,
+		"updated_at": time.Now(),
 	}
 
 	if err := s.repo.UpdateFosterChildrenCandidate(ctx, id, updateData); err != nil {
-		logrus.WithError(err).Error("failed to update candidate status")
-		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memperbarui status calon", nil, nil)
+		logrus.WithError(err).Error("failed to cancel candidate")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membatalkan calon", nil, nil)
 	}
 
-	if nextStatus == StatusAccepted {
-		if err := s.fosterChildrenRepo.CreateFosterChildrenFromCandidate(
-			ctx,
-			existing.ID.String(),
-			existing.Name,
-			existing.Nik,
-			existing.ProfilePicture,
-			existing.FamilyCard,
-			existing.SKTM,
-			existing.BirthPlace,
-			existing.SchoolName,
-			existing.Address,
-			string(existing.Gender),
-			string(existing.Category),
-			existing.EducationLevel,
-			existing.BirthDate,
-		); err != nil {
-			logrus.WithError(err).Error("failed to create foster children from accepted candidate")
-		} else {
-			if existing.Account.Email != "" {
-				go func(email, submitterName, candidateName string) {
-					if err := s.emailService.SendFosterChildrenCandidateAcceptedEmail(email, submitterName, candidateName); err != nil {
-						logrus.WithFields(logrus.Fields{
-							"component":      "foster_children_candidate.service",
-							"email":          email,
-							"candidate_name": candidateName,
-						}).WithError(err).Error("failed to send candidate accepted email asynchronously")
-					}
-				}(existing.Account.Email, existing.SubmitterName, existing.Name)
-			}
-		}
+	actorID := uuid.MustParse(accountID)
+	event := newReviewEvent(existing.ID, nil, ActionReviewClosed, &actorID, "Dibatalkan oleh pengaju")
+	if err := s.repo.CloseCandidateReviews(ctx, id, event); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":    "foster_children_candidate.service",
+			"candidate_id": id,
+		}).WithError(err).Error("failed to close reviews of cancelled candidate")
 	}
 
-	s.logService.CreateLog(ctx, nil, "UPDATE", "foster_children_candidate", id, existing.ToFosterChildrenCandidateResponse(), updateData)
-	return pkg.NewResponse(http.StatusOK, message, nil, nil)
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "foster_children_candidate", id, existing.ToFosterChildrenCandidateResponse(), updateData)
+	return pkg.NewResponse(http.StatusOK, "Calon berhasil dibatalkan", nil, nil)
 }
 
-func (s *service) RejectFosterChildrenCandidate(ctx context.Context, id string, req RejectFosterChildrenCandidateRequest) pkg.Response {
+// GetCandidateDocument hands out a short-lived link to one of the candidate's sensitive uploads.
+// Reviewers can open any candidate; submitters only their own.
+func (s *service) GetCandidateDocument(ctx context.Context, accountID string, role enum.RoleName, id string, document string) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	if req.RejectionReason == "" {
-		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"rejectionReason": "Alasan penolakan wajib diisi"}, nil)
+	if err := uuid.Validate(id); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format tidak valid"}, nil)
 	}
 
-	existing, err := s.repo.FindOneFosterChildrenCandidate(ctx, map[string]interface{}{
-		"id": id,
-	})
+	var candidate *FosterChildrenCandidate
+	switch role {
+	case enum.RoleSocialManager, enum.RoleChairman:
+		var res *pkg.Response
+		candidate, res = s.findCandidate(ctx, id, accountID)
+		if res != nil {
+			return *res
+		}
+	case enum.RoleOrangTuaAsuh:
+		var err error
+		candidate, err = s.repo.FindOneFosterChildrenCandidate(ctx, map[string]interface{}{
+			"id": id,
+		})
+		if err != nil {
+			return pkg.NewResponse(http.StatusNotFound, "Calon tidak ditemukan", nil, nil)
+		}
+		if candidate.SubmittedBy.String() != accountID {
+			return pkg.NewResponse(http.StatusForbidden, "Anda tidak memiliki akses untuk melihat dokumen ini", nil, nil)
+		}
+	default:
+		return pkg.NewResponse(http.StatusForbidden, "Anda tidak memiliki akses untuk melakukan tindakan ini", nil, nil)
+	}
+
+	value, ok := candidate.Document(document)
+	if !ok {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"document": "Jenis dokumen tidak valid"}, nil)
+	}
+	if value == "" {
+		return pkg.NewResponse(http.StatusNotFound, "Dokumen tidak ditemukan", nil, nil)
+	}
+
+	link, err := s3_pkg.SignDocument(ctx, s.privateStorage, value)
 	if err != nil {
-		return pkg.NewResponse(http.StatusNotFound, "Calon tidak ditemukan", nil, nil)
+		logrus.WithError(err).Error("failed to sign candidate document url")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membuat tautan dokumen", nil, nil)
 	}
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, link)
+}
 
-	if existing.Status != StatusPending && existing.Status != StatusSocialManagerAccepted {
-		return pkg.NewResponse(http.StatusBadRequest, "Hanya calon dengan status pending atau disetujui Koordinator Sosial yang dapat ditolak", nil, nil)
+const reviewPhotoFolder = "foster-children-candidates/reviews"
+
+// findCandidate loads the candidate within the access scope of the reviewing account, so a
+// candidate outside it looks missing. Submitter routes pass an empty account ID.
+func (s *service) findCandidate(ctx context.Context, id string, accountID string) (*FosterChildrenCandidate, *pkg.Response) {
+	if err := uuid.Validate(id); err != nil {
+		res := pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format tidak valid"}, nil)
+		return nil, &res
+	}
+	options := map[string]interface{}{"id": id}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "foster_children_candidate.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		res := pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data calon anak asuh", nil, nil)
+		return nil, &res
+	}
+	candidate, err := s.repo.FindOneFosterChildrenCandidate(ctx, options)
+	if err != nil {
+		res := pkg.NewResponse(http.StatusNotFound, "Calon tidak ditemukan", nil, nil)
+		return nil, &res
 	}
+	return candidate, nil
+}
 
-	updateData := map[string]interface{}{
-		"status":           StatusRejected,
-		"rejection_reason": req.RejectionReason,
-		"updated_at":       time.Now(),
+func (s *service) candidateReviews(ctx context.Context, candidate *FosterChildrenCandidate) ([]CandidateReview, *pkg.Response) {
+	reviews, err := s.ensureReviews(ctx, candidate)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":    "foster_children_candidate.service",
+			"candidate_id": candidate.ID.String(),
+		}).WithError(err).Error("failed to load candidate review")
+		res := pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data peninjauan calon", nil, nil)
+		return nil, &res
+	}
+	return reviews, nil
+}
+
+// findCandidateStage loads the candidate, its stages and the stage addressed by reviewID.
+func (s *service) findCandidateStage(ctx context.Context, id string, reviewID string, accountID string) (*FosterChildrenCandidate, []CandidateReview, *CandidateReview, *pkg.Response) {
+	if err := uuid.Validate(reviewID); err != nil {
+		res := pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"reviewId": "Format tidak valid"}, nil)
+		return nil, nil, nil, &res
+	}
+	candidate, res := s.findCandidate(ctx, id, accountID)
+	if res != nil {
+		return nil, nil, nil, res
+	}
+	reviews, res := s.candidateReviews(ctx, candidate)
+	if res != nil {
+		return nil, nil, nil, res
+	}
+	for i := range reviews {
+		if reviews[i].ID.String() == reviewID {
+			return candidate, reviews, &reviews[i], nil
+		}
 	}
+	notFound := pkg.NewResponse(http.StatusNotFound, "Tahap peninjauan tidak ditemukan", nil, nil)
+	return nil, nil, nil, &notFound
+}
 
-	if err := s.repo.UpdateFosterChildrenCandidate(ctx, id, updateData); err != nil {
-		logrus.WithError(err).Error("failed to update candidate status")
+// pipelineStages returns the configured pipeline, saving the default one on first use.
+func (s *service) pipelineStages(ctx context.Context) ([]ReviewStage, error) {
+	stages, err := s.repo.FindReviewStages(ctx)
+	if err != nil || len(stages) > 0 {
+		return stages, err
+	}
+	stages = buildReviewStages(defaultReviewStages)
+	if err := s.repo.ReplaceReviewStages(ctx, stages); err != nil {
+		return nil, err
+	}
+	return stages, nil
+}
+
+// ensureReviews returns the candidate's review stages, copying them from the pipeline when the
+// candidate has none yet. Candidates the Koordinator Sosial approved under the old two-step flow
+// continue at the last stage.
+func (s *service) ensureReviews(ctx context.Context, candidate *FosterChildrenCandidate) ([]CandidateReview, error) {
+	reviews, err := s.repo.FindCandidateReviews(ctx, candidate.ID.String())
+	if err != nil || len(reviews) > 0 {
+		return reviews, err
+	}
+	if candidate.Status != StatusPending && candidate.Status != StatusSocialManagerAccepted {
+		// decided before the pipeline existed, there is nothing to review
+		return reviews, nil
+	}
+
+	stages, err := s.pipelineStages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(stages) == 0 {
+		return nil, errors.New("review pipeline has no stages")
+	}
+
+	now := time.Now()
+	reviews = make([]CandidateReview, 0, len(stages))
+	for _, stage := range stages {
+		review := CandidateReview{
+			ID:            uuid.New(),
+			CandidateID:   candidate.ID,
+			StageKey:      stage.Key,
+			StageName:     stage.Name,
+			StageType:     stage.Type,
+			ApproverRole:  stage.ApproverRole,
+			Position:      stage.Position,
+			RequiredVotes: stage.RequiredVotes,
+			DueDays:       stage.DueDays,
+			Status:        ReviewWaiting,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		for _, item := range stage.ChecklistItems {
+			review.Checklist = append(review.Checklist, CandidateReviewChecklistItem{
+				ID:       uuid.New(),
+				ReviewID: review.ID,
+				Label:    item.Label,
+				Required: item.Required,
+				Position: item.Position,
+			})
+		}
+		reviews = append(reviews, review)
+	}
+
+	start := 0
+	var candidateData map[string]interface{}
+	if candidate.Status == StatusSocialManagerAccepted {
+		start = len(reviews) - 1
+		for i := 0; i < start; i++ {
+			reviews[i].Status = ReviewPassed
+			reviews[i].CompletedAt = &now
+			reviews[i].Notes = "Disetujui Koordinator Sosial sebelum alur peninjauan bertahap"
+		}
+		candidateData = map[string]interface{}{"status": StatusInReview, "updated_at": now}
+	}
+	reviews[start].Status = ReviewInProgress
+	reviews[start].StartedAt = &now
+	reviews[start].DueDate = dueDateFrom(now, reviews[start].DueDays)
+
+	events := []CandidateReviewEvent{
+		newReviewEvent(candidate.ID, nil, ActionReviewStarted, nil, ""),
+		newReviewEvent(candidate.ID, &reviews[start].ID, ActionStageStarted, nil, reviews[start].StageName),
+	}
+	if err := s.repo.StartCandidateReviews(ctx, candidate.ID.String(), reviews, candidateData, events); err != nil {
+		// a concurrent request may have started the review first
+		if existing, findErr := s.repo.FindCandidateReviews(ctx, candidate.ID.String()); findErr == nil && len(existing) > 0 {
+			return existing, nil
+		}
+		return nil, err
+	}
+	if candidateData != nil {
+		candidate.Status = StatusInReview
+	}
+	return reviews, nil
+}
+
+// decideReview passes or fails a running stage. A failed stage rejects the candidate; passing the
+// last stage accepts it and creates the foster children record.
+func (s *service) decideReview(ctx context.Context, accountID string, role enum.RoleName, candidate *FosterChildrenCandidate, reviews []CandidateReview, review *CandidateReview, outcome ReviewStatus, notes string) pkg.Response {
+	if review.Status != ReviewInProgress {
+		return pkg.NewResponse(http.StatusBadRequest, "Tahap peninjauan tidak sedang berjalan", nil, nil)
+	}
+	if !review.CanAct(accountID, role) {
+		return pkg.NewResponse(http.StatusForbidden, "Anda tidak memiliki akses untuk memutuskan tahap ini", nil, nil)
+	}
+
+	notes = strings.TrimSpace(notes)
+	if outcome == ReviewPassed {
+		errValidation := make(map[string]string)
+		for _, item := range review.Checklist {
+			if item.Required && !item.Checked {
+				errValidation["checklist"] = "Semua item wajib pada daftar periksa harus dicentang"
+				break
+			}
+		}
+		if review.StageType == StageVote {
+			approvals, rejections := review.VoteTally()
+			if approvals < review.RequiredVotes {
+				errValidation["votes"] = fmt.Sprintf("Dibutuhkan minimal %d suara setuju", review.RequiredVotes)
+			} else if approvals <= rejections {
+				errValidation["votes"] = "Suara setuju harus lebih banyak dari suara menolak"
+			}
+		}
+		if len(errValidation) > 0 {
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+		}
+	}
+
+	now := time.Now()
+	actorID := uuid.MustParse(accountID)
+	action := ActionStagePassed
+	if outcome == ReviewFailed {
+		action = ActionStageFailed
+	}
+	completion := ReviewCompletion{
+		ReviewID: review.ID,
+		ReviewData: map[string]interface{}{
+			"status":       outcome,
+			"notes":        notes,
+			"completed_at": now,
+			"completed_by": actorID,
+			"updated_at":   now,
+		},
+		CandidateID: candidate.ID,
+		Events:      []CandidateReviewEvent{newReviewEvent(candidate.ID, &review.ID, action, &actorID, notes)},
+	}
+
+	var next *CandidateReview
+	if outcome == ReviewPassed {
+		next = nextReview(reviews, review)
+	}
+	switch {
+	case outcome == ReviewFailed:
+		completion.CloseRemaining = true
+		completion.CandidateData = map[string]interface{}{
+			"status":           StatusRejected,
+			"rejection_reason": notes,
+			"updated_at":       now,
+		}
+	case next != nil:
+		dueDate := next.DueDate
+		if dueDate == nil {
+			dueDate = dueDateFrom(now, next.DueDays)
+		}
+		completion.NextReviewID = &next.ID
+		completion.NextData = map[string]interface{}{
+			"status":     ReviewInProgress,
+			"started_at": now,
+			"due_date":   dueDate,
+			"updated_at": now,
+		}
+		completion.CandidateData = map[string]interface{}{
+			"status":     StatusInReview,
+			"updated_at": now,
+		}
+		completion.Events = append(completion.Events, newReviewEvent(candidate.ID, &next.ID, ActionStageStarted, &actorID, next.StageName))
+	default:
+		registered, err := s.registeredFosterChildren(ctx, candidate)
+		if err != nil {
+			logrus.WithError(err).Error("failed to check registered foster children")
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal memeriksa data calon", nil, nil)
+		}
+		if registered != nil {
+			return pkg.NewResponse(http.StatusConflict, fmt.Sprintf("Anak dengan NIK yang sama sudah terdaftar sebagai anak asuh %s", registered.Name), nil, nil)
+		}
+		completion.CandidateData = map[string]interface{}{
+			"status":           StatusAccepted,
+			"rejection_reason": "",
+			"updated_at":       now,
+		}
+	}
+
+	// Accepting creates the foster child in the same transaction, from copies of the
+	// candidate's files that are removed again if the transaction does not commit.
+	var docs FosterChildrenDocuments
+	accepting := outcome == ReviewPassed && next == nil
+	if accepting {
+		var err error
+		docs, err = CopyDocumentsForFosterChildren(ctx, s.s3Client, s.privateStorage, candidate)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":    "foster_children_candidate.service",
+				"candidate_id": candidate.ID.String(),
+			}).WithError(err).Error("failed to copy candidate documents")
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal menyalin dokumen calon", nil, nil)
+		}
+		fosterChildrenID := uuid.New()
+		completion.Accept = func(tx *gorm.DB) error {
+			return CreateFosterChildrenInTx(ctx, tx, s.fosterChildrenRepo, candidate, fosterChildrenID, docs)
+		}
+	}
+
+	if err := s.repo.CompleteReview(ctx, completion); err != nil {
+		if accepting {
+			docs.Delete(ctx, s.s3Client, s.privateStorage)
+		}
+		if errors.Is(err, ErrReviewNotInProgress) {
+			return pkg.NewResponse(http.StatusConflict, "Tahap peninjauan sudah diputuskan oleh peninjau lain", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":    "foster_children_candidate.service",
+			"candidate_id": candidate.ID.String(),
+			"review_id":    review.ID.String(),
+		}).WithError(err).Error("failed to complete review stage")
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memperbarui status calon", nil, nil)
 	}
 
-	s.logService.CreateLog(ctx, nil, "UPDATE", "foster_children_candidate", id, existing.ToFosterChildrenCandidateResponse(), updateData)
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "foster_children_candidate", candidate.ID.String(), candidate.ToFosterChildrenCandidateResponse(), completion.CandidateData)
 
-	if existing.Account.Email != "" {
-		go func(email, submitterName, candidateName, rejectionReason string) {
-			if err := s.emailService.SendFosterChildrenCandidateRejectedEmail(email, submitterName, candidateName, rejectionReason); err != nil {
-				logrus.WithFields(logrus.Fields{
-					"component":      "foster_children_candidate.service",
-					"email":          email,
-					"candidate_name": candidateName,
-				}).WithError(err).Error("failed to send candidate rejected email asynchronously")
+	switch {
+	case outcome == ReviewFailed:
+		s.notifyCandidateRejected(candidate, notes)
+		return pkg.NewResponse(http.StatusOK, "Calon berhasil ditolak", nil, nil)
+	case next != nil:
+		return pkg.NewResponse(http.StatusOK, fmt.Sprintf("Tahap %s selesai, calon lanjut ke tahap %s", review.StageName, next.StageName), nil, nil)
+	}
+	s.notifyCandidateAccepted(candidate)
+	return pkg.NewResponse(http.StatusOK, "Calon berhasil diterima", nil, nil)
+}
+
+func (s *service) notifyCandidateAccepted(candidate *FosterChildrenCandidate) {
+	if candidate.Account.Email == "" {
+		return
+	}
+	go func(email, submitterName, candidateName string) {
+		if err := s.emailService.SendFosterChildrenCandidateAcceptedEmail(email, submitterName, candidateName); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":      "foster_children_candidate.service",
+				"email":          email,
+				"candidate_name": candidateName,
+			}).WithError(err).Error("failed to send candidate accepted email asynchronously")
+		}
+	}(candidate.Account.Email, candidate.SubmitterName, candidate.Name)
+}
+
+func (s *service) notifyCandidateRejected(candidate *FosterChildrenCandidate, rejectionReason string) {
+	if candidate.Account.Email == "" {
+		return
+	}
+	go func(email, submitterName, candidateName, rejectionReason string) {
+		if err := s.emailService.SendFosterChildrenCandidateRejectedEmail(email, submitterName, candidateName, rejectionReason); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":      "foster_children_candidate.service",
+				"email":          email,
+				"candidate_name": candidateName,
+			}).WithError(err).Error("failed to send candidate rejected email asynchronously")
+		}
+	}(candidate.Account.Email, candidate.SubmitterName, candidate.Name, rejectionReason)
+}
+
+func (s *service) GetReviewPipeline(ctx context.Context) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	stages, err := s.pipelineStages(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("failed to load review pipeline")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil alur peninjauan", nil, nil)
+	}
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, toReviewStageResponses(stages))
+}
+
+// UpdateReviewPipeline replaces the pipeline. Candidates already under review keep the stages
+// they started with.
+func (s *service) UpdateReviewPipeline(ctx context.Context, accountID string, req UpdateReviewPipelineRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	errValidation := make(map[string]string)
+	if len(req.Stages) == 0 {
+		errValidation["stages"] = "Minimal satu tahap peninjauan wajib diisi"
+	}
+	keys := make(map[string]bool)
+	templates := make([]ReviewStage, 0, len(req.Stages))
+	for i, st := range req.Stages {
+		field := fmt.Sprintf("stages[%d]", i)
+		key := strings.TrimSpace(st.Key)
+		if key == "" {
+			errValidation[field+".key"] = "Kunci tahap wajib diisi"
+		} else if keys[key] {
+			errValidation[field+".key"] = "Kunci tahap tidak boleh sama"
+		}
+		keys[key] = true
+		if strings.TrimSpace(st.Name) == "" {
+			errValidation[field+".name"] = "Nama tahap wajib diisi"
+		}
+		if !st.Type.IsValid() {
+			errValidation[field+".type"] = "Jenis tahap tidak valid"
+		}
+		if !isReviewerRole(st.ApproverRole) {
+			errValidation[field+".approverRole"] = "Peninjau harus Koordinator Sosial atau Ketua Yayasan"
+		}
+		if st.DueDays < 0 {
+			errValidation[field+".dueDays"] = "Batas waktu tidak boleh negatif"
+		}
+		if st.Type == StageVote && st.RequiredVotes < 1 {
+			errValidation[field+".requiredVotes"] = "Jumlah suara setuju minimal wajib diisi untuk tahap pemungutan suara"
+		}
+
+		stage := ReviewStage{
+			Key:          key,
+			Name:         strings.TrimSpace(st.Name),
+			Description:  strings.TrimSpace(st.Description),
+			Type:         st.Type,
+			ApproverRole: st.ApproverRole,
+			DueDays:      st.DueDays,
+		}
+		if st.Type == StageVote {
+			stage.RequiredVotes = st.RequiredVotes
+		}
+		for j, item := range st.Checklist {
+			label := strings.TrimSpace(item.Label)
+			if label == "" {
+				errValidation[fmt.Sprintf("%s.checklist[%d].label", field, j)] = "Label wajib diisi"
 			}
-		}(existing.Account.Email, existing.SubmitterName, existing.Name, req.RejectionReason)
+			stage.ChecklistItems = append(stage.ChecklistItems, ReviewStageChecklistItem{Label: label, Required: item.Required})
+		}
+		templates = append(templates, stage)
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	old, err := s.repo.FindReviewStages(ctx)
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil alur peninjauan", nil, nil)
+	}
+
+	stages := buildReviewStages(templates)
+	if err := s.repo.ReplaceReviewStages(ctx, stages); err != nil {
+		logrus.WithError(err).Error("failed to replace review pipeline")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memperbarui alur peninjauan", nil, nil)
 	}
 
-	return pkg.NewResponse(http.StatusOK, "Calon berhasil ditolak", nil, nil)
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "candidate_review_pipeline", "", toReviewStageResponses(old), toReviewStageResponses(stages))
+	return pkg.NewResponse(http.StatusOK, "Alur peninjauan berhasil diperbarui", nil, toReviewStageResponses(stages))
 }
 
-func (s *service) CancelFosterChildrenCandidate(ctx context.Context, accountID string, id string) pkg.Response {
+func (s *service) GetCandidateReviewTrail(ctx context.Context, id string, accountID string) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	existing, err := s.repo.FindOneFosterChildrenCandidate(ctx, map[string]interface{}{
-		"id": id,
-	})
+	candidate, res := s.findCandidate(ctx, id, accountID)
+	if res != nil {
+		return *res
+	}
+	reviews, res := s.candidateReviews(ctx, candidate)
+	if res != nil {
+		return *res
+	}
+	events, err := s.repo.FindReviewEvents(ctx, id)
 	if err != nil {
-		return pkg.NewResponse(http.StatusNotFound, "Calon tidak ditemukan", nil, nil)
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data peninjauan calon", nil, nil)
 	}
 
-	if existing.SubmittedBy.String() != accountID {
-		return pkg.NewResponse(http.StatusForbidden, "Anda tidak memiliki akses untuk membatalkan calon ini", nil, nil)
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, toCandidateReviewTrailResponse(ctx, s.privateStorage, candidate, reviews, events))
+}
+
+// GetMyCandidateReviewSummary shows the submitter how far the review has come.
+func (s *service) GetMyCandidateReviewSummary(ctx context.Context, accountID string, id string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	candidate, res := s.findCandidate(ctx, id, "")
+	if res != nil {
+		return *res
+	}
+	if candidate.SubmittedBy.String() != accountID {
+		return pkg.NewResponse(http.StatusForbidden, "Anda tidak memiliki akses untuk melihat calon ini", nil, nil)
+	}
+	reviews, res := s.candidateReviews(ctx, candidate)
+	if res != nil {
+		return *res
 	}
 
-	if existing.Status != StatusPending {
-		return pkg.NewResponse(http.StatusBadRequest, "Hanya calon dengan status pending yang dapat dibatalkan", nil, nil)
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, toCandidateReviewSummaryResponse(candidate, reviews))
+}
+
+// AssignReview sets who handles a stage and, optionally, its due date. Stages can be assigned
+// before they start.
+func (s *service) AssignReview(ctx context.Context, accountID string, role enum.RoleName, id string, reviewID string, req AssignReviewRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	candidate, _, review, res := s.findCandidateStage(ctx, id, reviewID, accountID)
+	if res != nil {
+		return *res
+	}
+	if role != review.ApproverRole && role != enum.RoleChairman {
+		return pkg.NewResponse(http.StatusForbidden, "Hanya peninjau tahap ini atau Ketua Yayasan yang dapat menugaskan tahap", nil, nil)
+	}
+	if review.Status != ReviewWaiting && review.Status != ReviewInProgress {
+		return pkg.NewResponse(http.StatusBadRequest, "Tahap peninjauan sudah selesai", nil, nil)
+	}
+
+	errValidation := make(map[string]string)
+	if err := uuid.Validate(req.AssigneeID); err != nil {
+		errValidation["assigneeId"] = "Format tidak valid"
+	}
+	var dueDate time.Time
+	if req.DueDate != "" {
+		now := time.Now()
+		date, err := time.ParseInLocation("2006-01-02", req.DueDate, now.Location())
+		if err != nil {
+			errValidation["dueDate"] = "Format tanggal tidak valid, diharapkan YYYY-MM-DD"
+		} else if date.Before(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())) {
+			errValidation["dueDate"] = "Tanggal tenggat tidak boleh di masa lalu"
+		}
+		// due at the end of the chosen day
+		dueDate = date.AddDate(0, 0, 1).Add(-time.Second)
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	eligible, err := s.repo.AccountHasAnyRole(ctx, req.AssigneeID, reviewerRoles)
+	if err != nil {
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memeriksa akun peninjau", nil, nil)
+	}
+	if !eligible {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"assigneeId": "Akun harus memiliki peran Koordinator Sosial atau Ketua Yayasan yang aktif"}, nil)
 	}
 
 	updateData := map[string]interface{}{
-		"status":     StatusCancelled,
-		"updated_at": time.Now(),
+		"assignee_id": uuid.MustParse(req.AssigneeID),
+		"updated_at":  time.Now(),
+	}
+	note := "Penanggung jawab: " + req.AssigneeID
+	if req.DueDate != "" {
+		updateData["due_date"] = dueDate
+		note += ", tenggat " + req.DueDate
 	}
 
-	if err := s.repo.UpdateFosterChildrenCandidate(ctx, id, updateData); err != nil {
-		logrus.WithError(err).Error("failed to cancel candidate")
-		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membatalkan calon", nil, nil)
+	actorID := uuid.MustParse(accountID)
+	event := newReviewEvent(candidate.ID, &review.ID, ActionAssigned, &actorID, note)
+	if err := s.repo.UpdateCandidateReview(ctx, reviewID, updateData, event); err != nil {
+		logrus.WithError(err).Error("failed to assign review stage")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menugaskan tahap peninjauan", nil, nil)
 	}
 
-	s.logService.CreateLog(ctx, &accountID, "UPDATE", "foster_children_candidate", id, existing.ToFosterChildrenCandidateResponse(), updateData)
-	return pkg.NewResponse(http.StatusOK, "Calon berhasil dibatalkan", nil, nil)
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "candidate_review", reviewID, map[string]interface{}{"assignee_id": review.AssigneeID, "due_date": review.DueDate}, updateData)
+	return pkg.NewResponse(http.StatusOK, "Tahap peninjauan berhasil ditugaskan", nil, nil)
+}
+
+func (s *service) UpdateReviewChecklist(ctx context.Context, accountID string, role enum.RoleName, id string, reviewID string, req UpdateReviewChecklistRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	candidate, _, review, res := s.findCandidateStage(ctx, id, reviewID, accountID)
+	if res != nil {
+		return *res
+	}
+	if review.Status != ReviewInProgress {
+		return pkg.NewResponse(http.StatusBadRequest, "Tahap peninjauan tidak sedang berjalan", nil, nil)
+	}
+	if !review.CanAct(accountID, role) {
+		return pkg.NewResponse(http.StatusForbidden, "Anda tidak memiliki akses untuk mengubah tahap ini", nil, nil)
+	}
+	if len(req.Items) == 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"items": "Minimal satu item wajib diisi"}, nil)
+	}
+
+	known := make(map[uuid.UUID]bool, len(review.Checklist))
+	for _, item := range review.Checklist {
+		known[item.ID] = true
+	}
+	checked := make(map[uuid.UUID]bool, len(req.Items))
+	for i, item := range req.Items {
+		itemID, err := uuid.Parse(item.ID)
+		if err != nil || !known[itemID] {
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{fmt.Sprintf("items[%d].id", i): "Item tidak ditemukan pada tahap ini"}, nil)
+		}
+		checked[itemID] = item.Checked
+	}
+
+	actorID := uuid.MustParse(accountID)
+	event := newReviewEvent(candidate.ID, &review.ID, ActionChecklistUpdated, &actorID, fmt.Sprintf("%d item diperbarui", len(checked)))
+	if err := s.repo.UpdateReviewChecklist(ctx, reviewID, checked, actorID, event); err != nil {
+		logrus.WithError(err).Error("failed to update review checklist")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memperbarui daftar periksa", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Daftar periksa berhasil diperbarui", nil, nil)
+}
+
+// AddReviewPhotos attaches photos from the field survey. They are stored privately like the
+// candidate's documents.
+func (s *service) AddReviewPhotos(ctx context.Context, accountID string, role enum.RoleName, id string, reviewID string, req AddReviewPhotosRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if len(req.Photos) == 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"photos": "Minimal satu foto wajib diunggah"}, nil)
+	}
+
+	candidate, _, review, res := s.findCandidateStage(ctx, id, reviewID, accountID)
+	if res != nil {
+		return *res
+	}
+	if review.StageType != StageSurvey {
+		return pkg.NewResponse(http.StatusBadRequest, "Foto hanya dapat ditambahkan pada tahap survei", nil, nil)
+	}
+	if review.Status != ReviewInProgress {
+		return pkg.NewResponse(http.StatusBadRequest, "Tahap peninjauan tidak sedang berjalan", nil, nil)
+	}
+	if !review.CanAct(accountID, role) {
+		return pkg.NewResponse(http.StatusForbidden, "Anda tidak memiliki akses untuk mengubah tahap ini", nil, nil)
+	}
+
+	actorID := uuid.MustParse(accountID)
+	now := time.Now()
+	photos := make([]CandidateReviewPhoto, 0, len(req.Photos))
+	for i, file := range req.Photos {
+		ref, err := s.privateStorage.UploadFile(ctx, file, reviewPhotoFolder)
+		if err != nil {
+			s.deleteReviewPhotoFiles(ctx, photos)
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah foto", nil, nil)
+		}
+		photo := CandidateReviewPhoto{
+			ID:         uuid.New(),
+			ReviewID:   review.ID,
+			URL:        ref,
+			UploadedBy: actorID,
+			CreatedAt:  now,
+		}
+		if i < len(req.PhotoCaptions) {
+			photo.Caption = strings.TrimSpace(req.PhotoCaptions[i])
+		}
+		photos = append(photos, photo)
+	}
+
+	event := newReviewEvent(candidate.ID, &review.ID, ActionPhotosAdded, &actorID, fmt.Sprintf("%d foto ditambahkan", len(photos)))
+	if err := s.repo.CreateReviewPhotos(ctx, photos, event); err != nil {
+		logrus.WithError(err).Error("failed to save review photos")
+		s.deleteReviewPhotoFiles(ctx, photos)
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menyimpan foto", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusCreated, "Foto berhasil ditambahkan", nil, nil)
+}
+
+func (s *service) deleteReviewPhotoFiles(ctx context.Context, photos []CandidateReviewPhoto) {
+	for _, p := range photos {
+		if err := s.privateStorage.DeleteFile(ctx, p.URL); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component": "foster_children_candidate.service",
+				"photo_id":  p.ID.String(),
+			}).WithError(err).Error("failed to delete review photo from storage")
+		}
+	}
+}
+
+// CastReviewVote records a committee member's vote; voting again replaces the earlier vote.
+func (s *service) CastReviewVote(ctx context.Context, accountID string, role enum.RoleName, id string, reviewID string, req CastReviewVoteRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if req.Decision != VoteApprove && req.Decision != VoteReject {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"decision": "Keputusan harus approve atau reject"}, nil)
+	}
+
+	candidate, _, review, res := s.findCandidateStage(ctx, id, reviewID, accountID)
+	if res != nil {
+		return *res
+	}
+	if review.StageType != StageVote {
+		return pkg.NewResponse(http.StatusBadRequest, "Tahap ini bukan tahap pemungutan suara", nil, nil)
+	}
+	if review.Status != ReviewInProgress {
+		return pkg.NewResponse(http.StatusBadRequest, "Tahap peninjauan tidak sedang berjalan", nil, nil)
+	}
+	if !isReviewerRole(role) {
+		return pkg.NewResponse(http.StatusForbidden, "Anda tidak memiliki akses untuk memberikan suara", nil, nil)
+	}
+
+	now := time.Now()
+	actorID := uuid.MustParse(accountID)
+	vote := CandidateReviewVote{
+		ID:        uuid.New(),
+		ReviewID:  review.ID,
+		AccountID: actorID,
+		Decision:  req.Decision,
+		Note:      strings.TrimSpace(req.Note),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	event := newReviewEvent(candidate.ID, &review.ID, ActionVoteCast, &actorID, string(req.Decision))
+	if err := s.repo.UpsertReviewVote(ctx, vote, event); err != nil {
+		logrus.WithError(err).Error("failed to save review vote")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menyimpan suara", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Suara berhasil dicatat", nil, nil)
+}
+
+func (s *service) CompleteReview(ctx context.Context, accountID string, role enum.RoleName, id string, reviewID string, req CompleteReviewRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	errValidation := make(map[string]string)
+	if req.Outcome != ReviewPassed && req.Outcome != ReviewFailed {
+		errValidation["outcome"] = "Hasil harus passed atau failed"
+	}
+	if req.Outcome == ReviewFailed && strings.TrimSpace(req.Notes) == "" {
+		errValidation["notes"] = "Catatan wajib diisi jika tahap tidak lolos"
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	candidate, reviews, review, res := s.findCandidateStage(ctx, id, reviewID, accountID)
+	if res != nil {
+		return *res
+	}
+	return s.decideReview(ctx, accountID, role, candidate, reviews, review, req.Outcome, req.Notes)
+}
+
+// screenCandidate collects what reviewers should know before accepting: possible duplicates
+// among candidates and foster children, and eligibility rules the candidate does not meet.
+func (s *service) screenCandidate(ctx context.Context, candidate *FosterChildrenCandidate) (*CandidateScreeningResponse, error) {
+	q := candidateDuplicateQuery(candidate)
+	records, err := s.repo.FindDuplicates(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	screening := &CandidateScreeningResponse{
+		Warnings:   []ScreeningWarningResponse{},
+		Duplicates: []DuplicateMatchResponse{},
+	}
+	for i := range records {
+		fields := records[i].MatchedFields(q)
+		if len(fields) == 0 {
+			continue
+		}
+		screening.Duplicates = append(screening.Duplicates, records[i].toDuplicateMatchResponse(fields))
+		for _, field := range fields {
+			screening.Warnings = append(screening.Warnings, duplicateWarning(&records[i], field))
+		}
+	}
+
+	now := time.Now()
+	if !candidate.Category.IsValid() {
+		screening.Warnings = append(screening.Warnings, ScreeningWarningResponse{
+			Code:    WarningCategoryInvalid,
+			Message: fmt.Sprintf("Kategori %q tidak termasuk kategori penerimaan", candidate.Category),
+		})
+	} else if msg := checkAge(candidate.Category, candidate.BirthDate, now); msg != "" {
+		screening.Warnings = append(screening.Warnings, ScreeningWarningResponse{Code: WarningAgeNotEligible, Message: msg})
+	}
+	if msg := checkEducationLevel(candidate.EducationLevel, candidate.BirthDate, now); msg != "" {
+		screening.Warnings = append(screening.Warnings, ScreeningWarningResponse{Code: WarningEducationMismatch, Message: msg})
+	}
+	return screening, nil
+}
+
+// registeredFosterChildren returns the foster child already registered with the candidate's NIK.
+func (s *service) registeredFosterChildren(ctx context.Context, candidate *FosterChildrenCandidate) (*DuplicateRecord, error) {
+	if candidate.NikIndex == "" {
+		return nil, nil
+	}
+	records, err := s.repo.FindDuplicates(ctx, DuplicateQuery{ExcludeCandidateID: &candidate.ID, NikIndex: candidate.NikIndex})
+	if err != nil {
+		return nil, err
+	}
+	for i := range records {
+		if records[i].Source == SourceFosterChildren {
+			return &records[i], nil
+		}
+	}
+	return nil, nil
 }
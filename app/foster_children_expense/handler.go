@@ -91,7 +91,8 @@ func (h *handler) GetAdminFosterChildrenExpenseList(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, err.Error(), nil, nil))
 		return
 	}
-	resp := h.service.GetAdminFosterChildrenExpenseList(ctx, fosterChildrenID, req)
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	resp := h.service.GetAdminFosterChildrenExpenseList(ctx, claims.AccountID, fosterChildrenID, req)
 	c.JSON(resp.Status, resp)
 }
 
@@ -110,7 +111,13 @@ func (h *handler) GetFosterChildrenExpenseByID(c *gin.Context) {
 	ctx := c.Request.Context()
 	id := c.Param("id")
 
-	resp := h.service.GetFosterChildrenExpenseByID(ctx, id)
+	// The public route has no claims and is not scoped.
+	var accountID string
+	if userData, ok := c.Get("user_data"); ok {
+		accountID = userData.(jwt_pkg.UserJWTClaims).AccountID
+	}
+
+	resp := h.service.GetFosterChildrenExpenseByID(ctx, accountID, id)
 	c.JSON(resp.Status, resp)
 }
 
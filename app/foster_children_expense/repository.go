@@ -16,6 +16,7 @@ type Repository interface {
 	FindAllAdminFosterChildrenExpensesForExport(ctx context.Context, fosterChildrenID string, params FosterChildrenExpenseExportParams) ([]FosterChildrenExpense, error)
 	FindOneFosterChildrenExpense(ctx context.Context, options map[string]interface{}) (*FosterChildrenExpense, error)
 	GetTotalExpenseByFosterChildrenID(ctx context.Context, fosterChildrenID string) (float64, error)
+	IsLinkedToNeed(ctx context.Context, fosterChildrenExpenseID string) (bool, error)
 	CreateFosterChildrenExpense(ctx context.Context, fosterChildrenExpense *FosterChildrenExpense) error
 	DeleteFosterChildrenExpense(ctx context.Context, fosterChildrenExpenseID string) error
 }
@@ -166,6 +167,17 @@ func (r *repo) DeleteFosterChildrenExpense(ctx context.Context, fosterChildrenEx
 	})
 }
 
+// IsLinkedToNeed reports whether the expense is recorded as the fulfilment of a wishlist item
+// of foster_children_need, which imports this package.
+func (r *repo) IsLinkedToNeed(ctx context.Context, fosterChildrenExpenseID string) (bool, error) {
+	var count int64
+	err := r.Conn.WithContext(ctx).
+		Table("foster_children_needs").
+		Where("expense_id = ?", fosterChildrenExpenseID).
+		Count(&count).Error
+	return count > 0, err
+}
+
 func (r *repo) GetTotalExpenseByFosterChildrenID(ctx context.Context, fosterChildrenID string) (float64, error) {
 	var total float64
 	err := r.Conn.WithContext(ctx).
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/Vilamuzz/yota-backend/app/access_grant"
 	"github.com/Vilamuzz/yota-backend/app/finance_record"
 	"github.com/Vilamuzz/yota-backend/app/foster_children"
 	app_log "github.com/Vilamuzz/yota-backend/app/log"
@@ -19,8 +20,8 @@ import (
 
 type Service interface {
 	GetFosterChildrenExpenseList(ctx context.Context, fosterChildrenSlug string, params FosterChildrenExpenseQueryParams) pkg.Response
-	GetAdminFosterChildrenExpenseList(ctx context.Context, fosterChildrenID string, params FosterChildrenExpenseQueryParams) pkg.Response
-	GetFosterChildrenExpenseByID(ctx context.Context, fosterChildrenExpenseID string) pkg.Response
+	GetAdminFosterChildrenExpenseList(ctx context.Context, accountID, fosterChildrenID string, params FosterChildrenExpenseQueryParams) pkg.Response
+	GetFosterChildrenExpenseByID(ctx context.Context, accountID, fosterChildrenExpenseID string) pkg.Response
 	CreateFosterChildrenExpense(ctx context.Context, accountID, fosterChildrenID string, payload *FosterChildrenExpenseRequest) pkg.Response
 	DeleteFosterChildrenExpense(ctx context.Context, accountID, fosterChildrenExpenseID string) pkg.Response
 	ExportFosterChildrenExpenseCSV(ctx context.Context, fosterChildrenID string, params FosterChildrenExpenseExportParams) ([]byte, string, error)
@@ -30,22 +31,44 @@ type service struct {
 	repo               Repository
 	financeRepo        finance_record.Repository
 	fosterChildrenRepo foster_children.Repository
+	accessGrantRepo    access_grant.Repository
 	s3Client           s3_pkg.Client
 	logService         app_log.Service
 	timeout            time.Duration
 }
 
-func NewService(repo Repository, financeRepo finance_record.Repository, fosterChildrenRepo foster_children.Repository, s3Client s3_pkg.Client, logService app_log.Service, timeout time.Duration) Service {
+func NewService(repo Repository, financeRepo finance_record.Repository, fosterChildrenRepo foster_children.Repository, accessGrantRepo access_grant.Repository, s3Client s3_pkg.Client, logService app_log.Service, timeout time.Duration) Service {
 	return &service{
 		repo:               repo,
 		financeRepo:        financeRepo,
 		fosterChildrenRepo: fosterChildrenRepo,
+		accessGrantRepo:    accessGrantRepo,
 		s3Client:           s3Client,
 		logService:         logService,
 		timeout:            timeout,
 	}
 }
 
+// checkChildAccess answers not found when the foster child is outside the account's access
+// scope, the same way the child itself is hidden. Public requests pass an empty account ID.
+func (s *service) checkChildAccess(ctx context.Context, accountID, fosterChildrenID, notFound string) *pkg.Response {
+	ok, err := s.accessGrantRepo.HasAccess(ctx, accountID, access_grant.ResourceFosterChildren, fosterChildrenID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":          "foster_children_expense.service",
+			"account_id":         accountID,
+			"foster_children_id": fosterChildrenID,
+		}).WithError(err).Error("failed to resolve access grants")
+		res := pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+		return &res
+	}
+	if !ok {
+		res := pkg.NewResponse(http.StatusNotFound, notFound, nil, nil)
+		return &res
+	}
+	return nil
+}
+
 func (s *service) GetFosterChildrenExpenseList(ctx context.Context, fosterChildrenSlug string, params FosterChildrenExpenseQueryParams) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
@@ -140,7 +163,7 @@ func (s *service) GetFosterChildrenExpenseList(ctx context.Context, fosterChildr
 	}))
 }
 
-func (s *service) GetAdminFosterChildrenExpenseList(ctx context.Context, fosterChildrenID string, params FosterChildrenExpenseQueryParams) pkg.Response {
+func (s *service) GetAdminFosterChildrenExpenseList(ctx context.Context, accountID, fosterChildrenID string, params FosterChildrenExpenseQueryParams) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
@@ -165,6 +188,9 @@ func (s *service) GetAdminFosterChildrenExpenseList(ctx context.Context, fosterC
 	if len(errValidation) > 0 {
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
 	}
+	if res := s.checkChildAccess(ctx, accountID, fosterChildrenID, "Anak asuh tidak ditemukan"); res != nil {
+		return *res
+	}
 
 	usingPrevCursor := params.PrevCursor != ""
 
@@ -234,7 +260,7 @@ func (s *service) GetAdminFosterChildrenExpenseList(ctx context.Context, fosterC
 	}))
 }
 
-func (s *service) GetFosterChildrenExpenseByID(ctx context.Context, id string) pkg.Response {
+func (s *service) GetFosterChildrenExpenseByID(ctx context.Context, accountID, id string) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
@@ -250,6 +276,9 @@ func (s *service) GetFosterChildrenExpenseByID(ctx context.Context, id string) p
 		}).WithError(err).Error("failed to fetch expense")
 		return pkg.NewResponse(http.StatusNotFound, "Pengeluaran tidak ditemukan", nil, nil)
 	}
+	if res := s.checkChildAccess(ctx, accountID, expense.FosterChildrenID.String(), "Pengeluaran tidak ditemukan"); res != nil {
+		return *res
+	}
 
 	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, expense.toFosterChildrenExpenseDetailResponse())
 }
@@ -277,6 +306,9 @@ func (s *service) CreateFosterChildrenExpense(ctx context.Context, accountID, fo
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
 	}
 
+	if res := s.checkChildAccess(ctx, accountID, fosterChildrenID, "Anak asuh tidak ditemukan"); res != nil {
+		return *res
+	}
 	fosterChild, err := s.fosterChildrenRepo.FindOneFosterChildren(ctx, map[string]interface{}{"id": fosterChildrenID})
 	if err != nil {
 		return pkg.NewResponse(http.StatusNotFound, "Anak asuh tidak ditemukan", nil, nil)
@@ -351,6 +383,21 @@ func (s *service) DeleteFosterChildrenExpense(ctx context.Context, accountID, fo
 	if err != nil {
 		return pkg.NewResponse(http.StatusNotFound, "Pengeluaran tidak ditemukan", nil, nil)
 	}
+	if res := s.checkChildAccess(ctx, accountID, expense.FosterChildrenID.String(), "Pengeluaran tidak ditemukan"); res != nil {
+		return *res
+	}
+
+	linked, err := s.repo.IsLinkedToNeed(ctx, fosterChildrenExpenseID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "foster_children_expense.service",
+			"expense_id": fosterChildrenExpenseID,
+		}).WithError(err).Error("failed to check need link")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menghapus pengeluaran", nil, nil)
+	}
+	if linked {
+		return pkg.NewResponse(http.StatusConflict, "Pengeluaran tercatat sebagai bukti pemenuhan kebutuhan anak asuh dan tidak dapat dihapus", nil, nil)
+	}
 
 	if err := s.repo.DeleteFosterChildrenExpense(ctx, fosterChildrenExpenseID); err != nil {
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menghapus pengeluaran", nil, nil)
@@ -0,0 +1,82 @@
+package foster_children_need
+
+import (
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/foster_children"
+	"github.com/Vilamuzz/yota-backend/app/foster_children_expense"
+	"github.com/google/uuid"
+)
+
+// FosterChildrenNeed is an item on a child's wishlist, such as a school uniform or a semester of
+// tuition. Donations can be earmarked to an open need; once bought, the need is fulfilled with
+// proof photos and linked to the expense that paid for it.
+type FosterChildrenNeed struct {
+	ID               uuid.UUID  `json:"id" gorm:"primaryKey"`
+	FosterChildrenID uuid.UUID  `json:"fosterChildrenId" gorm:"not null;index"`
+	Title            string     `json:"title" gorm:"not null"`
+	Description      string     `json:"description" gorm:"type:text"`
+	TargetAmount     float64    `json:"targetAmount" gorm:"not null"`
+	Deadline         *time.Time `json:"deadline"`
+	Status           Status     `json:"status" gorm:"type:varchar(20);not null;default:'open';index"`
+	ExpenseID        *uuid.UUID `json:"expenseId" gorm:"uniqueIndex"`
+	FulfillmentNote  string     `json:"fulfillmentNote" gorm:"type:text"`
+	FulfilledBy      *uuid.UUID `json:"fulfilledBy"`
+	FulfilledAt      *time.Time `json:"fulfilledAt"`
+	CancelReason     string     `json:"cancelReason"`
+	CreatedBy        uuid.UUID  `json:"createdBy" gorm:"not null"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
+
+	// RaisedAmount is the sum of paid donations earmarked to the need, selected by the repository.
+	RaisedAmount float64 `json:"raisedAmount" gorm:"->;-:migration"`
+
+	Photos         []NeedProofPhoto                               `json:"photos" gorm:"foreignKey:NeedID"`
+	Expense        *foster_children_expense.FosterChildrenExpense `json:"-" gorm:"foreignKey:ExpenseID;references:ID"`
+	FosterChildren *foster_children.FosterChildren                `json:"-" gorm:"foreignKey:FosterChildrenID;references:ID"`
+}
+
+type NeedProofPhoto struct {
+	ID        uuid.UUID `json:"id" gorm:"primaryKey"`
+	NeedID    uuid.UUID `json:"needId" gorm:"not null;index"`
+	URL       string    `json:"url" gorm:"not null"`
+	Caption   string    `json:"caption"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type Status string
+
+const (
+	StatusOpen      Status = "open"
+	StatusFulfilled Status = "fulfilled"
+	StatusCancelled Status = "cancelled"
+)
+
+func (s Status) IsValid() bool {
+	return s == StatusOpen || s == StatusFulfilled || s == StatusCancelled
+}
+
+// RemainingAmount is what is still needed to reach the target, never negative.
+func (n *FosterChildrenNeed) RemainingAmount() float64 {
+	if n.RaisedAmount >= n.TargetAmount {
+		return 0
+	}
+	return n.TargetAmount - n.RaisedAmount
+}
+
+// Progress is the raised share of the target in percent, capped at 100.
+func (n *FosterChildrenNeed) Progress() float64 {
+	if n.TargetAmount <= 0 || n.RaisedAmount >= n.TargetAmount {
+		return 100
+	}
+	return n.RaisedAmount / n.TargetAmount * 100
+}
+
+func (n *FosterChildrenNeed) IsFunded() bool {
+	return n.RaisedAmount >= n.TargetAmount
+}
+
+// AcceptsDonations reports whether a donation may still be earmarked to the need.
+func (n *FosterChildrenNeed) AcceptsDonations() bool {
+	return n.Status == StatusOpen && !n.IsFunded()
+}
@@ -0,0 +1,220 @@
+package foster_children_need
+
+import (
+	"net/http"
+
+	"github.com/Vilamuzz/yota-backend/app/middleware"
+	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/Vilamuzz/yota-backend/pkg/enum"
+	jwt_pkg "github.com/Vilamuzz/yota-backend/pkg/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	service    Service
+	middleware middleware.AppMiddleware
+}
+
+func NewHandler(r *gin.RouterGroup, s Service, m middleware.AppMiddleware) {
+	h := &handler{
+		service:    s,
+		middleware: m,
+	}
+	h.RegisterRoutes(r)
+}
+
+func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
+	public := r.Group("/foster-children")
+	public.GET("/:slug/needs", h.GetPublicNeedList)
+
+	admin := r.Group("/admin/foster-children")
+	admin.Use(h.middleware.RequireRoles(enum.RoleSocialManager, enum.RoleFinance))
+	{
+		admin.GET("/:id/needs", h.GetNeedList)
+		admin.GET("/needs/:id", h.GetNeedByID)
+		admin.POST("/needs/:id/fulfill", h.FulfillNeed)
+	}
+
+	socialManagerOnly := r.Group("/admin/foster-children")
+	socialManagerOnly.Use(h.middleware.RequireRoles(enum.RoleSocialManager))
+	{
+		socialManagerOnly.POST("/:id/needs", h.CreateNeed)
+		socialManagerOnly.PUT("/needs/:id", h.UpdateNeed)
+		socialManagerOnly.PATCH("/needs/:id/cancel", h.CancelNeed)
+	}
+}
+
+// GetPublicNeedList
+//
+// @Summary List Foster Children Needs
+// @Description Retrieve the open and fulfilled wishlist items of a foster child with their funding progress
+// @Tags Foster Children Needs
+// @Produce json
+// @Param slug path string true "Foster Children Slug"
+// @Param status query string false "Filter by status (open, fulfilled)"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Success 200 {object} pkg.Response{data=NeedListResponse}
+// @Router /api/foster-children/{slug}/needs [get]
+func (h *handler) GetPublicNeedList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var params NeedQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid query parameters", nil, nil))
+		return
+	}
+
+	res := h.service.GetPublicNeedList(ctx, c.Param("slug"), params)
+	c.JSON(res.Status, res)
+}
+
+// GetNeedList
+//
+// @Summary List Foster Children Needs (Admin)
+// @Description Retrieve every wishlist item of a foster child, cancelled ones included
+// @Tags Foster Children Needs
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Foster Children ID"
+// @Param status query string false "Filter by status (open, fulfilled, cancelled)"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Success 200 {object} pkg.Response{data=NeedListResponse}
+// @Router /api/admin/foster-children/{id}/needs [get]
+func (h *handler) GetNeedList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var params NeedQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid query parameters", nil, nil))
+		return
+	}
+
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.GetNeedList(ctx, claims.AccountID, c.Param("id"), params)
+	c.JSON(res.Status, res)
+}
+
+// GetNeedByID
+//
+// @Summary Get Foster Children Need
+// @Description Retrieve a wishlist item with its progress, linked expense and proof photos
+// @Tags Foster Children Needs
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Need ID"
+// @Success 200 {object} pkg.Response{data=NeedResponse}
+// @Router /api/admin/foster-children/needs/{id} [get]
+func (h *handler) GetNeedByID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.GetNeedByID(ctx, claims.AccountID, c.Param("id"))
+	c.JSON(res.Status, res)
+}
+
+// CreateNeed
+//
+// @Summary Create Foster Children Need
+// @Description Add a wishlist item with its cost that donors can earmark donations to
+// @Tags Foster Children Needs
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Foster Children ID"
+// @Param body body CreateNeedRequest true "Need Data"
+// @Success 201 {object} pkg.Response{data=NeedResponse}
+// @Router /api/admin/foster-children/{id}/needs [post]
+func (h *handler) CreateNeed(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req CreateNeedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid request body", nil, nil))
+		return
+	}
+
+	res := h.service.CreateNeed(ctx, claims.AccountID, c.Param("id"), req)
+	c.JSON(res.Status, res)
+}
+
+// UpdateNeed
+//
+// @Summary Update Foster Children Need
+// @Description Edit an open wishlist item; only the fields that are sent change
+// @Tags Foster Children Needs
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Need ID"
+// @Param body body UpdateNeedRequest true "Need Data"
+// @Success 200 {object} pkg.Response{data=NeedResponse}
+// @Router /api/admin/foster-children/needs/{id} [put]
+func (h *handler) UpdateNeed(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req UpdateNeedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid request body", nil, nil))
+		return
+	}
+
+	res := h.service.UpdateNeed(ctx, claims.AccountID, c.Param("id"), req)
+	c.JSON(res.Status, res)
+}
+
+// CancelNeed
+//
+// @Summary Cancel Foster Children Need
+// @Description Withdraw an open wishlist item; earmarked donations stay with the child's general fund
+// @Tags Foster Children Needs
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Need ID"
+// @Param body body CancelNeedRequest true "Cancellation reason"
+// @Success 200 {object} pkg.Response
+// @Router /api/admin/foster-children/needs/{id}/cancel [patch]
+func (h *handler) CancelNeed(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req CancelNeedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid request body", nil, nil))
+		return
+	}
+
+	res := h.service.CancelNeed(ctx, claims.AccountID, c.Param("id"), req)
+	c.JSON(res.Status, res)
+}
+
+// FulfillNeed
+//
+// @Summary Fulfill Foster Children Need
+// @Description Record that a wishlist item was bought, linking the expense that paid for it and uploading proof photos
+// @Tags Foster Children Needs
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Need ID"
+// @Param payload formData FulfillNeedRequest true "Fulfillment Data"
+// @Param photos[] formData file true "Proof photos"
+// @Success 200 {object} pkg.Response{data=NeedResponse}
+// @Router /api/admin/foster-children/needs/{id}/fulfill [post]
+func (h *handler) FulfillNeed(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req FulfillNeedRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid request body", nil, nil))
+		return
+	}
+
+	res := h.service.FulfillNeed(ctx, claims.AccountID, c.Param("id"), req)
+	c.JSON(res.Status, res)
+}
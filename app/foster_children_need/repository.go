@@ -0,0 +1,149 @@
+package foster_children_need
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+var ErrStatusChanged = errors.New("need status changed")
+
+// raisedAmountSQL sums the paid donations earmarked to a need. Offline donations are recorded as
+// settled right away; online ones once Midtrans reports a settlement or an accepted capture.
+const raisedAmountSQL = `COALESCE((
+	SELECT SUM(t.gross_amount) FROM foster_children_transactions t
+	WHERE t.need_id = foster_children_needs.id
+	AND t.paid_at IS NOT NULL
+	AND t.transaction_status IN ('settlement', 'capture')
+), 0) AS raised_amount`
+
+type Repository interface {
+	FindAllNeeds(ctx context.Context, options map[string]interface{}) ([]FosterChildrenNeed, error)
+	CountNeeds(ctx context.Context, options map[string]interface{}) (int64, error)
+	FindOneNeed(ctx context.Context, options map[string]interface{}) (*FosterChildrenNeed, error)
+	CreateNeed(ctx context.Context, need *FosterChildrenNeed) error
+	UpdateNeed(ctx context.Context, id string, from Status, updateData map[string]interface{}) error
+	FulfillNeed(ctx context.Context, id string, updateData map[string]interface{}, photos []NeedProofPhoto) error
+}
+
+type repository struct {
+	Conn *gorm.DB
+}
+
+func NewRepository(conn *gorm.DB) Repository {
+	return &repository{Conn: conn}
+}
+
+func (r *repository) applyFilters(query *gorm.DB, options map[string]interface{}) *gorm.DB {
+	if fosterChildrenID, ok := options["foster_children_id"]; ok && fosterChildrenID.(string) != "" {
+		query = query.Where("foster_children_needs.foster_children_id = ?", fosterChildrenID.(string))
+	}
+	if slug, ok := options["foster_children_slug"]; ok && slug.(string) != "" {
+		query = query.Joins("JOIN foster_childrens ON foster_childrens.id = foster_children_needs.foster_children_id").
+			Where("foster_childrens.slug = ? AND foster_childrens.deleted_at IS NULL", slug.(string))
+	}
+	if status, ok := options["status"]; ok && status.(string) != "" {
+		query = query.Where("foster_children_needs.status = ?", status.(string))
+	}
+	if statuses, ok := options["statuses"].([]string); ok && len(statuses) > 0 {
+		query = query.Where("foster_children_needs.status IN ?", statuses)
+	}
+	return query
+}
+
+// FindAllNeeds lists open needs first, then fulfilled and cancelled ones, newest first within
+// each status.
+func (r *repository) FindAllNeeds(ctx context.Context, options map[string]interface{}) ([]FosterChildrenNeed, error) {
+	var needs []FosterChildrenNeed
+	query := r.Conn.WithContext(ctx).
+		Select("foster_children_needs.*, "+raisedAmountSQL).
+		Preload("Photos", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("Expense")
+	query = r.applyFilters(query, options)
+
+	limit := 10
+	if l, ok := options["limit"]; ok && l.(int) > 0 {
+		limit = l.(int)
+	}
+	offset := 0
+	if page, ok := options["page"]; ok && page.(int) > 1 {
+		offset = (page.(int) - 1) * limit
+	}
+
+	if err := query.Order("CASE foster_children_needs.status WHEN 'open' THEN 0 WHEN 'fulfilled' THEN 1 ELSE 2 END, foster_children_needs.created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&needs).Error; err != nil {
+		return nil, err
+	}
+	return needs, nil
+}
+
+func (r *repository) CountNeeds(ctx context.Context, options map[string]interface{}) (int64, error) {
+	var total int64
+	query := r.applyFilters(r.Conn.WithContext(ctx).Model(&FosterChildrenNeed{}), options)
+	if err := query.Count(&total).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *repository) FindOneNeed(ctx context.Context, options map[string]interface{}) (*FosterChildrenNeed, error) {
+	var need FosterChildrenNeed
+	query := r.Conn.WithContext(ctx).
+		Select("foster_children_needs.*, "+raisedAmountSQL).
+		Preload("Photos", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("Expense").
+		Preload("FosterChildren")
+
+	if id, ok := options["id"]; ok && id.(string) != "" {
+		query = query.Where("foster_children_needs.id = ?", id.(string))
+	}
+	if expenseID, ok := options["expense_id"]; ok && expenseID.(string) != "" {
+		query = query.Where("foster_children_needs.expense_id = ?", expenseID.(string))
+	}
+	query = r.applyFilters(query, options)
+
+	if err := query.First(&need).Error; err != nil {
+		return nil, err
+	}
+	return &need, nil
+}
+
+func (r *repository) CreateNeed(ctx context.Context, need *FosterChildrenNeed) error {
+	return r.Conn.WithContext(ctx).Create(need).Error
+}
+
+// UpdateNeed only applies while the need still has status from, so concurrent edits cannot
+// reopen or change a need that was fulfilled or cancelled in the meantime.
+func (r *repository) UpdateNeed(ctx context.Context, id string, from Status, updateData map[string]interface{}) error {
+	result := r.Conn.WithContext(ctx).Model(&FosterChildrenNeed{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(updateData)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrStatusChanged
+	}
+	return nil
+}
+
+// FulfillNeed marks an open need as fulfilled and stores its proof photos in one transaction.
+func (r *repository) FulfillNeed(ctx context.Context, id string, updateData map[string]interface{}, photos []NeedProofPhoto) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&FosterChildrenNeed{}).
+			Where("id = ? AND status = ?", id, StatusOpen).
+			Updates(updateData)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrStatusChanged
+		}
+		if len(photos) > 0 {
+			return tx.Create(&photos).Error
+		}
+		return nil
+	})
+}
@@ -0,0 +1,39 @@
+package foster_children_need
+
+import "mime/multipart"
+
+type CreateNeedRequest struct {
+	Title        string  `json:"title"`
+	Description  string  `json:"description"`
+	TargetAmount float64 `json:"targetAmount"`
+	Deadline     string  `json:"deadline"` // optional, format: YYYY-MM-DD
+}
+
+// UpdateNeedRequest only changes the fields that are sent; an empty deadline keeps the current
+// one and clearDeadline removes it.
+type UpdateNeedRequest struct {
+	Title         string   `json:"title"`
+	Description   *string  `json:"description"`
+	TargetAmount  *float64 `json:"targetAmount"`
+	Deadline      string   `json:"deadline"`
+	ClearDeadline bool     `json:"clearDeadline"`
+}
+
+// FulfillNeedRequest links the expense that paid for the need and adds proof photos, captioned
+// through the parallel photoCaptions array.
+type FulfillNeedRequest struct {
+	ExpenseID     string                  `form:"expenseId"`
+	Note          string                  `form:"note"`
+	Photos        []*multipart.FileHeader `form:"photos[]" swaggerignore:"true"`
+	PhotoCaptions []string                `form:"photoCaptions[]"`
+}
+
+type CancelNeedRequest struct {
+	Reason string `json:"reason"`
+}
+
+type NeedQueryParams struct {
+	Status string `form:"status"`
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
+}
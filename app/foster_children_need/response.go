@@ -0,0 +1,100 @@
+package foster_children_need
+
+import (
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/pkg"
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
+)
+
+type NeedPhotoResponse struct {
+	ID      string `json:"id"`
+	URL     string `json:"url"`
+	Caption string `json:"caption"`
+}
+
+type NeedExpenseResponse struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Amount      float64   `json:"amount"`
+	ExpenseDate time.Time `json:"expenseDate"`
+	ProofFile   string    `json:"proofFile"`
+}
+
+type NeedResponse struct {
+	ID               string               `json:"id"`
+	FosterChildrenID string               `json:"fosterChildrenId"`
+	Title            string               `json:"title"`
+	Description      string               `json:"description"`
+	TargetAmount     float64              `json:"targetAmount"`
+	RaisedAmount     float64              `json:"raisedAmount"`
+	RemainingAmount  float64              `json:"remainingAmount"`
+	Progress         float64              `json:"progress"` // percent of the target raised, capped at 100
+	IsFunded         bool                 `json:"isFunded"`
+	Deadline         *time.Time           `json:"deadline"`
+	Status           Status               `json:"status"`
+	FulfillmentNote  string               `json:"fulfillmentNote"`
+	FulfilledAt      *time.Time           `json:"fulfilledAt"`
+	CancelReason     string               `json:"cancelReason"`
+	Expense          *NeedExpenseResponse `json:"expense"`
+	Photos           []NeedPhotoResponse  `json:"photos"`
+	CreatedAt        time.Time            `json:"createdAt"`
+	UpdatedAt        time.Time            `json:"updatedAt"`
+}
+
+type NeedListResponse struct {
+	Needs      []NeedResponse       `json:"needs"`
+	Pagination pkg.OffsetPagination `json:"pagination"`
+}
+
+func (n *FosterChildrenNeed) toNeedResponse() NeedResponse {
+	photos := make([]NeedPhotoResponse, 0, len(n.Photos))
+	for _, p := range n.Photos {
+		photos = append(photos, NeedPhotoResponse{
+			ID:      p.ID.String(),
+			URL:     s3_pkg.GetCDNURL(p.URL),
+			Caption: p.Caption,
+		})
+	}
+
+	resp := NeedResponse{
+		ID:               n.ID.String(),
+		FosterChildrenID: n.FosterChildrenID.String(),
+		Title:            n.Title,
+		Description:      n.Description,
+		TargetAmount:     n.TargetAmount,
+		RaisedAmount:     n.RaisedAmount,
+		RemainingAmount:  n.RemainingAmount(),
+		Progress:         n.Progress(),
+		IsFunded:         n.IsFunded(),
+		Deadline:         n.Deadline,
+		Status:           n.Status,
+		FulfillmentNote:  n.FulfillmentNote,
+		FulfilledAt:      n.FulfilledAt,
+		CancelReason:     n.CancelReason,
+		Photos:           photos,
+		CreatedAt:        n.CreatedAt,
+		UpdatedAt:        n.UpdatedAt,
+	}
+	if n.Expense != nil {
+		resp.Expense = &NeedExpenseResponse{
+			ID:          n.Expense.ID.String(),
+			Title:       n.Expense.Title,
+			Amount:      n.Expense.Amount,
+			ExpenseDate: n.Expense.ExpenseDate,
+			ProofFile:   s3_pkg.GetCDNURL(n.Expense.ProofFile),
+		}
+	}
+	return resp
+}
+
+func toNeedListResponse(needs []FosterChildrenNeed, pagination pkg.OffsetPagination) NeedListResponse {
+	responses := make([]NeedResponse, 0, len(needs))
+	for _, n := range needs {
+		responses = append(responses, n.toNeedResponse())
+	}
+	return NeedListResponse{
+		Needs:      responses,
+		Pagination: pagination,
+	}
+}
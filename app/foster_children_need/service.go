@@ -0,0 +1,476 @@
+package foster_children_need
+
+import (
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/access_grant"
+	"github.com/Vilamuzz/yota-backend/app/foster_children"
+	"github.com/Vilamuzz/yota-backend/app/foster_children_expense"
+	app_log "github.com/Vilamuzz/yota-backend/app/log"
+	"github.com/Vilamuzz/yota-backend/pkg"
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const photoFolder = "foster-children/needs"
+
+type Service interface {
+	GetPublicNeedList(ctx context.Context, fosterChildrenSlug string, params NeedQueryParams) pkg.Response
+	GetNeedList(ctx context.Context, accountID, fosterChildrenID string, params NeedQueryParams) pkg.Response
+	GetNeedByID(ctx context.Context, accountID, id string) pkg.Response
+	CreateNeed(ctx context.Context, accountID, fosterChildrenID string, req CreateNeedRequest) pkg.Response
+	UpdateNeed(ctx context.Context, accountID, id string, req UpdateNeedRequest) pkg.Response
+	CancelNeed(ctx context.Context, accountID, id string, req CancelNeedRequest) pkg.Response
+	FulfillNeed(ctx context.Context, accountID, id string, req FulfillNeedRequest) pkg.Response
+}
+
+type service struct {
+	repo               Repository
+	fosterChildrenRepo foster_children.Repository
+	expenseRepo        foster_children_expense.Repository
+	accessGrantRepo    access_grant.Repository
+	s3Client           s3_pkg.Client
+	logService         app_log.Service
+	timeout            time.Duration
+}
+
+func NewService(repo Repository, fosterChildrenRepo foster_children.Repository, expenseRepo foster_children_expense.Repository, accessGrantRepo access_grant.Repository, s3Client s3_pkg.Client, logService app_log.Service, timeout time.Duration) Service {
+	return &service{
+		repo:               repo,
+		fosterChildrenRepo: fosterChildrenRepo,
+		expenseRepo:        expenseRepo,
+		accessGrantRepo:    accessGrantRepo,
+		s3Client:           s3Client,
+		logService:         logService,
+		timeout:            timeout,
+	}
+}
+
+func parseDeadline(errs map[string]string, value string) *time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	deadline, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		errs["deadline"] = "Format tanggal tidak valid (gunakan YYYY-MM-DD)"
+		return nil
+	}
+	return &deadline
+}
+
+// uploadPhotos stores the proof photos publicly, like expense proofs. On failure the photos
+// uploaded so far are removed again so no orphan objects are left behind.
+func (s *service) uploadPhotos(ctx context.Context, needID uuid.UUID, files []*multipart.FileHeader, captions []string) ([]NeedProofPhoto, error) {
+	photos := make([]NeedProofPhoto, 0, len(files))
+	for i, file := range files {
+		objectName, err := s.s3Client.UploadFile(ctx, file, photoFolder)
+		if err != nil {
+			s.deletePhotoFiles(ctx, photos)
+			return nil, err
+		}
+		photo := NeedProofPhoto{
+			ID:     uuid.New(),
+			NeedID: needID,
+			URL:    objectName,
+		}
+		if i < len(captions) {
+			photo.Caption = strings.TrimSpace(captions[i])
+		}
+		photos = append(photos, photo)
+	}
+	return photos, nil
+}
+
+func (s *service) deletePhotoFiles(ctx context.Context, photos []NeedProofPhoto) {
+	for _, p := range photos {
+		if err := s.s3Client.DeleteFile(ctx, s3_pkg.ExtractObjectNameFromURL(p.URL)); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component": "foster_children_need.service",
+				"photo_id":  p.ID.String(),
+			}).WithError(err).Error("failed to delete need proof photo from storage")
+		}
+	}
+}
+
+func (s *service) listNeeds(ctx context.Context, options map[string]interface{}, params NeedQueryParams) pkg.Response {
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+
+	options["limit"] = params.Limit
+	options["page"] = params.Page
+	if params.Status != "" {
+		options["status"] = params.Status
+	}
+
+	total, err := s.repo.CountNeeds(ctx, options)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children_need.service",
+			"options":   options,
+		}).WithError(err).Error("failed to count needs")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil daftar kebutuhan", nil, nil)
+	}
+
+	needs, err := s.repo.FindAllNeeds(ctx, options)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children_need.service",
+			"options":   options,
+		}).WithError(err).Error("failed to fetch needs")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil daftar kebutuhan", nil, nil)
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit != 0 {
+		totalPages++
+	}
+
+	pagination := pkg.OffsetPagination{
+		Page:       params.Page,
+		Limit:      params.Limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, toNeedListResponse(needs, pagination))
+}
+
+// checkChildAccess answers not found when the foster child is outside the account's access
+// scope, the same way the child itself is hidden.
+func (s *service) checkChildAccess(ctx context.Context, accountID, fosterChildrenID, notFound string) *pkg.Response {
+	ok, err := s.accessGrantRepo.HasAccess(ctx, accountID, access_grant.ResourceFosterChildren, fosterChildrenID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":          "foster_children_need.service",
+			"account_id":         accountID,
+			"foster_children_id": fosterChildrenID,
+		}).WithError(err).Error("failed to resolve access grants")
+		res := pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+		return &res
+	}
+	if !ok {
+		res := pkg.NewResponse(http.StatusNotFound, notFound, nil, nil)
+		return &res
+	}
+	return nil
+}
+
+func (s *service) findNeed(ctx context.Context, accountID, id string) (*FosterChildrenNeed, *pkg.Response) {
+	if uuid.Validate(id) != nil {
+		res := pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID kebutuhan tidak valid"}, nil)
+		return nil, &res
+	}
+	need, err := s.repo.FindOneNeed(ctx, map[string]interface{}{"id": id})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := pkg.NewResponse(http.StatusNotFound, "Kebutuhan tidak ditemukan", nil, nil)
+			return nil, &res
+		}
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children_need.service",
+			"need_id":   id,
+		}).WithError(err).Error("failed to fetch need")
+		res := pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data kebutuhan", nil, nil)
+		return nil, &res
+	}
+	if res := s.checkChildAccess(ctx, accountID, need.FosterChildrenID.String(), "Kebutuhan tidak ditemukan"); res != nil {
+		return nil, res
+	}
+	return need, nil
+}
+
+// GetPublicNeedList shows donors the open and fulfilled needs of a child with their progress.
+// Cancelled needs are left out.
+func (s *service) GetPublicNeedList(ctx context.Context, fosterChildrenSlug string, params NeedQueryParams) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	status := Status(params.Status)
+	if params.Status != "" && status != StatusOpen && status != StatusFulfilled {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"status": "Status harus open atau fulfilled"}, nil)
+	}
+	if _, err := s.fosterChildrenRepo.FindOneFosterChildren(ctx, map[string]interface{}{"slug": fosterChildrenSlug}); err != nil {
+		return pkg.NewResponse(http.StatusNotFound, "Anak asuh tidak ditemukan", nil, nil)
+	}
+
+	options := map[string]interface{}{
+		"foster_children_slug": fosterChildrenSlug,
+		"statuses":             []string{string(StatusOpen), string(StatusFulfilled)},
+	}
+	return s.listNeeds(ctx, options, params)
+}
+
+func (s *service) GetNeedList(ctx context.Context, accountID, fosterChildrenID string, params NeedQueryParams) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	errs := map[string]string{}
+	if uuid.Validate(fosterChildrenID) != nil {
+		errs["id"] = "Format ID anak asuh tidak valid"
+	}
+	if params.Status != "" && !Status(params.Status).IsValid() {
+		errs["status"] = "Status tidak valid"
+	}
+	if len(errs) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errs, nil)
+	}
+	if res := s.checkChildAccess(ctx, accountID, fosterChildrenID, "Anak asuh tidak ditemukan"); res != nil {
+		return *res
+	}
+
+	return s.listNeeds(ctx, map[string]interface{}{"foster_children_id": fosterChildrenID}, params)
+}
+
+func (s *service) GetNeedByID(ctx context.Context, accountID, id string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	need, res := s.findNeed(ctx, accountID, id)
+	if res != nil {
+		return *res
+	}
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, need.toNeedResponse())
+}
+
+func (s *service) CreateNeed(ctx context.Context, accountID, fosterChildrenID string, req CreateNeedRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if uuid.Validate(fosterChildrenID) != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID anak asuh tidak valid"}, nil)
+	}
+
+	errs := map[string]string{}
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" {
+		errs["title"] = "Nama kebutuhan wajib diisi"
+	}
+	if req.TargetAmount <= 0 {
+		errs["targetAmount"] = "Biaya kebutuhan harus lebih besar dari 0"
+	}
+	deadline := parseDeadline(errs, req.Deadline)
+	if len(errs) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errs, nil)
+	}
+
+	if res := s.checkChildAccess(ctx, accountID, fosterChildrenID, "Anak asuh tidak ditemukan"); res != nil {
+		return *res
+	}
+	child, err := s.fosterChildrenRepo.FindOneFosterChildren(ctx, map[string]interface{}{"id": fosterChildrenID})
+	if err != nil {
+		return pkg.NewResponse(http.StatusNotFound, "Anak asuh tidak ditemukan", nil, nil)
+	}
+	if !child.IsEnrolled() {
+		return pkg.NewResponse(http.StatusBadRequest, "Anak asuh sudah tidak aktif dan tidak menerima donasi", nil, nil)
+	}
+
+	need := &FosterChildrenNeed{
+		ID:               uuid.New(),
+		FosterChildrenID: child.ID,
+		Title:            req.Title,
+		Description:      strings.TrimSpace(req.Description),
+		TargetAmount:     req.TargetAmount,
+		Deadline:         deadline,
+		Status:           StatusOpen,
+		CreatedBy:        uuid.MustParse(accountID),
+	}
+	if err := s.repo.CreateNeed(ctx, need); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":          "foster_children_need.service",
+			"foster_children_id": fosterChildrenID,
+		}).WithError(err).Error("failed to create need")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membuat kebutuhan", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "CREATE", "foster_children_need", need.ID.String(), nil, need.toNeedResponse())
+	return pkg.NewResponse(http.StatusCreated, "Kebutuhan berhasil dibuat", nil, need.toNeedResponse())
+}
+
+// UpdateNeed edits an open need. Lowering the target below what was already raised is allowed;
+// the need then simply counts as funded.
+func (s *service) UpdateNeed(ctx context.Context, accountID, id string, req UpdateNeedRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	need, res := s.findNeed(ctx, accountID, id)
+	if res != nil {
+		return *res
+	}
+	if need.Status != StatusOpen {
+		return pkg.NewResponse(http.StatusBadRequest, "Hanya kebutuhan yang masih terbuka yang dapat diubah", nil, nil)
+	}
+
+	errs := map[string]string{}
+	updateData := map[string]interface{}{}
+	if title := strings.TrimSpace(req.Title); title != "" {
+		updateData["title"] = title
+	}
+	if req.Description != nil {
+		updateData["description"] = strings.TrimSpace(*req.Description)
+	}
+	if req.TargetAmount != nil {
+		if *req.TargetAmount <= 0 {
+			errs["targetAmount"] = "Biaya kebutuhan harus lebih besar dari 0"
+		}
+		updateData["target_amount"] = *req.TargetAmount
+	}
+	if req.ClearDeadline {
+		updateData["deadline"] = nil
+	} else if deadline := parseDeadline(errs, req.Deadline); deadline != nil {
+		updateData["deadline"] = *deadline
+	}
+	if len(errs) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errs, nil)
+	}
+	if len(updateData) == 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Tidak ada data yang diubah", nil, nil)
+	}
+	updateData["updated_at"] = time.Now()
+
+	if err := s.repo.UpdateNeed(ctx, id, StatusOpen, updateData); err != nil {
+		if errors.Is(err, ErrStatusChanged) {
+			return pkg.NewResponse(http.StatusConflict, "Status kebutuhan telah berubah, muat ulang data", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children_need.service",
+			"need_id":   id,
+		}).WithError(err).Error("failed to update need")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memperbarui kebutuhan", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "foster_children_need", id, need.toNeedResponse(), updateData)
+	return s.GetNeedByID(ctx, accountID, id)
+}
+
+// CancelNeed withdraws an open need. Donations already earmarked to it stay with the child's
+// general fund, so the reason is shown publicly to explain where they went.
+func (s *service) CancelNeed(ctx context.Context, accountID, id string, req CancelNeedRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	need, res := s.findNeed(ctx, accountID, id)
+	if res != nil {
+		return *res
+	}
+	if need.Status != StatusOpen {
+		return pkg.NewResponse(http.StatusBadRequest, "Hanya kebutuhan yang masih terbuka yang dapat dibatalkan", nil, nil)
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"reason": "Alasan pembatalan wajib diisi"}, nil)
+	}
+
+	updateData := map[string]interface{}{
+		"status":        StatusCancelled,
+		"cancel_reason": reason,
+		"updated_at":    time.Now(),
+	}
+	if err := s.repo.UpdateNeed(ctx, id, StatusOpen, updateData); err != nil {
+		if errors.Is(err, ErrStatusChanged) {
+			return pkg.NewResponse(http.StatusConflict, "Status kebutuhan telah berubah, muat ulang data", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children_need.service",
+			"need_id":   id,
+		}).WithError(err).Error("failed to cancel need")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membatalkan kebutuhan", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "foster_children_need", id, need.toNeedResponse(), updateData)
+	return pkg.NewResponse(http.StatusOK, "Kebutuhan berhasil dibatalkan", nil, nil)
+}
+
+// FulfillNeed records that the need was bought: it links the expense of the same child that
+// paid for it and stores at least one proof photo. A need can be fulfilled before its target is
+// reached when the foundation covers the rest.
+func (s *service) FulfillNeed(ctx context.Context, accountID, id string, req FulfillNeedRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	need, res := s.findNeed(ctx, accountID, id)
+	if res != nil {
+		return *res
+	}
+	if need.Status != StatusOpen {
+		return pkg.NewResponse(http.StatusBadRequest, "Hanya kebutuhan yang masih terbuka yang dapat dipenuhi", nil, nil)
+	}
+
+	errs := map[string]string{}
+	if uuid.Validate(req.ExpenseID) != nil {
+		errs["expenseId"] = "Pengeluaran wajib dipilih"
+	}
+	if len(req.Photos) == 0 {
+		errs["photos"] = "Minimal satu foto bukti wajib diunggah"
+	}
+	if len(errs) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errs, nil)
+	}
+
+	expense, err := s.expenseRepo.FindOneFosterChildrenExpense(ctx, map[string]interface{}{"id": req.ExpenseID})
+	if err != nil || expense.FosterChildrenID != need.FosterChildrenID {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"expenseId": "Pengeluaran tidak ditemukan pada anak asuh ini"}, nil)
+	}
+	if linked, err := s.repo.FindOneNeed(ctx, map[string]interface{}{"expense_id": req.ExpenseID}); err == nil {
+		return pkg.NewResponse(http.StatusConflict, "Pengeluaran sudah tercatat untuk kebutuhan "+linked.Title, nil, nil)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"component":  "foster_children_need.service",
+			"need_id":    id,
+			"expense_id": req.ExpenseID,
+		}).WithError(err).Error("failed to check expense link")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memenuhi kebutuhan", nil, nil)
+	}
+
+	photos, err := s.uploadPhotos(ctx, need.ID, req.Photos, req.PhotoCaptions)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children_need.service",
+			"need_id":   id,
+		}).WithError(err).Error("failed to upload need proof photos")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah foto bukti", nil, nil)
+	}
+
+	now := time.Now()
+	fulfilledBy := uuid.MustParse(accountID)
+	updateData := map[string]interface{}{
+		"status":           StatusFulfilled,
+		"expense_id":       expense.ID,
+		"fulfillment_note": strings.TrimSpace(req.Note),
+		"fulfilled_by":     fulfilledBy,
+		"fulfilled_at":     now,
+		"updated_at":       now,
+	}
+	if err := s.repo.FulfillNeed(ctx, id, updateData, photos); err != nil {
+		s.deletePhotoFiles(ctx, photos)
+		if errors.Is(err, ErrStatusChanged) {
+			return pkg.NewResponse(http.StatusConflict, "Status kebutuhan telah berubah, muat ulang data", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children_need.service",
+			"need_id":   id,
+		}).WithError(err).Error("failed to fulfill need")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memenuhi kebutuhan", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "foster_children_need", id, need.toNeedResponse(), updateData)
+
+	need, res = s.findNeed(ctx, accountID, id)
+	if res != nil {
+		return *res
+	}
+	return pkg.NewResponse(http.StatusOK, "Kebutuhan berhasil dipenuhi", nil, need.toNeedResponse())
+}
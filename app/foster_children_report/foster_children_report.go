@@ -0,0 +1,98 @@
+package foster_children_report
+
+import (
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/foster_children"
+	"github.com/google/uuid"
+)
+
+// FosterChildrenReport is a term progress report written by the social coordinator. Drafts are
+// only visible to admins; once published the report is shown to the child's active sponsors.
+type FosterChildrenReport struct {
+	ID                 uuid.UUID  `json:"id" gorm:"primaryKey"`
+	FosterChildrenID   uuid.UUID  `json:"fosterChildrenId" gorm:"not null;uniqueIndex:idx_foster_children_report_term,priority:1"`
+	AcademicYear       string     `json:"academicYear" gorm:"not null;uniqueIndex:idx_foster_children_report_term,priority:2"` // e.g. 2025/2026
+	Semester           Semester   `json:"semester" gorm:"type:varchar(10);not null;uniqueIndex:idx_foster_children_report_term,priority:3"`
+	SchoolName         string     `json:"schoolName"`
+	EducationLevel     int        `json:"educationLevel"`
+	AttendancePresent  int        `json:"attendancePresent" gorm:"default:0"` // days
+	AttendanceSick     int        `json:"attendanceSick" gorm:"default:0"`
+	AttendancePermit   int        `json:"attendancePermit" gorm:"default:0"`
+	AttendanceAbsent   int        `json:"attendanceAbsent" gorm:"default:0"`
+	HealthNotes        string     `json:"healthNotes" gorm:"type:text"`
+	Narrative          string     `json:"narrative" gorm:"type:text"` // coordinator's summary for sponsors
+	Status             Status     `json:"status" gorm:"type:varchar(20);not null;default:'draft'"`
+	AuthoredBy         uuid.UUID  `json:"authoredBy" gorm:"not null"`
+	PublishedBy        *uuid.UUID `json:"publishedBy"`
+	PublishedAt        *time.Time `json:"publishedAt"`
+	SponsorsNotifiedAt *time.Time `json:"sponsorsNotifiedAt"`
+	CreatedAt          time.Time  `json:"createdAt"`
+	UpdatedAt          time.Time  `json:"updatedAt"`
+
+	Grades         []ReportGrade                   `json:"grades" gorm:"foreignKey:ReportID"`
+	Photos         []ReportPhoto                   `json:"photos" gorm:"foreignKey:ReportID"`
+	FosterChildren *foster_children.FosterChildren `json:"-" gorm:"foreignKey:FosterChildrenID;references:ID"`
+}
+
+type ReportGrade struct {
+	ID        uuid.UUID `json:"id" gorm:"primaryKey"`
+	ReportID  uuid.UUID `json:"reportId" gorm:"not null;index"`
+	Subject   string    `json:"subject" gorm:"not null"`
+	Score     float64   `json:"score"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ReportPhoto is stored in private storage; children's photos are only handed out as presigned
+// links to sponsors and admins.
+type ReportPhoto struct {
+	ID        uuid.UUID `json:"id" gorm:"primaryKey"`
+	ReportID  uuid.UUID `json:"reportId" gorm:"not null;index"`
+	URL       string    `json:"url" gorm:"not null"`
+	Caption   string    `json:"caption"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type Semester string
+
+const (
+	SemesterOdd  Semester = "ganjil"
+	SemesterEven Semester = "genap"
+)
+
+func (s Semester) IsValid() bool {
+	return s == SemesterOdd || s == SemesterEven
+}
+
+type Status string
+
+const (
+	StatusDraft     Status = "draft"
+	StatusPublished Status = "published"
+)
+
+func (s Status) IsValid() bool {
+	return s == StatusDraft || s == StatusPublished
+}
+
+// Term is the display label, e.g. "Semester Ganjil 2025/2026".
+func (r *FosterChildrenReport) Term() string {
+	label := "Ganjil"
+	if r.Semester == SemesterEven {
+		label = "Genap"
+	}
+	return "Semester " + label + " " + r.AcademicYear
+}
+
+// AverageScore is the mean of the subject scores, zero without grades.
+func (r *FosterChildrenReport) AverageScore() float64 {
+	if len(r.Grades) == 0 {
+		return 0
+	}
+	var total float64
+	for _, g := range r.Grades {
+		total += g.Score
+	}
+	return total / float64(len(r.Grades))
+}
@@ -0,0 +1,230 @@
+package foster_children_report
+
+import (
+	"net/http"
+
+	"github.com/Vilamuzz/yota-backend/app/middleware"
+	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/Vilamuzz/yota-backend/pkg/enum"
+	jwt_pkg "github.com/Vilamuzz/yota-backend/pkg/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	service    Service
+	middleware middleware.AppMiddleware
+}
+
+func NewHandler(r *gin.RouterGroup, s Service, m middleware.AppMiddleware) {
+	h := &handler{
+		service:    s,
+		middleware: m,
+	}
+	h.RegisterRoutes(r)
+}
+
+func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
+	me := r.Group("/foster-children/reports/me")
+	me.Use(h.middleware.RequireRoles(enum.RoleOrangTuaAsuh))
+	{
+		me.GET("", h.GetMyReportList)
+		me.GET("/:id", h.GetMyReportByID)
+	}
+
+	admin := r.Group("/admin/foster-children/reports")
+	admin.Use(h.middleware.RequireRoles(enum.RoleSocialManager, enum.RoleChairman))
+	{
+		admin.GET("", h.GetReportList)
+		admin.GET("/:id", h.GetReportByID)
+	}
+
+	socialManagerOnly := r.Group("/admin/foster-children")
+	socialManagerOnly.Use(h.middleware.RequireRoles(enum.RoleSocialManager))
+	{
+		socialManagerOnly.POST("/:id/reports", h.CreateReport)
+		socialManagerOnly.PUT("/reports/:id", h.UpdateReport)
+		socialManagerOnly.PATCH("/reports/:id/publish", h.PublishReport)
+		socialManagerOnly.DELETE("/reports/:id", h.DeleteReport)
+	}
+}
+
+// GetReportList
+//
+// @Summary List Foster Children Progress Reports
+// @Description Retrieve term progress reports, drafts included (admin only)
+// @Tags Foster Children Reports
+// @Security BearerAuth
+// @Produce json
+// @Param fosterChildrenId query string false "Filter by foster child ID"
+// @Param academicYear query string false "Filter by academic year, e.g. 2025/2026"
+// @Param status query string false "Filter by status (draft, published)"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Success 200 {object} pkg.Response{data=ReportListResponse}
+// @Router /api/admin/foster-children/reports [get]
+func (h *handler) GetReportList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var params ReportQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid query parameters", nil, nil))
+		return
+	}
+
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.GetReportList(ctx, claims.AccountID, params)
+	c.JSON(res.Status, res)
+}
+
+// GetReportByID
+//
+// @Summary Get Foster Children Progress Report
+// @Description Retrieve a progress report with grades and signed photo links (admin only)
+// @Tags Foster Children Reports
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Report ID"
+// @Success 200 {object} pkg.Response{data=ReportResponse}
+// @Router /api/admin/foster-children/reports/{id} [get]
+func (h *handler) GetReportByID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.GetReportByID(ctx, claims.AccountID, c.Param("id"))
+	c.JSON(res.Status, res)
+}
+
+// CreateReport
+//
+// @Summary Create Foster Children Progress Report
+// @Description Write a draft term report with grades, attendance, health notes, photos and a narrative
+// @Tags Foster Children Reports
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Foster Children ID"
+// @Param payload formData CreateReportRequest true "Report Data"
+// @Param photos[] formData file false "Photos"
+// @Success 201 {object} pkg.Response{data=ReportResponse}
+// @Router /api/admin/foster-children/{id}/reports [post]
+func (h *handler) CreateReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req CreateReportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid request body", nil, nil))
+		return
+	}
+
+	res := h.service.CreateReport(ctx, claims.AccountID, c.Param("id"), req)
+	c.JSON(res.Status, res)
+}
+
+// UpdateReport
+//
+// @Summary Update Foster Children Progress Report
+// @Description Edit a draft report; sending gradeSubjects replaces all grades
+// @Tags Foster Children Reports
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Report ID"
+// @Param payload formData UpdateReportRequest true "Report Data"
+// @Param photos[] formData file false "Photos to add"
+// @Success 200 {object} pkg.Response{data=ReportResponse}
+// @Router /api/admin/foster-children/reports/{id} [put]
+func (h *handler) UpdateReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req UpdateReportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid request body", nil, nil))
+		return
+	}
+
+	res := h.service.UpdateReport(ctx, claims.AccountID, c.Param("id"), req)
+	c.JSON(res.Status, res)
+}
+
+// PublishReport
+//
+// @Summary Publish Foster Children Progress Report
+// @Description Publish a draft report to the child's active sponsors and notify them by email
+// @Tags Foster Children Reports
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Report ID"
+// @Success 200 {object} pkg.Response
+// @Router /api/admin/foster-children/reports/{id}/publish [patch]
+func (h *handler) PublishReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.PublishReport(ctx, claims.AccountID, c.Param("id"))
+	c.JSON(res.Status, res)
+}
+
+// DeleteReport
+//
+// @Summary Delete Foster Children Progress Report
+// @Description Delete a draft report and its photos
+// @Tags Foster Children Reports
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Report ID"
+// @Success 200 {object} pkg.Response
+// @Router /api/admin/foster-children/reports/{id} [delete]
+func (h *handler) DeleteReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.DeleteReport(ctx, claims.AccountID, c.Param("id"))
+	c.JSON(res.Status, res)
+}
+
+// GetMyReportList
+//
+// @Summary List Progress Reports of My Sponsored Children
+// @Description Retrieve the published reports of the children the authenticated sponsor actively sponsors
+// @Tags Foster Children Reports
+// @Security BearerAuth
+// @Produce json
+// @Param fosterChildrenId query string false "Filter by foster child ID"
+// @Param academicYear query string false "Filter by academic year, e.g. 2025/2026"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Success 200 {object} pkg.Response{data=ReportListResponse}
+// @Router /api/foster-children/reports/me [get]
+func (h *handler) GetMyReportList(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var params ReportQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid query parameters", nil, nil))
+		return
+	}
+
+	res := h.service.GetMyReportList(ctx, claims.AccountID, params)
+	c.JSON(res.Status, res)
+}
+
+// GetMyReportByID
+//
+// @Summary Get Progress Report of My Sponsored Child
+// @Description Retrieve a published report of a child the authenticated sponsor actively sponsors
+// @Tags Foster Children Reports
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Report ID"
+// @Success 200 {object} pkg.Response{data=ReportResponse}
+// @Router /api/foster-children/reports/me/{id} [get]
+func (h *handler) GetMyReportByID(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.GetMyReportByID(ctx, claims.AccountID, c.Param("id"))
+	c.JSON(res.Status, res)
+}
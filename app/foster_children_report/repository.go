@@ -0,0 +1,165 @@
+package foster_children_report
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+var ErrAlreadyPublished = errors.New("report already published")
+
+type Repository interface {
+	FindAllReports(ctx context.Context, options map[string]interface{}) ([]FosterChildrenReport, error)
+	CountReports(ctx context.Context, options map[string]interface{}) (int64, error)
+	FindOneReport(ctx context.Context, options map[string]interface{}) (*FosterChildrenReport, error)
+	CreateReport(ctx context.Context, report *FosterChildrenReport) error
+	UpdateReport(ctx context.Context, id string, updateData map[string]interface{}, grades []ReportGrade, replaceGrades bool, newPhotos []ReportPhoto, deletePhotoIDs []string) error
+	PublishReport(ctx context.Context, id string, updateData map[string]interface{}) error
+	MarkSponsorsNotified(ctx context.Context, id string) error
+	DeleteReport(ctx context.Context, id string) error
+}
+
+type repository struct {
+	Conn *gorm.DB
+}
+
+func NewRepository(conn *gorm.DB) Repository {
+	return &repository{Conn: conn}
+}
+
+func (r *repository) applyFilters(query *gorm.DB, options map[string]interface{}) *gorm.DB {
+	if fosterChildrenID, ok := options["foster_children_id"]; ok && fosterChildrenID.(string) != "" {
+		query = query.Where("foster_children_id = ?", fosterChildrenID.(string))
+	}
+	// An empty list comes from a scoped account without grants and matches nothing.
+	if ids, ok := options["foster_children_ids"].([]string); ok {
+		query = query.Where("foster_children_id IN ?", ids)
+	}
+	if status, ok := options["status"]; ok && status.(string) != "" {
+		query = query.Where("status = ?", status.(string))
+	}
+	if academicYear, ok := options["academic_year"]; ok && academicYear.(string) != "" {
+		query = query.Where("academic_year = ?", academicYear.(string))
+	}
+	return query
+}
+
+// FindAllReports orders the newest term first; "genap" sorts after "ganjil", so the second
+// semester of a year comes first.
+func (r *repository) FindAllReports(ctx context.Context, options map[string]interface{}) ([]FosterChildrenReport, error) {
+	var reports []FosterChildrenReport
+	query := r.applyFilters(r.Conn.WithContext(ctx).Preload("Grades").Preload("FosterChildren"), options)
+
+	limit := 10
+	if l, ok := options["limit"]; ok && l.(int) > 0 {
+		limit = l.(int)
+	}
+	offset := 0
+	if page, ok := options["page"]; ok && page.(int) > 1 {
+		offset = (page.(int) - 1) * limit
+	}
+
+	if err := query.Order("academic_year DESC, semester DESC, created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&reports).Error; err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (r *repository) CountReports(ctx context.Context, options map[string]interface{}) (int64, error) {
+	var total int64
+	query := r.applyFilters(r.Conn.WithContext(ctx).Model(&FosterChildrenReport{}), options)
+	if err := query.Count(&total).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *repository) FindOneReport(ctx context.Context, options map[string]interface{}) (*FosterChildrenReport, error) {
+	var report FosterChildrenReport
+	query := r.Conn.WithContext(ctx).
+		Preload("Grades", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("Photos", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("FosterChildren")
+
+	if id, ok := options["id"]; ok && id.(string) != "" {
+		query = query.Where("id = ?", id.(string))
+	}
+	if semester, ok := options["semester"]; ok && semester.(string) != "" {
+		query = query.Where("semester = ?", semester.(string))
+	}
+	query = r.applyFilters(query, options)
+
+	if err := query.First(&report).Error; err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *repository) CreateReport(ctx context.Context, report *FosterChildrenReport) error {
+	return r.Conn.WithContext(ctx).Create(report).Error
+}
+
+// UpdateReport applies the field changes, replaces the grades when replaceGrades is set and adds
+// or removes photos in one transaction.
+func (r *repository) UpdateReport(ctx context.Context, id string, updateData map[string]interface{}, grades []ReportGrade, replaceGrades bool, newPhotos []ReportPhoto, deletePhotoIDs []string) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(updateData) > 0 {
+			if err := tx.Model(&FosterChildrenReport{}).Where("id = ?", id).Updates(updateData).Error; err != nil {
+				return err
+			}
+		}
+		if replaceGrades {
+			if err := tx.Where("report_id = ?", id).Delete(&ReportGrade{}).Error; err != nil {
+				return err
+			}
+			if len(grades) > 0 {
+				if err := tx.Create(&grades).Error; err != nil {
+					return err
+				}
+			}
+		}
+		if len(deletePhotoIDs) > 0 {
+			if err := tx.Where("report_id = ? AND id IN ?", id, deletePhotoIDs).Delete(&ReportPhoto{}).Error; err != nil {
+				return err
+			}
+		}
+		if len(newPhotos) > 0 {
+			if err := tx.Create(&newPhotos).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *repository) PublishReport(ctx context.Context, id string, updateData map[string]interface{}) error {
+	result := r.Conn.WithContext(ctx).Model(&FosterChildrenReport{}).
+		Where("id = ? AND status = ?", id, StatusDraft).
+		Updates(updateData)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAlreadyPublished
+	}
+	return nil
+}
+
+func (r *repository) MarkSponsorsNotified(ctx context.Context, id string) error {
+	return r.Conn.WithContext(ctx).Model(&FosterChildrenReport{}).Where("id = ?", id).Update("sponsors_notified_at", gorm.Expr("NOW()")).Error
+}
+
+func (r *repository) DeleteReport(ctx context.Context, id string) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("report_id = ?", id).Delete(&ReportGrade{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("report_id = ?", id).Delete(&ReportPhoto{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&FosterChildrenReport{}).Error
+	})
+}
@@ -0,0 +1,50 @@
+package foster_children_report
+
+import "mime/multipart"
+
+// Grades are sent as parallel arrays in the multipart form: the n-th subject belongs to the n-th
+// score and note.
+type CreateReportRequest struct {
+	AcademicYear      string                  `form:"academicYear"`
+	Semester          Semester                `form:"semester"`
+	SchoolName        string                  `form:"schoolName"`     // defaults to the child's current school
+	EducationLevel    int                     `form:"educationLevel"` // defaults to the child's current level
+	AttendancePresent int                     `form:"attendancePresent"`
+	AttendanceSick    int                     `form:"attendanceSick"`
+	AttendancePermit  int                     `form:"attendancePermit"`
+	AttendanceAbsent  int                     `form:"attendanceAbsent"`
+	HealthNotes       string                  `form:"healthNotes"`
+	Narrative         string                  `form:"narrative"`
+	GradeSubjects     []string                `form:"gradeSubjects[]"`
+	GradeScores       []float64               `form:"gradeScores[]"`
+	GradeNotes        []string                `form:"gradeNotes[]"`
+	Photos            []*multipart.FileHeader `form:"photos[]" swaggerignore:"true"`
+	PhotoCaptions     []string                `form:"photoCaptions[]"`
+}
+
+// UpdateReportRequest only changes the fields that are sent. Sending gradeSubjects replaces all
+// grades; photos are added and deletePhotoIds removes existing ones.
+type UpdateReportRequest struct {
+	SchoolName        string                  `form:"schoolName"`
+	EducationLevel    int                     `form:"educationLevel"`
+	AttendancePresent *int                    `form:"attendancePresent"`
+	AttendanceSick    *int                    `form:"attendanceSick"`
+	AttendancePermit  *int                    `form:"attendancePermit"`
+	AttendanceAbsent  *int                    `form:"attendanceAbsent"`
+	HealthNotes       string                  `form:"healthNotes"`
+	Narrative         string                  `form:"narrative"`
+	GradeSubjects     []string                `form:"gradeSubjects[]"`
+	GradeScores       []float64               `form:"gradeScores[]"`
+	GradeNotes        []string                `form:"gradeNotes[]"`
+	Photos            []*multipart.FileHeader `form:"photos[]" swaggerignore:"true"`
+	PhotoCaptions     []string                `form:"photoCaptions[]"`
+	DeletePhotoIDs    []string                `form:"deletePhotoIds[]"`
+}
+
+type ReportQueryParams struct {
+	AcademicYear     string `form:"academicYear"`
+	Status           string `form:"status"`
+	FosterChildrenID string `form:"fosterChildrenId"`
+	Page             int    `form:"page"`
+	Limit            int    `form:"limit"`
+}
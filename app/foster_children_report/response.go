@@ -0,0 +1,143 @@
+package foster_children_report
+
+import (
+	"context"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/pkg"
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
+	"github.com/sirupsen/logrus"
+)
+
+type ReportGradeResponse struct {
+	ID      string  `json:"id"`
+	Subject string  `json:"subject"`
+	Score   float64 `json:"score"`
+	Note    string  `json:"note"`
+}
+
+type ReportPhotoResponse struct {
+	ID        string `json:"id"`
+	URL       string `json:"url"`
+	ExpiresAt string `json:"expiresAt,omitempty"`
+	Caption   string `json:"caption"`
+}
+
+type AttendanceResponse struct {
+	Present int `json:"present"`
+	Sick    int `json:"sick"`
+	Permit  int `json:"permit"`
+	Absent  int `json:"absent"`
+}
+
+type ReportListItemResponse struct {
+	ID                 string             `json:"id"`
+	FosterChildrenID   string             `json:"fosterChildrenId"`
+	FosterChildrenName string             `json:"fosterChildrenName"`
+	FosterChildrenSlug string             `json:"fosterChildrenSlug"`
+	AcademicYear       string             `json:"academicYear"`
+	Semester           Semester           `json:"semester"`
+	Term               string             `json:"term"`
+	SchoolName         string             `json:"schoolName"`
+	EducationLevel     int                `json:"educationLevel"`
+	Attendance         AttendanceResponse `json:"attendance"`
+	AverageScore       float64            `json:"averageScore"`
+	Status             Status             `json:"status"`
+	PublishedAt        *time.Time         `json:"publishedAt"`
+	CreatedAt          time.Time          `json:"createdAt"`
+}
+
+type ReportResponse struct {
+	ReportListItemResponse
+	HealthNotes        string                `json:"healthNotes"`
+	Narrative          string                `json:"narrative"`
+	Grades             []ReportGradeResponse `json:"grades"`
+	Photos             []ReportPhotoResponse `json:"photos"`
+	SponsorsNotifiedAt *time.Time            `json:"sponsorsNotifiedAt"`
+	UpdatedAt          time.Time             `json:"updatedAt"`
+}
+
+type ReportListResponse struct {
+	Reports    []ReportListItemResponse `json:"reports"`
+	Pagination pkg.OffsetPagination     `json:"pagination"`
+}
+
+func (r *FosterChildrenReport) toReportListItemResponse() ReportListItemResponse {
+	resp := ReportListItemResponse{
+		ID:               r.ID.String(),
+		FosterChildrenID: r.FosterChildrenID.String(),
+		AcademicYear:     r.AcademicYear,
+		Semester:         r.Semester,
+		Term:             r.Term(),
+		SchoolName:       r.SchoolName,
+		EducationLevel:   r.EducationLevel,
+		Attendance: AttendanceResponse{
+			Present: r.AttendancePresent,
+			Sick:    r.AttendanceSick,
+			Permit:  r.AttendancePermit,
+			Absent:  r.AttendanceAbsent,
+		},
+		AverageScore: r.AverageScore(),
+		Status:       r.Status,
+		PublishedAt:  r.PublishedAt,
+		CreatedAt:    r.CreatedAt,
+	}
+	if r.FosterChildren != nil {
+		resp.FosterChildrenName = r.FosterChildren.Name
+		resp.FosterChildrenSlug = r.FosterChildren.Slug
+	}
+	return resp
+}
+
+// toReportResponse signs every photo; a photo that cannot be signed is left out rather than
+// failing the whole report.
+func (r *FosterChildrenReport) toReportResponse(ctx context.Context, storage s3_pkg.PrivateClient) ReportResponse {
+	grades := make([]ReportGradeResponse, 0, len(r.Grades))
+	for _, g := range r.Grades {
+		grades = append(grades, ReportGradeResponse{
+			ID:      g.ID.String(),
+			Subject: g.Subject,
+			Score:   g.Score,
+			Note:    g.Note,
+		})
+	}
+
+	photos := make([]ReportPhotoResponse, 0, len(r.Photos))
+	for _, p := range r.Photos {
+		link, err := s3_pkg.SignDocument(ctx, storage, p.URL)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component": "foster_children_report.response",
+				"photo_id":  p.ID.String(),
+			}).WithError(err).Error("failed to sign report photo url")
+			continue
+		}
+		photos = append(photos, ReportPhotoResponse{
+			ID:        p.ID.String(),
+			URL:       link.URL,
+			ExpiresAt: link.ExpiresAt,
+			Caption:   p.Caption,
+		})
+	}
+
+	return ReportResponse{
+		ReportListItemResponse: r.toReportListItemResponse(),
+		HealthNotes:            r.HealthNotes,
+		Narrative:              r.Narrative,
+		Grades:                 grades,
+		Photos:                 photos,
+		SponsorsNotifiedAt:     r.SponsorsNotifiedAt,
+		UpdatedAt:              r.UpdatedAt,
+	}
+}
+
+func toReportListResponse(reports []FosterChildrenReport, pagination pkg.OffsetPagination) ReportListResponse {
+	responses := make([]ReportListItemResponse, 0, len(reports))
+	for _, r := range reports {
+		responses = append(responses, r.toReportListItemResponse())
+	}
+	return ReportListResponse{
+		Reports:    responses,
+		Pagination: pagination,
+	}
+}
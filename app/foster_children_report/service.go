@@ -0,0 +1,658 @@
+package foster_children_report
+
+import (
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/access_grant"
+	"github.com/Vilamuzz/yota-backend/app/foster_children"
+	"github.com/Vilamuzz/yota-backend/app/foster_children_sponsorship"
+	app_log "github.com/Vilamuzz/yota-backend/app/log"
+	"github.com/Vilamuzz/yota-backend/pkg"
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const photoFolder = "foster-children/reports"
+
+var academicYearPattern = regexp.MustCompile(`^(\d{4})/(\d{4})$`)
+
+type Service interface {
+	GetReportList(ctx context.Context, accountID string, params ReportQueryParams) pkg.Response
+	GetReportByID(ctx context.Context, accountID, id string) pkg.Response
+	CreateReport(ctx context.Context, authorID, fosterChildrenID string, req CreateReportRequest) pkg.Response
+	UpdateReport(ctx context.Context, accountID, id string, req UpdateReportRequest) pkg.Response
+	PublishReport(ctx context.Context, accountID, id string) pkg.Response
+	DeleteReport(ctx context.Context, accountID, id string) pkg.Response
+	GetMyReportList(ctx context.Context, accountID string, params ReportQueryParams) pkg.Response
+	GetMyReportByID(ctx context.Context, accountID, id string) pkg.Response
+}
+
+type service struct {
+	repo               Repository
+	fosterChildrenRepo foster_children.Repository
+	sponsorshipRepo    foster_children_sponsorship.Repository
+	accessGrantRepo    access_grant.Repository
+	privateStorage     s3_pkg.PrivateClient
+	logService         app_log.Service
+	emailService       *pkg.EmailService
+	timeout            time.Duration
+}
+
+func NewService(repo Repository, fosterChildrenRepo foster_children.Repository, sponsorshipRepo foster_children_sponsorship.Repository, accessGrantRepo access_grant.Repository, privateStorage s3_pkg.PrivateClient, logService app_log.Service, timeout time.Duration) Service {
+	return &service{
+		repo:               repo,
+		fosterChildrenRepo: fosterChildrenRepo,
+		sponsorshipRepo:    sponsorshipRepo,
+		accessGrantRepo:    accessGrantRepo,
+		privateStorage:     privateStorage,
+		logService:         logService,
+		emailService:       pkg.NewEmailService(),
+		timeout:            timeout,
+	}
+}
+
+func validateAcademicYear(value string) string {
+	m := academicYearPattern.FindStringSubmatch(value)
+	if m == nil {
+		return "Tahun ajaran harus berformat YYYY/YYYY"
+	}
+	start, _ := strconv.Atoi(m[1])
+	end, _ := strconv.Atoi(m[2])
+	if end != start+1 {
+		return "Tahun ajaran harus dua tahun berurutan"
+	}
+	return ""
+}
+
+func validateAttendance(errs map[string]string, field string, value int) {
+	if value < 0 {
+		errs[field] = "Jumlah hari tidak boleh negatif"
+	}
+}
+
+// buildGrades pairs the parallel grade arrays of the form.
+func buildGrades(errs map[string]string, reportID uuid.UUID, subjects []string, scores []float64, notes []string) []ReportGrade {
+	if len(scores) != len(subjects) {
+		errs["gradeScores"] = "Jumlah nilai harus sama dengan jumlah mata pelajaran"
+		return nil
+	}
+	grades := make([]ReportGrade, 0, len(subjects))
+	for i, subject := range subjects {
+		subject = strings.TrimSpace(subject)
+		if subject == "" {
+			errs["gradeSubjects"] = "Nama mata pelajaran wajib diisi"
+			return nil
+		}
+		if scores[i] < 0 || scores[i] > 100 {
+			errs["gradeScores"] = "Nilai harus antara 0 dan 100"
+			return nil
+		}
+		grade := ReportGrade{
+			ID:       uuid.New(),
+			ReportID: reportID,
+			Subject:  subject,
+			Score:    scores[i],
+		}
+		if i < len(notes) {
+			grade.Note = strings.TrimSpace(notes[i])
+		}
+		grades = append(grades, grade)
+	}
+	return grades
+}
+
+// uploadPhotos stores the photos privately. On failure the photos uploaded so far are removed
+// again so no orphan objects are left behind.
+func (s *service) uploadPhotos(ctx context.Context, reportID uuid.UUID, files []*multipart.FileHeader, captions []string) ([]ReportPhoto, error) {
+	photos := make([]ReportPhoto, 0, len(files))
+	for i, file := range files {
+		ref, err := s.privateStorage.UploadFile(ctx, file, photoFolder)
+		if err != nil {
+			s.deletePhotoFiles(ctx, photos)
+			return nil, err
+		}
+		photo := ReportPhoto{
+			ID:       uuid.New(),
+			ReportID: reportID,
+			URL:      ref,
+		}
+		if i < len(captions) {
+			photo.Caption = strings.TrimSpace(captions[i])
+		}
+		photos = append(photos, photo)
+	}
+	return photos, nil
+}
+
+func (s *service) deletePhotoFiles(ctx context.Context, photos []ReportPhoto) {
+	for _, p := range photos {
+		if err := s.privateStorage.DeleteFile(ctx, p.URL); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component": "foster_children_report.service",
+				"photo_id":  p.ID.String(),
+			}).WithError(err).Error("failed to delete report photo from storage")
+		}
+	}
+}
+
+func (s *service) listReports(ctx context.Context, options map[string]interface{}, params ReportQueryParams) pkg.Response {
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+
+	options["limit"] = params.Limit
+	options["page"] = params.Page
+	if params.AcademicYear != "" {
+		options["academic_year"] = params.AcademicYear
+	}
+	if params.FosterChildrenID != "" {
+		options["foster_children_id"] = params.FosterChildrenID
+	}
+
+	total, err := s.repo.CountReports(ctx, options)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children_report.service",
+			"options":   options,
+		}).WithError(err).Error("failed to count reports")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil laporan perkembangan", nil, nil)
+	}
+
+	reports, err := s.repo.FindAllReports(ctx, options)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children_report.service",
+			"options":   options,
+		}).WithError(err).Error("failed to fetch reports")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil laporan perkembangan", nil, nil)
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit != 0 {
+		totalPages++
+	}
+
+	pagination := pkg.OffsetPagination{
+		Page:       params.Page,
+		Limit:      params.Limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, toReportListResponse(reports, pagination))
+}
+
+func (s *service) findReport(ctx context.Context, options map[string]interface{}) (*FosterChildrenReport, *pkg.Response) {
+	if id, _ := options["id"].(string); uuid.Validate(id) != nil {
+		res := pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID laporan tidak valid"}, nil)
+		return nil, &res
+	}
+	report, err := s.repo.FindOneReport(ctx, options)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := pkg.NewResponse(http.StatusNotFound, "Laporan perkembangan tidak ditemukan", nil, nil)
+			return nil, &res
+		}
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children_report.service",
+			"options":   options,
+		}).WithError(err).Error("failed to fetch report")
+		res := pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil laporan perkembangan", nil, nil)
+		return nil, &res
+	}
+	return report, nil
+}
+
+// applyAccessScope narrows options to the reports of the foster children granted to the account
+// when it is scoped. A scoped account without grants gets an empty list and sees nothing.
+func (s *service) applyAccessScope(ctx context.Context, accountID string, options map[string]interface{}) error {
+	ids, scoped, err := s.accessGrantRepo.FindAccessScope(ctx, accountID, access_grant.ResourceFosterChildren)
+	if err != nil {
+		return err
+	}
+	if scoped {
+		options["foster_children_ids"] = ids
+	}
+	return nil
+}
+
+// findScopedReport loads a report for the admin routes; reports of children outside the
+// account's access scope look missing.
+func (s *service) findScopedReport(ctx context.Context, accountID, id string) (*FosterChildrenReport, *pkg.Response) {
+	options := map[string]interface{}{"id": id}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "foster_children_report.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		res := pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil laporan perkembangan", nil, nil)
+		return nil, &res
+	}
+	return s.findReport(ctx, options)
+}
+
+func (s *service) GetReportList(ctx context.Context, accountID string, params ReportQueryParams) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if params.Status != "" && !Status(params.Status).IsValid() {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"status": "Status tidak valid"}, nil)
+	}
+	options := map[string]interface{}{}
+	if params.Status != "" {
+		options["status"] = params.Status
+	}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "foster_children_report.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil laporan perkembangan", nil, nil)
+	}
+	return s.listReports(ctx, options, params)
+}
+
+func (s *service) GetReportByID(ctx context.Context, accountID, id string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	report, res := s.findScopedReport(ctx, accountID, id)
+	if res != nil {
+		return *res
+	}
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, report.toReportResponse(ctx, s.privateStorage))
+}
+
+func (s *service) CreateReport(ctx context.Context, authorID, fosterChildrenID string, req CreateReportRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if uuid.Validate(fosterChildrenID) != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID anak asuh tidak valid"}, nil)
+	}
+
+	errs := map[string]string{}
+	req.AcademicYear = strings.TrimSpace(req.AcademicYear)
+	if msg := validateAcademicYear(req.AcademicYear); msg != "" {
+		errs["academicYear"] = msg
+	}
+	if !req.Semester.IsValid() {
+		errs["semester"] = "Semester harus ganjil atau genap"
+	}
+	if req.EducationLevel < 0 {
+		errs["educationLevel"] = "Jenjang pendidikan tidak valid"
+	}
+	validateAttendance(errs, "attendancePresent", req.AttendancePresent)
+	validateAttendance(errs, "attendanceSick", req.AttendanceSick)
+	validateAttendance(errs, "attendancePermit", req.AttendancePermit)
+	validateAttendance(errs, "attendanceAbsent", req.AttendanceAbsent)
+	if strings.TrimSpace(req.Narrative) == "" {
+		errs["narrative"] = "Catatan perkembangan wajib diisi"
+	}
+
+	reportID := uuid.New()
+	grades := buildGrades(errs, reportID, req.GradeSubjects, req.GradeScores, req.GradeNotes)
+	if len(errs) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errs, nil)
+	}
+
+	ok, err := s.accessGrantRepo.HasAccess(ctx, authorID, access_grant.ResourceFosterChildren, fosterChildrenID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":          "foster_children_report.service",
+			"account_id":         authorID,
+			"foster_children_id": fosterChildrenID,
+		}).WithError(err).Error("failed to resolve access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membuat laporan perkembangan", nil, nil)
+	}
+	if !ok {
+		return pkg.NewResponse(http.StatusNotFound, "Anak asuh tidak ditemukan", nil, nil)
+	}
+	child, err := s.fosterChildrenRepo.FindOneFosterChildren(ctx, map[string]interface{}{"id": fosterChildrenID})
+	if err != nil {
+		return pkg.NewResponse(http.StatusNotFound, "Anak asuh tidak ditemukan", nil, nil)
+	}
+
+	if _, err := s.repo.FindOneReport(ctx, map[string]interface{}{
+		"foster_children_id": fosterChildrenID,
+		"academic_year":      req.AcademicYear,
+		"semester":           string(req.Semester),
+	}); err == nil {
+		return pkg.NewResponse(http.StatusConflict, "Laporan untuk semester ini sudah ada", nil, nil)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"component":          "foster_children_report.service",
+			"foster_children_id": fosterChildrenID,
+		}).WithError(err).Error("failed to check existing report")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membuat laporan perkembangan", nil, nil)
+	}
+
+	photos, err := s.uploadPhotos(ctx, reportID, req.Photos, req.PhotoCaptions)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":          "foster_children_report.service",
+			"foster_children_id": fosterChildrenID,
+		}).WithError(err).Error("failed to upload report photos")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah foto laporan", nil, nil)
+	}
+
+	report := &FosterChildrenReport{
+		ID:                reportID,
+		FosterChildrenID:  child.ID,
+		AcademicYear:      req.AcademicYear,
+		Semester:          req.Semester,
+		SchoolName:        strings.TrimSpace(req.SchoolName),
+		EducationLevel:    req.EducationLevel,
+		AttendancePresent: req.AttendancePresent,
+		AttendanceSick:    req.AttendanceSick,
+		AttendancePermit:  req.AttendancePermit,
+		AttendanceAbsent:  req.AttendanceAbsent,
+		HealthNotes:       strings.TrimSpace(req.HealthNotes),
+		Narrative:         strings.TrimSpace(req.Narrative),
+		Status:            StatusDraft,
+		AuthoredBy:        uuid.MustParse(authorID),
+		Grades:            grades,
+		Photos:            photos,
+	}
+	if report.SchoolName == "" {
+		report.SchoolName = child.SchoolName
+	}
+	if report.EducationLevel == 0 {
+		report.EducationLevel = child.EducationLevel
+	}
+
+	if err := s.repo.CreateReport(ctx, report); err != nil {
+		s.deletePhotoFiles(ctx, photos)
+		logrus.WithFields(logrus.Fields{
+			"component":          "foster_children_report.service",
+			"foster_children_id": fosterChildrenID,
+		}).WithError(err).Error("failed to create report")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membuat laporan perkembangan", nil, nil)
+	}
+
+	report.FosterChildren = child
+	s.logService.CreateLog(ctx, &authorID, "CREATE", "foster_children_report", report.ID.String(), nil, report.toReportListItemResponse())
+	return pkg.NewResponse(http.StatusCreated, "Laporan perkembangan berhasil dibuat", nil, report.toReportResponse(ctx, s.privateStorage))
+}
+
+// UpdateReport edits a draft. Published reports are final because sponsors have already been
+// notified about them.
+func (s *service) UpdateReport(ctx context.Context, accountID, id string, req UpdateReportRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	report, res := s.findScopedReport(ctx, accountID, id)
+	if res != nil {
+		return *res
+	}
+	if report.Status != StatusDraft {
+		return pkg.NewResponse(http.StatusBadRequest, "Laporan yang sudah diterbitkan tidak dapat diubah", nil, nil)
+	}
+
+	errs := map[string]string{}
+	updateData := map[string]interface{}{}
+	if req.SchoolName != "" {
+		updateData["school_name"] = strings.TrimSpace(req.SchoolName)
+	}
+	if req.EducationLevel != 0 {
+		if req.EducationLevel < 0 {
+			errs["educationLevel"] = "Jenjang pendidikan tidak valid"
+		}
+		updateData["education_level"] = req.EducationLevel
+	}
+	attendance := []struct {
+		field  string
+		column string
+		value  *int
+	}{
+		{"attendancePresent", "attendance_present", req.AttendancePresent},
+		{"attendanceSick", "attendance_sick", req.AttendanceSick},
+		{"attendancePermit", "attendance_permit", req.AttendancePermit},
+		{"attendanceAbsent", "attendance_absent", req.AttendanceAbsent},
+	}
+	for _, a := range attendance {
+		if a.value == nil {
+			continue
+		}
+		validateAttendance(errs, a.field, *a.value)
+		updateData[a.column] = *a.value
+	}
+	if req.HealthNotes != "" {
+		updateData["health_notes"] = strings.TrimSpace(req.HealthNotes)
+	}
+	if req.Narrative != "" {
+		updateData["narrative"] = strings.TrimSpace(req.Narrative)
+	}
+
+	replaceGrades := len(req.GradeSubjects) > 0
+	var grades []ReportGrade
+	if replaceGrades {
+		grades = buildGrades(errs, report.ID, req.GradeSubjects, req.GradeScores, req.GradeNotes)
+	}
+
+	var removedPhotos []ReportPhoto
+	for _, photoID := range req.DeletePhotoIDs {
+		found := false
+		for _, p := range report.Photos {
+			if p.ID.String() == photoID {
+				removedPhotos = append(removedPhotos, p)
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs["deletePhotoIds"] = "Foto tidak ditemukan pada laporan ini"
+			break
+		}
+	}
+	if len(errs) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errs, nil)
+	}
+
+	newPhotos, err := s.uploadPhotos(ctx, report.ID, req.Photos, req.PhotoCaptions)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children_report.service",
+			"report_id": id,
+		}).WithError(err).Error("failed to upload report photos")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah foto laporan", nil, nil)
+	}
+
+	if err := s.repo.UpdateReport(ctx, id, updateData, grades, replaceGrades, newPhotos, req.DeletePhotoIDs); err != nil {
+		s.deletePhotoFiles(ctx, newPhotos)
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children_report.service",
+			"report_id": id,
+		}).WithError(err).Error("failed to update report")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memperbarui laporan perkembangan", nil, nil)
+	}
+	s.deletePhotoFiles(ctx, removedPhotos)
+
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "foster_children_report", id, report.toReportListItemResponse(), updateData)
+
+	updated, res := s.findReport(ctx, map[string]interface{}{"id": id})
+	if res != nil {
+		return *res
+	}
+	return pkg.NewResponse(http.StatusOK, "Laporan perkembangan berhasil diperbarui", nil, updated.toReportResponse(ctx, s.privateStorage))
+}
+
+// PublishReport makes the report visible to the child's active sponsors and emails each of them.
+func (s *service) PublishReport(ctx context.Context, accountID, id string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	report, res := s.findScopedReport(ctx, accountID, id)
+	if res != nil {
+		return *res
+	}
+	if report.Status != StatusDraft {
+		return pkg.NewResponse(http.StatusConflict, "Laporan sudah diterbitkan", nil, nil)
+	}
+	if strings.TrimSpace(report.Narrative) == "" {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"narrative": "Catatan perkembangan wajib diisi sebelum diterbitkan"}, nil)
+	}
+
+	updateData := map[string]interface{}{
+		"status":       StatusPublished,
+		"published_by": uuid.MustParse(accountID),
+		"published_at": time.Now(),
+	}
+	if err := s.repo.PublishReport(ctx, id, updateData); err != nil {
+		if errors.Is(err, ErrAlreadyPublished) {
+			return pkg.NewResponse(http.StatusConflict, "Laporan sudah diterbitkan", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children_report.service",
+			"report_id": id,
+		}).WithError(err).Error("failed to publish report")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menerbitkan laporan perkembangan", nil, nil)
+	}
+
+	s.notifySponsors(ctx, report)
+
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "foster_children_report", id, map[string]interface{}{"status": report.Status}, updateData)
+	return pkg.NewResponse(http.StatusOK, "Laporan perkembangan berhasil diterbitkan", nil, nil)
+}
+
+// notifySponsors emails the active sponsors in the background. The report is marked as
+// notified once the emails have been handed off, so a failed lookup can be spotted by admins.
+func (s *service) notifySponsors(ctx context.Context, report *FosterChildrenReport) {
+	sponsors, err := s.sponsorshipRepo.FindActiveSponsors(ctx, report.FosterChildrenID.String())
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children_report.service",
+			"report_id": report.ID.String(),
+		}).WithError(err).Error("failed to fetch sponsors for report notification")
+		return
+	}
+
+	childName := ""
+	if report.FosterChildren != nil {
+		childName = report.FosterChildren.Name
+	}
+	for _, sponsorship := range sponsors {
+		if sponsorship.Account == nil || sponsorship.Account.Email == "" {
+			continue
+		}
+		go func(email, sponsorName string) {
+			if err := s.emailService.SendFosterChildrenReportPublishedEmail(email, sponsorName, childName, report.Term(), report.ID.String()); err != nil {
+				logrus.WithFields(logrus.Fields{
+					"component": "foster_children_report.service",
+					"email":     email,
+					"report_id": report.ID.String(),
+				}).WithError(err).Error("failed to send report published email asynchronously")
+			}
+		}(sponsorship.Account.Email, sponsorship.Account.UserProfile.Username)
+	}
+
+	if err := s.repo.MarkSponsorsNotified(ctx, report.ID.String()); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children_report.service",
+			"report_id": report.ID.String(),
+		}).WithError(err).Error("failed to mark report sponsors notified")
+	}
+}
+
+func (s *service) DeleteReport(ctx context.Context, accountID, id string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	report, res := s.findScopedReport(ctx, accountID, id)
+	if res != nil {
+		return *res
+	}
+	if report.Status != StatusDraft {
+		return pkg.NewResponse(http.StatusBadRequest, "Laporan yang sudah diterbitkan tidak dapat dihapus", nil, nil)
+	}
+
+	if err := s.repo.DeleteReport(ctx, id); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children_report.service",
+			"report_id": id,
+		}).WithError(err).Error("failed to delete report")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menghapus laporan perkembangan", nil, nil)
+	}
+	s.deletePhotoFiles(ctx, report.Photos)
+
+	s.logService.CreateLog(ctx, &accountID, "DELETE", "foster_children_report", id, report.toReportListItemResponse(), nil)
+	return pkg.NewResponse(http.StatusOK, "Laporan perkembangan berhasil dihapus", nil, nil)
+}
+
+// sponsoredChildrenIDs returns the children the sponsor may read reports for.
+func (s *service) sponsoredChildrenIDs(ctx context.Context, accountID string) ([]string, *pkg.Response) {
+	ids, err := s.sponsorshipRepo.FindActiveFosterChildrenIDs(ctx, accountID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "foster_children_report.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to fetch sponsored children")
+		res := pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil laporan perkembangan", nil, nil)
+		return nil, &res
+	}
+	return ids, nil
+}
+
+func (s *service) GetMyReportList(ctx context.Context, accountID string, params ReportQueryParams) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	ids, res := s.sponsoredChildrenIDs(ctx, accountID)
+	if res != nil {
+		return *res
+	}
+	if len(ids) == 0 {
+		if params.Limit <= 0 {
+			params.Limit = 10
+		}
+		if params.Page <= 0 {
+			params.Page = 1
+		}
+		return pkg.NewResponse(http.StatusOK, "Berhasil", nil, toReportListResponse(nil, pkg.OffsetPagination{Page: params.Page, Limit: params.Limit}))
+	}
+
+	options := map[string]interface{}{
+		"foster_children_ids": ids,
+		"status":              string(StatusPublished),
+	}
+	return s.listReports(ctx, options, params)
+}
+
+// GetMyReportByID answers 404 for drafts and for children the sponsor does not actively sponsor,
+// so the endpoint does not reveal which reports exist.
+func (s *service) GetMyReportByID(ctx context.Context, accountID, id string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	ids, res := s.sponsoredChildrenIDs(ctx, accountID)
+	if res != nil {
+		return *res
+	}
+	if len(ids) == 0 {
+		return pkg.NewResponse(http.StatusNotFound, "Laporan perkembangan tidak ditemukan", nil, nil)
+	}
+
+	report, res := s.findReport(ctx, map[string]interface{}{
+		"id":                  id,
+		"foster_children_ids": ids,
+		"status":              string(StatusPublished),
+	})
+	if res != nil {
+		return *res
+	}
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, report.toReportResponse(ctx, s.privateStorage))
+}
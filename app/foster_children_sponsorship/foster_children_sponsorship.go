@@ -0,0 +1,61 @@
+package foster_children_sponsorship
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/account"
+	"github.com/Vilamuzz/yota-backend/app/foster_children"
+	"github.com/google/uuid"
+)
+
+// FosterChildrenSponsorship is the standing commitment of an Orang Tua Asuh account to a foster
+// child. Donations are still recorded as FosterChildrenTransaction; the sponsorship only ties the
+// sponsor to the child.
+type FosterChildrenSponsorship struct {
+	ID                uuid.UUID  `json:"id" gorm:"primaryKey"`
+	FosterChildrenID  uuid.UUID  `json:"fosterChildrenId" gorm:"not null;index"`
+	AccountID         uuid.UUID  `json:"accountId" gorm:"not null;index"`
+	MonthlyCommitment float64    `json:"monthlyCommitment" gorm:"not null"`
+	Status            Status     `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
+	Note              string     `json:"note"` // message from the sponsor sent with the request
+	RejectionReason   string     `json:"rejectionReason"`
+	EndReason         string     `json:"endReason"`
+	StartDate         *time.Time `json:"startDate"`
+	EndDate           *time.Time `json:"endDate"`
+	ReviewedBy        *uuid.UUID `json:"reviewedBy"`
+	ReviewedAt        *time.Time `json:"reviewedAt"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	UpdatedAt         time.Time  `json:"updatedAt"`
+
+	TotalContribution float64                         `json:"totalContribution" gorm:"->"`
+	FosterChildren    *foster_children.FosterChildren `gorm:"foreignKey:FosterChildrenID;references:ID"`
+	Account           *account.Account                `gorm:"foreignKey:AccountID;references:ID"`
+}
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusActive    Status = "active"
+	StatusRejected  Status = "rejected"
+	StatusEnded     Status = "ended"
+	StatusCancelled Status = "cancelled"
+)
+
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusActive, StatusRejected, StatusEnded, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+// openStatuses are the statuses that hold a sponsor slot or block a second request.
+var openStatuses = []Status{StatusPending, StatusActive}
+
+var (
+	ErrSponsorLimitReached = errors.New("sponsor limit reached")
+	ErrNotPending          = errors.New("sponsorship is not pending")
+	ErrChildNotEnrolled    = errors.New("foster child is no longer enrolled")
+)
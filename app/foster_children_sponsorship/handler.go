@@ -0,0 +1,296 @@
+package foster_children_sponsorship
+
+import (
+	"net/http"
+
+	"github.com/Vilamuzz/yota-backend/app/foster_children_transaction"
+	"github.com/Vilamuzz/yota-backend/app/middleware"
+	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/Vilamuzz/yota-backend/pkg/enum"
+	jwt_pkg "github.com/Vilamuzz/yota-backend/pkg/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	service    Service
+	middleware middleware.AppMiddleware
+}
+
+func NewHandler(r *gin.RouterGroup, s Service, m middleware.AppMiddleware) {
+	h := &handler{
+		service:    s,
+		middleware: m,
+	}
+	h.RegisterRoutes(r)
+}
+
+func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
+	r.POST("/foster-children/:slug/sponsorships", h.middleware.RequireRoles(enum.RoleOrangTuaAsuh), h.RequestSponsorship)
+
+	me := r.Group("/foster-children/sponsorships/me")
+	me.Use(h.middleware.RequireRoles(enum.RoleOrangTuaAsuh))
+	{
+		me.GET("", h.GetMySponsorshipList)
+		me.GET("/:id", h.GetMySponsorshipByID)
+		me.GET("/:id/contributions", h.GetMySponsorshipContributions)
+		me.PATCH("/:id/cancel", h.CancelMySponsorship)
+	}
+
+	admin := r.Group("/admin/foster-children/sponsorships")
+	admin.Use(h.middleware.RequireRoles(enum.RoleSocialManager, enum.RoleFinance))
+	{
+		admin.GET("", h.GetSponsorshipList)
+		admin.GET("/:id", h.GetSponsorshipByID)
+	}
+
+	socialManagerOnly := r.Group("/admin/foster-children/sponsorships")
+	socialManagerOnly.Use(h.middleware.RequireRoles(enum.RoleSocialManager))
+	{
+		socialManagerOnly.PATCH("/:id/approve", h.ApproveSponsorship)
+		socialManagerOnly.PATCH("/:id/reject", h.RejectSponsorship)
+		socialManagerOnly.PATCH("/:id/end", h.EndSponsorship)
+	}
+}
+
+// RequestSponsorship
+//
+// @Summary Request Foster Children Sponsorship
+// @Description Ask to become the sponsor (orang tua asuh) of a foster child with a monthly commitment
+// @Tags Foster Children Sponsorships
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param slug path string true "Foster Children Slug"
+// @Param payload body CreateSponsorshipRequest true "Sponsorship Request"
+// @Success 201 {object} pkg.Response{data=SponsorshipResponse}
+// @Router /api/foster-children/{slug}/sponsorships [post]
+func (h *handler) RequestSponsorship(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req CreateSponsorshipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid request body", nil, nil))
+		return
+	}
+
+	res := h.service.RequestSponsorship(ctx, claims.AccountID, c.Param("slug"), req)
+	c.JSON(res.Status, res)
+}
+
+// GetMySponsorshipList
+//
+// @Summary List My Sponsored Children
+// @Description Retrieve the sponsorships of the authenticated sponsor with each child's progress and total contribution
+// @Tags Foster Children Sponsorships
+// @Security BearerAuth
+// @Produce json
+// @Param status query string false "Filter by status (pending, active, rejected, ended, cancelled)"
+// @Param search query string false "Search by child name"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Success 200 {object} pkg.Response{data=SponsorshipListResponse}
+// @Router /api/foster-children/sponsorships/me [get]
+func (h *handler) GetMySponsorshipList(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var params SponsorshipQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid query parameters", nil, nil))
+		return
+	}
+
+	res := h.service.GetMySponsorshipList(ctx, claims.AccountID, params)
+	c.JSON(res.Status, res)
+}
+
+// GetMySponsorshipByID
+//
+// @Summary Get My Sponsored Child
+// @Description Retrieve one sponsorship of the authenticated sponsor with the child's progress and contribution summary
+// @Tags Foster Children Sponsorships
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Sponsorship ID"
+// @Success 200 {object} pkg.Response{data=SponsoredChildResponse}
+// @Router /api/foster-children/sponsorships/me/{id} [get]
+func (h *handler) GetMySponsorshipByID(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.GetMySponsorshipByID(ctx, claims.AccountID, c.Param("id"))
+	c.JSON(res.Status, res)
+}
+
+// GetMySponsorshipContributions
+//
+// @Summary List My Contributions to a Sponsored Child
+// @Description Retrieve the authenticated sponsor's donation history for the sponsored child
+// @Tags Foster Children Sponsorships
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Sponsorship ID"
+// @Param status query string false "Filter by payment status"
+// @Param limit query int false "Items per page"
+// @Param next_cursor query string false "Cursor for next page"
+// @Param prev_cursor query string false "Cursor for previous page"
+// @Success 200 {object} pkg.Response
+// @Router /api/foster-children/sponsorships/me/{id}/contributions [get]
+func (h *handler) GetMySponsorshipContributions(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var params foster_children_transaction.FosterChildrenTransactionQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid query parameters", nil, nil))
+		return
+	}
+
+	res := h.service.GetMySponsorshipContributions(ctx, claims.AccountID, c.Param("id"), params)
+	c.JSON(res.Status, res)
+}
+
+// CancelMySponsorship
+//
+// @Summary Cancel My Sponsorship
+// @Description Withdraw a pending sponsorship request or end an active sponsorship
+// @Tags Foster Children Sponsorships
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Sponsorship ID"
+// @Param payload body EndSponsorshipRequest false "Reason"
+// @Success 200 {object} pkg.Response
+// @Router /api/foster-children/sponsorships/me/{id}/cancel [patch]
+func (h *handler) CancelMySponsorship(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req EndSponsorshipRequest
+	_ = c.ShouldBindJSON(&req)
+
+	res := h.service.CancelMySponsorship(ctx, claims.AccountID, c.Param("id"), req)
+	c.JSON(res.Status, res)
+}
+
+// GetSponsorshipList
+//
+// @Summary List Foster Children Sponsorships
+// @Description Retrieve sponsorships and sponsorship requests (admin only)
+// @Tags Foster Children Sponsorships
+// @Security BearerAuth
+// @Produce json
+// @Param status query string false "Filter by status (pending, active, rejected, ended, cancelled)"
+// @Param fosterChildrenId query string false "Filter by foster child ID"
+// @Param search query string false "Search by child name or sponsor username"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Success 200 {object} pkg.Response{data=SponsorshipListResponse}
+// @Router /api/admin/foster-children/sponsorships [get]
+func (h *handler) GetSponsorshipList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var params SponsorshipQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid query parameters", nil, nil))
+		return
+	}
+
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.GetSponsorshipList(ctx, claims.AccountID, params)
+	c.JSON(res.Status, res)
+}
+
+// GetSponsorshipByID
+//
+// @Summary Get Foster Children Sponsorship
+// @Description Retrieve a sponsorship by ID (admin only)
+// @Tags Foster Children Sponsorships
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Sponsorship ID"
+// @Success 200 {object} pkg.Response{data=SponsorshipResponse}
+// @Router /api/admin/foster-children/sponsorships/{id} [get]
+func (h *handler) GetSponsorshipByID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.GetSponsorshipByID(ctx, claims.AccountID, c.Param("id"))
+	c.JSON(res.Status, res)
+}
+
+// ApproveSponsorship
+//
+// @Summary Approve Sponsorship Request
+// @Description Approve a pending sponsorship request; fails when the child already has the maximum number of sponsors
+// @Tags Foster Children Sponsorships
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Sponsorship ID"
+// @Param payload body ApproveSponsorshipRequest false "Start date"
+// @Success 200 {object} pkg.Response
+// @Router /api/admin/foster-children/sponsorships/{id}/approve [patch]
+func (h *handler) ApproveSponsorship(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req ApproveSponsorshipRequest
+	_ = c.ShouldBindJSON(&req)
+
+	res := h.service.ApproveSponsorship(ctx, claims.AccountID, c.Param("id"), req)
+	c.JSON(res.Status, res)
+}
+
+// RejectSponsorship
+//
+// @Summary Reject Sponsorship Request
+// @Description Reject a pending sponsorship request with a reason
+// @Tags Foster Children Sponsorships
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Sponsorship ID"
+// @Param payload body RejectSponsorshipRequest true "Rejection reason"
+// @Success 200 {object} pkg.Response
+// @Router /api/admin/foster-children/sponsorships/{id}/reject [patch]
+func (h *handler) RejectSponsorship(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req RejectSponsorshipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid request body", nil, nil))
+		return
+	}
+
+	res := h.service.RejectSponsorship(ctx, claims.AccountID, c.Param("id"), req)
+	c.JSON(res.Status, res)
+}
+
+// EndSponsorship
+//
+// @Summary End Sponsorship
+// @Description End an active sponsorship with a reason
+// @Tags Foster Children Sponsorships
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Sponsorship ID"
+// @Param payload body EndSponsorshipRequest true "Reason"
+// @Success 200 {object} pkg.Response
+// @Router /api/admin/foster-children/sponsorships/{id}/end [patch]
+func (h *handler) EndSponsorship(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req EndSponsorshipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid request body", nil, nil))
+		return
+	}
+
+	res := h.service.EndSponsorship(ctx, claims.AccountID, c.Param("id"), req)
+	c.JSON(res.Status, res)
+}
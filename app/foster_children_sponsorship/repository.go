@@ -0,0 +1,190 @@
+package foster_children_sponsorship
+
+import (
+	"context"
+
+	"github.com/Vilamuzz/yota-backend/app/foster_children"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	FindAllSponsorships(ctx context.Context, options map[string]interface{}) ([]FosterChildrenSponsorship, error)
+	CountSponsorships(ctx context.Context, options map[string]interface{}) (int64, error)
+	FindOneSponsorship(ctx context.Context, options map[string]interface{}) (*FosterChildrenSponsorship, error)
+	CreateSponsorship(ctx context.Context, sponsorship *FosterChildrenSponsorship) error
+	UpdateSponsorship(ctx context.Context, id string, updateData map[string]interface{}) error
+	ApproveSponsorship(ctx context.Context, id, fosterChildrenID string, maxSponsors int, updateData map[string]interface{}) error
+	FindActiveFosterChildrenIDs(ctx context.Context, accountID string) ([]string, error)
+	FindActiveSponsors(ctx context.Context, fosterChildrenID string) ([]FosterChildrenSponsorship, error)
+}
+
+type repository struct {
+	Conn *gorm.DB
+}
+
+func NewRepository(conn *gorm.DB) Repository {
+	return &repository{Conn: conn}
+}
+
+// totalContributionSelect sums the sponsor's settled donations to the sponsored child.
+const totalContributionSelect = `foster_children_sponsorships.*,
+	(SELECT COALESCE(SUM(fct.gross_amount), 0)
+	 FROM foster_children_transactions fct
+	 WHERE fct.account_id = foster_children_sponsorships.account_id
+	   AND fct.foster_children_id = foster_children_sponsorships.foster_children_id
+	   AND fct.transaction_status = 'settlement') as total_contribution`
+
+// preloadFosterChildren loads the sponsored child together with its total expense, which is
+// what sponsors follow as the child's progress.
+func preloadFosterChildren(db *gorm.DB) *gorm.DB {
+	return db.Select(`foster_childrens.*,
+		(SELECT COALESCE(SUM(amount), 0) FROM foster_children_expenses
+		 WHERE foster_children_id = foster_childrens.id) as total_expense`)
+}
+
+func (r *repository) applyFilters(query *gorm.DB, options map[string]interface{}) *gorm.DB {
+	if accountID, ok := options["account_id"]; ok && accountID.(string) != "" {
+		query = query.Where("foster_children_sponsorships.account_id = ?", accountID.(string))
+	}
+	if fosterChildrenID, ok := options["foster_children_id"]; ok && fosterChildrenID.(string) != "" {
+		query = query.Where("foster_children_sponsorships.foster_children_id = ?", fosterChildrenID.(string))
+	}
+	// An empty list comes from a scoped account without grants and matches nothing.
+	if ids, ok := options["foster_children_ids"].([]string); ok {
+		query = query.Where("foster_children_sponsorships.foster_children_id IN ?", ids)
+	}
+	if status, ok := options["status"]; ok && status.(string) != "" {
+		query = query.Where("foster_children_sponsorships.status = ?", status.(string))
+	}
+	if statuses, ok := options["statuses"].([]Status); ok && len(statuses) > 0 {
+		query = query.Where("foster_children_sponsorships.status IN ?", statuses)
+	}
+	if search, ok := options["search"]; ok && search.(string) != "" {
+		searchQuery := "%" + search.(string) + "%"
+		query = query.Joins("JOIN foster_childrens ON foster_childrens.id = foster_children_sponsorships.foster_children_id").
+			Joins("JOIN user_profiles ON user_profiles.account_id = foster_children_sponsorships.account_id").
+			Where("foster_childrens.name ILIKE ? OR user_profiles.username ILIKE ?", searchQuery, searchQuery)
+	}
+	return query
+}
+
+func (r *repository) FindAllSponsorships(ctx context.Context, options map[string]interface{}) ([]FosterChildrenSponsorship, error) {
+	var sponsorships []FosterChildrenSponsorship
+	query := r.Conn.WithContext(ctx).Model(&FosterChildrenSponsorship{}).
+		Select(totalContributionSelect).
+		Preload("Account.UserProfile").
+		Preload("FosterChildren", preloadFosterChildren)
+	query = r.applyFilters(query, options)
+
+	limit := 10
+	if l, ok := options["limit"]; ok && l.(int) > 0 {
+		limit = l.(int)
+	}
+	offset := 0
+	if page, ok := options["page"]; ok && page.(int) > 1 {
+		offset = (page.(int) - 1) * limit
+	}
+
+	if err := query.Order("foster_children_sponsorships.created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&sponsorships).Error; err != nil {
+		return nil, err
+	}
+	return sponsorships, nil
+}
+
+func (r *repository) CountSponsorships(ctx context.Context, options map[string]interface{}) (int64, error) {
+	var total int64
+	query := r.applyFilters(r.Conn.WithContext(ctx).Model(&FosterChildrenSponsorship{}), options)
+	if err := query.Count(&total).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *repository) FindOneSponsorship(ctx context.Context, options map[string]interface{}) (*FosterChildrenSponsorship, error) {
+	var sponsorship FosterChildrenSponsorship
+	query := r.Conn.WithContext(ctx).Model(&FosterChildrenSponsorship{}).
+		Select(totalContributionSelect).
+		Preload("Account.UserProfile").
+		Preload("FosterChildren", preloadFosterChildren)
+
+	if id, ok := options["id"]; ok && id.(string) != "" {
+		query = query.Where("foster_children_sponsorships.id = ?", id.(string))
+	}
+	query = r.applyFilters(query, options)
+
+	if err := query.First(&sponsorship).Error; err != nil {
+		return nil, err
+	}
+	return &sponsorship, nil
+}
+
+func (r *repository) CreateSponsorship(ctx context.Context, sponsorship *FosterChildrenSponsorship) error {
+	return r.Conn.WithContext(ctx).Create(sponsorship).Error
+}
+
+func (r *repository) UpdateSponsorship(ctx context.Context, id string, updateData map[string]interface{}) error {
+	return r.Conn.WithContext(ctx).Model(&FosterChildrenSponsorship{}).Where("id = ?", id).Updates(updateData).Error
+}
+
+// ApproveSponsorship locks the foster child row so two coordinators cannot approve past the
+// sponsor cap concurrently and an approval cannot race a graduation or exit, re-counts the
+// active sponsors and then activates the request.
+func (r *repository) ApproveSponsorship(ctx context.Context, id, fosterChildrenID string, maxSponsors int, updateData map[string]interface{}) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var child foster_children.FosterChildren
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "status").
+			Where("id = ?", fosterChildrenID).
+			First(&child).Error; err != nil {
+			return err
+		}
+		// A status change closes sponsorships under the same row, so re-check it here.
+		if !child.IsEnrolled() {
+			return ErrChildNotEnrolled
+		}
+
+		var active int64
+		if err := tx.Model(&FosterChildrenSponsorship{}).
+			Where("foster_children_id = ? AND status = ?", fosterChildrenID, StatusActive).
+			Count(&active).Error; err != nil {
+			return err
+		}
+		if maxSponsors > 0 && active >= int64(maxSponsors) {
+			return ErrSponsorLimitReached
+		}
+
+		result := tx.Model(&FosterChildrenSponsorship{}).
+			Where("id = ? AND status = ?", id, StatusPending).
+			Updates(updateData)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotPending
+		}
+		return nil
+	})
+}
+
+// FindActiveFosterChildrenIDs returns the children the account currently sponsors.
+func (r *repository) FindActiveFosterChildrenIDs(ctx context.Context, accountID string) ([]string, error) {
+	var ids []string
+	err := r.Conn.WithContext(ctx).Model(&FosterChildrenSponsorship{}).
+		Where("account_id = ? AND status = ?", accountID, StatusActive).
+		Distinct().
+		Pluck("foster_children_id", &ids).Error
+	return ids, err
+}
+
+// FindActiveSponsors returns the active sponsorships of a child with the sponsor accounts loaded.
+func (r *repository) FindActiveSponsors(ctx context.Context, fosterChildrenID string) ([]FosterChildrenSponsorship, error) {
+	var sponsorships []FosterChildrenSponsorship
+	err := r.Conn.WithContext(ctx).
+		Preload("Account.UserProfile").
+		Where("foster_children_id = ? AND status = ?", fosterChildrenID, StatusActive).
+		Find(&sponsorships).Error
+	return sponsorships, err
+}
@@ -0,0 +1,26 @@
+package foster_children_sponsorship
+
+type CreateSponsorshipRequest struct {
+	MonthlyCommitment float64 `json:"monthlyCommitment"`
+	Note              string  `json:"note"`
+}
+
+type ApproveSponsorshipRequest struct {
+	StartDate string `json:"startDate"` // YYYY-MM-DD, defaults to today
+}
+
+type RejectSponsorshipRequest struct {
+	RejectionReason string `json:"rejectionReason"`
+}
+
+type EndSponsorshipRequest struct {
+	Reason string `json:"reason"`
+}
+
+type SponsorshipQueryParams struct {
+	Search           string `form:"search"`
+	Status           string `form:"status"`
+	FosterChildrenID string `form:"fosterChildrenId"`
+	Page             int    `form:"page"`
+	Limit            int    `form:"limit"`
+}
@@ -0,0 +1,115 @@
+package foster_children_sponsorship
+
+import (
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/foster_children"
+	"github.com/Vilamuzz/yota-backend/pkg"
+)
+
+type SponsorshipResponse struct {
+	ID                string                                          `json:"id"`
+	FosterChildren    *foster_children.FosterChildrenListItemResponse `json:"fosterChildren"`
+	SponsorID         string                                          `json:"sponsorId"`
+	SponsorUsername   string                                          `json:"sponsorUsername"`
+	MonthlyCommitment float64                                         `json:"monthlyCommitment"`
+	TotalContribution float64                                         `json:"totalContribution"`
+	Status            Status                                          `json:"status"`
+	Note              string                                          `json:"note"`
+	RejectionReason   string                                          `json:"rejectionReason"`
+	EndReason         string                                          `json:"endReason"`
+	StartDate         string                                          `json:"startDate"`
+	EndDate           string                                          `json:"endDate"`
+	ReviewedAt        *time.Time                                      `json:"reviewedAt"`
+	CreatedAt         time.Time                                       `json:"createdAt"`
+}
+
+// SponsoredChildResponse is the sponsor's view of one sponsorship: the child's progress and the
+// sponsor's own contribution.
+type SponsoredChildResponse struct {
+	SponsorshipResponse
+	FosterChildrenDetail foster_children.FosterChildrenDetailResponse `json:"fosterChildrenDetail"`
+	MonthsSponsored      int                                          `json:"monthsSponsored"`
+	ExpectedContribution float64                                      `json:"expectedContribution"`
+}
+
+type SponsorshipListResponse struct {
+	Sponsorships []SponsorshipResponse `json:"sponsorships"`
+	Pagination   pkg.OffsetPagination  `json:"pagination"`
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func (s *FosterChildrenSponsorship) toSponsorshipResponse() SponsorshipResponse {
+	resp := SponsorshipResponse{
+		ID:                s.ID.String(),
+		SponsorID:         s.AccountID.String(),
+		MonthlyCommitment: s.MonthlyCommitment,
+		TotalContribution: s.TotalContribution,
+		Status:            s.Status,
+		Note:              s.Note,
+		RejectionReason:   s.RejectionReason,
+		EndReason:         s.EndReason,
+		StartDate:         formatDate(s.StartDate),
+		EndDate:           formatDate(s.EndDate),
+		ReviewedAt:        s.ReviewedAt,
+		CreatedAt:         s.CreatedAt,
+	}
+	if s.FosterChildren != nil {
+		child := s.FosterChildren.ToFosterChildrenListItemResponse()
+		resp.FosterChildren = &child
+	}
+	if s.Account != nil {
+		resp.SponsorUsername = s.Account.UserProfile.Username
+	}
+	return resp
+}
+
+// monthsSponsored counts the started months between the start date and the end date, or now for
+// a running sponsorship.
+func (s *FosterChildrenSponsorship) monthsSponsored(now time.Time) int {
+	if s.StartDate == nil || s.StartDate.After(now) {
+		return 0
+	}
+	end := now
+	if s.EndDate != nil && s.EndDate.Before(now) {
+		end = *s.EndDate
+	}
+	months := (end.Year()-s.StartDate.Year())*12 + int(end.Month()-s.StartDate.Month())
+	if end.Day() >= s.StartDate.Day() {
+		months++
+	}
+	if months < 1 {
+		months = 1
+	}
+	return months
+}
+
+func (s *FosterChildrenSponsorship) toSponsoredChildResponse(child *foster_children.FosterChildren) SponsoredChildResponse {
+	months := s.monthsSponsored(time.Now())
+	return SponsoredChildResponse{
+		SponsorshipResponse:  s.toSponsorshipResponse(),
+		FosterChildrenDetail: child.ToFosterChildrenDetailResponse(),
+		MonthsSponsored:      months,
+		ExpectedContribution: float64(months) * s.MonthlyCommitment,
+	}
+}
+
+func toSponsorshipListResponse(sponsorships []FosterChildrenSponsorship, pagination pkg.OffsetPagination) SponsorshipListResponse {
+	var responses []SponsorshipResponse
+	for _, s := range sponsorships {
+		responses = append(responses, s.toSponsorshipResponse())
+	}
+	if responses == nil {
+		responses = []SponsorshipResponse{}
+	}
+	return SponsorshipListResponse{
+		Sponsorships: responses,
+		Pagination:   pagination,
+	}
+}
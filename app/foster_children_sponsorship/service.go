@@ -0,0 +1,452 @@
+package foster_children_sponsorship
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/access_grant"
+	"github.com/Vilamuzz/yota-backend/app/foster_children"
+	"github.com/Vilamuzz/yota-backend/app/foster_children_transaction"
+	app_log "github.com/Vilamuzz/yota-backend/app/log"
+	"github.com/Vilamuzz/yota-backend/pkg"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	RequestSponsorship(ctx context.Context, accountID, fosterChildrenSlug string, req CreateSponsorshipRequest) pkg.Response
+	GetMySponsorshipList(ctx context.Context, accountID string, params SponsorshipQueryParams) pkg.Response
+	GetMySponsorshipByID(ctx context.Context, accountID, id string) pkg.Response
+	GetMySponsorshipContributions(ctx context.Context, accountID, id string, params foster_children_transaction.FosterChildrenTransactionQueryParams) pkg.Response
+	CancelMySponsorship(ctx context.Context, accountID, id string, req EndSponsorshipRequest) pkg.Response
+	GetSponsorshipList(ctx context.Context, accountID string, params SponsorshipQueryParams) pkg.Response
+	GetSponsorshipByID(ctx context.Context, accountID, id string) pkg.Response
+	ApproveSponsorship(ctx context.Context, reviewerID, id string, req ApproveSponsorshipRequest) pkg.Response
+	RejectSponsorship(ctx context.Context, reviewerID, id string, req RejectSponsorshipRequest) pkg.Response
+	EndSponsorship(ctx context.Context, reviewerID, id string, req EndSponsorshipRequest) pkg.Response
+}
+
+type service struct {
+	repo               Repository
+	fosterChildrenRepo foster_children.Repository
+	transactionService foster_children_transaction.Service
+	accessGrantRepo    access_grant.Repository
+	logService         app_log.Service
+	maxSponsors        int
+	timeout            time.Duration
+}
+
+// NewService creates the sponsorship service. maxSponsors caps the active sponsors per child;
+// zero disables the cap.
+func NewService(repo Repository, fosterChildrenRepo foster_children.Repository, transactionService foster_children_transaction.Service, accessGrantRepo access_grant.Repository, logService app_log.Service, maxSponsors int, timeout time.Duration) Service {
+	return &service{
+		repo:               repo,
+		fosterChildrenRepo: fosterChildrenRepo,
+		transactionService: transactionService,
+		accessGrantRepo:    accessGrantRepo,
+		logService:         logService,
+		maxSponsors:        maxSponsors,
+		timeout:            timeout,
+	}
+}
+
+func (s *service) RequestSponsorship(ctx context.Context, accountID, fosterChildrenSlug string, req CreateSponsorshipRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if req.MonthlyCommitment <= 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"monthlyCommitment": "Komitmen bulanan harus lebih dari 0"}, nil)
+	}
+
+	child, err := s.fosterChildrenRepo.FindOneFosterChildren(ctx, map[string]interface{}{"slug": fosterChildrenSlug})
+	if err != nil {
+		return pkg.NewResponse(http.StatusNotFound, "Anak asuh tidak ditemukan", nil, nil)
+	}
+	if !child.IsEnrolled() {
+		return pkg.NewResponse(http.StatusBadRequest, "Anak asuh sudah tidak aktif dan tidak menerima orang tua asuh baru", nil, nil)
+	}
+
+	existing, err := s.repo.CountSponsorships(ctx, map[string]interface{}{
+		"account_id":         accountID,
+		"foster_children_id": child.ID.String(),
+		"statuses":           openStatuses,
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "foster_children_sponsorship.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to check existing sponsorship")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengajukan orang tua asuh", nil, nil)
+	}
+	if existing > 0 {
+		return pkg.NewResponse(http.StatusConflict, "Anda sudah mengajukan atau menjadi orang tua asuh anak ini", nil, nil)
+	}
+
+	if s.maxSponsors > 0 {
+		active, err := s.repo.CountSponsorships(ctx, map[string]interface{}{
+			"foster_children_id": child.ID.String(),
+			"status":             string(StatusActive),
+		})
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":          "foster_children_sponsorship.service",
+				"foster_children_id": child.ID.String(),
+			}).WithError(err).Error("failed to count active sponsors")
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengajukan orang tua asuh", nil, nil)
+		}
+		if active >= int64(s.maxSponsors) {
+			return pkg.NewResponse(http.StatusConflict, "Kuota orang tua asuh untuk anak ini sudah penuh", nil, nil)
+		}
+	}
+
+	sponsorship := &FosterChildrenSponsorship{
+		ID:                uuid.New(),
+		FosterChildrenID:  child.ID,
+		AccountID:         uuid.MustParse(accountID),
+		MonthlyCommitment: req.MonthlyCommitment,
+		Status:            StatusPending,
+		Note:              req.Note,
+	}
+	if err := s.repo.CreateSponsorship(ctx, sponsorship); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "foster_children_sponsorship.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to create sponsorship")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengajukan orang tua asuh", nil, nil)
+	}
+	sponsorship.FosterChildren = child
+
+	s.logService.CreateLog(ctx, &accountID, "CREATE", "foster_children_sponsorship", sponsorship.ID.String(), nil, sponsorship.toSponsorshipResponse())
+	return pkg.NewResponse(http.StatusCreated, "Pengajuan orang tua asuh berhasil dikirim", nil, sponsorship.toSponsorshipResponse())
+}
+
+func (s *service) GetMySponsorshipList(ctx context.Context, accountID string, params SponsorshipQueryParams) pkg.Response {
+	params.FosterChildrenID = ""
+	return s.getSponsorshipList(ctx, map[string]interface{}{"account_id": accountID}, params)
+}
+
+func (s *service) GetSponsorshipList(ctx context.Context, accountID string, params SponsorshipQueryParams) pkg.Response {
+	if params.FosterChildrenID != "" {
+		if err := uuid.Validate(params.FosterChildrenID); err != nil {
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"fosterChildrenId": "Format ID anak asuh tidak valid"}, nil)
+		}
+	}
+	options := map[string]interface{}{}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "foster_children_sponsorship.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data orang tua asuh", nil, nil)
+	}
+	return s.getSponsorshipList(ctx, options, params)
+}
+
+// applyAccessScope narrows options to the sponsorships of the foster children granted to the
+// account when it is scoped. A scoped account without grants gets an empty list and sees nothing.
+func (s *service) applyAccessScope(ctx context.Context, accountID string, options map[string]interface{}) error {
+	ids, scoped, err := s.accessGrantRepo.FindAccessScope(ctx, accountID, access_grant.ResourceFosterChildren)
+	if err != nil {
+		return err
+	}
+	if scoped {
+		options["foster_children_ids"] = ids
+	}
+	return nil
+}
+
+func (s *service) getSponsorshipList(ctx context.Context, options map[string]interface{}, params SponsorshipQueryParams) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if params.Status != "" && !Status(params.Status).IsValid() {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"status": "Status tidak valid"}, nil)
+	}
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+
+	options["limit"] = params.Limit
+	options["page"] = params.Page
+	if params.Status != "" {
+		options["status"] = params.Status
+	}
+	if params.FosterChildrenID != "" {
+		options["foster_children_id"] = params.FosterChildrenID
+	}
+	if params.Search != "" {
+		options["search"] = params.Search
+	}
+
+	total, err := s.repo.CountSponsorships(ctx, options)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children_sponsorship.service",
+			"options":   options,
+		}).WithError(err).Error("failed to count sponsorships")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data orang tua asuh", nil, nil)
+	}
+
+	sponsorships, err := s.repo.FindAllSponsorships(ctx, options)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children_sponsorship.service",
+			"options":   options,
+		}).WithError(err).Error("failed to fetch sponsorships")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data orang tua asuh", nil, nil)
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit != 0 {
+		totalPages++
+	}
+
+	pagination := pkg.OffsetPagination{
+		Page:       params.Page,
+		Limit:      params.Limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, toSponsorshipListResponse(sponsorships, pagination))
+}
+
+func (s *service) findSponsorship(ctx context.Context, options map[string]interface{}) (*FosterChildrenSponsorship, *pkg.Response) {
+	if id, _ := options["id"].(string); uuid.Validate(id) != nil {
+		res := pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID orang tua asuh tidak valid"}, nil)
+		return nil, &res
+	}
+	sponsorship, err := s.repo.FindOneSponsorship(ctx, options)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := pkg.NewResponse(http.StatusNotFound, "Data orang tua asuh tidak ditemukan", nil, nil)
+			return nil, &res
+		}
+		logrus.WithFields(logrus.Fields{
+			"component": "foster_children_sponsorship.service",
+			"options":   options,
+		}).WithError(err).Error("failed to fetch sponsorship")
+		res := pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data orang tua asuh", nil, nil)
+		return nil, &res
+	}
+	return sponsorship, nil
+}
+
+// findScopedSponsorship loads a sponsorship for the admin routes; sponsorships of children outside
+// the account's access scope look missing.
+func (s *service) findScopedSponsorship(ctx context.Context, accountID, id string) (*FosterChildrenSponsorship, *pkg.Response) {
+	options := map[string]interface{}{"id": id}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "foster_children_sponsorship.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		res := pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data orang tua asuh", nil, nil)
+		return nil, &res
+	}
+	return s.findSponsorship(ctx, options)
+}
+
+func (s *service) GetMySponsorshipByID(ctx context.Context, accountID, id string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	sponsorship, res := s.findSponsorship(ctx, map[string]interface{}{"id": id, "account_id": accountID})
+	if res != nil {
+		return *res
+	}
+
+	// Reload the child with its achievements and expense total for the progress view.
+	child, err := s.fosterChildrenRepo.FindOneFosterChildren(ctx, map[string]interface{}{"id": sponsorship.FosterChildrenID.String()})
+	if err != nil {
+		return pkg.NewResponse(http.StatusNotFound, "Anak asuh tidak ditemukan", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, sponsorship.toSponsoredChildResponse(child))
+}
+
+func (s *service) GetMySponsorshipContributions(ctx context.Context, accountID, id string, params foster_children_transaction.FosterChildrenTransactionQueryParams) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	sponsorship, res := s.findSponsorship(ctx, map[string]interface{}{"id": id, "account_id": accountID})
+	if res != nil {
+		return *res
+	}
+	return s.transactionService.GetFosterChildrenTransactionList(ctx, accountID, sponsorship.FosterChildrenID.String(), params)
+}
+
+// CancelMySponsorship withdraws a pending request or ends a running sponsorship on the sponsor's
+// behalf.
+func (s *service) CancelMySponsorship(ctx context.Context, accountID, id string, req EndSponsorshipRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	sponsorship, res := s.findSponsorship(ctx, map[string]interface{}{"id": id, "account_id": accountID})
+	if res != nil {
+		return *res
+	}
+
+	now := time.Now()
+	var updateData map[string]interface{}
+	var message string
+	switch sponsorship.Status {
+	case StatusPending:
+		updateData = map[string]interface{}{"status": StatusCancelled}
+		message = "Pengajuan orang tua asuh berhasil dibatalkan"
+	case StatusActive:
+		updateData = map[string]interface{}{"status": StatusEnded, "end_date": now, "end_reason": req.Reason}
+		message = "Status orang tua asuh berhasil diakhiri"
+	default:
+		return pkg.NewResponse(http.StatusBadRequest, "Status orang tua asuh tidak dapat diubah", nil, nil)
+	}
+
+	if err := s.repo.UpdateSponsorship(ctx, id, updateData); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":      "foster_children_sponsorship.service",
+			"sponsorship_id": id,
+		}).WithError(err).Error("failed to cancel sponsorship")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memperbarui data orang tua asuh", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "foster_children_sponsorship", id, sponsorship.toSponsorshipResponse(), updateData)
+	return pkg.NewResponse(http.StatusOK, message, nil, nil)
+}
+
+func (s *service) GetSponsorshipByID(ctx context.Context, accountID, id string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	sponsorship, res := s.findScopedSponsorship(ctx, accountID, id)
+	if res != nil {
+		return *res
+	}
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, sponsorship.toSponsorshipResponse())
+}
+
+func (s *service) ApproveSponsorship(ctx context.Context, reviewerID, id string, req ApproveSponsorshipRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	sponsorship, res := s.findScopedSponsorship(ctx, reviewerID, id)
+	if res != nil {
+		return *res
+	}
+	if sponsorship.Status != StatusPending {
+		return pkg.NewResponse(http.StatusBadRequest, "Hanya pengajuan yang menunggu yang dapat disetujui", nil, nil)
+	}
+	if sponsorship.FosterChildren != nil && !sponsorship.FosterChildren.IsEnrolled() {
+		return pkg.NewResponse(http.StatusBadRequest, "Anak asuh sudah tidak aktif dan tidak menerima orang tua asuh baru", nil, nil)
+	}
+
+	now := time.Now()
+	startDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if req.StartDate != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", req.StartDate, now.Location())
+		if err != nil {
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"startDate": "Format tanggal tidak valid, diharapkan YYYY-MM-DD"}, nil)
+		}
+		startDate = parsed
+	}
+
+	reviewer := uuid.MustParse(reviewerID)
+	updateData := map[string]interface{}{
+		"status":      StatusActive,
+		"start_date":  startDate,
+		"reviewed_by": reviewer,
+		"reviewed_at": now,
+	}
+	if err := s.repo.ApproveSponsorship(ctx, id, sponsorship.FosterChildrenID.String(), s.maxSponsors, updateData); err != nil {
+		switch {
+		case errors.Is(err, ErrSponsorLimitReached):
+			return pkg.NewResponse(http.StatusConflict, "Kuota orang tua asuh untuk anak ini sudah penuh", nil, nil)
+		case errors.Is(err, ErrNotPending):
+			return pkg.NewResponse(http.StatusConflict, "Pengajuan sudah diproses", nil, nil)
+		case errors.Is(err, ErrChildNotEnrolled):
+			return pkg.NewResponse(http.StatusConflict, "Anak asuh sudah tidak aktif dan tidak menerima orang tua asuh baru", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":      "foster_children_sponsorship.service",
+			"sponsorship_id": id,
+		}).WithError(err).Error("failed to approve sponsorship")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menyetujui pengajuan orang tua asuh", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &reviewerID, "UPDATE", "foster_children_sponsorship", id, sponsorship.toSponsorshipResponse(), updateData)
+	return pkg.NewResponse(http.StatusOK, "Pengajuan orang tua asuh berhasil disetujui", nil, nil)
+}
+
+func (s *service) RejectSponsorship(ctx context.Context, reviewerID, id string, req RejectSponsorshipRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if req.RejectionReason == "" {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"rejectionReason": "Alasan penolakan wajib diisi"}, nil)
+	}
+
+	sponsorship, res := s.findScopedSponsorship(ctx, reviewerID, id)
+	if res != nil {
+		return *res
+	}
+	if sponsorship.Status != StatusPending {
+		return pkg.NewResponse(http.StatusBadRequest, "Hanya pengajuan yang menunggu yang dapat ditolak", nil, nil)
+	}
+
+	updateData := map[string]interface{}{
+		"status":           StatusRejected,
+		"rejection_reason": req.RejectionReason,
+		"reviewed_by":      uuid.MustParse(reviewerID),
+		"reviewed_at":      time.Now(),
+	}
+	if err := s.repo.UpdateSponsorship(ctx, id, updateData); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":      "foster_children_sponsorship.service",
+			"sponsorship_id": id,
+		}).WithError(err).Error("failed to reject sponsorship")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menolak pengajuan orang tua asuh", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &reviewerID, "UPDATE", "foster_children_sponsorship", id, sponsorship.toSponsorshipResponse(), updateData)
+	return pkg.NewResponse(http.StatusOK, "Pengajuan orang tua asuh berhasil ditolak", nil, nil)
+}
+
+func (s *service) EndSponsorship(ctx context.Context, reviewerID, id string, req EndSponsorshipRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if req.Reason == "" {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"reason": "Alasan wajib diisi"}, nil)
+	}
+
+	sponsorship, res := s.findScopedSponsorship(ctx, reviewerID, id)
+	if res != nil {
+		return *res
+	}
+	if sponsorship.Status != StatusActive {
+		return pkg.NewResponse(http.StatusBadRequest, "Hanya orang tua asuh aktif yang dapat diakhiri", nil, nil)
+	}
+
+	updateData := map[string]interface{}{
+		"status":     StatusEnded,
+		"end_date":   time.Now(),
+		"end_reason": req.Reason,
+	}
+	if err := s.repo.UpdateSponsorship(ctx, id, updateData); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":      "foster_children_sponsorship.service",
+			"sponsorship_id": id,
+		}).WithError(err).Error("failed to end sponsorship")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memperbarui data orang tua asuh", nil, nil)
+	}
+
+	s.logService.CreateLog(ctx, &reviewerID, "UPDATE", "foster_children_sponsorship", id, sponsorship.toSponsorshipResponse(), updateData)
+	return pkg.NewResponse(http.StatusOK, "Status orang tua asuh berhasil diakhiri", nil, nil)
+}
@@ -27,6 +27,9 @@ type FosterChildrenTransaction struct {
 	CreatedAt         time.Time  `json:"createdAt"`
 	UpdatedAt         time.Time  `json:"updatedAt"`
 
+	// NeedID earmarks the donation to a wishlist item of the child, if any.
+	NeedID *uuid.UUID `json:"needId" gorm:"index"`
+
 	Account        *account.Account                `gorm:"foreignKey:AccountID;references:ID"`
 	FosterChildren *foster_children.FosterChildren `json:"-" gorm:"foreignKey:FosterChildrenID;references:ID"`
 }
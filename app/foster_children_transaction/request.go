@@ -6,6 +6,7 @@ type CreateFosterChildrenTransactionRequest struct {
 	DonorName   string  `json:"donorName"`
 	DonorEmail  string  `json:"donorEmail"`
 	GrossAmount float64 `json:"grossAmount"`
+	NeedID      string  `json:"needId"` // optional, earmarks the donation to an open wishlist item
 }
 
 type FosterChildrenTransactionQueryParams struct {
@@ -10,6 +10,7 @@ type FosterChildrenTransactionResponse struct {
 	ID                 string     `json:"id"`
 	FosterChildrenName string     `json:"fosterChildrenName"`
 	OrderID            string     `json:"orderId"`
+	NeedID             *string    `json:"needId"`
 	DonorName          string     `json:"donorName"`
 	DonorEmail         string     `json:"donorEmail"`
 	IsOnline           bool       `json:"isOnline"`
@@ -27,10 +28,16 @@ type FosterChildrenTransactionListResponse struct {
 }
 
 func (tx *FosterChildrenTransaction) toFosterChildrenTransactionResponse() FosterChildrenTransactionResponse {
+	var needID *string
+	if tx.NeedID != nil {
+		id := tx.NeedID.String()
+		needID = &id
+	}
 	return FosterChildrenTransactionResponse{
 		ID:                 tx.ID.String(),
 		FosterChildrenName: tx.FosterChildren.Name,
 		OrderID:            tx.OrderID,
+		NeedID:             needID,
 		DonorName:          tx.DonorName,
 		DonorEmail:         tx.DonorEmail,
 		IsOnline:           tx.IsOnline,
@@ -10,6 +10,7 @@ import (
 	"github.com/Vilamuzz/yota-backend/app/account"
 	"github.com/Vilamuzz/yota-backend/app/finance_record"
 	"github.com/Vilamuzz/yota-backend/app/foster_children"
+	"github.com/Vilamuzz/yota-backend/app/foster_children_need"
 	app_log "github.com/Vilamuzz/yota-backend/app/log"
 	"github.com/Vilamuzz/yota-backend/pkg"
 	payment_pkg "github.com/Vilamuzz/yota-backend/pkg/payment"
@@ -34,17 +35,19 @@ type service struct {
 	repo               Repository
 	accountRepo        account.Repository
 	fosterChildrenRepo foster_children.Repository
+	needRepo           foster_children_need.Repository
 	financeRepo        finance_record.Repository
 	midtransClient     payment_pkg.Client
 	logService         app_log.Service
 	timeout            time.Duration
 }
 
-func NewService(repo Repository, accountRepo account.Repository, fosterChildrenRepo foster_children.Repository, financeRepo finance_record.Repository, midtransClient payment_pkg.Client, logService app_log.Service, timeout time.Duration) Service {
+func NewService(repo Repository, accountRepo account.Repository, fosterChildrenRepo foster_children.Repository, needRepo foster_children_need.Repository, financeRepo finance_record.Repository, midtransClient payment_pkg.Client, logService app_log.Service, timeout time.Duration) Service {
 	return &service{
 		repo:               repo,
 		accountRepo:        accountRepo,
 		fosterChildrenRepo: fosterChildrenRepo,
+		needRepo:           needRepo,
 		financeRepo:        financeRepo,
 		midtransClient:     midtransClient,
 		logService:         logService,
@@ -165,11 +168,18 @@ func (s *service) CreateOfflineFosterChildrenTransaction(ctx context.Context, ac
 			errValidation["foster_children_id"] = "Anak Asuh tidak ditemukan"
 		} else if fc.IsGraduated {
 			errValidation["foster_children_id"] = "Anak Asuh sudah lulus"
+		} else if !fc.IsEnrolled() {
+			errValidation["foster_children_id"] = "Anak Asuh sudah tidak aktif dan tidak menerima donasi"
 		} else {
 			fosterChild = fc
 		}
 	}
 
+	var needID *uuid.UUID
+	if fosterChild != nil {
+		needID = s.earmarkNeed(ctx, payload.NeedID, fosterChild.ID, errValidation)
+	}
+
 	if payload.GrossAmount <= 0 {
 		errValidation["gross_amount"] = "Jumlah kotor harus lebih besar dari 0"
 	}
@@ -191,6 +201,7 @@ func (s *service) CreateOfflineFosterChildrenTransaction(ctx context.Context, ac
 	transaction := &FosterChildrenTransaction{
 		ID:                uuid.New(),
 		FosterChildrenID:  uuid.MustParse(fosterChildrenID),
+		NeedID:            needID,
 		OrderID:           fmt.Sprintf("OFF-%s", uuid.New().String()),
 		DonorName:         donorName,
 		DonorEmail:        donorEmail,
@@ -249,6 +260,8 @@ func (s *service) CreateFosterChildrenTransaction(ctx context.Context, accountID
 			errValidation["foster_children_slug"] = "Anak Asuh tidak ditemukan"
 		} else if fc.IsGraduated {
 			errValidation["foster_children_slug"] = "Anak Asuh sudah lulus"
+		} else if !fc.IsEnrolled() {
+			errValidation["foster_children_slug"] = "Anak Asuh sudah tidak aktif dan tidak menerima donasi"
 		} else {
 			fosterChild = fc
 		}
@@ -261,6 +274,11 @@ func (s *service) CreateFosterChildrenTransaction(ctx context.Context, accountID
 		}
 	}
 
+	var needID *uuid.UUID
+	if fosterChild != nil {
+		needID = s.earmarkNeed(ctx, payload.NeedID, fosterChild.ID, errValidation)
+	}
+
 	if payload.GrossAmount <= 0 {
 		errValidation["gross_amount"] = "Jumlah kotor harus lebih besar dari 0"
 	}
@@ -280,6 +298,10 @@ func (s *service) CreateFosterChildrenTransaction(ctx context.Context, accountID
 
 	orderID := fmt.Sprintf("FC-%s", uuid.New().String())
 	grossAmountInt := int64(payload.GrossAmount)
+	itemName := "Donasi Anak Asuh"
+	if needID != nil {
+		itemName = "Donasi Kebutuhan Anak Asuh"
+	}
 
 	snapReq := &snap.Request{
 		TransactionDetails: midtrans.TransactionDetails{
@@ -295,7 +317,7 @@ func (s *service) CreateFosterChildrenTransaction(ctx context.Context, accountID
 				ID:    fosterChild.ID.String(),
 				Price: grossAmountInt,
 				Qty:   1,
-				Name:  "Donasi Anak Asuh",
+				Name:  itemName,
 			},
 		},
 	}
@@ -316,6 +338,7 @@ func (s *service) CreateFosterChildrenTransaction(ctx context.Context, accountID
 		ID:                uuid.New(),
 		FosterChildrenID:  fosterChild.ID,
 		AccountID:         accountIDPtr,
+		NeedID:            needID,
 		OrderID:           orderID,
 		DonorName:         donorName,
 		DonorEmail:        donorEmail,
@@ -343,6 +366,29 @@ func (s *service) CreateFosterChildrenTransaction(ctx context.Context, accountID
 	return pkg.NewResponse(http.StatusCreated, "Transaksi berhasil dibuat", nil, transaction.toFosterChildrenTransactionResponse())
 }
 
+// earmarkNeed resolves the wishlist item a donation is earmarked to. The need must belong to the
+// child and still accept donations. Money given beyond the target is not refused at payment
+// time; it stays in the child's general fund like any other donation.
+func (s *service) earmarkNeed(ctx context.Context, needID string, fosterChildrenID uuid.UUID, errValidation map[string]string) *uuid.UUID {
+	if needID == "" {
+		return nil
+	}
+	if uuid.Validate(needID) != nil {
+		errValidation["need_id"] = "Format ID kebutuhan tidak valid"
+		return nil
+	}
+	need, err := s.needRepo.FindOneNeed(ctx, map[string]interface{}{"id": needID, "foster_children_id": fosterChildrenID.String()})
+	if err != nil {
+		errValidation["need_id"] = "Kebutuhan tidak ditemukan pada anak asuh ini"
+		return nil
+	}
+	if !need.AcceptsDonations() {
+		errValidation["need_id"] = "Kebutuhan sudah terpenuhi atau tidak lagi menerima donasi"
+		return nil
+	}
+	return &need.ID
+}
+
 func (s *service) HandleNotification(ctx context.Context, payload payment_pkg.MidtransNotificationRequest) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
@@ -3,6 +3,7 @@ package foundation_profile
 import (
 	"time"
 
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
 	"github.com/google/uuid"
 )
 
@@ -27,4 +28,9 @@ type FoundationProfile struct {
 	HeroImageFour         string    `json:"heroImageFour"`
 	CreatedAt             time.Time `json:"createdAt"`
 	UpdatedAt             time.Time `json:"updatedAt"`
+
+	HeroImageOneVariants   s3_pkg.ImageVariants `json:"heroImageOneVariants" gorm:"type:jsonb"`
+	HeroImageTwoVariants   s3_pkg.ImageVariants `json:"heroImageTwoVariants" gorm:"type:jsonb"`
+	HeroImageThreeVariants s3_pkg.ImageVariants `json:"heroImageThreeVariants" gorm:"type:jsonb"`
+	HeroImageFourVariants  s3_pkg.ImageVariants `json:"heroImageFourVariants" gorm:"type:jsonb"`
 }
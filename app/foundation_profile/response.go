@@ -27,6 +27,11 @@ type FoundationProfileResponse struct {
 	HeroImageFour         string    `json:"heroImageFour"`
 	CreatedAt             time.Time `json:"createdAt"`
 	UpdatedAt             time.Time `json:"updatedAt"`
+
+	HeroImageOneSet   *s3_pkg.ImageSet `json:"heroImageOneSet,omitempty"`
+	HeroImageTwoSet   *s3_pkg.ImageSet `json:"heroImageTwoSet,omitempty"`
+	HeroImageThreeSet *s3_pkg.ImageSet `json:"heroImageThreeSet,omitempty"`
+	HeroImageFourSet  *s3_pkg.ImageSet `json:"heroImageFourSet,omitempty"`
 }
 
 func (f *FoundationProfile) toFoundationProfileResponse() FoundationProfileResponse {
@@ -51,5 +56,10 @@ func (f *FoundationProfile) toFoundationProfileResponse() FoundationProfileRespo
 		HeroImageFour:         s3_pkg.GetCDNURL(f.HeroImageFour),
 		CreatedAt:             f.CreatedAt,
 		UpdatedAt:             f.UpdatedAt,
+
+		HeroImageOneSet:   s3_pkg.NewImageSet(f.HeroImageOneVariants),
+		HeroImageTwoSet:   s3_pkg.NewImageSet(f.HeroImageTwoVariants),
+		HeroImageThreeSet: s3_pkg.NewImageSet(f.HeroImageThreeVariants),
+		HeroImageFourSet:  s3_pkg.NewImageSet(f.HeroImageFourVariants),
 	}
 }
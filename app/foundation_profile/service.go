@@ -58,6 +58,12 @@ func (e uploadError) Error() string {
 	return e.msg
 }
 
+// storedImage is a replaced image whose renditions are removed after the update is saved.
+type storedImage struct {
+	path     string
+	variants s3_pkg.ImageVariants
+}
+
 func (s *service) CreateFoundationProfile(ctx context.Context, payload FoundationProfileCreateRequest) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
@@ -107,6 +113,11 @@ func (s *service) CreateFoundationProfile(ctx context.Context, payload Foundatio
 		heroImageTwoURL          string
 		heroImageThreeURL        string
 		heroImageFourURL         string
+
+		heroImageOneVariants   s3_pkg.ImageVariants
+		heroImageTwoVariants   s3_pkg.ImageVariants
+		heroImageThreeVariants s3_pkg.ImageVariants
+		heroImageFourVariants  s3_pkg.ImageVariants
 	)
 
 	g, gCtx := errgroup.WithContext(ctx)
@@ -161,48 +172,52 @@ func (s *service) CreateFoundationProfile(ctx context.Context, payload Foundatio
 
 	if payload.HeroImageOne != nil {
 		g.Go(func() error {
-			url, err := s.s3Client.UploadFile(gCtx, payload.HeroImageOne, "foundation-profile")
+			url, variants, err := s.s3Client.UploadImage(gCtx, payload.HeroImageOne, "foundation-profile")
 			if err != nil {
 				logrus.WithField("component", "foundation_profile.service").WithError(err).Error("failed to upload hero image one")
 				return uploadError{field: "hero_image_one", msg: "Gagal mengunggah hero image 1", err: err}
 			}
 			heroImageOneURL = url
+			heroImageOneVariants = variants
 			return nil
 		})
 	}
 
 	if payload.HeroImageTwo != nil {
 		g.Go(func() error {
-			url, err := s.s3Client.UploadFile(gCtx, payload.HeroImageTwo, "foundation-profile")
+			url, variants, err := s.s3Client.UploadImage(gCtx, payload.HeroImageTwo, "foundation-profile")
 			if err != nil {
 				logrus.WithField("component", "foundation_profile.service").WithError(err).Error("failed to upload hero image two")
 				return uploadError{field: "hero_image_two", msg: "Gagal mengunggah hero image 2", err: err}
 			}
 			heroImageTwoURL = url
+			heroImageTwoVariants = variants
 			return nil
 		})
 	}
 
 	if payload.HeroImageThree != nil {
 		g.Go(func() error {
-			url, err := s.s3Client.UploadFile(gCtx, payload.HeroImageThree, "foundation-profile")
+			url, variants, err := s.s3Client.UploadImage(gCtx, payload.HeroImageThree, "foundation-profile")
 			if err != nil {
 				logrus.WithField("component", "foundation_profile.service").WithError(err).Error("failed to upload hero image three")
 				return uploadError{field: "hero_image_three", msg: "Gagal mengunggah hero image 3", err: err}
 			}
 			heroImageThreeURL = url
+			heroImageThreeVariants = variants
 			return nil
 		})
 	}
 
 	if payload.HeroImageFour != nil {
 		g.Go(func() error {
-			url, err := s.s3Client.UploadFile(gCtx, payload.HeroImageFour, "foundation-profile")
+			url, variants, err := s.s3Client.UploadImage(gCtx, payload.HeroImageFour, "foundation-profile")
 			if err != nil {
 				logrus.WithField("component", "foundation_profile.service").WithError(err).Error("failed to upload hero image four")
 				return uploadError{field: "hero_image_four", msg: "Gagal mengunggah hero image 4", err: err}
 			}
 			heroImageFourURL = url
+			heroImageFourVariants = variants
 			return nil
 		})
 	}
@@ -229,15 +244,19 @@ func (s *service) CreateFoundationProfile(ctx context.Context, payload Foundatio
 	}
 	if heroImageOneURL != "" {
 		profile.HeroImageOne = heroImageOneURL
+		profile.HeroImageOneVariants = heroImageOneVariants
 	}
 	if heroImageTwoURL != "" {
 		profile.HeroImageTwo = heroImageTwoURL
+		profile.HeroImageTwoVariants = heroImageTwoVariants
 	}
 	if heroImageThreeURL != "" {
 		profile.HeroImageThree = heroImageThreeURL
+		profile.HeroImageThreeVariants = heroImageThreeVariants
 	}
 	if heroImageFourURL != "" {
 		profile.HeroImageFour = heroImageFourURL
+		profile.HeroImageFourVariants = heroImageFourVariants
 	}
 
 	if err := s.repo.CreateFoundationProfile(ctx, profile); err != nil {
@@ -313,6 +332,11 @@ func (s *service) UpdateFoundationProfile(ctx context.Context, id string, payloa
 		heroImageTwoURL          string
 		heroImageThreeURL        string
 		heroImageFourURL         string
+
+		heroImageOneVariants   s3_pkg.ImageVariants
+		heroImageTwoVariants   s3_pkg.ImageVariants
+		heroImageThreeVariants s3_pkg.ImageVariants
+		heroImageFourVariants  s3_pkg.ImageVariants
 	)
 
 	g, gCtx := errgroup.WithContext(ctx)
@@ -367,48 +391,52 @@ func (s *service) UpdateFoundationProfile(ctx context.Context, id string, payloa
 
 	if payload.HeroImageOne != nil {
 		g.Go(func() error {
-			url, err := s.s3Client.UploadFile(gCtx, payload.HeroImageOne, "foundation-profile")
+			url, variants, err := s.s3Client.UploadImage(gCtx, payload.HeroImageOne, "foundation-profile")
 			if err != nil {
 				logrus.WithField("component", "foundation_profile.service").WithError(err).Error("failed to upload hero image one")
 				return uploadError{field: "hero_image_one", msg: "Gagal mengunggah hero image 1", err: err}
 			}
 			heroImageOneURL = url
+			heroImageOneVariants = variants
 			return nil
 		})
 	}
 
 	if payload.HeroImageTwo != nil {
 		g.Go(func() error {
-			url, err := s.s3Client.UploadFile(gCtx, payload.HeroImageTwo, "foundation-profile")
+			url, variants, err := s.s3Client.UploadImage(gCtx, payload.HeroImageTwo, "foundation-profile")
 			if err != nil {
 				logrus.WithField("component", "foundation_profile.service").WithError(err).Error("failed to upload hero image two")
 				return uploadError{field: "hero_image_two", msg: "Gagal mengunggah hero image 2", err: err}
 			}
 			heroImageTwoURL = url
+			heroImageTwoVariants = variants
 			return nil
 		})
 	}
 
 	if payload.HeroImageThree != nil {
 		g.Go(func() error {
-			url, err := s.s3Client.UploadFile(gCtx, payload.HeroImageThree, "foundation-profile")
+			url, variants, err := s.s3Client.UploadImage(gCtx, payload.HeroImageThree, "foundation-profile")
 			if err != nil {
 				logrus.WithField("component", "foundation_profile.service").WithError(err).Error("failed to upload hero image three")
 				return uploadError{field: "hero_image_three", msg: "Gagal mengunggah hero image 3", err: err}
 			}
 			heroImageThreeURL = url
+			heroImageThreeVariants = variants
 			return nil
 		})
 	}
 
 	if payload.HeroImageFour != nil {
 		g.Go(func() error {
-			url, err := s.s3Client.UploadFile(gCtx, payload.HeroImageFour, "foundation-profile")
+			url, variants, err := s.s3Client.UploadImage(gCtx, payload.HeroImageFour, "foundation-profile")
 			if err != nil {
 				logrus.WithField("component", "foundation_profile.service").WithError(err).Error("failed to upload hero image four")
 				return uploadError{field: "hero_image_four", msg: "Gagal mengunggah hero image 4", err: err}
 			}
 			heroImageFourURL = url
+			heroImageFourVariants = variants
 			return nil
 		})
 	}
@@ -422,6 +450,7 @@ func (s *service) UpdateFoundationProfile(ctx context.Context, id string, payloa
 	}
 
 	var filesToDelete []string
+	var imagesToDelete []storedImage
 
 	if payload.FounderPicture != nil {
 		if existing.FounderPicture != "" {
@@ -453,30 +482,34 @@ func (s *service) UpdateFoundationProfile(ctx context.Context, id string, payloa
 
 	if payload.HeroImageOne != nil {
 		if existing.HeroImageOne != "" {
-			filesToDelete = append(filesToDelete, existing.HeroImageOne)
+			imagesToDelete = append(imagesToDelete, storedImage{path: existing.HeroImageOne, variants: existing.HeroImageOneVariants})
 		}
 		updateData["hero_image_one"] = heroImageOneURL
+		updateData["hero_image_one_variants"] = heroImageOneVariants
 	}
 
 	if payload.HeroImageTwo != nil {
 		if existing.HeroImageTwo != "" {
-			filesToDelete = append(filesToDelete, existing.HeroImageTwo)
+			imagesToDelete = append(imagesToDelete, storedImage{path: existing.HeroImageTwo, variants: existing.HeroImageTwoVariants})
 		}
 		updateData["hero_image_two"] = heroImageTwoURL
+		updateData["hero_image_two_variants"] = heroImageTwoVariants
 	}
 
 	if payload.HeroImageThree != nil {
 		if existing.HeroImageThree != "" {
-			filesToDelete = append(filesToDelete, existing.HeroImageThree)
+			imagesToDelete = append(imagesToDelete, storedImage{path: existing.HeroImageThree, variants: existing.HeroImageThreeVariants})
 		}
 		updateData["hero_image_three"] = heroImageThreeURL
+		updateData["hero_image_three_variants"] = heroImageThreeVariants
 	}
 
 	if payload.HeroImageFour != nil {
 		if existing.HeroImageFour != "" {
-			filesToDelete = append(filesToDelete, existing.HeroImageFour)
+			imagesToDelete = append(imagesToDelete, storedImage{path: existing.HeroImageFour, variants: existing.HeroImageFourVariants})
 		}
 		updateData["hero_image_four"] = heroImageFourURL
+		updateData["hero_image_four_variants"] = heroImageFourVariants
 	}
 
 	if len(updateData) == 0 {
@@ -496,6 +529,9 @@ func (s *service) UpdateFoundationProfile(ctx context.Context, id string, payloa
 	for _, oldFile := range filesToDelete {
 		_ = s.s3Client.DeleteFile(ctx, s3_pkg.ExtractObjectNameFromURL(oldFile))
 	}
+	for _, oldImage := range imagesToDelete {
+		_ = s.s3Client.DeleteImage(ctx, s3_pkg.ExtractObjectNameFromURL(oldImage.path), oldImage.variants)
+	}
 
 	return pkg.NewResponse(http.StatusOK, "Profil yayasan berhasil diperbarui", nil, nil)
 }
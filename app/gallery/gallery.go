@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/Vilamuzz/yota-backend/app/media"
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
 	"github.com/google/uuid"
 )
 
@@ -20,5 +21,7 @@ type Gallery struct {
 	UpdatedAt   time.Time           `json:"updatedAt"`
 	DeletedAt   *time.Time          `json:"deletedAt" gorm:"index"`
 
+	CoverImageVariants s3_pkg.ImageVariants `json:"coverImageVariants" gorm:"type:jsonb"`
+
 	Media []media.Media `gorm:"foreignKey:GalleryID"`
 }
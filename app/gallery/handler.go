@@ -40,6 +40,10 @@ func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
 		admin.DELETE("/:id", h.DeleteGallery)
 		admin.PATCH("/:id/publish", h.UpdatePublishGallery)
 		admin.PATCH("/:id/archive", h.UpdateArchiveGallery)
+		admin.POST("/:id/video-uploads", h.CreateVideoUpload)
+		admin.GET("/:id/video-uploads/:uploadId", h.GetVideoUpload)
+		admin.POST("/:id/video-uploads/:uploadId/complete", h.CompleteVideoUpload)
+		admin.DELETE("/:id/video-uploads/:uploadId", h.AbortVideoUpload)
 	}
 }
 
@@ -254,3 +258,91 @@ func (h *handler) UpdateArchiveGallery(c *gin.Context) {
 	res := h.service.UpdateArchivedGallery(ctx, galleryID)
 	c.JSON(res.Status, res)
 }
+
+// CreateVideoUpload
+//
+// @Summary Start Gallery Video Upload
+// @Description Open a resumable upload session for a large video. PUT each part to its presigned URL, then confirm with the complete endpoint (requires publication manager role)
+// @Tags Gallery
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Gallery ID"
+// @Param body body CreateVideoUploadRequest true "Video file details"
+// @Success 201 {object} pkg.Response{data=media.UploadSessionResponse}
+// @Router /api/admin/galleries/{id}/video-uploads [post]
+func (h *handler) CreateVideoUpload(c *gin.Context) {
+	ctx := c.Request.Context()
+	galleryID := c.Param("id")
+
+	var req CreateVideoUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid request body: "+err.Error(), nil, nil))
+		return
+	}
+
+	res := h.service.CreateVideoUpload(ctx, galleryID, req)
+	c.JSON(res.Status, res)
+}
+
+// GetVideoUpload
+//
+// @Summary Resume Gallery Video Upload
+// @Description Retrieve an upload session with the parts already stored and fresh presigned URLs for the remaining parts (requires publication manager role)
+// @Tags Gallery
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Gallery ID"
+// @Param uploadId path string true "Upload Session ID"
+// @Success 200 {object} pkg.Response{data=media.UploadSessionResponse}
+// @Router /api/admin/galleries/{id}/video-uploads/{uploadId} [get]
+func (h *handler) GetVideoUpload(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	res := h.service.GetVideoUpload(ctx, c.Param("id"), c.Param("uploadId"))
+	c.JSON(res.Status, res)
+}
+
+// CompleteVideoUpload
+//
+// @Summary Complete Gallery Video Upload
+// @Description Assemble the uploaded parts, validate size and content type, and add the video to the gallery (requires publication manager role)
+// @Tags Gallery
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Gallery ID"
+// @Param uploadId path string true "Upload Session ID"
+// @Param body body CompleteVideoUploadRequest true "Media details"
+// @Success 201 {object} pkg.Response{data=media.MediaResponse}
+// @Router /api/admin/galleries/{id}/video-uploads/{uploadId}/complete [post]
+func (h *handler) CompleteVideoUpload(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req CompleteVideoUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Invalid request body: "+err.Error(), nil, nil))
+		return
+	}
+
+	res := h.service.CompleteVideoUpload(ctx, c.Param("id"), c.Param("uploadId"), req)
+	c.JSON(res.Status, res)
+}
+
+// AbortVideoUpload
+//
+// @Summary Abort Gallery Video Upload
+// @Description Cancel an upload session and discard the parts stored so far (requires publication manager role)
+// @Tags Gallery
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Gallery ID"
+// @Param uploadId path string true "Upload Session ID"
+// @Success 200 {object} pkg.Response
+// @Router /api/admin/galleries/{id}/video-uploads/{uploadId} [delete]
+func (h *handler) AbortVideoUpload(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	res := h.service.AbortVideoUpload(ctx, c.Param("id"), c.Param("uploadId"))
+	c.JSON(res.Status, res)
+}
@@ -38,3 +38,14 @@ type GalleryQueryParams struct {
 	Page     int                 `form:"page"`
 	Limit    int                 `form:"limit"`
 }
+
+type CreateVideoUploadRequest struct {
+	FileName    string `json:"fileName"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+}
+
+type CompleteVideoUploadRequest struct {
+	Alt   string `json:"alt"`
+	Order int    `json:"order"`
+}
@@ -19,6 +19,8 @@ type GalleryResponse struct {
 	Views       int                   `json:"views"`
 	Status      media.MediaStatus     `json:"status"`
 	CreatedAt   time.Time             `json:"createdAt"`
+
+	CoverImageSet *s3_pkg.ImageSet `json:"coverImageSet,omitempty"`
 }
 
 type GalleryListResponseItem struct {
@@ -31,6 +33,8 @@ type GalleryListResponseItem struct {
 	Views       int                 `json:"views"`
 	Status      media.MediaStatus   `json:"status"`
 	CreatedAt   time.Time           `json:"createdAt"`
+
+	CoverImageSet *s3_pkg.ImageSet `json:"coverImageSet,omitempty"`
 }
 
 type GalleryListResponse struct {
@@ -48,6 +52,8 @@ func (g *Gallery) toGalleryResponse() GalleryResponse {
 			URL:       s3_pkg.GetCDNURL(m.URL),
 			Alt:       m.Alt,
 			Order:     m.Order,
+
+			ImageSet: s3_pkg.NewImageSet(m.Variants),
 		})
 	}
 
@@ -62,6 +68,8 @@ func (g *Gallery) toGalleryResponse() GalleryResponse {
 		Views:       g.Views,
 		Status:      g.Status,
 		CreatedAt:   g.CreatedAt,
+
+		CoverImageSet: s3_pkg.NewImageSet(g.CoverImageVariants),
 	}
 }
 
@@ -76,6 +84,8 @@ func (g *Gallery) toGalleryListResponseItem() GalleryListResponseItem {
 		Views:       g.Views,
 		Status:      g.Status,
 		CreatedAt:   g.CreatedAt,
+
+		CoverImageSet: s3_pkg.NewImageSet(g.CoverImageVariants),
 	}
 }
 
@@ -3,11 +3,14 @@ package gallery
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	app_log "github.com/Vilamuzz/yota-backend/app/log"
 	"github.com/Vilamuzz/yota-backend/app/media"
+	"github.com/Vilamuzz/yota-backend/config"
 	"github.com/Vilamuzz/yota-backend/pkg"
 	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
 	"github.com/google/uuid"
@@ -33,6 +36,11 @@ type Service interface {
 	DeleteGallery(ctx context.Context, id string) pkg.Response
 	UpdatePublishGallery(ctx context.Context, id string) pkg.Response
 	UpdateArchivedGallery(ctx context.Context, id string) pkg.Response
+
+	CreateVideoUpload(ctx context.Context, id string, payload CreateVideoUploadRequest) pkg.Response
+	GetVideoUpload(ctx context.Context, id string, uploadID string) pkg.Response
+	CompleteVideoUpload(ctx context.Context, id string, uploadID string, payload CompleteVideoUploadRequest) pkg.Response
+	AbortVideoUpload(ctx context.Context, id string, uploadID string) pkg.Response
 }
 
 type service struct {
@@ -204,12 +212,13 @@ func (s *service) CreateGallery(ctx context.Context, payload GalleryCreateReques
 
 	var mediaItems []media.Media
 	var coverImageURL string
+	var coverImageVariants s3_pkg.ImageVariants
 
 	g, gCtx := errgroup.WithContext(ctx)
 
 	if payload.CoverImage != nil {
 		g.Go(func() error {
-			uploadedURL, err := s.s3Client.UploadFile(gCtx, payload.CoverImage, "galleries")
+			uploadedURL, variants, err := s.s3Client.UploadImage(gCtx, payload.CoverImage, "galleries")
 			if err != nil {
 				logrus.WithFields(logrus.Fields{
 					"component": "gallery.service",
@@ -218,6 +227,7 @@ func (s *service) CreateGallery(ctx context.Context, payload GalleryCreateReques
 				return uploadError{msg: "Gagal mengunggah gambar sampul", err: err}
 			}
 			coverImageURL = uploadedURL
+			coverImageVariants = variants
 			return nil
 		})
 	}
@@ -230,7 +240,7 @@ func (s *service) CreateGallery(ctx context.Context, payload GalleryCreateReques
 		i := i
 		file := file
 		g.Go(func() error {
-			url, err := s.s3Client.UploadFile(gCtx, file, "galleries")
+			url, variants, err := s.s3Client.UploadImage(gCtx, file, "galleries")
 			if err != nil {
 				logrus.WithFields(logrus.Fields{
 					"component": "gallery.service",
@@ -245,11 +255,12 @@ func (s *service) CreateGallery(ctx context.Context, payload GalleryCreateReques
 			}
 
 			tempMedia[i] = media.Media{
-				ID:    uuid.New(),
-				Type:  media.MediaTypeImage, // Default to image
-				URL:   url,
-				Alt:   alt,
-				Order: i + 1,
+				ID:       uuid.New(),
+				Type:     media.MediaTypeImage, // Default to image
+				URL:      url,
+				Alt:      alt,
+				Order:    i + 1,
+				Variants: variants,
 			}
 			return nil
 		})
@@ -271,6 +282,7 @@ func (s *service) CreateGallery(ctx context.Context, payload GalleryCreateReques
 
 	if len(tempMedia) > 0 && tempMedia[0].URL != "" && coverImageURL == "" {
 		coverImageURL = tempMedia[0].URL
+		coverImageVariants = tempMedia[0].Variants
 	}
 
 	timeNow := time.Now()
@@ -287,6 +299,8 @@ func (s *service) CreateGallery(ctx context.Context, payload GalleryCreateReques
 		Media:       mediaItems,
 		CreatedAt:   timeNow,
 		UpdatedAt:   timeNow,
+
+		CoverImageVariants: coverImageVariants,
 	}
 
 	if err := s.repo.CreateGallery(ctx, gallery); err != nil {
@@ -447,15 +461,16 @@ func (s *service) UpdateGallery(ctx context.Context, id string, payload GalleryU
 
 	// 3. Add New Media Files
 	var (
-		coverImageURL string
-		newMediaList  []media.Media
+		coverImageURL      string
+		coverImageVariants s3_pkg.ImageVariants
+		newMediaList       []media.Media
 	)
 
 	g, gCtx := errgroup.WithContext(ctx)
 
 	if payload.CoverImage != nil {
 		g.Go(func() error {
-			uploadedURL, err := s.s3Client.UploadFile(gCtx, payload.CoverImage, "galleries")
+			uploadedURL, variants, err := s.s3Client.UploadImage(gCtx, payload.CoverImage, "galleries")
 			if err != nil {
 				logrus.WithFields(logrus.Fields{
 					"component":  "gallery.service",
@@ -464,6 +479,7 @@ func (s *service) UpdateGallery(ctx context.Context, id string, payload GalleryU
 				return uploadError{msg: "Gagal mengunggah gambar sampul baru", err: err}
 			}
 			coverImageURL = uploadedURL
+			coverImageVariants = variants
 			return nil
 		})
 	}
@@ -476,7 +492,7 @@ func (s *service) UpdateGallery(ctx context.Context, id string, payload GalleryU
 		i := i
 		file := file
 		g.Go(func() error {
-			url, err := s.s3Client.UploadFile(gCtx, file, "galleries")
+			url, variants, err := s.s3Client.UploadImage(gCtx, file, "galleries")
 			if err != nil {
 				logrus.WithFields(logrus.Fields{
 					"component": "gallery.service",
@@ -496,11 +512,12 @@ func (s *service) UpdateGallery(ctx context.Context, id string, payload GalleryU
 			}
 
 			tempNewMedia[i] = media.Media{
-				ID:    uuid.New(),
-				URL:   url,
-				Type:  media.MediaTypeImage,
-				Alt:   alt,
-				Order: order,
+				ID:       uuid.New(),
+				URL:      url,
+				Type:     media.MediaTypeImage,
+				Alt:      alt,
+				Order:    order,
+				Variants: variants,
 			}
 			return nil
 		})
@@ -533,10 +550,11 @@ func (s *service) UpdateGallery(ctx context.Context, id string, payload GalleryU
 	if coverImageURL != "" {
 		if existingGallery.CoverImage != "" {
 			existingCoverImage := s3_pkg.ExtractObjectNameFromURL(existingGallery.CoverImage)
-			_ = s.s3Client.DeleteFile(ctx, existingCoverImage)
+			_ = s.s3Client.DeleteImage(ctx, existingCoverImage, existingGallery.CoverImageVariants)
 		}
 
 		updateData["cover_image"] = coverImageURL
+		updateData["cover_image_variants"] = coverImageVariants
 	}
 
 	if len(updateData) == 0 && len(payload.MediaFiles) == 0 && payload.CoverImage == nil {
@@ -582,7 +600,7 @@ func (s *service) DeleteGallery(ctx context.Context, id string) pkg.Response {
 	if existingGallery.CoverImage != "" {
 		objectName := s3_pkg.ExtractObjectNameFromURL(existingGallery.CoverImage)
 		if objectName != "" {
-			_ = s.s3Client.DeleteFile(ctx, objectName)
+			_ = s.s3Client.DeleteImage(ctx, objectName, existingGallery.CoverImageVariants)
 		}
 	}
 
@@ -693,3 +711,171 @@ func (s *service) UpdateArchivedGallery(ctx context.Context, id string) pkg.Resp
 	s.logService.CreateLog(ctx, nil, "UPDATE", "gallery", id, gallery.toGalleryResponse(), updateData)
 	return pkg.NewResponse(http.StatusOK, "Galeri berhasil diarsipkan", nil, nil)
 }
+
+// CreateVideoUpload opens an upload session for a gallery video. The client PUTs each part to its
+// presigned URL and then confirms, so the video never passes through the API server.
+func (s *service) CreateVideoUpload(ctx context.Context, id string, payload CreateVideoUploadRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := uuid.Validate(id); err != nil {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID galeri tidak valid"}, nil)
+	}
+
+	errValidation := make(map[string]string)
+	payload.FileName = strings.TrimSpace(payload.FileName)
+	if payload.FileName == "" {
+		errValidation["fileName"] = "Nama file wajib diisi"
+	}
+	if _, ok := media.AllowedVideoContentTypes[payload.ContentType]; !ok {
+		errValidation["contentType"] = "Tipe video tidak didukung, gunakan MP4, WEBM atau MOV"
+	}
+	maxSize := config.GetMaxVideoUploadSize()
+	if payload.Size <= 0 {
+		errValidation["size"] = "Ukuran file wajib diisi"
+	} else if payload.Size > maxSize {
+		errValidation["size"] = fmt.Sprintf("Ukuran video tidak boleh melebihi %d MB", maxSize/(1024*1024))
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	if _, err := s.repo.FindOneGallery(ctx, map[string]interface{}{"id": id}); err != nil {
+		return pkg.NewResponse(http.StatusNotFound, "Galeri tidak ditemukan", nil, nil)
+	}
+
+	session, err := s.mediaService.StartUpload(ctx, id, "galleries", "galleries/videos", payload.FileName, payload.ContentType, payload.Size)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "gallery.service",
+			"gallery_id": id,
+		}).WithError(err).Error("failed to start video upload")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memulai sesi unggah video", nil, nil)
+	}
+
+	return s.uploadSessionResponse(ctx, http.StatusCreated, "Sesi unggah video berhasil dibuat", session)
+}
+
+// GetVideoUpload returns the parts already stored and fresh URLs for the rest, so an interrupted
+// upload can resume.
+func (s *service) GetVideoUpload(ctx context.Context, id string, uploadID string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	session, errRes := s.findUploadSession(ctx, id, uploadID)
+	if errRes != nil {
+		return *errRes
+	}
+	if session.Status != media.UploadStatusPending || session.IsExpired(time.Now()) {
+		return pkg.NewResponse(http.StatusOK, "Berhasil", nil, session.ToUploadSessionResponse(nil, nil))
+	}
+
+	return s.uploadSessionResponse(ctx, http.StatusOK, "Berhasil", session)
+}
+
+func (s *service) CompleteVideoUpload(ctx context.Context, id string, uploadID string, payload CompleteVideoUploadRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	session, errRes := s.findUploadSession(ctx, id, uploadID)
+	if errRes != nil {
+		return *errRes
+	}
+
+	if payload.Order <= 0 {
+		existingMedia, _ := s.mediaService.FetchEntityMedia(ctx, id, "galleries")
+		payload.Order = len(existingMedia) + 1
+	}
+
+	created, err := s.mediaService.CompleteUpload(ctx, session, payload.Alt, payload.Order)
+	if err != nil {
+		switch {
+		case errors.Is(err, media.ErrUploadSessionClosed):
+			return pkg.NewResponse(http.StatusConflict, "Sesi unggah sudah ditutup", nil, nil)
+		case errors.Is(err, media.ErrUploadExpired):
+			return pkg.NewResponse(http.StatusGone, "Sesi unggah sudah kedaluwarsa", nil, nil)
+		case errors.Is(err, media.ErrUploadIncomplete):
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"parts": "Masih ada bagian video yang belum diunggah"}, nil)
+		case errors.Is(err, media.ErrUploadSizeMismatch):
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"size": "Ukuran video yang diunggah tidak sesuai"}, nil)
+		case errors.Is(err, media.ErrUploadContentType):
+			return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"contentType": "File yang diunggah bukan video yang didukung"}, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "gallery.service",
+			"gallery_id": id,
+			"upload_id":  uploadID,
+		}).WithError(err).Error("failed to complete video upload")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menyelesaikan unggah video", nil, nil)
+	}
+
+	res := media.MediaResponse{
+		ID:        created.ID.String(),
+		GalleryID: created.GalleryID.String(),
+		Type:      string(created.Type),
+		URL:       s3_pkg.GetCDNURL(created.URL),
+		Alt:       created.Alt,
+		Order:     created.Order,
+	}
+	s.logService.CreateLog(ctx, nil, "CREATE", "media", created.ID.String(), nil, res)
+
+	return pkg.NewResponse(http.StatusCreated, "Video berhasil ditambahkan ke galeri", nil, res)
+}
+
+func (s *service) AbortVideoUpload(ctx context.Context, id string, uploadID string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	session, errRes := s.findUploadSession(ctx, id, uploadID)
+	if errRes != nil {
+		return *errRes
+	}
+
+	if err := s.mediaService.AbortUpload(ctx, session); err != nil {
+		if errors.Is(err, media.ErrUploadSessionClosed) {
+			return pkg.NewResponse(http.StatusConflict, "Sesi unggah sudah ditutup", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "gallery.service",
+			"gallery_id": id,
+			"upload_id":  uploadID,
+		}).WithError(err).Error("failed to abort video upload")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal membatalkan unggah video", nil, nil)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Unggah video berhasil dibatalkan", nil, nil)
+}
+
+func (s *service) findUploadSession(ctx context.Context, id string, uploadID string) (*media.UploadSession, *pkg.Response) {
+	errValidation := make(map[string]string)
+	if err := uuid.Validate(id); err != nil {
+		errValidation["id"] = "Format ID galeri tidak valid"
+	}
+	if err := uuid.Validate(uploadID); err != nil {
+		errValidation["uploadId"] = "Format ID sesi unggah tidak valid"
+	}
+	if len(errValidation) > 0 {
+		res := pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+		return nil, &res
+	}
+
+	session, err := s.mediaService.FindUploadSession(ctx, id, "galleries", uploadID)
+	if err != nil {
+		res := pkg.NewResponse(http.StatusNotFound, "Sesi unggah tidak ditemukan", nil, nil)
+		return nil, &res
+	}
+	return session, nil
+}
+
+func (s *service) uploadSessionResponse(ctx context.Context, status int, msg string, session *media.UploadSession) pkg.Response {
+	uploaded, parts, err := s.mediaService.PresignPendingParts(ctx, session)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "gallery.service",
+			"upload_id":  session.ID,
+			"gallery_id": session.EntityID,
+		}).WithError(err).Error("failed to presign upload parts")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menyiapkan tautan unggah", nil, nil)
+	}
+	return pkg.NewResponse(status, msg, nil, session.ToUploadSessionResponse(uploaded, parts))
+}
@@ -3,6 +3,7 @@ package media
 import (
 	"time"
 
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
 	"github.com/google/uuid"
 )
 
@@ -17,6 +18,9 @@ type Media struct {
 	Order     int       `json:"order" gorm:"not null;default:0"`
 	CreatedAt time.Time `json:"createdAt" gorm:"not null"`
 	UpdatedAt time.Time `json:"updatedAt" gorm:"not null"`
+
+	// Variants holds the responsive renditions of an image; empty for videos and older uploads.
+	Variants s3_pkg.ImageVariants `json:"variants" gorm:"type:jsonb"`
 }
 
 type MediaType string
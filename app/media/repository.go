@@ -2,6 +2,7 @@ package media
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -14,6 +15,12 @@ type Repository interface {
 	DeleteMediaByID(ctx context.Context, mediaID string) (*Media, error)
 	FetchMediaByID(ctx context.Context, mediaID string) (*Media, error)
 	UpdateMediaByID(ctx context.Context, mediaID string, updateData map[string]interface{}) error
+
+	CreateUploadSession(ctx context.Context, session *UploadSession) error
+	FindUploadSession(ctx context.Context, options map[string]interface{}) (*UploadSession, error)
+	UpdateUploadSession(ctx context.Context, id string, updateData map[string]interface{}) error
+	CompleteUploadSession(ctx context.Context, id string, media *Media, updateData map[string]interface{}) error
+	FindExpiredUploadSessions(ctx context.Context, now time.Time, limit int) ([]UploadSession, error)
 }
 
 type repository struct {
@@ -54,17 +61,20 @@ func (r *repository) FetchMediaByID(ctx context.Context, mediaID string) (*Media
 
 func (r *repository) CreateEntityMedia(ctx context.Context, entityID, entityType string, media []Media) error {
 	for i := range media {
-		id := uuid.MustParse(entityID)
-		switch entityType {
-		case "news":
-			media[i].NewsID = &id
-		case "gallery", "galleries":
-			media[i].GalleryID = &id
-		}
+		linkEntity(&media[i], uuid.MustParse(entityID), entityType)
 	}
 	return r.Conn.WithContext(ctx).Create(&media).Error
 }
 
+func linkEntity(media *Media, id uuid.UUID, entityType string) {
+	switch entityType {
+	case "news":
+		media.NewsID = &id
+	case "gallery", "galleries":
+		media.GalleryID = &id
+	}
+}
+
 func (r *repository) UpdateMediaByID(ctx context.Context, mediaID string, updateData map[string]interface{}) error {
 	return r.Conn.WithContext(ctx).Model(&Media{}).Where("id = ?", mediaID).Updates(updateData).Error
 }
@@ -94,3 +104,68 @@ func (r *repository) DeleteMediaByID(ctx context.Context, mediaID string) (*Medi
 	}
 	return &media, nil
 }
+
+func (r *repository) CreateUploadSession(ctx context.Context, session *UploadSession) error {
+	return r.Conn.WithContext(ctx).Create(session).Error
+}
+
+func (r *repository) FindUploadSession(ctx context.Context, options map[string]interface{}) (*UploadSession, error) {
+	var session UploadSession
+	query := r.Conn.WithContext(ctx)
+	if id, ok := options["id"]; ok {
+		query = query.Where("id = ?", id)
+	}
+	if entityType, ok := options["entity_type"]; ok {
+		query = query.Where("entity_type = ?", entityType)
+	}
+	if entityID, ok := options["entity_id"]; ok {
+		query = query.Where("entity_id = ?", entityID)
+	}
+	if err := query.First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// UpdateUploadSession changes a session only while it is still pending, so a confirm racing the
+// cleanup job or an abort cannot both win.
+func (r *repository) UpdateUploadSession(ctx context.Context, id string, updateData map[string]interface{}) error {
+	result := r.Conn.WithContext(ctx).Model(&UploadSession{}).
+		Where("id = ? AND status = ?", id, UploadStatusPending).
+		Updates(updateData)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUploadSessionClosed
+	}
+	return nil
+}
+
+// CompleteUploadSession closes a pending session and registers its Media row in one transaction.
+func (r *repository) CompleteUploadSession(ctx context.Context, id string, media *Media, updateData map[string]interface{}) error {
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&UploadSession{}).
+			Where("id = ? AND status = ?", id, UploadStatusPending).
+			Updates(updateData)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrUploadSessionClosed
+		}
+		return tx.Create(media).Error
+	})
+}
+
+func (r *repository) FindExpiredUploadSessions(ctx context.Context, now time.Time, limit int) ([]UploadSession, error) {
+	var sessions []UploadSession
+	if err := r.Conn.WithContext(ctx).
+		Where("status = ? AND expires_at < ?", UploadStatusPending, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
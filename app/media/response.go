@@ -1,5 +1,11 @@
 package media
 
+import (
+	"time"
+
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
+)
+
 type PublishedMediaResponse struct {
 	URL   string `json:"url"`
 	Alt   string `json:"alt"`
@@ -14,4 +20,57 @@ type MediaResponse struct {
 	URL       string `json:"url"`
 	Alt       string `json:"alt"`
 	Order     int    `json:"order"`
+
+	ImageSet *s3_pkg.ImageSet `json:"imageSet,omitempty"`
+}
+
+type UploadPartURL struct {
+	PartNumber int       `json:"partNumber"`
+	URL        string    `json:"url"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+type UploadSessionResponse struct {
+	ID            string          `json:"id"`
+	FileName      string          `json:"fileName"`
+	ContentType   string          `json:"contentType"`
+	Size          int64           `json:"size"`
+	PartSize      int64           `json:"partSize"`
+	TotalParts    int             `json:"totalParts"`
+	Status        UploadStatus    `json:"status"`
+	ExpiresAt     time.Time       `json:"expiresAt"`
+	UploadedParts []int           `json:"uploadedParts"`
+	Parts         []UploadPartURL `json:"parts"`
+	MediaID       *string         `json:"mediaId"`
+}
+
+// ToUploadSessionResponse describes a session with the parts already stored and the presigned URLs
+// for the remaining ones.
+func (u *UploadSession) ToUploadSessionResponse(uploaded []int, parts []UploadPartURL) UploadSessionResponse {
+	if uploaded == nil {
+		uploaded = []int{}
+	}
+	if parts == nil {
+		parts = []UploadPartURL{}
+	}
+
+	var mediaID *string
+	if u.MediaID != nil {
+		id := u.MediaID.String()
+		mediaID = &id
+	}
+
+	return UploadSessionResponse{
+		ID:            u.ID.String(),
+		FileName:      u.FileName,
+		ContentType:   u.ContentType,
+		Size:          u.Size,
+		PartSize:      u.PartSize,
+		TotalParts:    u.TotalParts(),
+		Status:        u.Status,
+		ExpiresAt:     u.ExpiresAt,
+		UploadedParts: uploaded,
+		Parts:         parts,
+		MediaID:       mediaID,
+	}
 }
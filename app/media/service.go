@@ -2,9 +2,13 @@ package media
 
 import (
 	"context"
+	"errors"
 	"mime/multipart"
+	"time"
 
 	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 )
 
 type Service interface {
@@ -14,6 +18,13 @@ type Service interface {
 	DeleteMediaByID(ctx context.Context, mediaID string) error
 	FetchEntityMedia(ctx context.Context, entityID, entityType string) ([]Media, error)
 	UpdateMediaByID(ctx context.Context, mediaID string, updateData map[string]interface{}) error
+
+	StartUpload(ctx context.Context, entityID, entityType, folder, fileName, contentType string, size int64) (*UploadSession, error)
+	FindUploadSession(ctx context.Context, entityID, entityType, sessionID string) (*UploadSession, error)
+	PresignPendingParts(ctx context.Context, session *UploadSession) ([]int, []UploadPartURL, error)
+	CompleteUpload(ctx context.Context, session *UploadSession, alt string, order int) (*Media, error)
+	AbortUpload(ctx context.Context, session *UploadSession) error
+	CleanupExpiredUploads(ctx context.Context) error
 }
 
 type service struct {
@@ -45,15 +56,23 @@ func (s *service) UploadMedia(ctx context.Context, files []*multipart.FileHeader
 			mediaType = MediaTypeVideo
 		}
 
-		fileURL, err := s.s3Client.UploadFile(ctx, file, folder)
+		var fileURL string
+		var variants s3_pkg.ImageVariants
+		var err error
+		if mediaType == MediaTypeImage {
+			fileURL, variants, err = s.s3Client.UploadImage(ctx, file, folder)
+		} else {
+			fileURL, err = s.s3Client.UploadFile(ctx, file, folder)
+		}
 		if err != nil {
 			return nil, err
 		}
 
 		mediaItems = append(mediaItems, Media{
-			URL:  fileURL,
-			Type: mediaType,
-			Alt:  file.Filename,
+			URL:      fileURL,
+			Type:     mediaType,
+			Alt:      file.Filename,
+			Variants: variants,
 		})
 	}
 
@@ -73,8 +92,8 @@ func (s *service) DeleteEntityMedia(ctx context.Context, entityID, entityType st
 		// URL format: http://minio:9000/bucket-name/path/to/file.jpg
 		objectName := s3_pkg.ExtractObjectNameFromURL(m.URL)
 		if objectName != "" {
-			// Delete file from MinIO (ignore error if file doesn't exist)
-			_ = s.s3Client.DeleteFile(ctx, objectName)
+			// Delete the file and its variants from MinIO (ignore error if file doesn't exist)
+			_ = s.s3Client.DeleteImage(ctx, objectName, m.Variants)
 		}
 	}
 
@@ -86,11 +105,12 @@ func (s *service) CreateEntityMedia(ctx context.Context, entityID, entityType st
 	var mediaItems []Media
 	for _, m := range mediaRequests {
 		mediaItems = append(mediaItems, Media{
-			ID:    m.ID,
-			Type:  m.Type,
-			URL:   m.URL,
-			Alt:   m.Alt,
-			Order: m.Order,
+			ID:       m.ID,
+			Type:     m.Type,
+			URL:      m.URL,
+			Alt:      m.Alt,
+			Order:    m.Order,
+			Variants: m.Variants,
 		})
 	}
 	return s.repo.CreateEntityMedia(ctx, entityID, entityType, mediaItems)
@@ -107,7 +127,7 @@ func (s *service) DeleteMediaByID(ctx context.Context, mediaID string) error {
 	objectName := s3_pkg.ExtractObjectNameFromURL(media.URL)
 	if objectName != "" {
 		// Ignore error if file doesn't exist
-		_ = s.s3Client.DeleteFile(ctx, objectName)
+		_ = s.s3Client.DeleteImage(ctx, objectName, media.Variants)
 	}
 
 	return nil
@@ -120,3 +140,231 @@ func (s *service) FetchEntityMedia(ctx context.Context, entityID, entityType str
 func (s *service) UpdateMediaByID(ctx context.Context, mediaID string, updateData map[string]interface{}) error {
 	return s.repo.UpdateMediaByID(ctx, mediaID, updateData)
 }
+
+// StartUpload opens a multipart upload in storage and records its session. Size and content type
+// are validated by the caller against the limits of its entity.
+func (s *service) StartUpload(ctx context.Context, entityID, entityType, folder, fileName, contentType string, size int64) (*UploadSession, error) {
+	objectName, uploadID, err := s.s3Client.StartMultipartUpload(ctx, folder, AllowedVideoContentTypes[contentType], contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := &UploadSession{
+		ID:          uuid.New(),
+		EntityType:  entityType,
+		EntityID:    uuid.MustParse(entityID),
+		ObjectName:  objectName,
+		UploadID:    uploadID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+		PartSize:    UploadPartSize,
+		Status:      UploadStatusPending,
+		ExpiresAt:   now.Add(UploadSessionTTL),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.repo.CreateUploadSession(ctx, session); err != nil {
+		_ = s.s3Client.AbortMultipartUpload(ctx, objectName, uploadID)
+		return nil, err
+	}
+	return session, nil
+}
+
+func (s *service) FindUploadSession(ctx context.Context, entityID, entityType, sessionID string) (*UploadSession, error) {
+	return s.repo.FindUploadSession(ctx, map[string]interface{}{
+		"id":          sessionID,
+		"entity_type": entityType,
+		"entity_id":   entityID,
+	})
+}
+
+// PresignPendingParts returns the part numbers already stored and fresh URLs for the rest, so an
+// interrupted upload resumes where it stopped.
+func (s *service) PresignPendingParts(ctx context.Context, session *UploadSession) ([]int, []UploadPartURL, error) {
+	stored, err := s.s3Client.ListUploadedParts(ctx, session.ObjectName, session.UploadID)
+	if err != nil {
+		return nil, nil, err
+	}
+	done := make(map[int]bool, len(stored))
+	uploaded := make([]int, 0, len(stored))
+	for _, p := range stored {
+		done[p.PartNumber] = true
+		uploaded = append(uploaded, p.PartNumber)
+	}
+
+	expiresAt := time.Now().Add(UploadPartURLExpiry)
+	var pending []UploadPartURL
+	for n := 1; n <= session.TotalParts(); n++ {
+		if done[n] {
+			continue
+		}
+		url, err := s.s3Client.PresignUploadPart(ctx, session.ObjectName, session.UploadID, n, UploadPartURLExpiry)
+		if err != nil {
+			return nil, nil, err
+		}
+		pending = append(pending, UploadPartURL{PartNumber: n, URL: url, ExpiresAt: expiresAt})
+	}
+	return uploaded, pending, nil
+}
+
+// CompleteUpload assembles the stored parts, checks the result against the declared size and the
+// sniffed content type, and registers the Media row. The parts are read from storage rather than
+// taken from the uploader, so browsers need not expose ETag headers. Missing parts leave the
+// session pending; a wrong size or type rejects it and removes the file.
+func (s *service) CompleteUpload(ctx context.Context, session *UploadSession, alt string, order int) (*Media, error) {
+	if session.Status != UploadStatusPending {
+		return nil, ErrUploadSessionClosed
+	}
+	if session.IsExpired(time.Now()) {
+		return nil, ErrUploadExpired
+	}
+
+	parts, err := s.s3Client.ListUploadedParts(ctx, session.ObjectName, session.UploadID)
+	switch {
+	case s3_pkg.IsNoSuchUpload(err):
+		// An earlier confirm assembled the object but failed before the session was closed, so
+		// validate the assembled object instead.
+		size, err := s.s3Client.StatFileSize(ctx, session.ObjectName)
+		if err != nil {
+			if s3_pkg.IsNoSuchKey(err) {
+				s.rejectUpload(ctx, session, false)
+				return nil, ErrUploadSessionClosed
+			}
+			return nil, err
+		}
+		if size != session.Size {
+			s.rejectUpload(ctx, session, true)
+			return nil, ErrUploadSizeMismatch
+		}
+	case err != nil:
+		return nil, err
+	default:
+		if len(parts) != session.TotalParts() {
+			return nil, ErrUploadIncomplete
+		}
+		var total int64
+		for i, p := range parts {
+			if p.PartNumber != i+1 {
+				return nil, ErrUploadIncomplete
+			}
+			total += p.Size
+		}
+		if total != session.Size {
+			s.rejectUpload(ctx, session, false)
+			return nil, ErrUploadSizeMismatch
+		}
+
+		if err := s.s3Client.CompleteMultipartUpload(ctx, session.ObjectName, session.UploadID, parts); err != nil {
+			return nil, err
+		}
+	}
+
+	head, err := s.s3Client.ReadFileHead(ctx, session.ObjectName, sniffLength)
+	if err != nil {
+		s.rejectUpload(ctx, session, true)
+		return nil, err
+	}
+	if _, ok := AllowedVideoContentTypes[sniffVideoType(head)]; !ok {
+		s.rejectUpload(ctx, session, true)
+		return nil, ErrUploadContentType
+	}
+
+	now := time.Now()
+	media := &Media{
+		ID:        uuid.New(),
+		Type:      MediaTypeVideo,
+		URL:       session.ObjectName,
+		Alt:       alt,
+		Order:     order,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	linkEntity(media, session.EntityID, session.EntityType)
+
+	if err := s.repo.CompleteUploadSession(ctx, session.ID.String(), media, map[string]interface{}{
+		"status":       UploadStatusCompleted,
+		"media_id":     media.ID,
+		"completed_at": now,
+		"updated_at":   now,
+	}); err != nil {
+		if errors.Is(err, ErrUploadSessionClosed) {
+			_ = s.s3Client.DeleteFile(ctx, session.ObjectName)
+		} else {
+			s.rejectUpload(ctx, session, true)
+		}
+		return nil, err
+	}
+	return media, nil
+}
+
+func (s *service) AbortUpload(ctx context.Context, session *UploadSession) error {
+	if err := s.discardUpload(ctx, session); err != nil {
+		return err
+	}
+	return s.repo.UpdateUploadSession(ctx, session.ID.String(), map[string]interface{}{
+		"status":     UploadStatusAborted,
+		"updated_at": time.Now(),
+	})
+}
+
+// CleanupExpiredUploads aborts sessions that were never confirmed, freeing the parts held in
+// storage. Each run handles one batch; the job runs often enough to keep up.
+func (s *service) CleanupExpiredUploads(ctx context.Context) error {
+	sessions, err := s.repo.FindExpiredUploadSessions(ctx, time.Now(), 500)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component": "media.service",
+		}).WithError(err).Error("failed to fetch expired upload sessions")
+		return err
+	}
+
+	for _, session := range sessions {
+		if err := s.discardUpload(ctx, &session); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":  "media.service",
+				"session_id": session.ID,
+			}).WithError(err).Warn("failed to abort expired upload")
+			continue
+		}
+		if err := s.repo.UpdateUploadSession(ctx, session.ID.String(), map[string]interface{}{
+			"status":     UploadStatusExpired,
+			"updated_at": time.Now(),
+		}); err != nil && !errors.Is(err, ErrUploadSessionClosed) {
+			logrus.WithFields(logrus.Fields{
+				"component":  "media.service",
+				"session_id": session.ID,
+			}).WithError(err).Warn("failed to mark upload session expired")
+		}
+	}
+	return nil
+}
+
+// discardUpload aborts the multipart upload of a session. When the upload no longer exists a
+// confirm already assembled the object without registering it, so the object is removed instead.
+func (s *service) discardUpload(ctx context.Context, session *UploadSession) error {
+	err := s.s3Client.AbortMultipartUpload(ctx, session.ObjectName, session.UploadID)
+	if s3_pkg.IsNoSuchUpload(err) {
+		return s.s3Client.DeleteFile(ctx, session.ObjectName)
+	}
+	return err
+}
+
+// rejectUpload closes a session whose upload failed validation and discards what was stored.
+func (s *service) rejectUpload(ctx context.Context, session *UploadSession, completed bool) {
+	if completed {
+		_ = s.s3Client.DeleteFile(ctx, session.ObjectName)
+	} else {
+		_ = s.s3Client.AbortMultipartUpload(ctx, session.ObjectName, session.UploadID)
+	}
+	if err := s.repo.UpdateUploadSession(ctx, session.ID.String(), map[string]interface{}{
+		"status":     UploadStatusRejected,
+		"updated_at": time.Now(),
+	}); err != nil && !errors.Is(err, ErrUploadSessionClosed) {
+		logrus.WithFields(logrus.Fields{
+			"component":  "media.service",
+			"session_id": session.ID,
+		}).WithError(err).Warn("failed to mark upload session rejected")
+	}
+}
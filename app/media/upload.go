@@ -0,0 +1,89 @@
+package media
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UploadSession tracks a video uploaded straight to storage as a multipart upload. The uploader
+// PUTs parts to presigned URLs and confirms; only then is the Media row registered.
+type UploadSession struct {
+	ID          uuid.UUID    `json:"id" gorm:"primaryKey"`
+	EntityType  string       `json:"entityType" gorm:"type:varchar(20);not null"`
+	EntityID    uuid.UUID    `json:"entityId" gorm:"type:uuid;not null;index"`
+	ObjectName  string       `json:"objectName" gorm:"not null"`
+	UploadID    string       `json:"uploadId" gorm:"not null"`
+	FileName    string       `json:"fileName" gorm:"not null"`
+	ContentType string       `json:"contentType" gorm:"type:varchar(100);not null"`
+	Size        int64        `json:"size" gorm:"not null"`
+	PartSize    int64        `json:"partSize" gorm:"not null"`
+	Status      UploadStatus `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"`
+	MediaID     *uuid.UUID   `json:"mediaId" gorm:"type:uuid"`
+	ExpiresAt   time.Time    `json:"expiresAt" gorm:"not null;index"`
+	CompletedAt *time.Time   `json:"completedAt"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+}
+
+type UploadStatus string
+
+const (
+	UploadStatusPending   UploadStatus = "pending"
+	UploadStatusCompleted UploadStatus = "completed"
+	UploadStatusAborted   UploadStatus = "aborted"
+	UploadStatusRejected  UploadStatus = "rejected"
+	UploadStatusExpired   UploadStatus = "expired"
+)
+
+const (
+	// UploadPartSize is the size of every part but the last; S3 requires at least 5 MB.
+	UploadPartSize int64 = 16 * 1024 * 1024
+	// UploadSessionTTL bounds how long an upload may be resumed before the cleanup job aborts it.
+	UploadSessionTTL = 24 * time.Hour
+	// UploadPartURLExpiry is the lifetime of presigned part URLs; resuming issues fresh ones.
+	UploadPartURLExpiry = time.Hour
+
+	sniffLength = 512
+)
+
+var AllowedVideoContentTypes = map[string]string{
+	"video/mp4":       ".mp4",
+	"video/webm":      ".webm",
+	"video/quicktime": ".mov",
+}
+
+var (
+	ErrUploadSessionClosed = errors.New("upload session is no longer pending")
+	ErrUploadExpired       = errors.New("upload session has expired")
+	ErrUploadIncomplete    = errors.New("upload is missing parts")
+	ErrUploadSizeMismatch  = errors.New("uploaded size does not match the declared size")
+	ErrUploadContentType   = errors.New("uploaded file is not an allowed video")
+)
+
+// TotalParts is the number of parts the declared size splits into.
+func (u *UploadSession) TotalParts() int {
+	return int((u.Size + u.PartSize - 1) / u.PartSize)
+}
+
+func (u *UploadSession) IsExpired(now time.Time) bool {
+	return now.After(u.ExpiresAt)
+}
+
+// sniffVideoType detects the type of an uploaded video from its first bytes, so a renamed file
+// cannot be registered as a video. QuickTime files are ISO media but not recognised by
+// http.DetectContentType.
+func sniffVideoType(head []byte) string {
+	if detected := http.DetectContentType(head); detected == "video/mp4" || detected == "video/webm" {
+		return detected
+	}
+	if len(head) >= 12 && string(head[4:8]) == "ftyp" {
+		if string(head[8:12]) == "qt  " {
+			return "video/quicktime"
+		}
+		return "video/mp4"
+	}
+	return ""
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/Vilamuzz/yota-backend/app/media"
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
 	"github.com/google/uuid"
 )
 
@@ -21,5 +22,7 @@ type News struct {
 	UpdatedAt   time.Time           `json:"updatedAt"`
 	DeletedAt   *time.Time          `json:"deletedAt" gorm:"index"`
 
+	CoverImageVariants s3_pkg.ImageVariants `json:"coverImageVariants" gorm:"type:jsonb"`
+
 	Media []media.Media `gorm:"foreignKey:NewsID"`
 }
@@ -21,6 +21,8 @@ type NewsResponse struct {
 	PublishedAt *time.Time            `json:"publishedAt"`
 	CreatedAt   time.Time             `json:"createdAt"`
 	UpdatedAt   time.Time             `json:"updatedAt"`
+
+	CoverImageSet *s3_pkg.ImageSet `json:"coverImageSet,omitempty"`
 }
 
 type NewsListResponseItem struct {
@@ -33,6 +35,8 @@ type NewsListResponseItem struct {
 	Status      media.MediaStatus   `json:"status"`
 	PublishedAt *time.Time          `json:"publishedAt"`
 	CreatedAt   time.Time           `json:"createdAt"`
+
+	CoverImageSet *s3_pkg.ImageSet `json:"coverImageSet,omitempty"`
 }
 
 type NewsListResponse struct {
@@ -50,6 +54,8 @@ func (n *News) toNewsResponse() NewsResponse {
 			URL:    s3_pkg.GetCDNURL(m.URL),
 			Alt:    m.Alt,
 			Order:  m.Order,
+
+			ImageSet: s3_pkg.NewImageSet(m.Variants),
 		})
 	}
 
@@ -66,6 +72,8 @@ func (n *News) toNewsResponse() NewsResponse {
 		PublishedAt: n.PublishedAt,
 		CreatedAt:   n.CreatedAt,
 		UpdatedAt:   n.UpdatedAt,
+
+		CoverImageSet: s3_pkg.NewImageSet(n.CoverImageVariants),
 	}
 }
 
@@ -80,6 +88,8 @@ func (n *News) toNewsListResponseItem() NewsListResponseItem {
 		Status:      n.Status,
 		PublishedAt: n.PublishedAt,
 		CreatedAt:   n.CreatedAt,
+
+		CoverImageSet: s3_pkg.NewImageSet(n.CoverImageVariants),
 	}
 }
 
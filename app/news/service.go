@@ -187,8 +187,9 @@ func (s *service) CreateNews(ctx context.Context, payload NewsCreateRequest) pkg
 	}
 
 	var coverImageURL string
+	var coverImageVariants s3_pkg.ImageVariants
 	if payload.CoverImage != nil {
-		uploadedURL, err := s.s3Client.UploadFile(ctx, payload.CoverImage, "news")
+		uploadedURL, variants, err := s.s3Client.UploadImage(ctx, payload.CoverImage, "news")
 		if err != nil {
 			logrus.WithFields(logrus.Fields{
 				"component": "news.service",
@@ -197,13 +198,15 @@ func (s *service) CreateNews(ctx context.Context, payload NewsCreateRequest) pkg
 			return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah gambar sampul", nil, nil)
 		}
 		coverImageURL = uploadedURL
+		coverImageVariants = variants
 	}
 
 	var mediaItems []media.Media
 	for i, file := range payload.MediaFiles {
 		var finalURL string
+		var finalVariants s3_pkg.ImageVariants
 		if file != nil {
-			url, err := s.s3Client.UploadFile(ctx, file, "news")
+			url, variants, err := s.s3Client.UploadImage(ctx, file, "news")
 			if err != nil {
 				logrus.WithFields(logrus.Fields{
 					"component": "news.service",
@@ -212,6 +215,7 @@ func (s *service) CreateNews(ctx context.Context, payload NewsCreateRequest) pkg
 				return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah file media", nil, nil)
 			}
 			finalURL = url
+			finalVariants = variants
 		}
 
 		if finalURL != "" {
@@ -221,15 +225,17 @@ func (s *service) CreateNews(ctx context.Context, payload NewsCreateRequest) pkg
 			}
 
 			item := media.Media{
-				ID:    uuid.New(),
-				Type:  media.MediaTypeImage, // Default to image
-				URL:   finalURL,
-				Alt:   alt,
-				Order: i + 1,
+				ID:       uuid.New(),
+				Type:     media.MediaTypeImage, // Default to image
+				URL:      finalURL,
+				Alt:      alt,
+				Order:    i + 1,
+				Variants: finalVariants,
 			}
 
 			if i == 0 && coverImageURL == "" {
 				coverImageURL = finalURL
+				coverImageVariants = finalVariants
 			}
 
 			mediaItems = append(mediaItems, item)
@@ -249,6 +255,8 @@ func (s *service) CreateNews(ctx context.Context, payload NewsCreateRequest) pkg
 		Views:      0,
 		CreatedAt:  timeNow,
 		UpdatedAt:  timeNow,
+
+		CoverImageVariants: coverImageVariants,
 	}
 
 	if status == media.MediaStatusPublished {
@@ -416,7 +424,7 @@ func (s *service) UpdateNews(ctx context.Context, id string, payload NewsUpdateR
 	// 3. Add New Media Files
 	for i, file := range payload.MediaFiles {
 		if file != nil {
-			url, err := s.s3Client.UploadFile(ctx, file, "news")
+			url, variants, err := s.s3Client.UploadImage(ctx, file, "news")
 			if err != nil {
 				logrus.WithFields(logrus.Fields{
 					"component": "news.service",
@@ -437,11 +445,12 @@ func (s *service) UpdateNews(ctx context.Context, id string, payload NewsUpdateR
 
 			newMedia := []media.Media{
 				{
-					ID:    uuid.New(),
-					URL:   url,
-					Type:  media.MediaTypeImage,
-					Alt:   alt,
-					Order: order,
+					ID:       uuid.New(),
+					URL:      url,
+					Type:     media.MediaTypeImage,
+					Alt:      alt,
+					Order:    order,
+					Variants: variants,
 				},
 			}
 
@@ -456,7 +465,7 @@ func (s *service) UpdateNews(ctx context.Context, id string, payload NewsUpdateR
 	}
 
 	if payload.CoverImage != nil {
-		uploadedURL, err := s.s3Client.UploadFile(ctx, payload.CoverImage, "news")
+		uploadedURL, variants, err := s.s3Client.UploadImage(ctx, payload.CoverImage, "news")
 		if err != nil {
 			logrus.WithFields(logrus.Fields{
 				"component": "news.service",
@@ -467,10 +476,11 @@ func (s *service) UpdateNews(ctx context.Context, id string, payload NewsUpdateR
 
 		if existingNews.CoverImage != "" {
 			existingCoverImage := s3_pkg.ExtractObjectNameFromURL(existingNews.CoverImage)
-			_ = s.s3Client.DeleteFile(ctx, existingCoverImage)
+			_ = s.s3Client.DeleteImage(ctx, existingCoverImage, existingNews.CoverImageVariants)
 		}
 
 		updateData["cover_image"] = uploadedURL
+		updateData["cover_image_variants"] = variants
 	}
 
 	if len(updateData) == 0 && len(payload.MediaFiles) == 0 && payload.CoverImage == nil {
@@ -516,7 +526,7 @@ func (s *service) DeleteNews(ctx context.Context, id string) pkg.Response {
 	if existingNews.CoverImage != "" {
 		objectName := s3_pkg.ExtractObjectNameFromURL(existingNews.CoverImage)
 		if objectName != "" {
-			_ = s.s3Client.DeleteFile(ctx, objectName)
+			_ = s.s3Client.DeleteImage(ctx, objectName, existingNews.CoverImageVariants)
 		}
 	}
 
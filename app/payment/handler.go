@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/Vilamuzz/yota-backend/app/ambulance_transaction"
 	"github.com/Vilamuzz/yota-backend/app/donation_program_transaction"
 	"github.com/Vilamuzz/yota-backend/app/foster_children_transaction"
 	"github.com/Vilamuzz/yota-backend/app/social_program_transaction"
@@ -16,13 +17,15 @@ type handler struct {
 	donationService       donation_program_transaction.Service
 	socialService         social_program_transaction.Service
 	fosterChildrenService foster_children_transaction.Service
+	ambulanceService      ambulance_transaction.Service
 }
 
-func NewHandler(r *gin.RouterGroup, ds donation_program_transaction.Service, ss social_program_transaction.Service, fs foster_children_transaction.Service) {
+func NewHandler(r *gin.RouterGroup, ds donation_program_transaction.Service, ss social_program_transaction.Service, fs foster_children_transaction.Service, as ambulance_transaction.Service) {
 	h := &handler{
 		donationService:       ds,
 		socialService:         ss,
 		fosterChildrenService: fs,
+		ambulanceService:      as,
 	}
 	h.RegisterRoutes(r)
 }
@@ -60,6 +63,8 @@ func (h *handler) HandleMidtransNotification(c *gin.Context) {
 		res = h.socialService.HandleNotification(ctx, notification)
 	} else if strings.HasPrefix(notification.OrderID, "FC-") {
 		res = h.fosterChildrenService.HandleNotification(ctx, notification)
+	} else if strings.HasPrefix(notification.OrderID, ambulance_transaction.OrderPrefix) {
+		res = h.ambulanceService.HandleNotification(ctx, notification)
 	} else {
 		c.JSON(http.StatusOK, pkg.NewResponse(http.StatusOK, "Unknown order prefix, notification ignored", nil, nil))
 		return
@@ -0,0 +1,85 @@
+package privacy
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/middleware"
+	"github.com/Vilamuzz/yota-backend/pkg"
+	jwt_pkg "github.com/Vilamuzz/yota-backend/pkg/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	service    Service
+	middleware middleware.AppMiddleware
+}
+
+func NewHandler(r *gin.RouterGroup, s Service, m middleware.AppMiddleware) {
+	handler := &handler{
+		service:    s,
+		middleware: m,
+	}
+	handler.RegisterRoutes(r)
+}
+
+func (h *handler) RegisterRoutes(r *gin.RouterGroup) {
+	me := r.Group("/me")
+	me.Use(h.middleware.AuthRequired())
+	{
+		me.GET("/data-export", h.middleware.CustomRateLimitHandler(3, 1*time.Minute), h.ExportPersonalData)
+		me.POST("/account-deletion", h.middleware.AuthRateLimitHandler(), h.DeleteAccount)
+	}
+}
+
+// ExportPersonalData
+//
+// @Summary Export Personal Data
+// @Description Download a zip archive with all personal data stored for the current account
+// @Tags Account
+// @Security BearerAuth
+// @Produce application/zip
+// @Success 200 {file} binary "ZIP archive"
+// @Failure 404 {object} pkg.Response
+// @Failure 500 {object} pkg.Response
+// @Router /api/me/data-export [get]
+func (h *handler) ExportPersonalData(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	res := h.service.ExportPersonalData(ctx, claims.AccountID)
+	file, ok := res.Data.(DataExportFile)
+	if res.Status != http.StatusOK || !ok {
+		c.JSON(res.Status, res)
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename="+file.Filename)
+	c.Header("Content-Transfer-Encoding", "binary")
+	c.Data(http.StatusOK, "application/zip", file.Content)
+}
+
+// DeleteAccount
+//
+// @Summary Delete Account
+// @Description Permanently erase personal data of the current account. Financial records are kept anonymised
+// @Tags Account
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body DeleteAccountRequest true "Confirmation"
+// @Success 200 {object} pkg.Response
+// @Router /api/me/account-deletion [post]
+func (h *handler) DeleteAccount(c *gin.Context) {
+	ctx := c.Request.Context()
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+
+	var req DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, pkg.NewResponse(http.StatusBadRequest, "Permintaan tidak valid", nil, nil))
+		return
+	}
+
+	res := h.service.DeleteAccount(ctx, claims.AccountID, req)
+	c.JSON(res.Status, res)
+}
@@ -0,0 +1,23 @@
+package privacy
+
+import "github.com/google/uuid"
+
+// Placeholder donor identity written over erased accounts' transactions. It matches the
+// values used for anonymous checkouts so reports treat both the same way.
+const (
+	AnonymousDonorName  = "anonymous"
+	AnonymousDonorEmail = "anonymous@example.com"
+)
+
+// AnonymizeAccountInput describes the replacement values written by AnonymizeAccount.
+type AnonymizeAccountInput struct {
+	AccountID uuid.UUID
+	Email     string
+	Username  string
+}
+
+// PrayerAmenRow is a prayer the account said amen to.
+type PrayerAmenRow struct {
+	PrayerID      uuid.UUID
+	PrayerContent string
+}
@@ -0,0 +1,382 @@
+package privacy
+
+import (
+	"context"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/access_grant"
+	"github.com/Vilamuzz/yota-backend/app/account"
+	"github.com/Vilamuzz/yota-backend/app/ambulance_history"
+	"github.com/Vilamuzz/yota-backend/app/ambulance_service_request"
+	"github.com/Vilamuzz/yota-backend/app/ambulance_transaction"
+	"github.com/Vilamuzz/yota-backend/app/auth"
+	"github.com/Vilamuzz/yota-backend/app/donation_program_transaction"
+	"github.com/Vilamuzz/yota-backend/app/foster_children_sponsorship"
+	"github.com/Vilamuzz/yota-backend/app/foster_children_transaction"
+	"github.com/Vilamuzz/yota-backend/app/news_comment"
+	"github.com/Vilamuzz/yota-backend/app/prayer"
+	"github.com/Vilamuzz/yota-backend/app/social_program_subscription"
+	"github.com/Vilamuzz/yota-backend/app/social_program_transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	FindDonationProgramTransactions(ctx context.Context, accountID string) ([]donation_program_transaction.DonationProgramTransaction, error)
+	FindFosterChildrenTransactions(ctx context.Context, accountID string) ([]foster_children_transaction.FosterChildrenTransaction, error)
+	FindSocialProgramTransactions(ctx context.Context, accountID string) ([]social_program_transaction.SocialProgramTransaction, error)
+	FindAmbulanceTransactions(ctx context.Context, accountID string) ([]ambulance_transaction.AmbulanceTransaction, error)
+	FindPrayers(ctx context.Context, accountID string) ([]prayer.Prayer, error)
+	FindPrayerAmens(ctx context.Context, accountID string) ([]PrayerAmenRow, error)
+	FindNewsComments(ctx context.Context, accountID string) ([]news_comment.NewsComment, error)
+	FindSocialProgramSubscriptions(ctx context.Context, accountID string) ([]social_program_subscription.SocialProgramSubscription, error)
+	FindFosterChildrenSponsorships(ctx context.Context, accountID string) ([]foster_children_sponsorship.FosterChildrenSponsorship, error)
+	FindAmbulanceServiceRequests(ctx context.Context, accountID string) ([]ambulance_service_request.AmbulanceServiceRequest, error)
+	FindTripFeedback(ctx context.Context, accountID string) ([]ambulance_history.TripFeedback, error)
+	CountOpenAmbulanceServiceRequests(ctx context.Context, accountID string) (int64, error)
+	AnonymizeAccount(ctx context.Context, input AnonymizeAccountInput) error
+}
+
+type repository struct {
+	Conn *gorm.DB
+}
+
+func NewRepository(conn *gorm.DB) Repository {
+	return &repository{Conn: conn}
+}
+
+func (r *repository) FindDonationProgramTransactions(ctx context.Context, accountID string) ([]donation_program_transaction.DonationProgramTransaction, error) {
+	var transactions []donation_program_transaction.DonationProgramTransaction
+	if err := r.Conn.WithContext(ctx).
+		Preload("DonationProgram").
+		Where("account_id = ?", accountID).
+		Order("created_at ASC").
+		Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+func (r *repository) FindFosterChildrenTransactions(ctx context.Context, accountID string) ([]foster_children_transaction.FosterChildrenTransaction, error) {
+	var transactions []foster_children_transaction.FosterChildrenTransaction
+	if err := r.Conn.WithContext(ctx).
+		Preload("FosterChildren").
+		Where("account_id = ?", accountID).
+		Order("created_at ASC").
+		Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+func (r *repository) FindSocialProgramTransactions(ctx context.Context, accountID string) ([]social_program_transaction.SocialProgramTransaction, error) {
+	var transactions []social_program_transaction.SocialProgramTransaction
+	if err := r.Conn.WithContext(ctx).
+		Preload("SocialProgramInvoice").
+		Where("account_id = ?", accountID).
+		Order("created_at ASC").
+		Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+func (r *repository) FindAmbulanceTransactions(ctx context.Context, accountID string) ([]ambulance_transaction.AmbulanceTransaction, error) {
+	var transactions []ambulance_transaction.AmbulanceTransaction
+	if err := r.Conn.WithContext(ctx).
+		Preload("Ambulance").
+		Where("account_id = ?", accountID).
+		Order("created_at ASC").
+		Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+func (r *repository) FindPrayers(ctx context.Context, accountID string) ([]prayer.Prayer, error) {
+	var prayers []prayer.Prayer
+	if err := r.Conn.WithContext(ctx).
+		Joins("JOIN donation_program_transactions ON donation_program_transactions.id = prayers.donation_program_transaction_id").
+		Where("donation_program_transactions.account_id = ?", accountID).
+		Order("prayers.created_at ASC").
+		Find(&prayers).Error; err != nil {
+		return nil, err
+	}
+
+	return prayers, nil
+}
+
+func (r *repository) FindPrayerAmens(ctx context.Context, accountID string) ([]PrayerAmenRow, error) {
+	var rows []PrayerAmenRow
+	if err := r.Conn.WithContext(ctx).
+		Table("prayer_amens").
+		Select("prayer_amens.prayer_id, prayers.content AS prayer_content").
+		Joins("JOIN prayers ON prayers.id = prayer_amens.prayer_id").
+		Where("prayer_amens.account_id = ?", accountID).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *repository) FindNewsComments(ctx context.Context, accountID string) ([]news_comment.NewsComment, error) {
+	var comments []news_comment.NewsComment
+	if err := r.Conn.WithContext(ctx).
+		Preload("News").
+		Where("account_id = ?", accountID).
+		Order("created_at ASC").
+		Find(&comments).Error; err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (r *repository) FindSocialProgramSubscriptions(ctx context.Context, accountID string) ([]social_program_subscription.SocialProgramSubscription, error) {
+	var subscriptions []social_program_subscription.SocialProgramSubscription
+	if err := r.Conn.WithContext(ctx).
+		Preload("SocialProgram").
+		Where("account_id = ?", accountID).
+		Order("created_at ASC").
+		Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (r *repository) FindFosterChildrenSponsorships(ctx context.Context, accountID string) ([]foster_children_sponsorship.FosterChildrenSponsorship, error) {
+	var sponsorships []foster_children_sponsorship.FosterChildrenSponsorship
+	if err := r.Conn.WithContext(ctx).
+		Preload("FosterChildren").
+		Where("account_id = ?", accountID).
+		Order("created_at ASC").
+		Find(&sponsorships).Error; err != nil {
+		return nil, err
+	}
+
+	return sponsorships, nil
+}
+
+func (r *repository) FindAmbulanceServiceRequests(ctx context.Context, accountID string) ([]ambulance_service_request.AmbulanceServiceRequest, error) {
+	var requests []ambulance_service_request.AmbulanceServiceRequest
+	if err := r.Conn.WithContext(ctx).
+		Where("submitted_by = ?", accountID).
+		Order("created_at ASC").
+		Find(&requests).Error; err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+func (r *repository) CountOpenAmbulanceServiceRequests(ctx context.Context, accountID string) (int64, error) {
+	var count int64
+	if err := r.Conn.WithContext(ctx).
+		Model(&ambulance_service_request.AmbulanceServiceRequest{}).
+		Where("submitted_by = ? AND status IN ?", accountID, []ambulance_service_request.Status{
+			ambulance_service_request.StatusAccepted,
+			ambulance_service_request.StatusInService,
+		}).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *repository) FindTripFeedback(ctx context.Context, accountID string) ([]ambulance_history.TripFeedback, error) {
+	var feedback []ambulance_history.TripFeedback
+	if err := r.Conn.WithContext(ctx).
+		Where("account_id = ?", accountID).
+		Order("created_at ASC").
+		Find(&feedback).Error; err != nil {
+		return nil, err
+	}
+
+	return feedback, nil
+}
+
+// AnonymizeAccount erases personal data in one transaction. Transaction amounts, dates
+// and statuses are kept for accounting; everything identifying the person is removed.
+func (r *repository) AnonymizeAccount(ctx context.Context, input AnonymizeAccountInput) error {
+	accountID := input.AccountID
+	now := time.Now()
+
+	return r.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Financial records: detach from the account and drop donor identity.
+		donorUpdates := map[string]interface{}{
+			"account_id":  nil,
+			"donor_name":  AnonymousDonorName,
+			"donor_email": AnonymousDonorEmail,
+		}
+		if err := tx.Model(&donation_program_transaction.DonationProgramTransaction{}).
+			Where("account_id = ?", accountID).Updates(donorUpdates).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&foster_children_transaction.FosterChildrenTransaction{}).
+			Where("account_id = ?", accountID).Updates(donorUpdates).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&ambulance_transaction.AmbulanceTransaction{}).
+			Where("account_id = ?", accountID).Updates(donorUpdates).Error; err != nil {
+			return err
+		}
+		// Social program transactions require an account; they stay linked to the
+		// anonymised account row, which is why the row itself is never removed.
+		if err := tx.Model(&social_program_subscription.SocialProgramSubscription{}).
+			Where("account_id = ?", accountID).
+			Update("status", social_program_subscription.StatusInactive).Error; err != nil {
+			return err
+		}
+		// Open sponsorships would keep holding a sponsor slot for an unreachable account.
+		if err := tx.Model(&foster_children_sponsorship.FosterChildrenSponsorship{}).
+			Where("account_id = ? AND status = ?", accountID, foster_children_sponsorship.StatusActive).
+			Updates(map[string]interface{}{
+				"status":     foster_children_sponsorship.StatusEnded,
+				"end_reason": "Akun orang tua asuh dihapus",
+				"end_date":   now,
+			}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&foster_children_sponsorship.FosterChildrenSponsorship{}).
+			Where("account_id = ? AND status = ?", accountID, foster_children_sponsorship.StatusPending).
+			Updates(map[string]interface{}{
+				"status":     foster_children_sponsorship.StatusCancelled,
+				"end_reason": "Akun orang tua asuh dihapus",
+			}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&foster_children_sponsorship.FosterChildrenSponsorship{}).
+			Where("account_id = ?", accountID).
+			Update("note", "").Error; err != nil {
+			return err
+		}
+
+		// Prayers are free text written by the donor, so they are removed entirely.
+		ownPrayerIDs := tx.Model(&prayer.Prayer{}).Select("prayers.id").
+			Joins("JOIN donation_program_transactions ON donation_program_transactions.id = prayers.donation_program_transaction_id").
+			Where("donation_program_transactions.account_id = ?", accountID)
+		if err := tx.Where("prayer_id IN (?)", ownPrayerIDs).Delete(&prayer.PrayerAmen{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("prayer_id IN (?)", ownPrayerIDs).Delete(&prayer.PrayerReport{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id IN (?)", ownPrayerIDs).Delete(&prayer.Prayer{}).Error; err != nil {
+			return err
+		}
+
+		// Amens and reports given by the account, keeping the counters consistent.
+		if err := tx.Model(&prayer.Prayer{}).
+			Where("id IN (SELECT prayer_id FROM prayer_amens WHERE account_id = ?)", accountID).
+			Update("amen_count", gorm.Expr("GREATEST(amen_count - 1, 0)")).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("account_id = ?", accountID).Delete(&prayer.PrayerAmen{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&prayer.Prayer{}).
+			Where("id IN (SELECT prayer_id FROM prayer_reports WHERE account_id = ?)", accountID).
+			Update("report_count", gorm.Expr("GREATEST(report_count - 1, 0)")).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("account_id = ?", accountID).Delete(&prayer.PrayerReport{}).Error; err != nil {
+			return err
+		}
+
+		// Comments are blanked and soft-deleted so reply threads keep their structure.
+		if err := tx.Model(&news_comment.NewsComment{}).
+			Where("account_id = ?", accountID).
+			Updates(map[string]interface{}{"content": "", "deleted_at": now}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("account_id = ?", accountID).Delete(&news_comment.NewsCommentReport{}).Error; err != nil {
+			return err
+		}
+
+		// Ambulance requests keep schedule and category for operational statistics.
+		if err := tx.Model(&ambulance_service_request.AmbulanceServiceRequest{}).
+			Where("submitted_by = ? AND status = ?", accountID, ambulance_service_request.StatusPending).
+			Updates(map[string]interface{}{
+				"status":             ambulance_service_request.StatusCancelled,
+				"cancelation_reason": "Akun pemohon dihapus",
+			}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&ambulance_service_request.AmbulanceServiceRequest{}).
+			Where("submitted_by = ?", accountID).
+			Updates(map[string]interface{}{
+				"submitter_name":    "",
+				"submitter_phone":   "",
+				"submitter_id_card": "",
+				"patient_name":      "",
+				"patient_address":   "",
+				"disease":           "",
+				"destination":       "",
+				"note":              "",
+			}).Error; err != nil {
+			return err
+		}
+		// Ratings stay in the driver and vehicle averages; the free-text comment goes.
+		if err := tx.Model(&ambulance_history.TripFeedback{}).
+			Where("account_id = ?", accountID).
+			Update("comment", "").Error; err != nil {
+			return err
+		}
+		// GPS tracks reveal the pickup address, so they go with the rest of the PII.
+		ownRequestIDs := tx.Model(&ambulance_service_request.AmbulanceServiceRequest{}).
+			Select("id").
+			Where("submitted_by = ?", accountID)
+		if err := tx.Where("request_id IN (?)", ownRequestIDs).Delete(&ambulance_service_request.TripLocation{}).Error; err != nil {
+			return err
+		}
+
+		// Credentials, pending verifications and linked identities.
+		for _, model := range []interface{}{
+			&auth.PasswordResetToken{},
+			&auth.EmailVerificationToken{},
+			&auth.LoginToken{},
+			&auth.AccountIdentity{},
+			&auth.IdentityLinkToken{},
+			&account.EmailChangeToken{},
+			&account.PhoneVerificationCode{},
+			&access_grant.AccessGrant{},
+		} {
+			if err := tx.Where("account_id = ?", accountID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&account.AccountRole{}).
+			Where("account_id = ?", accountID).
+			Updates(map[string]interface{}{"is_active": false, "is_default": false}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&account.UserProfile{}).
+			Where("account_id = ?", accountID).
+			Updates(map[string]interface{}{
+				"username":        input.Username,
+				"phone":           nil,
+				"phone_verified":  false,
+				"address":         "",
+				"profile_picture": "",
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&account.Account{}).
+			Where("id = ?", accountID).
+			Updates(map[string]interface{}{
+				"email":          input.Email,
+				"password":       "",
+				"email_verified": false,
+				"is_banned":      true,
+				"deleted_at":     now,
+			}).Error
+	})
+}
@@ -0,0 +1,6 @@
+package privacy
+
+type DeleteAccountRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	Confirmation    string `json:"confirmation"`
+}
@@ -0,0 +1,350 @@
+package privacy
+
+import (
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/account"
+	"github.com/Vilamuzz/yota-backend/app/ambulance_history"
+	"github.com/Vilamuzz/yota-backend/app/ambulance_service_request"
+	"github.com/Vilamuzz/yota-backend/app/ambulance_transaction"
+	"github.com/Vilamuzz/yota-backend/app/donation_program_transaction"
+	"github.com/Vilamuzz/yota-backend/app/foster_children_sponsorship"
+	"github.com/Vilamuzz/yota-backend/app/foster_children_transaction"
+	"github.com/Vilamuzz/yota-backend/app/news_comment"
+	"github.com/Vilamuzz/yota-backend/app/prayer"
+	"github.com/Vilamuzz/yota-backend/app/social_program_subscription"
+	"github.com/Vilamuzz/yota-backend/app/social_program_transaction"
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
+)
+
+// DataExportFile is the zip archive produced by a personal data export.
+type DataExportFile struct {
+	Filename string
+	Content  []byte
+}
+
+type ProfileExport struct {
+	ID             string    `json:"id"`
+	Email          string    `json:"email"`
+	EmailVerified  bool      `json:"emailVerified"`
+	Username       string    `json:"username"`
+	Phone          string    `json:"phone"`
+	PhoneVerified  bool      `json:"phoneVerified"`
+	Address        string    `json:"address"`
+	ProfilePicture string    `json:"profilePicture"`
+	Roles          []string  `json:"roles"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type TransactionExport struct {
+	ID                string     `json:"id"`
+	OrderID           string     `json:"orderId"`
+	Target            string     `json:"target"`
+	DonorName         string     `json:"donorName,omitempty"`
+	DonorEmail        string     `json:"donorEmail,omitempty"`
+	GrossAmount       float64    `json:"grossAmount"`
+	TransactionStatus string     `json:"transactionStatus"`
+	IsOnline          bool       `json:"isOnline"`
+	PaidAt            *time.Time `json:"paidAt"`
+	CreatedAt         time.Time  `json:"createdAt"`
+}
+
+type PrayerExport struct {
+	ID          string    `json:"id"`
+	Content     string    `json:"content"`
+	IsPublished bool      `json:"isPublished"`
+	AmenCount   int64     `json:"amenCount"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type AmenExport struct {
+	PrayerID      string `json:"prayerId"`
+	PrayerContent string `json:"prayerContent"`
+}
+
+type NewsCommentExport struct {
+	ID        string     `json:"id"`
+	NewsTitle string     `json:"newsTitle"`
+	Content   string     `json:"content"`
+	CreatedAt time.Time  `json:"createdAt"`
+	DeletedAt *time.Time `json:"deletedAt"`
+}
+
+type SubscriptionExport struct {
+	ID               string    `json:"id"`
+	SocialProgram    string    `json:"socialProgram"`
+	Status           string    `json:"status"`
+	TotalPaidPeriods int       `json:"totalPaidPeriods"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+type SponsorshipExport struct {
+	ID                string     `json:"id"`
+	FosterChildren    string     `json:"fosterChildren"`
+	MonthlyCommitment float64    `json:"monthlyCommitment"`
+	Status            string     `json:"status"`
+	Note              string     `json:"note"`
+	StartDate         *time.Time `json:"startDate"`
+	EndDate           *time.Time `json:"endDate"`
+	EndReason         string     `json:"endReason"`
+	CreatedAt         time.Time  `json:"createdAt"`
+}
+
+type AmbulanceServiceRequestExport struct {
+	ID              string    `json:"id"`
+	SubmitterName   string    `json:"submitterName"`
+	SubmitterPhone  string    `json:"submitterPhone"`
+	SubmitterIDCard string    `json:"submitterIdCard"`
+	PatientName     string    `json:"patientName"`
+	PatientAddress  string    `json:"patientAddress"`
+	PatientAge      int       `json:"patientAge"`
+	Disease         string    `json:"disease"`
+	PickupDate      time.Time `json:"pickupDate"`
+	PickupTime      time.Time `json:"pickupTime"`
+	Destination     string    `json:"destination"`
+	ServiceCategory string    `json:"serviceCategory"`
+	Status          string    `json:"status"`
+	Note            string    `json:"note"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+type TripFeedbackExport struct {
+	ID            string    `json:"id"`
+	RequestID     string    `json:"requestId"`
+	DriverRating  int       `json:"driverRating"`
+	VehicleRating int       `json:"vehicleRating"`
+	Comment       string    `json:"comment"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+func toProfileExport(a *account.Account) ProfileExport {
+	phone := ""
+	if a.UserProfile.Phone != nil {
+		phone = *a.UserProfile.Phone
+	}
+	roles := make([]string, 0, len(a.AccountRoles))
+	for _, role := range a.AccountRoles {
+		roles = append(roles, string(role.Role.Name))
+	}
+
+	return ProfileExport{
+		ID:             a.ID.String(),
+		Email:          a.Email,
+		EmailVerified:  a.EmailVerified,
+		Username:       a.UserProfile.Username,
+		Phone:          phone,
+		PhoneVerified:  a.UserProfile.PhoneVerified,
+		Address:        a.UserProfile.Address,
+		ProfilePicture: s3_pkg.GetCDNURL(a.UserProfile.ProfilePicture),
+		Roles:          roles,
+		CreatedAt:      a.CreatedAt,
+	}
+}
+
+func toDonationProgramTransactionExports(transactions []donation_program_transaction.DonationProgramTransaction) []TransactionExport {
+	res := make([]TransactionExport, 0, len(transactions))
+	for _, t := range transactions {
+		target := ""
+		if t.DonationProgram != nil {
+			target = t.DonationProgram.Title
+		}
+		res = append(res, TransactionExport{
+			ID:                t.ID.String(),
+			OrderID:           t.OrderID,
+			Target:            target,
+			DonorName:         t.DonorName,
+			DonorEmail:        t.DonorEmail,
+			GrossAmount:       t.GrossAmount,
+			TransactionStatus: t.TransactionStatus,
+			IsOnline:          t.IsOnline,
+			PaidAt:            t.PaidAt,
+			CreatedAt:         t.CreatedAt,
+		})
+	}
+	return res
+}
+
+func toFosterChildrenTransactionExports(transactions []foster_children_transaction.FosterChildrenTransaction) []TransactionExport {
+	res := make([]TransactionExport, 0, len(transactions))
+	for _, t := range transactions {
+		target := ""
+		if t.FosterChildren != nil {
+			target = t.FosterChildren.Name
+		}
+		res = append(res, TransactionExport{
+			ID:                t.ID.String(),
+			OrderID:           t.OrderID,
+			Target:            target,
+			DonorName:         t.DonorName,
+			DonorEmail:        t.DonorEmail,
+			GrossAmount:       t.GrossAmount,
+			TransactionStatus: t.TransactionStatus,
+			IsOnline:          t.IsOnline,
+			PaidAt:            t.PaidAt,
+			CreatedAt:         t.CreatedAt,
+		})
+	}
+	return res
+}
+
+func toSocialProgramTransactionExports(transactions []social_program_transaction.SocialProgramTransaction) []TransactionExport {
+	res := make([]TransactionExport, 0, len(transactions))
+	for _, t := range transactions {
+		target := ""
+		if t.SocialProgramInvoice != nil {
+			target = t.SocialProgramInvoice.BillingPeriod.Format("2006-01")
+		}
+		res = append(res, TransactionExport{
+			ID:                t.ID.String(),
+			OrderID:           t.OrderID,
+			Target:            target,
+			GrossAmount:       t.GrossAmount,
+			TransactionStatus: t.TransactionStatus,
+			IsOnline:          t.IsOnline,
+			PaidAt:            t.PaidAt,
+			CreatedAt:         t.CreatedAt,
+		})
+	}
+	return res
+}
+
+func toAmbulanceTransactionExports(transactions []ambulance_transaction.AmbulanceTransaction) []TransactionExport {
+	res := make([]TransactionExport, 0, len(transactions))
+	for _, t := range transactions {
+		target := ""
+		if t.Ambulance != nil {
+			target = t.Ambulance.PlateNumber
+		}
+		res = append(res, TransactionExport{
+			ID:                t.ID.String(),
+			OrderID:           t.OrderID,
+			Target:            target,
+			DonorName:         t.DonorName,
+			DonorEmail:        t.DonorEmail,
+			GrossAmount:       t.GrossAmount,
+			TransactionStatus: t.TransactionStatus,
+			IsOnline:          t.IsOnline,
+			PaidAt:            t.PaidAt,
+			CreatedAt:         t.CreatedAt,
+		})
+	}
+	return res
+}
+
+func toPrayerExports(prayers []prayer.Prayer) []PrayerExport {
+	res := make([]PrayerExport, 0, len(prayers))
+	for _, p := range prayers {
+		res = append(res, PrayerExport{
+			ID:          p.ID.String(),
+			Content:     p.Content,
+			IsPublished: p.IsPublished,
+			AmenCount:   p.AmenCount,
+			CreatedAt:   p.CreatedAt,
+		})
+	}
+	return res
+}
+
+func toAmenExports(rows []PrayerAmenRow) []AmenExport {
+	res := make([]AmenExport, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, AmenExport{
+			PrayerID:      row.PrayerID.String(),
+			PrayerContent: row.PrayerContent,
+		})
+	}
+	return res
+}
+
+func toNewsCommentExports(comments []news_comment.NewsComment) []NewsCommentExport {
+	res := make([]NewsCommentExport, 0, len(comments))
+	for _, c := range comments {
+		res = append(res, NewsCommentExport{
+			ID:        c.ID.String(),
+			NewsTitle: c.News.Title,
+			Content:   c.Content,
+			CreatedAt: c.CreatedAt,
+			DeletedAt: c.DeletedAt,
+		})
+	}
+	return res
+}
+
+func toSubscriptionExports(subscriptions []social_program_subscription.SocialProgramSubscription) []SubscriptionExport {
+	res := make([]SubscriptionExport, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		program := ""
+		if sub.SocialProgram != nil {
+			program = sub.SocialProgram.Title
+		}
+		res = append(res, SubscriptionExport{
+			ID:               sub.ID.String(),
+			SocialProgram:    program,
+			Status:           string(sub.Status),
+			TotalPaidPeriods: sub.TotalPaidPeriods,
+			CreatedAt:        sub.CreatedAt,
+		})
+	}
+	return res
+}
+
+func toSponsorshipExports(sponsorships []foster_children_sponsorship.FosterChildrenSponsorship) []SponsorshipExport {
+	res := make([]SponsorshipExport, 0, len(sponsorships))
+	for _, sp := range sponsorships {
+		child := ""
+		if sp.FosterChildren != nil {
+			child = sp.FosterChildren.Name
+		}
+		res = append(res, SponsorshipExport{
+			ID:                sp.ID.String(),
+			FosterChildren:    child,
+			MonthlyCommitment: sp.MonthlyCommitment,
+			Status:            string(sp.Status),
+			Note:              sp.Note,
+			StartDate:         sp.StartDate,
+			EndDate:           sp.EndDate,
+			EndReason:         sp.EndReason,
+			CreatedAt:         sp.CreatedAt,
+		})
+	}
+	return res
+}
+
+func toAmbulanceServiceRequestExports(requests []ambulance_service_request.AmbulanceServiceRequest) []AmbulanceServiceRequestExport {
+	res := make([]AmbulanceServiceRequestExport, 0, len(requests))
+	for _, r := range requests {
+		res = append(res, AmbulanceServiceRequestExport{
+			ID:              r.ID.String(),
+			SubmitterName:   r.SubmitterName,
+			SubmitterPhone:  string(r.SubmitterPhone),
+			SubmitterIDCard: s3_pkg.GetCDNURL(r.SubmitterIDCard),
+			PatientName:     r.PatientName,
+			PatientAddress:  string(r.PatientAddress),
+			PatientAge:      r.PatientAge,
+			Disease:         string(r.Disease),
+			PickupDate:      r.PickupDate,
+			PickupTime:      r.PickupTime,
+			Destination:     r.Destination,
+			ServiceCategory: string(r.ServiceCategory),
+			Status:          string(r.Status),
+			Note:            r.Note,
+			CreatedAt:       r.CreatedAt,
+		})
+	}
+	return res
+}
+
+func toTripFeedbackExports(feedback []ambulance_history.TripFeedback) []TripFeedbackExport {
+	res := make([]TripFeedbackExport, 0, len(feedback))
+	for _, f := range feedback {
+		res = append(res, TripFeedbackExport{
+			ID:            f.ID.String(),
+			RequestID:     f.RequestID.String(),
+			DriverRating:  f.DriverRating,
+			VehicleRating: f.VehicleRating,
+			Comment:       f.Comment,
+			CreatedAt:     f.CreatedAt,
+		})
+	}
+	return res
+}
@@ -0,0 +1,292 @@
+package privacy
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/account"
+	app_log "github.com/Vilamuzz/yota-backend/app/log"
+	"github.com/Vilamuzz/yota-backend/pkg"
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// DeleteAccountConfirmation must be typed by the user to confirm account deletion.
+const DeleteAccountConfirmation = "HAPUS AKUN"
+
+type Service interface {
+	ExportPersonalData(ctx context.Context, accountID string) pkg.Response
+	DeleteAccount(ctx context.Context, accountID string, payload DeleteAccountRequest) pkg.Response
+}
+
+type service struct {
+	repo        Repository
+	accountRepo account.Repository
+	logService  app_log.Service
+	s3Client    s3_pkg.Client
+	timeout     time.Duration
+
+	privateStorage s3_pkg.PrivateClient
+}
+
+func NewService(r Repository, accountRepo account.Repository, logService app_log.Service, s3Client s3_pkg.Client, privateStorage s3_pkg.PrivateClient, timeout time.Duration) Service {
+	return &service{
+		repo:           r,
+		accountRepo:    accountRepo,
+		logService:     logService,
+		s3Client:       s3Client,
+		timeout:        timeout,
+		privateStorage: privateStorage,
+	}
+}
+
+// ExportPersonalData bundles everything stored about the account into a zip of JSON files.
+// On success the response data is a DataExportFile for the handler to stream.
+func (s *service) ExportPersonalData(ctx context.Context, accountID string) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	acc, err := s.accountRepo.FindOneAccount(ctx, map[string]interface{}{"id": accountID})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Akun tidak ditemukan", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "privacy.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to retrieve account for data export")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+	if acc.DeletedAt != nil {
+		return pkg.NewResponse(http.StatusNotFound, "Akun tidak ditemukan", nil, nil)
+	}
+
+	donationTransactions, err := s.repo.FindDonationProgramTransactions(ctx, accountID)
+	if err != nil {
+		return s.exportError(accountID, "donation program transactions", err)
+	}
+	fosterTransactions, err := s.repo.FindFosterChildrenTransactions(ctx, accountID)
+	if err != nil {
+		return s.exportError(accountID, "foster children transactions", err)
+	}
+	socialTransactions, err := s.repo.FindSocialProgramTransactions(ctx, accountID)
+	if err != nil {
+		return s.exportError(accountID, "social program transactions", err)
+	}
+	ambulanceTransactions, err := s.repo.FindAmbulanceTransactions(ctx, accountID)
+	if err != nil {
+		return s.exportError(accountID, "ambulance transactions", err)
+	}
+	prayers, err := s.repo.FindPrayers(ctx, accountID)
+	if err != nil {
+		return s.exportError(accountID, "prayers", err)
+	}
+	amens, err := s.repo.FindPrayerAmens(ctx, accountID)
+	if err != nil {
+		return s.exportError(accountID, "prayer amens", err)
+	}
+	comments, err := s.repo.FindNewsComments(ctx, accountID)
+	if err != nil {
+		return s.exportError(accountID, "news comments", err)
+	}
+	subscriptions, err := s.repo.FindSocialProgramSubscriptions(ctx, accountID)
+	if err != nil {
+		return s.exportError(accountID, "social program subscriptions", err)
+	}
+	sponsorships, err := s.repo.FindFosterChildrenSponsorships(ctx, accountID)
+	if err != nil {
+		return s.exportError(accountID, "foster children sponsorships", err)
+	}
+	ambulanceRequests, err := s.repo.FindAmbulanceServiceRequests(ctx, accountID)
+	if err != nil {
+		return s.exportError(accountID, "ambulance service requests", err)
+	}
+	tripFeedback, err := s.repo.FindTripFeedback(ctx, accountID)
+	if err != nil {
+		return s.exportError(accountID, "trip feedback", err)
+	}
+
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", toProfileExport(acc)},
+		{"donation_program_transactions.json", toDonationProgramTransactionExports(donationTransactions)},
+		{"foster_children_transactions.json", toFosterChildrenTransactionExports(fosterTransactions)},
+		{"social_program_transactions.json", toSocialProgramTransactionExports(socialTransactions)},
+		{"ambulance_transactions.json", toAmbulanceTransactionExports(ambulanceTransactions)},
+		{"prayers.json", toPrayerExports(prayers)},
+		{"prayer_amens.json", toAmenExports(amens)},
+		{"news_comments.json", toNewsCommentExports(comments)},
+		{"social_program_subscriptions.json", toSubscriptionExports(subscriptions)},
+		{"foster_children_sponsorships.json", toSponsorshipExports(sponsorships)},
+		{"ambulance_service_requests.json", toAmbulanceServiceRequestExports(ambulanceRequests)},
+		{"trip_feedback.json", toTripFeedbackExports(tripFeedback)},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return s.archiveError(accountID, err)
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(f.data); err != nil {
+			return s.archiveError(accountID, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return s.archiveError(accountID, err)
+	}
+
+	return pkg.NewResponse(http.StatusOK, "Berhasil", nil, DataExportFile{
+		Filename: fmt.Sprintf("data_pribadi_%s_%s.zip", acc.UserProfile.Username, time.Now().Format("20060102")),
+		Content:  buf.Bytes(),
+	})
+}
+
+func (s *service) exportError(accountID, section string, err error) pkg.Response {
+	logrus.WithFields(logrus.Fields{
+		"component":  "privacy.service",
+		"account_id": accountID,
+		"section":    section,
+	}).WithError(err).Error("failed to collect data for export")
+	return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data untuk ekspor", nil, nil)
+}
+
+func (s *service) archiveError(accountID string, err error) pkg.Response {
+	logrus.WithFields(logrus.Fields{
+		"component":  "privacy.service",
+		"account_id": accountID,
+	}).WithError(err).Error("failed to write data export archive")
+	return pkg.NewResponse(http.StatusInternalServerError, "Gagal membuat arsip data", nil, nil)
+}
+
+// DeleteAccount anonymises the account instead of removing the row, so donation and
+// subscription records keep their amounts for accounting without pointing to a person.
+func (s *service) DeleteAccount(ctx context.Context, accountID string, payload DeleteAccountRequest) pkg.Response {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	acc, err := s.accountRepo.FindOneAccount(ctx, map[string]interface{}{"id": accountID})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewResponse(http.StatusNotFound, "Akun tidak ditemukan", nil, nil)
+		}
+		logrus.WithFields(logrus.Fields{
+			"component":  "privacy.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to retrieve account for deletion")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+	if acc.DeletedAt != nil {
+		return pkg.NewResponse(http.StatusNotFound, "Akun tidak ditemukan", nil, nil)
+	}
+
+	errValidation := make(map[string]string)
+	if strings.TrimSpace(payload.Confirmation) != DeleteAccountConfirmation {
+		errValidation["confirmation"] = fmt.Sprintf("Ketik \"%s\" untuk mengonfirmasi", DeleteAccountConfirmation)
+	}
+	if acc.Password != "" {
+		if payload.CurrentPassword == "" {
+			errValidation["currentPassword"] = "Kata sandi saat ini wajib diisi"
+		} else if err := bcrypt.CompareHashAndPassword([]byte(acc.Password), []byte(payload.CurrentPassword)); err != nil {
+			errValidation["currentPassword"] = "Kata sandi saat ini salah"
+		}
+	}
+	if len(errValidation) > 0 {
+		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", errValidation, nil)
+	}
+
+	// Staff accounts are managed by the superadmin; self-service deletion is for donors.
+	for _, role := range acc.AccountRoles {
+		if role.IsActive && role.RoleID != account.OrangTuaAsuhRoleID {
+			return pkg.NewResponse(http.StatusForbidden, "Akun pengurus tidak dapat dihapus secara mandiri", nil, nil)
+		}
+	}
+
+	openRequests, err := s.repo.CountOpenAmbulanceServiceRequests(ctx, accountID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "privacy.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to count open ambulance requests")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+	if openRequests > 0 {
+		return pkg.NewResponse(http.StatusConflict, "Masih ada permintaan ambulans yang sedang berjalan", nil, nil)
+	}
+
+	// Collect stored files before their references are cleared. ID cards live in the private
+	// bucket; its client also removes those not yet migrated out of the public one.
+	var profilePicture string
+	if acc.UserProfile.ProfilePicture != "" {
+		profilePicture = s3_pkg.ExtractObjectNameFromURL(acc.UserProfile.ProfilePicture)
+	}
+	var documents []string
+	ambulanceRequests, err := s.repo.FindAmbulanceServiceRequests(ctx, accountID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "privacy.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to fetch ambulance requests for deletion")
+		return pkg.NewResponse(http.StatusInternalServerError, "Terjadi kesalahan pada server", nil, nil)
+	}
+	for _, req := range ambulanceRequests {
+		if req.SubmitterIDCard != "" {
+			documents = append(documents, req.SubmitterIDCard)
+		}
+	}
+
+	// ID card scans are removed before the account is anonymised: once their references are
+	// cleared a failed delete could never be retried.
+	for _, document := range documents {
+		if err := s.privateStorage.DeleteFile(ctx, document); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":  "privacy.service",
+				"account_id": accountID,
+				"object":     document,
+			}).WithError(err).Error("failed to delete personal document from storage")
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal menghapus dokumen pribadi", nil, nil)
+		}
+	}
+
+	shortID := strings.ReplaceAll(acc.ID.String(), "-", "")[:12]
+	input := AnonymizeAccountInput{
+		AccountID: acc.ID,
+		Email:     fmt.Sprintf("deleted-%s@deleted.invalid", acc.ID.String()),
+		Username:  "deleted_" + shortID,
+	}
+	if err := s.repo.AnonymizeAccount(ctx, input); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "privacy.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to anonymize account")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menghapus akun", nil, nil)
+	}
+
+	if profilePicture != "" {
+		if err := s.s3Client.DeleteFile(ctx, profilePicture); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":  "privacy.service",
+				"account_id": accountID,
+				"object":     profilePicture,
+			}).WithError(err).Warn("failed to delete profile picture from storage")
+		}
+	}
+
+	s.logService.CreateLog(ctx, &accountID, "DELETE", "account", accountID, nil, nil)
+
+	return pkg.NewResponse(http.StatusOK, "Akun berhasil dihapus", nil, nil)
+}
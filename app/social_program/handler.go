@@ -231,7 +231,8 @@ func (h *handler) UpdateSocialProgram(c *gin.Context) {
 		return
 	}
 
-	res := h.service.UpdateSocialProgram(ctx, id, req)
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
+	res := h.service.UpdateSocialProgram(ctx, id, claims.AccountID, req)
 	c.JSON(res.Status, res)
 }
 
@@ -249,8 +250,9 @@ func (h *handler) UpdateSocialProgram(c *gin.Context) {
 func (h *handler) DeleteSocialProgram(c *gin.Context) {
 	ctx := c.Request.Context()
 	id := c.Param("id")
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
 
-	res := h.service.DeleteSocialProgram(ctx, id)
+	res := h.service.DeleteSocialProgram(ctx, id, claims.AccountID)
 	c.JSON(res.Status, res)
 }
 
@@ -268,8 +270,9 @@ func (h *handler) DeleteSocialProgram(c *gin.Context) {
 func (h *handler) CompleteSocialProgram(c *gin.Context) {
 	ctx := c.Request.Context()
 	id := c.Param("id")
+	claims := c.MustGet("user_data").(jwt_pkg.UserJWTClaims)
 
-	res := h.service.CompleteSocialProgram(ctx, id)
+	res := h.service.CompleteSocialProgram(ctx, id, claims.AccountID)
 	c.JSON(res.Status, res)
 }
 
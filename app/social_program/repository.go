@@ -82,6 +82,10 @@ func (r *repository) FindAllSocialPrograms(ctx context.Context, options map[stri
 	if search, ok := options["search"]; ok && search.(string) != "" {
 		query = query.Where("title ILIKE ?", "%"+search.(string)+"%")
 	}
+	// An empty ids list comes from a scoped account without grants and matches nothing.
+	if ids, ok := options["ids"].([]string); ok {
+		query = query.Where("social_programs.id IN ?", ids)
+	}
 	if startDay, ok := options["start_day"]; ok {
 		if endDay, ok := options["end_day"]; ok {
 			sDay := startDay.(int)
@@ -148,6 +152,9 @@ func (r *repository) CountSocialPrograms(ctx context.Context, options map[string
 	if search, ok := options["search"]; ok && search.(string) != "" {
 		query = query.Where("title ILIKE ?", "%"+search.(string)+"%")
 	}
+	if ids, ok := options["ids"].([]string); ok {
+		query = query.Where("social_programs.id IN ?", ids)
+	}
 	if startDay, ok := options["start_day"]; ok {
 		if endDay, ok := options["end_day"]; ok {
 			sDay := startDay.(int)
@@ -203,6 +210,9 @@ func (r *repository) FindOneSocialProgram(ctx context.Context, options map[strin
 	if title, ok := options["title"]; ok && title.(string) != "" {
 		query = query.Where("title = ?", title.(string))
 	}
+	if ids, ok := options["ids"].([]string); ok {
+		query = query.Where("social_programs.id IN ?", ids)
+	}
 
 	if err := query.First(&socialProgram).Error; err != nil {
 		return nil, err
@@ -19,6 +19,8 @@ type SocialProgramDetailResponse struct {
 	BillingDay       int     `json:"billingDay"`
 	CreatedAt        string  `json:"createdAt"`
 	TotalExpense     float64 `json:"totalExpense"`
+
+	CoverImageSet *s3_pkg.ImageSet `json:"coverImageSet,omitempty"`
 }
 
 type SocialProgramListItemResponse struct {
@@ -33,6 +35,8 @@ type SocialProgramListItemResponse struct {
 	MinimumAmount    float64 `json:"minimumAmount"`
 	BillingDay       int     `json:"billingDay"`
 	TotalExpense     float64 `json:"totalExpense"`
+
+	CoverImageSet *s3_pkg.ImageSet `json:"coverImageSet,omitempty"`
 }
 
 type SocialProgramListResponse struct {
@@ -55,6 +59,8 @@ func (r *SocialProgram) ToSocialProgramDetailResponse() SocialProgramDetailRespo
 		BillingDay:       r.BillingDay,
 		CreatedAt:        r.CreatedAt.Format("2006-01-02 15:04:05"),
 		TotalExpense:     r.TotalExpense,
+
+		CoverImageSet: s3_pkg.NewImageSet(r.CoverImageVariants),
 	}
 }
 
@@ -71,6 +77,8 @@ func (r *SocialProgram) ToSocialProgramListItemResponse() SocialProgramListItemR
 		MinimumAmount:    r.MinimumAmount,
 		BillingDay:       r.BillingDay,
 		TotalExpense:     r.TotalExpense,
+
+		CoverImageSet: s3_pkg.NewImageSet(r.CoverImageVariants),
 	}
 }
 
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/Vilamuzz/yota-backend/app/access_grant"
 	app_log "github.com/Vilamuzz/yota-backend/app/log"
 	"github.com/Vilamuzz/yota-backend/pkg"
 	"github.com/Vilamuzz/yota-backend/pkg/enum"
@@ -19,29 +20,44 @@ type Service interface {
 	GetSocialProgramBySlug(ctx context.Context, socialProgramSlug string, accountID string) pkg.Response
 	GetSocialProgramByID(ctx context.Context, socialProgramID string, accountID string) pkg.Response
 	CreateSocialProgram(ctx context.Context, payload SocialProgramRequest) pkg.Response
-	UpdateSocialProgram(ctx context.Context, socialProgramID string, payload SocialProgramRequest) pkg.Response
-	DeleteSocialProgram(ctx context.Context, socialProgramID string) pkg.Response
+	UpdateSocialProgram(ctx context.Context, socialProgramID string, accountID string, payload SocialProgramRequest) pkg.Response
+	DeleteSocialProgram(ctx context.Context, socialProgramID string, accountID string) pkg.Response
 	ActivateSocialProgram(ctx context.Context, socialProgramID string) pkg.Response
 	RejectSocialProgram(ctx context.Context, socialProgramID string, payload RejectSocialProgramRequest) pkg.Response
-	CompleteSocialProgram(ctx context.Context, socialProgramID string) pkg.Response
+	CompleteSocialProgram(ctx context.Context, socialProgramID string, accountID string) pkg.Response
 }
 
 type service struct {
-	repo       Repository
-	logService app_log.Service
-	s3Client   s3_pkg.Client
-	timeout    time.Duration
+	repo            Repository
+	accessGrantRepo access_grant.Repository
+	logService      app_log.Service
+	s3Client        s3_pkg.Client
+	timeout         time.Duration
 }
 
-func NewService(repo Repository, logService app_log.Service, s3Client s3_pkg.Client, timeout time.Duration) Service {
+func NewService(repo Repository, accessGrantRepo access_grant.Repository, logService app_log.Service, s3Client s3_pkg.Client, timeout time.Duration) Service {
 	return &service{
-		repo:       repo,
-		logService: logService,
-		s3Client:   s3Client,
-		timeout:    timeout,
+		repo:            repo,
+		accessGrantRepo: accessGrantRepo,
+		logService:      logService,
+		s3Client:        s3Client,
+		timeout:         timeout,
 	}
 }
 
+// applyAccessScope narrows options to the social programs granted to the account when it is
+// scoped. A scoped account without grants gets an empty list and sees nothing.
+func (s *service) applyAccessScope(ctx context.Context, accountID string, options map[string]interface{}) error {
+	ids, scoped, err := s.accessGrantRepo.FindAccessScope(ctx, accountID, access_grant.ResourceSocialProgram)
+	if err != nil {
+		return err
+	}
+	if scoped {
+		options["ids"] = ids
+	}
+	return nil
+}
+
 func (s *service) GetSocialProgramList(ctx context.Context, params SocialProgramQueryParams, isAdmin bool, accountID string, role string) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
@@ -82,6 +98,15 @@ func (s *service) GetSocialProgramList(ctx context.Context, params SocialProgram
 	if accountID != "" {
 		options["account_id"] = accountID
 	}
+	if isAdmin {
+		if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"component":  "social_program.service",
+				"account_id": accountID,
+			}).WithError(err).Error("failed to resolve access grants")
+			return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data program sosial", nil, nil)
+		}
+	}
 
 	if params.StartDate != "" && params.EndDate != "" {
 		startDate, errStart := time.Parse("2006-01-02", params.StartDate)
@@ -167,6 +192,13 @@ func (s *service) GetSocialProgramByID(ctx context.Context, socialProgramID stri
 	if accountID != "" {
 		options["account_id"] = accountID
 	}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "social_program.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Failed to fetch social program", nil, nil)
+	}
 
 	socialProgram, err := s.repo.FindOneSocialProgram(ctx, options)
 	if err != nil {
@@ -226,8 +258,9 @@ func (s *service) CreateSocialProgram(ctx context.Context, payload SocialProgram
 	}
 
 	var coverImageURL string
+	var coverImageVariants s3_pkg.ImageVariants
 	if payload.CoverImage != nil {
-		uploadedURL, err := s.s3Client.UploadFile(ctx, payload.CoverImage, "social-programs/covers")
+		uploadedURL, variants, err := s.s3Client.UploadImage(ctx, payload.CoverImage, "social-programs/covers")
 		if err != nil {
 			logrus.WithFields(logrus.Fields{
 				"component": "social_program.service",
@@ -236,6 +269,7 @@ func (s *service) CreateSocialProgram(ctx context.Context, payload SocialProgram
 			return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengunggah gambar cover", nil, nil)
 		}
 		coverImageURL = uploadedURL
+		coverImageVariants = variants
 	}
 
 	now := time.Now()
@@ -250,6 +284,8 @@ func (s *service) CreateSocialProgram(ctx context.Context, payload SocialProgram
 		BillingDay:    payload.BillingDay,
 		CreatedAt:     now,
 		UpdatedAt:     now,
+
+		CoverImageVariants: coverImageVariants,
 	}
 
 	if err := s.repo.CreateSocialProgram(ctx, socialProgram); err != nil {
@@ -264,7 +300,7 @@ func (s *service) CreateSocialProgram(ctx context.Context, payload SocialProgram
 	return pkg.NewResponse(http.StatusCreated, "Program sosial berhasil dibuat", nil, socialProgram.ToSocialProgramDetailResponse())
 }
 
-func (s *service) UpdateSocialProgram(ctx context.Context, id string, payload SocialProgramRequest) pkg.Response {
+func (s *service) UpdateSocialProgram(ctx context.Context, id string, accountID string, payload SocialProgramRequest) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
@@ -272,7 +308,16 @@ func (s *service) UpdateSocialProgram(ctx context.Context, id string, payload So
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID program sosial tidak valid"}, nil)
 	}
 
-	socialProgram, err := s.repo.FindOneSocialProgram(ctx, map[string]interface{}{"id": id})
+	options := map[string]interface{}{"id": id}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "social_program.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data program sosial", nil, nil)
+	}
+
+	socialProgram, err := s.repo.FindOneSocialProgram(ctx, options)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return pkg.NewResponse(http.StatusNotFound, "Program sosial tidak ditemukan", nil, nil)
@@ -356,7 +401,7 @@ func (s *service) UpdateSocialProgram(ctx context.Context, id string, payload So
 	}
 
 	if payload.CoverImage != nil {
-		uploadedURL, err := s.s3Client.UploadFile(ctx, payload.CoverImage, "social-programs/covers")
+		uploadedURL, variants, err := s.s3Client.UploadImage(ctx, payload.CoverImage, "social-programs/covers")
 		if err != nil {
 			logrus.WithFields(logrus.Fields{
 				"component": "social_program.service",
@@ -367,10 +412,11 @@ func (s *service) UpdateSocialProgram(ctx context.Context, id string, payload So
 
 		if socialProgram.CoverImage != "" {
 			existingImage := s3_pkg.ExtractObjectNameFromURL(socialProgram.CoverImage)
-			_ = s.s3Client.DeleteFile(ctx, existingImage)
+			_ = s.s3Client.DeleteImage(ctx, existingImage, socialProgram.CoverImageVariants)
 		}
 
 		updateData["cover_image"] = uploadedURL
+		updateData["cover_image_variants"] = variants
 	}
 
 	if len(updateData) == 0 {
@@ -387,11 +433,11 @@ func (s *service) UpdateSocialProgram(ctx context.Context, id string, payload So
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal memperbarui program sosial", nil, nil)
 	}
 
-	s.logService.CreateLog(ctx, nil, "UPDATE", "social_program", id, socialProgram.ToSocialProgramDetailResponse(), updateData)
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "social_program", id, socialProgram.ToSocialProgramDetailResponse(), updateData)
 	return pkg.NewResponse(http.StatusOK, "Program sosial berhasil diperbarui", nil, nil)
 }
 
-func (s *service) DeleteSocialProgram(ctx context.Context, socialProgramID string) pkg.Response {
+func (s *service) DeleteSocialProgram(ctx context.Context, socialProgramID string, accountID string) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
@@ -399,7 +445,16 @@ func (s *service) DeleteSocialProgram(ctx context.Context, socialProgramID strin
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID program sosial tidak valid"}, nil)
 	}
 
-	socialProgram, err := s.repo.FindOneSocialProgram(ctx, map[string]interface{}{"id": socialProgramID})
+	options := map[string]interface{}{"id": socialProgramID}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "social_program.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data program sosial", nil, nil)
+	}
+
+	socialProgram, err := s.repo.FindOneSocialProgram(ctx, options)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return pkg.NewResponse(http.StatusNotFound, "Program sosial tidak ditemukan", nil, nil)
@@ -420,10 +475,10 @@ func (s *service) DeleteSocialProgram(ctx context.Context, socialProgramID strin
 
 	if socialProgram.CoverImage != "" {
 		existingImage := s3_pkg.ExtractObjectNameFromURL(socialProgram.CoverImage)
-		_ = s.s3Client.DeleteFile(ctx, existingImage)
+		_ = s.s3Client.DeleteImage(ctx, existingImage, socialProgram.CoverImageVariants)
 	}
 
-	s.logService.CreateLog(ctx, nil, "DELETE", "social_program", socialProgramID, socialProgram.ToSocialProgramDetailResponse(), nil)
+	s.logService.CreateLog(ctx, &accountID, "DELETE", "social_program", socialProgramID, socialProgram.ToSocialProgramDetailResponse(), nil)
 	return pkg.NewResponse(http.StatusOK, "Program sosial berhasil dihapus", nil, nil)
 }
 
@@ -506,7 +561,7 @@ func (s *service) RejectSocialProgram(ctx context.Context, socialProgramID strin
 	return pkg.NewResponse(http.StatusOK, "Program sosial berhasil ditolak", nil, nil)
 }
 
-func (s *service) CompleteSocialProgram(ctx context.Context, socialProgramID string) pkg.Response {
+func (s *service) CompleteSocialProgram(ctx context.Context, socialProgramID string, accountID string) pkg.Response {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
@@ -514,7 +569,16 @@ func (s *service) CompleteSocialProgram(ctx context.Context, socialProgramID str
 		return pkg.NewResponse(http.StatusBadRequest, "Kesalahan validasi", map[string]string{"id": "Format ID program sosial tidak valid"}, nil)
 	}
 
-	socialProgram, err := s.repo.FindOneSocialProgram(ctx, map[string]interface{}{"id": socialProgramID})
+	options := map[string]interface{}{"id": socialProgramID}
+	if err := s.applyAccessScope(ctx, accountID, options); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"component":  "social_program.service",
+			"account_id": accountID,
+		}).WithError(err).Error("failed to resolve access grants")
+		return pkg.NewResponse(http.StatusInternalServerError, "Gagal mengambil data program sosial", nil, nil)
+	}
+
+	socialProgram, err := s.repo.FindOneSocialProgram(ctx, options)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return pkg.NewResponse(http.StatusNotFound, "Program sosial tidak ditemukan", nil, nil)
@@ -539,6 +603,6 @@ func (s *service) CompleteSocialProgram(ctx context.Context, socialProgramID str
 		return pkg.NewResponse(http.StatusInternalServerError, "Gagal menyelesaikan program sosial", nil, nil)
 	}
 
-	s.logService.CreateLog(ctx, nil, "UPDATE", "social_program", socialProgramID, socialProgram.ToSocialProgramDetailResponse(), updates)
+	s.logService.CreateLog(ctx, &accountID, "UPDATE", "social_program", socialProgramID, socialProgram.ToSocialProgramDetailResponse(), updates)
 	return pkg.NewResponse(http.StatusOK, "Program sosial berhasil diselesaikan", nil, nil)
 }
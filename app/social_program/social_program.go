@@ -3,6 +3,7 @@ package social_program
 import (
 	"time"
 
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
 	"github.com/google/uuid"
 )
 
@@ -20,6 +21,8 @@ type SocialProgram struct {
 	UpdatedAt       time.Time  `json:"updatedAt"`
 	DeletedAt       *time.Time `json:"deletedAt" gorm:"index"`
 
+	CoverImageVariants s3_pkg.ImageVariants `json:"coverImageVariants" gorm:"type:jsonb"`
+
 	TotalSubscribers int64   `json:"totalSubscribers" gorm:"->"`
 	IsSubscribed     bool    `json:"isSubscribed" gorm:"->"`
 	SubscriptionID   string  `json:"subscriptionId" gorm:"->"`
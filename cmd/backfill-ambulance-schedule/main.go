@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/ambulance_service_request"
+	"github.com/Vilamuzz/yota-backend/config"
+	"github.com/joho/godotenv"
+	"gorm.io/gorm"
+)
+
+func init() {
+	// Load env file if exists (for local running)
+	_ = godotenv.Load()
+}
+
+// Requests accepted before the schedule columns existed have no scheduled window, so they never
+// block the ambulance or driver calendar. Their window is derived the same way accepting does:
+// from the pickup date and time plus the estimated duration of the service category. Only
+// accepted and in-service requests without a window are touched, which makes reruns safe.
+func main() {
+	dryRun := flag.Bool("dry-run", false, "Count the requests that would be backfilled without changing anything")
+	flag.Parse()
+
+	fmt.Println("Backfilling ambulance service request schedules...")
+
+	db := config.ConnectDB()
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("Failed to get database instance: %v", err)
+	}
+	defer sqlDB.Close()
+
+	// Encrypted columns are left out so the command runs without PII keys.
+	var requests []ambulance_service_request.AmbulanceServiceRequest
+	if err := db.Model(&ambulance_service_request.AmbulanceServiceRequest{}).
+		Select("id", "pickup_date", "pickup_time", "service_category").
+		Where("status IN ?", []ambulance_service_request.Status{
+			ambulance_service_request.StatusAccepted,
+			ambulance_service_request.StatusInService,
+		}).
+		Where("scheduled_start IS NULL OR scheduled_end IS NULL").
+		Find(&requests).Error; err != nil {
+		log.Fatalf("Failed to fetch requests without schedule: %v", err)
+	}
+
+	if *dryRun {
+		fmt.Printf("Dry run finished: %d schedules would be backfilled\n", len(requests))
+		return
+	}
+
+	var updated int64
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, req := range requests {
+			start, end := req.ScheduleWindow()
+			result := tx.Model(&ambulance_service_request.AmbulanceServiceRequest{}).
+				Where("id = ? AND (scheduled_start IS NULL OR scheduled_end IS NULL)", req.ID).
+				Updates(map[string]interface{}{
+					"scheduled_start": start,
+					"scheduled_end":   end,
+					"updated_at":      time.Now(),
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			updated += result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Failed to backfill schedules: %v", err)
+	}
+	fmt.Printf("Finished: %d schedules backfilled\n", updated)
+}
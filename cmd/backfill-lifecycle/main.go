@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Vilamuzz/yota-backend/app/foster_children"
+	"github.com/Vilamuzz/yota-backend/config"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"gorm.io/gorm"
+)
+
+func init() {
+	// Load env file if exists (for local running)
+	_ = godotenv.Load()
+}
+
+// The status column is added with the default "enrolled", so children graduated through the old
+// isGraduated flag need their status set, and every child gets a first timeline entry. Both
+// steps only touch rows that still need them, which makes reruns safe.
+func main() {
+	dryRun := flag.Bool("dry-run", false, "Count the rows that would be backfilled without changing anything")
+	flag.Parse()
+
+	fmt.Println("Backfilling foster children lifecycle status and timeline...")
+
+	db := config.ConnectDB()
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("Failed to get database instance: %v", err)
+	}
+	defer sqlDB.Close()
+
+	graduated := db.Model(&foster_children.FosterChildren{}).
+		Where("is_graduated = ? AND status = ?", true, foster_children.StatusEnrolled)
+	// Encrypted columns are left out so the command runs without PII keys.
+	var children []foster_children.FosterChildren
+	if err := db.Model(&foster_children.FosterChildren{}).
+		Select("id", "is_graduated", "education_level", "school_name", "created_at").
+		Where("NOT EXISTS (SELECT 1 FROM lifecycle_events le WHERE le.foster_children_id = foster_childrens.id)").
+		Find(&children).Error; err != nil {
+		log.Fatalf("Failed to fetch foster children without timeline: %v", err)
+	}
+
+	if *dryRun {
+		var count int64
+		if err := graduated.Count(&count).Error; err != nil {
+			log.Fatalf("Failed to count graduated foster children: %v", err)
+		}
+		fmt.Printf("Dry run finished: %d statuses and %d timelines would be backfilled\n", count, len(children))
+		return
+	}
+
+	var statuses int64
+	var events int
+	err = db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&foster_children.FosterChildren{}).
+			Where("is_graduated = ? AND status = ?", true, foster_children.StatusEnrolled).
+			Updates(map[string]interface{}{"status": foster_children.StatusGraduated, "updated_at": time.Now()})
+		if result.Error != nil {
+			return result.Error
+		}
+		statuses = result.RowsAffected
+
+		for _, child := range children {
+			status := foster_children.StatusEnrolled
+			if child.IsGraduated {
+				status = foster_children.StatusGraduated
+			}
+			if err := tx.Create(&foster_children.LifecycleEvent{
+				ID:               uuid.New(),
+				FosterChildrenID: child.ID,
+				Type:             foster_children.EventStatusChange,
+				ToStatus:         status,
+				ToEducationLevel: child.EducationLevel,
+				ToSchoolName:     child.SchoolName,
+				Reason:           "Data awal sebelum riwayat dicatat",
+				EffectiveDate:    child.CreatedAt,
+			}).Error; err != nil {
+				return err
+			}
+			events++
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Failed to backfill lifecycle: %v", err)
+	}
+	fmt.Printf("Finished: %d statuses and %d timelines backfilled\n", statuses, events)
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Vilamuzz/yota-backend/config"
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
+	"github.com/joho/godotenv"
+	"gorm.io/gorm"
+)
+
+func init() {
+	// Load env file if exists (for local running)
+	_ = godotenv.Load()
+	s3_pkg.InitCDN()
+}
+
+// documentColumns lists every column holding a sensitive upload, narrowed by where when only some
+// rows are sensitive. Candidates come before foster children because accepted candidates share
+// their family card and SKTM objects.
+var documentColumns = []struct {
+	table   string
+	columns []string
+	where   string
+}{
+	{"foster_children_candidates", []string{"family_card", "sktm", "submitter_id_card"}, ""},
+	{"foster_childrens", []string{"family_card", "sktm"}, ""},
+	{"ambulance_service_requests", []string{"submitter_id_card"}, ""},
+	{"achivements", []string{"url"}, "visibility = 'private'"},
+}
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "List the documents that would be moved without changing anything")
+	flag.Parse()
+
+	fmt.Println("Moving sensitive documents to private storage...")
+
+	db := config.ConnectDB()
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("Failed to get database instance: %v", err)
+	}
+	defer sqlDB.Close()
+
+	storage := s3_pkg.NewPrivateClient(config.ConnectS3())
+	ctx := context.Background()
+
+	var moved, failed int
+	for _, target := range documentColumns {
+		m, f, err := migrateTable(ctx, db, storage, target.table, target.columns, target.where, *dryRun)
+		if err != nil {
+			log.Fatalf("Failed to migrate %s: %v", target.table, err)
+		}
+		moved += m
+		failed += f
+	}
+
+	if *dryRun {
+		fmt.Printf("Dry run finished: %d documents would be moved\n", moved)
+		return
+	}
+	fmt.Printf("Finished: %d documents moved, %d failed\n", moved, failed)
+	if failed > 0 {
+		log.Fatal("Some documents could not be moved; rerun the command after fixing the errors above")
+	}
+}
+
+// migrateTable moves the documents of one table row by row so a failure leaves every other row
+// consistent. Rows already pointing at private storage are skipped, which makes reruns safe.
+func migrateTable(ctx context.Context, db *gorm.DB, storage s3_pkg.PrivateClient, table string, columns []string, where string, dryRun bool) (int, int, error) {
+	conditions := make([]string, len(columns))
+	for i, column := range columns {
+		conditions[i] = fmt.Sprintf("(%s <> '' AND %s NOT LIKE '%s%%')", column, column, s3_pkg.PrivateRefPrefix)
+	}
+
+	query := db.Table(table).
+		Select(append([]string{"id"}, columns...)).
+		Where("(" + strings.Join(conditions, " OR ") + ")")
+	if where != "" {
+		query = query.Where(where)
+	}
+
+	var rows []map[string]interface{}
+	if err := query.Find(&rows).Error; err != nil {
+		return 0, 0, err
+	}
+
+	var moved, failed int
+	for _, row := range rows {
+		updates := make(map[string]interface{})
+		for _, column := range columns {
+			value, _ := row[column].(string)
+			if value == "" || s3_pkg.IsPrivateRef(value) {
+				continue
+			}
+			if dryRun {
+				fmt.Printf("[dry-run] %s %v %s: %s\n", table, row["id"], column, value)
+				moved++
+				continue
+			}
+			ref, err := storage.MigrateFromPublic(ctx, value)
+			if err != nil {
+				fmt.Printf("Failed to move %s %v %s: %v\n", table, row["id"], column, err)
+				failed++
+				continue
+			}
+			updates[column] = ref
+			moved++
+		}
+		if len(updates) == 0 {
+			continue
+		}
+		if err := db.Table(table).Where("id = ?", row["id"]).Updates(updates).Error; err != nil {
+			return moved, failed, fmt.Errorf("update %v: %w", row["id"], err)
+		}
+	}
+
+	fmt.Printf("%s: %d rows checked\n", table, len(rows))
+	return moved, failed, nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Vilamuzz/yota-backend/config"
+	"github.com/Vilamuzz/yota-backend/pkg/pii"
+	"github.com/joho/godotenv"
+	"gorm.io/gorm"
+)
+
+func init() {
+	// Load env file if exists (for local running)
+	_ = godotenv.Load()
+}
+
+// encryptedColumns lists every column stored as pii.String. indexes maps a column to its blind
+// index column, which is backfilled for rows written before encryption was introduced.
+var encryptedColumns = []struct {
+	table   string
+	columns []string
+	indexes map[string]string
+}{
+	{"foster_childrens", []string{"nik", "family_card_number"}, map[string]string{"nik": "nik_index", "family_card_number": "family_card_number_index"}},
+	{"foster_children_candidates", []string{"nik", "family_card_number", "submitter_phone"}, map[string]string{"nik": "nik_index", "family_card_number": "family_card_number_index"}},
+	{"ambulance_service_requests", []string{"submitter_phone", "patient_address", "disease"}, nil},
+}
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "Count the values that would be re-encrypted without changing anything")
+	flag.Parse()
+
+	if err := pii.Init(); err != nil {
+		log.Fatalf("Failed to initialize PII keys: %v", err)
+	}
+	fmt.Printf("Re-encrypting personal identifiers with key version %d...\n", pii.ActiveVersion())
+
+	db := config.ConnectDB()
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("Failed to get database instance: %v", err)
+	}
+	defer sqlDB.Close()
+
+	var updated, failed int
+	for _, target := range encryptedColumns {
+		u, f, err := reencryptTable(db, target.table, target.columns, target.indexes, *dryRun)
+		if err != nil {
+			log.Fatalf("Failed to re-encrypt %s: %v", target.table, err)
+		}
+		updated += u
+		failed += f
+	}
+
+	if *dryRun {
+		fmt.Printf("Dry run finished: %d rows would be updated\n", updated)
+		return
+	}
+	fmt.Printf("Finished: %d rows updated, %d failed\n", updated, failed)
+	if failed > 0 {
+		log.Fatal("Some rows could not be decrypted; check that every key version in use is listed in PII_KEYS")
+	}
+}
+
+// reencryptTable rewrites plaintext values and values sealed with an older key version, row by
+// row. Rows already on the active version with their blind index set are not selected, which
+// makes reruns safe.
+func reencryptTable(db *gorm.DB, table string, columns []string, indexes map[string]string, dryRun bool) (int, int, error) {
+	activePrefix := fmt.Sprintf("pii:v%d:", pii.ActiveVersion())
+	selectColumns := append([]string{"id"}, columns...)
+	var conditions []string
+	for _, column := range columns {
+		conditions = append(conditions, fmt.Sprintf("(%s <> '' AND %s NOT LIKE '%s%%')", column, column, activePrefix))
+		if index, ok := indexes[column]; ok {
+			selectColumns = append(selectColumns, index)
+			conditions = append(conditions, fmt.Sprintf("(%s <> '' AND COALESCE(%s, '') = '')", column, index))
+		}
+	}
+
+	var rows []map[string]interface{}
+	if err := db.Table(table).
+		Select(selectColumns).
+		Where(strings.Join(conditions, " OR ")).
+		Find(&rows).Error; err != nil {
+		return 0, 0, err
+	}
+
+	var updated, failed int
+	for _, row := range rows {
+		updates := make(map[string]interface{})
+		for _, column := range columns {
+			value, _ := row[column].(string)
+			if value == "" {
+				continue
+			}
+			plaintext, err := pii.Decrypt(value)
+			if err != nil {
+				fmt.Printf("Failed to decrypt %s %v %s: %v\n", table, row["id"], column, err)
+				failed++
+				continue
+			}
+			if pii.NeedsRotation(value) {
+				encrypted, err := pii.Encrypt(plaintext)
+				if err != nil {
+					return updated, failed, err
+				}
+				updates[column] = encrypted
+			}
+			if index, ok := indexes[column]; ok {
+				if current, _ := row[index].(string); current == "" {
+					updates[index] = pii.BlindIndex(plaintext)
+				}
+			}
+		}
+		if len(updates) == 0 {
+			continue
+		}
+		updated++
+		if dryRun {
+			continue
+		}
+		if err := db.Table(table).Where("id = ?", row["id"]).Updates(updates).Error; err != nil {
+			return updated, failed, fmt.Errorf("update %v: %w", row["id"], err)
+		}
+	}
+
+	fmt.Printf("%s: %d rows checked\n", table, len(rows))
+	return updated, failed, nil
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/Vilamuzz/yota-backend/app/foster_children"
+	"github.com/Vilamuzz/yota-backend/app/foster_children_candidate"
+	"github.com/Vilamuzz/yota-backend/config"
+	"github.com/Vilamuzz/yota-backend/pkg/pii"
+	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"gorm.io/gorm"
+)
+
+func init() {
+	// Load env file if exists (for local running)
+	_ = godotenv.Load()
+	s3_pkg.InitCDN()
+}
+
+// Before acceptance ran in a single transaction, a candidate could be marked accepted while
+// creating its foster child failed. Accepted candidates without a linked child are repaired one
+// by one: a child created for them earlier is linked, matched by candidate, NIK or name and
+// birth date. Creating a new child from a copy of the candidate's documents only happens with
+// -create-missing, and never for candidates without a NIK index, since an existing child could
+// not be ruled out. Linked candidates are skipped, which makes reruns safe.
+func main() {
+	dryRun := flag.Bool("dry-run", false, "List the candidates that would be repaired without changing anything")
+	createMissing := flag.Bool("create-missing", false, "Create a foster child for candidates no existing child matches")
+	flag.Parse()
+
+	if err := pii.Init(); err != nil {
+		log.Fatalf("Failed to initialize PII keys: %v", err)
+	}
+	fmt.Println("Repairing accepted foster children candidates without a foster child...")
+
+	db := config.ConnectDB()
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("Failed to get database instance: %v", err)
+	}
+	defer sqlDB.Close()
+
+	minioClient := config.ConnectS3()
+	s3Client := s3_pkg.NewClient(minioClient)
+	privateStorage := s3_pkg.NewPrivateClient(minioClient)
+	creator := foster_children.NewRepository(db)
+	ctx := context.Background()
+
+	var candidates []foster_children_candidate.FosterChildrenCandidate
+	if err := db.Where("status = ? AND foster_children_id IS NULL", foster_children_candidate.StatusAccepted).
+		Order("updated_at ASC").
+		Find(&candidates).Error; err != nil {
+		log.Fatalf("Failed to fetch accepted candidates: %v", err)
+	}
+
+	var linked, created, skipped, failed int
+	for i := range candidates {
+		candidate := &candidates[i]
+		child, err := findExistingChild(db, candidate)
+		if err != nil {
+			log.Printf("Failed to look up foster child for candidate %s: %v", candidate.ID, err)
+			failed++
+			continue
+		}
+
+		if child == nil && candidate.NikIndex == "" {
+			log.Printf("Candidate %s has no NIK index; run reencrypt-pii first so an existing foster child can be matched", candidate.ID)
+			failed++
+			continue
+		}
+
+		if *dryRun {
+			switch {
+			case child != nil:
+				fmt.Printf("Candidate %s would be linked to foster child %s\n", candidate.ID, child.ID)
+				linked++
+			case *createMissing:
+				fmt.Printf("Candidate %s would get a new foster child\n", candidate.ID)
+				created++
+			default:
+				fmt.Printf("Candidate %s has no matching foster child\n", candidate.ID)
+				skipped++
+			}
+			continue
+		}
+
+		if child != nil {
+			if err := linkChild(db, candidate, child.ID); err != nil {
+				log.Printf("Failed to link candidate %s to foster child %s: %v", candidate.ID, child.ID, err)
+				failed++
+				continue
+			}
+			linked++
+			continue
+		}
+
+		if !*createMissing {
+			fmt.Printf("Candidate %s has no matching foster child; rerun with -create-missing to create one\n", candidate.ID)
+			skipped++
+			continue
+		}
+
+		docs, err := foster_children_candidate.CopyDocumentsForFosterChildren(ctx, s3Client, privateStorage, candidate)
+		if err != nil {
+			log.Printf("Failed to copy documents of candidate %s: %v", candidate.ID, err)
+			failed++
+			continue
+		}
+		if err := db.Transaction(func(tx *gorm.DB) error {
+			return foster_children_candidate.CreateFosterChildrenInTx(ctx, tx, creator, candidate, uuid.New(), docs)
+		}); err != nil {
+			docs.Delete(ctx, s3Client, privateStorage)
+			log.Printf("Failed to create foster child for candidate %s: %v", candidate.ID, err)
+			failed++
+			continue
+		}
+		created++
+	}
+
+	if *dryRun {
+		fmt.Printf("Dry run finished: %d candidates would be linked, %d would get a new foster child, %d without a match, %d failed\n", linked, created, skipped, failed)
+		return
+	}
+	fmt.Printf("Finished: %d candidates linked, %d foster children created, %d without a match, %d failed\n", linked, created, skipped, failed)
+	if failed > 0 {
+		log.Fatal("Some candidates could not be repaired; rerun the command after fixing the errors above")
+	}
+}
+
+// findExistingChild returns the foster child already created for the candidate, either linked by
+// candidate_id or, for children created before that column existed, an unlinked child with the
+// same NIK or, when the NIK is missing or was edited since, the same normalised name and birth
+// date.
+func findExistingChild(db *gorm.DB, candidate *foster_children_candidate.FosterChildrenCandidate) (*foster_children.FosterChildren, error) {
+	child, err := firstChild(db.Where("candidate_id = ?", candidate.ID))
+	if child != nil || err != nil {
+		return child, err
+	}
+	if candidate.NikIndex != "" {
+		child, err = firstChild(db.Where("nik_index = ? AND candidate_id IS NULL", candidate.NikIndex))
+		if child != nil || err != nil {
+			return child, err
+		}
+	}
+	return firstChild(db.Where(
+		foster_children_candidate.NormalizedNameSQL+" = ? AND (birth_date AT TIME ZONE 'UTC')::date = ? AND candidate_id IS NULL",
+		foster_children_candidate.NormalizeName(candidate.Name), candidate.BirthDate.UTC().Format("2006-01-02"),
+	))
+}
+
+// firstChild returns the oldest foster child matching query, or nil when none does. Only the
+// id is loaded, so the encrypted columns are never scanned.
+func firstChild(query *gorm.DB) (*foster_children.FosterChildren, error) {
+	var child foster_children.FosterChildren
+	err := query.Select("id").Order("created_at ASC").First(&child).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &child, nil
+}
+
+func linkChild(db *gorm.DB, candidate *foster_children_candidate.FosterChildrenCandidate, childID uuid.UUID) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&foster_children.FosterChildren{}).
+			Where("id = ?", childID).
+			Update("candidate_id", candidate.ID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&foster_children_candidate.FosterChildrenCandidate{}).
+			Where("id = ?", candidate.ID).
+			Update("foster_children_id", childID).Error
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/Vilamuzz/yota-backend/app/account"
 	"github.com/Vilamuzz/yota-backend/cmd/seed/models"
 	"github.com/Vilamuzz/yota-backend/config"
+	"github.com/Vilamuzz/yota-backend/pkg/pii"
 	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 	"golang.org/x/crypto/bcrypt"
@@ -27,6 +28,10 @@ func main() {
 
 	fmt.Println("Starting database seeder...")
 
+	if err := pii.Init(); err != nil {
+		log.Fatalf("Failed to initialize PII keys: %v", err)
+	}
+
 	db := config.ConnectDB()
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"github.com/Vilamuzz/yota-backend/app/ambulance"
 	"github.com/Vilamuzz/yota-backend/app/ambulance_history"
 	"github.com/Vilamuzz/yota-backend/app/ambulance_service_request"
+	"github.com/Vilamuzz/yota-backend/pkg/pii"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -124,10 +125,10 @@ func SeedAmbulanceServiceRequests(db *gorm.DB) error {
 				SubmitterPhone:  "081234567890",
 				SubmitterIDCard: "https://placehold.co/600x400.png",
 				PatientName:     patientNames[i%len(patientNames)],
-				PatientAddress:  patientAddresses[i%len(patientAddresses)],
+				PatientAddress:  pii.String(patientAddresses[i%len(patientAddresses)]),
 				PatientAge:      20 + (i * 5),
 				IsInfectious:    i%5 == 0,
-				Disease:         diseases[i%len(diseases)],
+				Disease:         pii.String(diseases[i%len(diseases)]),
 				IsAbleToSit:     i%2 == 0,
 				PickupDate:      pickupDate,
 				PickupTime:      pickupDate,
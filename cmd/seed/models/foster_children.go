@@ -22,6 +22,7 @@ func SeedFosterChildren(db *gorm.DB) error {
 			ProfilePicture: "https://images.unsplash.com/photo-1761638344047-de8170f7cc7f?w=600&auto=format&fit=crop&w=800&q=80",
 			Gender:         foster_children.Male,
 			IsGraduated:    false,
+			Status:         foster_children.StatusEnrolled,
 			Category:       foster_children.CategoryOrphan,
 			BirthDate:      time.Date(2010, 5, 14, 0, 0, 0, 0, time.UTC),
 			BirthPlace:     "Jakarta",
@@ -39,6 +40,7 @@ func SeedFosterChildren(db *gorm.DB) error {
 			ProfilePicture: "https://plus.unsplash.com/premium_photo-1726826637082-16602bf15ba8?w=600&auto=format&fit=crop&w=800&q=80",
 			Gender:         foster_children.Female,
 			IsGraduated:    false,
+			Status:         foster_children.StatusEnrolled,
 			Category:       foster_children.CategoryFatherless,
 			BirthDate:      time.Date(2012, 8, 22, 0, 0, 0, 0, time.UTC),
 			BirthPlace:     "Bandung",
@@ -56,6 +58,7 @@ func SeedFosterChildren(db *gorm.DB) error {
 			ProfilePicture: "https://images.unsplash.com/photo-1648851280857-5ca540251130?w=600&auto=format&fit=crop&w=800&q=80",
 			Gender:         foster_children.Male,
 			IsGraduated:    false,
+			Status:         foster_children.StatusEnrolled,
 			Category:       foster_children.CategoryMotherless,
 			BirthDate:      time.Date(2008, 11, 2, 0, 0, 0, 0, time.UTC),
 			BirthPlace:     "Surabaya",
@@ -73,6 +76,7 @@ func SeedFosterChildren(db *gorm.DB) error {
 			ProfilePicture: "https://images.unsplash.com/photo-1635146914900-4e58ad412f1f?q=80&w=880&auto=format&fit=crop&w=800&q=80",
 			Gender:         foster_children.Female,
 			IsGraduated:    false,
+			Status:         foster_children.StatusEnrolled,
 			Category:       foster_children.CategoryOrphan,
 			BirthDate:      time.Date(2014, 2, 18, 0, 0, 0, 0, time.UTC),
 			BirthPlace:     "Semarang",
@@ -90,6 +94,7 @@ func SeedFosterChildren(db *gorm.DB) error {
 			ProfilePicture: "https://plus.unsplash.com/premium_photo-1769871805454-c5759d918a99?q=80&w=880&auto=format&fit=crop&w=800&q=80",
 			Gender:         foster_children.Male,
 			IsGraduated:    false,
+			Status:         foster_children.StatusEnrolled,
 			Category:       foster_children.CategoryFatherless,
 			BirthDate:      time.Date(2009, 9, 30, 0, 0, 0, 0, time.UTC),
 			BirthPlace:     "Yogyakarta",
@@ -107,6 +112,7 @@ func SeedFosterChildren(db *gorm.DB) error {
 			ProfilePicture: "https://images.unsplash.com/photo-1762077656275-4514300d7da8?w=600&auto=format&fit=crop&w=800&q=80",
 			Gender:         foster_children.Female,
 			IsGraduated:    true,
+			Status:         foster_children.StatusGraduated,
 			Category:       foster_children.CategoryOrphan,
 			BirthDate:      time.Date(2005, 4, 12, 0, 0, 0, 0, time.UTC),
 			BirthPlace:     "Malang",
@@ -124,6 +130,7 @@ func SeedFosterChildren(db *gorm.DB) error {
 			ProfilePicture: "https://images.unsplash.com/photo-1542385151-efd9000785a0?auto=format&fit=crop&w=800&q=80",
 			Gender:         foster_children.Male,
 			IsGraduated:    false,
+			Status:         foster_children.StatusEnrolled,
 			Category:       foster_children.CategoryMotherless,
 			BirthDate:      time.Date(2013, 7, 5, 0, 0, 0, 0, time.UTC),
 			BirthPlace:     "Medan",
@@ -141,6 +148,7 @@ func SeedFosterChildren(db *gorm.DB) error {
 			ProfilePicture: "https://images.unsplash.com/photo-1693009025862-f2330446d2b6?w=600&auto=format&fit=crop&w=800&q=80",
 			Gender:         foster_children.Female,
 			IsGraduated:    false,
+			Status:         foster_children.StatusEnrolled,
 			Category:       foster_children.CategoryFatherless,
 			BirthDate:      time.Date(2011, 1, 20, 0, 0, 0, 0, time.UTC),
 			BirthPlace:     "Padang",
@@ -158,6 +166,7 @@ func SeedFosterChildren(db *gorm.DB) error {
 			ProfilePicture: "https://images.unsplash.com/photo-1658460029652-90b33eb1c682?w=600&auto=format&fit=crop&w=800&q=80",
 			Gender:         foster_children.Male,
 			IsGraduated:    false,
+			Status:         foster_children.StatusEnrolled,
 			Category:       foster_children.CategoryOrphan,
 			BirthDate:      time.Date(2007, 12, 10, 0, 0, 0, 0, time.UTC),
 			BirthPlace:     "Palembang",
@@ -175,6 +184,7 @@ func SeedFosterChildren(db *gorm.DB) error {
 			ProfilePicture: "https://images.unsplash.com/photo-1673602557002-ba863312909b?q=80&w=881&auto=format&fit=crop&w=800&q=80",
 			Gender:         foster_children.Female,
 			IsGraduated:    true,
+			Status:         foster_children.StatusGraduated,
 			Category:       foster_children.CategoryFatherless,
 			BirthDate:      time.Date(2004, 6, 8, 0, 0, 0, 0, time.UTC),
 			BirthPlace:     "Denpasar",
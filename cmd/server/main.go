@@ -6,6 +6,7 @@ import (
 
 	"github.com/Vilamuzz/yota-backend/internal/app"
 	"github.com/Vilamuzz/yota-backend/pkg/oauth"
+	"github.com/Vilamuzz/yota-backend/pkg/pii"
 	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
 	"github.com/joho/godotenv"
 )
@@ -28,6 +29,11 @@ func main() {
 	// Initialize OAuth
 	oauth.InitOAuth()
 
+	// Initialize encryption keys for personal identifiers
+	if err := pii.Init(); err != nil {
+		log.Fatalf("Failed to initialize PII keys: %v", err)
+	}
+
 	application, cleanup, err := app.NewApp()
 	if err != nil {
 		log.Fatalf("Failed to initialize application: %v", err)
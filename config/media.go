@@ -12,3 +12,12 @@ func GetMaxFileUploadSize() int64 {
 	}
 	return maxFileUploadSize * 1024 * 1024
 }
+
+// GetMaxVideoUploadSize limits videos uploaded directly to storage through upload sessions.
+func GetMaxVideoUploadSize() int64 {
+	maxVideoUploadSize, _ := strconv.ParseInt(os.Getenv("MAX_VIDEO_FILE_SIZE"), 10, 64)
+	if maxVideoUploadSize <= 0 {
+		maxVideoUploadSize = 500 // default 500 mb
+	}
+	return maxVideoUploadSize * 1024 * 1024
+}
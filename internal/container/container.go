@@ -7,19 +7,26 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/Vilamuzz/yota-backend/app/access_grant"
 	"github.com/Vilamuzz/yota-backend/app/account"
 	"github.com/Vilamuzz/yota-backend/app/ambulance"
+	"github.com/Vilamuzz/yota-backend/app/ambulance_fleet"
 	"github.com/Vilamuzz/yota-backend/app/ambulance_history"
 	"github.com/Vilamuzz/yota-backend/app/ambulance_service_request"
+	"github.com/Vilamuzz/yota-backend/app/ambulance_transaction"
 	"github.com/Vilamuzz/yota-backend/app/auth"
 	"github.com/Vilamuzz/yota-backend/app/backup"
 	"github.com/Vilamuzz/yota-backend/app/donation_program"
 	"github.com/Vilamuzz/yota-backend/app/donation_program_expense"
 	"github.com/Vilamuzz/yota-backend/app/donation_program_transaction"
+	"github.com/Vilamuzz/yota-backend/app/driver_roster"
 	"github.com/Vilamuzz/yota-backend/app/finance_record"
 	"github.com/Vilamuzz/yota-backend/app/foster_children"
 	"github.com/Vilamuzz/yota-backend/app/foster_children_candidate"
 	"github.com/Vilamuzz/yota-backend/app/foster_children_expense"
+	"github.com/Vilamuzz/yota-backend/app/foster_children_need"
+	"github.com/Vilamuzz/yota-backend/app/foster_children_report"
+	"github.com/Vilamuzz/yota-backend/app/foster_children_sponsorship"
 	"github.com/Vilamuzz/yota-backend/app/foster_children_transaction"
 	"github.com/Vilamuzz/yota-backend/app/foundation_profile"
 	"github.com/Vilamuzz/yota-backend/app/gallery"
@@ -30,6 +37,7 @@ import (
 	"github.com/Vilamuzz/yota-backend/app/news_comment"
 	"github.com/Vilamuzz/yota-backend/app/payment"
 	"github.com/Vilamuzz/yota-backend/app/prayer"
+	"github.com/Vilamuzz/yota-backend/app/privacy"
 	"github.com/Vilamuzz/yota-backend/app/social_program"
 	"github.com/Vilamuzz/yota-backend/app/social_program_expense"
 	"github.com/Vilamuzz/yota-backend/app/social_program_invoice"
@@ -38,8 +46,10 @@ import (
 	"github.com/Vilamuzz/yota-backend/config"
 	"github.com/Vilamuzz/yota-backend/internal/scheduler"
 	payment_pkg "github.com/Vilamuzz/yota-backend/pkg/payment"
+	"github.com/Vilamuzz/yota-backend/pkg/realtime"
 	redis_pkg "github.com/Vilamuzz/yota-backend/pkg/redis"
 	s3_pkg "github.com/Vilamuzz/yota-backend/pkg/s3"
+	"github.com/Vilamuzz/yota-backend/pkg/sms"
 	"github.com/gin-gonic/gin"
 	"github.com/minio/minio-go/v7"
 	"github.com/redis/go-redis/v9"
@@ -51,10 +61,19 @@ type Container struct {
 	DB             *gorm.DB
 	RedisClient    *redis_pkg.Client
 	S3Client       s3_pkg.Client
+	PrivateStorage s3_pkg.PrivateClient
 	MinioClient    *minio.Client
 	MidtransClient payment_pkg.Client
+	SMSSender      sms.Sender
+	Broker         realtime.Broker
 	Timeout        time.Duration
 
+	// AmbulanceEmergencyEscalation is how long an emergency may stay unclaimed before it is escalated
+	AmbulanceEmergencyEscalation time.Duration
+
+	// FosterChildrenMaxSponsors caps the active sponsors per foster child; zero disables the cap
+	FosterChildrenMaxSponsors int
+
 	// Repositories
 	AccountRepo                   account.Repository
 	AuthRepo                      auth.Repository
@@ -71,16 +90,24 @@ type Container struct {
 	AmbulanceRepo                 ambulance.Repository
 	AmbulanceHistoryRepo          ambulance_history.Repository
 	AmbulanceServiceRequestRepo   ambulance_service_request.Repository
+	AmbulanceFleetRepo            ambulance_fleet.Repository
+	DriverRosterRepo              driver_roster.Repository
 	FosterChildrenRepo            foster_children.Repository
 	FosterChildrenCandidateRepo   foster_children_candidate.Repository
 	FosterChildrenExpenseRepo     foster_children_expense.Repository
 	FosterChildrenTransactionRepo foster_children_transaction.Repository
+	FosterChildrenSponsorshipRepo foster_children_sponsorship.Repository
+	FosterChildrenReportRepo      foster_children_report.Repository
+	FosterChildrenNeedRepo        foster_children_need.Repository
+	AmbulanceTransactionRepo      ambulance_transaction.Repository
 	SocialProgramRepo             social_program.Repository
 	SocialProgramExpenseRepo      social_program_expense.Repository
 	SocialProgramInvoiceRepo      social_program_invoice.Repository
 	SocialProgramSubscriptionRepo social_program_subscription.Repository
 	SocialProgramTransactionRepo  social_program_transaction.Repository
 	LogRepo                       app_log.Repository
+	AccessGrantRepo               access_grant.Repository
+	PrivacyRepo                   privacy.Repository
 
 	// Services
 	AuthService                      auth.Service
@@ -98,16 +125,24 @@ type Container struct {
 	AmbulanceService                 ambulance.Service
 	AmbulanceHistoryService          ambulance_history.Service
 	AmbulanceServiceRequestService   ambulance_service_request.Service
+	AmbulanceFleetService            ambulance_fleet.Service
+	DriverRosterService              driver_roster.Service
 	FosterChildrenService            foster_children.Service
 	FosterChildrenCandidateService   foster_children_candidate.Service
 	FosterChildrenExpenseService     foster_children_expense.Service
 	FosterChildrenTransactionService foster_children_transaction.Service
+	FosterChildrenSponsorshipService foster_children_sponsorship.Service
+	FosterChildrenReportService      foster_children_report.Service
+	FosterChildrenNeedService        foster_children_need.Service
+	AmbulanceTransactionService      ambulance_transaction.Service
 	SocialProgramService             social_program.Service
 	SocialProgramExpenseService      social_program_expense.Service
 	SocialProgramInvoiceService      social_program_invoice.Service
 	SocialProgramSubscriptionService social_program_subscription.Service
 	SocialProgramTransactionService  social_program_transaction.Service
 	LogService                       app_log.Service
+	AccessGrantService               access_grant.Service
+	PrivacyService                   privacy.Service
 	BackupService                    backup.Service
 	BackupRepo                       backup.Repository
 
@@ -175,6 +210,7 @@ func (c *Container) initInfrastructure() error {
 	minioClient := config.ConnectS3()
 	c.MinioClient = minioClient
 	c.S3Client = s3_pkg.NewClient(minioClient)
+	c.PrivateStorage = s3_pkg.NewPrivateClient(minioClient)
 
 	// Timeout
 	timeoutStr := os.Getenv("TIMEOUT")
@@ -184,9 +220,34 @@ func (c *Container) initInfrastructure() error {
 	timeout, _ := strconv.Atoi(timeoutStr)
 	c.Timeout = time.Duration(timeout) * time.Second
 
+	// Emergency ambulance escalation
+	escalationStr := os.Getenv("AMBULANCE_EMERGENCY_ESCALATION_MINUTES")
+	if escalationStr == "" {
+		escalationStr = "5"
+	}
+	escalation, _ := strconv.Atoi(escalationStr)
+	c.AmbulanceEmergencyEscalation = time.Duration(escalation) * time.Minute
+
+	// Foster children sponsor cap
+	maxSponsorsStr := os.Getenv("FOSTER_CHILDREN_MAX_SPONSORS")
+	if maxSponsorsStr == "" {
+		maxSponsorsStr = "3"
+	}
+	c.FosterChildrenMaxSponsors, _ = strconv.Atoi(maxSponsorsStr)
+
 	// Midtrans
 	c.MidtransClient = payment_pkg.NewClient()
 
+	// SMS / WhatsApp
+	c.SMSSender = sms.NewSender()
+
+	// Realtime pub/sub for live streams
+	var brokerRedis *redis.Client
+	if c.RedisClient != nil {
+		brokerRedis = c.RedisClient.GetClient()
+	}
+	c.Broker = realtime.NewBroker(brokerRedis)
+
 	return nil
 }
 
@@ -206,41 +267,57 @@ func (c *Container) initRepositories() {
 	c.AmbulanceRepo = ambulance.NewRepository(c.DB)
 	c.AmbulanceHistoryRepo = ambulance_history.NewRepository(c.DB)
 	c.AmbulanceServiceRequestRepo = ambulance_service_request.NewRepository(c.DB)
+	c.AmbulanceFleetRepo = ambulance_fleet.NewRepository(c.DB)
+	c.DriverRosterRepo = driver_roster.NewRepository(c.DB)
 	c.FosterChildrenRepo = foster_children.NewRepository(c.DB)
 	c.FosterChildrenCandidateRepo = foster_children_candidate.NewRepository(c.DB)
 	c.FosterChildrenExpenseRepo = foster_children_expense.NewRepository(c.DB)
 	c.FosterChildrenTransactionRepo = foster_children_transaction.NewRepository(c.DB)
+	c.FosterChildrenSponsorshipRepo = foster_children_sponsorship.NewRepository(c.DB)
+	c.FosterChildrenReportRepo = foster_children_report.NewRepository(c.DB)
+	c.FosterChildrenNeedRepo = foster_children_need.NewRepository(c.DB)
+	c.AmbulanceTransactionRepo = ambulance_transaction.NewRepository(c.DB)
 	c.SocialProgramRepo = social_program.NewRepository(c.DB)
 	c.SocialProgramExpenseRepo = social_program_expense.NewRepository(c.DB)
 	c.SocialProgramInvoiceRepo = social_program_invoice.NewRepository(c.DB)
 	c.SocialProgramSubscriptionRepo = social_program_subscription.NewRepository(c.DB)
 	c.SocialProgramTransactionRepo = social_program_transaction.NewRepository(c.DB)
 	c.LogRepo = app_log.NewRepository(c.DB)
+	c.AccessGrantRepo = access_grant.NewRepository(c.DB)
+	c.PrivacyRepo = privacy.NewRepository(c.DB)
 	c.BackupRepo = backup.NewRepository(c.DB)
 }
 
 func (c *Container) initServices() {
 	c.LogService = app_log.NewService(c.LogRepo, c.Timeout)
 	c.AuthService = auth.NewService(c.AuthRepo, c.AccountRepo, c.Timeout)
-	c.AccountService = account.NewService(c.AccountRepo, c.Timeout, c.S3Client)
+	c.AccountService = account.NewService(c.AccountRepo, c.Timeout, c.S3Client, c.SMSSender)
+	c.AccessGrantService = access_grant.NewService(c.AccessGrantRepo, c.AccountRepo, c.LogService, c.Timeout)
+	c.PrivacyService = privacy.NewService(c.PrivacyRepo, c.AccountRepo, c.LogService, c.S3Client, c.PrivateStorage, c.Timeout)
 	c.FinanceRecordService = finance_record.NewService(c.FinanceRecordRepo, c.Timeout)
-	c.DonationService = donation_program.NewService(c.DonationRepo, c.LogService, c.S3Client, c.Timeout)
+	c.DonationService = donation_program.NewService(c.DonationRepo, c.AccessGrantRepo, c.LogService, c.S3Client, c.Timeout)
 	c.MediaService = media.NewService(c.MediaRepo, c.S3Client)
 	c.NewsService = news.NewService(c.NewsRepo, c.LogService, c.S3Client, c.MediaService, c.Timeout)
 	c.NewsCommentService = news_comment.NewService(c.NewsCommentRepo, c.NewsRepo, c.Timeout)
 	c.FoundationProfileService = foundation_profile.NewService(c.FoundationProfileRepo, c.LogService, c.S3Client, c.Timeout)
 	c.GalleryService = gallery.NewService(c.GalleryRepo, c.LogService, c.S3Client, c.MediaService, c.Timeout)
 	c.TransactionDonationService = donation_program_transaction.NewService(c.TransactionDonationRepo, c.AccountRepo, c.DonationRepo, c.PrayerRepo, c.FinanceRecordRepo, c.MidtransClient, c.LogService, c.Timeout)
+	c.AmbulanceTransactionService = ambulance_transaction.NewService(c.AmbulanceTransactionRepo, c.AccountRepo, c.AmbulanceRepo, c.AmbulanceServiceRequestRepo, c.FinanceRecordRepo, c.MidtransClient, c.LogService, c.Timeout)
 	c.PrayerService = prayer.NewService(c.PrayerRepo, c.DonationRepo, c.Timeout)
 	c.DonationExpenseService = donation_program_expense.NewService(c.DonationExpenseRepo, c.FinanceRecordRepo, c.DonationRepo, c.S3Client, c.LogService, c.Timeout)
 	c.AmbulanceService = ambulance.NewService(c.AmbulanceRepo, c.S3Client, c.Timeout)
-	c.AmbulanceHistoryService = ambulance_history.NewService(c.AmbulanceHistoryRepo, c.AmbulanceRepo, c.Timeout)
-	c.AmbulanceServiceRequestService = ambulance_service_request.NewService(c.AmbulanceServiceRequestRepo, c.AmbulanceRepo, c.AmbulanceHistoryRepo, c.Timeout, c.S3Client)
-	c.FosterChildrenService = foster_children.NewService(c.FosterChildrenRepo, c.LogService, c.S3Client, c.Timeout)
-	c.FosterChildrenCandidateService = foster_children_candidate.NewService(c.FosterChildrenCandidateRepo, c.FosterChildrenRepo, c.LogService, c.S3Client, c.Timeout)
-	c.FosterChildrenExpenseService = foster_children_expense.NewService(c.FosterChildrenExpenseRepo, c.FinanceRecordRepo, c.FosterChildrenRepo, c.S3Client, c.LogService, c.Timeout)
-	c.FosterChildrenTransactionService = foster_children_transaction.NewService(c.FosterChildrenTransactionRepo, c.AccountRepo, c.FosterChildrenRepo, c.FinanceRecordRepo, c.MidtransClient, c.LogService, c.Timeout)
-	c.SocialProgramService = social_program.NewService(c.SocialProgramRepo, c.LogService, c.S3Client, c.Timeout)
+	c.AmbulanceHistoryService = ambulance_history.NewService(c.AmbulanceHistoryRepo, c.AmbulanceRepo, c.S3Client, c.LogService, c.Timeout)
+	c.AmbulanceServiceRequestService = ambulance_service_request.NewService(c.AmbulanceServiceRequestRepo, c.AmbulanceRepo, c.AmbulanceHistoryRepo, c.DriverRosterRepo, c.Timeout, c.S3Client, c.PrivateStorage, c.Broker, c.SMSSender, c.AmbulanceEmergencyEscalation)
+	c.AmbulanceFleetService = ambulance_fleet.NewService(c.AmbulanceFleetRepo, c.S3Client, c.LogService, c.Timeout)
+	c.DriverRosterService = driver_roster.NewService(c.DriverRosterRepo, c.LogService, c.Timeout)
+	c.FosterChildrenService = foster_children.NewService(c.FosterChildrenRepo, c.AccessGrantRepo, c.LogService, c.S3Client, c.PrivateStorage, c.Timeout)
+	c.FosterChildrenCandidateService = foster_children_candidate.NewService(c.FosterChildrenCandidateRepo, c.FosterChildrenRepo, c.AccessGrantRepo, c.LogService, c.S3Client, c.PrivateStorage, c.Timeout)
+	c.FosterChildrenExpenseService = foster_children_expense.NewService(c.FosterChildrenExpenseRepo, c.FinanceRecordRepo, c.FosterChildrenRepo, c.AccessGrantRepo, c.S3Client, c.LogService, c.Timeout)
+	c.FosterChildrenTransactionService = foster_children_transaction.NewService(c.FosterChildrenTransactionRepo, c.AccountRepo, c.FosterChildrenRepo, c.FosterChildrenNeedRepo, c.FinanceRecordRepo, c.MidtransClient, c.LogService, c.Timeout)
+	c.FosterChildrenSponsorshipService = foster_children_sponsorship.NewService(c.FosterChildrenSponsorshipRepo, c.FosterChildrenRepo, c.FosterChildrenTransactionService, c.AccessGrantRepo, c.LogService, c.FosterChildrenMaxSponsors, c.Timeout)
+	c.FosterChildrenReportService = foster_children_report.NewService(c.FosterChildrenReportRepo, c.FosterChildrenRepo, c.FosterChildrenSponsorshipRepo, c.AccessGrantRepo, c.PrivateStorage, c.LogService, c.Timeout)
+	c.FosterChildrenNeedService = foster_children_need.NewService(c.FosterChildrenNeedRepo, c.FosterChildrenRepo, c.FosterChildrenExpenseRepo, c.AccessGrantRepo, c.S3Client, c.LogService, c.Timeout)
+	c.SocialProgramService = social_program.NewService(c.SocialProgramRepo, c.AccessGrantRepo, c.LogService, c.S3Client, c.Timeout)
 	c.SocialProgramExpenseService = social_program_expense.NewService(c.SocialProgramExpenseRepo, c.FinanceRecordRepo, c.SocialProgramRepo, c.S3Client, c.LogService, c.Timeout)
 	c.SocialProgramInvoiceService = social_program_invoice.NewService(c.SocialProgramInvoiceRepo, c.SocialProgramSubscriptionRepo, c.Timeout)
 	c.SocialProgramSubscriptionService = social_program_subscription.NewService(c.SocialProgramSubscriptionRepo, c.SocialProgramRepo, c.Timeout)
@@ -280,6 +357,21 @@ func (c *Container) initScheduler() {
 		}
 	})
 
+	// Email ambulance managers about due maintenance and expiring vehicle documents daily at 6 AM
+	c.Scheduler.Add("0 6 * * *", "fleet-reminders", func() {
+		_ = c.AmbulanceFleetService.SendFleetReminders(context.Background())
+	})
+
+	// Escalate unclaimed emergency ambulance requests every minute
+	c.Scheduler.Add("* * * * *", "escalate-emergency-ambulance-requests", func() {
+		_ = c.AmbulanceServiceRequestService.EscalateEmergencyRequests(context.Background())
+	})
+
+	// Abort video upload sessions that were never confirmed every hour
+	c.Scheduler.Add("0 * * * *", "cleanup-expired-uploads", func() {
+		_ = c.MediaService.CleanupExpiredUploads(context.Background())
+	})
+
 	// Create database backup daily at 2 AM
 	c.Scheduler.Add("0 2 * * *", "database-backup", func() {
 		_ = c.BackupService.CreateBackup(context.Background())
@@ -296,6 +388,8 @@ func (c *Container) initScheduler() {
 func (c *Container) RegisterHandlers(router *gin.RouterGroup) {
 	auth.NewHandler(router, c.AuthService, c.AccountService, *c.Middleware)
 	account.NewHandler(router, c.AccountService, *c.Middleware)
+	access_grant.NewHandler(router, c.AccessGrantService, *c.Middleware)
+	privacy.NewHandler(router, c.PrivacyService, *c.Middleware)
 	finance_record.NewHandler(router, c.FinanceRecordService, *c.Middleware)
 	donation_program.NewHandler(router, c.DonationService, *c.Middleware)
 	donation_program_transaction.NewHandler(router, c.TransactionDonationService, *c.Middleware)
@@ -308,10 +402,16 @@ func (c *Container) RegisterHandlers(router *gin.RouterGroup) {
 	ambulance.NewHandler(router, c.AmbulanceService, *c.Middleware)
 	ambulance_history.NewHandler(router, c.AmbulanceHistoryService, *c.Middleware)
 	ambulance_service_request.NewHandler(router, c.AmbulanceServiceRequestService, *c.Middleware)
+	ambulance_fleet.NewHandler(router, c.AmbulanceFleetService, *c.Middleware)
+	driver_roster.NewHandler(router, c.DriverRosterService, *c.Middleware)
 	foster_children.NewHandler(router, c.FosterChildrenService, *c.Middleware)
 	foster_children_candidate.NewHandler(router, c.FosterChildrenCandidateService, *c.Middleware)
 	foster_children_expense.NewHandler(router, c.FosterChildrenExpenseService, *c.Middleware)
 	foster_children_transaction.NewHandler(router, c.FosterChildrenTransactionService, *c.Middleware)
+	foster_children_sponsorship.NewHandler(router, c.FosterChildrenSponsorshipService, *c.Middleware)
+	foster_children_report.NewHandler(router, c.FosterChildrenReportService, *c.Middleware)
+	foster_children_need.NewHandler(router, c.FosterChildrenNeedService, *c.Middleware)
+	ambulance_transaction.NewHandler(router, c.AmbulanceTransactionService, *c.Middleware)
 	social_program.NewHandler(router, c.SocialProgramService, *c.Middleware)
 	social_program_expense.NewHandler(router, c.SocialProgramExpenseService, *c.Middleware)
 	social_program_invoice.NewHandler(router, c.SocialProgramInvoiceService, *c.Middleware)
@@ -322,5 +422,5 @@ func (c *Container) RegisterHandlers(router *gin.RouterGroup) {
 
 	// Payment Webhooks
 	paymentGroup := router.Group("/webhooks")
-	payment.NewHandler(paymentGroup, c.TransactionDonationService, c.SocialProgramTransactionService, c.FosterChildrenTransactionService)
+	payment.NewHandler(paymentGroup, c.TransactionDonationService, c.SocialProgramTransactionService, c.FosterChildrenTransactionService, c.AmbulanceTransactionService)
 }
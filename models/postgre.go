@@ -1,19 +1,26 @@
 package postgre_models
 
 import (
+	"github.com/Vilamuzz/yota-backend/app/access_grant"
 	"github.com/Vilamuzz/yota-backend/app/account"
 	"github.com/Vilamuzz/yota-backend/app/ambulance"
+	"github.com/Vilamuzz/yota-backend/app/ambulance_fleet"
 	"github.com/Vilamuzz/yota-backend/app/ambulance_history"
 	"github.com/Vilamuzz/yota-backend/app/ambulance_service_request"
+	"github.com/Vilamuzz/yota-backend/app/ambulance_transaction"
 	"github.com/Vilamuzz/yota-backend/app/auth"
 	"github.com/Vilamuzz/yota-backend/app/backup"
 	"github.com/Vilamuzz/yota-backend/app/donation_program"
 	"github.com/Vilamuzz/yota-backend/app/donation_program_expense"
 	"github.com/Vilamuzz/yota-backend/app/donation_program_transaction"
+	"github.com/Vilamuzz/yota-backend/app/driver_roster"
 	"github.com/Vilamuzz/yota-backend/app/finance_record"
 	"github.com/Vilamuzz/yota-backend/app/foster_children"
 	"github.com/Vilamuzz/yota-backend/app/foster_children_candidate"
 	"github.com/Vilamuzz/yota-backend/app/foster_children_expense"
+	"github.com/Vilamuzz/yota-backend/app/foster_children_need"
+	"github.com/Vilamuzz/yota-backend/app/foster_children_report"
+	"github.com/Vilamuzz/yota-backend/app/foster_children_sponsorship"
 	"github.com/Vilamuzz/yota-backend/app/foster_children_transaction"
 	"github.com/Vilamuzz/yota-backend/app/foundation_profile"
 	"github.com/Vilamuzz/yota-backend/app/gallery"
@@ -36,8 +43,15 @@ func GetAllModels() []interface{} {
 		&account.Account{},
 		&account.UserProfile{},
 		&account.AccountRole{},
+		&account.EmailChangeToken{},
+		&account.PhoneVerificationCode{},
+		&access_grant.AccessGrant{},
+		&access_grant.AccessScope{},
 		&auth.PasswordResetToken{},
 		&auth.EmailVerificationToken{},
+		&auth.LoginToken{},
+		&auth.AccountIdentity{},
+		&auth.IdentityLinkToken{},
 		&donation_program.DonationProgram{},
 		&donation_program_transaction.DonationProgramTransaction{},
 		&donation_program_expense.DonationProgramExpense{},
@@ -50,10 +64,24 @@ func GetAllModels() []interface{} {
 		&social_program_transaction.SocialProgramTransaction{},
 		&social_program_expense.SocialProgramExpense{},
 		&foster_children.FosterChildren{},
+		&foster_children.LifecycleEvent{},
 		&foster_children.Achivement{},
 		&foster_children_candidate.FosterChildrenCandidate{},
+		&foster_children_candidate.ReviewStage{},
+		&foster_children_candidate.ReviewStageChecklistItem{},
+		&foster_children_candidate.CandidateReview{},
+		&foster_children_candidate.CandidateReviewChecklistItem{},
+		&foster_children_candidate.CandidateReviewPhoto{},
+		&foster_children_candidate.CandidateReviewVote{},
+		&foster_children_candidate.CandidateReviewEvent{},
 		&foster_children_expense.FosterChildrenExpense{},
+		&foster_children_need.FosterChildrenNeed{},
+		&foster_children_need.NeedProofPhoto{},
 		&foster_children_transaction.FosterChildrenTransaction{},
+		&foster_children_sponsorship.FosterChildrenSponsorship{},
+		&foster_children_report.FosterChildrenReport{},
+		&foster_children_report.ReportGrade{},
+		&foster_children_report.ReportPhoto{},
 		&finance_record.FinanceRecord{},
 		&foundation_profile.FoundationProfile{},
 		&news.News{},
@@ -61,9 +89,23 @@ func GetAllModels() []interface{} {
 		&news_comment.NewsCommentReport{},
 		&gallery.Gallery{},
 		&media.Media{},
+		&media.UploadSession{},
 		&ambulance.Ambulance{},
 		&ambulance_service_request.AmbulanceServiceRequest{},
+		&ambulance_service_request.TripLocation{},
 		&ambulance_history.AmbulanceHistory{},
+		&ambulance_history.TripFeedback{},
+		&ambulance_history.IncidentReport{},
+		&ambulance_history.IncidentPhoto{},
+		&ambulance_history.TripCost{},
+		&ambulance_transaction.AmbulanceTransaction{},
+		&ambulance_fleet.OdometerReading{},
+		&ambulance_fleet.FuelLog{},
+		&ambulance_fleet.MaintenanceSchedule{},
+		&ambulance_fleet.MaintenanceJob{},
+		&ambulance_fleet.VehicleDocument{},
+		&driver_roster.DriverShift{},
+		&driver_roster.DriverLeave{},
 		&backup.Backup{},
 	}
 }
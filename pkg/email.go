@@ -120,3 +120,62 @@ func (e *EmailService) SendAmbulanceServiceRequestRejectedEmail(to, recipientNam
 
 	return e.SendEmail(to, subject, body)
 }
+
+func (e *EmailService) SendPasswordlessLoginEmail(to, username, loginToken, code string, ttlMinutes int) error {
+	loginURL := fmt.Sprintf("%s/auth/magic-link?token=%s", os.Getenv("FE_URL"), loginToken)
+
+	subject := "Kode Masuk Akun Anda"
+	body := PasswordlessLoginTemplate(username, loginURL, code, ttlMinutes)
+
+	return e.SendEmail(to, subject, body)
+}
+
+func (e *EmailService) SendEmailChangeVerificationEmail(to, username, verificationToken string, ttlMinutes int) error {
+	verificationURL := fmt.Sprintf("%s/verify-email-change?token=%s", os.Getenv("FE_URL"), verificationToken)
+
+	subject := "Konfirmasi Perubahan Email"
+	body := EmailChangeVerificationTemplate(username, to, verificationURL, ttlMinutes)
+
+	return e.SendEmail(to, subject, body)
+}
+
+func (e *EmailService) SendEmailChangedNotificationEmail(to, username, newEmail string) error {
+	subject := "Email Akun Anda Telah Diubah"
+	body := EmailChangedNotificationTemplate(username, newEmail)
+
+	return e.SendEmail(to, subject, body)
+}
+
+func (e *EmailService) SendFleetReminderEmail(to string, maintenanceItems, documentItems []string) error {
+	subject := "Pengingat Perawatan dan Dokumen Ambulans"
+	body := FleetReminderTemplate(maintenanceItems, documentItems)
+
+	return e.SendEmail(to, subject, body)
+}
+
+func (e *EmailService) SendEmergencyDispatchEmail(to, recipientName string, details []string, escalationLevel int) error {
+	subject := "[DARURAT] Permintaan Ambulans Darurat"
+	if escalationLevel > 0 {
+		subject = fmt.Sprintf("[DARURAT] Eskalasi %d: Permintaan Ambulans Belum Diklaim", escalationLevel)
+	}
+	body := EmergencyDispatchTemplate(recipientName, details, escalationLevel)
+
+	return e.SendEmail(to, subject, body)
+}
+
+func (e *EmailService) SendAmbulanceTripCompletedEmail(to, recipientName, submitterName, requestID string) error {
+	subject := "Layanan Ambulans Selesai"
+	feedbackURL := fmt.Sprintf("%s/ambulance/requests/%s/feedback", os.Getenv("FE_URL"), requestID)
+	donationURL := fmt.Sprintf("%s/ambulance/requests/%s/donate", os.Getenv("FE_URL"), requestID)
+	body := AmbulanceTripCompletedTemplate(recipientName, submitterName, feedbackURL, donationURL)
+
+	return e.SendEmail(to, subject, body)
+}
+
+func (e *EmailService) SendFosterChildrenReportPublishedEmail(to, sponsorName, childName, term, reportID string) error {
+	subject := "Laporan Perkembangan Anak Asuh Terbaru"
+	reportURL := fmt.Sprintf("%s/foster-children/reports/%s", os.Getenv("FE_URL"), reportID)
+	body := FosterChildrenReportPublishedTemplate(sponsorName, childName, term, reportURL)
+
+	return e.SendEmail(to, subject, body)
+}
@@ -1,6 +1,10 @@
 package pkg
 
-import "fmt"
+import (
+	"fmt"
+	"html"
+	"strings"
+)
 
 // PasswordResetTemplate generates the HTML body for a password reset email.
 func PasswordResetTemplate(recipientName, resetURL string) string {
@@ -398,3 +402,497 @@ func AmbulanceServiceRequestRejectedTemplate(recipientName, submitterName, rejec
             </body>
         </html>`, recipientName, submitterName, rejectionReason)
 }
+
+// PasswordlessLoginTemplate generates the HTML body for a magic link and one-time code login email.
+func PasswordlessLoginTemplate(recipientName, loginURL, code string, ttlMinutes int) string {
+	return fmt.Sprintf(`
+        <!DOCTYPE html>
+        <html lang="id">
+            <head>
+              <meta charset="UTF-8" />
+              <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+              <title>Masuk ke Akun Anda</title>
+              <link href="https://fonts.googleapis.com/css2?family=Poppins:wght@400;500;600&display=swap" rel="stylesheet">
+            </head>
+            <body style="margin:0; padding:0; background-color:#f4f6f8;">
+              <table width="100%%" cellpadding="0" cellspacing="0" role="presentation" style="background-color:#f4f6f8;">
+                <tr>
+                  <td align="center" style="padding:40px 16px;">
+                    <table width="100%%" cellpadding="0" cellspacing="0" role="presentation" style="max-width:600px; background-color:#ffffff; border-radius:8px; padding:32px; font-family:'Poppins', Arial, sans-serif; text-align:center; color:#333333;">
+
+                      <tr>
+                        <td style="font-size:22px; font-weight:600; padding-bottom:16px;">
+                          Masuk ke Akun Anda
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:15px; padding-bottom:12px;">
+                          Hai, <strong>%s</strong>
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:14px; line-height:1.6; padding-bottom:20px;">
+                          Kami menerima permintaan untuk masuk ke akun Anda di Yayasan Orang Tua Asuh tanpa kata sandi.
+                          <br /><br />
+                          Silakan tekan tombol di bawah ini untuk masuk:
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="padding:20px 0;">
+                          <a href="%s"
+                             style="display:inline-block; background-color:#0E733B; color:#ffffff; text-decoration:none; font-size:14px; font-weight:500; padding:14px 96px; border-radius:6px;">
+                            Masuk Sekarang
+                          </a>
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:14px; line-height:1.6; padding-bottom:12px;">
+                          Atau masukkan kode berikut pada halaman masuk:
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:32px; font-weight:600; letter-spacing:8px; color:#0E733B; padding-bottom:20px;">
+                          %s
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:13px; line-height:1.6; padding-bottom:24px; color:#555555;">
+                          Tautan dan kode ini hanya dapat digunakan satu kali dan akan kedaluwarsa dalam %d menit. Apabila Anda tidak merasa melakukan permintaan ini, silakan abaikan email ini.
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:14px;">
+                          Terima kasih,
+                          <br />
+                          <strong>Yayasan Orang Tua Asuh</strong>
+                        </td>
+                      </tr>
+
+                    </table>
+                  </td>
+                </tr>
+              </table>
+            </body>
+        </html>`, recipientName, loginURL, code, ttlMinutes)
+}
+
+// EmailChangeVerificationTemplate generates the HTML body sent to a new address to confirm an email change.
+func EmailChangeVerificationTemplate(recipientName, newEmail, verificationURL string, ttlMinutes int) string {
+	return fmt.Sprintf(`
+        <!DOCTYPE html>
+        <html lang="id">
+            <head>
+              <meta charset="UTF-8" />
+              <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+              <title>Konfirmasi Perubahan Email</title>
+              <link href="https://fonts.googleapis.com/css2?family=Poppins:wght@400;500;600&display=swap" rel="stylesheet">
+            </head>
+            <body style="margin:0; padding:0; background-color:#f4f6f8;">
+              <table width="100%%" cellpadding="0" cellspacing="0" role="presentation" style="background-color:#f4f6f8;">
+                <tr>
+                  <td align="center" style="padding:40px 16px;">
+                    <table width="100%%" cellpadding="0" cellspacing="0" role="presentation" style="max-width:600px; background-color:#ffffff; border-radius:8px; padding:32px; font-family:'Poppins', Arial, sans-serif; text-align:center; color:#333333;">
+
+                      <tr>
+                        <td style="font-size:22px; font-weight:600; padding-bottom:16px;">
+                          Konfirmasi Perubahan Email
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:15px; padding-bottom:12px;">
+                          Hai, <strong>%s</strong>
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:14px; line-height:1.6; padding-bottom:20px;">
+                          Kami menerima permintaan untuk mengubah email akun Anda di Yayasan Orang Tua Asuh menjadi <strong>%s</strong>.
+                          <br /><br />
+                          Silakan tekan tombol di bawah ini untuk mengonfirmasi perubahan:
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="padding:20px 0;">
+                          <a href="%s"
+                             style="display:inline-block; background-color:#0E733B; color:#ffffff; text-decoration:none; font-size:14px; font-weight:500; padding:14px 96px; border-radius:6px;">
+                            Konfirmasi Email
+                          </a>
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:13px; line-height:1.6; padding-bottom:24px; color:#555555;">
+                          Tautan ini akan kedaluwarsa dalam %d menit. Email akun Anda tidak akan berubah sebelum dikonfirmasi. Apabila Anda tidak merasa melakukan permintaan ini, silakan abaikan email ini.
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:14px;">
+                          Terima kasih,
+                          <br />
+                          <strong>Yayasan Orang Tua Asuh</strong>
+                        </td>
+                      </tr>
+
+                    </table>
+                  </td>
+                </tr>
+              </table>
+            </body>
+        </html>`, recipientName, newEmail, verificationURL, ttlMinutes)
+}
+
+// EmailChangedNotificationTemplate generates the HTML body that tells the previous address its email was changed.
+func EmailChangedNotificationTemplate(recipientName, newEmail string) string {
+	return fmt.Sprintf(`
+        <!DOCTYPE html>
+        <html lang="id">
+            <head>
+              <meta charset="UTF-8" />
+              <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+              <title>Email Akun Telah Diubah</title>
+              <link href="https://fonts.googleapis.com/css2?family=Poppins:wght@400;500;600&display=swap" rel="stylesheet">
+            </head>
+            <body style="margin:0; padding:0; background-color:#f4f6f8;">
+              <table width="100%%" cellpadding="0" cellspacing="0" role="presentation" style="background-color:#f4f6f8;">
+                <tr>
+                  <td align="center" style="padding:40px 16px;">
+                    <table width="100%%" cellpadding="0" cellspacing="0" role="presentation" style="max-width:600px; background-color:#ffffff; border-radius:8px; padding:32px; font-family:'Poppins', Arial, sans-serif; text-align:center; color:#333333;">
+
+                      <tr>
+                        <td style="font-size:22px; font-weight:600; padding-bottom:16px;">
+                          Email Akun Telah Diubah
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:15px; padding-bottom:12px;">
+                          Hai, <strong>%s</strong>
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:14px; line-height:1.6; padding-bottom:24px;">
+                          Email akun Anda di Yayasan Orang Tua Asuh telah diubah menjadi <strong>%s</strong>. Mulai sekarang, gunakan email tersebut untuk masuk.
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:13px; line-height:1.6; padding-bottom:24px; color:#555555;">
+                          Apabila Anda tidak merasa melakukan perubahan ini, segera hubungi kami agar akun Anda dapat diamankan.
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:14px; border-top:1px solid #eeeeee; padding-top:24px;">
+                          Terima kasih,
+                          <br />
+                          <strong>Yayasan Orang Tua Asuh</strong>
+                        </td>
+                      </tr>
+
+                    </table>
+                  </td>
+                </tr>
+              </table>
+            </body>
+        </html>`, recipientName, newEmail)
+}
+
+// htmlListItems renders plain text lines as escaped <li> elements.
+func htmlListItems(items []string) string {
+	var b strings.Builder
+	for _, item := range items {
+		b.WriteString("<li style=\"padding-bottom:6px;\">")
+		b.WriteString(html.EscapeString(item))
+		b.WriteString("</li>")
+	}
+	return b.String()
+}
+
+// FleetReminderTemplate generates the HTML body for the ambulance fleet maintenance and document reminder.
+func FleetReminderTemplate(maintenanceItems, documentItems []string) string {
+	var sections strings.Builder
+	if len(maintenanceItems) > 0 {
+		sections.WriteString(fmt.Sprintf(`
+                      <tr>
+                        <td style="font-size:15px; font-weight:600; padding-bottom:8px; text-align:left;">
+                          Perawatan yang akan jatuh tempo
+                        </td>
+                      </tr>
+                      <tr>
+                        <td style="font-size:14px; line-height:1.6; padding-bottom:16px; text-align:left;">
+                          <ul style="margin:0; padding-left:20px;">%s</ul>
+                        </td>
+                      </tr>`, htmlListItems(maintenanceItems)))
+	}
+	if len(documentItems) > 0 {
+		sections.WriteString(fmt.Sprintf(`
+                      <tr>
+                        <td style="font-size:15px; font-weight:600; padding-bottom:8px; text-align:left;">
+                          Dokumen kendaraan yang perlu diperpanjang
+                        </td>
+                      </tr>
+                      <tr>
+                        <td style="font-size:14px; line-height:1.6; padding-bottom:16px; text-align:left;">
+                          <ul style="margin:0; padding-left:20px;">%s</ul>
+                        </td>
+                      </tr>`, htmlListItems(documentItems)))
+	}
+
+	return fmt.Sprintf(`
+        <!DOCTYPE html>
+        <html lang="id">
+            <head>
+              <meta charset="UTF-8" />
+              <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+              <title>Pengingat Armada Ambulans</title>
+              <link href="https://fonts.googleapis.com/css2?family=Poppins:wght@400;500;600&display=swap" rel="stylesheet">
+            </head>
+            <body style="margin:0; padding:0; background-color:#f4f6f8;">
+              <table width="100%%" cellpadding="0" cellspacing="0" role="presentation" style="background-color:#f4f6f8;">
+                <tr>
+                  <td align="center" style="padding:40px 16px;">
+                    <table width="100%%" cellpadding="0" cellspacing="0" role="presentation" style="max-width:600px; background-color:#ffffff; border-radius:8px; padding:32px; font-family:'Poppins', Arial, sans-serif; text-align:center; color:#333333;">
+
+                      <tr>
+                        <td style="font-size:22px; font-weight:600; padding-bottom:16px; color:#0E733B;">
+                          Pengingat Armada Ambulans
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:14px; line-height:1.6; padding-bottom:24px;">
+                          Berikut daftar ambulans yang memerlukan perhatian. Silakan jadwalkan perawatan atau perpanjang dokumen sebelum jatuh tempo.
+                        </td>
+                      </tr>
+%s
+
+                      <tr>
+                        <td style="font-size:14px; border-top:1px solid #eeeeee; padding-top:24px;">
+                          Terima kasih,
+                          <br />
+                          <strong>Yayasan Orang Tua Asuh</strong>
+                        </td>
+                      </tr>
+
+                    </table>
+                  </td>
+                </tr>
+              </table>
+            </body>
+        </html>`, sections.String())
+}
+
+// EmergencyDispatchTemplate generates the HTML body for an unclaimed emergency ambulance request.
+func EmergencyDispatchTemplate(recipientName string, details []string, escalationLevel int) string {
+	title := "Permintaan Ambulans Darurat"
+	intro := "Ada permintaan ambulans darurat yang belum ditangani. Supir yang bertugas dapat langsung mengklaim permintaan ini melalui aplikasi."
+	if escalationLevel > 0 {
+		title = "Eskalasi Permintaan Ambulans Darurat"
+		intro = fmt.Sprintf("Permintaan ambulans darurat berikut <strong>belum diklaim</strong> oleh supir mana pun (eskalasi ke-%d). Mohon segera dikoordinasikan.", escalationLevel)
+	}
+
+	return fmt.Sprintf(`
+        <!DOCTYPE html>
+        <html lang="id">
+            <head>
+              <meta charset="UTF-8" />
+              <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+              <title>%s</title>
+              <link href="https://fonts.googleapis.com/css2?family=Poppins:wght@400;500;600&display=swap" rel="stylesheet">
+            </head>
+            <body style="margin:0; padding:0; background-color:#f4f6f8;">
+              <table width="100%%" cellpadding="0" cellspacing="0" role="presentation" style="background-color:#f4f6f8;">
+                <tr>
+                  <td align="center" style="padding:40px 16px;">
+                    <table width="100%%" cellpadding="0" cellspacing="0" role="presentation" style="max-width:600px; background-color:#ffffff; border-radius:8px; padding:32px; font-family:'Poppins', Arial, sans-serif; text-align:center; color:#333333;">
+
+                      <tr>
+                        <td style="font-size:22px; font-weight:600; padding-bottom:16px; color:#C62828;">
+                          %s
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:15px; padding-bottom:12px;">
+                          Hai, <strong>%s</strong>
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:14px; line-height:1.6; padding-bottom:16px;">
+                          %s
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:14px; line-height:1.6; padding-bottom:24px; text-align:left;">
+                          <ul style="margin:0; padding-left:20px;">%s</ul>
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:14px; border-top:1px solid #eeeeee; padding-top:24px;">
+                          Terima kasih,
+                          <br />
+                          <strong>Yayasan Orang Tua Asuh</strong>
+                        </td>
+                      </tr>
+
+                    </table>
+                  </td>
+                </tr>
+              </table>
+            </body>
+        </html>`, title, title, html.EscapeString(recipientName), intro, htmlListItems(details))
+}
+
+// AmbulanceTripCompletedTemplate thanks the requester after a finished trip and links to the rating and donation pages.
+func AmbulanceTripCompletedTemplate(recipientName, submitterName, feedbackURL, donationURL string) string {
+	return fmt.Sprintf(`
+        <!DOCTYPE html>
+        <html lang="id">
+            <head>
+              <meta charset="UTF-8" />
+              <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+              <title>Layanan Ambulans Selesai</title>
+              <link href="https://fonts.googleapis.com/css2?family=Poppins:wght@400;500;600&display=swap" rel="stylesheet">
+            </head>
+            <body style="margin:0; padding:0; background-color:#f4f6f8;">
+              <table width="100%%" cellpadding="0" cellspacing="0" role="presentation" style="background-color:#f4f6f8;">
+                <tr>
+                  <td align="center" style="padding:40px 16px;">
+                    <table width="100%%" cellpadding="0" cellspacing="0" role="presentation" style="max-width:600px; background-color:#ffffff; border-radius:8px; padding:32px; font-family:'Poppins', Arial, sans-serif; text-align:center; color:#333333;">
+
+                      <tr>
+                        <td style="font-size:22px; font-weight:600; padding-bottom:16px; color:#0E733B;">
+                          Layanan Ambulans Selesai
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:15px; padding-bottom:12px;">
+                          Hai, <strong>%s</strong>
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:14px; line-height:1.6; padding-bottom:24px;">
+                          Layanan ambulans untuk permintaan atas nama <strong>%s</strong> telah <strong>selesai</strong>.
+                          <br /><br />
+                          Mohon luangkan waktu sejenak untuk menilai pelayanan supir dan kendaraan kami agar kami dapat terus memperbaiki layanan.
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="padding:8px 0 20px;">
+                          <a href="%s"
+                             style="display:inline-block; background-color:#0E733B; color:#ffffff; text-decoration:none; font-size:14px; font-weight:500; padding:14px 64px; border-radius:6px;">
+                            Beri Penilaian
+                          </a>
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:13px; line-height:1.6; padding-bottom:16px; color:#555555;">
+                          Layanan ambulans kami gratis. Jika berkenan, Anda dapat membantu biaya operasional ambulans (bahan bakar, tol, dan uang saku supir) melalui donasi.
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="padding:0 0 24px;">
+                          <a href="%s"
+                             style="display:inline-block; background-color:#ffffff; color:#0E733B; border:1px solid #0E733B; text-decoration:none; font-size:14px; font-weight:500; padding:12px 64px; border-radius:6px;">
+                            Donasi Layanan Ambulans
+                          </a>
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:14px; border-top:1px solid #eeeeee; padding-top:24px;">
+                          Terima kasih,
+                          <br />
+                          <strong>Yayasan Orang Tua Asuh</strong>
+                        </td>
+                      </tr>
+
+                    </table>
+                  </td>
+                </tr>
+              </table>
+            </body>
+        </html>`, recipientName, submitterName, feedbackURL, donationURL)
+}
+
+// FosterChildrenReportPublishedTemplate generates the HTML body sent to sponsors when a progress
+// report of their foster child is published.
+func FosterChildrenReportPublishedTemplate(sponsorName, childName, term, reportURL string) string {
+	return fmt.Sprintf(`
+        <!DOCTYPE html>
+        <html lang="id">
+            <head>
+              <meta charset="UTF-8" />
+              <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+              <title>Laporan Perkembangan Anak Asuh</title>
+              <link href="https://fonts.googleapis.com/css2?family=Poppins:wght@400;500;600&display=swap" rel="stylesheet">
+            </head>
+            <body style="margin:0; padding:0; background-color:#f4f6f8;">
+              <table width="100%%" cellpadding="0" cellspacing="0" role="presentation" style="background-color:#f4f6f8;">
+                <tr>
+                  <td align="center" style="padding:40px 16px;">
+                    <table width="100%%" cellpadding="0" cellspacing="0" role="presentation" style="max-width:600px; background-color:#ffffff; border-radius:8px; padding:32px; font-family:'Poppins', Arial, sans-serif; text-align:center; color:#333333;">
+
+                      <tr>
+                        <td style="font-size:22px; font-weight:600; padding-bottom:16px; color:#0E733B;">
+                          Laporan Perkembangan Terbaru
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:15px; padding-bottom:12px;">
+                          Hai, <strong>%s</strong>
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:14px; line-height:1.6; padding-bottom:24px;">
+                          Laporan perkembangan <strong>%s</strong> untuk <strong>%s</strong> telah terbit.
+                          <br /><br />
+                          Laporan ini berisi nilai sekolah, kehadiran, catatan kesehatan, dan cerita dari koordinator sosial kami.
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="padding:8px 0 20px;">
+                          <a href="%s"
+                             style="display:inline-block; background-color:#0E733B; color:#ffffff; text-decoration:none; font-size:14px; font-weight:500; padding:14px 64px; border-radius:6px;">
+                            Lihat Laporan
+                          </a>
+                        </td>
+                      </tr>
+
+                      <tr>
+                        <td style="font-size:14px; border-top:1px solid #eeeeee; padding-top:24px;">
+                          Terima kasih atas dukungan Anda,
+                          <br />
+                          <strong>Yayasan Orang Tua Asuh</strong>
+                        </td>
+                      </tr>
+
+                    </table>
+                  </td>
+                </tr>
+              </table>
+            </body>
+        </html>`, sponsorName, childName, term, reportURL)
+}
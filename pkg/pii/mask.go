@@ -0,0 +1,23 @@
+package pii
+
+import "strings"
+
+// Mask keeps the first head and last tail characters and replaces the rest with '*'. Values too
+// short to keep anything hidden are masked completely.
+func Mask(value string, head, tail int) string {
+	runes := []rune(value)
+	if len(runes) <= head+tail {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:head]) + strings.Repeat("*", len(runes)-head-tail) + string(runes[len(runes)-tail:])
+}
+
+// MaskNIK keeps the region code and the last digits, e.g. 3201********0001.
+func MaskNIK(nik String) string {
+	return Mask(string(nik), 4, 4)
+}
+
+// MaskPhone keeps the operator prefix and the last digits, e.g. 0812*****890.
+func MaskPhone(phone String) string {
+	return Mask(string(phone), 4, 3)
+}
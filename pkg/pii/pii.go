@@ -0,0 +1,280 @@
+package pii
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// prefix marks an encrypted value. Stored values look like
+// "pii:v<key version>:<wrapped data key>:<nonce+ciphertext>", both parts base64 encoded.
+const prefix = "pii:v"
+
+// keyring holds the key-encryption keys (KEKs) by version and the separate key used for blind
+// indexes. The blind index key never rotates with the KEKs, otherwise every index would change.
+type keyring struct {
+	keks     map[int][]byte
+	active   int
+	indexKey []byte
+}
+
+var ring *keyring
+
+var errNotInitialized = errors.New("pii: keyring not initialized")
+
+// Init loads the keyring from the environment:
+//
+//	PII_KEYS                "1:<base64 32-byte key>,2:<base64 32-byte key>"
+//	PII_ACTIVE_KEY_VERSION  version used for new values, defaults to the highest version
+//	PII_BLIND_INDEX_KEY     base64 32-byte key for exact-match lookups
+func Init() error {
+	keks := make(map[int][]byte)
+	active := 0
+	for _, entry := range strings.Split(os.Getenv("PII_KEYS"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		versionPart, keyPart, ok := strings.Cut(entry, ":")
+		if !ok {
+			return fmt.Errorf("pii: invalid PII_KEYS entry %q", entry)
+		}
+		version, err := strconv.Atoi(versionPart)
+		if err != nil || version <= 0 {
+			return fmt.Errorf("pii: invalid key version %q", versionPart)
+		}
+		key, err := decodeKey(keyPart)
+		if err != nil {
+			return fmt.Errorf("pii: key version %d: %w", version, err)
+		}
+		keks[version] = key
+		if version > active {
+			active = version
+		}
+	}
+	if len(keks) == 0 {
+		return errors.New("pii: PII_KEYS is not set")
+	}
+
+	if v := os.Getenv("PII_ACTIVE_KEY_VERSION"); v != "" {
+		version, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("pii: invalid PII_ACTIVE_KEY_VERSION %q", v)
+		}
+		if _, ok := keks[version]; !ok {
+			return fmt.Errorf("pii: active key version %d is not in PII_KEYS", version)
+		}
+		active = version
+	}
+
+	indexKey, err := decodeKey(os.Getenv("PII_BLIND_INDEX_KEY"))
+	if err != nil {
+		return fmt.Errorf("pii: PII_BLIND_INDEX_KEY: %w", err)
+	}
+
+	ring = &keyring{keks: keks, active: active, indexKey: indexKey}
+	return nil
+}
+
+func decodeKey(value string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return nil, errors.New("key is not valid base64")
+	}
+	if len(key) != 32 {
+		return nil, errors.New("key must be 32 bytes")
+	}
+	return key, nil
+}
+
+// ActiveVersion returns the key version used to encrypt new values.
+func ActiveVersion() int {
+	if ring == nil {
+		return 0
+	}
+	return ring.active
+}
+
+// Encrypt seals a value with a fresh data key, which is in turn wrapped by the active KEK.
+func Encrypt(plaintext string) (string, error) {
+	if ring == nil {
+		return "", errNotInitialized
+	}
+	dek := make([]byte, 32)
+	if _, err := rand.Read(dek); err != nil {
+		return "", err
+	}
+	wrapped, err := seal(ring.keks[ring.active], dek)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(dek, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%d:%s:%s", prefix, ring.active,
+		base64.StdEncoding.EncodeToString(wrapped),
+		base64.StdEncoding.EncodeToString(sealed)), nil
+}
+
+// Decrypt opens a stored value. Values without the encryption prefix are rows written before
+// encryption was introduced and are returned as they are.
+func Decrypt(stored string) (string, error) {
+	version, ok := KeyVersion(stored)
+	if !ok {
+		return stored, nil
+	}
+	if ring == nil {
+		return "", errNotInitialized
+	}
+	kek, found := ring.keks[version]
+	if !found {
+		return "", fmt.Errorf("pii: unknown key version %d", version)
+	}
+
+	parts := strings.SplitN(stored, ":", 4)
+	if len(parts) != 4 {
+		return "", errors.New("pii: malformed value")
+	}
+	wrapped, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", errors.New("pii: malformed data key")
+	}
+	sealed, err := base64.StdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return "", errors.New("pii: malformed ciphertext")
+	}
+
+	dek, err := open(kek, wrapped)
+	if err != nil {
+		return "", fmt.Errorf("pii: unwrap data key: %w", err)
+	}
+	plaintext, err := open(dek, sealed)
+	if err != nil {
+		return "", fmt.Errorf("pii: decrypt value: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// KeyVersion reports the KEK version a stored value was encrypted with. ok is false for
+// plaintext values.
+func KeyVersion(stored string) (version int, ok bool) {
+	if !strings.HasPrefix(stored, prefix) {
+		return 0, false
+	}
+	versionPart, _, found := strings.Cut(strings.TrimPrefix(stored, prefix), ":")
+	if !found {
+		return 0, false
+	}
+	version, err := strconv.Atoi(versionPart)
+	if err != nil {
+		return 0, false
+	}
+	return version, true
+}
+
+// NeedsRotation reports whether a stored value is plaintext or encrypted with a KEK other than
+// the active one.
+func NeedsRotation(stored string) bool {
+	if stored == "" {
+		return false
+	}
+	version, ok := KeyVersion(stored)
+	return !ok || version != ActiveVersion()
+}
+
+// BlindIndex returns a keyed hash of the normalized value, so exact matches (e.g. a duplicate
+// NIK) can be found without decrypting every row. Empty values have an empty index.
+func BlindIndex(value string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, value)
+	if normalized == "" || ring == nil {
+		return ""
+	}
+	mac := hmac.New(sha256.New, ring.indexKey)
+	mac.Write([]byte(normalized))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func seal(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(key, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// String is a personal identifier stored encrypted. It behaves as a plain string in Go code and
+// JSON; encryption happens when GORM writes the column and decryption when it is scanned.
+type String string
+
+func (s String) Value() (driver.Value, error) {
+	if s == "" {
+		return "", nil
+	}
+	return Encrypt(string(s))
+}
+
+func (s *String) Scan(value interface{}) error {
+	var stored string
+	switch v := value.(type) {
+	case nil:
+		*s = ""
+		return nil
+	case string:
+		stored = v
+	case []byte:
+		stored = string(v)
+	default:
+		return fmt.Errorf("pii: cannot scan %T", value)
+	}
+	plaintext, err := Decrypt(stored)
+	if err != nil {
+		return err
+	}
+	*s = String(plaintext)
+	return nil
+}
+
+func (String) GormDataType() string {
+	return "text"
+}
@@ -0,0 +1,126 @@
+package realtime
+
+import (
+	"context"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// subscriberBuffer is how many undelivered messages a slow subscriber may queue
+// before newer messages are dropped for it.
+const subscriberBuffer = 16
+
+// Broker fans out messages published on a topic to every live subscriber of
+// that topic. Delivery is best effort: slow subscribers miss messages instead of
+// blocking publishers.
+type Broker interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+	// Subscribe returns a channel of messages for topic and a function that must be
+	// called to release the subscription. The channel is closed on release.
+	Subscribe(ctx context.Context, topic string) (<-chan []byte, func(), error)
+}
+
+// NewBroker uses Redis pub/sub when a client is available so every API instance
+// sees the same stream, and falls back to an in-process broker otherwise.
+func NewBroker(redisClient *redis.Client) Broker {
+	if redisClient != nil {
+		return &redisBroker{client: redisClient}
+	}
+	return &memoryBroker{subscribers: make(map[string]map[chan []byte]struct{})}
+}
+
+type memoryBroker struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan []byte]struct{}
+}
+
+func (b *memoryBroker) Publish(ctx context.Context, topic string, payload []byte) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers[topic] {
+		select {
+		case ch <- payload:
+		default:
+		}
+	}
+	return nil
+}
+
+func (b *memoryBroker) Subscribe(ctx context.Context, topic string) (<-chan []byte, func(), error) {
+	ch := make(chan []byte, subscriberBuffer)
+
+	b.mu.Lock()
+	if b.subscribers[topic] == nil {
+		b.subscribers[topic] = make(map[chan []byte]struct{})
+	}
+	b.subscribers[topic][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers[topic], ch)
+			if len(b.subscribers[topic]) == 0 {
+				delete(b.subscribers, topic)
+			}
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, release, nil
+}
+
+type redisBroker struct {
+	client *redis.Client
+}
+
+func (b *redisBroker) Publish(ctx context.Context, topic string, payload []byte) error {
+	return b.client.Publish(ctx, topic, payload).Err()
+}
+
+func (b *redisBroker) Subscribe(ctx context.Context, topic string) (<-chan []byte, func(), error) {
+	pubsub := b.client.Subscribe(ctx, topic)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, nil, err
+	}
+
+	ch := make(chan []byte, subscriberBuffer)
+	done := make(chan struct{})
+	go func() {
+		defer close(ch)
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-done:
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				select {
+				case ch <- []byte(msg.Payload):
+				default:
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			close(done)
+			if err := pubsub.Close(); err != nil {
+				logrus.WithFields(logrus.Fields{
+					"component": "realtime.redis_broker",
+					"topic":     topic,
+				}).WithError(err).Warn("failed to close subscription")
+			}
+		})
+	}
+	return ch, release, nil
+}
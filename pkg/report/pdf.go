@@ -0,0 +1,323 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 portrait in PDF points.
+const (
+	pageWidth   = 595.28
+	pageHeight  = 841.89
+	pageMargin  = 40.0
+	contentWide = pageWidth - 2*pageMargin
+
+	fontRegular = "F1"
+	fontBold    = "F2"
+)
+
+// PDF is a minimal single-column document writer built on the standard Type1 Helvetica fonts,
+// enough for printable forms and tabular reports without an external dependency.
+type PDF struct {
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+	y     float64
+}
+
+func NewPDF() *PDF {
+	p := &PDF{}
+	p.addPage()
+	return p
+}
+
+func (p *PDF) addPage() {
+	p.page = &bytes.Buffer{}
+	p.pages = append(p.pages, p.page)
+	p.y = pageHeight - pageMargin
+}
+
+// ensure starts a new page when fewer than height points are left.
+func (p *PDF) ensure(height float64) {
+	if p.y-height < pageMargin {
+		p.addPage()
+	}
+}
+
+func (p *PDF) text(x, y float64, font string, size float64, s string) {
+	fmt.Fprintf(p.page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escape(s))
+}
+
+func (p *PDF) line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(p.page, "%.2f %.2f m %.2f %.2f l S\n", x1, y1, x2, y2)
+}
+
+// Title writes a centered document title.
+func (p *PDF) Title(s string) {
+	const size = 16
+	p.ensure(size * 2)
+	p.y -= size
+	x := (pageWidth - textWidth(s, size)) / 2
+	p.text(x, p.y, fontBold, size, s)
+	p.y -= size
+}
+
+// Heading writes a bold section heading with a rule below it.
+func (p *PDF) Heading(s string) {
+	const size = 12
+	p.ensure(size * 3)
+	p.y -= size + 6
+	p.text(pageMargin, p.y, fontBold, size, s)
+	p.y -= 4
+	p.line(pageMargin, p.y, pageWidth-pageMargin, p.y)
+	p.y -= 6
+}
+
+// Paragraph writes wrapped body text.
+func (p *PDF) Paragraph(s string) {
+	const size = 10
+	for _, l := range wrap(s, size, contentWide) {
+		p.ensure(size + 4)
+		p.y -= size + 4
+		p.text(pageMargin, p.y, fontRegular, size, l)
+	}
+}
+
+// Field writes a label and its value; long values wrap under the value column.
+func (p *PDF) Field(label, value string) {
+	const (
+		size       = 10
+		labelWidth = 150.0
+	)
+	if strings.TrimSpace(value) == "" {
+		value = "-"
+	}
+	lines := wrap(value, size, contentWide-labelWidth)
+	for i, l := range lines {
+		p.ensure(size + 5)
+		p.y -= size + 5
+		if i == 0 {
+			p.text(pageMargin, p.y, fontBold, size, label)
+		}
+		p.text(pageMargin+labelWidth, p.y, fontRegular, size, l)
+	}
+}
+
+// Spacer adds vertical whitespace.
+func (p *PDF) Spacer(height float64) {
+	p.ensure(height)
+	p.y -= height
+}
+
+// Table writes a bordered table. Column widths are weighted by the header text and cells that
+// do not fit are truncated. The header repeats on every page the table spans.
+func (p *PDF) Table(headers []string, rows [][]string) {
+	const (
+		size    = 9
+		rowH    = 16.0
+		padding = 3.0
+	)
+	if len(headers) == 0 {
+		return
+	}
+	widths := columnWidths(headers, rows, size)
+
+	drawRow := func(cells []string, font string) {
+		x := pageMargin
+		top := p.y
+		p.y -= rowH
+		for i, w := range widths {
+			var cell string
+			if i < len(cells) {
+				cell = truncate(cells[i], size, w-2*padding)
+			}
+			p.text(x+padding, p.y+5, font, size, cell)
+			p.line(x, top, x, p.y)
+			x += w
+		}
+		p.line(x, top, x, p.y)
+		p.line(pageMargin, p.y, x, p.y)
+	}
+	drawHeader := func() {
+		p.line(pageMargin, p.y, pageMargin+sum(widths), p.y)
+		drawRow(headers, fontBold)
+	}
+
+	p.ensure(rowH * 2)
+	drawHeader()
+	for _, row := range rows {
+		if p.y-rowH < pageMargin {
+			p.addPage()
+			drawHeader()
+		}
+		drawRow(row, fontRegular)
+	}
+}
+
+// Signatures draws side-by-side signature boxes with a name line under each label.
+func (p *PDF) Signatures(labels ...string) {
+	const (
+		size   = 10
+		height = 90.0
+	)
+	if len(labels) == 0 {
+		return
+	}
+	p.ensure(height)
+	width := contentWide / float64(len(labels))
+	top := p.y - size - 6
+	for i, label := range labels {
+		x := pageMargin + float64(i)*width
+		center := x + width/2
+		p.text(center-textWidth(label, size)/2, top, fontRegular, size, label)
+		p.line(x+15, top-60, x+width-15, top-60)
+		p.text(center-textWidth("( nama & tanda tangan )", 8)/2, top-72, fontRegular, 8, "( nama & tanda tangan )")
+	}
+	p.y -= height
+}
+
+// Bytes renders the document, numbering pages in the footer.
+func (p *PDF) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	// Objects 1-4 are fixed; each page then takes a page object and a content stream.
+	kids := make([]string, len(p.pages))
+	for i := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range p.pages {
+		footer := fmt.Sprintf("Halaman %d dari %d", i+1, len(p.pages))
+		content := page.String() + fmt.Sprintf("BT /%s 8 Tf %.2f %.2f Td (%s) Tj ET\n",
+			fontRegular, pageWidth-pageMargin-textWidth(footer, 8), pageMargin/2, escape(footer))
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, fontRegular, fontBold, 6+i*2))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// escape encodes s for a PDF literal string in WinAnsi; runes outside Latin-1 become '?'.
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n' || r == '\r' || r == '\t':
+			b.WriteByte(' ')
+		case r < 0x20:
+		case r < 0x80:
+			b.WriteRune(r)
+		case r <= 0xFF:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// textWidth estimates the rendered width of s in Helvetica. Averages are close enough for
+// layout since every cell is truncated with some padding.
+func textWidth(s string, size float64) float64 {
+	var units float64
+	for _, r := range s {
+		switch {
+		case r == ' ' || strings.ContainsRune("iljtfI.,:;'|!()[]", r):
+			units += 0.28
+		case r >= 'A' && r <= 'Z', strings.ContainsRune("mwMW@%", r):
+			units += 0.72
+		default:
+			units += 0.56
+		}
+	}
+	return units * size
+}
+
+func wrap(s string, size, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+		current := words[0]
+		for _, w := range words[1:] {
+			if textWidth(current+" "+w, size) > width {
+				lines = append(lines, truncate(current, size, width))
+				current = w
+				continue
+			}
+			current += " " + w
+		}
+		lines = append(lines, truncate(current, size, width))
+	}
+	return lines
+}
+
+func truncate(s string, size, width float64) string {
+	if textWidth(s, size) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && textWidth(string(runes)+"...", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+func columnWidths(headers []string, rows [][]string, size float64) []float64 {
+	natural := make([]float64, len(headers))
+	for i, h := range headers {
+		natural[i] = textWidth(h, size)
+	}
+	for _, row := range rows {
+		for i := range headers {
+			if i < len(row) {
+				if w := textWidth(row[i], size); w > natural[i] {
+					natural[i] = w
+				}
+			}
+		}
+	}
+	total := sum(natural)
+	widths := make([]float64, len(headers))
+	for i := range natural {
+		widths[i] = contentWide / float64(len(headers))
+		if total > 0 {
+			// Blend an even split with the natural widths so narrow columns keep some room.
+			widths[i] = (widths[i] + contentWide*natural[i]/total) / 2
+		}
+	}
+	return widths
+}
+
+func sum(values []float64) float64 {
+	var total float64
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
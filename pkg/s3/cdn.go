@@ -27,10 +27,11 @@ func InitCDN() {
 	}
 }
 
-// GetCDNURL prepends the CDNBaseURL to the path if it is a relative path.
+// GetCDNURL prepends the CDNBaseURL to the path if it is a relative path. Private document refs
+// are returned unchanged so they never resolve to a public URL.
 func GetCDNURL(path string) string {
-	if path == "" {
-		return ""
+	if path == "" || IsPrivateRef(path) {
+		return path
 	}
 	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
 		return path
@@ -13,6 +13,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
@@ -23,8 +24,19 @@ type Client interface {
 	UploadFile(ctx context.Context, file *multipart.FileHeader, folder string) (string, error)
 	UploadFileOriginal(ctx context.Context, file *multipart.FileHeader, folder string) (string, error)
 	UploadFileFromBytes(ctx context.Context, fileContent []byte, originalFilename string, contentType string, folder string) (string, error)
+	UploadImage(ctx context.Context, file *multipart.FileHeader, folder string) (string, ImageVariants, error)
 	GetFileLink(ctx context.Context, objectName string) (string, error)
 	DeleteFile(ctx context.Context, objectName string) error
+	DeleteImage(ctx context.Context, objectName string, variants ImageVariants) error
+	CopyFile(ctx context.Context, value string, folder string) (string, error)
+
+	StartMultipartUpload(ctx context.Context, folder string, ext string, contentType string) (string, string, error)
+	PresignUploadPart(ctx context.Context, objectName string, uploadID string, partNumber int, expiry time.Duration) (string, error)
+	ListUploadedParts(ctx context.Context, objectName string, uploadID string) ([]UploadedPart, error)
+	CompleteMultipartUpload(ctx context.Context, objectName string, uploadID string, parts []UploadedPart) error
+	AbortMultipartUpload(ctx context.Context, objectName string, uploadID string) error
+	ReadFileHead(ctx context.Context, objectName string, n int64) ([]byte, error)
+	StatFileSize(ctx context.Context, objectName string) (int64, error)
 }
 
 type client struct {
@@ -129,14 +141,13 @@ func (c *client) uploadBytes(ctx context.Context, fileContent []byte, originalFi
 		contentType = "application/octet-stream"
 	}
 
-	// Convert any decodable image format to JPEG at 80% quality.
-	isImageUpload := strings.HasPrefix(contentType, "image/") ||
-		ext == ".jpg" || ext == ".jpeg" || ext == ".png" || ext == ".gif" || ext == ".webp"
-	if compress && isImageUpload {
+	// Convert any decodable image format to an upright JPEG at 80% quality, dropping its metadata.
+	if compress && isImageUpload(contentType, ext) {
 		img, _, err := image.Decode(bytes.NewReader(fileContent))
 		if err == nil {
+			img = flatten(applyOrientation(img, exifOrientation(fileContent)))
 			buf := new(bytes.Buffer)
-			if err := jpeg.Encode(buf, img, &jpeg.Options{Quality: 80}); err == nil {
+			if err := jpeg.Encode(buf, img, &jpeg.Options{Quality: imageQuality}); err == nil {
 				fileContent = buf.Bytes()
 				ext = ".jpg"
 				contentType = "image/jpeg"
@@ -157,6 +168,60 @@ func (c *client) uploadBytes(ctx context.Context, fileContent []byte, originalFi
 	return filename, nil
 }
 
+func isImageUpload(contentType, ext string) bool {
+	return strings.HasPrefix(contentType, "image/") ||
+		ext == ".jpg" || ext == ".jpeg" || ext == ".png" || ext == ".gif" || ext == ".webp"
+}
+
+// UploadImage stores an image as responsive renditions and returns the primary object name (the
+// full-width JPEG) with every variant. Files that cannot be decoded as a raster image, such as
+// SVG, are stored as uploaded with no variants.
+func (c *client) UploadImage(ctx context.Context, file *multipart.FileHeader, folder string) (string, ImageVariants, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", nil, err
+	}
+	defer src.Close()
+
+	fileContent, err := io.ReadAll(src)
+	if err != nil {
+		return "", nil, err
+	}
+
+	contentType := file.Header.Get("Content-Type")
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	if !isImageUpload(contentType, ext) {
+		path, err := c.uploadBytes(ctx, fileContent, file.Filename, contentType, folder, false)
+		return path, nil, err
+	}
+
+	rendered, err := renderImage(fileContent, fmt.Sprintf("%s/%s", folder, uuid.New().String()))
+	if err != nil {
+		path, err := c.uploadBytes(ctx, fileContent, file.Filename, contentType, folder, false)
+		return path, nil, err
+	}
+
+	variants := make(ImageVariants, 0, len(rendered))
+	for _, r := range rendered {
+		if _, err := c.minioClient.PutObject(ctx, c.bucketName, r.variant.Path, bytes.NewReader(r.content), int64(len(r.content)), minio.PutObjectOptions{
+			ContentType: r.contentType,
+		}); err != nil {
+			_ = c.DeleteImage(ctx, "", variants)
+			return "", nil, err
+		}
+		variants = append(variants, r.variant)
+	}
+
+	// The primary object is the full rendition in the first (JPEG) format.
+	primary := ""
+	for _, v := range variants {
+		if v.Name == fullPreset && v.Format == imageEncoders[0].Format {
+			primary = v.Path
+		}
+	}
+	return primary, variants, nil
+}
+
 func (c *client) GetFileLink(ctx context.Context, objectName string) (string, error) {
 	return GetCDNURL(objectName), nil
 }
@@ -165,3 +230,42 @@ func (c *client) DeleteFile(ctx context.Context, objectName string) error {
 	err := c.minioClient.RemoveObject(ctx, c.bucketName, objectName, minio.RemoveObjectOptions{})
 	return err
 }
+
+// DeleteImage removes an image and all of its variants. The primary object is usually one of the
+// variants as well; removing a missing object is not an error.
+func (c *client) DeleteImage(ctx context.Context, objectName string, variants ImageVariants) error {
+	paths := variants.Paths()
+	if objectName != "" {
+		paths = append(paths, objectNameFromValue(objectName))
+	}
+
+	var firstErr error
+	seen := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		if path == "" || seen[path] {
+			continue
+		}
+		seen[path] = true
+		if err := c.DeleteFile(ctx, path); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// CopyFile duplicates a stored file under folder with a new name and returns the new object
+// name, so the copy can be deleted independently of the original.
+func (c *client) CopyFile(ctx context.Context, value string, folder string) (string, error) {
+	objectName := objectNameFromValue(value)
+	if objectName == "" {
+		return "", fmt.Errorf("cannot resolve object name from %q", value)
+	}
+	dest := fmt.Sprintf("%s/%s%s", folder, uuid.New().String(), strings.ToLower(filepath.Ext(objectName)))
+	if _, err := c.minioClient.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: c.bucketName, Object: dest},
+		minio.CopySrcOptions{Bucket: c.bucketName, Object: objectName},
+	); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
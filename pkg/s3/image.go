@@ -0,0 +1,229 @@
+package s3_pkg
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"strings"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+const imageQuality = 80
+
+// imagePreset is one rendition produced for every uploaded image. Presets never upscale: a preset
+// wider than the source is skipped, except full which is kept at the source width.
+type imagePreset struct {
+	Name  string
+	Width int
+}
+
+var imagePresets = []imagePreset{
+	{Name: "thumbnail", Width: 320},
+	{Name: "card", Width: 768},
+	{Name: "full", Width: 1600},
+}
+
+const fullPreset = "full"
+
+// ImageEncoder writes img in one output format.
+type ImageEncoder struct {
+	Format      string
+	Extension   string
+	ContentType string
+	Encode      func(w io.Writer, img image.Image, quality int) error
+}
+
+// imageEncoders lists the formats every preset is written in. JPEG is always produced and is the
+// format of the primary object; WebP renditions are added once an encoder is registered, because
+// golang.org/x/image only ships a WebP decoder.
+var imageEncoders = []ImageEncoder{
+	{
+		Format:      "jpeg",
+		Extension:   ".jpg",
+		ContentType: "image/jpeg",
+		Encode: func(w io.Writer, img image.Image, quality int) error {
+			return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+		},
+	},
+}
+
+// RegisterImageEncoder adds an output format such as WebP to the image pipeline. It must be called
+// during start-up, before any upload is handled.
+func RegisterImageEncoder(encoder ImageEncoder) {
+	imageEncoders = append(imageEncoders, encoder)
+}
+
+// ImageVariant is one stored rendition of an uploaded image.
+type ImageVariant struct {
+	Name   string `json:"name"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Format string `json:"format"`
+	Path   string `json:"path"`
+}
+
+// ImageVariants is stored as a JSON column next to the primary image path.
+type ImageVariants []ImageVariant
+
+func (v ImageVariants) Value() (driver.Value, error) {
+	if len(v) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (v *ImageVariants) Scan(value interface{}) error {
+	var raw []byte
+	switch val := value.(type) {
+	case nil:
+		*v = nil
+		return nil
+	case string:
+		raw = []byte(val)
+	case []byte:
+		raw = val
+	default:
+		return fmt.Errorf("s3: cannot scan %T into image variants", value)
+	}
+	return json.Unmarshal(raw, v)
+}
+
+func (ImageVariants) GormDataType() string {
+	return "jsonb"
+}
+
+// Paths returns the object names of every variant, for cleanup.
+func (v ImageVariants) Paths() []string {
+	paths := make([]string, 0, len(v))
+	for _, variant := range v {
+		paths = append(paths, variant.Path)
+	}
+	return paths
+}
+
+type renderedImage struct {
+	variant     ImageVariant
+	content     []byte
+	contentType string
+}
+
+// renderImage decodes content, fixes its EXIF orientation and renders every preset in every
+// registered format. Re-encoding drops all metadata (EXIF, GPS, ICC). The primary object is the
+// full JPEG rendition; names are derived from base so variants sit next to it.
+func renderImage(content []byte, base string) ([]renderedImage, error) {
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	img = flatten(applyOrientation(img, exifOrientation(content)))
+	srcW := img.Bounds().Dx()
+
+	var rendered []renderedImage
+	for _, preset := range imagePresets {
+		width := preset.Width
+		if width >= srcW {
+			if preset.Name != fullPreset {
+				continue
+			}
+			width = srcW
+		}
+		scaled := resize(img, width)
+
+		for i, encoder := range imageEncoders {
+			buf := new(bytes.Buffer)
+			if err := encoder.Encode(buf, scaled, imageQuality); err != nil {
+				return nil, err
+			}
+			path := fmt.Sprintf("%s-%s%s", base, preset.Name, encoder.Extension)
+			if preset.Name == fullPreset && i == 0 {
+				path = base + encoder.Extension
+			}
+			rendered = append(rendered, renderedImage{
+				variant: ImageVariant{
+					Name:   preset.Name,
+					Width:  scaled.Bounds().Dx(),
+					Height: scaled.Bounds().Dy(),
+					Format: encoder.Format,
+					Path:   path,
+				},
+				content:     buf.Bytes(),
+				contentType: encoder.ContentType,
+			})
+		}
+	}
+	return rendered, nil
+}
+
+// flatten draws img on a white background so transparent PNG and WebP areas do not turn black
+// when encoded to a format without alpha.
+func flatten(img image.Image) image.Image {
+	b := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	xdraw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, xdraw.Src)
+	xdraw.Draw(dst, dst.Bounds(), img, b.Min, xdraw.Over)
+	return dst
+}
+
+func resize(img image.Image, width int) image.Image {
+	b := img.Bounds()
+	if width >= b.Dx() {
+		return img
+	}
+	height := b.Dy() * width / b.Dx()
+	if height < 1 {
+		height = 1
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, b, xdraw.Src, nil)
+	return dst
+}
+
+// ImageSet carries srcset-ready URLs of an image for <img srcset> and <picture> sources.
+type ImageSet struct {
+	Srcset     string                 `json:"srcset"`
+	WebpSrcset string                 `json:"webpSrcset,omitempty"`
+	Variants   []ImageVariantResponse `json:"variants"`
+}
+
+type ImageVariantResponse struct {
+	Name   string `json:"name"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Format string `json:"format"`
+	URL    string `json:"url"`
+}
+
+// NewImageSet resolves stored variants into CDN URLs. It returns nil for images uploaded before
+// the pipeline existed so responses simply omit the field.
+func NewImageSet(variants ImageVariants) *ImageSet {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	set := &ImageSet{Variants: make([]ImageVariantResponse, 0, len(variants))}
+	srcsets := map[string][]string{}
+	for _, v := range variants {
+		url := GetCDNURL(v.Path)
+		set.Variants = append(set.Variants, ImageVariantResponse{
+			Name:   v.Name,
+			Width:  v.Width,
+			Height: v.Height,
+			Format: v.Format,
+			URL:    url,
+		})
+		srcsets[v.Format] = append(srcsets[v.Format], fmt.Sprintf("%s %dw", url, v.Width))
+	}
+	set.Srcset = strings.Join(srcsets["jpeg"], ", ")
+	set.WebpSrcset = strings.Join(srcsets["webp"], ", ")
+	return set
+}
@@ -0,0 +1,117 @@
+package s3_pkg
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/minio/minio-go/v7"
+)
+
+// UploadedPart is a part of a multipart upload already stored by the storage server.
+type UploadedPart struct {
+	PartNumber int
+	ETag       string
+	Size       int64
+}
+
+// StartMultipartUpload opens a multipart upload that the uploader fills directly with presigned
+// part URLs, so large files never pass through the API server.
+func (c *client) StartMultipartUpload(ctx context.Context, folder string, ext string, contentType string) (string, string, error) {
+	objectName := fmt.Sprintf("%s/%s%s", folder, uuid.New().String(), ext)
+	core := minio.Core{Client: c.minioClient}
+	uploadID, err := core.NewMultipartUpload(ctx, c.bucketName, objectName, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return objectName, uploadID, nil
+}
+
+// PresignUploadPart returns a URL the uploader PUTs one part to.
+func (c *client) PresignUploadPart(ctx context.Context, objectName string, uploadID string, partNumber int, expiry time.Duration) (string, error) {
+	params := url.Values{}
+	params.Set("partNumber", strconv.Itoa(partNumber))
+	params.Set("uploadId", uploadID)
+	u, err := c.minioClient.Presign(ctx, "PUT", c.bucketName, objectName, expiry, params)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// ListUploadedParts returns every part stored so far, in part number order.
+func (c *client) ListUploadedParts(ctx context.Context, objectName string, uploadID string) ([]UploadedPart, error) {
+	core := minio.Core{Client: c.minioClient}
+	var parts []UploadedPart
+	marker := 0
+	for {
+		result, err := core.ListObjectParts(ctx, c.bucketName, objectName, uploadID, marker, 1000)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range result.ObjectParts {
+			parts = append(parts, UploadedPart{PartNumber: p.PartNumber, ETag: p.ETag, Size: p.Size})
+		}
+		if !result.IsTruncated {
+			return parts, nil
+		}
+		marker = result.NextPartNumberMarker
+	}
+}
+
+// CompleteMultipartUpload assembles the parts into the final object.
+func (c *client) CompleteMultipartUpload(ctx context.Context, objectName string, uploadID string, parts []UploadedPart) error {
+	core := minio.Core{Client: c.minioClient}
+	completeParts := make([]minio.CompletePart, 0, len(parts))
+	for _, p := range parts {
+		completeParts = append(completeParts, minio.CompletePart{PartNumber: p.PartNumber, ETag: p.ETag})
+	}
+	_, err := core.CompleteMultipartUpload(ctx, c.bucketName, objectName, uploadID, completeParts, minio.PutObjectOptions{})
+	return err
+}
+
+// AbortMultipartUpload discards an unfinished upload and the parts stored for it.
+func (c *client) AbortMultipartUpload(ctx context.Context, objectName string, uploadID string) error {
+	core := minio.Core{Client: c.minioClient}
+	return core.AbortMultipartUpload(ctx, c.bucketName, objectName, uploadID)
+}
+
+// ReadFileHead returns the first n bytes of an object, for content sniffing.
+func (c *client) ReadFileHead(ctx context.Context, objectName string, n int64) ([]byte, error) {
+	opts := minio.GetObjectOptions{}
+	if err := opts.SetRange(0, n-1); err != nil {
+		return nil, err
+	}
+	obj, err := c.minioClient.GetObject(ctx, c.bucketName, objectName, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+	return io.ReadAll(io.LimitReader(obj, n))
+}
+
+// StatFileSize returns the size of a stored object.
+func (c *client) StatFileSize(ctx context.Context, objectName string) (int64, error) {
+	info, err := c.minioClient.StatObject(ctx, c.bucketName, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		return 0, err
+	}
+	return info.Size, nil
+}
+
+// IsNoSuchKey reports whether err means the object does not exist.
+func IsNoSuchKey(err error) bool {
+	return minio.ToErrorResponse(err).Code == "NoSuchKey"
+}
+
+// IsNoSuchUpload reports whether err means the multipart upload no longer exists, i.e. it was
+// already completed or aborted.
+func IsNoSuchUpload(err error) bool {
+	return minio.ToErrorResponse(err).Code == "NoSuchUpload"
+}
@@ -0,0 +1,201 @@
+package s3_pkg
+
+import (
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/minio/minio-go/v7"
+)
+
+// PrivateRefPrefix marks stored values that point into the private document bucket. Such values
+// are never resolved through the CDN; they are only served as presigned URLs.
+const PrivateRefPrefix = "private://"
+
+const defaultPresignExpiry = 5 * time.Minute
+
+// IsPrivateRef reports whether a stored file value lives in the private document bucket.
+func IsPrivateRef(value string) bool {
+	return strings.HasPrefix(value, PrivateRefPrefix)
+}
+
+// DocumentLink is a download link handed out after the caller passed an access check.
+type DocumentLink struct {
+	URL       string `json:"url"`
+	ExpiresAt string `json:"expiresAt,omitempty"`
+}
+
+// SignDocument resolves a stored document value into a link. Values not yet migrated to the
+// private bucket still resolve to their public URL.
+func SignDocument(ctx context.Context, storage PrivateClient, value string) (DocumentLink, error) {
+	if !IsPrivateRef(value) {
+		return DocumentLink{URL: GetCDNURL(value)}, nil
+	}
+	u, expiresAt, err := storage.PresignedURL(ctx, value)
+	if err != nil {
+		return DocumentLink{}, err
+	}
+	return DocumentLink{URL: u, ExpiresAt: expiresAt.Format(time.RFC3339)}, nil
+}
+
+// PrivateClient stores sensitive documents such as ID cards, family cards and SKTM letters in a
+// bucket without a public-read policy.
+type PrivateClient interface {
+	UploadFile(ctx context.Context, file *multipart.FileHeader, folder string) (string, error)
+	PresignedURL(ctx context.Context, ref string) (string, time.Time, error)
+	DeleteFile(ctx context.Context, ref string) error
+	MigrateFromPublic(ctx context.Context, value string) (string, error)
+	MoveToPublic(ctx context.Context, ref string) (string, error)
+	CopyFile(ctx context.Context, value string, folder string) (string, error)
+}
+
+type privateClient struct {
+	client
+	publicBucket string
+	expiry       time.Duration
+}
+
+func NewPrivateClient(minioClient *minio.Client) PrivateClient {
+	publicBucket := os.Getenv("S3_BUCKET_NAME")
+	if publicBucket == "" {
+		publicBucket = "default-bucket"
+	}
+	bucketName := os.Getenv("S3_PRIVATE_BUCKET_NAME")
+	if bucketName == "" {
+		bucketName = publicBucket + "-private"
+	}
+
+	expiry := defaultPresignExpiry
+	if v, err := strconv.Atoi(os.Getenv("S3_PRESIGN_EXPIRY_MINUTES")); err == nil && v > 0 {
+		expiry = time.Duration(v) * time.Minute
+	}
+
+	endpoint := os.Getenv("S3_ENDPOINT")
+	if !strings.Contains(endpoint, "r2.cloudflarestorage.com") {
+		ctx := context.Background()
+		if err := minioClient.MakeBucket(ctx, bucketName, minio.MakeBucketOptions{}); err != nil {
+			exists, errBucketExists := minioClient.BucketExists(ctx, bucketName)
+			if errBucketExists != nil || !exists {
+				fmt.Printf("Failed to create or verify private bucket %s: %v\n", bucketName, err)
+			}
+		}
+		// An empty policy removes any public grant left on the bucket.
+		if err := minioClient.SetBucketPolicy(ctx, bucketName, ""); err != nil {
+			fmt.Printf("Failed to clear private bucket policy: %v\n", err)
+		}
+	}
+
+	return &privateClient{
+		client: client{
+			minioClient: minioClient,
+			bucketName:  bucketName,
+		},
+		publicBucket: publicBucket,
+		expiry:       expiry,
+	}
+}
+
+// UploadFile stores the document unmodified, so scans keep their original quality and PDFs stay
+// PDFs, and returns a private ref.
+func (c *privateClient) UploadFile(ctx context.Context, file *multipart.FileHeader, folder string) (string, error) {
+	objectName, err := c.upload(ctx, file, folder, false)
+	if err != nil {
+		return "", err
+	}
+	return PrivateRefPrefix + objectName, nil
+}
+
+// PresignedURL returns a short-lived download link for a private ref.
+func (c *privateClient) PresignedURL(ctx context.Context, ref string) (string, time.Time, error) {
+	if !IsPrivateRef(ref) {
+		return "", time.Time{}, fmt.Errorf("not a private document: %s", ref)
+	}
+	expiresAt := time.Now().Add(c.expiry)
+	u, err := c.minioClient.PresignedGetObject(ctx, c.bucketName, strings.TrimPrefix(ref, PrivateRefPrefix), c.expiry, url.Values{})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return u.String(), expiresAt, nil
+}
+
+// DeleteFile removes a private document. Values not yet migrated are removed from the public
+// bucket instead.
+func (c *privateClient) DeleteFile(ctx context.Context, ref string) error {
+	if !IsPrivateRef(ref) {
+		return c.minioClient.RemoveObject(ctx, c.publicBucket, ExtractObjectNameFromURL(ref), minio.RemoveObjectOptions{})
+	}
+	return c.client.DeleteFile(ctx, strings.TrimPrefix(ref, PrivateRefPrefix))
+}
+
+// MigrateFromPublic moves a document stored in the public bucket into the private bucket and
+// returns its private ref. It is idempotent: refs pass through unchanged, and an object that was
+// already copied (e.g. shared by a candidate and the foster child created from it) is reused.
+func (c *privateClient) MigrateFromPublic(ctx context.Context, value string) (string, error) {
+	if value == "" || IsPrivateRef(value) {
+		return value, nil
+	}
+	objectName := objectNameFromValue(value)
+	if objectName == "" {
+		return "", fmt.Errorf("cannot resolve object name from %q", value)
+	}
+
+	if _, err := c.minioClient.StatObject(ctx, c.bucketName, objectName, minio.StatObjectOptions{}); err != nil {
+		if _, err := c.minioClient.CopyObject(ctx,
+			minio.CopyDestOptions{Bucket: c.bucketName, Object: objectName},
+			minio.CopySrcOptions{Bucket: c.publicBucket, Object: objectName},
+		); err != nil {
+			return "", err
+		}
+	}
+
+	if err := c.minioClient.RemoveObject(ctx, c.publicBucket, objectName, minio.RemoveObjectOptions{}); err != nil {
+		return "", err
+	}
+	return PrivateRefPrefix + objectName, nil
+}
+
+// MoveToPublic is the reverse of MigrateFromPublic, for files that stop being sensitive such as a
+// certificate made public. It returns the object name in the public bucket.
+func (c *privateClient) MoveToPublic(ctx context.Context, ref string) (string, error) {
+	if !IsPrivateRef(ref) {
+		return ref, nil
+	}
+	objectName := strings.TrimPrefix(ref, PrivateRefPrefix)
+	if _, err := c.minioClient.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: c.publicBucket, Object: objectName},
+		minio.CopySrcOptions{Bucket: c.bucketName, Object: objectName},
+	); err != nil {
+		return "", err
+	}
+	if err := c.minioClient.RemoveObject(ctx, c.bucketName, objectName, minio.RemoveObjectOptions{}); err != nil {
+		return "", err
+	}
+	return objectName, nil
+}
+
+// CopyFile duplicates a document under folder and returns the private ref of the copy. A
+// document still in the public bucket is copied into the private bucket.
+func (c *privateClient) CopyFile(ctx context.Context, value string, folder string) (string, error) {
+	srcBucket, objectName := c.bucketName, strings.TrimPrefix(value, PrivateRefPrefix)
+	if !IsPrivateRef(value) {
+		srcBucket, objectName = c.publicBucket, objectNameFromValue(value)
+	}
+	if objectName == "" {
+		return "", fmt.Errorf("cannot resolve object name from %q", value)
+	}
+	dest := fmt.Sprintf("%s/%s%s", folder, uuid.New().String(), strings.ToLower(filepath.Ext(objectName)))
+	if _, err := c.minioClient.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: c.bucketName, Object: dest},
+		minio.CopySrcOptions{Bucket: srcBucket, Object: objectName},
+	); err != nil {
+		return "", err
+	}
+	return PrivateRefPrefix + dest, nil
+}
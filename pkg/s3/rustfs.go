@@ -22,3 +22,12 @@ func ExtractObjectNameFromURL(fileURL string) string {
 	}
 	return ""
 }
+
+// objectNameFromValue returns the object name of a stored public file value, which is either an
+// object name, a CDN URL or a direct storage URL.
+func objectNameFromValue(value string) string {
+	if CDNBaseURL != "" && strings.HasPrefix(value, CDNBaseURL) {
+		return strings.TrimPrefix(strings.TrimPrefix(value, CDNBaseURL), "/")
+	}
+	return ExtractObjectNameFromURL(value)
+}
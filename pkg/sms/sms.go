@@ -0,0 +1,43 @@
+package sms
+
+import (
+	"context"
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Channel is the delivery channel for a short text message.
+type Channel string
+
+const (
+	ChannelSMS      Channel = "sms"
+	ChannelWhatsApp Channel = "whatsapp"
+)
+
+// Sender delivers short text messages such as OTP codes to a phone number.
+type Sender interface {
+	Send(ctx context.Context, channel Channel, to, message string) error
+}
+
+// NewSender returns the sender selected by SMS_PROVIDER. Only the log sender is
+// available for now; real gateways plug in here behind the same interface.
+func NewSender() Sender {
+	switch os.Getenv("SMS_PROVIDER") {
+	default:
+		return &logSender{}
+	}
+}
+
+// logSender writes messages to the application log instead of delivering them.
+// It is meant for local development only.
+type logSender struct{}
+
+func (l *logSender) Send(ctx context.Context, channel Channel, to, message string) error {
+	logrus.WithFields(logrus.Fields{
+		"component": "sms.log_sender",
+		"channel":   channel,
+		"to":        to,
+	}).Info(message)
+	return nil
+}
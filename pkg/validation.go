@@ -26,3 +26,9 @@ func IsValidPhoneNumber(phone string) bool {
 	re := regexp.MustCompile(`^[0-9]{9,15}$`)
 	return re.MatchString(phone)
 }
+
+// IsValidFamilyCardNumber checks the 16-digit number printed on a kartu keluarga.
+func IsValidFamilyCardNumber(number string) bool {
+	re := regexp.MustCompile(`^[0-9]{16}$`)
+	return re.MatchString(number)
+}